@@ -0,0 +1,63 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/ux"
+	"github.com/richardwilkes/toolbox/cmdline"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+const exportCmdName = "export"
+
+type exportCmd struct{}
+
+func (c *exportCmd) Name() string {
+	return exportCmdName
+}
+
+func (c *exportCmd) Usage() string {
+	return i18n.Text("Exports character sheets without opening any windows. If a directory is specified, it will be traversed recursively and all character sheets found will be exported")
+}
+
+func (c *exportCmd) Run(cl *cmdline.CmdLine, args []string) error {
+	format := ux.PDFExportFormat
+	cl.NewGeneralOption(&format).SetName("format").SetSingle('f').SetArg("format").
+		SetUsage(fmt.Sprintf(i18n.Text("The format to export to. One of: %s"), strings.Join(ux.SheetExportFormats, ", ")))
+	var textTmplPath string
+	cl.NewGeneralOption(&textTmplPath).SetName("text").SetSingle('x').SetArg("file").
+		SetUsage(i18n.Text("Export using the specified template file rather than --format"))
+	var outputDir string
+	cl.NewGeneralOption(&outputDir).SetName("output").SetSingle('o').SetArg("dir").
+		SetUsage(i18n.Text("The directory to write the exports into. If not specified, each export is written alongside its character sheet"))
+	fileList := cl.Parse(args)
+	gurps.CLI.Begin(exportCmdName)
+	switch {
+	case len(fileList) == 0:
+		return errNoFiles()
+	case textTmplPath != "":
+		list, err := gurps.SheetPaths(fileList...)
+		if err != nil {
+			return err
+		}
+		return gurps.ExportSheetsTo(textTmplPath, outputDir, list)
+	case !slices.Contains(ux.SheetExportFormats, format):
+		return errs.New(fmt.Sprintf(i18n.Text("Unknown export format: %s"), format))
+	default:
+		ux.ExportSheets(fileList, format, outputDir) // Never returns
+		return nil
+	}
+}
@@ -26,7 +26,6 @@ import (
 	"github.com/richardwilkes/toolbox/fatal"
 	"github.com/richardwilkes/toolbox/txt"
 	"github.com/richardwilkes/unison"
-	"github.com/richardwilkes/unison/enums/thememode"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 )
@@ -62,10 +61,9 @@ type cssInfo struct {
 }
 
 type color struct {
-	Name     string
-	Provider unison.ColorProvider
-	Light    unison.Color
-	Dark     unison.Color
+	Name  string
+	Light unison.Color
+	Dark  unison.Color
 }
 
 func main() {
@@ -74,7 +72,6 @@ func main() {
 		processEnumTemplate("enum.go.tmpl", one)
 	}
 
-	unison.SetThemeMode(thememode.Light)
 	processCSSTemplate("app.css.tmpl", &myCSSInfo)
 }
 
@@ -119,13 +116,11 @@ func processEnumTemplate(tmplName string, info *enumInfo) {
 }
 
 func processCSSTemplate(tmplName string, info *cssInfo) {
-	unison.SetThemeMode(thememode.Light)
-	for _, c := range info.Colors {
-		c.Light = c.Provider.GetColor()
-	}
-	unison.SetThemeMode(thememode.Dark)
-	for _, c := range info.Colors {
-		c.Dark = c.Provider.GetColor()
+	info.Colors = make([]*color, len(colors.WebColors))
+	for i, one := range colors.WebColors {
+		c := &color{Name: one.Name}
+		c.Light, c.Dark = one.LightAndDark()
+		info.Colors[i] = c
 	}
 	tmpl, err := template.New(tmplName).ParseFiles(tmplName)
 	fatal.IfErr(err)
@@ -341,6 +336,16 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/activation",
+		Name: "condition",
+		Desc: "holds the equipped state required for a modifier's features to be active",
+		Values: []*enumValue{
+			{Key: "always"},
+			{Key: "while_equipped"},
+			{Key: "while_not_equipped"},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/affects",
 		Name: "option",
@@ -363,6 +368,28 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/aoe",
+		Name: "shape",
+		Desc: "holds the shape of an attack that affects an area",
+		Values: []*enumValue{
+			{
+				Key:    "explosion",
+				String: "Explosion",
+				Alt:    "Explosion (damage ÷ 3×yards from the center)",
+			},
+			{
+				Key:    "area",
+				String: "Area",
+				Alt:    "Area (full damage within the radius)",
+			},
+			{
+				Key:    "cone",
+				String: "Cone",
+				Alt:    "Cone (full damage within the cone)",
+			},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/attribute",
 		Name: "placement",
@@ -412,6 +439,52 @@ var allEnums = []*enumInfo{
 			{Key: "markdown"},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/changereq",
+		Name: "kind",
+		Desc: "holds the kind of change a player is requesting",
+		Values: []*enumValue{
+			{
+				Key:    "improve_skill",
+				String: "Raise Skill",
+			},
+			{
+				Key:    "improve_spell",
+				String: "Raise Spell",
+			},
+			{
+				Key:    "add_trait",
+				String: "Buy Trait",
+			},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/changereq",
+		Name: "state",
+		Desc: "holds the state of a change request",
+		Values: []*enumValue{
+			{
+				Key: "pending",
+			},
+			{
+				Key: "approved",
+			},
+			{
+				Key: "rejected",
+			},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/comprehension",
+		Name: "level",
+		Desc: "holds the level of comprehension of a language",
+		Values: []*enumValue{
+			{Key: "none"},
+			{Key: "broken"},
+			{Key: "accented"},
+			{Key: "native"},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/container",
 		Name: "type",
@@ -430,6 +503,104 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/control",
+		Name: "rating",
+		Desc: "holds the Control Rating of a society",
+		Values: []*enumValue{
+			{
+				Name:   "CR0",
+				Key:    "cr0",
+				String: "CR0: Anarchy",
+			},
+			{
+				Name:   "CR1",
+				Key:    "cr1",
+				String: "CR1: Very Free",
+			},
+			{
+				Name:   "CR2",
+				Key:    "cr2",
+				String: "CR2: Free",
+			},
+			{
+				Name:   "CR3",
+				Key:    "cr3",
+				String: "CR3: Moderate",
+			},
+			{
+				Name:   "CR4",
+				Key:    "cr4",
+				String: "CR4: Controlled",
+			},
+			{
+				Name:   "CR5",
+				Key:    "cr5",
+				String: "CR5: Repressive",
+			},
+			{
+				Name:   "CR6",
+				Key:    "cr6",
+				String: "CR6: Total",
+			},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/defense",
+		Name: "bonus_rule",
+		Desc: "controls which active defenses a Defense Bonus (DB) from equipment applies to",
+		Values: []*enumValue{
+			{
+				Key:    "all_defenses",
+				String: "Dodge, Parry & Block",
+				Alt:    "*The standard rule from the Basic Set*",
+			},
+			{
+				Key:    "block_only",
+				String: "Block Only",
+				Alt:    "*A common house rule, which keeps shields from making Dodge and Parry better*",
+			},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/defense",
+		Name: "reflexes_rule",
+		Desc: "controls whether the Combat Reflexes bonus to active defenses stacks with Enhanced Defenses",
+		Values: []*enumValue{
+			{
+				Key:    "stacks",
+				String: "Stacks with Enhanced Defenses",
+				Alt:    "*The standard rule from the Basic Set*",
+			},
+			{
+				Key:    "no_stacking",
+				String: "Doesn't Stack with Enhanced Defenses",
+				Alt:    "*A common house rule; only the larger of the two bonuses applies to each defense*",
+			},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/defense",
+		Name: "cross_parry_rule",
+		Desc: "controls how a cross parry with two ready weapons is calculated",
+		Values: []*enumValue{
+			{
+				Key:    "no_cross_parry",
+				String: "None",
+				Alt:    "*The Basic Set has no cross parry*",
+			},
+			{
+				Key:    "better_plus_two",
+				String: "Better Parry +2",
+				Alt:    "*From Martial Arts; the better of the two weapons' Parry scores, at +2*",
+			},
+			{
+				Key:    "worse_plus_two",
+				String: "Worse Parry +2",
+				Alt:    "*A stricter variant; the worse of the two weapons' Parry scores, at +2*",
+			},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/dgroup",
 		Name: "group",
@@ -447,6 +618,22 @@ var allEnums = []*enumInfo{
 			{Key: "sub-editors"},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/diff",
+		Name: "kind",
+		Desc: "holds the kind of difference found between two versions of a character",
+		Values: []*enumValue{
+			{
+				Key: "added",
+			},
+			{
+				Key: "removed",
+			},
+			{
+				Key: "changed",
+			},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/difficulty",
 		Name: "level",
@@ -494,6 +681,26 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/effort",
+		Name: "option",
+		Desc: "holds a way of spending extra effort in combat",
+		Values: []*enumValue{
+			{
+				Key: "mighty_blows",
+				Alt: "Spend 1 FP to add +2 damage, or +1 per die if that is better, to a melee attack",
+			},
+			{
+				Key: "feverish_defense",
+				Alt: "Spend 1 FP to get +2 to one active defense roll",
+			},
+			{
+				Key:    "flurry_of_blows",
+				String: "Flurry of Blows",
+				Alt:    "Spend 1 FP per attack to halve the penalty for a Rapid Strike",
+			},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/emcost",
 		Name: "type",
@@ -808,6 +1015,7 @@ var allEnums = []*enumInfo{
 			{Key: "not_applicable"},
 			{Key: "count"},
 			{Key: "points"},
+			{Key: "optional"},
 		},
 	},
 	{
@@ -911,6 +1119,78 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/reputation",
+		Name: "audience",
+		Desc: "holds the size of the group that a reputation applies to",
+		Values: []*enumValue{
+			{
+				Key:    "everyone",
+				String: "Almost everyone",
+			},
+			{
+				Key:    "everyone_but_one_group",
+				String: "Almost everyone except one large group",
+			},
+			{
+				Key:    "large_group",
+				String: "A large group",
+			},
+			{
+				Key:    "small_group",
+				String: "A small group",
+			},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/reputation",
+		Name: "recognition",
+		Desc: "holds how often a reputation is recognized",
+		Values: []*enumValue{
+			{
+				Key:    "always",
+				String: "All the time",
+			},
+			{
+				Key:    "sometimes",
+				String: "Sometimes (10 or less)",
+			},
+			{
+				Key:    "occasionally",
+				String: "Occasionally (7 or less)",
+			},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/rpm",
+		Name: "effect",
+		Desc: "holds the verb of a Ritual Path Magic effect",
+		Values: []*enumValue{
+			{Key: "sense"},
+			{Key: "strengthen"},
+			{Key: "restore"},
+			{Key: "control"},
+			{Key: "destroy"},
+			{Key: "create"},
+			{Key: "transform"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/rpm",
+		Name: "path",
+		Desc: "holds the Ritual Path Magic path an effect draws upon",
+		Values: []*enumValue{
+			{Key: "body"},
+			{Key: "chance"},
+			{Key: "crossroads"},
+			{Key: "energy"},
+			{Key: "magic"},
+			{Key: "matter"},
+			{Key: "mind"},
+			{Key: "spirit"},
+			{Key: "undead"},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/selfctrl",
 		Name: "adjustment",
@@ -953,6 +1233,99 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/shipsys",
+		Name: "system",
+		Desc: "holds the kind of system installed in a spaceship hull location",
+		Values: []*enumValue{
+			{
+				Key:    "empty",
+				String: "Empty",
+			},
+			{
+				Key:    "armor",
+				String: "Armor",
+			},
+			{
+				Key:    "cabins",
+				String: "Cabins",
+			},
+			{
+				Key:    "cargo",
+				String: "Cargo Hold",
+			},
+			{
+				Key:    "control_room",
+				String: "Control Room",
+			},
+			{
+				Name:   "DefensiveECM",
+				Key:    "defensive_ecm",
+				String: "Defensive ECM",
+			},
+			{
+				Key:    "engine_room",
+				String: "Engine Room",
+			},
+			{
+				Key:    "fuel_tank",
+				String: "Fuel Tank",
+			},
+			{
+				Key:    "habitat",
+				String: "Habitat",
+			},
+			{
+				Key:    "hangar_bay",
+				String: "Hangar Bay",
+			},
+			{
+				Key:    "power_plant",
+				String: "Power Plant",
+			},
+			{
+				Key:    "weapon_battery",
+				String: "Weapon Battery",
+			},
+			{
+				Key:    "chemical_rocket",
+				String: "Chemical Rocket Engine",
+			},
+			{
+				Name:   "HEDMRocket",
+				Key:    "hedm_rocket",
+				String: "HEDM Rocket Engine",
+			},
+			{
+				Key:    "nuclear_thermal_rocket",
+				String: "Nuclear Thermal Rocket Engine",
+			},
+			{
+				Key:    "rotary_reactionless",
+				String: "Rotary Reactionless Engine",
+			},
+			{
+				Key:    "standard_reactionless",
+				String: "Standard Reactionless Engine",
+			},
+			{
+				Key:    "hot_reactionless",
+				String: "Hot Reactionless Engine",
+			},
+			{
+				Key:    "super_reactionless",
+				String: "Super Reactionless Engine",
+			},
+			{
+				Key:    "stardrive",
+				String: "Stardrive Engine",
+			},
+			{
+				Key:    "other",
+				String: "Other",
+			},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/skillsel",
 		Name: "type",
@@ -1214,6 +1587,50 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/timeuse",
+		Name: "kind",
+		Desc: "holds the kind of activity time is spent on",
+		Values: []*enumValue{
+			{
+				Key:    "job",
+				String: "Job",
+			},
+			{
+				Key:    "study",
+				String: "Study",
+			},
+			{
+				Key:    "chores",
+				String: "Chores",
+			},
+			{
+				Key:    "sleep",
+				String: "Sleep",
+			},
+			{
+				Key:    "other",
+				String: "Other",
+			},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/timeuse",
+		Name: "period",
+		Desc: "holds the period over which time is allocated",
+		Values: []*enumValue{
+			{
+				Name:   "PerDay",
+				Key:    "day",
+				String: "per day",
+			},
+			{
+				Name:   "PerWeek",
+				Key:    "week",
+				String: "per week",
+			},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/tmcost",
 		Name: "type",
@@ -1233,6 +1650,126 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "model/gurps/enums/updchan",
+		Name: "channel",
+		Desc: "holds the release channel used when checking for application updates",
+		Values: []*enumValue{
+			{Key: "stable"},
+			{Key: "beta"},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/wound",
+		Name: "type",
+		Desc: "holds the type of damage that caused a wound",
+		Values: []*enumValue{
+			{
+				Name:   "Crushing",
+				Key:    "cr",
+				String: "Crushing (cr)",
+			},
+			{
+				Name:   "Cutting",
+				Key:    "cut",
+				String: "Cutting (cut)",
+			},
+			{
+				Name:   "Impaling",
+				Key:    "imp",
+				String: "Impaling (imp)",
+			},
+			{
+				Name:   "SmallPiercing",
+				Key:    "pi-",
+				String: "Small Piercing (pi-)",
+			},
+			{
+				Name:   "Piercing",
+				Key:    "pi",
+				String: "Piercing (pi)",
+			},
+			{
+				Name:   "LargePiercing",
+				Key:    "pi+",
+				String: "Large Piercing (pi+)",
+			},
+			{
+				Name:   "HugePiercing",
+				Key:    "pi++",
+				String: "Huge Piercing (pi++)",
+			},
+			{
+				Name:   "Burning",
+				Key:    "burn",
+				String: "Burning (burn)",
+			},
+			{
+				Name:   "Corrosion",
+				Key:    "cor",
+				String: "Corrosion (cor)",
+			},
+			{
+				Name:   "Toxic",
+				Key:    "tox",
+				String: "Toxic (tox)",
+			},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/wound",
+		Name: "location",
+		Desc: "holds the part of the body a wound was inflicted on, as far as it changes the resulting injury",
+		Values: []*enumValue{
+			{
+				Key:    "torso",
+				String: "Torso",
+			},
+			{
+				Key:    "vitals",
+				String: "Vitals",
+			},
+			{
+				Key:    "skull",
+				String: "Skull or Eye",
+			},
+			{
+				Key:    "face",
+				String: "Face",
+			},
+			{
+				Key:    "neck",
+				String: "Neck",
+			},
+			{
+				Key:    "limb",
+				String: "Limb or Extremity",
+			},
+		},
+	},
+	{
+		Pkg:  "model/gurps/enums/wound",
+		Name: "tolerance",
+		Desc: "holds the variant of Injury Tolerance that changes how much injury penetrating damage causes",
+		Values: []*enumValue{
+			{
+				Key:    "none",
+				String: "None",
+			},
+			{
+				Key:    "unliving",
+				String: "Unliving",
+			},
+			{
+				Key:    "homogenous",
+				String: "Homogenous",
+			},
+			{
+				Key:    "diffuse",
+				String: "Diffuse",
+			},
+		},
+	},
 	{
 		Pkg:  "model/gurps/enums/wsel",
 		Name: "type",
@@ -1362,45 +1899,32 @@ var allEnums = []*enumInfo{
 			},
 		},
 	},
+	{
+		Pkg:  "server/websettings/tlsmode",
+		Name: "mode",
+		Desc: "holds the way the web server obtains its TLS certificate",
+		Values: []*enumValue{
+			{
+				Key:    "none",
+				String: "None (HTTP only)",
+			},
+			{
+				Key:    "files",
+				String: "Certificate Files",
+			},
+			{
+				Key:    "self_signed",
+				String: "Self-Signed Certificate",
+			},
+			{
+				Key:    "automatic",
+				String: "Automatic (ACME)",
+			},
+		},
+	},
 }
 
 var myCSSInfo = cssInfo{
 	Pkg:  "server/frontend/src",
 	Name: "app",
-	Colors: []*color{
-		{Name: "surface", Provider: unison.ThemeSurface},
-		{Name: "above-surface", Provider: unison.ThemeAboveSurface},
-		{Name: "below-surface", Provider: unison.ThemeBelowSurface},
-		{Name: "deep-below-surface", Provider: unison.ThemeDeepBelowSurface},
-		{Name: "surface-edge", Provider: unison.ThemeSurfaceEdge},
-		{Name: "on-surface", Provider: unison.ThemeOnSurface},
-		{Name: "on-above-surface", Provider: unison.ThemeOnAboveSurface},
-		{Name: "on-below-surface", Provider: unison.ThemeOnBelowSurface},
-		{Name: "on-deep-below-surface", Provider: unison.ThemeOnDeepBelowSurface},
-
-		{Name: "header", Provider: colors.Header},
-		{Name: "on-header", Provider: colors.OnHeader},
-
-		{Name: "banding", Provider: unison.ThemeBanding},
-		{Name: "on-banding", Provider: unison.ThemeOnBanding},
-
-		{Name: "focus", Provider: unison.ThemeFocus},
-		{Name: "deep-focus", Provider: unison.ThemeDeepFocus},
-		{Name: "deeper-focus", Provider: unison.ThemeDeeperFocus},
-		{Name: "deepest-focus", Provider: unison.ThemeDeepestFocus},
-		{Name: "on-focus", Provider: unison.ThemeOnFocus},
-		{Name: "on-deep-focus", Provider: unison.ThemeOnDeepFocus},
-		{Name: "on-deeper-focus", Provider: unison.ThemeOnDeeperFocus},
-		{Name: "on-deepest-focus", Provider: unison.ThemeOnDeepestFocus},
-
-		{Name: "tooltip", Provider: unison.ThemeTooltip},
-		{Name: "tooltip-edge", Provider: unison.ThemeTooltipEdge},
-		{Name: "on-tooltip", Provider: unison.ThemeOnTooltip},
-
-		{Name: "warning", Provider: unison.ThemeWarning},
-		{Name: "on-warning", Provider: unison.ThemeOnWarning},
-
-		{Name: "error", Provider: unison.ThemeError},
-		{Name: "on-error", Provider: unison.ThemeOnError},
-	},
 }
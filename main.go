@@ -14,6 +14,8 @@ import (
 	"log"
 	"log/slog"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/richardwilkes/gcs/v5/early"
 	"github.com/richardwilkes/gcs/v5/model/fxp"
@@ -23,6 +25,7 @@ import (
 	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/atexit"
 	"github.com/richardwilkes/toolbox/cmdline"
+	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/log/rotation"
 	"github.com/richardwilkes/toolbox/log/tracelog"
@@ -37,7 +40,10 @@ func main() {
 	unison.AttachConsole()
 	cl := cmdline.New(true)
 	cl.Description = ux.AppDescription()
-	cl.UsageTrailer = fmt.Sprintf(i18n.Text(`Translations dir: "%s"`), i18n.Dir)
+	cl.UsageTrailer = fmt.Sprintf(i18n.Text(`Exit codes: %d on success, %d on general failure or invalid arguments, %d when a file contains invalid data, %d when a file cannot be read or written, and %d when a file's data version is not supported.
+
+Translations dir: "%s"`), gurps.ExitSuccess, gurps.ExitFailure, gurps.ExitValidationFailed, gurps.ExitIOError,
+		gurps.ExitVersionMismatch, i18n.Dir)
 
 	settingsName := cmdline.AppCmdName + "_prefs.json"
 	gurps.SettingsPath = filepath.Join(paths.AppDataDir(), settingsName)
@@ -54,12 +60,38 @@ func main() {
 		SetUsage(i18n.Text("The file to load settings from and store them into"))
 	cl.NewGeneralOption(&textTmplPath).SetName("text").SetSingle('x').SetArg("file").
 		SetUsage(i18n.Text("Export sheets using the specified template file"))
+	var reportPath string
+	cl.NewGeneralOption(&reportPath).SetName("report").SetSingle('r').SetArg("file").
+		SetUsage(i18n.Text("Writes an aggregate report (skill coverage, wealth, best Perception and Stealth, languages known) of all character sheets specified on the command line to the file. The file must have a .html or .csv extension. If a directory is specified, it will be traversed recursively and all character sheets found will be included"))
+	var galleryDir string
+	cl.NewGeneralOption(&galleryDir).SetName("gallery").SetSingle('g').SetArg("dir").
+		SetUsage(i18n.Text("Writes a static HTML site with an index page and an individual page for each character sheet specified on the command line to the directory. If --text is also specified, that template will be used for the individual pages. If a directory is specified, it will be traversed recursively and all character sheets found will be included"))
 	var convertFiles bool
 	cl.NewGeneralOption(&convertFiles).SetName("convert").SetSingle('c').
 		SetUsage(i18n.Text("Converts all files specified on the command line to the current data format. If a directory is specified, it will be traversed recursively and all files found will be converted. After all files have been processed, GCS will exit"))
 	var syncSheetsAndTemplates bool
 	cl.NewGeneralOption(&syncSheetsAndTemplates).SetName("sync").SetSingle('S').
 		SetUsage(fmt.Sprintf(i18n.Text("Syncs all character sheet (%s) and template (%s) files specified on the command line with their library sources. If a directory is specified, it will be traversed recursively and all files found will be converted. After all files have been processed, GCS will exit"), gurps.SheetExt, gurps.TemplatesExt))
+	var touchFiles bool
+	cl.NewGeneralOption(&touchFiles).SetName("touch").SetSingle('t').
+		SetUsage(i18n.Text("Loads all files specified on the command line, migrating them to the current data format, recalculating them and rewriting them in canonical form, then reports which files were changed. If a directory is specified, it will be traversed recursively and all files found will be processed. After all files have been processed, GCS will exit"))
+	var watchFiles bool
+	cl.NewGeneralOption(&watchFiles).SetName("watch").SetSingle('W').
+		SetUsage(i18n.Text("Monitors all character sheets specified on the command line and re-exports them alongside the originals whenever they change, using the template specified by --text if present, or the format specified by --format otherwise. If a directory is specified, it will be monitored recursively. Runs until interrupted"))
+	exportFormat := ux.PDFExportFormat
+	cl.NewGeneralOption(&exportFormat).SetName("format").SetSingle('f').SetArg("format").
+		SetUsage(fmt.Sprintf(i18n.Text("The format to export sheets to when using --watch. One of: %s"), strings.Join(ux.SheetExportFormats, ", ")))
+	var printFiles bool
+	cl.NewGeneralOption(&printFiles).SetName("print").SetSingle('p').
+		SetUsage(i18n.Text("Prints all character sheets specified on the command line to the printer specified by --printer without opening any windows. If a directory is specified, it will be traversed recursively and all character sheets found will be printed. After all files have been printed, GCS will exit"))
+	var printerName string
+	cl.NewGeneralOption(&printerName).SetName("printer").SetSingle('P').SetArg("name").
+		SetUsage(i18n.Text("The name of the printer to use with --print"))
+	var remoteCmd string
+	cl.NewGeneralOption(&remoteCmd).SetName("remote").SetSingle('R').SetArg("command").
+		SetUsage(fmt.Sprintf(i18n.Text("Sends a command to the already running instance of GCS, then reports its response. The files specified on the command line are passed to the command, and --printer is passed to the print command. One of: %s"), strings.Join(ux.RemoteCommands, ", ")))
+	cl.NewGeneralOption(&gurps.CLI.JSON).SetName("json").SetSingle('j').
+		SetUsage(i18n.Text("Reports the results of command-line operations as JSON on stdout rather than as text. The document contains the command, the exit code, any error message, and the path and status of each file processed. With --watch, one JSON object is written per line as each file is exported"))
 	cl.NewGeneralOption(&fxp.DebugVariableResolver).SetName("debug-variable-resolver")
 	var backgroundOnly bool
 	cl.NewGeneralOption(&backgroundOnly).SetName("web-server-only").SetSingle('w').SetUsage(i18n.Text("Starts the web server and does not bring up the user interface. If the server has not been configured, just exits"))
@@ -68,25 +100,82 @@ func main() {
 	ux.RegisterKnownFileTypes()
 	settings := gurps.GlobalSettings() // Here to force early initialization
 
-	if convertFiles && syncSheetsAndTemplates {
-		cl.FatalMsg(i18n.Text("Cannot specify both --convert and --sync"))
+	if len(fileList) != 0 && fileList[0] == exportCmdName {
+		cl.AddCommand(&exportCmd{})
+		atexit.Exit(gurps.CLI.Finish(cl.RunCommand(fileList)))
+	}
+
+	if countTrue(convertFiles, syncSheetsAndTemplates, touchFiles, watchFiles, printFiles, remoteCmd != "",
+		reportPath != "", galleryDir != "") > 1 {
+		gurps.CLI.Begin("")
+		atexit.Exit(gurps.CLI.Finish(errs.New(i18n.Text("Only one of --convert, --sync, --touch, --watch, --print, --report, --gallery, or --remote may be specified"))))
 	}
 
+	var err error
 	switch {
-	case convertFiles:
-		if err := gurps.Convert(fileList...); err != nil {
-			cl.FatalMsg(err.Error())
+	case remoteCmd != "":
+		gurps.CLI.Begin(remoteCmd)
+		if !slices.Contains(ux.RemoteCommands, remoteCmd) {
+			err = errs.New(fmt.Sprintf(i18n.Text("Unknown remote command: %s"), remoteCmd))
+		} else {
+			var result *gurps.CLIResult
+			if result, err = ux.SendRemoteCommand(remoteCmd, fileList, printerName); err == nil {
+				atexit.Exit(gurps.CLI.Relay(result))
+			}
 		}
+	case convertFiles:
+		gurps.CLI.Begin("convert")
+		err = gurps.Convert(fileList...)
+	case touchFiles:
+		gurps.CLI.Begin("touch")
+		err = gurps.Touch(fileList...)
 	case syncSheetsAndTemplates:
-		if err := gurps.SyncSheetsAndTemplates(fileList...); err != nil {
-			cl.FatalMsg(err.Error())
+		gurps.CLI.Begin("sync")
+		err = gurps.SyncSheetsAndTemplates(fileList...)
+	case printFiles:
+		gurps.CLI.Begin("print")
+		switch {
+		case len(fileList) == 0:
+			err = errNoFiles()
+		case printerName == "":
+			err = errs.New(i18n.Text("A printer must be specified with --printer"))
+		default:
+			ux.PrintSheets(fileList, printerName) // Never returns
 		}
-	case textTmplPath != "":
+	case watchFiles:
+		gurps.CLI.BeginStreaming("watch")
+		switch {
+		case len(fileList) == 0:
+			err = errNoFiles()
+		case textTmplPath != "":
+			err = gurps.WatchSheets(fileList, func(sheetPath string) {
+				_ = gurps.ExportSheets(textTmplPath, []string{sheetPath}) //nolint:errcheck // Failures are reported by ExportSheets
+			})
+		case !slices.Contains(ux.SheetExportFormats, exportFormat):
+			err = errs.New(fmt.Sprintf(i18n.Text("Unknown export format: %s"), exportFormat))
+		default:
+			ux.WatchAndExport(fileList, exportFormat) // Never returns
+		}
+	case reportPath != "":
+		gurps.CLI.Begin("report")
+		if len(fileList) == 0 {
+			err = errNoFiles()
+		} else {
+			err = gurps.ExportPartyReport(reportPath, fileList)
+		}
+	case galleryDir != "":
+		gurps.CLI.Begin("gallery")
 		if len(fileList) == 0 {
-			cl.FatalMsg(i18n.Text("No files to process."))
+			err = errNoFiles()
+		} else {
+			err = gurps.ExportGallery(galleryDir, textTmplPath, fileList)
 		}
-		if err := gurps.ExportSheets(textTmplPath, fileList); err != nil {
-			cl.FatalMsg(err.Error())
+	case textTmplPath != "":
+		gurps.CLI.Begin("export")
+		if len(fileList) == 0 {
+			err = errNoFiles()
+		} else {
+			err = gurps.ExportSheets(textTmplPath, fileList)
 		}
 	case backgroundOnly:
 		if !settings.WebServer.Enabled {
@@ -103,5 +192,19 @@ func main() {
 			}
 		}) // Never returns
 	}
-	atexit.Exit(0)
+	atexit.Exit(gurps.CLI.Finish(err))
+}
+
+func errNoFiles() error {
+	return errs.New(i18n.Text("No files to process."))
+}
+
+func countTrue(values ...bool) int {
+	count := 0
+	for _, v := range values {
+		if v {
+			count++
+		}
+	}
+	return count
 }
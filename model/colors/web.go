@@ -0,0 +1,76 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package colors
+
+import "github.com/richardwilkes/unison"
+
+// WebColor associates the name of a color used by the web client with the theme color that provides it. The web client
+// refers to it with the CSS custom property "--color-" + Name.
+type WebColor struct {
+	Name     string
+	Provider unison.ColorProvider
+}
+
+// WebColors holds the theme colors used by the web client.
+var WebColors = []*WebColor{
+	{Name: "surface", Provider: unison.ThemeSurface},
+	{Name: "above-surface", Provider: unison.ThemeAboveSurface},
+	{Name: "below-surface", Provider: unison.ThemeBelowSurface},
+	{Name: "deep-below-surface", Provider: unison.ThemeDeepBelowSurface},
+	{Name: "surface-edge", Provider: unison.ThemeSurfaceEdge},
+	{Name: "on-surface", Provider: unison.ThemeOnSurface},
+	{Name: "on-above-surface", Provider: unison.ThemeOnAboveSurface},
+	{Name: "on-below-surface", Provider: unison.ThemeOnBelowSurface},
+	{Name: "on-deep-below-surface", Provider: unison.ThemeOnDeepBelowSurface},
+
+	{Name: "header", Provider: Header},
+	{Name: "on-header", Provider: OnHeader},
+
+	{Name: "banding", Provider: unison.ThemeBanding},
+	{Name: "on-banding", Provider: unison.ThemeOnBanding},
+
+	{Name: "focus", Provider: unison.ThemeFocus},
+	{Name: "deep-focus", Provider: unison.ThemeDeepFocus},
+	{Name: "deeper-focus", Provider: unison.ThemeDeeperFocus},
+	{Name: "deepest-focus", Provider: unison.ThemeDeepestFocus},
+	{Name: "on-focus", Provider: unison.ThemeOnFocus},
+	{Name: "on-deep-focus", Provider: unison.ThemeOnDeepFocus},
+	{Name: "on-deeper-focus", Provider: unison.ThemeOnDeeperFocus},
+	{Name: "on-deepest-focus", Provider: unison.ThemeOnDeepestFocus},
+
+	{Name: "tooltip", Provider: unison.ThemeTooltip},
+	{Name: "tooltip-edge", Provider: unison.ThemeTooltipEdge},
+	{Name: "on-tooltip", Provider: unison.ThemeOnTooltip},
+
+	{Name: "warning", Provider: unison.ThemeWarning},
+	{Name: "on-warning", Provider: unison.ThemeOnWarning},
+
+	{Name: "error", Provider: unison.ThemeError},
+	{Name: "on-error", Provider: unison.ThemeOnError},
+}
+
+// LightAndDark returns both the light and dark variants of the color, regardless of the current theme mode.
+func (c *WebColor) LightAndDark() (light, dark unison.Color) {
+	switch p := c.Provider.(type) {
+	case *unison.ThemeColor:
+		return p.Light, p.Dark
+	case *unison.DerivedThemeColor:
+		// The derived values aren't exposed directly, but deriving from the color hands them to us.
+		p.Derive(func(tc unison.ThemeColor) unison.ThemeColor {
+			light = tc.Light
+			dark = tc.Dark
+			return tc
+		})
+		return light, dark
+	default:
+		clr := c.Provider.GetColor()
+		return clr, clr
+	}
+}
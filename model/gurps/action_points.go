@@ -0,0 +1,124 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/attribute"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// WindedState is the pool threshold state for a character that has run out of Action Points.
+const WindedState = "Winded"
+
+// ActionPointsAttributeDef returns a new attribute definition for the optional Action Points pool from The Last Gasp
+// (Pyramid 3/44). Its maximum is derived from FP and it isn't bought separately.
+func ActionPointsAttributeDef() *AttributeDef {
+	return &AttributeDef{
+		AttributeDefData: AttributeDefData{
+			DefID:         ActionPointsID,
+			Type:          attribute.Pool,
+			Name:          "AP",
+			FullName:      i18n.Text("Action Points"),
+			AttributeBase: "$" + FatiguePointsID,
+			Thresholds: []*PoolThreshold{
+				{
+					PoolThresholdData: PoolThresholdData{
+						State:       WindedState,
+						Expression:  "0",
+						Explanation: i18n.Text("Actions that would cost AP cost FP instead until you rest (Pyramid 3/44)"),
+					},
+				},
+				{
+					PoolThresholdData: PoolThresholdData{
+						State:      i18n.Text("Fresh"),
+						Expression: "$" + ActionPointsID,
+					},
+				},
+			},
+		},
+	}
+}
+
+// ActionPointsEnabled returns true if the Action Points pool is present.
+func (a *AttributeDefs) ActionPointsEnabled() bool {
+	_, exists := a.Set[ActionPointsID]
+	return exists
+}
+
+// SetActionPointsEnabled adds or removes the Action Points pool. When added, it is placed immediately after FP.
+func (a *AttributeDefs) SetActionPointsEnabled(enabled bool) {
+	if enabled == a.ActionPointsEnabled() {
+		return
+	}
+	if !enabled {
+		delete(a.Set, ActionPointsID)
+		return
+	}
+	def := ActionPointsAttributeDef()
+	if fp, exists := a.Set[FatiguePointsID]; exists {
+		def.Order = fp.Order + 1
+		for _, one := range a.Set {
+			if one.Order >= def.Order {
+				one.Order++
+			}
+		}
+	} else {
+		for _, one := range a.Set {
+			def.Order = max(def.Order, one.Order+1)
+		}
+	}
+	a.Set[ActionPointsID] = def
+}
+
+// SetActionPointsEnabled adds or removes the Action Points pool for this entity.
+func (e *Entity) SetActionPointsEnabled(enabled bool) {
+	defs := e.SheetSettings.Attributes
+	defs.SetActionPointsEnabled(enabled)
+	if !enabled {
+		delete(e.Attributes.Set, ActionPointsID)
+	}
+	for attrID, def := range defs.Set {
+		if attr, exists := e.Attributes.Set[attrID]; exists {
+			attr.Order = def.Order
+		} else {
+			e.Attributes.Set[attrID] = NewAttribute(e, attrID, def.Order)
+		}
+	}
+}
+
+// Winded returns true if the entity is tracking Action Points and has none left.
+func (e *Entity) Winded() bool {
+	attr, ok := e.Attributes.Set[ActionPointsID]
+	return ok && attr.Current() <= 0
+}
+
+// RecoverActionPoints restores the Action Points regained by resting for the given number of seconds, one per second,
+// recording the change in the pool's log. Returns nil if the entity isn't tracking Action Points or has nothing to
+// recover.
+func (e *Entity) RecoverActionPoints(seconds int) *PoolRecord {
+	attr, ok := e.Attributes.Set[ActionPointsID]
+	if !ok || seconds <= 0 || attr.Damage <= 0 {
+		return nil
+	}
+	return attr.ApplyHealing(fxp.From(seconds), fmt.Sprintf(i18n.Text("Short Rest (%d seconds)"), seconds))
+}
+
+// CatchBreath restores all of the entity's Action Points, recording the change in the pool's log. Returns nil if the
+// entity isn't tracking Action Points or has nothing to recover.
+func (e *Entity) CatchBreath() *PoolRecord {
+	attr, ok := e.Attributes.Set[ActionPointsID]
+	if !ok || attr.Damage <= 0 {
+		return nil
+	}
+	return attr.ApplyHealing(attr.Damage, i18n.Text("Caught Breath"))
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestActionPoints(t *testing.T) {
+	e := gurps.NewEntity()
+	check.False(t, e.SheetSettings.Attributes.ActionPointsEnabled())
+	check.Nil(t, e.RecoverActionPoints(5), "not tracking action points")
+
+	e.SetActionPointsEnabled(true)
+	e.Recalculate()
+	defs := e.SheetSettings.Attributes
+	check.True(t, defs.ActionPointsEnabled())
+	check.Equal(t, defs.Set[gurps.FatiguePointsID].Order+1, defs.Set[gurps.ActionPointsID].Order, "placed after FP")
+	ap := e.Attributes.Set[gurps.ActionPointsID]
+	check.NotNil(t, ap)
+	check.Equal(t, e.Attributes.Maximum(gurps.FatiguePointsID), ap.Maximum(), "maximum derived from FP")
+	check.False(t, e.Winded())
+
+	ap.ApplyDamage(ap.Maximum(), "")
+	check.True(t, e.Winded())
+	check.Equal(t, gurps.WindedState, ap.CurrentThreshold().State)
+	record := e.RecoverActionPoints(3)
+	check.NotNil(t, record)
+	check.Equal(t, fxp.Three, ap.Current())
+	check.False(t, e.Winded())
+	check.NotNil(t, e.CatchBreath())
+	check.Equal(t, ap.Maximum(), ap.Current())
+	check.Nil(t, e.CatchBreath(), "nothing left to recover")
+
+	e.SetActionPointsEnabled(false)
+	check.False(t, defs.ActionPointsEnabled())
+	_, exists := e.Attributes.Set[gurps.ActionPointsID]
+	check.False(t, exists)
+}
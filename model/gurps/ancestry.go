@@ -20,6 +20,7 @@ import (
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/eval"
 	xfs "github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/toolbox/xmath/rand"
 )
 
 // DefaultAncestry holds the name of the default ancestry.
@@ -184,15 +185,28 @@ func (a *Ancestry) RandomHandedness(gender, not string) string {
 
 // RandomName returns a randomized name.
 func (a *Ancestry) RandomName(nameGeneratorRefs []*NameGeneratorRef, gender string) string {
-	if options := a.GenderedOptions(gender); options != nil && len(options.NameGenerators) != 0 {
+	if options := a.GenderedOptions(gender); options != nil && options.HasNames() {
 		return options.RandomName(nameGeneratorRefs)
 	}
-	if a.CommonOptions != nil && len(a.CommonOptions.NameGenerators) != 0 {
+	if a.CommonOptions != nil && a.CommonOptions.HasNames() {
 		return a.CommonOptions.RandomName(nameGeneratorRefs)
 	}
 	return ""
 }
 
+// RandomTraits returns the bundled traits chosen for a member of this ancestry with the given gender. Traits bundled with
+// the common options are combined with those bundled with the gendered options.
+func (a *Ancestry) RandomTraits(rnd rand.Randomizer, gender string) []*Trait {
+	var list []*Trait
+	if a.CommonOptions != nil {
+		list = append(list, a.CommonOptions.RandomTraits(rnd)...)
+	}
+	if options := a.GenderedOptions(gender); options != nil {
+		list = append(list, options.RandomTraits(rnd)...)
+	}
+	return list
+}
+
 // ActiveAncestries returns a list of Ancestry nodes that are enabled in the given Trait nodes and their descendants.
 func ActiveAncestries(list []*Trait) []*Ancestry {
 	var ancestries []*Ancestry
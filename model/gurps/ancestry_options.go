@@ -15,6 +15,7 @@ import (
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/eval"
+	"github.com/richardwilkes/toolbox/xmath/rand"
 )
 
 const (
@@ -39,6 +40,49 @@ type AncestryOptions struct {
 	SkinOptions       []*WeightedStringOption `json:"skin_options,omitempty"`
 	HandednessOptions []*WeightedStringOption `json:"handedness_options,omitempty"`
 	NameGenerators    []string                `json:"name_generators,omitempty"`
+	NameParts         []*WeightedStringOption `json:"name_parts,omitempty"`
+	Traits            []*WeightedTrait        `json:"traits,omitempty"`
+}
+
+// WeightedTrait is a trait bundled with an ancestry that has a weight and an optional group associated with it. See
+// ChooseWeightedGroups for how these are used.
+type WeightedTrait struct {
+	Weight int    `json:"weight"`
+	Group  string `json:"group,omitempty"`
+	Value  *Trait `json:"value"`
+}
+
+// HasNames returns true if these options can generate names.
+func (o *AncestryOptions) HasNames() bool {
+	return len(o.NameParts) != 0 || len(o.NameGenerators) != 0
+}
+
+// NameGeneratorsToUse returns the names of the name generators to use when generating a name, in order. If name parts
+// are present, they are chosen according to their weights and groups. Otherwise, all of the name generators are used.
+func (o *AncestryOptions) NameGeneratorsToUse(rnd rand.Randomizer) []string {
+	if len(o.NameParts) == 0 {
+		return o.NameGenerators
+	}
+	parts := ChooseWeightedGroups(rnd, o.NameParts, func(opt *WeightedStringOption) int { return opt.Weight },
+		func(opt *WeightedStringOption) string { return opt.Group })
+	list := make([]string, 0, len(parts))
+	for _, one := range parts {
+		list = append(list, one.Value)
+	}
+	return list
+}
+
+// RandomTraits returns the bundled traits chosen according to their weights and groups.
+func (o *AncestryOptions) RandomTraits(rnd rand.Randomizer) []*Trait {
+	chosen := ChooseWeightedGroups(rnd, o.Traits, func(opt *WeightedTrait) int { return opt.Weight },
+		func(opt *WeightedTrait) string { return opt.Group })
+	list := make([]*Trait, 0, len(chosen))
+	for _, one := range chosen {
+		if one.Value != nil {
+			list = append(list, one.Value)
+		}
+	}
+	return list
 }
 
 // RandomHeight returns a randomized height.
@@ -124,7 +168,7 @@ func (o *AncestryOptions) RandomName(nameGeneratorRefs []*NameGeneratorRef) stri
 		m[one.FileRef.Name] = one
 	}
 	var buffer strings.Builder
-	for _, one := range o.NameGenerators {
+	for _, one := range o.NameGeneratorsToUse(rand.NewCryptoRand()) {
 		if ref, ok := m[one]; ok {
 			if generator, err := ref.Generator(); err != nil {
 				errs.Log(err)
@@ -0,0 +1,431 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/updchan"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+// Release assets are expected to be named "gcs-<version>-<platform>[-<arch>]<ext>" for a full install and
+// "gcs-<version>-<platform>[-<arch>]-delta-<from version>.zip" for a delta holding only the files that changed since
+// the "from" version. A delta may also contain a file named by updateDeleteListName that lists, one per line, the
+// paths that should be removed from the install.
+const (
+	updateAssetPrefix    = "gcs-"
+	updateDeltaMarker    = "-delta-"
+	updateDeleteListName = ".delete"
+)
+
+// The SHA-256 checksum of each release asset is expected to be published alongside it, in an asset with the same name
+// followed by updateChecksumExt.
+const (
+	updateChecksumExt         = ".sha256"
+	maxUpdateChecksumFileSize = 4096
+)
+
+// updateOldExt is appended to the name of an installed file while it is being replaced.
+const updateOldExt = ".old"
+
+var updateAssetExtensions = []string{".zip", ".tar.gz", ".tgz", ".dmg", ".msi", ".exe"}
+
+// ReleasesForChannel returns the releases that should be offered when following the given update channel.
+func ReleasesForChannel(releases []Release, channel updchan.Channel) []Release {
+	if channel == updchan.Beta {
+		return releases
+	}
+	list := make([]Release, 0, len(releases))
+	for _, one := range releases {
+		if !one.Prerelease {
+			list = append(list, one)
+		}
+	}
+	return list
+}
+
+// UpdatePlatform returns the platform name used within release asset names for the given GOOS value.
+func UpdatePlatform(goos string) string {
+	if goos == "darwin" {
+		return "macos"
+	}
+	return goos
+}
+
+// SelectUpdateAsset returns the asset that should be downloaded to move from currentVersion to this release on the
+// given platform. A delta asset is preferred over a full one, as is an asset specific to the architecture over one
+// that isn't.
+func (r *Release) SelectUpdateAsset(currentVersion, goos, goarch string) (asset ReleaseAsset, delta, ok bool) {
+	base := updateAssetPrefix + r.Version + "-" + UpdatePlatform(goos)
+	bestScore := 0
+	for _, one := range r.Assets {
+		name := strings.ToLower(one.Name)
+		score := 0
+		if deltaSuffix := strings.ToLower(updateDeltaMarker + currentVersion + ".zip"); strings.HasSuffix(name, deltaSuffix) {
+			name = strings.TrimSuffix(name, deltaSuffix)
+			score = 2
+		} else {
+			found := false
+			for _, ext := range updateAssetExtensions {
+				if strings.HasSuffix(name, ext) && !strings.Contains(name, updateDeltaMarker) {
+					name = strings.TrimSuffix(name, ext)
+					found = true
+					break
+				}
+			}
+			if !found {
+				continue
+			}
+		}
+		switch name {
+		case strings.ToLower(base):
+			score++
+		case strings.ToLower(base + "-" + goarch):
+			score += 2
+		default:
+			continue
+		}
+		if score > bestScore {
+			bestScore = score
+			asset = one
+			delta = score > 2
+		}
+	}
+	return asset, delta, bestScore != 0
+}
+
+// CanApplyUpdateInPlace returns true if the asset can be unpacked directly over the current installation rather than
+// needing to be handed off to the platform's installer.
+func CanApplyUpdateInPlace(asset ReleaseAsset, goos string) bool {
+	return goos != "darwin" && strings.EqualFold(filepath.Ext(asset.Name), ".zip")
+}
+
+// InstallDir returns the directory the running executable was installed into.
+func InstallDir() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", errs.Wrap(err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return "", errs.Wrap(err)
+	}
+	return filepath.Dir(exe), nil
+}
+
+// IsDirWritable returns true if files can be created within the directory.
+func IsDirWritable(dir string) bool {
+	f, err := os.CreateTemp(dir, ".gcs_write_check_*")
+	if err != nil {
+		return false
+	}
+	name := f.Name()
+	xio.CloseIgnoringErrors(f)
+	if err = os.Remove(name); err != nil {
+		errs.Log(err, "path", name)
+	}
+	return true
+}
+
+// ChecksumAsset returns the asset holding the published SHA-256 checksum of the given asset. Updates are only applied
+// when such a checksum is available.
+func (r *Release) ChecksumAsset(asset ReleaseAsset) (ReleaseAsset, bool) {
+	name := asset.Name + updateChecksumExt
+	for _, one := range r.Assets {
+		if strings.EqualFold(one.Name, name) {
+			return one, true
+		}
+	}
+	return ReleaseAsset{}, false
+}
+
+// FetchReleaseChecksum downloads the checksum asset and returns the SHA-256 checksum it holds. The file is expected to
+// be in the format produced by sha256sum, i.e. the hex-encoded checksum optionally followed by the file name.
+func FetchReleaseChecksum(ctx context.Context, client *http.Client, asset ReleaseAsset) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, asset.URL, http.NoBody)
+	if err != nil {
+		return nil, errs.NewWithCause("unable to create request for "+asset.URL, err)
+	}
+	var rsp *http.Response
+	if rsp, err = client.Do(req); err != nil {
+		return nil, errs.NewWithCause("unable to connect to "+asset.URL, err)
+	}
+	defer xio.DiscardAndCloseIgnoringErrors(rsp.Body)
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return nil, errs.New("unexpected response code from " + asset.URL + " -> " + rsp.Status)
+	}
+	var data []byte
+	if data, err = io.ReadAll(io.LimitReader(rsp.Body, maxUpdateChecksumFileSize)); err != nil {
+		return nil, errs.NewWithCause("unable to download "+asset.URL, err)
+	}
+	return ParseUpdateChecksum(data)
+}
+
+// ParseUpdateChecksum extracts the SHA-256 checksum from the contents of a checksum file.
+func ParseUpdateChecksum(data []byte) ([]byte, error) {
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return nil, errs.New("checksum file is empty")
+	}
+	checksum, err := hex.DecodeString(fields[0])
+	if err != nil || len(checksum) != sha256.Size {
+		return nil, errs.New("checksum file does not contain a valid SHA-256 checksum")
+	}
+	return checksum, nil
+}
+
+// DownloadReleaseAsset downloads the asset into the directory, returning the path to the downloaded file. The download
+// is discarded if its SHA-256 checksum doesn't match the expected one.
+func DownloadReleaseAsset(ctx context.Context, client *http.Client, asset ReleaseAsset, checksum []byte, dir string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, asset.URL, http.NoBody)
+	if err != nil {
+		return "", errs.NewWithCause("unable to create request for "+asset.URL, err)
+	}
+	var rsp *http.Response
+	if rsp, err = client.Do(req); err != nil {
+		return "", errs.NewWithCause("unable to connect to "+asset.URL, err)
+	}
+	defer xio.DiscardAndCloseIgnoringErrors(rsp.Body)
+	if rsp.StatusCode < 200 || rsp.StatusCode > 299 {
+		return "", errs.New("unexpected response code from " + asset.URL + " -> " + rsp.Status)
+	}
+	if err = os.MkdirAll(dir, 0o750); err != nil {
+		return "", errs.NewWithCause("unable to create "+dir, err)
+	}
+	filePath := filepath.Join(dir, filepath.Base(asset.Name))
+	var f *os.File
+	if f, err = os.Create(filePath); err != nil {
+		return "", errs.NewWithCause("unable to create "+filePath, err)
+	}
+	hasher := sha256.New()
+	var n int64
+	n, err = io.Copy(io.MultiWriter(f, hasher), rsp.Body)
+	if closeErr := f.Close(); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	switch {
+	case err != nil:
+	case asset.Size > 0 && n != asset.Size:
+		err = errs.Newf("expected %d bytes, but received %d", asset.Size, n)
+	case !bytes.Equal(hasher.Sum(nil), checksum):
+		err = errs.New("the checksum of the download does not match the published checksum")
+	}
+	if err != nil {
+		if removeErr := os.Remove(filePath); removeErr != nil {
+			errs.Log(removeErr, "path", filePath)
+		}
+		return "", errs.NewWithCause("unable to download "+asset.URL, err)
+	}
+	return filePath, nil
+}
+
+// ApplyUpdateArchive unpacks the zip archive over the installation directory. The archive is first extracted into a
+// staging directory, then each file is swapped into place, with the original kept alongside until the end so that the
+// executable of a running process can be replaced. If any step fails, the originals are restored.
+func ApplyUpdateArchive(zipPath, installDir string) error {
+	root := filepath.Clean(installDir)
+	staging, err := os.MkdirTemp(root, ".gcs_update_*")
+	if err != nil {
+		return errs.NewWithCause("unable to create a staging directory within "+root, err)
+	}
+	defer func() {
+		if removeErr := os.RemoveAll(staging); removeErr != nil {
+			errs.Log(removeErr, "path", staging)
+		}
+	}()
+	var files, deletions []string
+	if files, deletions, err = stageUpdateArchive(zipPath, root, staging); err != nil {
+		return err
+	}
+	swaps := make([]updateSwap, 0, len(files)+len(deletions))
+	for _, name := range files {
+		var swap updateSwap
+		if swap, err = swapInUpdateFile(filepath.Join(root, name), filepath.Join(staging, name)); err != nil {
+			rollbackUpdateSwaps(swaps)
+			return err
+		}
+		swaps = append(swaps, swap)
+	}
+	for _, name := range deletions {
+		var swap updateSwap
+		if swap, err = swapInUpdateFile(filepath.Join(root, name), ""); err != nil {
+			rollbackUpdateSwaps(swaps)
+			return err
+		}
+		swaps = append(swaps, swap)
+	}
+	for _, swap := range swaps {
+		swap.discardOriginal()
+	}
+	return nil
+}
+
+// stageUpdateArchive extracts the files within the archive into the staging directory, returning the paths of the
+// extracted files and of the files that should be deleted, relative to the root.
+func stageUpdateArchive(zipPath, root, staging string) (files, deletions []string, err error) {
+	var zr *zip.ReadCloser
+	if zr, err = zip.OpenReader(zipPath); err != nil {
+		return nil, nil, errs.NewWithCause("unable to open archive "+zipPath, err)
+	}
+	defer xio.CloseIgnoringErrors(zr)
+	for _, f := range zr.File {
+		if f.FileInfo().Mode()&os.ModeType != 0 {
+			continue
+		}
+		if f.Name == updateDeleteListName {
+			if deletions, err = readUpdateDeletions(f, root); err != nil {
+				return nil, nil, err
+			}
+			continue
+		}
+		var name string
+		if name, err = updateRelativePath(root, f.Name); err != nil {
+			return nil, nil, err
+		}
+		stagedPath := filepath.Join(staging, name)
+		if err = os.MkdirAll(filepath.Dir(stagedPath), 0o755); err != nil {
+			return nil, nil, errs.NewWithCause("unable to create "+filepath.Dir(stagedPath), err)
+		}
+		if err = extractFileFromZip(f, stagedPath); err != nil {
+			return nil, nil, errs.NewWithCause("unable to extract "+f.Name, err)
+		}
+		files = append(files, name)
+	}
+	return files, deletions, nil
+}
+
+// updateRelativePath returns the cleaned, OS-specific form of the archive path, failing if it would resolve to a
+// location outside of the root.
+func updateRelativePath(root, name string) (string, error) {
+	fullPath := filepath.Join(root, filepath.FromSlash(name))
+	if !strings.HasPrefix(fullPath, root+string(filepath.Separator)) {
+		return "", errs.Newf("path outside of root is not permitted: %s", fullPath)
+	}
+	return fullPath[len(root)+1:], nil
+}
+
+func readUpdateDeletions(f *zip.File, root string) ([]string, error) {
+	r, err := f.Open()
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	defer xio.CloseIgnoringErrors(r)
+	var list []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		name := strings.TrimSpace(scanner.Text())
+		if name == "" {
+			continue
+		}
+		if name, err = updateRelativePath(root, name); err != nil {
+			return nil, err
+		}
+		list = append(list, name)
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return list, nil
+}
+
+func extractFileFromZip(f *zip.File, dst string) (err error) {
+	var r io.ReadCloser
+	if r, err = f.Open(); err != nil {
+		return errs.Wrap(err)
+	}
+	defer xio.CloseIgnoringErrors(r)
+	var file *os.File
+	if file, err = os.OpenFile(dst, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, f.FileInfo().Mode().Perm()|0o600); err != nil {
+		return errs.Wrap(err)
+	}
+	if _, err = io.Copy(file, r); err != nil {
+		err = errs.Wrap(err)
+	}
+	if closeErr := file.Close(); closeErr != nil && err == nil {
+		err = errs.Wrap(closeErr)
+	}
+	return err
+}
+
+// updateSwap records the changes made to a single file in the installation, so that they can be undone.
+type updateSwap struct {
+	target   string
+	backedUp bool
+	placed   bool
+}
+
+// swapInUpdateFile moves the target aside and, if stagedPath isn't empty, moves the staged file into its place. Any
+// partial change is undone before an error is returned.
+func swapInUpdateFile(target, stagedPath string) (updateSwap, error) {
+	swap := updateSwap{target: target}
+	old := target + updateOldExt
+	if err := os.Remove(old); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return swap, errs.NewWithCause("unable to remove "+old, err)
+	}
+	if err := os.Rename(target, old); err == nil {
+		swap.backedUp = true
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return swap, errs.NewWithCause("unable to move aside "+target, err)
+	}
+	if stagedPath != "" {
+		err := os.MkdirAll(filepath.Dir(target), 0o755)
+		if err == nil {
+			err = os.Rename(stagedPath, target)
+		}
+		if err != nil {
+			swap.restore()
+			return swap, errs.NewWithCause("unable to update "+target, err)
+		}
+		swap.placed = true
+	}
+	return swap, nil
+}
+
+func (s updateSwap) restore() {
+	if s.placed {
+		if err := os.Remove(s.target); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs.Log(err, "path", s.target)
+		}
+	}
+	if s.backedUp {
+		if err := os.Rename(s.target+updateOldExt, s.target); err != nil {
+			errs.Log(err, "path", s.target)
+		}
+	}
+}
+
+func (s updateSwap) discardOriginal() {
+	if s.backedUp {
+		// The old file may still be in use by the running process on some platforms, in which case it will be left
+		// behind until the next update.
+		if err := os.Remove(s.target + updateOldExt); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs.Log(err, "path", s.target+updateOldExt)
+		}
+	}
+}
+
+func rollbackUpdateSwaps(swaps []updateSwap) {
+	for i := len(swaps) - 1; i >= 0; i-- {
+		swaps[i].restore()
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/updchan"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestReleasesForChannel(t *testing.T) {
+	releases := []gurps.Release{
+		{Version: "5.31.0", Prerelease: true},
+		{Version: "5.30.1"},
+	}
+	check.Equal(t, 2, len(gurps.ReleasesForChannel(releases, updchan.Beta)))
+	stable := gurps.ReleasesForChannel(releases, updchan.Stable)
+	check.Equal(t, 1, len(stable))
+	check.Equal(t, "5.30.1", stable[0].Version)
+}
+
+func TestSelectUpdateAsset(t *testing.T) {
+	rel := gurps.Release{
+		Version: "5.30.1",
+		Assets: []gurps.ReleaseAsset{
+			{Name: "gcs-5.30.1-macos.dmg"},
+			{Name: "gcs-5.30.1-windows.zip"},
+			{Name: "gcs-5.30.1-linux.tgz"},
+			{Name: "gcs-5.30.1-linux-arm64.tgz"},
+			{Name: "gcs-5.30.1-windows-delta-5.30.0.zip"},
+			{Name: "gcs-5.30.1-windows-delta-5.29.0.zip"},
+		},
+	}
+	asset, delta, ok := rel.SelectUpdateAsset("5.30.0", "windows", "amd64")
+	check.True(t, ok)
+	check.True(t, delta)
+	check.Equal(t, "gcs-5.30.1-windows-delta-5.30.0.zip", asset.Name)
+	check.True(t, gurps.CanApplyUpdateInPlace(asset, "windows"))
+
+	asset, delta, ok = rel.SelectUpdateAsset("5.28.0", "windows", "amd64")
+	check.True(t, ok)
+	check.False(t, delta)
+	check.Equal(t, "gcs-5.30.1-windows.zip", asset.Name)
+
+	asset, _, ok = rel.SelectUpdateAsset("5.30.0", "linux", "arm64")
+	check.True(t, ok)
+	check.Equal(t, "gcs-5.30.1-linux-arm64.tgz", asset.Name)
+	check.False(t, gurps.CanApplyUpdateInPlace(asset, "linux"))
+
+	asset, _, ok = rel.SelectUpdateAsset("5.30.0", "darwin", "arm64")
+	check.True(t, ok)
+	check.Equal(t, "gcs-5.30.1-macos.dmg", asset.Name)
+
+	_, _, ok = rel.SelectUpdateAsset("5.30.0", "freebsd", "amd64")
+	check.False(t, ok)
+}
+
+func TestReleaseChecksum(t *testing.T) {
+	rel := gurps.Release{
+		Version: "5.30.1",
+		Assets: []gurps.ReleaseAsset{
+			{Name: "gcs-5.30.1-windows.zip"},
+			{Name: "gcs-5.30.1-windows.zip.sha256"},
+			{Name: "gcs-5.30.1-linux.tgz"},
+		},
+	}
+	asset, ok := rel.ChecksumAsset(rel.Assets[0])
+	check.True(t, ok)
+	check.Equal(t, "gcs-5.30.1-windows.zip.sha256", asset.Name)
+	_, ok = rel.ChecksumAsset(rel.Assets[2])
+	check.False(t, ok, "assets without a published checksum can't be installed")
+
+	sum := sha256.Sum256([]byte("data"))
+	checksum, err := gurps.ParseUpdateChecksum([]byte(hex.EncodeToString(sum[:]) + "  gcs-5.30.1-windows.zip\n"))
+	check.NoError(t, err)
+	check.Equal(t, sum[:], checksum)
+	_, err = gurps.ParseUpdateChecksum([]byte("abc123  gcs-5.30.1-windows.zip\n"))
+	check.Error(t, err)
+	_, err = gurps.ParseUpdateChecksum(nil)
+	check.Error(t, err)
+}
+
+func writeUpdateArchive(t *testing.T, zipPath string, files ...string) {
+	t.Helper()
+	f, err := os.Create(zipPath)
+	check.NoError(t, err)
+	zw := zip.NewWriter(f)
+	for i := 0; i < len(files); i += 2 {
+		w, createErr := zw.Create(files[i])
+		check.NoError(t, createErr)
+		_, err = w.Write([]byte(files[i+1]))
+		check.NoError(t, err)
+	}
+	check.NoError(t, zw.Close())
+	check.NoError(t, f.Close())
+}
+
+func TestApplyUpdateArchive(t *testing.T) {
+	dir := t.TempDir()
+	installDir := filepath.Join(dir, "install")
+	check.NoError(t, os.MkdirAll(installDir, 0o750))
+	check.NoError(t, os.WriteFile(filepath.Join(installDir, "gcs"), []byte("old"), 0o750))
+	check.NoError(t, os.WriteFile(filepath.Join(installDir, "stale.txt"), []byte("stale"), 0o640))
+
+	zipPath := filepath.Join(dir, "delta.zip")
+	writeUpdateArchive(t, zipPath,
+		"gcs", "new",
+		"docs/new.txt", "added",
+		".delete", "stale.txt\nmissing.txt\n",
+	)
+
+	check.NoError(t, gurps.ApplyUpdateArchive(zipPath, installDir))
+	data, err := os.ReadFile(filepath.Join(installDir, "gcs"))
+	check.NoError(t, err)
+	check.Equal(t, "new", string(data))
+	data, err = os.ReadFile(filepath.Join(installDir, "docs", "new.txt"))
+	check.NoError(t, err)
+	check.Equal(t, "added", string(data))
+	_, err = os.Stat(filepath.Join(installDir, "stale.txt"))
+	check.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(installDir, "gcs.old"))
+	check.True(t, os.IsNotExist(err))
+	entries, err := os.ReadDir(installDir)
+	check.NoError(t, err)
+	check.Equal(t, 2, len(entries), "the staging directory is removed")
+}
+
+func TestApplyUpdateArchiveRollback(t *testing.T) {
+	dir := t.TempDir()
+	installDir := filepath.Join(dir, "install")
+	check.NoError(t, os.MkdirAll(installDir, 0o750))
+	check.NoError(t, os.WriteFile(filepath.Join(installDir, "gcs"), []byte("old"), 0o750))
+	check.NoError(t, os.WriteFile(filepath.Join(installDir, "docs"), []byte("docs"), 0o640))
+
+	// The "docs" file in the install blocks the creation of the "docs" directory, so the second file can't be
+	// swapped in and the first must be put back.
+	zipPath := filepath.Join(dir, "update.zip")
+	writeUpdateArchive(t, zipPath,
+		"gcs", "new",
+		"docs/new.txt", "added",
+	)
+
+	check.Error(t, gurps.ApplyUpdateArchive(zipPath, installDir))
+	data, err := os.ReadFile(filepath.Join(installDir, "gcs"))
+	check.NoError(t, err)
+	check.Equal(t, "old", string(data))
+	_, err = os.Stat(filepath.Join(installDir, "gcs.old"))
+	check.True(t, os.IsNotExist(err))
+	entries, err := os.ReadDir(installDir)
+	check.NoError(t, err)
+	check.Equal(t, 2, len(entries), "the staging directory is removed")
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/cmdline"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+// Well-known entries within a character archive.
+const (
+	archiveManifestName = "manifest.json"
+	archiveSheetName    = "character" + SheetExt
+	archivePDFName      = "character.pdf"
+	archivePortraitBase = "portrait"
+	archiveLibraryDir   = "library"
+)
+
+// ArchiveManifest describes the contents of a character archive.
+type ArchiveManifest struct {
+	Version      int           `json:"version"`
+	Name         string        `json:"name,omitempty"`
+	PlayerName   string        `json:"player_name,omitempty"`
+	Reason       string        `json:"reason,omitempty"`
+	Note         string        `json:"note,omitempty"`
+	ArchivedOn   jio.Time      `json:"archived_on"`
+	AppVersion   string        `json:"app_version,omitempty"`
+	Portrait     string        `json:"portrait,omitempty"`
+	PDF          string        `json:"pdf,omitempty"`
+	LibraryFiles []LibraryFile `json:"library_files,omitempty"`
+}
+
+// CharacterArchive describes a character archive: a single zip file holding a sheet, its portrait, the library files
+// its contents were sourced from and a final PDF of the sheet.
+type CharacterArchive struct {
+	Path     string
+	Manifest ArchiveManifest
+}
+
+// WriteCharacterArchive writes a character archive for the entity to the file. The pdf may be empty, in which case it
+// is omitted from the archive.
+func WriteCharacterArchive(filePath string, entity *Entity, pdf []byte, reason, note string) (err error) {
+	var f *os.File
+	if f, err = os.Create(filePath); err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = errs.Wrap(closeErr)
+		}
+	}()
+	return writeCharacterArchive(f, entity, pdf, reason, note, GlobalSettings().Libraries())
+}
+
+func writeCharacterArchive(w io.Writer, entity *Entity, pdf []byte, reason, note string, libraries Libraries) error {
+	manifest := ArchiveManifest{
+		Version:    jio.CurrentDataVersion,
+		Name:       entity.Profile.Name,
+		PlayerName: entity.Profile.PlayerName,
+		Reason:     strings.TrimSpace(reason),
+		Note:       strings.TrimSpace(note),
+		ArchivedOn: jio.Now(),
+		AppVersion: cmdline.AppVersion,
+	}
+	zw := zip.NewWriter(w)
+	if err := writeArchiveEntry(zw, archiveSheetName, func(w io.Writer) error {
+		return jio.Save(context.Background(), w, entity)
+	}); err != nil {
+		return err
+	}
+	if ext := entity.Profile.PortraitExtension(); ext != "" {
+		manifest.Portrait = archivePortraitBase + ext
+		if err := writeArchiveBytes(zw, manifest.Portrait, entity.Profile.PortraitData); err != nil {
+			return err
+		}
+	}
+	if len(pdf) != 0 {
+		manifest.PDF = archivePDFName
+		if err := writeArchiveBytes(zw, manifest.PDF, pdf); err != nil {
+			return err
+		}
+	}
+	for libFile := range ReferencedLibraryFiles(entity) {
+		lib, ok := libraries[libFile.Library]
+		if !ok {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(lib.Path(), libFile.Path))
+		if err != nil {
+			errs.Log(err, "library", libFile.Library, "path", libFile.Path)
+			continue
+		}
+		if err = writeArchiveBytes(zw, archiveLibraryPath(libFile), data); err != nil {
+			return err
+		}
+		manifest.LibraryFiles = append(manifest.LibraryFiles, libFile)
+	}
+	slices.SortFunc(manifest.LibraryFiles, func(a, b LibraryFile) int {
+		if result := txt.NaturalCmp(a.Library, b.Library, true); result != 0 {
+			return result
+		}
+		return txt.NaturalCmp(a.Path, b.Path, true)
+	})
+	if err := writeArchiveEntry(zw, archiveManifestName, func(w io.Writer) error {
+		return jio.Save(context.Background(), w, &manifest)
+	}); err != nil {
+		return err
+	}
+	return errs.Wrap(zw.Close())
+}
+
+func archiveLibraryPath(libFile LibraryFile) string {
+	return path.Join(archiveLibraryDir, strings.ReplaceAll(libFile.Library, "/", "_"), filepath.ToSlash(libFile.Path))
+}
+
+func writeArchiveEntry(zw *zip.Writer, name string, writer func(w io.Writer) error) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	return writer(w)
+}
+
+func writeArchiveBytes(zw *zip.Writer, name string, data []byte) error {
+	return writeArchiveEntry(zw, name, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return errs.Wrap(err)
+	})
+}
+
+// OpenCharacterArchive reads the manifest of a character archive.
+func OpenCharacterArchive(filePath string) (*CharacterArchive, error) {
+	a := &CharacterArchive{Path: filePath}
+	if err := a.read(func(r *zip.Reader) error {
+		return jio.LoadFromFS(context.Background(), r, archiveManifestName, &a.Manifest)
+	}); err != nil {
+		return nil, errs.NewWithCause(InvalidFileData(), err)
+	}
+	if err := jio.CheckVersion(a.Manifest.Version); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (a *CharacterArchive) read(reader func(r *zip.Reader) error) error {
+	r, err := zip.OpenReader(a.Path)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() {
+		if closeErr := r.Close(); closeErr != nil {
+			errs.Log(closeErr, "path", a.Path)
+		}
+	}()
+	return reader(&r.Reader)
+}
+
+// Entity loads the archived character. Its sources are matched against the library files stored in the archive.
+func (a *CharacterArchive) Entity() (*Entity, error) {
+	var entity *Entity
+	if err := a.read(func(r *zip.Reader) error {
+		var err error
+		entity, err = NewEntityFromFile(r, archiveSheetName)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	entity.SourceMatcher().UseArchivedLibraries(a)
+	entity.Recalculate()
+	return entity, nil
+}
+
+// PDF returns the final PDF of the archived character, if one was stored.
+func (a *CharacterArchive) PDF() ([]byte, error) {
+	if a.Manifest.PDF == "" {
+		return nil, nil
+	}
+	return a.readFile(a.Manifest.PDF)
+}
+
+// LibraryFile returns the archived copy of the library file.
+func (a *CharacterArchive) LibraryFile(libFile LibraryFile) ([]byte, error) {
+	return a.readFile(archiveLibraryPath(libFile))
+}
+
+func (a *CharacterArchive) readFile(name string) ([]byte, error) {
+	var data []byte
+	err := a.read(func(r *zip.Reader) error {
+		var err error
+		data, err = fs.ReadFile(r, name)
+		return errs.Wrap(err)
+	})
+	return data, err
+}
+
+// ScanForCharacterArchives returns the character archives found within the directory, along with their manifests,
+// sorted by name. Archives that can't be read are logged and skipped.
+func ScanForCharacterArchives(dirPath string) []*CharacterArchive {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			errs.Log(err, "path", dirPath)
+		}
+		return nil
+	}
+	var list []*CharacterArchive
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.EqualFold(filepath.Ext(entry.Name()), CharacterArchiveExt) {
+			continue
+		}
+		p := filepath.Join(dirPath, entry.Name())
+		var a *CharacterArchive
+		if a, err = OpenCharacterArchive(p); err != nil {
+			errs.Log(err, "path", p)
+			continue
+		}
+		list = append(list, a)
+	}
+	slices.SortFunc(list, func(a, b *CharacterArchive) int {
+		return txt.NaturalCmp(a.Manifest.Name, b.Manifest.Name, true)
+	})
+	return list
+}
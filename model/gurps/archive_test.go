@@ -0,0 +1,51 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestCharacterArchive(t *testing.T) {
+	dir := t.TempDir()
+	e := gurps.NewEntity()
+	e.Profile.Name = "Old Tom"
+	e.Profile.PlayerName = "Sam"
+	pdf := []byte("%PDF-1.7 stand-in")
+	p := filepath.Join(dir, "Old Tom"+gurps.CharacterArchiveExt)
+	check.NoError(t, gurps.WriteCharacterArchive(p, e, pdf, " Died ", "Fell at the bridge"))
+	check.NoError(t, os.WriteFile(filepath.Join(dir, "junk"+gurps.CharacterArchiveExt), []byte("junk"), 0o640))
+
+	list := gurps.ScanForCharacterArchives(dir)
+	check.Equal(t, 1, len(list))
+	a := list[0]
+	check.Equal(t, "Old Tom", a.Manifest.Name)
+	check.Equal(t, "Sam", a.Manifest.PlayerName)
+	check.Equal(t, "Died", a.Manifest.Reason)
+	check.Equal(t, "Fell at the bridge", a.Manifest.Note)
+	check.Equal(t, "", a.Manifest.Portrait)
+
+	data, err := a.PDF()
+	check.NoError(t, err)
+	check.Equal(t, pdf, data)
+
+	var restored *gurps.Entity
+	restored, err = a.Entity()
+	check.NoError(t, err)
+	check.Equal(t, e.Profile.Name, restored.Profile.Name)
+	check.Equal(t, e.ID, restored.ID)
+
+	check.Equal(t, 0, len(gurps.ScanForCharacterArchives(filepath.Join(dir, "missing"))))
+}
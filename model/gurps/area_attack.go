@@ -0,0 +1,100 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/aoe"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wound"
+)
+
+// FragmentationSkill is the effective skill fragments attack each target in range with, before range and size
+// modifiers (B414).
+const FragmentationSkill = 15
+
+// AreaAttack holds an explosion (B414) or area effect attack (B413) that may hit several targets at once.
+type AreaAttack struct {
+	Shape  aoe.Shape
+	Damage fxp.Int
+	Type   wound.Type
+	// Radius is the radius of an area, or the maximum range of a cone, in yards. Not used for explosions.
+	Radius fxp.Int
+	// ConeWidth is the width of a cone at its maximum range, in yards.
+	ConeWidth fxp.Int
+	// FragmentDice is the number of dice of fragmentation damage, if any.
+	FragmentDice int
+}
+
+// AreaTarget holds the position and protection of a single target of an AreaAttack.
+type AreaTarget struct {
+	// Distance is the distance from the center of an explosion or area, or from the origin of a cone, in yards.
+	Distance fxp.Int
+	// Offset is the distance from the center line of a cone, in yards. Not used for other shapes.
+	Offset       fxp.Int
+	DR           int
+	SizeModifier int
+}
+
+// AreaHit holds the result of an AreaAttack against a single target.
+type AreaHit struct {
+	// Damage is the basic damage that reaches the target, before DR.
+	Damage fxp.Int
+	// Wound holds the damage that penetrated the target's DR.
+	Wound Wound
+	// FragmentSkill is the effective skill of the fragments' attack on the target. Only valid if InFragmentRange is true.
+	FragmentSkill   int
+	InFragmentRange bool
+}
+
+// FragmentRange returns the distance in yards out to which fragments can hit a target.
+func (a *AreaAttack) FragmentRange() fxp.Int {
+	return fxp.From(5 * a.FragmentDice)
+}
+
+// Inside returns true if the target is within the area affected by the attack. Explosions affect everyone, although
+// their damage drops off rapidly with distance.
+func (a *AreaAttack) Inside(target *AreaTarget) bool {
+	switch a.Shape {
+	case aoe.Area:
+		return target.Distance <= a.Radius
+	case aoe.Cone:
+		if target.Distance > a.Radius || a.Radius <= 0 {
+			return false
+		}
+		// A cone widens evenly from 1 yard at its origin to its full width at its maximum range
+		width := max(a.ConeWidth.Mul(target.Distance).Div(a.Radius), fxp.One)
+		return target.Offset.Abs().Mul(fxp.Two) <= width
+	default:
+		return true
+	}
+}
+
+// Resolve determines the effect of the attack on the target.
+func (a *AreaAttack) Resolve(target *AreaTarget) AreaHit {
+	var hit AreaHit
+	if a.Inside(target) {
+		hit.Damage = a.Damage
+		if a.Shape == aoe.Explosion && target.Distance >= fxp.One {
+			// Those outside the hex the explosion occurred in take 1/(3×yards) of the damage
+			hit.Damage = a.Damage.Div(fxp.Three.Mul(target.Distance)).Trunc()
+		}
+	}
+	hit.Wound = Wound{
+		Penetrating: max(hit.Damage-fxp.From(target.DR), 0),
+		Type:        a.Type,
+		Location:    wound.Torso,
+	}
+	if a.FragmentDice > 0 && target.Distance <= a.FragmentRange() {
+		hit.InFragmentRange = true
+		hit.FragmentSkill = FragmentationSkill - yardsToValue(fxp.Length(target.Distance.Mul(fxp.ThirtySix)), false) +
+			target.SizeModifier
+	}
+	return hit
+}
@@ -0,0 +1,49 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/aoe"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestAreaAttack(t *testing.T) {
+	a := &AreaAttack{
+		Shape:        aoe.Explosion,
+		Damage:       fxp.From(30),
+		FragmentDice: 2,
+	}
+	hit := a.Resolve(&AreaTarget{DR: 5})
+	check.Equal(t, fxp.From(30), hit.Damage, "full damage in the explosion's hex")
+	check.Equal(t, fxp.From(25), hit.Wound.Penetrating, "penetrating damage")
+	check.True(t, hit.InFragmentRange, "in fragment range")
+	check.Equal(t, FragmentationSkill, hit.FragmentSkill, "no range penalty up close")
+
+	hit = a.Resolve(&AreaTarget{Distance: fxp.Four, SizeModifier: 1})
+	check.Equal(t, fxp.Two, hit.Damage, "damage divided by 3×yards")
+	check.Equal(t, FragmentationSkill-2+1, hit.FragmentSkill, "range and size modifiers")
+
+	hit = a.Resolve(&AreaTarget{Distance: fxp.Eleven})
+	check.False(t, hit.InFragmentRange, "beyond 5 yards per die of fragments")
+
+	a.Shape = aoe.Area
+	a.Radius = fxp.Three
+	check.Equal(t, fxp.From(30), a.Resolve(&AreaTarget{Distance: fxp.Three}).Damage, "full damage inside the area")
+	check.Equal(t, fxp.Int(0), a.Resolve(&AreaTarget{Distance: fxp.Four}).Damage, "outside the area")
+
+	a.Shape = aoe.Cone
+	a.Radius = fxp.Ten
+	a.ConeWidth = fxp.Four
+	check.True(t, a.Inside(&AreaTarget{Distance: fxp.Ten, Offset: fxp.Two}), "edge of the cone at full range")
+	check.False(t, a.Inside(&AreaTarget{Distance: fxp.Five, Offset: fxp.Two}), "outside the narrower part of the cone")
+}
@@ -11,10 +11,12 @@ package gurps
 
 import (
 	"hash"
+	"slices"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/attribute"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/threshold"
+	"github.com/richardwilkes/gcs/v5/model/jio"
 	"github.com/richardwilkes/json"
 	"github.com/richardwilkes/toolbox/xmath/hashhelper"
 )
@@ -23,9 +25,11 @@ var _ Hashable = &Attribute{}
 
 // AttributeData holds the Attribute data that is written to disk.
 type AttributeData struct {
-	AttrID     string  `json:"attr_id"`
-	Adjustment fxp.Int `json:"adj"`
-	Damage     fxp.Int `json:"damage,omitempty"`
+	AttrID     string        `json:"attr_id"`
+	Adjustment fxp.Int       `json:"adj"`
+	Damage     fxp.Int       `json:"damage,omitempty"`
+	Temporary  fxp.Int       `json:"temporary,omitempty"`
+	Log        []*PoolRecord `json:"log,omitempty"`
 }
 
 // Attribute holds the current state of an AttributeDef.
@@ -90,6 +94,7 @@ func (a *Attribute) UnmarshalJSON(data []byte) error {
 func (a *Attribute) Clone(entity *Entity) *Attribute {
 	clone := *a
 	clone.Entity = entity
+	clone.Log = ClonePoolRecordList(a.Log)
 	return &clone
 }
 
@@ -148,6 +153,51 @@ func (a *Attribute) Current() fxp.Int {
 	return maximum - a.Damage
 }
 
+// ApplyDamage reduces the current value of a pool, using up any temporary points first, and records the change in the
+// pool's log.
+func (a *Attribute) ApplyDamage(amount fxp.Int, note string) *PoolRecord {
+	absorbed := amount.Min(a.Temporary).Max(0)
+	return a.recordPoolChange(amount-absorbed, -absorbed, note)
+}
+
+// ApplyHealing increases the current value of a pool, up to its maximum, and records the change in the pool's log.
+func (a *Attribute) ApplyHealing(amount fxp.Int, note string) *PoolRecord {
+	return a.recordPoolChange(-amount.Min(a.Damage).Max(0), 0, note)
+}
+
+// AddTemporary adds temporary points to a pool, which are used up before its current value when damage is applied, and
+// records the change in the pool's log.
+func (a *Attribute) AddTemporary(amount fxp.Int, note string) *PoolRecord {
+	return a.recordPoolChange(0, amount.Max(-a.Temporary), note)
+}
+
+func (a *Attribute) recordPoolChange(damage, temporary fxp.Int, note string) *PoolRecord {
+	a.Damage += damage
+	a.Temporary += temporary
+	record := &PoolRecord{
+		When:      jio.Now(),
+		Damage:    damage,
+		Temporary: temporary,
+		Note:      note,
+	}
+	a.Log = append(a.Log, record)
+	return record
+}
+
+// UndoPoolRecord reverses the change the record made and removes it from the pool's log. Later changes are left in
+// place, so the result is as if the record's change had never been made, other than for any clamping needed to keep the
+// damage and temporary points from going negative. Returns false if the record is not in the log.
+func (a *Attribute) UndoPoolRecord(record *PoolRecord) bool {
+	i := slices.Index(a.Log, record)
+	if i == -1 {
+		return false
+	}
+	a.Damage = (a.Damage - record.Damage).Max(0)
+	a.Temporary = (a.Temporary - record.Temporary).Max(0)
+	a.Log = slices.Delete(a.Log, i, i+1)
+	return true
+}
+
 // CurrentThreshold return the current PoolThreshold, if any.
 func (a *Attribute) CurrentThreshold() *PoolThreshold {
 	def := a.AttributeDef()
@@ -204,6 +254,11 @@ func (a *Attribute) Hash(h hash.Hash) {
 	hashhelper.String(h, a.AttrID)
 	hashhelper.Num64(h, a.Adjustment)
 	hashhelper.Num64(h, a.Damage)
+	hashhelper.Num64(h, a.Temporary)
+	hashhelper.Num64(h, len(a.Log))
+	for _, record := range a.Log {
+		record.Hash(h)
+	}
 	hashhelper.Num64(h, a.Bonus)
 	hashhelper.Num64(h, a.CostReduction)
 	hashhelper.Num64(h, a.Order)
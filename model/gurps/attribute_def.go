@@ -45,6 +45,7 @@ type AttributeDefData struct {
 	CostPerPoint        fxp.Int             `json:"cost_per_point,omitempty"`
 	CostAdjPercentPerSM fxp.Int             `json:"cost_adj_percent_per_sm,omitempty"`
 	Thresholds          []*PoolThreshold    `json:"thresholds,omitempty"`
+	Regeneration        []*PoolRegeneration `json:"regeneration,omitempty"`
 }
 
 // MarshalJSON implements json.Marshaler.
@@ -64,6 +65,9 @@ func (a *AttributeDef) UnmarshalJSON(data []byte) error {
 	for _, threshold := range a.Thresholds {
 		threshold.Expression = strings.ReplaceAll(threshold.Expression, "$self", "$"+a.DefID)
 	}
+	for _, regen := range a.Regeneration {
+		regen.Expression = strings.ReplaceAll(regen.Expression, "$self", "$"+a.DefID)
+	}
 	return nil
 }
 
@@ -77,8 +81,15 @@ func (a *AttributeDef) Clone() *AttributeDef {
 				clone.Thresholds[i] = one.Clone()
 			}
 		}
+		if a.Regeneration != nil {
+			clone.Regeneration = make([]*PoolRegeneration, len(a.Regeneration))
+			for i, one := range a.Regeneration {
+				clone.Regeneration[i] = one.Clone()
+			}
+		}
 	} else {
 		a.Thresholds = nil
+		a.Regeneration = nil
 	}
 	return &clone
 }
@@ -199,4 +210,8 @@ func (a *AttributeDef) Hash(h hash.Hash) {
 	for _, one := range a.Thresholds {
 		one.Hash(h)
 	}
+	hashhelper.Num64(h, len(a.Regeneration))
+	for _, one := range a.Regeneration {
+		one.Hash(h)
+	}
 }
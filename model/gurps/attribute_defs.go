@@ -170,5 +170,8 @@ func (a *AttributeDefs) ResetTargetKeyPrefixes(prefixProvider func() string) {
 		for _, threshold := range one.Thresholds {
 			threshold.KeyPrefix = prefixProvider()
 		}
+		for _, regen := range one.Regeneration {
+			regen.KeyPrefix = prefixProvider()
+		}
 	}
 }
@@ -25,6 +25,7 @@ const (
 	BlockLayoutTraitsKey               = "traits"
 	BlockLayoutSkillsKey               = "skills"
 	BlockLayoutSpellsKey               = "spells"
+	BlockLayoutLanguagesKey            = "languages"
 	BlockLayoutEquipmentKey            = "equipment"
 	BlockLayoutOtherEquipmentKey       = "other_equipment"
 	BlockLayoutNotesKey                = "notes"
@@ -38,6 +39,7 @@ var allBlockLayoutKeys = []string{
 	BlockLayoutTraitsKey,
 	BlockLayoutSkillsKey,
 	BlockLayoutSpellsKey,
+	BlockLayoutLanguagesKey,
 	BlockLayoutEquipmentKey,
 	BlockLayoutOtherEquipmentKey,
 	BlockLayoutNotesKey,
@@ -200,6 +202,7 @@ func (b *BlockLayout) Reset() {
 		BlockLayoutRangedKey,
 		BlockLayoutTraitsKey + " " + BlockLayoutSkillsKey,
 		BlockLayoutSpellsKey,
+		BlockLayoutLanguagesKey,
 		BlockLayoutEquipmentKey,
 		BlockLayoutOtherEquipmentKey,
 		BlockLayoutNotesKey,
@@ -0,0 +1,152 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+)
+
+// BulkEdit holds the changes to make to a set of rows at once.
+type BulkEdit struct {
+	// TechLevel replaces the tech level of rows that have one, if not nil.
+	TechLevel *string
+	// AddTag is added to the tags of rows that have them, if not empty.
+	AddTag string
+	// RemoveTag is removed from the tags of rows that have them, if not empty.
+	RemoveTag string
+	// ValueMultiplier multiplies the value of equipment, if not zero.
+	ValueMultiplier fxp.Int
+	// ReplacePageRefPrefix enables replacing OldPageRefPrefix with NewPageRefPrefix at the start of each page
+	// reference. An empty OldPageRefPrefix adds NewPageRefPrefix to every page reference.
+	ReplacePageRefPrefix bool
+	OldPageRefPrefix     string
+	NewPageRefPrefix     string
+}
+
+// IsEmpty returns true if the bulk edit wouldn't change anything.
+func (b *BulkEdit) IsEmpty() bool {
+	return b.TechLevel == nil && strings.TrimSpace(b.AddTag) == "" && strings.TrimSpace(b.RemoveTag) == "" &&
+		(b.ValueMultiplier == 0 || b.ValueMultiplier == fxp.One) &&
+		(!b.ReplacePageRefPrefix || b.OldPageRefPrefix == b.NewPageRefPrefix)
+}
+
+// CanBulkEdit returns true if the data is of a type that bulk edits can change.
+func CanBulkEdit(data any) bool {
+	switch data.(type) {
+	case *Trait, *TraitModifier, *Skill, *Spell, *Equipment, *EquipmentModifier, *Note:
+		return true
+	default:
+		return false
+	}
+}
+
+// Apply the changes to the data, which should be one of the node types. Changes that don't apply to the type of data
+// are ignored. Returns true if the data was modified.
+func (b *BulkEdit) Apply(data any) bool {
+	var tags *[]string
+	var pageRef *string
+	changed := false
+	switch one := data.(type) {
+	case *Trait:
+		tags = &one.Tags
+		pageRef = &one.PageRef
+	case *TraitModifier:
+		tags = &one.Tags
+		pageRef = &one.PageRef
+	case *Skill:
+		tags = &one.Tags
+		pageRef = &one.PageRef
+		changed = b.applyTechLevel(one.TechLevel)
+	case *Spell:
+		tags = &one.Tags
+		pageRef = &one.PageRef
+		changed = b.applyTechLevel(one.TechLevel)
+	case *Equipment:
+		tags = &one.Tags
+		pageRef = &one.PageRef
+		if b.TechLevel != nil && one.TechLevel != *b.TechLevel {
+			one.TechLevel = *b.TechLevel
+			changed = true
+		}
+		if b.ValueMultiplier != 0 && b.ValueMultiplier != fxp.One && one.Value != 0 {
+			one.Value = one.Value.Mul(b.ValueMultiplier)
+			changed = true
+		}
+	case *EquipmentModifier:
+		tags = &one.Tags
+		pageRef = &one.PageRef
+	case *Note:
+		pageRef = &one.PageRef
+	default:
+		return false
+	}
+	if tags != nil && b.applyTags(tags) {
+		changed = true
+	}
+	if pageRef != nil && b.applyPageRef(pageRef) {
+		changed = true
+	}
+	return changed
+}
+
+// applyTechLevel changes the tech level, which is nil for skills and spells that don't have one.
+func (b *BulkEdit) applyTechLevel(techLevel *string) bool {
+	if b.TechLevel == nil || techLevel == nil || *techLevel == *b.TechLevel {
+		return false
+	}
+	*techLevel = *b.TechLevel
+	return true
+}
+
+func (b *BulkEdit) applyTags(tags *[]string) bool {
+	changed := false
+	if tag := strings.TrimSpace(b.RemoveTag); tag != "" {
+		if i := slices.IndexFunc(*tags, func(one string) bool { return strings.EqualFold(one, tag) }); i != -1 {
+			*tags = slices.Delete(slices.Clone(*tags), i, i+1)
+			changed = true
+		}
+	}
+	if tag := strings.TrimSpace(b.AddTag); tag != "" {
+		if !slices.ContainsFunc(*tags, func(one string) bool { return strings.EqualFold(one, tag) }) {
+			*tags = append(slices.Clone(*tags), tag)
+			changed = true
+		}
+	}
+	return changed
+}
+
+// applyPageRef replaces the prefix of each of the comma-separated page references that use it.
+func (b *BulkEdit) applyPageRef(pageRef *string) bool {
+	if !b.ReplacePageRefPrefix || b.OldPageRefPrefix == b.NewPageRefPrefix || strings.TrimSpace(*pageRef) == "" {
+		return false
+	}
+	parts := strings.Split(*pageRef, ",")
+	changed := false
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		parts[i] = part
+		if part == "" {
+			continue
+		}
+		// A non-empty prefix must be followed by the page number, so that "B" doesn't also match "BX" or "Basic".
+		if rest, ok := strings.CutPrefix(part, b.OldPageRefPrefix); ok &&
+			(b.OldPageRefPrefix == "" || (rest != "" && rest[0] >= '0' && rest[0] <= '9')) {
+			parts[i] = b.NewPageRefPrefix + rest
+			changed = true
+		}
+	}
+	if changed {
+		*pageRef = strings.Join(parts, ",")
+	}
+	return changed
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestBulkEdit(t *testing.T) {
+	check.True(t, (&gurps.BulkEdit{ValueMultiplier: fxp.One}).IsEmpty())
+
+	tl := "8"
+	edit := &gurps.BulkEdit{
+		TechLevel:            &tl,
+		AddTag:               "Gear",
+		RemoveTag:            "old",
+		ValueMultiplier:      fxp.FromStringForced("1.2"),
+		ReplacePageRefPrefix: true,
+		OldPageRefPrefix:     "B",
+		NewPageRefPrefix:     "Basic",
+	}
+	check.False(t, edit.IsEmpty())
+
+	eqp := &gurps.Equipment{}
+	eqp.TechLevel = "3"
+	eqp.Tags = []string{"Old", "Weapon"}
+	eqp.Value = fxp.From(100)
+	eqp.PageRef = "B274, MA12,B275,BX10,Bio5"
+	check.True(t, edit.Apply(eqp))
+	check.Equal(t, "8", eqp.TechLevel)
+	check.Equal(t, []string{"Weapon", "Gear"}, eqp.Tags)
+	check.Equal(t, fxp.From(120), eqp.Value)
+	check.Equal(t, "Basic274,MA12,Basic275,BX10,Bio5", eqp.PageRef,
+		"only page references where the prefix is followed by the page number are changed")
+	check.True(t, edit.Apply(eqp), "applying again multiplies the value again")
+	check.Equal(t, fxp.From(144), eqp.Value)
+	check.Equal(t, "Basic274,MA12,Basic275,BX10,Bio5", eqp.PageRef, "the new prefix isn't matched by the old one")
+	check.Equal(t, []string{"Weapon", "Gear"}, eqp.Tags)
+
+	skill := &gurps.Skill{}
+	skill.Tags = []string{"gear"}
+	check.False(t, edit.Apply(skill), "skills without a tech level keep it that way")
+	check.Nil(t, skill.TechLevel)
+	skillTL := "3"
+	skill.TechLevel = &skillTL
+	check.True(t, edit.Apply(skill))
+	check.Equal(t, "8", *skill.TechLevel)
+	check.Equal(t, []string{"gear"}, skill.Tags, "tags are compared without regard to case")
+
+	note := &gurps.Note{}
+	note.PageRef = "B10"
+	check.True(t, (&gurps.BulkEdit{ReplacePageRefPrefix: true, NewPageRefPrefix: "X:"}).Apply(note))
+	check.Equal(t, "X:B10", note.PageRef)
+	check.False(t, gurps.CanBulkEdit(&gurps.Weapon{}))
+	check.False(t, edit.Apply(&gurps.Weapon{}))
+}
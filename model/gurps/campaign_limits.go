@@ -0,0 +1,74 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// DisadvantagesExceedLimit returns true if the points spent on disadvantages exceed the campaign's disadvantage limit.
+func (e *Entity) DisadvantagesExceedLimit() bool {
+	limit := e.SheetSettings.DisadvantageLimit
+	return limit > 0 && -e.PointsBreakdown().Disadvantages > limit
+}
+
+// QuirksExceedLimit returns true if the points spent on quirks exceed the campaign's quirk limit.
+func (e *Entity) QuirksExceedLimit() bool {
+	limit := e.SheetSettings.QuirkLimit
+	return limit > 0 && -e.PointsBreakdown().Quirks > limit
+}
+
+// SkillsExceedingPointLimit returns the names of the skills and spells that have more points in them than the
+// campaign's per-skill limit allows.
+func (e *Entity) SkillsExceedingPointLimit() []string {
+	limit := e.SheetSettings.SkillPointLimit
+	if limit <= 0 {
+		return nil
+	}
+	var list []string
+	Traverse(func(s *Skill) bool {
+		if s.Points > limit {
+			list = append(list, s.String())
+		}
+		return false
+	}, true, true, e.Skills...)
+	Traverse(func(s *Spell) bool {
+		if s.Points > limit {
+			list = append(list, s.String())
+		}
+		return false
+	}, true, true, e.Spells...)
+	return list
+}
+
+// CampaignLimitViolations returns a description of each campaign limit the character exceeds, including spending more
+// on equipment than its starting wealth allows when so configured, and each point budget it fails to meet.
+func (e *Entity) CampaignLimitViolations() []string {
+	var list []string
+	if e.DisadvantagesExceedLimit() {
+		list = append(list, fmt.Sprintf(i18n.Text("Disadvantages total %s points, exceeding the limit of %s"),
+			(-e.PointsBreakdown().Disadvantages).Comma(), e.SheetSettings.DisadvantageLimit.Comma()))
+	}
+	if e.QuirksExceedLimit() {
+		list = append(list, fmt.Sprintf(i18n.Text("Quirks total %s points, exceeding the limit of %s"),
+			(-e.PointsBreakdown().Quirks).Comma(), e.SheetSettings.QuirkLimit.Comma()))
+	}
+	for _, name := range e.SkillsExceedingPointLimit() {
+		list = append(list, fmt.Sprintf(i18n.Text("%s has more than the limit of %s points"), name,
+			e.SheetSettings.SkillPointLimit.Comma()))
+	}
+	if e.EquipmentExceedsWealth() {
+		list = append(list, fmt.Sprintf(i18n.Text("Equipment costs $%s, exceeding the starting wealth of $%s"),
+			e.EquipmentCost().Comma(), e.StartingWealth().Comma()))
+	}
+	return append(list, e.PointBudgetViolations()...)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/paper"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Standard poker-sized card dimensions.
+var (
+	CardWidth  = paper.Length{Length: 2.5, Units: paper.Inch}
+	CardHeight = paper.Length{Length: 3.5, Units: paper.Inch}
+)
+
+// Card holds the information printed on a reference card for a spell, technique or trait.
+type Card struct {
+	Title   string
+	Kind    string
+	Cost    string
+	Fields  []CardField
+	Notes   string
+	PageRef string
+}
+
+// CardField holds a labeled value printed on a Card.
+type CardField struct {
+	Label string
+	Value string
+}
+
+func (c *Card) addField(label, value string) {
+	if value = strings.TrimSpace(value); value != "" {
+		c.Fields = append(c.Fields, CardField{Label: label, Value: value})
+	}
+}
+
+// NewSpellCard creates a new Card for the spell.
+func NewSpellCard(s *Spell) *Card {
+	c := &Card{
+		Title:   s.String(),
+		Kind:    i18n.Text("Spell"),
+		Cost:    s.CastingCostWithReplacements(),
+		Notes:   s.Notes(),
+		PageRef: s.PageRef,
+	}
+	c.addField(i18n.Text("Level"), spellCardLevel(s))
+	c.addField(i18n.Text("Class"), s.ClassWithReplacements())
+	c.addField(i18n.Text("College"), strings.Join(s.CollegeWithReplacements(), ", "))
+	c.addField(i18n.Text("Resist"), s.ResistWithReplacements())
+	c.addField(i18n.Text("Maintain"), s.MaintenanceCostWithReplacements())
+	c.addField(i18n.Text("Time"), s.CastingTimeWithReplacements())
+	c.addField(i18n.Text("Duration"), s.DurationWithReplacements())
+	return c
+}
+
+func spellCardLevel(s *Spell) string {
+	if s.LevelData.Level <= 0 {
+		return ""
+	}
+	return s.LevelData.Level.String() + " (" + s.RelativeLevel() + ")"
+}
+
+// NewTechniqueCard creates a new Card for the technique.
+func NewTechniqueCard(s *Skill) *Card {
+	c := &Card{
+		Title:   s.String(),
+		Kind:    i18n.Text("Technique"),
+		Cost:    s.AdjustedPoints(nil).String() + " " + i18n.Text("pts"),
+		Notes:   s.Notes(),
+		PageRef: s.PageRef,
+	}
+	if s.LevelData.Level > 0 {
+		c.addField(i18n.Text("Level"), s.LevelData.Level.String()+" ("+s.RelativeLevel()+")")
+	}
+	if s.TechniqueDefault != nil {
+		c.addField(i18n.Text("Default"), s.TechniqueDefaultsText())
+	}
+	return c
+}
+
+// NewTraitCard creates a new Card for the trait.
+func NewTraitCard(t *Trait) *Card {
+	c := &Card{
+		Title:   t.String(),
+		Kind:    i18n.Text("Trait"),
+		Cost:    t.AdjustedPoints().String() + " " + i18n.Text("pts"),
+		Notes:   t.Notes(),
+		PageRef: t.PageRef,
+	}
+	c.addField(i18n.Text("Modifiers"), t.ModifierNotes())
+	return c
+}
+
+// CardsForSpells returns cards for the spells, skipping containers.
+func CardsForSpells(spells []*Spell) []*Card {
+	cards := make([]*Card, 0, len(spells))
+	for _, one := range spells {
+		if !one.Container() {
+			cards = append(cards, NewSpellCard(one))
+		}
+	}
+	return cards
+}
+
+// CardsForTechniques returns cards for the techniques, skipping containers and regular skills.
+func CardsForTechniques(skills []*Skill) []*Card {
+	cards := make([]*Card, 0, len(skills))
+	for _, one := range skills {
+		if one.IsTechnique() {
+			cards = append(cards, NewTechniqueCard(one))
+		}
+	}
+	return cards
+}
+
+// CardsForTraits returns cards for the traits, skipping containers.
+func CardsForTraits(traits []*Trait) []*Card {
+	cards := make([]*Card, 0, len(traits))
+	for _, one := range traits {
+		if !one.Container() {
+			cards = append(cards, NewTraitCard(one))
+		}
+	}
+	return cards
+}
+
+// CardsPerPage returns the number of columns and rows of cards that fit within the printable area of a page, always
+// allowing at least one card.
+func CardsPerPage(printableWidth, printableHeight float32) (columns, rows int) {
+	return max(int(printableWidth/CardWidth.Pixels()), 1), max(int(printableHeight/CardHeight.Pixels()), 1)
+}
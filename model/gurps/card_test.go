@@ -0,0 +1,54 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/paper"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestCards(t *testing.T) {
+	e := gurps.NewEntity()
+	spell := gurps.NewSpell(e, nil, false)
+	spell.Name = "Light"
+	spell.CastingCost = "1"
+	spell.Duration = "1 min."
+	spell.PageRef = "M249"
+	container := gurps.NewSpell(e, nil, true)
+	cards := gurps.CardsForSpells([]*gurps.Spell{spell, container})
+	check.Equal(t, 1, len(cards), "containers are skipped")
+	card := cards[0]
+	check.Equal(t, "Light", card.Title)
+	check.Equal(t, "1", card.Cost)
+	check.Equal(t, "M249", card.PageRef)
+	found := false
+	for _, field := range card.Fields {
+		if field.Label == "Duration" {
+			found = true
+			check.Equal(t, "1 min.", field.Value)
+		}
+		check.NotEqual(t, "", field.Value, "empty fields are omitted")
+	}
+	check.True(t, found, "duration field")
+
+	skill := gurps.NewSkill(e, nil, false)
+	technique := gurps.NewTechnique(e, nil, "Karate")
+	check.Equal(t, 1, len(gurps.CardsForTechniques([]*gurps.Skill{skill, technique})), "only techniques")
+
+	columns, rows := gurps.CardsPerPage(paper.Length{Length: 7.5, Units: paper.Inch}.Pixels(),
+		paper.Length{Length: 10.5, Units: paper.Inch}.Pixels())
+	check.Equal(t, 3, columns)
+	check.Equal(t, 3, rows)
+	columns, rows = gurps.CardsPerPage(10, 10)
+	check.Equal(t, 1, columns*rows, "always at least one card")
+}
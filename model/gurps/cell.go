@@ -49,6 +49,7 @@ type CellData struct {
 	UnsatisfiedReason string
 	TemplateInfo      string
 	InlineTag         string
+	RollResolver      RollTargetResolver
 }
 
 // ForSort returns a string that can be used to sort or search against for this data.
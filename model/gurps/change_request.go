@@ -0,0 +1,224 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/changereq"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/gcs/v5/model/kinds"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// ChangeRequest holds a change to the character proposed by a player, which must be approved by the GM before it is
+// applied to the sheet. Requests that have been resolved are retained as a log.
+type ChangeRequest struct {
+	ID        tid.TID         `json:"id"`
+	Kind      changereq.Kind  `json:"kind"`
+	State     changereq.State `json:"state"`
+	Requester string          `json:"requester,omitempty"`
+	TargetID  tid.TID         `json:"target_id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Points    fxp.Int         `json:"points"`
+	Reason    string          `json:"reason,omitempty"`
+	Comment   string          `json:"comment,omitempty"`
+	Created   jio.Time        `json:"created"`
+	Resolved  *jio.Time       `json:"resolved,omitempty"`
+}
+
+// NewChangeRequest creates a new pending ChangeRequest.
+func NewChangeRequest(requester string, kind changereq.Kind, targetID tid.TID, name string, points fxp.Int, reason string) *ChangeRequest {
+	return &ChangeRequest{
+		ID:        tid.MustNewTID(kinds.ChangeRequest),
+		Kind:      kind.EnsureValid(),
+		State:     changereq.Pending,
+		Requester: strings.TrimSpace(requester),
+		TargetID:  targetID,
+		Name:      strings.TrimSpace(name),
+		Points:    points,
+		Reason:    strings.TrimSpace(reason),
+		Created:   jio.Now(),
+	}
+}
+
+// CloneChangeRequestList creates a clone of the provided ChangeRequest list.
+func CloneChangeRequestList(list []*ChangeRequest) []*ChangeRequest {
+	clone := make([]*ChangeRequest, len(list))
+	for i, one := range list {
+		req := *one
+		if one.Resolved != nil {
+			resolved := *one.Resolved
+			req.Resolved = &resolved
+		}
+		clone[i] = &req
+	}
+	return clone
+}
+
+// Validate checks the request against the entity, returning an error describing the problem if it cannot be applied.
+func (cr *ChangeRequest) Validate(e *Entity) error {
+	switch cr.Kind {
+	case changereq.ImproveSkill:
+		if cr.Points <= 0 {
+			return errs.New(i18n.Text("the points to spend must be greater than zero"))
+		}
+		if e.changeRequestSkill(cr.TargetID) == nil {
+			return errs.New(i18n.Text("unable to locate the skill to raise"))
+		}
+	case changereq.ImproveSpell:
+		if cr.Points <= 0 {
+			return errs.New(i18n.Text("the points to spend must be greater than zero"))
+		}
+		if e.changeRequestSpell(cr.TargetID) == nil {
+			return errs.New(i18n.Text("unable to locate the spell to raise"))
+		}
+	case changereq.AddTrait:
+		if cr.Points == 0 {
+			return errs.New(i18n.Text("the points for the trait must not be zero"))
+		}
+		if cr.Name == "" {
+			return errs.New(i18n.Text("a name for the trait is required"))
+		}
+	default:
+		return errs.New(i18n.Text("unknown change request"))
+	}
+	return nil
+}
+
+// Description returns a short description of the requested change.
+func (cr *ChangeRequest) Description(e *Entity) string {
+	name := cr.Name
+	switch cr.Kind {
+	case changereq.ImproveSkill:
+		if s := e.changeRequestSkill(cr.TargetID); s != nil {
+			name = s.String()
+		}
+	case changereq.ImproveSpell:
+		if s := e.changeRequestSpell(cr.TargetID); s != nil {
+			name = s.String()
+		}
+	default:
+	}
+	return fmt.Sprintf(i18n.Text("%s: %s (%s points)"), cr.Kind, name, cr.Points.StringWithSign())
+}
+
+// Apply the change to the entity. The request's state is not altered.
+func (cr *ChangeRequest) Apply(e *Entity) error {
+	if err := cr.Validate(e); err != nil {
+		return err
+	}
+	switch cr.Kind {
+	case changereq.ImproveSkill:
+		s := e.changeRequestSkill(cr.TargetID)
+		s.SetRawPoints(s.RawPoints() + cr.Points)
+	case changereq.ImproveSpell:
+		s := e.changeRequestSpell(cr.TargetID)
+		s.SetRawPoints(s.RawPoints() + cr.Points)
+	case changereq.AddTrait:
+		t := NewTrait(e, nil, false)
+		t.Name = cr.Name
+		t.LocalNotes = cr.Reason
+		t.BasePoints = cr.Points
+		e.SetTraitList(append(e.Traits, t))
+	default:
+	}
+	e.Recalculate()
+	return nil
+}
+
+func (cr *ChangeRequest) resolve(state changereq.State, comment string) {
+	now := jio.Now()
+	cr.State = state
+	cr.Comment = strings.TrimSpace(comment)
+	cr.Resolved = &now
+}
+
+// AddChangeRequest validates the request and appends it to the entity's queue.
+func (e *Entity) AddChangeRequest(cr *ChangeRequest) error {
+	if err := cr.Validate(e); err != nil {
+		return err
+	}
+	e.ChangeRequests = append(e.ChangeRequests, cr)
+	return nil
+}
+
+// PendingChangeRequests returns the change requests that are awaiting approval.
+func (e *Entity) PendingChangeRequests() []*ChangeRequest {
+	var list []*ChangeRequest
+	for _, one := range e.ChangeRequests {
+		if one.State == changereq.Pending {
+			list = append(list, one)
+		}
+	}
+	return list
+}
+
+// ChangeRequestByID returns the change request with the given ID, or nil.
+func (e *Entity) ChangeRequestByID(id tid.TID) *ChangeRequest {
+	for _, one := range e.ChangeRequests {
+		if one.ID == id {
+			return one
+		}
+	}
+	return nil
+}
+
+// ApproveChangeRequest applies the pending change request with the given ID to the entity and marks it as approved.
+func (e *Entity) ApproveChangeRequest(id tid.TID, comment string) error {
+	cr := e.ChangeRequestByID(id)
+	if cr == nil || cr.State != changereq.Pending {
+		return errs.New(i18n.Text("no pending change request with that ID"))
+	}
+	if err := cr.Apply(e); err != nil {
+		return err
+	}
+	cr.resolve(changereq.Approved, comment)
+	return nil
+}
+
+// RejectChangeRequest marks the pending change request with the given ID as rejected, returning it to the requester
+// with the comment.
+func (e *Entity) RejectChangeRequest(id tid.TID, comment string) error {
+	cr := e.ChangeRequestByID(id)
+	if cr == nil || cr.State != changereq.Pending {
+		return errs.New(i18n.Text("no pending change request with that ID"))
+	}
+	cr.resolve(changereq.Rejected, comment)
+	return nil
+}
+
+func (e *Entity) changeRequestSkill(id tid.TID) *Skill {
+	var found *Skill
+	Traverse(func(s *Skill) bool {
+		if s.TID == id {
+			found = s
+			return true
+		}
+		return false
+	}, false, true, e.Skills...)
+	return found
+}
+
+func (e *Entity) changeRequestSpell(id tid.TID) *Spell {
+	var found *Spell
+	Traverse(func(s *Spell) bool {
+		if s.TID == id {
+			found = s
+			return true
+		}
+		return false
+	}, false, true, e.Spells...)
+	return found
+}
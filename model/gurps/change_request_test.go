@@ -0,0 +1,50 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/changereq"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestChangeRequests(t *testing.T) {
+	e := NewEntity()
+	skill := NewSkill(e, nil, false)
+	skill.Name = "Broadsword"
+	skill.Points = fxp.Two
+	e.SetSkillList([]*Skill{skill})
+	traitCount := len(e.Traits)
+
+	check.Error(t, e.AddChangeRequest(NewChangeRequest("player", changereq.ImproveSkill, skill.TID, "", 0, "")),
+		"zero points are rejected")
+	check.Error(t, e.AddChangeRequest(NewChangeRequest("player", changereq.AddTrait, "", "", fxp.One, "")),
+		"trait requires a name")
+
+	raise := NewChangeRequest("player", changereq.ImproveSkill, skill.TID, "", fxp.Two, "Practice")
+	check.NoError(t, e.AddChangeRequest(raise))
+	perk := NewChangeRequest("player", changereq.AddTrait, "", "Fearlessness", fxp.Two, "")
+	check.NoError(t, e.AddChangeRequest(perk))
+	check.Equal(t, 2, len(e.PendingChangeRequests()), "two pending")
+
+	check.NoError(t, e.ApproveChangeRequest(raise.ID, "Well earned"))
+	check.Equal(t, fxp.Four, skill.Points, "skill points raised")
+	check.Equal(t, changereq.Approved, raise.State, "approved")
+	check.Equal(t, "Well earned", raise.Comment, "comment recorded")
+	check.NotNil(t, raise.Resolved, "resolution time recorded")
+	check.Error(t, e.ApproveChangeRequest(raise.ID, ""), "cannot approve twice")
+
+	check.NoError(t, e.RejectChangeRequest(perk.ID, "Not in this campaign"))
+	check.Equal(t, changereq.Rejected, perk.State, "rejected")
+	check.Equal(t, traitCount, len(e.Traits), "rejected trait not added")
+	check.Equal(t, 0, len(e.PendingChangeRequests()), "nothing pending")
+}
@@ -0,0 +1,244 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	iofs "io/fs"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// Exit codes used by the command-line operations.
+const (
+	// ExitSuccess indicates the operation completed without error.
+	ExitSuccess = 0
+	// ExitFailure indicates a general failure, including invalid command-line arguments.
+	ExitFailure = 1
+	// ExitValidationFailed indicates that file data could not be parsed or was otherwise invalid.
+	ExitValidationFailed = 2
+	// ExitIOError indicates that a file could not be read or written.
+	ExitIOError = 3
+	// ExitVersionMismatch indicates that a file was written by a version of GCS whose data format cannot be loaded.
+	ExitVersionMismatch = 4
+)
+
+// Statuses reported for individual files by the command-line operations.
+const (
+	CLIStatusProcessed = "processed"
+	CLIStatusUpdated   = "updated"
+	CLIStatusUnchanged = "unchanged"
+	CLIStatusExported  = "exported"
+	CLIStatusPrinted   = "printed"
+	CLIStatusSkipped   = "skipped"
+	CLIStatusFailed    = "failed"
+)
+
+// CLI is the reporter used by the command-line operations.
+var CLI = &CLIReporter{}
+
+// CLIReporter handles progress reporting for the command-line operations. In text mode, human-readable progress is
+// emitted as the operation proceeds. In JSON mode, a single JSON document describing the outcome is emitted once the
+// operation finishes, or, for long-running operations, one JSON object per line is emitted as each file is processed.
+type CLIReporter struct {
+	lock      sync.Mutex
+	result    CLIResult
+	JSON      bool
+	streaming bool
+}
+
+// CLIResult holds the outcome of a command-line operation. This is the structure emitted in JSON mode.
+type CLIResult struct {
+	Command  string           `json:"command"`
+	ExitCode int              `json:"exit_code"`
+	Error    string           `json:"error,omitempty"`
+	Files    []*CLIFileResult `json:"files"`
+}
+
+// CLIFileResult holds the outcome for a single file processed by a command-line operation.
+type CLIFileResult struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Begin resets the reporter for a new command.
+func (r *CLIReporter) Begin(command string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.result = CLIResult{Command: command, Files: make([]*CLIFileResult, 0)}
+	r.streaming = false
+}
+
+// BeginStreaming resets the reporter for a new long-running command. File results are emitted as they occur rather
+// than being collected for a final report.
+func (r *CLIReporter) BeginStreaming(command string) {
+	r.Begin(command)
+	r.lock.Lock()
+	r.streaming = true
+	r.lock.Unlock()
+}
+
+// File records the status of a file. In text mode, text is printed to the console.
+func (r *CLIReporter) File(filePath, status, text string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.addFile(&CLIFileResult{Path: filePath, Status: status})
+	if !r.JSON {
+		fmt.Println(text)
+	}
+}
+
+// Failed records a failure for a file. Unless streaming, the error is expected to also be returned to Finish, so
+// nothing is printed in text mode.
+func (r *CLIReporter) Failed(filePath string, err error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.addFile(&CLIFileResult{Path: filePath, Status: CLIStatusFailed, Error: ErrorMessage(err)})
+	if r.streaming && !r.JSON {
+		errs.Log(err, "file", filePath)
+	}
+}
+
+func (r *CLIReporter) addFile(fileResult *CLIFileResult) {
+	if r.streaming {
+		if r.JSON {
+			r.emit(fileResult, false)
+		}
+		return
+	}
+	r.result.Files = append(r.result.Files, fileResult)
+}
+
+// Text prints informational text to the console. Does nothing in JSON mode.
+func (r *CLIReporter) Text(text string) {
+	if !r.JSON {
+		fmt.Println(text)
+	}
+}
+
+// Finish completes the command, emitting the JSON document when in JSON mode or the error message, if any, when in
+// text mode. Returns the exit code that should be used.
+func (r *CLIReporter) Finish(err error) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.result.ExitCode = ExitCodeForError(err)
+	if err != nil {
+		r.result.Error = ErrorMessage(err)
+	}
+	if r.JSON {
+		r.emit(&r.result, !r.streaming)
+	} else if err != nil {
+		fmt.Fprintln(os.Stderr, r.result.Error)
+	}
+	return r.result.ExitCode
+}
+
+// Relay completes the command using a result produced elsewhere, such as by a running instance of GCS that was sent
+// the command. Returns the exit code that should be used.
+func (r *CLIReporter) Relay(result *CLIResult) int {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.result = *result
+	if r.result.Files == nil {
+		r.result.Files = make([]*CLIFileResult, 0)
+	}
+	if r.JSON {
+		r.emit(&r.result, true)
+		return r.result.ExitCode
+	}
+	for _, one := range r.result.Files {
+		if one.Status != CLIStatusFailed {
+			fmt.Printf("%s: %s\n", one.Status, one.Path)
+		}
+	}
+	if r.result.Error != "" {
+		fmt.Fprintln(os.Stderr, r.result.Error)
+	}
+	return r.result.ExitCode
+}
+
+func (r *CLIReporter) emit(data any, indent bool) {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	if indent {
+		encoder.SetIndent("", "  ")
+	}
+	if err := encoder.Encode(data); err != nil {
+		errs.Log(err)
+	}
+}
+
+// ExitCodeForError returns the exit code that best describes the error.
+func ExitCodeForError(err error) int {
+	switch {
+	case err == nil:
+		return ExitSuccess
+	case errors.Is(err, jio.ErrVersionMismatch):
+		return ExitVersionMismatch
+	case isInvalidFileDataError(err):
+		return ExitValidationFailed
+	}
+	var pathErr *iofs.PathError
+	if errors.As(err, &pathErr) {
+		return ExitIOError
+	}
+	return ExitFailure
+}
+
+func isInvalidFileDataError(err error) bool {
+	msg := InvalidFileData()
+	for ; err != nil; err = errors.Unwrap(err) {
+		//nolint:errorlint // Each error in the chain needs to be examined individually
+		if e, ok := err.(*errs.Error); ok && e.Message() == msg {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorMessage returns the messages from the error and its causes, joined together, without any stack trace
+// information.
+func ErrorMessage(err error) string {
+	var buffer strings.Builder
+	var last string
+	for ; err != nil; err = errors.Unwrap(err) {
+		var part string
+		//nolint:errorlint // Each error in the chain needs to be examined individually
+		if e, ok := err.(*errs.Error); ok {
+			part = e.Message()
+		} else {
+			part = err.Error()
+		}
+		part = strings.TrimSpace(part)
+		if part != "" && part != last {
+			if buffer.Len() != 0 {
+				if strings.HasSuffix(last, ".") {
+					buffer.WriteByte(' ')
+				} else {
+					buffer.WriteString(": ")
+				}
+			}
+			buffer.WriteString(part)
+			last = part
+		}
+		if _, ok := err.(*errs.Error); !ok { //nolint:errorlint // See above
+			// Plain errors typically already include the text of their causes.
+			break
+		}
+	}
+	return buffer.String()
+}
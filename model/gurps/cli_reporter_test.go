@@ -0,0 +1,35 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+func TestExitCodeForError(t *testing.T) {
+	check.Equal(t, ExitSuccess, ExitCodeForError(nil))
+	check.Equal(t, ExitFailure, ExitCodeForError(errs.New("usage")))
+	check.Equal(t, ExitVersionMismatch, ExitCodeForError(errs.NewWithCause("x", jio.CheckVersion(jio.CurrentDataVersion+1))))
+	check.Equal(t, ExitVersionMismatch, ExitCodeForError(errs.NewWithCause(InvalidFileData(), jio.CheckVersion(0))))
+	check.Equal(t, ExitValidationFailed, ExitCodeForError(errs.NewWithCause("x", errs.NewWithCause(InvalidFileData(), errors.New("bad")))))
+	_, err := os.Open("/this/path/does/not/exist")
+	check.Equal(t, ExitIOError, ExitCodeForError(errs.NewWithCause("x", errs.Wrap(err))))
+}
+
+func TestErrorMessage(t *testing.T) {
+	check.Equal(t, "a. b: c", ErrorMessage(errs.NewWithCause("a.", errs.NewWithCause("b", errors.New("c")))))
+	check.Equal(t, "a: c", ErrorMessage(errs.NewWithCause("a", errs.Wrap(errors.New("c")))))
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"maps"
+	"slices"
+)
+
+// ColumnLayout holds the user's customization of the columns of one type of table.
+type ColumnLayout struct {
+	Order  []int           `json:"order,omitempty"`
+	Hidden []int           `json:"hidden,omitempty"`
+	Widths map[int]float32 `json:"widths,omitempty"`
+}
+
+// ColumnLayoutKey returns the key used to store the column layout for tables of the given kind. Tables on sheets and
+// templates are kept separate from those in library files.
+func ColumnLayoutKey(refKey string, forPage bool) string {
+	if forPage {
+		return refKey + ".page"
+	}
+	return refKey + ".library"
+}
+
+// Clone a copy of this.
+func (c *ColumnLayout) Clone() *ColumnLayout {
+	if c == nil {
+		return nil
+	}
+	return &ColumnLayout{
+		Order:  slices.Clone(c.Order),
+		Hidden: slices.Clone(c.Hidden),
+		Widths: maps.Clone(c.Widths),
+	}
+}
+
+// Empty returns true if the layout makes no changes to the default.
+func (c *ColumnLayout) Empty() bool {
+	return c == nil || (len(c.Order) == 0 && len(c.Hidden) == 0 && len(c.Widths) == 0)
+}
+
+// IsHidden returns true if the column has been hidden.
+func (c *ColumnLayout) IsHidden(id int) bool {
+	return c != nil && slices.Contains(c.Hidden, id)
+}
+
+// Apply returns the column IDs in the user's chosen order, minus any that have been hidden. Columns in 'required' are
+// never hidden. Columns without a recorded position are placed after the column that precedes them in the default
+// order.
+func (c *ColumnLayout) Apply(ids []int, required ...int) []int {
+	if c.Empty() {
+		return ids
+	}
+	result := make([]int, 0, len(ids))
+	for _, id := range c.Order {
+		if slices.Contains(ids, id) && !slices.Contains(result, id) {
+			result = append(result, id)
+		}
+	}
+	for i, id := range ids {
+		if slices.Contains(result, id) {
+			continue
+		}
+		pos := 0
+		if i > 0 {
+			pos = slices.Index(result, ids[i-1]) + 1
+		}
+		result = slices.Insert(result, pos, id)
+	}
+	return slices.DeleteFunc(result, func(id int) bool {
+		return c.IsHidden(id) && !slices.Contains(required, id)
+	})
+}
+
+// ColumnLayout returns the column layout for the key, or nil if there isn't one.
+func (s *Settings) ColumnLayout(key string) *ColumnLayout {
+	return s.ColumnLayouts[key]
+}
+
+// SetColumnLayout sets the column layout for the key. Passing in an empty layout removes it.
+func (s *Settings) SetColumnLayout(key string, layout *ColumnLayout) {
+	if layout.Empty() {
+		delete(s.ColumnLayouts, key)
+		return
+	}
+	if s.ColumnLayouts == nil {
+		s.ColumnLayouts = make(map[string]*ColumnLayout)
+	}
+	s.ColumnLayouts[key] = layout
+}
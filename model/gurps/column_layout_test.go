@@ -0,0 +1,42 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestColumnLayout(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5}
+	var layout *gurps.ColumnLayout
+	check.Equal(t, ids, layout.Apply(ids))
+
+	layout = &gurps.ColumnLayout{Hidden: []int{4, 2}}
+	check.Equal(t, []int{1, 3, 5}, layout.Apply(ids))
+	check.Equal(t, []int{1, 2, 3, 5}, layout.Apply(ids, 2), "required columns are never hidden")
+
+	layout = &gurps.ColumnLayout{Order: []int{3, 1, 2, 4, 5}, Hidden: []int{5}}
+	check.Equal(t, []int{3, 1, 2, 4}, layout.Apply(ids))
+
+	// Columns that were not present when the order was recorded follow their default predecessor
+	check.Equal(t, []int{3, 1, 2, 6, 4}, layout.Apply([]int{1, 2, 6, 3, 4, 5}))
+	check.Equal(t, []int{0, 3, 1, 2, 4}, layout.Apply([]int{0, 1, 2, 3, 4, 5}))
+
+	var settings gurps.Settings
+	key := gurps.ColumnLayoutKey("equipment", true)
+	check.NotEqual(t, key, gurps.ColumnLayoutKey("equipment", false))
+	settings.SetColumnLayout(key, layout)
+	check.Equal(t, layout, settings.ColumnLayout(key))
+	settings.SetColumnLayout(key, &gurps.ColumnLayout{})
+	check.Nil(t, settings.ColumnLayout(key))
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// ColumnSort identifies a column a table is sorted on. A table sorted on more than one column holds a list of these,
+// with the primary sort column first.
+type ColumnSort struct {
+	ID         int  `json:"id"`
+	Descending bool `json:"descending,omitempty"`
+}
+
+// LibrarySort holds the columns the table of a library file is sorted on.
+type LibrarySort struct {
+	Columns  []ColumnSort `json:"columns"`
+	LastUsed int64        `json:"last"`
+}
+
+// CloneColumnSorts returns a copy of the column sorts.
+func CloneColumnSorts(in map[string][]ColumnSort) map[string][]ColumnSort {
+	if in == nil {
+		return nil
+	}
+	out := maps.Clone(in)
+	for k, v := range out {
+		out[k] = slices.Clone(v)
+	}
+	return out
+}
+
+// ColumnSort returns the columns the table with the given key is sorted on.
+func (s *SheetSettings) ColumnSort(key string) []ColumnSort {
+	return s.ColumnSorts[key]
+}
+
+// SetColumnSort sets the columns the table with the given key is sorted on. Passing in an empty list removes it.
+func (s *SheetSettings) SetColumnSort(key string, columns []ColumnSort) {
+	if len(columns) == 0 {
+		delete(s.ColumnSorts, key)
+		return
+	}
+	if s.ColumnSorts == nil {
+		s.ColumnSorts = make(map[string][]ColumnSort)
+	}
+	s.ColumnSorts[key] = columns
+}
+
+// LibrarySort returns the columns the table of the library file is sorted on.
+func (s *Settings) LibrarySort(filePath string) []ColumnSort {
+	if one, ok := s.LibrarySorts[filePath]; ok {
+		return one.Columns
+	}
+	return nil
+}
+
+// SetLibrarySort sets the columns the table of the library file is sorted on. Passing in an empty list removes it.
+func (s *Settings) SetLibrarySort(filePath string, columns []ColumnSort) {
+	if len(columns) == 0 {
+		delete(s.LibrarySorts, filePath)
+		return
+	}
+	if s.LibrarySorts == nil {
+		s.LibrarySorts = make(map[string]*LibrarySort)
+	}
+	s.LibrarySorts[filePath] = &LibrarySort{
+		Columns:  columns,
+		LastUsed: time.Now().Unix(),
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestColumnSort(t *testing.T) {
+	ss := gurps.FactorySheetSettings()
+	sorts := []gurps.ColumnSort{{ID: gurps.EquipmentTLColumn}, {ID: gurps.EquipmentCostColumn, Descending: true}}
+	ss.SetColumnSort(gurps.BlockLayoutEquipmentKey, sorts)
+	check.Equal(t, sorts, ss.ColumnSort(gurps.BlockLayoutEquipmentKey))
+	check.Nil(t, ss.ColumnSort(gurps.BlockLayoutSkillsKey))
+
+	clone := ss.Clone(nil)
+	clone.ColumnSort(gurps.BlockLayoutEquipmentKey)[0].Descending = true
+	check.False(t, ss.ColumnSort(gurps.BlockLayoutEquipmentKey)[0].Descending, "clones don't share column sorts")
+
+	ss.SetColumnSort(gurps.BlockLayoutEquipmentKey, nil)
+	check.Equal(t, 0, len(ss.ColumnSorts))
+
+	var settings gurps.Settings
+	settings.SetLibrarySort("Gear.eqp", sorts)
+	check.Equal(t, sorts, settings.LibrarySort("Gear.eqp"))
+	check.True(t, settings.LibrarySorts["Gear.eqp"].LastUsed > 0)
+	settings.SetLibrarySort("Gear.eqp", nil)
+	check.Nil(t, settings.LibrarySort("Gear.eqp"))
+}
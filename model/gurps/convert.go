@@ -33,8 +33,45 @@ func Convert(paths ...string) error {
 	if err != nil {
 		return err
 	}
+	list := collectConvertiblePaths(paths)
+	for _, p := range list {
+		if err = convertFile(p); err != nil {
+			CLI.Failed(p, err)
+			return err
+		}
+		CLI.File(p, CLIStatusProcessed, fmt.Sprintf(i18n.Text("Processed %s"), p))
+	}
+	reportProcessedCount(len(list))
+	return nil
+}
+
+func reportProcessedCount(count int) {
+	if count == 1 {
+		CLI.Text(i18n.Text("Processed 1 file"))
+	} else {
+		CLI.Text(fmt.Sprintf(i18n.Text("Processed %d files"), count))
+	}
+}
+
+// SheetPaths walks the given paths and returns a sorted list of the character sheet files found within them.
+func SheetPaths(paths ...string) ([]string, error) {
+	var err error
+	if paths, err = fs.UniquePaths(paths...); err != nil {
+		return nil, err
+	}
+	return collectPaths(paths, collection.NewSet(SheetExt)), nil
+}
+
+// collectConvertiblePaths walks the given paths and returns a sorted list of the GCS files found within them.
+func collectConvertiblePaths(paths []string) []string {
 	extSet := collection.NewSet(GCSExtensions()...)
 	extSet.Add(GCSSecondaryExtensions()...)
+	return collectPaths(paths, extSet)
+}
+
+// collectPaths walks the given paths and returns a sorted list of the files found within them that have one of the
+// extensions in extSet.
+func collectPaths(paths []string, extSet collection.Set[string]) []string {
 	pathSet := collection.NewSet[string]()
 	f := convertWalker(pathSet, extSet)
 	for _, p := range paths {
@@ -42,180 +79,178 @@ func Convert(paths ...string) error {
 	}
 	list := pathSet.Values()
 	txt.SortStringsNaturalAscending(list)
-	for _, p := range list {
-		fmt.Printf(i18n.Text("Processing %s\n"), p)
-		switch strings.ToLower(filepath.Ext(p)) {
-		case TraitsExt:
-			var data []*Trait
-			if data, err = NewTraitsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveTraits(data, p); err != nil {
-				return err
-			}
-		case TraitModifiersExt:
-			var data []*TraitModifier
-			if data, err = NewTraitModifiersFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveTraitModifiers(data, p); err != nil {
-				return err
-			}
-		case EquipmentExt:
-			var data []*Equipment
-			if data, err = NewEquipmentFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveEquipment(data, p); err != nil {
-				return err
-			}
-		case EquipmentModifiersExt:
-			var data []*EquipmentModifier
-			if data, err = NewEquipmentModifiersFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveEquipmentModifiers(data, p); err != nil {
-				return err
-			}
-		case SkillsExt:
-			var data []*Skill
-			if data, err = NewSkillsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveSkills(data, p); err != nil {
-				return err
-			}
-		case SpellsExt:
-			var data []*Spell
-			if data, err = NewSpellsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveSpells(data, p); err != nil {
-				return err
-			}
-		case NotesExt:
-			var data []*Note
-			if data, err = NewNotesFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = SaveNotes(data, p); err != nil {
-				return err
-			}
-		case TemplatesExt:
-			var tmpl *Template
-			if tmpl, err = NewTemplateFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = tmpl.Save(p); err != nil {
-				return err
-			}
-		// TODO: Re-enable Campaign files
-		// case CampaignExt:
-		// 	var campaign *Campaign
-		// 	if campaign, err = NewCampaignFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-		// 		return err
-		// 	}
-		// 	if err = campaign.Save(p); err != nil {
-		// 		return err
-		// 	}
-		case SheetExt:
-			var entity *Entity
-			if entity, err = NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = entity.Save(p); err != nil {
-				return err
-			}
-		case AncestryExt:
-			var data *Ancestry
-			if data, err = NewAncestryFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case AttributesExt, AttributesExtAlt1, AttributesExtAlt2:
-			var data *AttributeDefs
-			if data, err = NewAttributeDefsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case BodyExt, BodyExtAlt:
-			var data *Body
-			if data, err = NewBodyFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case CalendarExt:
-			// Currently have no version info, so nothing to update
-		case ColorSettingsExt:
-			var data *colors.Colors
-			if data, err = colors.NewFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case FontSettingsExt:
-			var data *fonts.Fonts
-			if data, err = fonts.NewFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case GeneralSettingsExt:
-			var data *GeneralSettings
-			if data, err = NewGeneralSettingsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case KeySettingsExt:
-			var data *KeyBindings
-			if data, err = NewKeyBindingsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case NamesExt:
-			// Currently have no version info, so nothing to update
-		case PageRefSettingsExt:
-			var data *PageRefs
-			if data, err = NewPageRefsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case SheetSettingsExt:
-			var data *SheetSettings
-			if data, err = NewSheetSettingsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
-		case WebSettingsExt:
-			var data *websettings.Settings
-			if data, err = websettings.NewSettingsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			if err = data.Save(p); err != nil {
-				return err
-			}
+	return list
+}
+
+// convertFile loads the GCS file at the given path and saves it back out in the current file format.
+func convertFile(p string) error {
+	var err error
+	switch strings.ToLower(filepath.Ext(p)) {
+	case TraitsExt:
+		var data []*Trait
+		if data, err = NewTraitsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = SaveTraits(data, p); err != nil {
+			return err
+		}
+	case TraitModifiersExt:
+		var data []*TraitModifier
+		if data, err = NewTraitModifiersFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = SaveTraitModifiers(data, p); err != nil {
+			return err
+		}
+	case EquipmentExt:
+		var data []*Equipment
+		if data, err = NewEquipmentFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = SaveEquipment(data, p); err != nil {
+			return err
+		}
+	case EquipmentModifiersExt:
+		var data []*EquipmentModifier
+		if data, err = NewEquipmentModifiersFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = SaveEquipmentModifiers(data, p); err != nil {
+			return err
+		}
+	case SkillsExt:
+		var data []*Skill
+		if data, err = NewSkillsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = SaveSkills(data, p); err != nil {
+			return err
+		}
+	case SpellsExt:
+		var data []*Spell
+		if data, err = NewSpellsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = SaveSpells(data, p); err != nil {
+			return err
+		}
+	case NotesExt:
+		var data []*Note
+		if data, err = NewNotesFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = SaveNotes(data, p); err != nil {
+			return err
+		}
+	case TemplatesExt:
+		var tmpl *Template
+		if tmpl, err = NewTemplateFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = tmpl.Save(p); err != nil {
+			return err
+		}
+	// TODO: Re-enable Campaign files
+	// case CampaignExt:
+	// 	var campaign *Campaign
+	// 	if campaign, err = NewCampaignFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+	// 		return err
+	// 	}
+	// 	if err = campaign.Save(p); err != nil {
+	// 		return err
+	// 	}
+	case SheetExt:
+		var entity *Entity
+		if entity, err = NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = entity.Save(p); err != nil {
+			return err
+		}
+	case AncestryExt:
+		var data *Ancestry
+		if data, err = NewAncestryFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = data.Save(p); err != nil {
+			return err
+		}
+	case AttributesExt, AttributesExtAlt1, AttributesExtAlt2:
+		var data *AttributeDefs
+		if data, err = NewAttributeDefsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = data.Save(p); err != nil {
+			return err
+		}
+	case BodyExt, BodyExtAlt:
+		var data *Body
+		if data, err = NewBodyFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = data.Save(p); err != nil {
+			return err
+		}
+	case CalendarExt:
+		// Currently have no version info, so nothing to update
+	case ColorSettingsExt:
+		var data *colors.Colors
+		if data, err = colors.NewFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = data.Save(p); err != nil {
+			return err
+		}
+	case FontSettingsExt:
+		var data *fonts.Fonts
+		if data, err = fonts.NewFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = data.Save(p); err != nil {
+			return err
+		}
+	case GeneralSettingsExt:
+		var data *GeneralSettings
+		if data, err = NewGeneralSettingsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = data.Save(p); err != nil {
+			return err
+		}
+	case KeySettingsExt:
+		var data *KeyBindings
+		if data, err = NewKeyBindingsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = data.Save(p); err != nil {
+			return err
+		}
+	case NameListExt, NamesExt:
+		// Currently have no version info, so nothing to update
+	case PageRefSettingsExt:
+		var data *PageRefs
+		if data, err = NewPageRefsFromFS(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = data.Save(p); err != nil {
+			return err
+		}
+	case SheetSettingsExt:
+		var data *SheetSettings
+		if data, err = NewSheetSettingsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = data.Save(p); err != nil {
+			return err
+		}
+	case WebSettingsExt:
+		var data *websettings.Settings
+		if data, err = websettings.NewSettingsFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			return err
+		}
+		if err = data.Save(p); err != nil {
+			return err
 		}
-	}
-	if len(list) == 1 {
-		fmt.Println(i18n.Text("Processed 1 file"))
-	} else {
-		fmt.Printf(i18n.Text("Processed %d files\n"), len(list))
 	}
 	return nil
 }
@@ -0,0 +1,61 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+var _ json.Omitter = Cultures{}
+
+// SocialSkillTag is the tag used to identify the skills that are affected by cultural unfamiliarity.
+const SocialSkillTag = "Social"
+
+// CulturalUnfamiliarityPenalty is the penalty applied to social skills when dealing with a culture the character is
+// not familiar with.
+var CulturalUnfamiliarityPenalty = -fxp.Three
+
+// Cultures holds the character's native culture and any additional cultures they are familiar with.
+type Cultures struct {
+	Native        string   `json:"native,omitempty"`
+	Familiar      []string `json:"familiar,omitempty"`
+	AlienFamiliar []string `json:"alien_familiar,omitempty"`
+	// Unfamiliar is true when the character is currently dealing with a culture they are not familiar with.
+	Unfamiliar bool `json:"unfamiliar,omitempty"`
+}
+
+// ShouldOmit implements json.Omitter.
+func (c Cultures) ShouldOmit() bool {
+	return c.Native == "" && len(c.Familiar) == 0 && len(c.AlienFamiliar) == 0 && !c.Unfamiliar
+}
+
+// Clone returns a copy of the cultures.
+func (c Cultures) Clone() Cultures {
+	c.Familiar = slices.Clone(c.Familiar)
+	c.AlienFamiliar = slices.Clone(c.AlienFamiliar)
+	return c
+}
+
+// Points returns the point cost of the cultural familiarities. The native culture is free, each additional culture
+// costs 1 point and each culture of an alien race costs 2 points.
+func (c Cultures) Points() fxp.Int {
+	return fxp.From(len(c.Familiar) + 2*len(c.AlienFamiliar))
+}
+
+// UnfamiliarityTooltip returns the text to add to a tooltip to explain the cultural unfamiliarity penalty.
+func UnfamiliarityTooltip() string {
+	return fmt.Sprintf(i18n.Text("Includes %s for dealing with an unfamiliar culture"),
+		CulturalUnfamiliarityPenalty.StringWithSign())
+}
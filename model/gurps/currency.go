@@ -0,0 +1,200 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Currency holds a denomination of money, such as a gold coin. Its value is the exchange rate into the $ used for
+// equipment costs, and its weight is that of a single coin.
+type Currency struct {
+	Name   string     `json:"name"`
+	Value  fxp.Int    `json:"value"`
+	Weight fxp.Weight `json:"weight,omitempty"`
+}
+
+// CloneCurrencies creates a clone of the provided Currency list.
+func CloneCurrencies(list []*Currency) []*Currency {
+	if list == nil {
+		return nil
+	}
+	clone := make([]*Currency, len(list))
+	for i, one := range list {
+		c := *one
+		clone[i] = &c
+	}
+	return clone
+}
+
+// NewCurrenciesFromString creates a Currency list from a string, one denomination per line in the form
+// "name = value[, weight]", e.g. "Gold Piece = 20, 0.02 lb". Lines that can't be parsed are dropped and inputWasValid
+// will be false.
+func NewCurrenciesFromString(str string) (list []*Currency, inputWasValid bool) {
+	inputWasValid = true
+	for _, line := range strings.Split(str, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if c, ok := parseCurrency(line); ok {
+			list = append(list, c)
+		} else {
+			inputWasValid = false
+		}
+	}
+	return list, inputWasValid
+}
+
+func parseCurrency(line string) (*Currency, bool) {
+	i := strings.LastIndex(line, "=")
+	if i < 0 {
+		return nil, false
+	}
+	c := &Currency{Name: strings.TrimSpace(line[:i])}
+	if c.Name == "" {
+		return nil, false
+	}
+	valuePart, weightPart, hasWeight := strings.Cut(line[i+1:], ",")
+	var err error
+	if c.Value, err = fxp.FromString(strings.TrimPrefix(strings.TrimSpace(valuePart), "$")); err != nil ||
+		c.Value <= 0 {
+		return nil, false
+	}
+	if hasWeight {
+		if c.Weight, err = fxp.WeightFromString(strings.TrimSpace(weightPart), fxp.Pound); err != nil || c.Weight < 0 {
+			return nil, false
+		}
+	}
+	return c, true
+}
+
+// CurrenciesToString returns the Currency list in the form accepted by NewCurrenciesFromString.
+func CurrenciesToString(list []*Currency) string {
+	var buffer strings.Builder
+	for _, one := range list {
+		buffer.WriteString(one.String())
+		buffer.WriteByte('\n')
+	}
+	return buffer.String()
+}
+
+// String implements fmt.Stringer.
+func (c *Currency) String() string {
+	if c.Weight == 0 {
+		return fmt.Sprintf("%s = %s", c.Name, c.Value.String())
+	}
+	return fmt.Sprintf("%s = %s, %s", c.Name, c.Value.String(), c.Weight.String())
+}
+
+// Convert returns the given $ value expressed in this currency.
+func (c *Currency) Convert(value fxp.Int) fxp.Int {
+	if c.Value <= 0 {
+		return 0
+	}
+	return value.Div(c.Value)
+}
+
+// CurrencyNamed returns the currency with the given name, or nil if there is none.
+func (s *SheetSettings) CurrencyNamed(name string) *Currency {
+	for _, one := range s.Currencies {
+		if one.Name == name {
+			return one
+		}
+	}
+	return nil
+}
+
+// CurrencyConversions returns a description of the given $ value in each of the currencies, one per line, or an empty
+// string if no currencies have been defined.
+func (s *SheetSettings) CurrencyConversions(value fxp.Int) string {
+	var buffer strings.Builder
+	for _, one := range s.Currencies {
+		if buffer.Len() != 0 {
+			buffer.WriteByte('\n')
+		}
+		fmt.Fprintf(&buffer, i18n.Text("= %s %s"), one.Convert(value).Comma(), one.Name)
+	}
+	return buffer.String()
+}
+
+// CoinValue returns the $ value of the given coins, keyed by currency name. Coins of currencies that aren't defined are
+// ignored.
+func (s *SheetSettings) CoinValue(coins map[string]int) fxp.Int {
+	var value fxp.Int
+	for name, count := range coins {
+		if c := s.CurrencyNamed(name); c != nil {
+			value += c.Value.Mul(fxp.From(count))
+		}
+	}
+	return value
+}
+
+// CoinWeight returns the weight of the given coins, keyed by currency name.
+func (s *SheetSettings) CoinWeight(coins map[string]int) fxp.Weight {
+	var weight fxp.Weight
+	for name, count := range coins {
+		if c := s.CurrencyNamed(name); c != nil {
+			weight += fxp.Weight(fxp.Int(c.Weight).Mul(fxp.From(count)))
+		}
+	}
+	return weight
+}
+
+// CoinValue returns the $ value of the coins the entity holds.
+func (e *Entity) CoinValue() fxp.Int {
+	return e.SheetSettings.CoinValue(e.Coins)
+}
+
+// CoinWeight returns the weight of the coins the entity holds.
+func (e *Entity) CoinWeight() fxp.Weight {
+	return e.SheetSettings.CoinWeight(e.Coins)
+}
+
+// AvailableFunds returns the entity's funds plus the value of the coins it holds.
+func (e *Entity) AvailableFunds() fxp.Int {
+	return e.Funds + e.CoinValue()
+}
+
+// Spend deducts the given $ amount from the entity's funds. Any shortfall is paid with coins, smallest denominations
+// first, with the change returned to its funds.
+func (e *Entity) Spend(amount fxp.Int) {
+	if amount <= e.Funds || len(e.Coins) == 0 {
+		e.Funds -= amount
+		return
+	}
+	shortfall := amount - e.Funds
+	currencies := slices.Clone(e.SheetSettings.Currencies)
+	slices.SortStableFunc(currencies, func(a, b *Currency) int { return cmp.Compare(a.Value, b.Value) })
+	for _, c := range currencies {
+		if shortfall <= 0 {
+			break
+		}
+		count := e.Coins[c.Name]
+		if count <= 0 {
+			continue
+		}
+		needed := shortfall.Div(c.Value).Ceil()
+		used := min(count, fxp.As[int](needed))
+		shortfall -= c.Value.Mul(fxp.From(used))
+		if count == used {
+			delete(e.Coins, c.Name)
+		} else {
+			e.Coins[c.Name] = count - used
+		}
+	}
+	e.Funds = -shortfall
+}
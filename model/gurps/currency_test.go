@@ -0,0 +1,64 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestCurrencies(t *testing.T) {
+	list, valid := gurps.NewCurrenciesFromString("Gold Piece = 20, 0.02 lb\n\nSilver Piece = $1, 0.02 lb\nIOU = 5\n")
+	check.True(t, valid)
+	check.Equal(t, 3, len(list))
+	check.Equal(t, "Gold Piece", list[0].Name)
+	check.Equal(t, fxp.Twenty, list[0].Value)
+	check.Equal(t, fxp.WeightFromStringForced("0.02", fxp.Pound), list[0].Weight)
+	check.Equal(t, fxp.Weight(0), list[2].Weight)
+	round, valid := gurps.NewCurrenciesFromString(gurps.CurrenciesToString(list))
+	check.True(t, valid)
+	check.Equal(t, list, round, "survives a round trip")
+
+	_, valid = gurps.NewCurrenciesFromString("Gold Piece\nCopper = 0")
+	check.False(t, valid, "missing and non-positive values")
+
+	e := gurps.NewEntity()
+	e.SheetSettings.Currencies = list
+	check.Equal(t, "= 2.5 Gold Piece\n= 50 Silver Piece\n= 10 IOU", e.SheetSettings.CurrencyConversions(fxp.Fifty))
+
+	e.Coins = map[string]int{"Gold Piece": 3, "Silver Piece": 15, "Unknown": 100}
+	check.Equal(t, fxp.From(75), e.CoinValue(), "unknown currencies are ignored")
+	check.Equal(t, fxp.WeightFromStringForced("0.36", fxp.Pound), e.CoinWeight())
+	check.Equal(t, e.CoinWeight(), e.WeightCarried(false), "coins count toward encumbrance")
+	e.Funds = fxp.Ten
+	check.Equal(t, fxp.From(85), e.AvailableFunds())
+}
+
+func TestSpendCoins(t *testing.T) {
+	e := gurps.NewEntity()
+	e.SheetSettings.Currencies, _ = gurps.NewCurrenciesFromString("Gold Piece = 20\nSilver Piece = 1")
+	e.Funds = fxp.Two
+	e.Coins = map[string]int{"Gold Piece": 2, "Silver Piece": 5}
+
+	e.Spend(fxp.One)
+	check.Equal(t, fxp.One, e.Funds, "paid from funds first")
+	check.Equal(t, map[string]int{"Gold Piece": 2, "Silver Piece": 5}, e.Coins)
+
+	e.Spend(fxp.Four)
+	check.Equal(t, fxp.Int(0), e.Funds, "no change due")
+	check.Equal(t, map[string]int{"Gold Piece": 2, "Silver Piece": 2}, e.Coins)
+
+	e.Spend(fxp.Ten)
+	check.Equal(t, fxp.From(12), e.Funds, "change from a gold piece")
+	check.Equal(t, map[string]int{"Gold Piece": 1}, e.Coins)
+}
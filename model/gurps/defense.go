@@ -0,0 +1,155 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/defense"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/stlimit"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+// Names of the traits that the defense calculation variants in the sheet settings look for.
+const (
+	CombatReflexesTraitName = "Combat Reflexes"
+	EnhancedDodgeTraitName  = "Enhanced Dodge"
+	EnhancedParryTraitName  = "Enhanced Parry"
+	EnhancedBlockTraitName  = "Enhanced Block"
+)
+
+// CrossParryBonus is the bonus for parrying with two weapons at once.
+const CrossParryBonus = 2
+
+// defenseBonusFor returns the total bonus to the active defense with the given attribute ID, applying the defense
+// variants chosen in the sheet settings. The portion of the bonus that still comes from Combat Reflexes is also
+// returned, so that weapon-specific Enhanced Defenses can be kept from stacking with it, too. A Defense Bonus (DB) is
+// taken to be any bonus provided by equipment.
+func (e *Entity) defenseBonusFor(attributeID string, tooltip *xio.ByteBuffer) (total, reflexes fxp.Int) {
+	settings := SheetSettingsFor(e)
+	var enhanced fxp.Int
+	for _, one := range e.features.attributeBonuses {
+		if one.ActualLimitation() != stlimit.None || one.Attribute != attributeID {
+			continue
+		}
+		if _, ok := one.Owner().(*Equipment); ok && attributeID != BlockID &&
+			settings.DefenseBonusRule == defense.BlockOnly {
+			continue
+		}
+		amt := one.AdjustedAmount()
+		switch {
+		case isCombatReflexes(one.Owner()):
+			reflexes += amt
+		case isEnhancedDefense(one.Owner(), attributeID):
+			enhanced += amt
+		}
+		total += amt
+		one.AddToTooltip(tooltip)
+	}
+	if settings.CombatReflexesRule == defense.NoStacking {
+		overlap := overlappingReflexesBonus(reflexes, enhanced, tooltip)
+		total -= overlap
+		reflexes -= overlap
+	}
+	return total, reflexes
+}
+
+// overlappingReflexesBonus returns the amount that must be removed from a defense so that only the larger of the
+// Combat Reflexes and Enhanced Defenses bonuses applies, adding an explanation to the tooltip, if there is one.
+func overlappingReflexesBonus(reflexes, enhanced fxp.Int, tooltip *xio.ByteBuffer) fxp.Int {
+	if reflexes <= 0 || enhanced <= 0 {
+		return 0
+	}
+	overlap := min(reflexes, enhanced)
+	if tooltip != nil {
+		fmt.Fprintf(tooltip, i18n.Text("\n%s doesn't stack with Enhanced Defenses [%s]"), CombatReflexesTraitName,
+			(-overlap).StringWithSign())
+	}
+	return overlap
+}
+
+func isCombatReflexes(owner fmt.Stringer) bool {
+	t, ok := owner.(*Trait)
+	return ok && strings.EqualFold(t.NameWithReplacements(), CombatReflexesTraitName)
+}
+
+func isEnhancedDefense(owner fmt.Stringer, attributeID string) bool {
+	t, ok := owner.(*Trait)
+	if !ok {
+		return false
+	}
+	var name string
+	switch attributeID {
+	case DodgeID:
+		name = EnhancedDodgeTraitName
+	case ParryID:
+		name = EnhancedParryTraitName
+	case BlockID:
+		name = EnhancedBlockTraitName
+	default:
+		return false
+	}
+	return strings.EqualFold(t.NameWithReplacements(), name)
+}
+
+// enhancedDefenseWeaponBonus returns the total of the weapon bonuses that come from Enhanced Defenses for the
+// defense with the given attribute ID.
+func (w *Weapon) enhancedDefenseWeaponBonus(bonuses []*WeaponBonus, attributeID string) fxp.Int {
+	var total fxp.Int
+	for _, bonus := range bonuses {
+		if !bonus.Percent && isEnhancedDefense(bonus.Owner(), attributeID) {
+			total += bonus.AdjustedAmountForWeapon(w)
+		}
+	}
+	return total
+}
+
+// CrossParry returns the Parry for parrying with two ready weapons at once, along with the two weapons used. The two
+// weapons with the best Parry that come from different pieces of equipment are chosen. Returns false if the sheet
+// settings don't allow cross parries or no such pair of weapons exists.
+func (e *Entity) CrossParry() (parry int, first, second *Weapon, ok bool) {
+	rule := SheetSettingsFor(e).CrossParryRule
+	if rule == defense.NoCrossParry {
+		return 0, nil, nil, false
+	}
+	var bestParry, secondParry int
+	for _, w := range e.EquippedWeapons(true) {
+		if _, isEquipment := w.Owner.(*Equipment); !isEquipment {
+			continue
+		}
+		p := w.Parry.Resolve(w, nil)
+		if !p.CanParry {
+			continue
+		}
+		level := fxp.As[int](p.Modifier)
+		switch {
+		case first == nil || level > bestParry:
+			if first != nil && first.Owner != w.Owner {
+				second = first
+				secondParry = bestParry
+			}
+			first = w
+			bestParry = level
+		case w.Owner != first.Owner && (second == nil || level > secondParry):
+			second = w
+			secondParry = level
+		}
+	}
+	if second == nil {
+		return 0, nil, nil, false
+	}
+	if rule == defense.WorsePlusTwo {
+		return secondParry + CrossParryBonus, first, second, true
+	}
+	return bestParry + CrossParryBonus, first, second, true
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/defense"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestDefenseBonusRules(t *testing.T) {
+	e := NewEntity()
+	reflexes := NewTrait(e, nil, false)
+	reflexes.Name = CombatReflexesTraitName
+	reflexes.Features = Features{NewAttributeBonus(DodgeID), NewAttributeBonus(ParryID), NewAttributeBonus(BlockID)}
+	enhanced := NewTrait(e, nil, false)
+	enhanced.Name = EnhancedParryTraitName
+	bonus := NewAttributeBonus(ParryID)
+	bonus.Amount = fxp.Two
+	enhanced.Features = Features{bonus}
+	e.SetTraitList([]*Trait{reflexes, enhanced})
+	shield := NewEquipment(e, nil, false)
+	shield.Name = "Shield"
+	bonus = NewAttributeBonus(DodgeID)
+	bonus.Amount = fxp.Two
+	shield.Features = Features{bonus}
+	bonus = NewAttributeBonus(BlockID)
+	bonus.Amount = fxp.Two
+	shield.Features = append(shield.Features, bonus)
+	e.SetCarriedEquipmentList([]*Equipment{shield})
+
+	e.Recalculate()
+	check.Equal(t, fxp.Three, e.DodgeBonus, "dodge gets the DB and Combat Reflexes")
+	check.Equal(t, fxp.Three, e.ParryBonus, "Combat Reflexes stacks with Enhanced Parry")
+	check.Equal(t, fxp.Three, e.BlockBonus, "block gets the DB and Combat Reflexes")
+
+	e.SheetSettings.DefenseBonusRule = defense.BlockOnly
+	e.SheetSettings.CombatReflexesRule = defense.NoStacking
+	e.Recalculate()
+	check.Equal(t, fxp.One, e.DodgeBonus, "dodge no longer gets the DB")
+	check.Equal(t, fxp.Two, e.ParryBonus, "only the larger of Combat Reflexes and Enhanced Parry applies")
+	check.Equal(t, fxp.Int(0), e.parryReflexesBonus, "no Combat Reflexes bonus remains for parry")
+	check.Equal(t, fxp.Three, e.BlockBonus, "block still gets the DB")
+	check.Equal(t, fxp.One, e.blockReflexesBonus, "Combat Reflexes bonus to block remains")
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// DerivedField holds a user-defined field whose value is calculated from a formula, e.g. "dodge() + 1".
+type DerivedField struct {
+	Name    string `json:"name"`
+	Formula string `json:"formula"`
+}
+
+// CloneDerivedFields creates a clone of the provided DerivedField list.
+func CloneDerivedFields(list []*DerivedField) []*DerivedField {
+	if list == nil {
+		return nil
+	}
+	clone := make([]*DerivedField, len(list))
+	for i, one := range list {
+		f := *one
+		clone[i] = &f
+	}
+	return clone
+}
+
+// NewDerivedFieldsFromString creates a DerivedField list from a string, one field per line in the form
+// "name = formula", e.g. "Effective Dodge = dodge() + 1". Lines that can't be parsed are dropped and inputWasValid will
+// be false.
+func NewDerivedFieldsFromString(str string) (list []*DerivedField, inputWasValid bool) {
+	inputWasValid = true
+	for _, line := range strings.Split(str, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, formula, found := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		formula = strings.TrimSpace(formula)
+		if !found || name == "" || formula == "" {
+			inputWasValid = false
+			continue
+		}
+		list = append(list, &DerivedField{Name: name, Formula: formula})
+	}
+	return list, inputWasValid
+}
+
+// DerivedFieldsToString returns the DerivedField list in the form accepted by NewDerivedFieldsFromString.
+func DerivedFieldsToString(list []*DerivedField) string {
+	var buffer strings.Builder
+	for _, one := range list {
+		fmt.Fprintf(&buffer, "%s = %s\n", one.Name, one.Formula)
+	}
+	return buffer.String()
+}
+
+// Value returns the result of evaluating the field's formula for the entity. If the formula can't be evaluated, the
+// error is returned along with an empty value.
+func (f *DerivedField) Value(entity *Entity) (string, error) {
+	result, err := fxp.NewEvaluator(entity).Evaluate(f.Formula)
+	if err != nil {
+		return "", errs.NewWithCause("unable to evaluate derived field "+f.Name, err)
+	}
+	switch v := result.(type) {
+	case fxp.Int:
+		return v.String(), nil
+	case string:
+		return v, nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
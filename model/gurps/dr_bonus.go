@@ -29,6 +29,7 @@ type DRBonusData struct {
 	Type           feature.Type `json:"type"`
 	Locations      []string     `json:"locations,omitempty"`
 	Specialization string       `json:"specialization,omitempty"`
+	Flexible       bool         `json:"flexible,omitempty"`
 	LeveledAmount
 }
 
@@ -79,6 +80,15 @@ func (d *DRBonus) Normalize() {
 	d.Specialization = s
 }
 
+func (d *DRBonus) appliesTo(locationID string, isTopLevel bool) bool {
+	for _, loc := range d.Locations {
+		if (loc == AllID && isTopLevel) || strings.EqualFold(loc, locationID) {
+			return true
+		}
+	}
+	return false
+}
+
 // FillWithNameableKeys implements Feature.
 func (d *DRBonus) FillWithNameableKeys(_, _ map[string]string) {
 }
@@ -99,6 +109,9 @@ func (d *DRBonus) AddToTooltip(buffer *xio.ByteBuffer) {
 		buffer.WriteString(i18n.Text(" against "))
 		buffer.WriteString(d.Specialization)
 		buffer.WriteString(i18n.Text(" attacks]"))
+		if d.Flexible {
+			buffer.WriteString(i18n.Text(" (flexible)"))
+		}
 	}
 }
 
@@ -142,5 +155,6 @@ func (d *DRBonus) Hash(h hash.Hash) {
 		hashhelper.String(h, loc)
 	}
 	hashhelper.String(h, d.Specialization)
+	hashhelper.Bool(h, d.Flexible)
 	d.LeveledAmount.Hash(h)
 }
@@ -50,6 +50,7 @@ import (
 var (
 	_ eval.VariableResolver = &Entity{}
 	_ ListProvider          = &Entity{}
+	_ LanguageListProvider  = &Entity{}
 	_ DataOwner             = &Entity{}
 	_ Hashable              = &Entity{}
 )
@@ -72,22 +73,36 @@ func (pb *PointsBreakdown) Total() fxp.Int {
 
 // EntityData holds the Entity data that is written to disk.
 type EntityData struct {
-	Version          int             `json:"version"`
-	ID               tid.TID         `json:"id"`
-	TotalPoints      fxp.Int         `json:"total_points"`
-	PointsRecord     []*PointsRecord `json:"points_record,omitempty"`
-	Profile          Profile         `json:"profile"`
-	SheetSettings    *SheetSettings  `json:"settings,omitempty"`
-	Attributes       *Attributes     `json:"attributes,omitempty"`
-	Traits           []*Trait        `json:"traits,alt=advantages,omitempty"`
-	Skills           []*Skill        `json:"skills,omitempty"`
-	Spells           []*Spell        `json:"spells,omitempty"`
-	CarriedEquipment []*Equipment    `json:"equipment,omitempty"`
-	OtherEquipment   []*Equipment    `json:"other_equipment,omitempty"`
-	Notes            []*Note         `json:"notes,omitempty"`
-	CreatedOn        jio.Time        `json:"created_date"`
-	ModifiedOn       jio.Time        `json:"modified_date"`
-	ThirdParty       map[string]any  `json:"third_party,omitempty"`
+	Version           int                   `json:"version"`
+	ID                tid.TID               `json:"id"`
+	TotalPoints       fxp.Int               `json:"total_points"`
+	PointsRecord      []*PointsRecord       `json:"points_record,omitempty"`
+	PointsJournal     []*PointsJournalEntry `json:"points_journal,omitempty"`
+	Profile           Profile               `json:"profile"`
+	SheetSettings     *SheetSettings        `json:"settings,omitempty"`
+	Attributes        *Attributes           `json:"attributes,omitempty"`
+	Traits            []*Trait              `json:"traits,alt=advantages,omitempty"`
+	Skills            []*Skill              `json:"skills,omitempty"`
+	Spells            []*Spell              `json:"spells,omitempty"`
+	Rituals           []*Ritual             `json:"rituals,omitempty"`
+	Languages         []*Language           `json:"languages,omitempty"`
+	Cultures          Cultures              `json:"cultures,omitempty"`
+	Funds             fxp.Int               `json:"funds,omitempty"`
+	Coins             map[string]int        `json:"coins,omitempty"`
+	PlayMode          bool                  `json:"play_mode,omitempty"`
+	ShockPenalty      int                   `json:"shock_penalty,omitempty"`
+	HighPainThreshold bool                  `json:"high_pain_threshold,omitempty"`
+	ControlPoints     int                   `json:"control_points,omitempty"`
+	ChangeRequests    []*ChangeRequest      `json:"change_requests,omitempty"`
+	TimeUse           TimeUsePlan           `json:"time_use,omitempty"`
+	CarriedEquipment  []*Equipment          `json:"equipment,omitempty"`
+	OtherEquipment    []*Equipment          `json:"other_equipment,omitempty"`
+	Loadouts          []*Loadout            `json:"loadouts,omitempty"`
+	ActiveLoadout     tid.TID               `json:"active_loadout,omitempty"`
+	Notes             []*Note               `json:"notes,omitempty"`
+	CreatedOn         jio.Time              `json:"created_date"`
+	ModifiedOn        jio.Time              `json:"modified_date"`
+	ThirdParty        map[string]any        `json:"third_party,omitempty"`
 }
 
 type features struct {
@@ -108,10 +123,13 @@ type Entity struct {
 	StrikingStrengthBonus           fxp.Int
 	ThrowingStrengthBonus           fxp.Int
 	DodgeBonus                      fxp.Int
+	DodgeBonusTooltip               string
 	ParryBonus                      fxp.Int
 	ParryBonusTooltip               string
 	BlockBonus                      fxp.Int
 	BlockBonusTooltip               string
+	parryReflexesBonus              fxp.Int
+	blockReflexesBonus              fxp.Int
 	features                        features
 	variableResolverExclusions      map[string]bool
 	skillResolverExclusions         map[string]bool
@@ -311,6 +329,9 @@ func (e *Entity) ensureAttachments() {
 	for _, one := range e.Spells {
 		one.SetDataOwner(e)
 	}
+	for _, one := range e.Languages {
+		one.SetDataOwner(e)
+	}
 	for _, one := range e.CarriedEquipment {
 		one.SetDataOwner(e)
 	}
@@ -338,8 +359,10 @@ func (e *Entity) processFeatures() {
 			e.processFeature(a, nil, f, levels)
 		}
 		Traverse(func(mod *TraitModifier) bool {
-			for _, f := range mod.Features {
-				e.processFeature(a, nil, f, mod.CurrentLevel())
+			if mod.FeaturesActive(e) {
+				for _, f := range mod.Features {
+					e.processFeature(a, nil, f, mod.CurrentLevel())
+				}
 			}
 			return false
 		}, true, true, a.Modifiers...)
@@ -352,22 +375,26 @@ func (e *Entity) processFeatures() {
 		return false
 	}, false, true, e.Skills...)
 	Traverse(func(eqp *Equipment) bool {
-		if !eqp.Equipped || eqp.Quantity <= 0 {
+		if eqp.Quantity <= 0 {
 			return false
 		}
-		for _, f := range eqp.Features {
-			e.processFeature(eqp, nil, f, eqp.Level.Max(0))
+		if eqp.Equipped {
+			for _, f := range eqp.Features {
+				e.processFeature(eqp, nil, f, eqp.Level.Max(0))
+			}
 		}
 		Traverse(func(mod *EquipmentModifier) bool {
-			for _, f := range mod.Features {
-				e.processFeature(eqp, mod, f, eqp.Level.Max(0))
+			if mod.FeaturesActive(eqp.Equipped) {
+				for _, f := range mod.Features {
+					e.processFeature(eqp, mod, f, eqp.Level.Max(0))
+				}
 			}
 			return false
 		}, true, true, eqp.Modifiers...)
 		return false
-	}, false, false, e.CarriedEquipment...)
-	e.LiftingStrengthBonus = e.AttributeBonusFor(StrengthID, stlimit.LiftingOnly, nil).Trunc()
-	e.StrikingStrengthBonus = e.AttributeBonusFor(StrengthID, stlimit.StrikingOnly, nil).Trunc()
+	}, false, false, e.ActiveCarriedEquipment()...)
+	e.LiftingStrengthBonus = e.strengthBonusFor(stlimit.LiftingOnly)
+	e.StrikingStrengthBonus = e.strengthBonusFor(stlimit.StrikingOnly)
 	e.ThrowingStrengthBonus = e.AttributeBonusFor(StrengthID, stlimit.ThrowingOnly, nil).Trunc()
 	for _, attr := range e.Attributes.Set {
 		if def := attr.AttributeDef(); def != nil {
@@ -382,16 +409,21 @@ func (e *Entity) processFeatures() {
 		}
 	}
 	e.Profile.Update(e)
+	var tooltip xio.ByteBuffer
 	if e.ResolveAttribute(DodgeID) == nil {
-		e.DodgeBonus = e.AttributeBonusFor(DodgeID, stlimit.None, nil).Trunc()
+		e.DodgeBonus, _ = e.defenseBonusFor(DodgeID, &tooltip)
+		e.DodgeBonus = e.DodgeBonus.Trunc()
 	} else {
 		e.DodgeBonus = 0
 	}
-	var tooltip xio.ByteBuffer
-	e.ParryBonus = e.AttributeBonusFor(ParryID, stlimit.None, &tooltip).Trunc()
+	e.DodgeBonusTooltip = tooltip.String()
+	tooltip.Reset()
+	e.ParryBonus, e.parryReflexesBonus = e.defenseBonusFor(ParryID, &tooltip)
+	e.ParryBonus = e.ParryBonus.Trunc()
 	e.ParryBonusTooltip = tooltip.String()
 	tooltip.Reset()
-	e.BlockBonus = e.AttributeBonusFor(BlockID, stlimit.None, &tooltip).Trunc()
+	e.BlockBonus, e.blockReflexesBonus = e.defenseBonusFor(BlockID, &tooltip)
+	e.BlockBonus = e.BlockBonus.Trunc()
 	e.BlockBonusTooltip = tooltip.String()
 }
 
@@ -425,6 +457,7 @@ func (e *Entity) processFeature(owner, subOwner fmt.Stringer, f Feature, levels
 									Type:           feature.DRBonus,
 									Locations:      slices.Clone(drBonus.Locations),
 									Specialization: actual.Specialization,
+									Flexible:       drBonus.Flexible,
 									LeveledAmount:  actual.LeveledAmount,
 								},
 							}
@@ -446,6 +479,7 @@ func (e *Entity) processFeature(owner, subOwner fmt.Stringer, f Feature, levels
 							Type:           feature.DRBonus,
 							Locations:      locations,
 							Specialization: actual.Specialization,
+							Flexible:       actual.Flexible,
 							LeveledAmount:  actual.LeveledAmount,
 						},
 					}
@@ -475,9 +509,23 @@ func (e *Entity) processFeature(owner, subOwner fmt.Stringer, f Feature, levels
 	}
 }
 
+// HasCinematicSkillTraining returns true if the character has an enabled trait that permits the use of cinematic
+// skills.
+func (e *Entity) HasCinematicSkillTraining() bool {
+	found := false
+	Traverse(func(t *Trait) bool {
+		name := t.NameWithReplacements()
+		found = strings.EqualFold(name, TrainedByAMasterTraitName) || strings.EqualFold(name, WeaponMasterTraitName)
+		return found
+	}, true, true, e.Traits...)
+	return found
+}
+
 func (e *Entity) processPrereqs() {
 	const prefix = "\n● "
 	notMetPrefix := i18n.Text("Prerequisites have not been met:")
+	specializationPrefix := i18n.Text("Specialization requirements have not been met:")
+	cinematicPrefix := i18n.Text("Cinematic training requirements have not been met:")
 	Traverse(func(a *Trait) bool {
 		a.UnsatisfiedReason = ""
 		if a.Prereq != nil {
@@ -517,6 +565,20 @@ func (e *Entity) processPrereqs() {
 			if !satisfied {
 				s.UnsatisfiedReason = notMetPrefix + tooltip.String()
 			}
+			tooltip.Reset()
+			if !s.CinematicTrainingSatisfied(&tooltip, prefix) {
+				if s.UnsatisfiedReason != "" {
+					s.UnsatisfiedReason += "\n"
+				}
+				s.UnsatisfiedReason += cinematicPrefix + tooltip.String()
+			}
+			tooltip.Reset()
+			if !s.SpecializationSatisfied(&tooltip, prefix) {
+				if s.UnsatisfiedReason != "" {
+					s.UnsatisfiedReason += "\n"
+				}
+				s.UnsatisfiedReason += specializationPrefix + tooltip.String()
+			}
 		}
 		return false
 	}, false, false, e.Skills...)
@@ -617,6 +679,15 @@ func (e *Entity) PointsBreakdown() *PointsBreakdown {
 		pb.Spells += s.Points
 		return false
 	}, false, true, e.Spells...)
+	for _, one := range e.Languages {
+		switch pts := one.AdjustedPoints(); {
+		case pts > 0:
+			pb.Advantages += pts
+		case pts < 0:
+			pb.Disadvantages += pts
+		}
+	}
+	pb.Advantages += e.Cultures.Points()
 	return &pb
 }
 
@@ -781,23 +852,38 @@ func (e *Entity) AddDRBonusesFor(locationID string, tooltip *xio.ByteBuffer, drM
 	if drMap == nil {
 		drMap = make(map[string]int)
 	}
-	isTopLevel := false
+	isTopLevel := e.isTopLevelLocation(locationID)
+	for _, one := range e.features.drBonuses {
+		if one.appliesTo(locationID, isTopLevel) {
+			drMap[strings.ToLower(one.Specialization)] += fxp.As[int](one.AdjustedAmount())
+			one.AddToTooltip(tooltip)
+		}
+	}
+	return drMap
+}
+
+func (e *Entity) isTopLevelLocation(locationID string) bool {
 	for _, one := range e.SheetSettings.BodyType.Locations {
 		if one.LocID == locationID {
-			isTopLevel = true
-			break
+			return true
 		}
 	}
+	return false
+}
+
+// DRLayersFor returns whether flexible and/or rigid armor is layered over the location.
+func (e *Entity) DRLayersFor(locationID string) (flexible, rigid bool) {
+	isTopLevel := e.isTopLevelLocation(locationID)
 	for _, one := range e.features.drBonuses {
-		for _, loc := range one.Locations {
-			if (loc == AllID && isTopLevel) || strings.EqualFold(loc, locationID) {
-				drMap[strings.ToLower(one.Specialization)] += fxp.As[int](one.AdjustedAmount())
-				one.AddToTooltip(tooltip)
-				break
+		if one.AdjustedAmount() > 0 && one.appliesTo(locationID, isTopLevel) {
+			if one.Flexible {
+				flexible = true
+			} else if _, isArmor := one.Owner().(*Equipment); isArmor {
+				rigid = true
 			}
 		}
 	}
-	return drMap
+	return flexible, rigid
 }
 
 // SkillBonusFor returns the total bonus for the matching skill bonuses.
@@ -874,7 +960,7 @@ func (e *Entity) SpellPointBonusFor(name, powerSource string, colleges, tags []s
 
 // AddWeaponWithSkillBonusesFor adds the bonuses for matching weapons that match to the map. If 'm' is nil, it will be
 // created. The provided map (or the newly created one) will be returned.
-func (e *Entity) AddWeaponWithSkillBonusesFor(name, specialization, usage string, tags []string, dieCount int, tooltip *xio.ByteBuffer, m map[*WeaponBonus]bool, allowedFeatureTypes map[feature.Type]bool) map[*WeaponBonus]bool {
+func (e *Entity) AddWeaponWithSkillBonusesFor(name, specialization, usage, damageType string, tags []string, dieCount int, tooltip *xio.ByteBuffer, m map[*WeaponBonus]bool, allowedFeatureTypes map[feature.Type]bool) map[*WeaponBonus]bool {
 	if m == nil {
 		m = make(map[*WeaponBonus]bool)
 	}
@@ -895,6 +981,7 @@ func (e *Entity) AddWeaponWithSkillBonusesFor(name, specialization, usage string
 			if bonus.NameCriteria.Matches(replacements, name) &&
 				bonus.SpecializationCriteria.Matches(replacements, specialization) &&
 				bonus.UsageCriteria.Matches(replacements, usage) &&
+				bonus.DamageTypeCriteria.Matches(replacements, damageType) &&
 				bonus.TagsCriteria.MatchesList(replacements, tags...) {
 				addWeaponBonusToMap(bonus, dieCount, tooltip, m)
 			}
@@ -905,7 +992,7 @@ func (e *Entity) AddWeaponWithSkillBonusesFor(name, specialization, usage string
 
 // AddNamedWeaponBonusesFor adds the bonuses for matching weapons that match to the map. If 'm' is nil, it will
 // be created. The provided map (or the newly created one) will be returned.
-func (e *Entity) AddNamedWeaponBonusesFor(nameQualifier, usageQualifier string, tagsQualifier []string, dieCount int, tooltip *xio.ByteBuffer, m map[*WeaponBonus]bool, allowedFeatureTypes map[feature.Type]bool) map[*WeaponBonus]bool {
+func (e *Entity) AddNamedWeaponBonusesFor(nameQualifier, usageQualifier, damageType string, tagsQualifier []string, dieCount int, tooltip *xio.ByteBuffer, m map[*WeaponBonus]bool, allowedFeatureTypes map[feature.Type]bool) map[*WeaponBonus]bool {
 	if m == nil {
 		m = make(map[*WeaponBonus]bool)
 	}
@@ -918,6 +1005,7 @@ func (e *Entity) AddNamedWeaponBonusesFor(nameQualifier, usageQualifier string,
 			}
 			if bonus.NameCriteria.Matches(replacements, nameQualifier) &&
 				bonus.SpecializationCriteria.Matches(replacements, usageQualifier) &&
+				bonus.DamageTypeCriteria.Matches(replacements, damageType) &&
 				bonus.TagsCriteria.MatchesList(replacements, tagsQualifier...) {
 				addWeaponBonusToMap(bonus, dieCount, tooltip, m)
 			}
@@ -992,7 +1080,7 @@ func (e *Entity) BestSkillNamed(name, specialization string, requirePoints bool,
 	return best
 }
 
-// SkillNamed returns a list of skills that match.
+// SkillNamed returns a list of skills that match, including any wildcard skills that cover the name.
 func (e *Entity) SkillNamed(name, specialization string, requirePoints bool, excludes map[string]bool) []*Skill {
 	var list []*Skill
 	Traverse(func(sk *Skill) bool {
@@ -1002,6 +1090,8 @@ func (e *Entity) SkillNamed(name, specialization string, requirePoints bool, exc
 					if specialization == "" || strings.EqualFold(sk.SpecializationWithReplacements(), specialization) {
 						list = append(list, sk)
 					}
+				} else if sk.CoversSkill(name) {
+					list = append(list, sk)
 				}
 			}
 		}
@@ -1054,13 +1144,13 @@ func (e *Entity) EncumbranceLevel(forSkills bool) encumbrance.Level {
 	return encumbrance.ExtraHeavy
 }
 
-// WeightCarried returns the carried weight.
+// WeightCarried returns the weight of the carried equipment in the active loadout.
 func (e *Entity) WeightCarried(forSkills bool) fxp.Weight {
 	var total fxp.Weight
-	for _, one := range e.CarriedEquipment {
+	for _, one := range e.ActiveCarriedEquipment() {
 		total += one.ExtendedWeight(forSkills, e.SheetSettings.DefaultWeightUnits)
 	}
-	return total
+	return total + e.CoinWeight()
 }
 
 // MaximumCarry returns the maximum amount the Entity can carry for the specified encumbrance level.
@@ -1197,6 +1287,11 @@ func (e *Entity) ResolveVariable(variableName string) string {
 	parts := strings.SplitN(variableName, ".", 2)
 	attr := e.Attributes.Set[parts[0]]
 	if attr == nil {
+		if st, ok := e.resolveStrengthVariable(variableName); ok {
+			result := st.String()
+			e.cachedVariables[variableName] = result
+			return result
+		}
 		if fxp.DebugVariableResolver {
 			errs.Log(errs.New("no such variable"), "name", "$"+variableName)
 		}
@@ -1296,6 +1391,19 @@ func (e *Entity) SetWeapons(_ bool, _ []*Weapon) {
 	// Not permitted
 }
 
+// HasEquippedWithTag returns true if any of the active carried equipment has the given tag and is equipped.
+func (e *Entity) HasEquippedWithTag(tag string) bool {
+	tag = strings.TrimSpace(tag)
+	found := false
+	Traverse(func(eqp *Equipment) bool {
+		found = eqp.Equipped && eqp.Quantity > 0 && slices.ContainsFunc(eqp.Tags, func(one string) bool {
+			return strings.EqualFold(one, tag)
+		})
+		return found
+	}, false, false, e.ActiveCarriedEquipment()...)
+	return found
+}
+
 // EquippedWeapons returns a sorted list of equipped weapons.
 func (e *Entity) EquippedWeapons(melee bool) []*Weapon {
 	m := make(map[uint64]*Weapon)
@@ -1316,7 +1424,7 @@ func (e *Entity) EquippedWeapons(melee bool) []*Weapon {
 			}
 		}
 		return false
-	}, false, false, e.CarriedEquipment...)
+	}, false, false, e.ActiveCarriedEquipment()...)
 	Traverse(func(s *Skill) bool {
 		for _, w := range s.Weapons {
 			if w.IsMelee() == melee {
@@ -1365,16 +1473,21 @@ func (e *Entity) Reactions() []*ConditionalModifier {
 		return false
 	}, true, false, e.Traits...)
 	Traverse(func(eqp *Equipment) bool {
-		if eqp.Equipped && eqp.Quantity > 0 {
-			source := i18n.Text("from equipment ") + eqp.NameWithReplacements()
+		if eqp.Quantity <= 0 {
+			return false
+		}
+		source := i18n.Text("from equipment ") + eqp.NameWithReplacements()
+		if eqp.Equipped {
 			e.reactionsFromFeatureList(source, eqp.Features, m)
-			Traverse(func(mod *EquipmentModifier) bool {
-				e.reactionsFromFeatureList(source, mod.Features, m)
-				return false
-			}, true, true, eqp.Modifiers...)
 		}
+		Traverse(func(mod *EquipmentModifier) bool {
+			if mod.FeaturesActive(eqp.Equipped) {
+				e.reactionsFromFeatureList(source, mod.Features, m)
+			}
+			return false
+		}, true, true, eqp.Modifiers...)
 		return false
-	}, false, false, e.CarriedEquipment...)
+	}, false, false, e.ActiveCarriedEquipment()...)
 	Traverse(func(sk *Skill) bool {
 		e.reactionsFromFeatureList(i18n.Text("from skill ")+sk.String(), sk.Features, m)
 		return false
@@ -1423,16 +1536,21 @@ func (e *Entity) ConditionalModifiers() []*ConditionalModifier {
 		return false
 	}, true, false, e.Traits...)
 	Traverse(func(eqp *Equipment) bool {
-		if eqp.Equipped && eqp.Quantity > 0 {
-			source := i18n.Text("from equipment ") + eqp.NameWithReplacements()
+		if eqp.Quantity <= 0 {
+			return false
+		}
+		source := i18n.Text("from equipment ") + eqp.NameWithReplacements()
+		if eqp.Equipped {
 			e.conditionalModifiersFromFeatureList(source, eqp.Features, m)
-			Traverse(func(mod *EquipmentModifier) bool {
-				e.conditionalModifiersFromFeatureList(source, mod.Features, m)
-				return false
-			}, true, true, eqp.Modifiers...)
 		}
+		Traverse(func(mod *EquipmentModifier) bool {
+			if mod.FeaturesActive(eqp.Equipped) {
+				e.conditionalModifiersFromFeatureList(source, mod.Features, m)
+			}
+			return false
+		}, true, true, eqp.Modifiers...)
 		return false
-	}, false, false, e.CarriedEquipment...)
+	}, false, false, e.ActiveCarriedEquipment()...)
 	Traverse(func(sk *Skill) bool {
 		e.conditionalModifiersFromFeatureList(i18n.Text("from skill ")+sk.String(), sk.Features, m)
 		return false
@@ -1531,6 +1649,19 @@ func (e *Entity) SetSpellList(list []*Spell) {
 	e.Spells = list
 }
 
+// LanguageList implements LanguageListProvider
+func (e *Entity) LanguageList() []*Language {
+	return e.Languages
+}
+
+// SetLanguageList implements LanguageListProvider
+func (e *Entity) SetLanguageList(list []*Language) {
+	for _, one := range list {
+		one.SetDataOwner(e)
+	}
+	e.Languages = list
+}
+
 // NoteList implements ListProvider
 func (e *Entity) NoteList() []*Note {
 	return e.Notes
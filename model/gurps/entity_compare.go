@@ -0,0 +1,192 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/diff"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// EntityDifference holds one difference found between two versions of a character.
+type EntityDifference struct {
+	Section string
+	Kind    diff.Kind
+	Name    string
+	Before  string
+	After   string
+}
+
+// CompareEntities returns the differences between two versions of a character. Items are matched by their ID first, so
+// that renamed items are reported as changed, and then by name, so that two different files can be compared as well.
+// Containers are not compared directly, but their contents are.
+func CompareEntities(before, after *Entity) []*EntityDifference {
+	var list []*EntityDifference
+	section := i18n.Text("General")
+	list = compareValues(list, section, i18n.Text("Name"), before.Profile.Name, after.Profile.Name)
+	list = compareValues(list, section, i18n.Text("Total Points"), before.TotalPoints.Comma(), after.TotalPoints.Comma())
+	list = compareValues(list, section, i18n.Text("Unspent Points"), before.UnspentPoints().Comma(),
+		after.UnspentPoints().Comma())
+	list = compareAttributes(list, before, after)
+	list = compareNodes(list, i18n.Text("Traits"), before.Traits, after.Traits,
+		func(t *Trait) string { return t.Description() },
+		func(t *Trait) string {
+			return fmt.Sprintf(i18n.Text("%s [%s pts]"), t.String(), t.AdjustedPoints().Comma())
+		})
+	list = compareNodes(list, i18n.Text("Skills"), before.Skills, after.Skills,
+		func(s *Skill) string { return s.String() },
+		func(s *Skill) string {
+			return fmt.Sprintf(i18n.Text("%s [%s pts]"), s.String(), s.AdjustedPoints(nil).Comma())
+		})
+	list = compareNodes(list, i18n.Text("Spells"), before.Spells, after.Spells,
+		func(s *Spell) string { return s.String() },
+		func(s *Spell) string {
+			return fmt.Sprintf(i18n.Text("%s [%s pts]"), s.String(), s.AdjustedPoints(nil).Comma())
+		})
+	list = compareNodes(list, i18n.Text("Carried Equipment"), before.CarriedEquipment, after.CarriedEquipment,
+		func(e *Equipment) string { return e.Description() }, equipmentCompareSummary)
+	return compareNodes(list, i18n.Text("Other Equipment"), before.OtherEquipment, after.OtherEquipment,
+		func(e *Equipment) string { return e.Description() }, equipmentCompareSummary)
+}
+
+func equipmentCompareSummary(e *Equipment) string {
+	summary := fmt.Sprintf("%s × %s", e.Quantity.Comma(), e.String())
+	if !e.Equipped {
+		summary += i18n.Text(" (not equipped)")
+	}
+	return summary
+}
+
+func compareValues(list []*EntityDifference, section, name, before, after string) []*EntityDifference {
+	if before != after {
+		list = append(list, &EntityDifference{
+			Section: section,
+			Kind:    diff.Changed,
+			Name:    name,
+			Before:  before,
+			After:   after,
+		})
+	}
+	return list
+}
+
+func compareAttributes(list []*EntityDifference, before, after *Entity) []*EntityDifference {
+	section := i18n.Text("Attributes")
+	beforeByID := make(map[string]*Attribute)
+	for _, attr := range before.Attributes.List() {
+		beforeByID[attr.AttrID] = attr
+	}
+	for _, attr := range after.Attributes.List() {
+		def := attr.AttributeDef()
+		if def == nil || def.IsSeparator() {
+			continue
+		}
+		if other, ok := beforeByID[attr.AttrID]; ok {
+			delete(beforeByID, attr.AttrID)
+			list = compareValues(list, section, def.CombinedName(), attributeCompareSummary(other),
+				attributeCompareSummary(attr))
+		} else {
+			list = append(list, &EntityDifference{
+				Section: section,
+				Kind:    diff.Added,
+				Name:    def.CombinedName(),
+				After:   attributeCompareSummary(attr),
+			})
+		}
+	}
+	for _, attr := range before.Attributes.List() {
+		if _, ok := beforeByID[attr.AttrID]; !ok {
+			continue
+		}
+		if def := attr.AttributeDef(); def != nil && !def.IsSeparator() {
+			list = append(list, &EntityDifference{
+				Section: section,
+				Kind:    diff.Removed,
+				Name:    def.CombinedName(),
+				Before:  attributeCompareSummary(attr),
+			})
+		}
+	}
+	return list
+}
+
+func attributeCompareSummary(attr *Attribute) string {
+	if def := attr.AttributeDef(); def != nil && def.Pool() {
+		return attr.Current().String() + "/" + attr.Maximum().String()
+	}
+	return attr.Maximum().String()
+}
+
+func compareNodes[T NodeTypes](list []*EntityDifference, section string, before, after []T, name, summary func(T) string) []*EntityDifference {
+	beforeList := flattenForCompare(before)
+	afterList := flattenForCompare(after)
+	matched := make(map[tid.TID]T)
+	used := make(map[tid.TID]bool)
+	byID := make(map[tid.TID]T, len(beforeList))
+	for _, one := range beforeList {
+		byID[AsNode(one).ID()] = one
+	}
+	for _, one := range afterList {
+		id := AsNode(one).ID()
+		if other, ok := byID[id]; ok {
+			matched[id] = other
+			used[id] = true
+		}
+	}
+	for _, one := range afterList {
+		id := AsNode(one).ID()
+		if _, ok := matched[id]; ok {
+			continue
+		}
+		key := name(one)
+		for _, other := range beforeList {
+			otherID := AsNode(other).ID()
+			if !used[otherID] && name(other) == key {
+				matched[id] = other
+				used[otherID] = true
+				break
+			}
+		}
+	}
+	for _, one := range afterList {
+		if other, ok := matched[AsNode(one).ID()]; ok {
+			list = compareValues(list, section, name(one), summary(other), summary(one))
+		} else {
+			list = append(list, &EntityDifference{
+				Section: section,
+				Kind:    diff.Added,
+				Name:    name(one),
+				After:   summary(one),
+			})
+		}
+	}
+	for _, one := range beforeList {
+		if !used[AsNode(one).ID()] {
+			list = append(list, &EntityDifference{
+				Section: section,
+				Kind:    diff.Removed,
+				Name:    name(one),
+				Before:  summary(one),
+			})
+		}
+	}
+	return list
+}
+
+func flattenForCompare[T NodeTypes](in []T) []T {
+	var list []T
+	Traverse(func(one T) bool {
+		list = append(list, one)
+		return false
+	}, false, true, in...)
+	return list
+}
@@ -0,0 +1,67 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/diff"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestCompareEntities(t *testing.T) {
+	before := NewEntity()
+	skill := NewSkill(before, nil, false)
+	skill.Name = "Broadsword"
+	skill.Points = fxp.Two
+	before.SetSkillList([]*Skill{skill})
+	rope := NewEquipment(before, nil, false)
+	rope.Name = "Rope"
+	before.SetCarriedEquipmentList([]*Equipment{rope})
+
+	data, err := json.Marshal(before)
+	check.NoError(t, err)
+	var after Entity
+	check.NoError(t, json.Unmarshal(data, &after))
+	check.Equal(t, 0, len(CompareEntities(before, &after)), "an unchanged copy has no differences")
+
+	after.Skills[0].Points = fxp.Four
+	trait := NewTrait(&after, nil, false)
+	trait.Name = "Fearlessness"
+	after.SetTraitList(append(after.Traits, trait))
+	after.SetCarriedEquipmentList(nil)
+	after.Recalculate()
+
+	diffs := CompareEntities(before, &after)
+	check.Equal(t, 4, len(diffs))
+	check.Equal(t, "Unspent Points", diffs[0].Name)
+	check.Equal(t, diff.Changed, diffs[0].Kind)
+	check.Equal(t, "Fearlessness", diffs[1].Name)
+	check.Equal(t, diff.Added, diffs[1].Kind)
+	check.Equal(t, "", diffs[1].Before)
+	check.Equal(t, "Broadsword", diffs[2].Name)
+	check.Equal(t, diff.Changed, diffs[2].Kind)
+	check.NotEqual(t, diffs[2].Before, diffs[2].After)
+	check.Equal(t, "Rope", diffs[3].Name)
+	check.Equal(t, diff.Removed, diffs[3].Kind)
+	check.Equal(t, "", diffs[3].After)
+
+	other := NewEntity()
+	otherSkill := NewSkill(other, nil, false)
+	otherSkill.Name = "Broadsword"
+	otherSkill.Points = fxp.Two
+	other.SetSkillList([]*Skill{otherSkill})
+	other.Recalculate()
+	for _, one := range CompareEntities(before, other) {
+		check.NotEqual(t, "Broadsword", one.Name, "skills in different files are matched by name")
+	}
+}
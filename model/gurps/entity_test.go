@@ -55,3 +55,98 @@ func TestEntityAttributeBonus(t *testing.T) {
 	check.Equal(t, fxp.Ten, e.Attributes.Current("st"), "ST; leveled +1 bonus, with 3 levels, for throwing only")
 	check.Equal(t, fxp.Three, e.ThrowingStrengthBonus, "Throwing ST Bonus; leveled +1 bonus, with 3 levels, for throwing only")
 }
+
+func TestCampaignLimits(t *testing.T) {
+	e := NewEntity()
+	e.SheetSettings.DisadvantageLimit = fxp.From(20)
+	e.SheetSettings.QuirkLimit = fxp.From(2)
+	e.SheetSettings.SkillPointLimit = fxp.Four
+	newTrait := func(points int) *Trait {
+		trait := NewTrait(e, nil, false)
+		trait.BasePoints = fxp.From(points)
+		return trait
+	}
+	e.SetTraitList([]*Trait{newTrait(-15), newTrait(-1), newTrait(-1)})
+	skill := NewSkill(e, nil, false)
+	skill.Name = "Stealth"
+	skill.Points = fxp.Four
+	e.SetSkillList([]*Skill{skill})
+	e.Recalculate()
+	check.False(t, e.DisadvantagesExceedLimit())
+	check.False(t, e.QuirksExceedLimit())
+	check.Equal(t, 0, len(e.SkillsExceedingPointLimit()))
+	check.Equal(t, 0, len(e.CampaignLimitViolations()))
+
+	e.SetTraitList(append(e.TraitList(), newTrait(-10), newTrait(-1)))
+	skill.Points = fxp.Eight
+	e.Recalculate()
+	check.True(t, e.DisadvantagesExceedLimit())
+	check.True(t, e.QuirksExceedLimit())
+	check.Equal(t, []string{"Stealth"}, e.SkillsExceedingPointLimit())
+	check.Equal(t, 3, len(e.CampaignLimitViolations()))
+
+	e.SheetSettings.DisadvantageLimit = 0
+	e.SheetSettings.QuirkLimit = 0
+	e.SheetSettings.SkillPointLimit = 0
+	check.Equal(t, 0, len(e.CampaignLimitViolations()), "a limit of zero means no limit")
+}
+
+func TestPointBudgets(t *testing.T) {
+	budgets, valid := NewPointBudgetsFromString("Attributes <= 20\n\nskills >= 10\nskills <= 30\ndisadvantages ≤ 15\n")
+	check.True(t, valid)
+	check.Equal(t, 3, len(budgets))
+	check.Equal(t, "attributes <= 20\nskills <= 30\nskills >= 10\ndisadvantages <= 15\n", PointBudgetsToString(budgets))
+	_, valid = NewPointBudgetsFromString("wealth <= 10\nskills = 5")
+	check.False(t, valid, "unknown category and missing comparison")
+
+	e := NewEntity()
+	e.SheetSettings.PointBudgets = budgets
+	check.Nil(t, e.PointBudgetStatus(AdvantagesBudgetCategory), "no budget")
+	status := e.PointBudgetStatus(SkillsBudgetCategory)
+	check.NotNil(t, status)
+	check.True(t, status.Short())
+	check.Equal(t, fxp.From(30), status.Remaining)
+	check.Equal(t, 1, len(e.PointBudgetViolations()))
+
+	skill := NewSkill(e, nil, false)
+	skill.Points = fxp.Twelve
+	e.SetSkillList([]*Skill{skill})
+	trait := NewTrait(e, nil, false)
+	trait.BasePoints = fxp.From(-20)
+	e.SetTraitList([]*Trait{trait})
+	e.Recalculate()
+	status = e.PointBudgetStatus(SkillsBudgetCategory)
+	check.False(t, status.Violated())
+	check.Equal(t, fxp.From(18), status.Remaining)
+	status = e.PointBudgetStatus(DisadvantagesBudgetCategory)
+	check.True(t, status.Exceeded())
+	check.Equal(t, fxp.From(20), status.Spent, "disadvantages are compared as positive values")
+	check.Equal(t, []string{"Disadvantages total 20 points, exceeding the budget of 15"}, e.CampaignLimitViolations())
+}
+
+func TestLiftingAndStrikingST(t *testing.T) {
+	e := NewEntity()
+	lifting := NewTrait(e, nil, false)
+	lifting.Name = LiftingSTTraitName
+	lifting.CanLevel = true
+	lifting.Levels = fxp.Five
+	striking := NewTrait(e, nil, false)
+	striking.Name = StrikingSTTraitName
+	striking.CanLevel = true
+	striking.Levels = fxp.Two
+	bonus := NewAttributeBonus(StrengthID)
+	bonus.Limitation = stlimit.StrikingOnly
+	bonus.Amount = fxp.One
+	bonus.PerLevel = true
+	striking.Features = Features{bonus}
+	e.SetTraitList([]*Trait{lifting, striking})
+	e.Recalculate()
+	check.Equal(t, fxp.Five, e.LiftingStrengthBonus, "levels of Lifting ST feed lifting ST automatically")
+	check.Equal(t, fxp.Two, e.StrikingStrengthBonus, "an explicit bonus isn't counted twice")
+	check.Equal(t, fxp.From(15), e.LiftingStrength())
+	check.Equal(t, fxp.Twelve, e.StrikingStrength())
+	check.Equal(t, fxp.WeightFromInteger(45, fxp.Pound), e.BasicLift(), "Basic Lift uses lifting ST")
+	check.Equal(t, e.SwingFor(12), e.Swing(), "damage uses striking ST")
+	check.Equal(t, "15", e.ResolveVariable(LiftingStrengthID))
+	check.Equal(t, "12", e.ResolveVariable(StrikingStrengthID))
+}
@@ -0,0 +1,29 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package comprehension
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+)
+
+// PointCost returns the point cost of this level of comprehension for a single component (spoken or written) of a
+// language.
+func (enum Level) PointCost() fxp.Int {
+	switch enum {
+	case Broken:
+		return fxp.One
+	case Accented:
+		return fxp.Two
+	case Native:
+		return fxp.Three
+	default:
+		return 0
+	}
+}
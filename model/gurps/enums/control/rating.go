@@ -0,0 +1,16 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package control
+
+// Permits returns true if an item of the given Legality Class is legal in a society with this Control Rating. Items
+// with a Legality Class below the Control Rating are illegal.
+func (enum Rating) Permits(legalityClass int) bool {
+	return legalityClass >= int(enum)
+}
@@ -0,0 +1,19 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package effort
+
+// FatigueCost returns the FP cost of the extra effort. attacks is the number of attacks being made, which is only used
+// by Flurry of Blows.
+func (enum Option) FatigueCost(attacks int) int {
+	if enum.EnsureValid() == FlurryOfBlows {
+		return max(attacks, 1)
+	}
+	return 1
+}
@@ -22,16 +22,18 @@ const (
 	NotApplicable Type = iota
 	Count
 	Points
+	Optional
 )
 
 // LastType is the last valid value.
-const LastType Type = Points
+const LastType Type = Optional
 
 // Types holds all possible values.
 var Types = []Type{
 	NotApplicable,
 	Count,
 	Points,
+	Optional,
 }
 
 // Type holds the type of template picker.
@@ -39,7 +41,7 @@ type Type byte
 
 // EnsureValid ensures this is of a known value.
 func (enum Type) EnsureValid() Type {
-	if enum <= Points {
+	if enum <= Optional {
 		return enum
 	}
 	return 0
@@ -54,6 +56,8 @@ func (enum Type) Key() string {
 		return "count"
 	case Points:
 		return "points"
+	case Optional:
+		return "optional"
 	default:
 		return Type(0).Key()
 	}
@@ -68,6 +72,8 @@ func (enum Type) String() string {
 		return i18n.Text("Count")
 	case Points:
 		return i18n.Text("Points")
+	case Optional:
+		return i18n.Text("Optional")
 	default:
 		return Type(0).String()
 	}
@@ -0,0 +1,34 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package reputation
+
+// Multiplier returns the cost multiplier for this frequency of recognition as a fraction.
+func (enum Recognition) Multiplier() (numerator, denominator int) {
+	switch enum {
+	case Sometimes:
+		return 1, 2
+	case Occasionally:
+		return 1, 3
+	default:
+		return 1, 1
+	}
+}
+
+// Roll returns the roll needed for the reputation to be recognized, or 0 if it is always recognized.
+func (enum Recognition) Roll() int {
+	switch enum {
+	case Sometimes:
+		return 10
+	case Occasionally:
+		return 7
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package shipsys
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+)
+
+// Acceleration returns the acceleration, in G, this system provides.
+func (enum System) Acceleration() fxp.Int {
+	switch enum {
+	case ChemicalRocket, HEDMRocket:
+		return fxp.Three
+	case NuclearThermalRocket, HotReactionless:
+		return fxp.Two
+	case RotaryReactionless:
+		return fxp.Tenth
+	case StandardReactionless:
+		return fxp.One
+	case SuperReactionless:
+		return fxp.Ten
+	default:
+		return 0
+	}
+}
+
+// DeltaVPerFuelTank returns the delta-V, in miles per second, each fuel tank provides to this system. Only rocket
+// engines consume fuel, so this is zero for everything else.
+func (enum System) DeltaVPerFuelTank() fxp.Int {
+	switch enum {
+	case ChemicalRocket:
+		return fxp.PointOneFive
+	case HEDMRocket:
+		return fxp.ThreeTenths
+	case NuclearThermalRocket:
+		return fxp.Half
+	default:
+		return 0
+	}
+}
+
+// IsRocket returns true if this is a rocket engine, which consumes fuel.
+func (enum System) IsRocket() bool {
+	return enum.DeltaVPerFuelTank() > 0
+}
+
+// IsReactionless returns true if this is a reactionless engine, which has no delta-V limit.
+func (enum System) IsReactionless() bool {
+	switch enum {
+	case RotaryReactionless, StandardReactionless, HotReactionless, SuperReactionless:
+		return true
+	default:
+		return false
+	}
+}
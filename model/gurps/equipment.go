@@ -42,6 +42,7 @@ var (
 	_ Node[*Equipment]              = &Equipment{}
 	_ TechLevelProvider[*Equipment] = &Equipment{}
 	_ EditorData[*Equipment]        = &EquipmentEditData{}
+	_ TemplatePickerProvider        = &Equipment{}
 )
 
 // Columns that can be used with the equipment method .CellData()
@@ -80,32 +81,34 @@ type EquipmentData struct {
 // EquipmentEditData holds the Equipment data that can be edited by the UI detail editor.
 type EquipmentEditData struct {
 	EquipmentSyncData
-	VTTNotes     string               `json:"vtt_notes,omitempty"`
-	Replacements map[string]string    `json:"replacements,omitempty"`
-	Modifiers    []*EquipmentModifier `json:"modifiers,omitempty"`
-	RatedST      fxp.Int              `json:"rated_strength,omitempty"`
-	Quantity     fxp.Int              `json:"quantity,omitempty"`
-	Level        fxp.Int              `json:"level,omitempty"`
-	Uses         int                  `json:"uses,omitempty"`
-	Equipped     bool                 `json:"equipped,omitempty"`
+	VTTNotes       string               `json:"vtt_notes,omitempty"`
+	Replacements   map[string]string    `json:"replacements,omitempty"`
+	Modifiers      []*EquipmentModifier `json:"modifiers,omitempty"`
+	RatedST        fxp.Int              `json:"rated_strength,omitempty"`
+	Quantity       fxp.Int              `json:"quantity,omitempty"`
+	Level          fxp.Int              `json:"level,omitempty"`
+	Uses           int                  `json:"uses,omitempty"`
+	Equipped       bool                 `json:"equipped,omitempty"`
+	TemplatePicker *TemplatePicker      `json:"template_picker,omitempty"`
 }
 
 // EquipmentSyncData holds the equipment sync data that is common to both containers and non-containers.
 type EquipmentSyncData struct {
-	Name                   string      `json:"description,omitempty"`
-	PageRef                string      `json:"reference,omitempty"`
-	PageRefHighlight       string      `json:"reference_highlight,omitempty"`
-	LocalNotes             string      `json:"notes,omitempty"`
-	TechLevel              string      `json:"tech_level,omitempty"`
-	LegalityClass          string      `json:"legality_class,omitempty"`
-	Tags                   []string    `json:"tags,omitempty"`
-	Value                  fxp.Int     `json:"value,omitempty"`
-	Weight                 fxp.Weight  `json:"weight,omitempty"`
-	MaxUses                int         `json:"max_uses,omitempty"`
-	Prereq                 *PrereqList `json:"prereqs,omitempty"`
-	Weapons                []*Weapon   `json:"weapons,omitempty"`
-	Features               Features    `json:"features,omitempty"`
-	WeightIgnoredForSkills bool        `json:"ignore_weight_for_skills,omitempty"`
+	Name                   string       `json:"description,omitempty"`
+	PageRef                string       `json:"reference,omitempty"`
+	PageRefHighlight       string       `json:"reference_highlight,omitempty"`
+	LocalNotes             string       `json:"notes,omitempty"`
+	Translations           Translations `json:"translations,omitempty"`
+	TechLevel              string       `json:"tech_level,omitempty"`
+	LegalityClass          string       `json:"legality_class,omitempty"`
+	Tags                   []string     `json:"tags,omitempty"`
+	Value                  fxp.Int      `json:"value,omitempty"`
+	Weight                 fxp.Weight   `json:"weight,omitempty"`
+	MaxUses                int          `json:"max_uses,omitempty"`
+	Prereq                 *PrereqList  `json:"prereqs,omitempty"`
+	Weapons                []*Weapon    `json:"weapons,omitempty"`
+	Features               Features     `json:"features,omitempty"`
+	WeightIgnoredForSkills bool         `json:"ignore_weight_for_skills,omitempty"`
 }
 
 type equipmentListData struct {
@@ -143,6 +146,9 @@ func NewEquipment(owner DataOwner, parent *Equipment, container bool) *Equipment
 	e.Equipped = true
 	e.parent = parent
 	e.owner = owner
+	if container {
+		e.TemplatePicker = &TemplatePicker{}
+	}
 	e.SetOpen(container)
 	return &e
 }
@@ -179,6 +185,11 @@ func (e *Equipment) SetChildren(children []*Equipment) {
 	e.Children = children
 }
 
+// TemplatePickerData returns the TemplatePicker data, if any.
+func (e *Equipment) TemplatePickerData() *TemplatePicker {
+	return e.TemplatePicker
+}
+
 // Parent returns the parent.
 func (e *Equipment) Parent() *Equipment {
 	return e.parent
@@ -377,6 +388,10 @@ func (e *Equipment) CellData(columnID int, data *CellData) {
 		data.Secondary = e.SecondaryText(func(option display.Option) bool { return option.Inline() })
 		data.UnsatisfiedReason = e.UnsatisfiedReason
 		data.Tooltip = e.SecondaryText(func(option display.Option) bool { return option.Tooltip() })
+		data.TemplateInfo = e.TemplatePicker.Description()
+		if e.IsIllegal() {
+			data.InlineTag = i18n.Text("Illegal")
+		}
 	case EquipmentUsesColumn:
 		if e.MaxUses > 0 {
 			data.Type = cell.Text
@@ -392,14 +407,20 @@ func (e *Equipment) CellData(columnID int, data *CellData) {
 		data.Type = cell.Text
 		data.Primary = e.LegalityClass
 		data.Alignment = align.End
+		if e.IsIllegal() {
+			data.Tooltip = fmt.Sprintf(i18n.Text("Illegal under %s"),
+				SheetSettingsFor(EntityFromNode(e)).ControlRating.String())
+		}
 	case EquipmentCostColumn:
 		data.Type = cell.Text
 		data.Primary = e.AdjustedValue().Comma()
 		data.Alignment = align.End
+		data.Tooltip = SheetSettingsFor(EntityFromNode(e)).CurrencyConversions(e.AdjustedValue())
 	case EquipmentExtendedCostColumn:
 		data.Type = cell.Text
 		data.Primary = e.ExtendedValue().Comma()
 		data.Alignment = align.End
+		data.Tooltip = SheetSettingsFor(EntityFromNode(e)).CurrencyConversions(e.ExtendedValue())
 	case EquipmentWeightColumn:
 		data.Type = cell.Text
 		units := SheetSettingsFor(EntityFromNode(e)).DefaultWeightUnits
@@ -483,7 +504,7 @@ func (e *Equipment) CurrentLevel() fxp.Int {
 
 // Description returns a description, which doesn't include any levels.
 func (e *Equipment) Description() string {
-	return e.NameWithReplacements()
+	return e.LocalizedNameWithReplacements()
 }
 
 // SecondaryText returns the "secondary" text: the text display below the description.
@@ -637,9 +658,16 @@ func (e *Equipment) NameWithReplacements() string {
 	return nameable.Apply(e.Name, e.Replacements)
 }
 
-// LocalNotesWithReplacements returns the local notes with any replacements applied.
+// LocalizedNameWithReplacements returns the name in the user's language, if a translation is available, with any
+// replacements applied. Use NameWithReplacements() when matching against the name.
+func (e *Equipment) LocalizedNameWithReplacements() string {
+	return nameable.Apply(e.Translations.Name(e.Name), e.Replacements)
+}
+
+// LocalNotesWithReplacements returns the local notes, in the user's language if a translation is available, with any
+// replacements applied.
 func (e *Equipment) LocalNotesWithReplacements() string {
-	return nameable.Apply(e.LocalNotes, e.Replacements)
+	return nameable.Apply(e.Translations.Notes(e.LocalNotes), e.Replacements)
 }
 
 // FillWithNameableKeys adds any nameable keys found to the provided map.
@@ -698,6 +726,21 @@ func (e *Equipment) DisplayLegalityClass() string {
 	}
 }
 
+// LegalityClassLevel returns the numeric Legality Class, if one is set.
+func (e *Equipment) LegalityClassLevel() (lc int, ok bool) {
+	str := strings.TrimSpace(e.LegalityClass)
+	if str == "" || str[0] < '0' || str[0] > '9' {
+		return 0, false
+	}
+	return int(str[0] - '0'), true
+}
+
+// IsIllegal returns true if the Legality Class of this equipment is below the Control Rating in effect.
+func (e *Equipment) IsIllegal() bool {
+	lc, ok := e.LegalityClassLevel()
+	return ok && !SheetSettingsFor(EntityFromNode(e)).ControlRating.Permits(lc)
+}
+
 // ActiveModifierFor returns the first modifier that matches the name (case-insensitive).
 func (e *Equipment) ActiveModifierFor(name string) *EquipmentModifier {
 	var found *EquipmentModifier
@@ -769,8 +812,13 @@ func (e *Equipment) Kind() string {
 
 // ClearUnusedFieldsForType zeroes out the fields that are not applicable to this type (container vs not-container).
 func (e *Equipment) ClearUnusedFieldsForType() {
-	if !e.Container() {
+	if e.Container() {
+		if e.TemplatePicker == nil {
+			e.TemplatePicker = &TemplatePicker{}
+		}
+	} else {
 		e.Children = nil
+		e.TemplatePicker = nil
 	}
 }
 
@@ -810,6 +858,7 @@ func (e *EquipmentSyncData) hash(h hash.Hash) {
 	hashhelper.String(h, e.PageRef)
 	hashhelper.String(h, e.PageRefHighlight)
 	hashhelper.String(h, e.LocalNotes)
+	e.Translations.hash(h)
 	hashhelper.String(h, e.TechLevel)
 	hashhelper.String(h, e.LegalityClass)
 	hashhelper.Num64(h, len(e.Tags))
@@ -845,6 +894,7 @@ func (e *EquipmentEditData) copyFrom(owner DataOwner, other *EquipmentEditData,
 	*e = *other
 	e.Tags = txt.CloneStringSlice(other.Tags)
 	e.Replacements = maps.Clone(other.Replacements)
+	e.Translations = maps.Clone(other.Translations)
 	e.Modifiers = nil
 	if len(other.Modifiers) != 0 {
 		e.Modifiers = make([]*EquipmentModifier, 0, len(other.Modifiers))
@@ -855,4 +905,5 @@ func (e *EquipmentEditData) copyFrom(owner DataOwner, other *EquipmentEditData,
 	e.Prereq = e.Prereq.CloneResolvingEmpty(false, isApply)
 	e.Weapons = CloneWeapons(other.Weapons, isApply)
 	e.Features = other.Features.Clone()
+	e.TemplatePicker = e.TemplatePicker.Clone()
 }
@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/activation"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/cell"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/display"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/emcost"
@@ -87,11 +88,12 @@ type EquipmentModifierEditDataNonContainerOnly struct {
 
 // EquipmentModifierSyncData holds the EquipmentModifier sync data that is common to both containers and non-containers.
 type EquipmentModifierSyncData struct {
-	Name             string   `json:"name,omitempty"`
-	PageRef          string   `json:"reference,omitempty"`
-	PageRefHighlight string   `json:"reference_highlight,omitempty"`
-	LocalNotes       string   `json:"notes,omitempty"`
-	Tags             []string `json:"tags,omitempty"`
+	Name             string       `json:"name,omitempty"`
+	PageRef          string       `json:"reference,omitempty"`
+	PageRefHighlight string       `json:"reference_highlight,omitempty"`
+	LocalNotes       string       `json:"notes,omitempty"`
+	Translations     Translations `json:"translations,omitempty"`
+	Tags             []string     `json:"tags,omitempty"`
 }
 
 // EquipmentModifierNonContainerSyncData holds the EquipmentModifier sync data that is only applicable to Equipment
@@ -105,6 +107,8 @@ type EquipmentModifierNonContainerSyncData struct {
 	CostAmount       string        `json:"cost,omitempty"`
 	WeightAmount     string        `json:"weight,omitempty"`
 	Features         Features      `json:"features,omitempty"`
+	// Activation controls whether the features are active based on the equipped state of the equipment
+	Activation activation.Condition `json:"activation,omitempty"`
 }
 
 type equipmentModifierListData struct {
@@ -311,7 +315,7 @@ func (e *EquipmentModifier) CellData(columnID int, data *CellData) {
 		}
 	case EquipmentModifierDescriptionColumn:
 		data.Type = cell.Text
-		data.Primary = e.NameWithReplacements()
+		data.Primary = e.LocalizedNameWithReplacements()
 		data.Secondary = e.SecondaryText(func(option display.Option) bool { return option.Inline() })
 		data.Tooltip = e.SecondaryText(func(option display.Option) bool { return option.Tooltip() })
 	case EquipmentModifierTechLevelColumn:
@@ -386,7 +390,7 @@ func (e *EquipmentModifier) SetDataOwner(owner DataOwner) {
 }
 
 func (e *EquipmentModifier) String() string {
-	return e.NameWithReplacements()
+	return e.LocalizedNameWithReplacements()
 }
 
 func (e *EquipmentModifier) resolveLocalNotes() string {
@@ -474,9 +478,16 @@ func (e *EquipmentModifier) NameWithReplacements() string {
 	return nameable.Apply(e.Name, e.Replacements)
 }
 
-// LocalNotesWithReplacements returns the local notes with any replacements applied.
+// LocalizedNameWithReplacements returns the name in the user's language, if a translation is available, with any
+// replacements applied. Use NameWithReplacements() when matching against the name.
+func (e *EquipmentModifier) LocalizedNameWithReplacements() string {
+	return nameable.Apply(e.Translations.Name(e.Name), e.Replacements)
+}
+
+// LocalNotesWithReplacements returns the local notes, in the user's language if a translation is available, with any
+// replacements applied.
 func (e *EquipmentModifier) LocalNotesWithReplacements() string {
-	return nameable.Apply(e.LocalNotes, e.Replacements)
+	return nameable.Apply(e.Translations.Notes(e.LocalNotes), e.Replacements)
 }
 
 // FillWithNameableKeys adds any nameable keys found in this EquipmentModifier to the provided map.
@@ -653,6 +664,17 @@ func processMultiplyAddWeightStep(equipment *Equipment, weightType emweight.Type
 	return weight + sum
 }
 
+// FeaturesActive returns true if this modifier's features should be applied, given the equipped state of the equipment
+// it is attached to.
+func (e *EquipmentModifier) FeaturesActive(equipped bool) bool {
+	switch e.Activation {
+	case activation.WhileNotEquipped:
+		return !equipped
+	default:
+		return equipped
+	}
+}
+
 // Kind returns the kind of data.
 func (e *EquipmentModifier) Kind() string {
 	if e.Container() {
@@ -710,6 +732,7 @@ func (e *EquipmentModifierSyncData) hash(h hash.Hash) {
 	hashhelper.String(h, e.PageRef)
 	hashhelper.String(h, e.PageRefHighlight)
 	hashhelper.String(h, e.LocalNotes)
+	e.Translations.hash(h)
 	hashhelper.Num64(h, len(e.Tags))
 	for _, tag := range e.Tags {
 		hashhelper.String(h, tag)
@@ -726,6 +749,7 @@ func (e *EquipmentModifierNonContainerSyncData) hash(h hash.Hash) {
 	for _, feature := range e.Features {
 		feature.Hash(h)
 	}
+	hashhelper.Num8(h, e.Activation)
 }
 
 // CopyFrom implements node.EditorData.
@@ -742,5 +766,6 @@ func (e *EquipmentModifierEditData) copyFrom(other *EquipmentModifierEditData) {
 	*e = *other
 	e.Tags = txt.CloneStringSlice(other.Tags)
 	e.Replacements = maps.Clone(other.Replacements)
+	e.Translations = maps.Clone(other.Translations)
 	e.Features = other.Features.Clone()
 }
@@ -0,0 +1,111 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/control"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/feature"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestEquipmentLegality(t *testing.T) {
+	e := NewEntity()
+	eqp := NewEquipment(e, nil, false)
+	eqp.LegalityClass = "2"
+	check.False(t, eqp.IsIllegal(), "CR0 permits everything")
+	e.SheetSettings.ControlRating = control.CR2
+	check.False(t, eqp.IsIllegal(), "LC2 is legal at CR2")
+	e.SheetSettings.ControlRating = control.CR3
+	check.True(t, eqp.IsIllegal(), "LC2 is illegal at CR3")
+	eqp.LegalityClass = ""
+	check.False(t, eqp.IsIllegal(), "no LC is never flagged")
+}
+
+func TestQualityModifiers(t *testing.T) {
+	e := NewEntity()
+	armor := NewEquipment(e, nil, false)
+	armor.Value = fxp.Hundred
+	armor.Equipped = true
+	armor.Features = Features{NewDRBonus()}
+	mods := NewQualityModifiers(e)
+	armor.Modifiers = []*EquipmentModifier{mods}
+	e.SetCarriedEquipmentList([]*Equipment{armor})
+	e.Recalculate()
+	baseDR := e.AddDRBonusesFor(TorsoID, nil, nil)[AllID]
+	check.Equal(t, fxp.Hundred, armor.AdjustedValue(), "all modifiers start disabled")
+
+	for _, one := range mods.Children {
+		if one.Name == "Reinforced Armor" {
+			one.Disabled = false
+		}
+	}
+	e.Recalculate()
+	check.Equal(t, fxp.From(200), armor.AdjustedValue(), "reinforced armor cost factor")
+	check.Equal(t, baseDR+1, e.AddDRBonusesFor(TorsoID, nil, nil)[AllID], "reinforced armor DR")
+
+	sword := NewEquipment(e, nil, false)
+	weapon := NewWeapon(sword, true)
+	sword.Weapons = []*Weapon{weapon}
+	swordMods := NewQualityModifiers(e)
+	sword.Modifiers = []*EquipmentModifier{swordMods}
+	for _, one := range swordMods.Children {
+		if one.Name == "Fine" {
+			one.Disabled = false
+		}
+	}
+	weapon.Damage.Type = "cr"
+	check.Equal(t, 0, len(weapon.collectWeaponBonuses(1, nil, feature.WeaponBonus)), "fine has no crushing bonus")
+	weapon.Damage.Type = "cut"
+	check.Equal(t, 1, len(weapon.collectWeaponBonuses(1, nil, feature.WeaponBonus)), "fine cutting bonus")
+	weapon.Damage.Type = "imp"
+	check.Equal(t, 1, len(weapon.collectWeaponBonuses(1, nil, feature.WeaponBonus)), "fine impaling bonus")
+}
+
+func TestLayeredDR(t *testing.T) {
+	e := NewEntity()
+	newArmor := func(dr int, flexible bool) *Equipment {
+		armor := NewEquipment(e, nil, false)
+		armor.Equipped = true
+		bonus := NewDRBonus()
+		bonus.Amount = fxp.From(dr)
+		bonus.Flexible = flexible
+		armor.Features = Features{bonus}
+		return armor
+	}
+	vest := newArmor(12, true)
+	split := NewDRBonus()
+	split.Specialization = "crushing"
+	split.Amount = fxp.From(-7)
+	split.Flexible = true
+	vest.Features = append(vest.Features, split)
+	e.SetCarriedEquipmentList([]*Equipment{vest})
+	e.Recalculate()
+	torso := e.SheetSettings.BodyType.LookupLocationByID(e, TorsoID)
+	check.Equal(t, "12/5*", torso.DisplayDR(e, nil), "flexible armor with split DR")
+	check.Equal(t, 5, torso.DRAgainst(e, "Crushing"))
+	check.Equal(t, 12, torso.DRAgainst(e, "cutting"))
+
+	e.SetCarriedEquipmentList([]*Equipment{vest, newArmor(5, false)})
+	e.Recalculate()
+	check.Equal(t, "17/10", torso.DisplayDR(e, nil), "rigid armor layered over flexible armor")
+	check.False(t, torso.IsFlexible(e))
+
+	drSplit, ok := ParseDRSplit("Burning: +2, crushing: -1")
+	check.True(t, ok)
+	torso.DRSplit = drSplit
+	check.Equal(t, "burning: +2, crushing: -1", torso.DRSplitString())
+	check.Equal(t, 19, torso.DRAgainst(e, "burning"))
+	check.Equal(t, 9, torso.DRAgainst(e, "crushing"))
+	_, ok = ParseDRSplit("all: 2")
+	check.False(t, ok)
+}
@@ -75,7 +75,7 @@ func (p *EquippedEquipmentPrereq) Satisfied(entity *Entity, exclude any, tooltip
 			p.NameCriteria.Matches(replacements, eqp.NameWithReplacements()) &&
 			p.TagsCriteria.MatchesList(replacements, eqp.Tags...)
 		return satisfied
-	}, false, false, entity.CarriedEquipment...)
+	}, false, false, entity.ActiveCarriedEquipment()...)
 	if !satisfied {
 		*hasEquipmentPenalty = true
 		if tooltip != nil {
@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/encumbrance"
 	"github.com/richardwilkes/rpgtools/dice"
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/eval"
@@ -30,12 +31,17 @@ func InstallEvaluatorFunctions(m map[string]eval.Function) {
 	m["dice_modifier"] = evalDiceModifier
 	m["dice_multiplier"] = evalDiceMultiplier
 	m["dice_sides"] = evalDiceSides
+	m["dodge"] = evalDodge
 	m["enc"] = evalEncumbrance
 	m["has_trait"] = evalHasTrait
 	m["random_height"] = evalRandomHeight
 	m["random_weight"] = evalRandomWeight
+	m["range_modifier"] = evalRangeModifier
+	m["range_to_yards"] = evalRangeToYards
 	m["roll"] = evalRoll
 	m["signed"] = evalSigned
+	m["size_modifier"] = evalSizeModifier
+	m["size_modifier_to_length"] = evalSizeModifierToLength
 	m["skill_level"] = evalSkillLevel
 	m["ssrt"] = evalSSRT
 	m["ssrt_to_yards"] = evalSSRTYards
@@ -102,6 +108,24 @@ func evalEncumbrance(ev *eval.Evaluator, arguments string) (any, error) {
 	return level, nil
 }
 
+// evalDodge takes an optional encumbrance level and returns the Dodge at that level, or at the current encumbrance level
+// if none is provided.
+func evalDodge(ev *eval.Evaluator, arguments string) (any, error) {
+	e, ok := ev.Resolver.(*Entity)
+	if !ok {
+		return fxp.Int(0), nil
+	}
+	level := e.EncumbranceLevel(false)
+	if arg := strings.TrimSpace(arguments); arg != "" {
+		value, err := evalToNumber(ev, arg)
+		if err != nil {
+			return nil, err
+		}
+		level = encumbrance.Level(min(max(fxp.As[int](value), 0), int(encumbrance.LastLevel)))
+	}
+	return fxp.From(e.Dodge(level)), nil
+}
+
 // evalSkillLevel takes up to 3 arguments: name (string, required), specialization (string, optional), relative (bool, optional)
 func evalSkillLevel(ev *eval.Evaluator, arguments string) (any, error) {
 	e, ok := ev.Resolver.(*Entity)
@@ -434,6 +458,67 @@ func evalSSRTYards(ev *eval.Evaluator, arguments string) (any, error) {
 	return valueToYards(fxp.As[int](v)), nil
 }
 
+func evalRangeModifier(ev *eval.Evaluator, arguments string) (any, error) {
+	// Takes 1 arg: distance in yards (number). Shorthand for ssrt(distance, yd, false).
+	return evalSSRT(ev, arguments+", yd, false")
+}
+
+func evalRangeToYards(ev *eval.Evaluator, arguments string) (any, error) {
+	// Takes 1 arg: speed/range modifier (number), which is expected to be zero or negative. Shorthand for
+	// ssrt_to_yards(-modifier).
+	v, err := evalToNumber(ev, arguments)
+	if err != nil {
+		return nil, err
+	}
+	return evalSSRTYards(ev, max(-v, 0).String())
+}
+
+func evalSizeModifier(ev *eval.Evaluator, arguments string) (any, error) {
+	// Takes up to 2 args: length (number) and units (string, optional, defaults to yards). Shorthand for
+	// ssrt(length, units, true).
+	length, arguments := eval.NextArg(arguments)
+	units, _ := eval.NextArg(arguments)
+	if strings.TrimSpace(units) == "" {
+		units = "yd"
+	}
+	return evalSSRT(ev, length+", "+units+", true")
+}
+
+func evalSizeModifierToLength(ev *eval.Evaluator, arguments string) (any, error) {
+	// Takes up to 2 args: size modifier (number) and units (string, optional, defaults to yards)
+	var arg string
+	arg, arguments = eval.NextArg(arguments)
+	yards, err := evalSSRTYards(ev, arg)
+	if err != nil {
+		return nil, err
+	}
+	var units fxp.LengthUnit
+	if units, err = evalLengthUnits(ev, arguments); err != nil {
+		return nil, err
+	}
+	// The smaller sizes are fractions of a yard, so round away the conversion error
+	length := yards.(fxp.Int).Mul(fxp.ThirtySix).Div(units.ToInches(fxp.One))
+	return length.Mul(fxp.Hundred).Round().Div(fxp.Hundred), nil
+}
+
+func evalLengthUnits(ev *eval.Evaluator, arguments string) (fxp.LengthUnit, error) {
+	arg, _ := eval.NextArg(arguments)
+	if strings.TrimSpace(arg) == "" {
+		return fxp.Yard, nil
+	}
+	units, err := evalToString(ev, arg)
+	if err != nil {
+		return fxp.Yard, err
+	}
+	units = strings.TrimSpace(units)
+	for _, one := range fxp.LengthUnits {
+		if strings.EqualFold(one.Key(), units) {
+			return one, nil
+		}
+	}
+	return fxp.Yard, errs.Newf("invalid length units: %s", units)
+}
+
 func yardsToValue(length fxp.Length, allowNegative bool) int {
 	inches := fxp.Int(length)
 	yards := inches.Div(fxp.ThirtySix)
@@ -613,7 +698,7 @@ func evalRandomWeight(ev *eval.Evaluator, arguments string) (any, error) {
 			overweight = true
 		case "fat":
 			fat = true
-		case "very Fat":
+		case "very fat":
 			veryFat = true
 		}
 		return false
@@ -0,0 +1,43 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestSizeAndSpeedRangeFunctions(t *testing.T) {
+	gurps.InstallEvaluatorFunctions(fxp.EvalFuncs)
+	for _, one := range []struct {
+		expression string
+		expected   fxp.Int
+	}{
+		{expression: "range_modifier(2)", expected: 0},
+		{expression: "range_modifier(10)", expected: fxp.From(-4)},
+		{expression: "range_modifier(11)", expected: fxp.From(-5)},
+		{expression: "range_modifier(100)", expected: fxp.From(-10)},
+		{expression: "range_to_yards(-4)", expected: fxp.Ten},
+		{expression: "range_to_yards(-10)", expected: fxp.Hundred},
+		{expression: "range_to_yards(3)", expected: fxp.Two},
+		{expression: "size_modifier(2)", expected: 0},
+		{expression: "size_modifier(30, yd)", expected: fxp.Seven},
+		{expression: "size_modifier(6, ft)", expected: 0},
+		{expression: "size_modifier(1, ft)", expected: fxp.From(-5)},
+		{expression: "size_modifier_to_length(4)", expected: fxp.Ten},
+		{expression: "size_modifier_to_length(4, ft)", expected: fxp.Thirty},
+		{expression: "size_modifier_to_length(-5, in)", expected: fxp.Twelve},
+	} {
+		check.Equal(t, one.expected, fxp.EvaluateToNumber(one.expression, nil), one.expression)
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"bufio"
 	"cmp"
 	"encoding/base64"
+	"fmt"
 	htmltmpl "html/template"
 	"io"
 	"net/http"
@@ -25,6 +26,7 @@ import (
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/encumbrance"
 	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/tid"
 	"github.com/richardwilkes/toolbox/xio"
 	"github.com/richardwilkes/toolbox/xio/fs"
@@ -69,12 +71,17 @@ type exportedRangedWeapon struct {
 }
 
 type exportedHitLocation struct {
-	RollRange string
-	Where     string
-	Penalty   int
-	DR        string
-	Notes     string
-	Depth     int
+	RollRange    string
+	Where        string
+	ChoiceName   string
+	Penalty      int
+	DR           string
+	Wounding     string
+	Description  string
+	Notes        string
+	Depth        int
+	SubRoll      string
+	SubLocations []*exportedHitLocation
 }
 
 type exportedBodyType struct {
@@ -242,6 +249,12 @@ type exportedPoints struct {
 	PointsBreakdown
 }
 
+type exportedDerivedField struct {
+	Name    string
+	Formula string
+	Value   string
+}
+
 type exportedMargins struct {
 	Top    string
 	Left   string
@@ -284,6 +297,7 @@ type exportedEntity struct {
 	BodyType                exportedBodyType
 	Reactions               []*exportedConditionalModifier
 	ConditionalModifiers    []*exportedConditionalModifier
+	DerivedFields           []*exportedDerivedField
 	Traits                  []*exportedTrait
 	Skills                  []*exportedSkill
 	Spells                  []*exportedSpell
@@ -297,24 +311,46 @@ type exportedEntity struct {
 
 // ExportSheets exports the files to a text representation.
 func ExportSheets(templatePath string, fileList []string) error {
+	return ExportSheetsTo(templatePath, "", fileList)
+}
+
+// ExportSheetsTo exports the files to a text representation, placing the results in outputDir. If outputDir is empty,
+// each result is placed alongside its source file.
+func ExportSheetsTo(templatePath, outputDir string, fileList []string) error {
+	if outputDir != "" {
+		if err := os.MkdirAll(outputDir, 0o750); err != nil {
+			return errs.Wrap(err)
+		}
+	}
 	for _, one := range fileList {
 		if FileInfoFor(one).IsExportable {
 			// Currently, only one file type supports exporting. Should this change, this will need to be adjusted to
 			// call the correct loader.
 			entity, err := NewEntityFromFile(os.DirFS(filepath.Dir(one)), filepath.Base(one))
-			if err != nil {
-				return err
+			if err == nil {
+				err = Export(entity, templatePath, ExportBasePath(one, outputDir)+filepath.Ext(templatePath))
 			}
-			if err = Export(entity, templatePath, fs.TrimExtension(one)+filepath.Ext(templatePath)); err != nil {
+			if err != nil {
+				CLI.Failed(one, err)
 				return err
 			}
+			CLI.File(one, CLIStatusExported, fmt.Sprintf(i18n.Text("Exported %s"), one))
 		} else {
-			errs.Log(errs.New("not exportable, skipping"), "file", one)
+			CLI.File(one, CLIStatusSkipped, fmt.Sprintf(i18n.Text("Skipped %s, as it is not exportable"), one))
 		}
 	}
 	return nil
 }
 
+// ExportBasePath returns the path, without an extension, that an export of the file should be written to. If outputDir
+// is empty, the export is placed alongside the file.
+func ExportBasePath(filePath, outputDir string) string {
+	if outputDir == "" {
+		return fs.TrimExtension(filePath)
+	}
+	return filepath.Join(outputDir, fs.TrimExtension(filepath.Base(filePath)))
+}
+
 // Export an Entity to exportPath using the template found at templatePath.
 func Export(entity *Entity, templatePath, exportPath string) error {
 	tmpl, err := os.ReadFile(templatePath)
@@ -401,6 +437,14 @@ func export(entity *Entity, tmpl exporter, exportPath string) (err error) {
 			err = errs.Wrap(closeErr)
 		}
 	}()
+	if err = tmpl.Execute(buffer, newExportedEntity(entity)); err != nil {
+		err = errs.Wrap(err)
+		return err
+	}
+	return nil
+}
+
+func newExportedEntity(entity *Entity) *exportedEntity {
 	pb := entity.PointsBreakdown()
 	data := &exportedEntity{
 		Name:         entity.Profile.Name,
@@ -443,6 +487,7 @@ func export(entity *Entity, tmpl exporter, exportPath string) (err error) {
 		},
 		Reactions:            newExportedConditionalModifiers(entity.Reactions()),
 		ConditionalModifiers: newExportedConditionalModifiers(entity.ConditionalModifiers()),
+		DerivedFields:        newExportedDerivedFields(entity),
 		Equipment: exportedAllEquipment{
 			Carried:       newExportedEquipment(entity, entity.CarriedEquipment, true),
 			CarriedValue:  entity.WealthCarried(),
@@ -637,11 +682,7 @@ func export(entity *Entity, tmpl exporter, exportPath string) (err error) {
 			StrengthParts:   weaponST,
 		})
 	}
-	if err = tmpl.Execute(buffer, data); err != nil {
-		err = errs.Wrap(err)
-		return err
-	}
-	return nil
+	return data
 }
 
 func newExportedAttribute(def *AttributeDef, attr *Attribute) *exportedAttribute {
@@ -676,6 +717,22 @@ func newExportedConditionalModifiers(list []*ConditionalModifier) []*exportedCon
 	return result
 }
 
+func newExportedDerivedFields(entity *Entity) []*exportedDerivedField {
+	result := make([]*exportedDerivedField, 0, len(entity.SheetSettings.DerivedFields))
+	for _, one := range entity.SheetSettings.DerivedFields {
+		value, err := one.Value(entity)
+		if err != nil {
+			errs.Log(err)
+		}
+		result = append(result, &exportedDerivedField{
+			Name:    one.Name,
+			Formula: one.Formula,
+			Value:   value,
+		})
+	}
+	return result
+}
+
 func newExportedEquipment(entity *Entity, list []*Equipment, carried bool) []*exportedEquipment {
 	var result []*exportedEquipment
 	Traverse(func(e *Equipment) bool {
@@ -733,15 +790,7 @@ func groupOrItem(isContainer bool) string {
 
 func addToHitLocations(entity *Entity, locations []*exportedHitLocation, depth int, hitLocations []*HitLocation) []*exportedHitLocation {
 	for _, location := range hitLocations {
-		loc := &exportedHitLocation{
-			RollRange: location.RollRange,
-			Where:     location.TableName,
-			Penalty:   location.HitPenalty,
-			Depth:     depth,
-		}
-		var tooltip xio.ByteBuffer
-		loc.DR = location.DisplayDR(entity, &tooltip)
-		loc.Notes = tooltip.String()
+		loc := newExportedHitLocation(entity, location, depth)
 		locations = append(locations, loc)
 		if location.SubTable != nil {
 			locations = addToHitLocations(entity, locations, depth+1, location.SubTable.Locations)
@@ -749,3 +798,25 @@ func addToHitLocations(entity *Entity, locations []*exportedHitLocation, depth i
 	}
 	return locations
 }
+
+func newExportedHitLocation(entity *Entity, location *HitLocation, depth int) *exportedHitLocation {
+	loc := &exportedHitLocation{
+		RollRange:   location.RollRange,
+		Where:       location.TableName,
+		ChoiceName:  location.ChoiceName,
+		Penalty:     location.HitPenalty,
+		Wounding:    location.Wounding.String(),
+		Description: location.Description,
+		Depth:       depth,
+	}
+	var tooltip xio.ByteBuffer
+	loc.DR = location.DisplayDR(entity, &tooltip)
+	loc.Notes = tooltip.String()
+	if location.SubTable != nil {
+		loc.SubRoll = location.SubTable.Roll.String()
+		for _, one := range location.SubTable.Locations {
+			loc.SubLocations = append(loc.SubLocations, newExportedHitLocation(entity, one, depth+1))
+		}
+	}
+	return loc
+}
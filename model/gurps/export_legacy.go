@@ -461,6 +461,10 @@ func (ex *legacyExporter) emitKey(key string) error {
 		ex.writeEncodedText(strconv.Itoa(len(ex.entity.ConditionalModifiers())))
 	case "CONDITIONAL_MODIFIERS_LOOP_START":
 		ex.processConditionalModifiersLoop(ex.entity.ConditionalModifiers(), ex.extractUpToMarker("CONDITIONAL_MODIFIERS_LOOP_END"))
+	case "DERIVED_FIELDS_LOOP_COUNT":
+		ex.writeEncodedText(strconv.Itoa(len(ex.entity.SheetSettings.DerivedFields)))
+	case "DERIVED_FIELDS_LOOP_START":
+		ex.processDerivedFieldsLoop(ex.extractUpToMarker("DERIVED_FIELDS_LOOP_END"))
 	case "PRIMARY_ATTRIBUTE_LOOP_COUNT":
 		count := 0
 		for _, def := range ex.entity.SheetSettings.Attributes.List(true) {
@@ -725,7 +729,11 @@ func (ex *legacyExporter) processEncumbranceLoop(buffer []byte) {
 }
 
 func (ex *legacyExporter) processHitLocationLoop(buffer []byte) {
-	for i, location := range ex.entity.SheetSettings.BodyType.Locations {
+	ex.processHitLocations(buffer, ex.entity.SheetSettings.BodyType.Locations, 0, 0)
+}
+
+func (ex *legacyExporter) processHitLocations(buffer []byte, locations []*HitLocation, depth, i int) int {
+	for _, location := range locations {
 		ex.processBuffer(buffer, func(key string, _ []byte, index int) int {
 			switch key {
 			case idExportKey:
@@ -734,6 +742,8 @@ func (ex *legacyExporter) processHitLocationLoop(buffer []byte) {
 				ex.writeEncodedText(location.RollRange)
 			case "WHERE":
 				ex.writeEncodedText(location.TableName)
+			case "CHOICE_NAME":
+				ex.writeEncodedText(location.ChoiceName)
 			case "PENALTY":
 				ex.writeEncodedText(strconv.Itoa(location.HitPenalty))
 			case "DR":
@@ -742,6 +752,12 @@ func (ex *legacyExporter) processHitLocationLoop(buffer []byte) {
 				var tooltip xio.ByteBuffer
 				location.DisplayDR(ex.entity, &tooltip)
 				ex.writeEncodedText(tooltip.String())
+			case "WOUNDING":
+				ex.writeEncodedText(location.Wounding.String())
+			case "DESCRIPTION":
+				ex.writeEncodedText(location.Description)
+			case "DEPTH":
+				ex.writeEncodedText(strconv.Itoa(depth))
 			case "EQUIPMENT":
 				ex.writeEncodedText(strings.Join(ex.hitLocationEquipment(location), ", "))
 			case "EQUIPMENT_FORMATTED":
@@ -751,11 +767,20 @@ func (ex *legacyExporter) processHitLocationLoop(buffer []byte) {
 					ex.out.WriteString("</p>\n")
 				}
 			default:
-				ex.unidentifiedKey(key)
+				if strings.HasPrefix(key, "DEPTHx") {
+					ex.handlePrefixDepth(key, depth)
+				} else {
+					ex.unidentifiedKey(key)
+				}
 			}
 			return index
 		})
+		i++
+		if location.SubTable != nil {
+			i = ex.processHitLocations(buffer, location.SubTable.Locations, depth+1, i)
+		}
 	}
+	return i
 }
 
 func (ex *legacyExporter) hitLocationEquipment(location *HitLocation) []string {
@@ -1166,6 +1191,28 @@ func (ex *legacyExporter) processConditionalModifiersLoop(list []*ConditionalMod
 	}
 }
 
+func (ex *legacyExporter) processDerivedFieldsLoop(buffer []byte) {
+	for _, one := range ex.entity.SheetSettings.DerivedFields {
+		ex.processBuffer(buffer, func(key string, _ []byte, index int) int {
+			switch key {
+			case nameExportKey:
+				ex.writeEncodedText(one.Name)
+			case "FORMULA":
+				ex.writeEncodedText(one.Formula)
+			case "VALUE":
+				value, err := one.Value(ex.entity)
+				if err != nil {
+					errs.Log(err)
+				}
+				ex.writeEncodedText(value)
+			default:
+				ex.unidentifiedKey(key)
+			}
+			return index
+		})
+	}
+}
+
 func (ex *legacyExporter) processAttributesLoop(buffer []byte, primary bool) {
 	for _, def := range ex.entity.SheetSettings.Attributes.List(true) {
 		if (def.Type != attribute.Pool && def.Type != attribute.PoolRef) && def.Primary() == primary {
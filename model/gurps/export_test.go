@@ -10,6 +10,7 @@
 package gurps
 
 import (
+	"path/filepath"
 	"strings"
 	"testing"
 	"text/template"
@@ -62,3 +63,34 @@ func TestTemplateFuncs(t *testing.T) {
 	check.NoError(t, err)
 	check.Error(t, tmpl.Execute(&buffer, nil))
 }
+
+func TestExportBasePath(t *testing.T) {
+	check.Equal(t, filepath.Join("a", "b", "sheet"), ExportBasePath(filepath.Join("a", "b", "sheet.gcs"), ""))
+	check.Equal(t, filepath.Join("out", "sheet"), ExportBasePath(filepath.Join("a", "b", "sheet.gcs"), "out"))
+}
+
+func TestDerivedFields(t *testing.T) {
+	fields, valid := NewDerivedFieldsFromString("Double ST = $st * 2\n\nEffective Dodge = dodge() + 1\nBroken = nosuch(1)\n")
+	check.True(t, valid)
+	check.Equal(t, 3, len(fields))
+	check.Equal(t, "Double ST = $st * 2\nEffective Dodge = dodge() + 1\nBroken = nosuch(1)\n", DerivedFieldsToString(fields))
+	_, valid = NewDerivedFieldsFromString("No formula =")
+	check.False(t, valid)
+
+	e := NewEntity()
+	e.SheetSettings.DerivedFields = fields
+	value, err := fields[0].Value(e)
+	check.NoError(t, err)
+	check.Equal(t, "20", value)
+	value, err = fields[1].Value(e)
+	check.NoError(t, err)
+	check.Equal(t, "9", value)
+	_, err = fields[2].Value(e)
+	check.Error(t, err)
+
+	exported := newExportedDerivedFields(e)
+	check.Equal(t, 3, len(exported))
+	check.Equal(t, "Effective Dodge", exported[1].Name)
+	check.Equal(t, "9", exported[1].Value)
+	check.Equal(t, "", exported[2].Value)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/effort"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Extra effort in combat (B357).
+const (
+	FeverishDefenseBonus = 2
+	RapidStrikePenalty   = -6
+)
+
+// MightyBlowsBonus returns the bonus to damage from Mighty Blows for an attack with the given number of dice: +2, or +1
+// per die if that is better.
+func MightyBlowsBonus(dieCount int) int {
+	return max(2, dieCount)
+}
+
+// RapidStrikePenalty returns the penalty to each attack of a Rapid Strike (B370), which is halved for those with
+// Trained by a Master or Weapon Master. If flurryOfBlows is true, the penalty is halved again, rounding in the
+// character's favor.
+func (e *Entity) RapidStrikePenalty(flurryOfBlows bool) int {
+	penalty := RapidStrikePenalty
+	if e.HasCinematicSkillTraining() {
+		penalty /= 2
+	}
+	if flurryOfBlows {
+		penalty /= 2
+	}
+	return penalty
+}
+
+// SpendExtraEffort deducts the FP cost of the extra effort from the character's fatigue points, recording the change in
+// the pool's log. Returns nil if the character has no fatigue points.
+func (e *Entity) SpendExtraEffort(option effort.Option, attacks int) *PoolRecord {
+	fp, ok := e.Attributes.Set[FatiguePointsID]
+	if !ok {
+		return nil
+	}
+	return fp.ApplyDamage(fxp.From(option.FatigueCost(attacks)), fmt.Sprintf(i18n.Text("Extra Effort: %s"), option))
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/effort"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestExtraEffort(t *testing.T) {
+	check.Equal(t, 2, gurps.MightyBlowsBonus(1))
+	check.Equal(t, 3, gurps.MightyBlowsBonus(3))
+	check.Equal(t, 1, effort.MightyBlows.FatigueCost(3))
+	check.Equal(t, 3, effort.FlurryOfBlows.FatigueCost(3))
+
+	e := gurps.NewEntity()
+	check.Equal(t, -6, e.RapidStrikePenalty(false))
+	check.Equal(t, -3, e.RapidStrikePenalty(true))
+	master := gurps.NewTrait(e, nil, false)
+	master.Name = gurps.WeaponMasterTraitName
+	e.SetTraitList([]*gurps.Trait{master})
+	check.Equal(t, -3, e.RapidStrikePenalty(false))
+	check.Equal(t, -1, e.RapidStrikePenalty(true), "rounds in the character's favor")
+
+	fp := e.Attributes.Set[gurps.FatiguePointsID]
+	before := fp.Current()
+	record := e.SpendExtraEffort(effort.FlurryOfBlows, 2)
+	check.NotNil(t, record)
+	check.Equal(t, before-fxp.Two, fp.Current())
+	check.Equal(t, "Extra Effort: Flurry of Blows", record.Note)
+}
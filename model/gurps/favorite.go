@@ -0,0 +1,99 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"os"
+	"slices"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+// Favorite identifies a trait, skill, spell, equipment or note in a library file that has been pinned for quick access
+// or was recently added to a sheet. The name is only used for display when the item can no longer be found.
+type Favorite struct {
+	From LibraryFile `json:"from"`
+	ID   tid.TID     `json:"id"`
+	Name string      `json:"name,omitempty"`
+}
+
+// NewFavorite creates a new Favorite for a trait, skill, spell, equipment or note from the library file. Returns nil
+// for any other type of item.
+func NewFavorite(from LibraryFile, item IDer) *Favorite {
+	var name string
+	switch one := item.(type) {
+	case *Trait:
+		name = one.Name
+	case *Skill:
+		name = one.Name
+	case *Spell:
+		name = one.Name
+	case *Equipment:
+		name = one.Name
+	case *Note:
+		name = noteSearchName(one.Text)
+	default:
+		return nil
+	}
+	return &Favorite{
+		From: from,
+		ID:   item.ID(),
+		Name: name,
+	}
+}
+
+// IsFavorite returns true if the item from the library file has been pinned.
+func (s *Settings) IsFavorite(from LibraryFile, id tid.TID) bool {
+	return slices.ContainsFunc(s.Favorites, func(one *Favorite) bool { return one.From == from && one.ID == id })
+}
+
+// AddFavorite pins the item, keeping the favorites sorted by name. Returns false if it was already pinned.
+func (s *Settings) AddFavorite(favorite *Favorite) bool {
+	if s.IsFavorite(favorite.From, favorite.ID) {
+		return false
+	}
+	s.Favorites = append(s.Favorites, favorite)
+	slices.SortStableFunc(s.Favorites, func(a, b *Favorite) int { return txt.NaturalCmp(a.Name, b.Name, true) })
+	return true
+}
+
+// RemoveFavorite unpins the item from the library file.
+func (s *Settings) RemoveFavorite(from LibraryFile, id tid.TID) {
+	s.Favorites = slices.DeleteFunc(s.Favorites, func(one *Favorite) bool { return one.From == from && one.ID == id })
+}
+
+// ResolveFavorites loads the items the favorites refer to, in the same order. Favorites whose library file or item can
+// no longer be found have a nil entry. Each library file is only loaded once.
+func ResolveFavorites(libraries Libraries, favorites []*Favorite) []*LibrarySearchResult {
+	indexes := make(map[LibraryFile]*LibrarySearchIndex)
+	list := make([]*LibrarySearchResult, len(favorites))
+	for i, one := range favorites {
+		idx, ok := indexes[one.From]
+		if !ok {
+			idx = &LibrarySearchIndex{}
+			if lib, exists := libraries[one.From.Library]; exists {
+				if err := idx.AddFile(os.DirFS(lib.Path()), one.From); err != nil {
+					errs.Log(err, "library", one.From.Library, "path", one.From.Path)
+				}
+			}
+			indexes[one.From] = idx
+		}
+		for _, entry := range idx.entries {
+			if item, hasID := entry.result.Item.(IDer); hasID && item.ID() == one.ID {
+				result := entry.result
+				list[i] = &result
+				break
+			}
+		}
+	}
+	return list
+}
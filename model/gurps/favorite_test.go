@@ -0,0 +1,60 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestFavorites(t *testing.T) {
+	dir := t.TempDir()
+	check.NoError(t, os.WriteFile(filepath.Join(dir, "Traits.adq"), []byte(`{"version":5,"rows":[
+  {"id":"taaaaaaaaaaaaaaaa","name":"Combat Reflexes"},
+  {"id":"tbbbbbbbbbbbbbbbb","name":"Night Vision"}
+]}`), 0o600))
+	lib := &gurps.Library{GitHubAccountName: "test", RepoName: "lib", PathOnDisk: dir}
+	libraries := gurps.Libraries{lib.Key(): lib}
+	from := gurps.LibraryFile{Library: lib.Key(), Path: "Traits.adq"}
+
+	var settings gurps.Settings
+	check.True(t, settings.AddFavorite(&gurps.Favorite{From: from, ID: "tbbbbbbbbbbbbbbbb", Name: "Night Vision"}))
+	check.True(t, settings.AddFavorite(&gurps.Favorite{From: from, ID: "taaaaaaaaaaaaaaaa", Name: "Combat Reflexes"}))
+	check.False(t, settings.AddFavorite(&gurps.Favorite{From: from, ID: "taaaaaaaaaaaaaaaa", Name: "Combat Reflexes"}))
+	check.True(t, settings.AddFavorite(&gurps.Favorite{
+		From: gurps.LibraryFile{Library: lib.Key(), Path: "Missing.adq"},
+		ID:   "tcccccccccccccccc",
+		Name: "Gone",
+	}))
+	check.Equal(t, 3, len(settings.Favorites))
+	check.Equal(t, "Combat Reflexes", settings.Favorites[0].Name, "favorites are sorted by name")
+	check.True(t, settings.IsFavorite(from, "tbbbbbbbbbbbbbbbb"))
+
+	results := gurps.ResolveFavorites(libraries, settings.Favorites)
+	check.Equal(t, 3, len(results))
+	check.NotNil(t, results[0])
+	check.Equal(t, "Combat Reflexes", results[0].Name)
+	check.Nil(t, results[1], "the missing file can't be resolved")
+	check.NotNil(t, results[2])
+	trait, ok := results[2].Item.(*gurps.Trait)
+	check.True(t, ok)
+	check.Equal(t, "Night Vision", trait.Name)
+
+	check.Equal(t, &gurps.Favorite{From: from, ID: trait.ID(), Name: "Night Vision"}, gurps.NewFavorite(from, trait))
+	check.Nil(t, gurps.NewFavorite(from, &gurps.TraitModifier{}))
+
+	settings.RemoveFavorite(from, "tbbbbbbbbbbbbbbbb")
+	check.False(t, settings.IsFavorite(from, "tbbbbbbbbbbbbbbbb"))
+	check.Equal(t, 2, len(settings.Favorites))
+}
@@ -32,13 +32,28 @@ const (
 	NotesExt              = ".not"
 	SheetExt              = ".gcs"
 	SkillsExt             = ".skl"
+	SpaceshipExt          = ".ship"
 	SpellsExt             = ".spl"
 	TemplatesExt          = ".gct"
 	TraitModifiersExt     = ".adm"
 	TraitsExt             = ".adq"
 	MarkdownExt           = ".md"
+	CharacterArchiveExt   = ".gca"
 )
 
+// NameCorpusExt is the extension for plain text files holding one name per line, which are used to train name
+// generators. The compound extension keeps other text files, such as a library's README, from being picked up. Since
+// these are ordinary text files, it is not claimed as a GCS file extension.
+const NameCorpusExt = ".names.txt"
+
+// DictionaryExt is the extension for the word lists used by the spell checker. Hunspell dictionaries use the same
+// extension and may be used as-is. These aren't GCS files, so it is not claimed as a GCS file extension.
+const DictionaryExt = ".dic"
+
+// AffixExt is the extension of the Hunspell affix file that may accompany a dictionary. Only its prefix and suffix
+// rules are used.
+const AffixExt = ".aff"
+
 // Secondary GCS file extensions (no visible display for these, since you don't open them into a view).
 const (
 	AncestryExt        = ".ancestry"
@@ -52,6 +67,7 @@ const (
 	FontSettingsExt    = ".fonts"
 	GeneralSettingsExt = ".general"
 	KeySettingsExt     = ".keys"
+	NameListExt        = ".namelist"
 	NamesExt           = ".names"
 	PageRefSettingsExt = ".refs"
 	SheetSettingsExt   = ".sheet"
@@ -148,6 +164,7 @@ func GCSSecondaryExtensions() []string {
 		FontSettingsExt,
 		GeneralSettingsExt,
 		KeySettingsExt,
+		NameListExt,
 		NamesExt,
 		PageRefSettingsExt,
 		SheetSettingsExt,
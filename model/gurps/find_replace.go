@@ -0,0 +1,160 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"regexp"
+
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// FindReplace holds the criteria for a find & replace operation across the rows of a data file.
+type FindReplace struct {
+	Find      string
+	Replace   string
+	UseRegex  bool
+	MatchCase bool
+}
+
+// TextReplacement is a single change that a find & replace would make to a text field of a row.
+type TextReplacement struct {
+	Item   any
+	Name   string
+	Field  string
+	Before string
+	After  string
+	target *string
+}
+
+// Apply the replacement.
+func (r *TextReplacement) Apply() {
+	*r.target = r.After
+}
+
+// Compile the criteria into a regular expression.
+func (f *FindReplace) Compile() (*regexp.Regexp, error) {
+	pattern := f.Find
+	if !f.UseRegex {
+		pattern = regexp.QuoteMeta(pattern)
+	}
+	if !f.MatchCase {
+		pattern = "(?i)" + pattern
+	}
+	return regexp.Compile(pattern)
+}
+
+// Replacements returns the changes the find & replace would make to the names, notes, and user descriptions of the
+// data, which should be one or more of the node types. Children and modifiers are included.
+func (f *FindReplace) Replacements(data ...any) ([]*TextReplacement, error) {
+	if f.Find == "" {
+		return nil, nil
+	}
+	re, err := f.Compile()
+	if err != nil {
+		return nil, err
+	}
+	var list []*TextReplacement
+	for _, one := range data {
+		list = f.collect(re, one, list)
+	}
+	return list, nil
+}
+
+// ApplyReplacements applies each of the replacements.
+func ApplyReplacements(list []*TextReplacement) {
+	for _, one := range list {
+		one.Apply()
+	}
+}
+
+func (f *FindReplace) collect(re *regexp.Regexp, data any, list []*TextReplacement) []*TextReplacement {
+	nameField := i18n.Text("Name")
+	notesField := i18n.Text("Notes")
+	switch one := data.(type) {
+	case *Trait:
+		name := one.Name
+		list = f.check(re, one, name, nameField, &one.Name, list)
+		list = f.check(re, one, name, notesField, &one.LocalNotes, list)
+		list = f.check(re, one, name, i18n.Text("User Description"), &one.UserDesc, list)
+		for _, mod := range one.Modifiers {
+			list = f.collect(re, mod, list)
+		}
+		for _, child := range one.Children {
+			list = f.collect(re, child, list)
+		}
+	case *TraitModifier:
+		name := one.Name
+		list = f.check(re, one, name, nameField, &one.Name, list)
+		list = f.check(re, one, name, notesField, &one.LocalNotes, list)
+		for _, child := range one.Children {
+			list = f.collect(re, child, list)
+		}
+	case *Skill:
+		name := one.Name
+		list = f.check(re, one, name, nameField, &one.Name, list)
+		list = f.check(re, one, name, notesField, &one.LocalNotes, list)
+		for _, child := range one.Children {
+			list = f.collect(re, child, list)
+		}
+	case *Spell:
+		name := one.Name
+		list = f.check(re, one, name, nameField, &one.Name, list)
+		list = f.check(re, one, name, notesField, &one.LocalNotes, list)
+		for _, child := range one.Children {
+			list = f.collect(re, child, list)
+		}
+	case *Equipment:
+		name := one.Name
+		list = f.check(re, one, name, nameField, &one.Name, list)
+		list = f.check(re, one, name, notesField, &one.LocalNotes, list)
+		for _, mod := range one.Modifiers {
+			list = f.collect(re, mod, list)
+		}
+		for _, child := range one.Children {
+			list = f.collect(re, child, list)
+		}
+	case *EquipmentModifier:
+		name := one.Name
+		list = f.check(re, one, name, nameField, &one.Name, list)
+		list = f.check(re, one, name, notesField, &one.LocalNotes, list)
+		for _, child := range one.Children {
+			list = f.collect(re, child, list)
+		}
+	case *Note:
+		list = f.check(re, one, one.Text, i18n.Text("Text"), &one.Text, list)
+		for _, child := range one.Children {
+			list = f.collect(re, child, list)
+		}
+	}
+	return list
+}
+
+func (f *FindReplace) check(re *regexp.Regexp, item any, name, field string, target *string, list []*TextReplacement) []*TextReplacement {
+	if !re.MatchString(*target) {
+		return list
+	}
+	var after string
+	if f.UseRegex {
+		after = re.ReplaceAllString(*target, f.Replace)
+	} else {
+		after = re.ReplaceAllLiteralString(*target, f.Replace)
+	}
+	if after == *target {
+		return list
+	}
+	return append(list, &TextReplacement{
+		Item:   item,
+		Name:   name,
+		Field:  field,
+		Before: *target,
+		After:  after,
+		target: target,
+	})
+}
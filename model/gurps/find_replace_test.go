@@ -0,0 +1,56 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestFindReplace(t *testing.T) {
+	trait := &gurps.Trait{}
+	trait.Name = "Night Vision"
+	trait.LocalNotes = "Vision in the night"
+	trait.UserDesc = "nothing here"
+	mod := &gurps.TraitModifier{}
+	mod.Name = "Vision Only"
+	trait.Modifiers = []*gurps.TraitModifier{mod}
+	note := &gurps.Note{}
+	note.Text = "See vision.txt"
+
+	fr := &gurps.FindReplace{Find: "vision", Replace: "Sight"}
+	list, err := fr.Replacements(trait, note)
+	check.NoError(t, err)
+	check.Equal(t, 4, len(list))
+	check.Equal(t, "Night Sight", list[0].After)
+	check.Equal(t, "Night Vision", list[1].Name, "the name of the row is recorded before changes")
+	check.Equal(t, "Sight Only", list[2].After)
+	check.Equal(t, "See Sight.txt", list[3].After, "plain text searches treat the dot literally")
+
+	fr.MatchCase = true
+	list, err = fr.Replacements(trait, note)
+	check.NoError(t, err)
+	check.Equal(t, 1, len(list))
+	gurps.ApplyReplacements(list)
+	check.Equal(t, "See Sight.txt", note.Text)
+	check.Equal(t, "Night Vision", trait.Name)
+
+	fr = &gurps.FindReplace{Find: `(\w+) Vision`, Replace: "$1 Eyes", UseRegex: true}
+	list, err = fr.Replacements(trait)
+	check.NoError(t, err)
+	check.Equal(t, 1, len(list))
+	gurps.ApplyReplacements(list)
+	check.Equal(t, "Night Eyes", trait.Name)
+
+	_, err = (&gurps.FindReplace{Find: "(", UseRegex: true}).Replacements(trait)
+	check.Error(t, err)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+	"unicode"
+)
+
+// FuzzyMatch returns true if every character of the pattern appears in the text in the same order, ignoring case and
+// whitespace in the pattern. The returned score is higher for better matches: those whose characters are consecutive
+// or begin words in the text. An empty pattern matches everything with a score of zero.
+func FuzzyMatch(pattern, text string) (score int, ok bool) {
+	p := []rune(strings.ToLower(strings.Join(strings.Fields(pattern), "")))
+	if len(p) == 0 {
+		return 0, true
+	}
+	t := []rune(text)
+	pi := 0
+	last := -1
+	for i, r := range t {
+		if pi == len(p) {
+			break
+		}
+		if unicode.ToLower(r) != p[pi] {
+			continue
+		}
+		score++
+		switch {
+		case i == 0:
+			score += 3
+		case !unicode.IsLetter(t[i-1]) && !unicode.IsDigit(t[i-1]):
+			score += 3
+		case unicode.IsUpper(r) && unicode.IsLower(t[i-1]):
+			score += 2
+		}
+		if last != -1 && last == i-1 {
+			score += 2
+		}
+		if last == -1 {
+			score -= min(i, 3)
+		}
+		last = i
+		pi++
+	}
+	if pi != len(p) {
+		return 0, false
+	}
+	return score, true
+}
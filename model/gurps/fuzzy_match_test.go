@@ -0,0 +1,38 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	score, ok := gurps.FuzzyMatch("", "Anything")
+	check.True(t, ok)
+	check.Equal(t, 0, score)
+
+	_, ok = gurps.FuzzyMatch("NSH", "New Sheet")
+	check.True(t, ok, "case is ignored")
+	_, ok = gurps.FuzzyMatch("new sh", "New Sheet")
+	check.True(t, ok, "whitespace in the pattern is ignored")
+	_, ok = gurps.FuzzyMatch("hsn", "New Sheet")
+	check.False(t, ok, "characters must appear in order")
+	_, ok = gurps.FuzzyMatch("sheets", "New Sheet")
+	check.False(t, ok, "every character must be present")
+
+	wordStarts, ok := gurps.FuzzyMatch("nsh", "New Sheet")
+	check.True(t, ok)
+	scattered, ok := gurps.FuzzyMatch("nsh", "Unsaved Changes")
+	check.True(t, ok)
+	check.True(t, wordStarts > scattered, "matches at word starts score higher")
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"bufio"
+	"fmt"
+	htmltmpl "html/template"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/collection"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio/fs"
+)
+
+const galleryStyle = `body { font-family: sans-serif; font-size: 10pt; margin: 2em; }
+a { color: #036; }
+img.portrait { width: 96px; height: 128px; object-fit: cover; border: 1px solid #999; }
+.cards { display: flex; flex-wrap: wrap; gap: 1em; }
+.card { display: flex; gap: 1em; width: 24em; padding: 0.5em; border: 1px solid #999; border-radius: 4px; }
+.card h2 { margin: 0 0 0.25em 0; font-size: 12pt; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #999; padding: 2px 6px; text-align: left; }
+th { background: #ddd; }
+td.num { text-align: right; }`
+
+const galleryIndexHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>{{.Style}}</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<div class="cards">
+{{range .Entries}}<div class="card">
+{{if .Portrait}}<a href="{{.Page}}"><img class="portrait" src="{{.Portrait}}" alt="{{.Name}}"></a>{{end}}
+<div>
+<h2><a href="{{.Page}}">{{.Name}}</a></h2>
+{{if .Title}}<div>{{.Title}}</div>{{end}}
+{{if .Player}}<div>{{$.PlayerLabel}}: {{.Player}}</div>{{end}}
+<div>{{$.PointsLabel}}: {{.Points}}</div>
+<div>{{range $i, $a := .Attributes}}{{if $i}}, {{end}}{{$a.Name}} {{$a.Value}}{{end}}</div>
+</div>
+</div>
+{{end}}</div>
+</body>
+</html>
+`
+
+const gallerySheetHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Entity.Name}}</title>
+<style>{{.Style}}</style>
+</head>
+<body>
+<p><a href="index.html">{{.BackLabel}}</a></p>
+{{with .Entity}}
+<div class="card">
+{{if .EmbeddedPortraitDataURL}}<img class="portrait" src="{{.EmbeddedPortraitDataURL}}" alt="{{.Name}}">{{end}}
+<div>
+<h1>{{.Name}}</h1>
+{{if .Title}}<div>{{.Title}}</div>{{end}}
+{{if .Organization}}<div>{{.Organization}}</div>{{end}}
+{{if .Player}}<div>{{$.PlayerLabel}}: {{.Player}}</div>{{end}}
+<div>{{$.PointsLabel}}: {{.Points.Total}}</div>
+</div>
+</div>
+<h2>{{$.AttributesLabel}}</h2>
+<table>
+{{range .Attributes.Primary}}<tr><th>{{.CombinedName}}</th><td class="num">{{.Value}}</td></tr>
+{{end}}{{range .Attributes.Secondary}}<tr><th>{{.CombinedName}}</th><td class="num">{{.Value}}</td></tr>
+{{end}}{{range .Attributes.Pools}}<tr><th>{{.CombinedName}}</th><td class="num">{{.Current}}/{{.Maximum}}</td></tr>
+{{end}}</table>
+{{if .Traits}}<h2>{{$.TraitsLabel}}</h2>
+<table>
+{{range .Traits}}<tr><td style="padding-left:{{.Depth}}em">{{.Description}}{{if .ModifierNotes}}; {{.ModifierNotes}}{{end}}</td><td class="num">{{.Points}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Skills}}<h2>{{$.SkillsLabel}}</h2>
+<table>
+{{range .Skills}}<tr><td style="padding-left:{{.Depth}}em">{{.Description}}</td><td class="num">{{.Level}}</td><td>{{.RelativeLevel}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Spells}}<h2>{{$.SpellsLabel}}</h2>
+<table>
+{{range .Spells}}<tr><td style="padding-left:{{.Depth}}em">{{.Description}}</td><td class="num">{{.Level}}</td><td>{{.RelativeLevel}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Equipment.Carried}}<h2>{{$.EquipmentLabel}}</h2>
+<table>
+{{range .Equipment.Carried}}<tr><td class="num">{{.Quantity}}</td><td style="padding-left:{{.Depth}}em">{{.Description}}</td><td class="num">{{.ExtendedWeight}}</td></tr>
+{{end}}</table>{{end}}
+{{if .Notes}}<h2>{{$.NotesLabel}}</h2>
+{{range .Notes}}<p>{{.Description}}</p>
+{{end}}{{end}}
+{{end}}
+</body>
+</html>
+`
+
+type galleryEntry struct {
+	Name       string
+	Title      string
+	Player     string
+	Page       string
+	Portrait   htmltmpl.URL
+	Points     string
+	Attributes []*exportedAttribute
+}
+
+// ExportGallery loads the character sheets found in the given paths and writes a static HTML site to outputDir,
+// consisting of an index page with a portrait and summary for each character, linked to an individual page for each
+// sheet. If templatePath is not empty, it will be used to produce the individual sheet pages rather than the built-in
+// layout.
+func ExportGallery(outputDir, templatePath string, fileList []string) error {
+	list, err := SheetPaths(fileList...)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return errs.New(i18n.Text("No character sheets to include in the gallery"))
+	}
+	if err = os.MkdirAll(outputDir, 0o750); err != nil {
+		return errs.Wrap(err)
+	}
+	var sheetTmpl *htmltmpl.Template
+	if templatePath == "" {
+		if sheetTmpl, err = htmltmpl.New("").Parse(gallerySheetHTML); err != nil {
+			return errs.Wrap(err)
+		}
+	}
+	used := collection.NewSet("index")
+	entries := make([]*galleryEntry, 0, len(list))
+	for _, p := range list {
+		var entity *Entity
+		if entity, err = NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			CLI.Failed(p, err)
+			return errs.NewWithCause(p, err)
+		}
+		entity.Recalculate()
+		page := galleryPageName(fs.BaseName(p), used)
+		pagePath := filepath.Join(outputDir, page)
+		data := newExportedEntity(entity)
+		if sheetTmpl != nil {
+			err = writeGalleryPage(pagePath, sheetTmpl, galleryPageData(map[string]any{"Entity": data}))
+		} else {
+			err = Export(entity, templatePath, pagePath)
+		}
+		if err != nil {
+			CLI.Failed(p, err)
+			return err
+		}
+		CLI.File(p, CLIStatusExported, fmt.Sprintf(i18n.Text("Exported %s"), p))
+		entry := &galleryEntry{
+			Name:       data.Name,
+			Title:      data.Title,
+			Player:     data.Player,
+			Page:       page,
+			Portrait:   data.EmbeddedPortraitDataURL,
+			Points:     data.Points.Total.String(),
+			Attributes: data.Attributes.Primary,
+		}
+		if entry.Name == "" {
+			entry.Name = i18n.Text("Unnamed")
+		}
+		entries = append(entries, entry)
+	}
+	var indexTmpl *htmltmpl.Template
+	if indexTmpl, err = htmltmpl.New("").Parse(galleryIndexHTML); err != nil {
+		return errs.Wrap(err)
+	}
+	return writeGalleryPage(filepath.Join(outputDir, "index.html"), indexTmpl, galleryPageData(map[string]any{
+		"Title":   i18n.Text("Characters"),
+		"Entries": entries,
+	}))
+}
+
+func galleryPageName(base string, used collection.Set[string]) string {
+	var buffer strings.Builder
+	for _, ch := range strings.ToLower(base) {
+		switch {
+		case (ch >= 'a' && ch <= 'z') || (ch >= '0' && ch <= '9') || ch == '-' || ch == '_':
+			buffer.WriteRune(ch)
+		default:
+			buffer.WriteByte('_')
+		}
+	}
+	name := buffer.String()
+	if name == "" {
+		name = "sheet"
+	}
+	candidate := name
+	for i := 2; used.Contains(candidate); i++ {
+		candidate = fmt.Sprintf("%s-%d", name, i)
+	}
+	used.Add(candidate)
+	return candidate + ".html"
+}
+
+func galleryPageData(data map[string]any) map[string]any {
+	data["Style"] = htmltmpl.CSS(galleryStyle)
+	data["BackLabel"] = i18n.Text("All Characters")
+	data["PlayerLabel"] = i18n.Text("Player")
+	data["PointsLabel"] = i18n.Text("Points")
+	data["AttributesLabel"] = i18n.Text("Attributes")
+	data["TraitsLabel"] = i18n.Text("Traits")
+	data["SkillsLabel"] = i18n.Text("Skills")
+	data["SpellsLabel"] = i18n.Text("Spells")
+	data["EquipmentLabel"] = i18n.Text("Equipment")
+	data["NotesLabel"] = i18n.Text("Notes")
+	return data
+}
+
+func writeGalleryPage(pagePath string, tmpl *htmltmpl.Template, data any) (err error) {
+	var f *os.File
+	if f, err = os.Create(pagePath); err != nil {
+		return errs.Wrap(err)
+	}
+	buffer := bufio.NewWriter(f)
+	defer func() {
+		if flushErr := buffer.Flush(); flushErr != nil && err == nil {
+			err = errs.Wrap(flushErr)
+		}
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = errs.Wrap(closeErr)
+		}
+	}()
+	return errs.Wrap(tmpl.Execute(buffer, data))
+}
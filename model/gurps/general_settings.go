@@ -15,6 +15,7 @@ import (
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/autoscale"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/updchan"
 	"github.com/richardwilkes/gcs/v5/model/jio"
 	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/errs"
@@ -45,6 +46,9 @@ const (
 	ImageResolutionDef         = 200
 	ImageResolutionMin         = 50
 	ImageResolutionMax         = 400
+	UIScaleMin                 = 50
+	UIScaleMax                 = 300
+	UIScaleDef                 = 100
 	InitialUIScaleMin          = 50
 	InitialUIScaleMax          = 400
 	InitialNavigatorUIScaleDef = 100
@@ -58,6 +62,9 @@ const (
 	AutoColWidthMin            = 50
 	AutoColWidthMax            = 9999
 	MaximumAutoColWidthDef     = 800
+	AutosaveIntervalDef        = 60
+	AutosaveIntervalMin        = 10
+	AutosaveIntervalMax        = 3600
 )
 
 // GeneralSettings holds general settings for a sheet.
@@ -65,11 +72,14 @@ type GeneralSettings struct {
 	DefaultPlayerName           string           `json:"default_player_name,omitempty"`
 	DefaultTechLevel            string           `json:"default_tech_level,omitempty"`
 	CalendarName                string           `json:"calendar_ref,omitempty"`
+	NameCulture                 string           `json:"name_culture,omitempty"`
+	SpellCheckLanguage          string           `json:"spell_check_language,omitempty"`
 	ExternalPDFCmdLine          string           `json:"external_pdf_cmd_line,omitempty"`
 	InitialPoints               fxp.Int          `json:"initial_points"`
 	TooltipDelay                fxp.Int          `json:"tooltip_delay"`
 	TooltipDismissal            fxp.Int          `json:"tooltip_dismissal"`
 	ScrollWheelMultiplier       fxp.Int          `json:"scroll_wheel_multiplier"`
+	UIScale                     int              `json:"ui_scale"`
 	NavigatorUIScale            int              `json:"navigator_scale"`
 	InitialListUIScale          int              `json:"initial_list_scale"`
 	InitialEditorUIScale        int              `json:"initial_editor_scale"`
@@ -79,12 +89,15 @@ type GeneralSettings struct {
 	InitialImageUIScale         int              `json:"initial_img_scale"`
 	MaximumAutoColWidth         int              `json:"maximum_auto_col_width"`
 	ImageResolution             int              `json:"image_resolution"`
+	AutosaveInterval            int              `json:"autosave_interval"`
 	MonitorResolution           int              `json:"monitor_resolution,omitempty"`
 	PDFAutoScaling              autoscale.Option `json:"pdf_auto_scaling,omitempty"`
+	UpdateChannel               updchan.Channel  `json:"update_channel,omitempty"`
 	AutoFillProfile             bool             `json:"auto_fill_profile"`
 	AutoAddNaturalAttacks       bool             `json:"add_natural_attacks"`
 	GroupContainersOnSort       bool             `json:"group_containers_on_sort"`
 	InitialFieldClickSelectsAll bool             `json:"initial_field_click_selects_all"`
+	RestoreSession              bool             `json:"restore_session"`
 }
 
 // NewGeneralSettings creates settings with factory defaults.
@@ -96,6 +109,7 @@ func NewGeneralSettings() *GeneralSettings {
 		TooltipDelay:           TooltipDelayDef,
 		TooltipDismissal:       TooltipDismissalDef,
 		ScrollWheelMultiplier:  fxp.From(unison.MouseWheelMultiplier),
+		UIScale:                UIScaleDef,
 		NavigatorUIScale:       InitialNavigatorUIScaleDef,
 		InitialListUIScale:     InitialListUIScaleDef,
 		InitialEditorUIScale:   InitialEditorUIScaleDef,
@@ -105,9 +119,11 @@ func NewGeneralSettings() *GeneralSettings {
 		InitialImageUIScale:    InitialImageUIScaleDef,
 		MaximumAutoColWidth:    MaximumAutoColWidthDef,
 		ImageResolution:        ImageResolutionDef,
+		AutosaveInterval:       AutosaveIntervalDef,
 		PDFAutoScaling:         InitialPDFAutoScaling,
 		AutoFillProfile:        true,
 		AutoAddNaturalAttacks:  true,
+		RestoreSession:         true,
 	}
 }
 
@@ -163,6 +179,8 @@ func (s *GeneralSettings) EnsureValidity() {
 		s.MonitorResolution = fxp.ResetIfOutOfRange(s.MonitorResolution, MonitorResolutionMin, MonitorResolutionMax, 0)
 	}
 	s.ImageResolution = fxp.ResetIfOutOfRange(s.ImageResolution, ImageResolutionMin, ImageResolutionMax, ImageResolutionDef)
+	s.AutosaveInterval = fxp.ResetIfOutOfRange(s.AutosaveInterval, AutosaveIntervalMin, AutosaveIntervalMax, AutosaveIntervalDef)
+	s.UIScale = fxp.ResetIfOutOfRange(s.UIScale, UIScaleMin, UIScaleMax, UIScaleDef)
 	s.NavigatorUIScale = fxp.ResetIfOutOfRange(s.NavigatorUIScale, InitialUIScaleMin, InitialUIScaleMax, InitialNavigatorUIScaleDef)
 	s.InitialListUIScale = fxp.ResetIfOutOfRange(s.InitialListUIScale, InitialUIScaleMin, InitialUIScaleMax, InitialListUIScaleDef)
 	s.InitialEditorUIScale = fxp.ResetIfOutOfRange(s.InitialEditorUIScale, InitialUIScaleMin, InitialUIScaleMax, InitialEditorUIScaleDef)
@@ -172,5 +190,6 @@ func (s *GeneralSettings) EnsureValidity() {
 	s.InitialImageUIScale = fxp.ResetIfOutOfRange(s.InitialImageUIScale, InitialUIScaleMin, InitialUIScaleMax, InitialImageUIScaleDef)
 	s.MaximumAutoColWidth = fxp.ResetIfOutOfRange(s.MaximumAutoColWidth, AutoColWidthMin, AutoColWidthMax, MaximumAutoColWidthDef)
 	s.PDFAutoScaling = s.PDFAutoScaling.EnsureValid()
+	s.UpdateChannel = s.UpdateChannel.EnsureValid()
 	s.UpdateToolTipTiming()
 }
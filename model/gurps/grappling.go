@@ -0,0 +1,117 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+// Names of the skills that use Trained ST when grappling with the Technical Grappling rules.
+const (
+	JudoSkillName          = "Judo"
+	SumoWrestlingSkillName = "Sumo Wrestling"
+	WrestlingSkillName     = "Wrestling"
+)
+
+// MaxGrapplingPenalty is the largest penalty that control points can inflict.
+const MaxGrapplingPenalty = 8
+
+// GrapplingPenaltyFor returns the penalty to DX and DX-based skills inflicted by the given control points on a victim
+// with the given control maximum. Each quarter of the control maximum that has been reached doubles the penalty, from
+// -1 up to -8. The result is zero or negative.
+func GrapplingPenaltyFor(controlPoints, controlMaximum int) int {
+	if controlPoints <= 0 {
+		return 0
+	}
+	controlMaximum = max(controlMaximum, 1)
+	penalty := 1
+	for quarter := 1; quarter < 4 && controlPoints*4 > controlMaximum*quarter; quarter++ {
+		penalty *= 2
+	}
+	return -min(penalty, MaxGrapplingPenalty)
+}
+
+// TechnicalGrappling returns true if the Technical Grappling rules are in use for this entity.
+func (e *Entity) TechnicalGrappling() bool {
+	return e != nil && SheetSettingsFor(e).TechnicalGrappling
+}
+
+// ControlMaximum returns the number of control points at which this entity is fully controlled, which is the higher of
+// its ST and HP.
+func (e *Entity) ControlMaximum() int {
+	st := e.ResolveAttributeCurrent(StrengthID).Max(0)
+	var hp fxp.Int
+	if attr, ok := e.Attributes.Set[HitPointsID]; ok {
+		hp = attr.Maximum()
+	}
+	return fxp.As[int](st.Max(hp).Trunc())
+}
+
+// GrapplingPenalty returns the penalty currently inflicted by the control points applied to this entity. Always returns
+// zero if the Technical Grappling rules are not in use.
+func (e *Entity) GrapplingPenalty() int {
+	if !e.TechnicalGrappling() {
+		return 0
+	}
+	return GrapplingPenaltyFor(e.ControlPoints, e.ControlMaximum())
+}
+
+// ClearControlPoints removes all control points applied to this entity, which should be done once it has escaped or
+// been released.
+func (e *Entity) ClearControlPoints() {
+	e.ControlPoints = 0
+}
+
+// grapplingPenaltyForAttribute returns the penalty from control points to apply to skills and spells based on the
+// attribute. Only those based on DX are affected.
+func (e *Entity) grapplingPenaltyForAttribute(attrID string, tooltip *xio.ByteBuffer) fxp.Int {
+	if attrID != DexterityID {
+		return 0
+	}
+	p := e.GrapplingPenalty()
+	if p == 0 {
+		return 0
+	}
+	penalty := fxp.From(p)
+	if tooltip != nil {
+		fmt.Fprintf(tooltip, i18n.Text("\nGrappled [%s]"), penalty.StringWithSign())
+	}
+	return penalty
+}
+
+// IsGrapplingSkill returns true if this skill benefits from Trained ST when grappling.
+func (s *Skill) IsGrapplingSkill() bool {
+	if s.Container() || s.IsTechnique() {
+		return false
+	}
+	name := s.NameWithReplacements()
+	return strings.EqualFold(name, JudoSkillName) || strings.EqualFold(name, SumoWrestlingSkillName) ||
+		strings.EqualFold(name, WrestlingSkillName)
+}
+
+// TrainedST returns the ST this skill provides when grappling under the Technical Grappling rules: ST plus the
+// relative skill level for Sumo Wrestling and Wrestling, or half of it for Judo. Skill levels below DX provide no
+// bonus. Returns 0 if this isn't a grappling skill or the Technical Grappling rules are not in use.
+func (s *Skill) TrainedST() fxp.Int {
+	e := EntityFromNode(s)
+	if !e.TechnicalGrappling() || !s.IsGrapplingSkill() || s.LevelData.Level <= 0 {
+		return 0
+	}
+	bonus := s.LevelData.RelativeLevel.Max(0)
+	if strings.EqualFold(s.NameWithReplacements(), JudoSkillName) {
+		bonus = bonus.Div(fxp.Two)
+	}
+	return (e.ResolveAttributeCurrent(StrengthID).Max(0) + bonus).Trunc()
+}
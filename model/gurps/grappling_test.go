@@ -0,0 +1,53 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/difficulty"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestGrapplingPenaltyFor(t *testing.T) {
+	check.Equal(t, 0, GrapplingPenaltyFor(0, 12), "no control points")
+	check.Equal(t, -1, GrapplingPenaltyFor(3, 12), "up to a quarter")
+	check.Equal(t, -2, GrapplingPenaltyFor(4, 12), "up to a half")
+	check.Equal(t, -4, GrapplingPenaltyFor(7, 12), "up to three quarters")
+	check.Equal(t, -8, GrapplingPenaltyFor(10, 12), "more than three quarters")
+	check.Equal(t, -MaxGrapplingPenalty, GrapplingPenaltyFor(50, 12), "capped")
+}
+
+func TestTechnicalGrappling(t *testing.T) {
+	e := NewEntity()
+	wrestling := NewSkill(e, nil, false)
+	wrestling.Name = WrestlingSkillName
+	wrestling.Points = fxp.Eight
+	judo := NewSkill(e, nil, false)
+	judo.Name = JudoSkillName
+	judo.Difficulty.Difficulty = difficulty.Hard
+	judo.Points = fxp.Twelve
+	e.SetSkillList([]*Skill{wrestling, judo})
+	e.ControlPoints = 4
+	e.Recalculate()
+	check.Equal(t, fxp.Twelve, wrestling.LevelData.Level, "no penalty without the setting")
+	check.Equal(t, fxp.Int(0), wrestling.TrainedST(), "no Trained ST without the setting")
+
+	e.SheetSettings.TechnicalGrappling = true
+	e.Recalculate()
+	check.Equal(t, 10, e.ControlMaximum())
+	check.Equal(t, -2, e.GrapplingPenalty())
+	check.Equal(t, fxp.Ten, wrestling.LevelData.Level, "control points penalize DX-based skills")
+	e.ClearControlPoints()
+	e.Recalculate()
+	check.Equal(t, fxp.Twelve, wrestling.TrainedST(), "full relative level for Wrestling")
+	check.Equal(t, fxp.Eleven, judo.TrainedST(), "half relative level for Judo")
+}
@@ -13,9 +13,11 @@ import (
 	"bytes"
 	"fmt"
 	"hash"
+	"maps"
 	"strconv"
 	"strings"
 
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wound"
 	"github.com/richardwilkes/json"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/txt"
@@ -27,15 +29,17 @@ var _ Hashable = &HitLocation{}
 
 // HitLocationData holds the Hitlocation data that gets written to disk.
 type HitLocationData struct {
-	LocID       string `json:"id"`
-	ChoiceName  string `json:"choice_name"`
-	TableName   string `json:"table_name"`
-	Slots       int    `json:"slots,omitempty"`
-	HitPenalty  int    `json:"hit_penalty,omitempty"`
-	DRBonus     int    `json:"dr_bonus,omitempty"`
-	Description string `json:"description,omitempty"`
-	Notes       string `json:"notes,omitempty"`
-	SubTable    *Body  `json:"sub_table,omitempty"`
+	LocID       string         `json:"id"`
+	ChoiceName  string         `json:"choice_name"`
+	TableName   string         `json:"table_name"`
+	Slots       int            `json:"slots,omitempty"`
+	HitPenalty  int            `json:"hit_penalty,omitempty"`
+	DRBonus     int            `json:"dr_bonus,omitempty"`
+	DRSplit     map[string]int `json:"dr_split,omitempty"`
+	Wounding    wound.Location `json:"wounding,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Notes       string         `json:"notes,omitempty"`
+	SubTable    *Body          `json:"sub_table,omitempty"`
 }
 
 // HitLocation holds a single hit location.
@@ -65,6 +69,7 @@ func (h *HitLocation) Clone(entity *Entity, owningTable *Body) *HitLocation {
 	clone := *h
 	clone.Entity = entity
 	clone.owningTable = owningTable
+	clone.DRSplit = maps.Clone(h.DRSplit)
 	if h.SubTable != nil {
 		clone.SubTable = h.SubTable.Clone(entity, &clone)
 	}
@@ -101,6 +106,7 @@ func (h *HitLocation) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &h.HitLocationData); err != nil {
 		return err
 	}
+	h.Wounding = h.Wounding.EnsureValid()
 	if h.SubTable != nil {
 		h.SubTable.SetOwningLocation(h)
 	}
@@ -135,6 +141,12 @@ func (h *HitLocation) DR(entity *Entity, tooltip *xio.ByteBuffer, drMap map[stri
 			fmt.Fprintf(tooltip, "\n%s [%+d against %s attacks]", h.ChoiceName, h.DRBonus, AllID)
 		}
 	}
+	for _, k := range h.drSplitKeys() {
+		drMap[k] += h.DRSplit[k]
+		if tooltip != nil {
+			fmt.Fprintf(tooltip, "\n%s [%+d against %s attacks]", h.ChoiceName, h.DRSplit[k], k)
+		}
+	}
 	drMap = entity.AddDRBonusesFor(h.LocID, tooltip, drMap)
 	if h.owningTable != nil && h.owningTable.owningLocation != nil {
 		drMap = h.owningTable.owningLocation.DR(entity, tooltip, drMap)
@@ -161,7 +173,79 @@ func (h *HitLocation) DR(entity *Entity, tooltip *xio.ByteBuffer, drMap map[stri
 	return drMap
 }
 
-// DisplayDR returns the DR for this location, formatted as a string.
+// DRSplitString returns the DR split in the form accepted by ParseDRSplit, e.g. "crushing: -7, burning: +2".
+func (h *HitLocation) DRSplitString() string {
+	var buffer strings.Builder
+	for _, k := range h.drSplitKeys() {
+		if buffer.Len() != 0 {
+			buffer.WriteString(", ")
+		}
+		fmt.Fprintf(&buffer, "%s: %+d", k, h.DRSplit[k])
+	}
+	return buffer.String()
+}
+
+// ParseDRSplit parses a comma-separated list of "damage type: amount" entries, as returned by DRSplitString.
+func ParseDRSplit(str string) (split map[string]int, ok bool) {
+	for _, part := range strings.Split(str, ",") {
+		if part = strings.TrimSpace(part); part == "" {
+			continue
+		}
+		k, v, found := strings.Cut(part, ":")
+		if !found {
+			return nil, false
+		}
+		if k = strings.ToLower(strings.TrimSpace(k)); k == "" || k == AllID {
+			return nil, false
+		}
+		amount, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(v), "+"))
+		if err != nil {
+			return nil, false
+		}
+		if split == nil {
+			split = make(map[string]int)
+		}
+		split[k] += amount
+	}
+	return split, true
+}
+
+func (h *HitLocation) drSplitKeys() []string {
+	keys := make([]string, 0, len(h.DRSplit))
+	for k := range h.DRSplit {
+		keys = append(keys, k)
+	}
+	txt.SortStringsNaturalAscending(keys)
+	return keys
+}
+
+// DRAgainst returns the effective DR for this location against the given damage type.
+func (h *HitLocation) DRAgainst(entity *Entity, damageType string) int {
+	drMap := h.DR(entity, nil, nil)
+	dr := drMap[AllID]
+	if damageType = strings.ToLower(strings.TrimSpace(damageType)); damageType != "" && damageType != AllID {
+		dr += drMap[damageType]
+	}
+	return dr
+}
+
+// IsFlexible returns true if the armor covering this location is flexible, i.e. no rigid armor is layered over it.
+func (h *HitLocation) IsFlexible(entity *Entity) bool {
+	flexible, rigid := h.armorLayers(entity)
+	return flexible && !rigid
+}
+
+func (h *HitLocation) armorLayers(entity *Entity) (flexible, rigid bool) {
+	flexible, rigid = entity.DRLayersFor(h.LocID)
+	if h.owningTable != nil && h.owningTable.owningLocation != nil {
+		f, r := h.owningTable.owningLocation.armorLayers(entity)
+		flexible = flexible || f
+		rigid = rigid || r
+	}
+	return flexible, rigid
+}
+
+// DisplayDR returns the DR for this location, formatted as a string. Flexible armor is marked with an asterisk.
 func (h *HitLocation) DisplayDR(entity *Entity, tooltip *xio.ByteBuffer) string {
 	drMap := h.DR(entity, tooltip, nil)
 	all, exists := drMap[AllID]
@@ -187,6 +271,9 @@ func (h *HitLocation) DisplayDR(entity *Entity, tooltip *xio.ByteBuffer) string
 		}
 		buffer.WriteString(strconv.Itoa(dr))
 	}
+	if h.IsFlexible(entity) {
+		buffer.WriteByte('*')
+	}
 	return buffer.String()
 }
 
@@ -231,6 +318,16 @@ func (h *HitLocation) Hash(hasher hash.Hash) {
 	hashhelper.Num64(hasher, h.Slots)
 	hashhelper.Num64(hasher, h.HitPenalty)
 	hashhelper.Num64(hasher, h.DRBonus)
+	if len(h.DRSplit) != 0 {
+		hashhelper.Num64(hasher, len(h.DRSplit))
+		for _, k := range h.drSplitKeys() {
+			hashhelper.String(hasher, k)
+			hashhelper.Num64(hasher, h.DRSplit[k])
+		}
+	}
+	if h.Wounding != wound.Torso {
+		hashhelper.Num8(hasher, h.Wounding)
+	}
 	hashhelper.String(hasher, h.Description)
 	hashhelper.String(hasher, h.Notes)
 	if h.SubTable != nil {
@@ -17,15 +17,20 @@ const containerKeyPostfix = "_container"
 
 // Various commonly used IDs
 const (
+	ActionPointsID     = "ap"
 	AllID              = "all"
 	BasicMoveID        = "basic_move"
 	BasicSpeedID       = "basic_speed"
 	BlockID            = "block"
 	DexterityID        = "dx"
 	DodgeID            = "dodge"
+	FatiguePointsID    = "fp"
+	HitPointsID        = "hp"
+	IntelligenceID     = "iq"
 	LiftingStrengthID  = "lifting_st"
 	MoveID             = "move"
 	ParryID            = "parry"
+	PerceptionID       = "per"
 	RitualMagicSpellID = "ritual_magic_spell"
 	SizeModifierID     = "sm"
 	SkillID            = "skill"
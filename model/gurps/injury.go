@@ -0,0 +1,232 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wound"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Names of the traits and trait modifiers that change how much injury penetrating damage causes.
+const (
+	InjuryToleranceTraitName        = "Injury Tolerance"
+	SupernaturalDurabilityTraitName = "Supernatural Durability"
+	DamageReductionModifierName     = "Damage Reduction"
+	NoVitalsModifierName            = "No Vitals"
+)
+
+// Wound holds the penetrating damage of a single attack, along with what is needed to determine the injury it causes.
+type Wound struct {
+	Penetrating fxp.Int
+	Type        wound.Type
+	Location    wound.Location
+	// FromWeakness should be true if the damage comes from the thing that can truly kill a character with Supernatural
+	// Durability.
+	FromWeakness bool
+}
+
+// InjuryResistance holds the traits of an entity that change how much injury it takes from penetrating damage.
+type InjuryResistance struct {
+	Tolerance              wound.Tolerance
+	NoVitals               bool
+	DamageReduction        int
+	SupernaturalDurability bool
+}
+
+// InjuryResistance returns the entity's resistance to injury, as determined by its enabled Injury Tolerance (B60) and
+// Supernatural Durability (B89) traits. Damage Reduction is taken from the Injury Tolerance modifier of that name; each
+// level beyond the first increases the divisor by one.
+func (e *Entity) InjuryResistance() InjuryResistance {
+	var r InjuryResistance
+	Traverse(func(t *Trait) bool {
+		name := t.NameWithReplacements()
+		switch {
+		case strings.EqualFold(name, SupernaturalDurabilityTraitName):
+			r.SupernaturalDurability = true
+		case strings.EqualFold(name, InjuryToleranceTraitName):
+			for _, tolerance := range []wound.Tolerance{wound.Diffuse, wound.Homogenous, wound.Unliving} {
+				if r.Tolerance < tolerance && t.ActiveModifierFor(tolerance.Key()) != nil {
+					r.Tolerance = tolerance
+				}
+			}
+			if t.ActiveModifierFor(NoVitalsModifierName) != nil {
+				r.NoVitals = true
+			}
+			if mod := t.ActiveModifierFor(DamageReductionModifierName); mod != nil {
+				r.DamageReduction = max(r.DamageReduction, 1+max(fxp.As[int](mod.CurrentLevel()), 1))
+			}
+		}
+		return false
+	}, true, true, e.Traits...)
+	return r
+}
+
+// HasVitals returns true if vital organs can be targeted. Homogenous and Diffuse characters never have any.
+func (r *InjuryResistance) HasVitals() bool {
+	return !r.NoVitals && r.Tolerance != wound.Homogenous && r.Tolerance != wound.Diffuse
+}
+
+// WoundingModifier returns the wounding modifier (B379) for the wound, taking into account the location that was hit
+// and any Injury Tolerance (B380). The modifier is returned as a fraction, so that modifiers such as 1/3 don't suffer
+// from rounding.
+func (r *InjuryResistance) WoundingModifier(w *Wound) (numerator, denominator int) {
+	location := w.Location
+	if location == wound.Vitals && !r.HasVitals() {
+		location = wound.Torso
+	}
+	switch location {
+	case wound.Vitals:
+		if isImpalingOrPiercing(w.Type) {
+			return 3, 1
+		}
+	case wound.Skull:
+		if w.Type != wound.Toxic {
+			return 4, 1
+		}
+	case wound.Face:
+		if w.Type == wound.Corrosion {
+			return 3, 2
+		}
+	case wound.Neck:
+		switch w.Type {
+		case wound.Crushing, wound.Corrosion:
+			return 3, 2
+		case wound.Cutting:
+			return 2, 1
+		default:
+		}
+	default:
+	}
+	if isImpalingOrPiercing(w.Type) {
+		switch r.Tolerance {
+		case wound.Unliving:
+			switch w.Type {
+			case wound.Impaling, wound.HugePiercing:
+				return 1, 1
+			case wound.LargePiercing:
+				return 1, 2
+			case wound.Piercing:
+				return 1, 3
+			default:
+				return 1, 5
+			}
+		case wound.Homogenous:
+			switch w.Type {
+			case wound.Impaling, wound.HugePiercing:
+				return 1, 2
+			case wound.LargePiercing:
+				return 1, 3
+			case wound.Piercing:
+				return 1, 5
+			default:
+				return 1, 10
+			}
+		default:
+		}
+	}
+	if location == wound.Limb {
+		switch w.Type {
+		case wound.Impaling, wound.LargePiercing, wound.HugePiercing:
+			return 1, 1
+		default:
+		}
+	}
+	switch w.Type {
+	case wound.Cutting, wound.LargePiercing:
+		return 3, 2
+	case wound.Impaling, wound.HugePiercing:
+		return 2, 1
+	case wound.SmallPiercing:
+		return 1, 2
+	default:
+		return 1, 1
+	}
+}
+
+func isImpalingOrPiercing(dmgType wound.Type) bool {
+	switch dmgType {
+	case wound.Impaling, wound.SmallPiercing, wound.Piercing, wound.LargePiercing, wound.HugePiercing:
+		return true
+	default:
+		return false
+	}
+}
+
+// Injury returns the injury the wound causes to the entity, after applying the wounding modifier, Injury Tolerance,
+// Damage Reduction (B46) and Supernatural Durability.
+func (e *Entity) Injury(w *Wound) fxp.Int {
+	if w.Penetrating <= 0 {
+		return 0
+	}
+	r := e.InjuryResistance()
+	numerator, denominator := r.WoundingModifier(w)
+	if r.DamageReduction > 1 {
+		denominator *= r.DamageReduction
+	}
+	// Fractions of injury are dropped, but any penetrating damage causes at least 1 point of injury (B379)
+	injury := max(w.Penetrating.Mul(fxp.From(numerator)).Div(fxp.From(denominator)).Trunc(), fxp.One)
+	if r.Tolerance == wound.Diffuse {
+		// Diffuse characters never take more than 1 injury from impaling or piercing attacks, or 2 from anything else
+		if isImpalingOrPiercing(w.Type) {
+			injury = min(injury, fxp.One)
+		} else {
+			injury = min(injury, fxp.Two)
+		}
+	}
+	if r.SupernaturalDurability && !w.FromWeakness {
+		// Only the character's weakness can take them to the point of death
+		if attr, ok := e.Attributes.Set[HitPointsID]; ok {
+			injury = max(min(injury, attr.Current()+attr.Temporary+fxp.Five.Mul(attr.Maximum())-fxp.One), 0)
+		}
+	}
+	return injury
+}
+
+// InjuryExplanation returns a short description of the wound and the modifiers that determined its injury, suitable
+// for use as a note.
+func (e *Entity) InjuryExplanation(w *Wound) string {
+	text := w.Penetrating.Comma() + " " + w.Type.Key()
+	if w.Location != wound.Torso {
+		text += fmt.Sprintf(i18n.Text(" to the %s"), w.Location)
+	}
+	r := e.InjuryResistance()
+	numerator, denominator := r.WoundingModifier(w)
+	if denominator == 1 {
+		text += fmt.Sprintf(", ×%d", numerator)
+	} else {
+		text += fmt.Sprintf(", ×%d/%d", numerator, denominator)
+	}
+	if r.DamageReduction > 1 {
+		text += fmt.Sprintf(i18n.Text(", Damage Reduction ÷%d"), r.DamageReduction)
+	}
+	return text
+}
+
+// String returns a description of the traits in effect, or an empty string if there are none.
+func (r *InjuryResistance) String() string {
+	var parts []string
+	if r.Tolerance != wound.None {
+		parts = append(parts, r.Tolerance.String())
+	}
+	if r.NoVitals {
+		parts = append(parts, i18n.Text("No Vitals"))
+	}
+	if r.DamageReduction > 1 {
+		parts = append(parts, fmt.Sprintf(i18n.Text("Damage Reduction ÷%d"), r.DamageReduction))
+	}
+	if r.SupernaturalDurability {
+		parts = append(parts, i18n.Text("Supernatural Durability"))
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wound"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestInjury(t *testing.T) {
+	e := NewEntity()
+	six := fxp.Six
+	check.Equal(t, fxp.Nine, e.Injury(&Wound{Penetrating: six, Type: wound.Cutting}), "cutting")
+	check.Equal(t, fxp.One, e.Injury(&Wound{Penetrating: fxp.One, Type: wound.SmallPiercing}), "minimum injury")
+	check.Equal(t, fxp.From(18), e.Injury(&Wound{Penetrating: six, Type: wound.Piercing, Location: wound.Vitals}),
+		"piercing to the vitals")
+
+	tolerance := NewTrait(e, nil, false)
+	tolerance.Name = InjuryToleranceTraitName
+	e.SetTraitList([]*Trait{tolerance})
+	setModifiers := func(names ...string) {
+		tolerance.Modifiers = nil
+		for _, name := range names {
+			mod := NewTraitModifier(e, nil, false)
+			mod.Name = name
+			tolerance.Modifiers = append(tolerance.Modifiers, mod)
+		}
+	}
+
+	setModifiers("Unliving")
+	check.Equal(t, fxp.Two, e.Injury(&Wound{Penetrating: six, Type: wound.Piercing}), "unliving piercing")
+	check.Equal(t, fxp.From(18), e.Injury(&Wound{Penetrating: six, Type: wound.Piercing, Location: wound.Vitals}),
+		"unliving still have vitals")
+	check.Equal(t, fxp.Six, e.Injury(&Wound{Penetrating: six, Type: wound.Crushing}), "unliving crushing")
+
+	setModifiers("Homogenous")
+	check.Equal(t, fxp.Three, e.Injury(&Wound{Penetrating: six, Type: wound.Impaling, Location: wound.Vitals}),
+		"homogenous have no vitals")
+
+	setModifiers("Diffuse")
+	check.Equal(t, fxp.One, e.Injury(&Wound{Penetrating: six, Type: wound.Impaling}), "diffuse impaling")
+	check.Equal(t, fxp.Two, e.Injury(&Wound{Penetrating: six, Type: wound.Burning}), "diffuse burning")
+
+	setModifiers(NoVitalsModifierName, DamageReductionModifierName)
+	check.Equal(t, fxp.Six, e.Injury(&Wound{Penetrating: six, Type: wound.Impaling, Location: wound.Vitals}),
+		"no vitals with damage reduction")
+	tolerance.Modifiers[1].Levels = fxp.Two
+	check.Equal(t, fxp.Four, e.Injury(&Wound{Penetrating: six, Type: wound.Impaling}), "damage reduction level 2")
+
+	durability := NewTrait(e, nil, false)
+	durability.Name = SupernaturalDurabilityTraitName
+	e.SetTraitList([]*Trait{durability})
+	check.Equal(t, fxp.From(59), e.Injury(&Wound{Penetrating: fxp.From(100), Type: wound.Crushing}),
+		"supernatural durability stops short of death")
+	check.Equal(t, fxp.From(100), e.Injury(&Wound{Penetrating: fxp.From(100), Type: wound.Crushing, FromWeakness: true}),
+		"supernatural durability doesn't protect against the weakness")
+}
+
+func TestWoundingByLocation(t *testing.T) {
+	e := NewEntity()
+	six := fxp.Six
+	check.Equal(t, fxp.Nine, e.Injury(&Wound{Penetrating: six, Type: wound.Corrosion, Location: wound.Face}),
+		"corrosion to the face")
+	check.Equal(t, fxp.From(12), e.Injury(&Wound{Penetrating: six, Type: wound.Cutting, Location: wound.Neck}),
+		"cutting to the neck")
+	check.Equal(t, fxp.Nine, e.Injury(&Wound{Penetrating: six, Type: wound.Crushing, Location: wound.Neck}),
+		"crushing to the neck")
+	check.Equal(t, fxp.Six, e.Injury(&Wound{Penetrating: six, Type: wound.Impaling, Location: wound.Limb}),
+		"impaling to a limb")
+	check.Equal(t, fxp.Nine, e.Injury(&Wound{Penetrating: six, Type: wound.Cutting, Location: wound.Limb}),
+		"cutting to a limb")
+
+	locations := make(map[string]wound.Location)
+	for _, loc := range FactoryBody().Locations {
+		locations[loc.LocID] = loc.Wounding
+	}
+	check.Equal(t, wound.Skull, locations["skull"])
+	check.Equal(t, wound.Limb, locations["hand"])
+	check.Equal(t, wound.Torso, locations["torso"])
+}
@@ -158,6 +158,14 @@ func (b *KeyBindings) Current(id string) unison.KeyBinding {
 	return unison.KeyBinding{}
 }
 
+// FactoryKeyBinding returns the default binding for the given ID.
+func FactoryKeyBinding(id string) unison.KeyBinding {
+	if f, ok := factoryBindings[id]; ok {
+		return f.KeyBinding
+	}
+	return unison.KeyBinding{}
+}
+
 // Set the binding for the given ID.
 func (b *KeyBindings) Set(id string, binding unison.KeyBinding) {
 	if f, ok := factoryBindings[id]; ok {
@@ -183,3 +191,19 @@ func (b *KeyBindings) ResetOne(id string) {
 		delete(b.data, id)
 	}
 }
+
+// Conflicts returns the IDs of the other bindings that currently use the same keys as the given binding would, in
+// sorted order. An empty binding never conflicts.
+func (b *KeyBindings) Conflicts(id string, binding unison.KeyBinding) []string {
+	if binding.KeyCode == 0 || binding.KeyCode == unison.KeyNone {
+		return nil
+	}
+	var list []string
+	for k := range factoryBindings {
+		if k != id && b.Current(k) == binding {
+			list = append(list, k)
+		}
+	}
+	slices.Sort(list)
+	return list
+}
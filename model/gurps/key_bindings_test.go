@@ -0,0 +1,37 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/unison"
+)
+
+func TestKeyBindingConflicts(t *testing.T) {
+	first := unison.KeyBinding{KeyCode: unison.KeyF, Modifiers: unison.ControlModifier | unison.ShiftModifier}
+	second := unison.KeyBinding{KeyCode: unison.KeyG, Modifiers: unison.ControlModifier | unison.ShiftModifier}
+	gurps.RegisterKeyBinding("test.conflict.first", &unison.Action{KeyBinding: first})
+	gurps.RegisterKeyBinding("test.conflict.second", &unison.Action{KeyBinding: second})
+
+	check.Equal(t, first, gurps.FactoryKeyBinding("test.conflict.first"))
+	var b gurps.KeyBindings
+	check.Equal(t, 0, len(b.Conflicts("test.conflict.second", second)))
+	check.Equal(t, []string{"test.conflict.first"}, b.Conflicts("test.conflict.second", first))
+	check.Equal(t, 0, len(b.Conflicts("test.conflict.first", first)), "a binding doesn't conflict with itself")
+	check.Equal(t, 0, len(b.Conflicts("test.conflict.second", unison.KeyBinding{})), "empty bindings never conflict")
+
+	b.Set("test.conflict.first", unison.KeyBinding{})
+	check.Equal(t, 0, len(b.Conflicts("test.conflict.second", first)), "cleared bindings no longer conflict")
+	b.Set("test.conflict.first", second)
+	check.Equal(t, []string{"test.conflict.first"}, b.Conflicts("test.conflict.second", second))
+}
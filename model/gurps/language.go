@@ -0,0 +1,329 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"hash"
+	"maps"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/cell"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/comprehension"
+	"github.com/richardwilkes/gcs/v5/model/kinds"
+	"github.com/richardwilkes/gcs/v5/model/nameable"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/toolbox/xmath/hashhelper"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+var (
+	_ Node[*Language]       = &Language{}
+	_ EditorData[*Language] = &LanguageEditData{}
+)
+
+// Columns that can be used with the language method .CellData()
+const (
+	LanguageNameColumn = iota
+	LanguageSpokenColumn
+	LanguageWrittenColumn
+	LanguagePointsColumn
+	LanguageReferenceColumn
+)
+
+// Language holds a language the character has some comprehension of.
+type Language struct {
+	LanguageData
+	owner DataOwner
+}
+
+// LanguageData holds the Language data that is written to disk.
+type LanguageData struct {
+	TID tid.TID `json:"id"`
+	LanguageEditData
+	ThirdParty map[string]any `json:"third_party,omitempty"`
+}
+
+// LanguageEditData holds the Language data that can be edited by the UI detail editor.
+type LanguageEditData struct {
+	Name             string              `json:"name,omitempty"`
+	Spoken           comprehension.Level `json:"spoken,omitempty"`
+	Written          comprehension.Level `json:"written,omitempty"`
+	Native           bool                `json:"native,omitempty"`
+	LocalNotes       string              `json:"notes,omitempty"`
+	PageRef          string              `json:"reference,omitempty"`
+	PageRefHighlight string              `json:"reference_highlight,omitempty"`
+	Replacements     map[string]string   `json:"replacements,omitempty"`
+}
+
+// NewLanguage creates a new Language.
+func NewLanguage(owner DataOwner) *Language {
+	var l Language
+	l.TID = tid.MustNewTID(kinds.Language)
+	l.owner = owner
+	l.Name = l.Kind()
+	l.Spoken = comprehension.Native
+	l.Written = comprehension.Native
+	return &l
+}
+
+// ID returns the local ID of this data.
+func (l *Language) ID() tid.TID {
+	return l.TID
+}
+
+// Container returns true if this is a container.
+func (l *Language) Container() bool {
+	return false
+}
+
+// HasChildren returns true if this node has children.
+func (l *Language) HasChildren() bool {
+	return false
+}
+
+// NodeChildren returns the children of this node, if any.
+func (l *Language) NodeChildren() []*Language {
+	return nil
+}
+
+// SetChildren sets the children of this node.
+func (l *Language) SetChildren(_ []*Language) {
+}
+
+// Parent returns the parent.
+func (l *Language) Parent() *Language {
+	return nil
+}
+
+// SetParent sets the parent.
+func (l *Language) SetParent(_ *Language) {
+}
+
+// IsOpen returns true if this node is currently open.
+func (l *Language) IsOpen() bool {
+	return false
+}
+
+// SetOpen sets the current open state for this node.
+func (l *Language) SetOpen(_ bool) {
+}
+
+// Clone implements Node.
+func (l *Language) Clone(_ LibraryFile, owner DataOwner, _ *Language, preserveID bool) *Language {
+	other := NewLanguage(owner)
+	if preserveID {
+		other.TID = l.TID
+	}
+	other.ThirdParty = l.ThirdParty
+	other.LanguageEditData.CopyFrom(l)
+	return other
+}
+
+// MarshalJSON implements json.Marshaler.
+func (l *Language) MarshalJSON() ([]byte, error) {
+	type calc struct {
+		Points fxp.Int `json:"points"`
+	}
+	data := struct {
+		LanguageData
+		Calc calc `json:"calc"`
+	}{
+		LanguageData: l.LanguageData,
+		Calc:         calc{Points: l.AdjustedPoints()},
+	}
+	return json.Marshal(&data)
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *Language) UnmarshalJSON(data []byte) error {
+	l.LanguageData = LanguageData{}
+	if err := json.Unmarshal(data, &l.LanguageData); err != nil {
+		return err
+	}
+	if !tid.IsKindAndValid(l.TID, kinds.Language) {
+		l.TID = tid.MustNewTID(kinds.Language)
+	}
+	l.Spoken = l.Spoken.EnsureValid()
+	l.Written = l.Written.EnsureValid()
+	return nil
+}
+
+// AdjustedPoints returns the point cost of the language. Each of the spoken and written components costs 1 point for
+// Broken, 2 points for Accented and 3 points for Native comprehension. A native language is known at the Native level
+// for free, so reduced comprehension of it is worth negative points.
+func (l *Language) AdjustedPoints() fxp.Int {
+	pts := l.Spoken.PointCost() + l.Written.PointCost()
+	if l.Native {
+		pts -= comprehension.Native.PointCost() * 2
+	}
+	return pts
+}
+
+// NameWithReplacements returns the name with any replacements applied.
+func (l *Language) NameWithReplacements() string {
+	return nameable.Apply(l.Name, l.Replacements)
+}
+
+// LocalNotesWithReplacements returns the local notes with any replacements applied.
+func (l *Language) LocalNotesWithReplacements() string {
+	return nameable.Apply(l.LocalNotes, l.Replacements)
+}
+
+// ComprehensionText returns a description of the spoken and written comprehension levels.
+func (l *Language) ComprehensionText() string {
+	if l.Spoken == l.Written {
+		return l.Spoken.String()
+	}
+	return fmt.Sprintf(i18n.Text("Spoken: %s, Written: %s"), l.Spoken.String(), l.Written.String())
+}
+
+func (l *Language) String() string {
+	return l.NameWithReplacements()
+}
+
+// LanguagesHeaderData returns the header data information for the given language column.
+func LanguagesHeaderData(columnID int) HeaderData {
+	var data HeaderData
+	switch columnID {
+	case LanguageNameColumn:
+		data.Title = i18n.Text("Language")
+		data.Primary = true
+	case LanguageSpokenColumn:
+		data.Title = i18n.Text("Spoken")
+		data.Detail = i18n.Text("Spoken comprehension")
+	case LanguageWrittenColumn:
+		data.Title = i18n.Text("Written")
+		data.Detail = i18n.Text("Written comprehension")
+	case LanguagePointsColumn:
+		data.Title = i18n.Text("Pts")
+		data.Detail = i18n.Text("Points")
+	case LanguageReferenceColumn:
+		data.Title = HeaderBookmark
+		data.TitleIsImageKey = true
+		data.Detail = PageRefTooltip()
+	}
+	return data
+}
+
+// CellData returns the cell data information for the given column.
+func (l *Language) CellData(columnID int, data *CellData) {
+	switch columnID {
+	case LanguageNameColumn:
+		data.Type = cell.Text
+		data.Primary = l.String()
+		data.Secondary = l.LocalNotesWithReplacements()
+		if l.Native {
+			data.InlineTag = i18n.Text("Native")
+		}
+	case LanguageSpokenColumn:
+		data.Type = cell.Text
+		data.Primary = l.Spoken.String()
+	case LanguageWrittenColumn:
+		data.Type = cell.Text
+		data.Primary = l.Written.String()
+	case LanguagePointsColumn:
+		data.Type = cell.Text
+		data.Primary = l.AdjustedPoints().String()
+		data.Alignment = align.End
+	case LanguageReferenceColumn, PageRefCellAlias:
+		data.Type = cell.PageRef
+		data.Primary = l.PageRef
+		if l.PageRefHighlight != "" {
+			data.Secondary = l.PageRefHighlight
+		} else {
+			data.Secondary = l.NameWithReplacements()
+		}
+	}
+}
+
+// DataOwner returns the data owner.
+func (l *Language) DataOwner() DataOwner {
+	return l.owner
+}
+
+// SetDataOwner sets the data owner and configures any sub-components as needed.
+func (l *Language) SetDataOwner(owner DataOwner) {
+	l.owner = owner
+}
+
+// Enabled returns true if this node is enabled.
+func (l *Language) Enabled() bool {
+	return true
+}
+
+// NameableReplacements returns the replacements to be used with Nameables.
+func (l *Language) NameableReplacements() map[string]string {
+	if l == nil {
+		return nil
+	}
+	return l.Replacements
+}
+
+// FillWithNameableKeys adds any nameable keys found to the provided map.
+func (l *Language) FillWithNameableKeys(m, existing map[string]string) {
+	if existing == nil {
+		existing = l.Replacements
+	}
+	nameable.Extract(l.Name, m, existing)
+	nameable.Extract(l.LocalNotes, m, existing)
+}
+
+// ApplyNameableKeys replaces any nameable keys found with the corresponding values in the provided map.
+func (l *Language) ApplyNameableKeys(m map[string]string) {
+	needed := make(map[string]string)
+	l.FillWithNameableKeys(needed, nil)
+	l.Replacements = nameable.Reduce(needed, m)
+}
+
+// Kind returns the kind of data.
+func (l *Language) Kind() string {
+	return i18n.Text("Language")
+}
+
+// GetSource returns the source of this data. Languages are not drawn from libraries, so this is always empty.
+func (l *Language) GetSource() Source {
+	return Source{}
+}
+
+// ClearSource clears the source of this data.
+func (l *Language) ClearSource() {
+}
+
+// SyncWithSource synchronizes this data with the source.
+func (l *Language) SyncWithSource() {
+}
+
+// Hash writes this object's contents into the hasher.
+func (l *Language) Hash(h hash.Hash) {
+	hashhelper.String(h, l.Name)
+	hashhelper.Num8(h, l.Spoken)
+	hashhelper.Num8(h, l.Written)
+	hashhelper.Bool(h, l.Native)
+	hashhelper.String(h, l.PageRef)
+	hashhelper.String(h, l.PageRefHighlight)
+}
+
+// CopyFrom implements node.EditorData.
+func (l *LanguageEditData) CopyFrom(other *Language) {
+	l.copyFrom(&other.LanguageEditData)
+}
+
+// ApplyTo implements node.EditorData.
+func (l *LanguageEditData) ApplyTo(other *Language) {
+	other.LanguageEditData.copyFrom(l)
+}
+
+func (l *LanguageEditData) copyFrom(other *LanguageEditData) {
+	*l = *other
+	l.Replacements = maps.Clone(other.Replacements)
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/comprehension"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestLanguagePoints(t *testing.T) {
+	e := NewEntity()
+	native := NewLanguage(e)
+	native.Native = true
+	check.Equal(t, fxp.Int(0), native.AdjustedPoints(), "native language at native levels")
+	native.Written = comprehension.None
+	check.Equal(t, -fxp.Three, native.AdjustedPoints(), "illiterate in native language")
+
+	other := NewLanguage(e)
+	other.Spoken = comprehension.Accented
+	other.Written = comprehension.Broken
+	check.Equal(t, fxp.Three, other.AdjustedPoints(), "accented spoken, broken written")
+
+	before := e.PointsBreakdown()
+	e.SetLanguageList([]*Language{native, other})
+	after := e.PointsBreakdown()
+	check.Equal(t, before.Advantages+fxp.Three, after.Advantages, "advantages")
+	check.Equal(t, before.Disadvantages-fxp.Three, after.Disadvantages, "disadvantages")
+}
@@ -18,11 +18,23 @@ import (
 	"github.com/richardwilkes/toolbox/xmath/hashhelper"
 )
 
-// LeveledAmount holds an amount that can be either a fixed amount, or an amount per level.
+// LeveledAmount holds an amount that can be either a fixed amount, or an amount per level. An amount per level may
+// instead be given by a formula, which can refer to the level as $level, e.g. "2*$level+1".
 type LeveledAmount struct {
-	Level    fxp.Int `json:"-"`
-	Amount   fxp.Int `json:"amount"`
-	PerLevel bool    `json:"per_level,omitempty"`
+	Level        fxp.Int `json:"-"`
+	Amount       fxp.Int `json:"amount"`
+	PerLevel     bool    `json:"per_level,omitempty"`
+	LevelFormula string  `json:"level_formula,omitempty"`
+}
+
+type levelVariableResolver fxp.Int
+
+// ResolveVariable implements eval.VariableResolver.
+func (l levelVariableResolver) ResolveVariable(variableName string) string {
+	if variableName == "level" {
+		return fxp.Int(l).String()
+	}
+	return ""
 }
 
 // AdjustedAmount returns the amount, adjusted for level, if requested.
@@ -31,6 +43,9 @@ func (l *LeveledAmount) AdjustedAmount() fxp.Int {
 		if l.Level < 0 {
 			return 0
 		}
+		if l.LevelFormula != "" {
+			return fxp.EvaluateToNumber(l.LevelFormula, levelVariableResolver(l.Level))
+		}
 		return l.Amount.Mul(l.Level)
 	}
 	return l.Amount
@@ -47,6 +62,9 @@ func (l *LeveledAmount) Format(asPercentage bool) string {
 		if asPercentage {
 			leveled += "%"
 		}
+		if l.LevelFormula != "" {
+			return fmt.Sprintf(i18n.Text("%s (%s)"), leveled, l.LevelFormula)
+		}
 		return fmt.Sprintf(i18n.Text("%s (%s per level)"), leveled, amt)
 	}
 	return amt
@@ -60,4 +78,7 @@ func (l *LeveledAmount) Hash(h hash.Hash) {
 	}
 	hashhelper.Num64(h, l.Amount)
 	hashhelper.Bool(h, l.PerLevel)
+	if l.LevelFormula != "" {
+		hashhelper.String(h, l.LevelFormula)
+	}
 }
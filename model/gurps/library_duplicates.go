@@ -0,0 +1,153 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+// LibraryDuplicates holds library items of the same kind whose names are identical or differ only in case, spacing or
+// punctuation.
+type LibraryDuplicates struct {
+	Name      string
+	Kind      string
+	Identical bool // true if the items also have the same content
+	Items     []*LibrarySearchResult
+}
+
+// FindDuplicates returns the groups of items in the index that appear to be duplicates of each other, sorted by name.
+// Containers are ignored, since it is common for many files to use the same names for them.
+func (idx *LibrarySearchIndex) FindDuplicates() []*LibraryDuplicates {
+	type groupKey struct {
+		kind string
+		name string
+	}
+	groups := make(map[groupKey]*LibraryDuplicates)
+	var order []groupKey
+	for _, entry := range idx.entries {
+		if node, ok := entry.result.Item.(Openable); ok && node.Container() {
+			continue
+		}
+		key := groupKey{kind: entry.result.Kind, name: duplicateNameKey(entry.result.Name)}
+		if key.name == "" {
+			continue
+		}
+		group, exists := groups[key]
+		if !exists {
+			group = &LibraryDuplicates{
+				Name: entry.result.Name,
+				Kind: entry.result.Kind,
+			}
+			groups[key] = group
+			order = append(order, key)
+		}
+		result := entry.result
+		group.Items = append(group.Items, &result)
+	}
+	var list []*LibraryDuplicates
+	for _, key := range order {
+		group := groups[key]
+		if len(group.Items) < 2 {
+			continue
+		}
+		group.Identical = true
+		var first uint64
+		for i, one := range group.Items {
+			h, ok := one.Item.(Hashable)
+			if !ok {
+				group.Identical = false
+				break
+			}
+			if i == 0 {
+				first = Hash64(h)
+			} else if Hash64(h) != first {
+				group.Identical = false
+				break
+			}
+		}
+		list = append(list, group)
+	}
+	slices.SortStableFunc(list, func(a, b *LibraryDuplicates) int {
+		if c := txt.NaturalCmp(a.Name, b.Name, true); c != 0 {
+			return c
+		}
+		return txt.NaturalCmp(a.Kind, b.Kind, true)
+	})
+	return list
+}
+
+// duplicateNameKey returns the name in a form that ignores differences in case, spacing and punctuation.
+func duplicateNameKey(name string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(name), func(ch rune) bool {
+		return !unicode.IsLetter(ch) && !unicode.IsDigit(ch)
+	}), " ")
+}
+
+// RemoveFromLibraryFile removes the items with the given IDs, along with any children they have, from the library file.
+func RemoveFromLibraryFile(libraries Libraries, from LibraryFile, ids ...tid.TID) error {
+	lib, ok := libraries[from.Library]
+	if !ok {
+		return errs.Newf(i18n.Text("Unknown library: %s"), from.Library)
+	}
+	idSet := make(map[tid.TID]bool, len(ids))
+	for _, id := range ids {
+		idSet[id] = true
+	}
+	fileSystem := os.DirFS(lib.Path())
+	filePath := filepath.Join(lib.Path(), from.Path)
+	switch strings.ToLower(path.Ext(from.Path)) {
+	case TraitsExt:
+		return removeFromFile(fileSystem, from.Path, filePath, idSet, NewTraitsFromFile, SaveTraits)
+	case SkillsExt:
+		return removeFromFile(fileSystem, from.Path, filePath, idSet, NewSkillsFromFile, SaveSkills)
+	case SpellsExt:
+		return removeFromFile(fileSystem, from.Path, filePath, idSet, NewSpellsFromFile, SaveSpells)
+	case EquipmentExt:
+		return removeFromFile(fileSystem, from.Path, filePath, idSet, NewEquipmentFromFile, SaveEquipment)
+	case NotesExt:
+		return removeFromFile(fileSystem, from.Path, filePath, idSet, NewNotesFromFile, SaveNotes)
+	default:
+		return errs.Newf(i18n.Text("Unsupported file type: %s"), from.Path)
+	}
+}
+
+func removeFromFile[T NodeTypes](fileSystem fs.FS, fsPath, filePath string, ids map[tid.TID]bool, load func(fs.FS, string) ([]T, error), save func([]T, string) error) error {
+	data, err := load(fileSystem, fsPath)
+	if err != nil {
+		return err
+	}
+	return save(withoutIDs(data, ids), filePath)
+}
+
+func withoutIDs[T NodeTypes](list []T, ids map[tid.TID]bool) []T {
+	result := make([]T, 0, len(list))
+	for _, one := range list {
+		node := AsNode(one)
+		if ids[node.ID()] {
+			continue
+		}
+		if node.HasChildren() {
+			node.SetChildren(withoutIDs(node.NodeChildren(), ids))
+		}
+		result = append(result, one)
+	}
+	return result
+}
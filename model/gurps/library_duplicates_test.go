@@ -0,0 +1,56 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestLibraryDuplicates(t *testing.T) {
+	coreDir := t.TempDir()
+	check.NoError(t, os.WriteFile(filepath.Join(coreDir, "Traits.adq"), []byte(`{"version":5,"rows":[
+  {"id":"taaaaaaaaaaaaaaaa","name":"Combat Reflexes","reference":"B43"},
+  {"id":"tbbbbbbbbbbbbbbbb","name":"Night Vision","reference":"B71"},
+  {"id":"Taaaaaaaaaaaaaaaa","name":"Mental","children":[]}
+]}`), 0o600))
+	homebrewDir := t.TempDir()
+	check.NoError(t, os.WriteFile(filepath.Join(homebrewDir, "Mine.adq"), []byte(`{"version":5,"rows":[
+  {"id":"tcccccccccccccccc","name":"Night Vision","reference":"B71"},
+  {"id":"tdddddddddddddddd","name":"combat-reflexes","reference":"HB1"},
+  {"id":"teeeeeeeeeeeeeeee","name":"Unique"},
+  {"id":"Tbbbbbbbbbbbbbbbb","name":"Mental","children":[]}
+]}`), 0o600))
+	core := &gurps.Library{GitHubAccountName: "test", RepoName: "core", PathOnDisk: coreDir}
+	homebrew := &gurps.Library{GitHubAccountName: "test", RepoName: "homebrew", PathOnDisk: homebrewDir}
+	libraries := gurps.Libraries{core.Key(): core, homebrew.Key(): homebrew}
+
+	dups := gurps.NewLibrarySearchIndex(libraries).FindDuplicates()
+	check.Equal(t, 2, len(dups), "containers and unique items aren't reported")
+	check.Equal(t, "Combat Reflexes", dups[0].Name)
+	check.Equal(t, 2, len(dups[0].Items))
+	check.False(t, dups[0].Identical, "names differ only in case and punctuation, but the content differs")
+	check.Equal(t, "Night Vision", dups[1].Name)
+	check.True(t, dups[1].Identical)
+
+	mine := gurps.LibraryFile{Library: homebrew.Key(), Path: "Mine.adq"}
+	check.NoError(t, gurps.RemoveFromLibraryFile(libraries, mine, "tcccccccccccccccc", "tdddddddddddddddd"))
+	traits, err := gurps.NewTraitsFromFile(os.DirFS(homebrewDir), "Mine.adq")
+	check.NoError(t, err)
+	check.Equal(t, 2, len(traits))
+	check.Equal(t, "Unique", traits[0].Name)
+	check.Equal(t, 0, len(gurps.NewLibrarySearchIndex(libraries).FindDuplicates()))
+
+	check.Error(t, gurps.RemoveFromLibraryFile(libraries, gurps.LibraryFile{Library: "missing", Path: "Mine.adq"}))
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+// Weights used when ranking library search results.
+const (
+	librarySearchExactNameScore = 100
+	librarySearchNameWordScore  = 20
+	librarySearchNameScore      = 10
+	librarySearchTagScore       = 5
+	librarySearchDescribedScore = 2
+)
+
+const librarySearchNoteNameMaxSize = 60
+
+// LibrarySearchResult holds an item found by a library search, along with the file it came from.
+type LibrarySearchResult struct {
+	From  LibraryFile
+	Item  any // One of *Trait, *Skill, *Spell, *Equipment or *Note
+	Name  string
+	Kind  string
+	Score int
+}
+
+type librarySearchEntry struct {
+	result LibrarySearchResult
+	name   string
+	tags   []string
+	text   string
+}
+
+// LibrarySearchIndex holds the searchable text of the items in a set of library files.
+type LibrarySearchIndex struct {
+	entries []*librarySearchEntry
+}
+
+// NewLibrarySearchIndex creates a new LibrarySearchIndex covering the trait, skill, spell, equipment and note files in
+// the libraries. Files that can't be loaded are logged and skipped.
+func NewLibrarySearchIndex(libraries Libraries) *LibrarySearchIndex {
+	var idx LibrarySearchIndex
+	for _, lib := range libraries.List() {
+		fileSystem := os.DirFS(lib.Path())
+		_ = fs.WalkDir(fileSystem, ".", func(p string, d fs.DirEntry, err error) error { //nolint:errcheck // Intentionally ignored the error result
+			if err != nil {
+				return nil
+			}
+			if strings.HasPrefix(d.Name(), ".") && p != "." {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+			if err = idx.AddFile(fileSystem, LibraryFile{Library: lib.Key(), Path: p}); err != nil {
+				errs.Log(err, "library", lib.Title, "path", p)
+			}
+			return nil
+		})
+	}
+	return &idx
+}
+
+// AddFile adds the items in the file to the index. Files that aren't trait, skill, spell, equipment or note files are
+// ignored.
+func (idx *LibrarySearchIndex) AddFile(fileSystem fs.FS, from LibraryFile) error {
+	switch strings.ToLower(path.Ext(from.Path)) {
+	case TraitsExt:
+		traits, err := NewTraitsFromFile(fileSystem, from.Path)
+		if err != nil {
+			return err
+		}
+		Traverse(func(t *Trait) bool {
+			idx.add(from, t, t.Name, i18n.Text("Trait"), t.Tags, t.LocalNotes, t.UserDesc)
+			return false
+		}, false, false, traits...)
+	case SkillsExt:
+		skills, err := NewSkillsFromFile(fileSystem, from.Path)
+		if err != nil {
+			return err
+		}
+		Traverse(func(s *Skill) bool {
+			idx.add(from, s, s.Name, i18n.Text("Skill"), s.Tags, s.LocalNotes)
+			return false
+		}, false, false, skills...)
+	case SpellsExt:
+		spells, err := NewSpellsFromFile(fileSystem, from.Path)
+		if err != nil {
+			return err
+		}
+		Traverse(func(s *Spell) bool {
+			idx.add(from, s, s.Name, i18n.Text("Spell"), s.Tags, s.LocalNotes)
+			return false
+		}, false, false, spells...)
+	case EquipmentExt:
+		equipment, err := NewEquipmentFromFile(fileSystem, from.Path)
+		if err != nil {
+			return err
+		}
+		Traverse(func(e *Equipment) bool {
+			idx.add(from, e, e.Name, i18n.Text("Equipment"), e.Tags, e.LocalNotes)
+			return false
+		}, false, false, equipment...)
+	case NotesExt:
+		notes, err := NewNotesFromFile(fileSystem, from.Path)
+		if err != nil {
+			return err
+		}
+		Traverse(func(n *Note) bool {
+			idx.add(from, n, noteSearchName(n.Text), i18n.Text("Note"), nil, n.Text)
+			return false
+		}, false, false, notes...)
+	}
+	return nil
+}
+
+func noteSearchName(text string) string {
+	name, _, _ := strings.Cut(strings.TrimSpace(text), "\n")
+	name = strings.TrimSpace(name)
+	if runes := []rune(name); len(runes) > librarySearchNoteNameMaxSize {
+		name = string(runes[:librarySearchNoteNameMaxSize]) + "…"
+	}
+	return name
+}
+
+func (idx *LibrarySearchIndex) add(from LibraryFile, item any, name, kind string, tags []string, text ...string) {
+	entry := &librarySearchEntry{
+		result: LibrarySearchResult{
+			From: from,
+			Item: item,
+			Name: name,
+			Kind: kind,
+		},
+		name: strings.ToLower(name),
+		text: strings.ToLower(strings.Join(text, "\n")),
+	}
+	for _, tag := range tags {
+		entry.tags = append(entry.tags, strings.ToLower(tag))
+	}
+	idx.entries = append(idx.entries, entry)
+}
+
+// Len returns the number of items in the index.
+func (idx *LibrarySearchIndex) Len() int {
+	return len(idx.entries)
+}
+
+// Search returns the items matching every word in the query, best matches first. Matches in an item's name rank above
+// matches in its tags, which in turn rank above matches in its notes and descriptions.
+func (idx *LibrarySearchIndex) Search(query string) []*LibrarySearchResult {
+	query = strings.ToLower(strings.TrimSpace(query))
+	terms := strings.Fields(query)
+	if len(terms) == 0 {
+		return nil
+	}
+	var list []*LibrarySearchResult
+	for _, entry := range idx.entries {
+		if score := entry.score(query, terms); score > 0 {
+			result := entry.result
+			result.Score = score
+			list = append(list, &result)
+		}
+	}
+	slices.SortStableFunc(list, func(a, b *LibrarySearchResult) int {
+		if a.Score != b.Score {
+			return b.Score - a.Score
+		}
+		return txt.NaturalCmp(a.Name, b.Name, true)
+	})
+	return list
+}
+
+func (e *librarySearchEntry) score(query string, terms []string) int {
+	total := 0
+	if e.name == query {
+		total += librarySearchExactNameScore
+	}
+	var words []string
+	for _, term := range terms {
+		termScore := 0
+		if strings.Contains(e.name, term) {
+			if words == nil {
+				words = strings.FieldsFunc(e.name, func(ch rune) bool { return !unicode.IsLetter(ch) && !unicode.IsDigit(ch) })
+			}
+			if slices.ContainsFunc(words, func(word string) bool { return strings.HasPrefix(word, term) }) {
+				termScore += librarySearchNameWordScore
+			} else {
+				termScore += librarySearchNameScore
+			}
+		}
+		if slices.ContainsFunc(e.tags, func(tag string) bool { return strings.Contains(tag, term) }) {
+			termScore += librarySearchTagScore
+		}
+		if strings.Contains(e.text, term) {
+			termScore += librarySearchDescribedScore
+		}
+		if termScore == 0 {
+			return 0
+		}
+		total += termScore
+	}
+	return total
+}
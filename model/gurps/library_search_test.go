@@ -0,0 +1,71 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestLibrarySearch(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"Traits.adq": &fstest.MapFile{Data: []byte(`{"version":5,"rows":[
+  {"name":"Combat Reflexes","tags":["Advantage","Mental"]},
+  {"name":"Fearlessness","tags":["Advantage"],"userdesc":"Steady in combat"},
+  {"name":"Night Vision","notes":"See in the dark"}
+]}`)},
+		"Gear.eqp": &fstest.MapFile{Data: []byte(`{"version":5,"rows":[
+  {"description":"Combat Knife","tags":["Melee Weapon"]}
+]}`)},
+		"Lore.not": &fstest.MapFile{Data: []byte(`{"version":5,"rows":[
+  {"text":"Dark elves\nThey shun combat in daylight."}
+]}`)},
+		"Readme.md": &fstest.MapFile{Data: []byte("# Not indexed")},
+	}
+	idx := &gurps.LibrarySearchIndex{}
+	for _, p := range []string{"Traits.adq", "Gear.eqp", "Lore.not", "Readme.md"} {
+		check.NoError(t, idx.AddFile(fileSystem, gurps.LibraryFile{Library: "test", Path: p}))
+	}
+	check.Equal(t, 5, idx.Len())
+
+	results := idx.Search("combat")
+	names := make([]string, 0, len(results))
+	for _, one := range results {
+		names = append(names, one.Name)
+	}
+	check.Equal(t, []string{"Combat Knife", "Combat Reflexes", "Dark elves", "Fearlessness"}, names)
+	check.Equal(t, gurps.LibraryFile{Library: "test", Path: "Gear.eqp"}, results[0].From)
+	_, isEquipment := results[0].Item.(*gurps.Equipment)
+	check.True(t, isEquipment)
+
+	results = idx.Search("Combat Reflexes")
+	check.Equal(t, 1, len(results))
+	check.Equal(t, "Combat Reflexes", results[0].Name)
+	check.Equal(t, 140, results[0].Score)
+
+	results = idx.Search("flex")
+	check.Equal(t, 1, len(results))
+	check.Equal(t, 10, results[0].Score)
+
+	results = idx.Search("mental")
+	check.Equal(t, 1, len(results))
+	check.Equal(t, "Combat Reflexes", results[0].Name)
+
+	results = idx.Search("dark")
+	check.Equal(t, 2, len(results))
+	check.Equal(t, "Dark elves", results[0].Name)
+	check.Equal(t, "Night Vision", results[1].Name)
+
+	check.Equal(t, 0, len(idx.Search("combat vision")))
+	check.Equal(t, 0, len(idx.Search("  ")))
+}
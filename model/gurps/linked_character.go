@@ -0,0 +1,119 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// LinkedCharacter references a separate character sheet file that an Ally or Dependent trait is built upon. The name and
+// point total of that character are remembered from the last time the file was examined.
+type LinkedCharacter struct {
+	Path      string  `json:"path"`
+	Dependent bool    `json:"dependent,omitempty"`
+	Name      string  `json:"name,omitempty"`
+	Points    fxp.Int `json:"points,omitempty"`
+}
+
+// Clone creates a copy of this linked character.
+func (l *LinkedCharacter) Clone() *LinkedCharacter {
+	if l == nil {
+		return nil
+	}
+	other := *l
+	return &other
+}
+
+// Load the character from the linked file.
+func (l *LinkedCharacter) Load() (*Entity, error) {
+	return NewEntityFromFile(os.DirFS(filepath.Dir(l.Path)), filepath.Base(l.Path))
+}
+
+// Percentage returns the linked character's point total as a percentage of the owner's.
+func (l *LinkedCharacter) Percentage(owner *Entity) fxp.Int {
+	if owner == nil || owner.TotalPoints <= 0 {
+		return 0
+	}
+	return l.Points.Mul(fxp.Hundred).Div(owner.TotalPoints)
+}
+
+// BasePoints returns the base point cost of the trait, before frequency and other modifiers, given the linked
+// character's point total relative to the owner's.
+func (l *LinkedCharacter) BasePoints(owner *Entity) fxp.Int {
+	if l.Dependent {
+		return DependentBasePoints(l.Points, l.Percentage(owner))
+	}
+	return AllyBasePoints(l.Percentage(owner))
+}
+
+// Summary returns a short description of the linked character, suitable for display with the trait.
+func (l *LinkedCharacter) Summary(owner *Entity) string {
+	if l == nil || l.Path == "" {
+		return ""
+	}
+	name := l.Name
+	if name == "" {
+		name = filepath.Base(l.Path)
+	}
+	return fmt.Sprintf(i18n.Text("Linked character: %s, %s points (%s%% of yours)"), name, l.Points.Comma(),
+		l.Percentage(owner).Trunc().String())
+}
+
+// AllyBasePoints returns the base point cost of an Ally built on the given percentage of the character's points.
+// Allies built on more than 150% are treated as 150%.
+func AllyBasePoints(percentage fxp.Int) fxp.Int {
+	switch {
+	case percentage <= fxp.TwentyFive:
+		return fxp.One
+	case percentage <= fxp.Fifty:
+		return fxp.Two
+	case percentage <= fxp.Fifty+fxp.TwentyFive:
+		return fxp.Three
+	case percentage <= fxp.Hundred:
+		return fxp.Five
+	default:
+		return fxp.Ten
+	}
+}
+
+// DependentBasePoints returns the base point cost of a Dependent with the given point total, which is the given
+// percentage of the character's points.
+func DependentBasePoints(points, percentage fxp.Int) fxp.Int {
+	switch {
+	case points <= 0:
+		return -fxp.Ten
+	case percentage <= fxp.TwentyFive:
+		return -fxp.Five
+	case percentage <= fxp.Fifty:
+		return -fxp.Two
+	default:
+		return -fxp.One
+	}
+}
+
+// SyncLinkedCharacter updates the remembered details of the linked character and recomputes the trait's base point
+// cost from them. Returns true if anything changed.
+func (t *Trait) SyncLinkedCharacter(linked *Entity) bool {
+	l := t.LinkedCharacter
+	if l == nil || linked == nil {
+		return false
+	}
+	before := *l
+	beforePoints := t.BasePoints
+	l.Name = linked.Profile.Name
+	l.Points = linked.TotalPoints
+	t.BasePoints = l.BasePoints(EntityFromNode(t))
+	return before != *l || beforePoints != t.BasePoints
+}
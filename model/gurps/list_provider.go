@@ -41,6 +41,13 @@ type NoteListProvider interface {
 	SetNoteList(list []*Note)
 }
 
+// LanguageListProvider defines the methods needed to access the language list data.
+type LanguageListProvider interface {
+	DataOwnerProvider
+	LanguageList() []*Language
+	SetLanguageList(list []*Language)
+}
+
 // SkillListProvider defines the methods needed to access the skill list data.
 type SkillListProvider interface {
 	DataOwnerProvider
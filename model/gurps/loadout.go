@@ -0,0 +1,118 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/kinds"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// Loadout holds a named set of the top-level carried equipment, e.g. "Town clothes" or "Dungeon kit". While a loadout is
+// active, only the carried equipment within it contributes to weight, encumbrance, weapons and features.
+type Loadout struct {
+	ID        tid.TID   `json:"id"`
+	Name      string    `json:"name"`
+	Equipment []tid.TID `json:"equipment,omitempty"`
+}
+
+// NewLoadout creates a new Loadout holding the top-level carried equipment that contains each of the provided pieces of
+// equipment.
+func NewLoadout(name string, equipment []*Equipment) *Loadout {
+	l := &Loadout{
+		ID:   tid.MustNewTID(kinds.Loadout),
+		Name: name,
+	}
+	l.Add(equipment)
+	return l
+}
+
+// Clone creates a copy of this loadout.
+func (l *Loadout) Clone() *Loadout {
+	other := *l
+	other.Equipment = slices.Clone(l.Equipment)
+	return &other
+}
+
+// String implements fmt.Stringer.
+func (l *Loadout) String() string {
+	return l.Name
+}
+
+// Contains returns true if the top-level carried equipment containing the equipment is part of this loadout.
+func (l *Loadout) Contains(eqp *Equipment) bool {
+	return slices.Contains(l.Equipment, topLevelEquipment(eqp).TID)
+}
+
+// Add the top-level carried equipment containing each of the provided pieces of equipment to this loadout.
+func (l *Loadout) Add(equipment []*Equipment) {
+	for _, eqp := range equipment {
+		if id := topLevelEquipment(eqp).TID; !slices.Contains(l.Equipment, id) {
+			l.Equipment = append(l.Equipment, id)
+		}
+	}
+}
+
+// Remove the top-level carried equipment containing each of the provided pieces of equipment from this loadout.
+func (l *Loadout) Remove(equipment []*Equipment) {
+	for _, eqp := range equipment {
+		id := topLevelEquipment(eqp).TID
+		l.Equipment = slices.DeleteFunc(l.Equipment, func(one tid.TID) bool { return one == id })
+	}
+}
+
+func topLevelEquipment(eqp *Equipment) *Equipment {
+	for eqp.parent != nil {
+		eqp = eqp.parent
+	}
+	return eqp
+}
+
+// CloneLoadouts creates a copy of the loadouts.
+func CloneLoadouts(loadouts []*Loadout) []*Loadout {
+	if loadouts == nil {
+		return nil
+	}
+	list := make([]*Loadout, len(loadouts))
+	for i, one := range loadouts {
+		list[i] = one.Clone()
+	}
+	return list
+}
+
+// CurrentLoadout returns the active loadout, or nil if there isn't one.
+func (e *Entity) CurrentLoadout() *Loadout {
+	if e.ActiveLoadout == "" {
+		return nil
+	}
+	for _, one := range e.Loadouts {
+		if one.ID == e.ActiveLoadout {
+			return one
+		}
+	}
+	return nil
+}
+
+// ActiveCarriedEquipment returns the top-level carried equipment that is part of the active loadout. If no loadout is
+// active, all of the carried equipment is returned.
+func (e *Entity) ActiveCarriedEquipment() []*Equipment {
+	loadout := e.CurrentLoadout()
+	if loadout == nil {
+		return e.CarriedEquipment
+	}
+	list := make([]*Equipment, 0, len(e.CarriedEquipment))
+	for _, one := range e.CarriedEquipment {
+		if slices.Contains(loadout.Equipment, one.TID) {
+			list = append(list, one)
+		}
+	}
+	return list
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestLoadouts(t *testing.T) {
+	e := gurps.NewEntity()
+	pack := gurps.NewEquipment(e, nil, true)
+	pack.Weight = fxp.WeightFromInteger(5, e.SheetSettings.DefaultWeightUnits)
+	rope := gurps.NewEquipment(e, pack, false)
+	rope.Weight = fxp.WeightFromInteger(10, e.SheetSettings.DefaultWeightUnits)
+	pack.Children = []*gurps.Equipment{rope}
+	cloak := gurps.NewEquipment(e, nil, false)
+	cloak.Weight = fxp.WeightFromInteger(2, e.SheetSettings.DefaultWeightUnits)
+	e.SetCarriedEquipmentList([]*gurps.Equipment{pack, cloak})
+	check.Equal(t, fxp.WeightFromInteger(17, e.SheetSettings.DefaultWeightUnits), e.WeightCarried(false))
+
+	town := gurps.NewLoadout("Town clothes", []*gurps.Equipment{cloak})
+	dungeon := gurps.NewLoadout("Dungeon kit", []*gurps.Equipment{rope, cloak})
+	check.Equal(t, 2, len(dungeon.Equipment), "rope is represented by its top-level container")
+	check.True(t, dungeon.Contains(rope))
+	check.False(t, town.Contains(rope))
+	e.Loadouts = []*gurps.Loadout{town, dungeon}
+
+	e.ActiveLoadout = town.ID
+	check.Equal(t, town, e.CurrentLoadout())
+	check.Equal(t, 1, len(e.ActiveCarriedEquipment()))
+	check.Equal(t, fxp.WeightFromInteger(2, e.SheetSettings.DefaultWeightUnits), e.WeightCarried(false))
+
+	e.ActiveLoadout = dungeon.ID
+	check.Equal(t, fxp.WeightFromInteger(17, e.SheetSettings.DefaultWeightUnits), e.WeightCarried(false))
+	dungeon.Remove([]*gurps.Equipment{pack})
+	check.Equal(t, fxp.WeightFromInteger(2, e.SheetSettings.DefaultWeightUnits), e.WeightCarried(false))
+
+	e.ActiveLoadout = ""
+	check.Nil(t, e.CurrentLoadout())
+	check.Equal(t, 2, len(e.ActiveCarriedEquipment()))
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/activation"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestModifierActivation(t *testing.T) {
+	e := gurps.NewEntity()
+	training := gurps.NewTrait(e, nil, false)
+	training.Name = "Shield Training"
+	mod := gurps.NewTraitModifier(e, nil, false)
+	mod.Name = "With Shield"
+	mod.Features = gurps.Features{gurps.NewAttributeBonus(gurps.DodgeID)}
+	mod.Activation = activation.WhileEquipped
+	mod.ActivationTag = "shield"
+	training.Modifiers = []*gurps.TraitModifier{mod}
+	e.SetTraitList([]*gurps.Trait{training})
+
+	shield := gurps.NewEquipment(e, nil, false)
+	shield.Name = "Small Shield"
+	shield.Tags = []string{"Shield"}
+	strapped := gurps.NewEquipmentModifier(e, nil, false)
+	strapped.Name = "Strapped to Back"
+	bonus := gurps.NewAttributeBonus(gurps.DodgeID)
+	bonus.Amount = fxp.Two
+	strapped.Features = gurps.Features{bonus, gurps.NewReactionBonus(), gurps.NewConditionalModifierBonus()}
+	strapped.Activation = activation.WhileNotEquipped
+	shield.Modifiers = []*gurps.EquipmentModifier{strapped}
+	e.SetCarriedEquipmentList([]*gurps.Equipment{shield})
+
+	e.Recalculate()
+	check.Equal(t, fxp.One, e.DodgeBonus, "trait modifier is active while the shield is equipped")
+	check.Equal(t, 0, len(e.Reactions()))
+	check.Equal(t, 0, len(e.ConditionalModifiers()))
+
+	shield.Equipped = false
+	e.Recalculate()
+	check.Equal(t, fxp.Two, e.DodgeBonus, "only the unequipped modifier is active")
+	check.Equal(t, 1, len(e.Reactions()), "reactions of the unequipped modifier are listed")
+	check.Equal(t, 1, len(e.ConditionalModifiers()), "conditional modifiers of the unequipped modifier are listed")
+
+	mod.Activation = activation.WhileNotEquipped
+	e.Recalculate()
+	check.Equal(t, fxp.Three, e.DodgeBonus)
+
+	mod.Activation = activation.Always
+	strapped.Activation = activation.Always
+	e.Recalculate()
+	check.Equal(t, fxp.One, e.DodgeBonus, "equipment modifiers default to the equipment's equipped state")
+}
@@ -12,7 +12,9 @@ package gurps
 import (
 	"context"
 	"io/fs"
+	"path"
 	"slices"
+	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/namegen"
 	"github.com/richardwilkes/gcs/v5/model/jio"
@@ -20,6 +22,7 @@ import (
 	"github.com/richardwilkes/rpgtools/names/namesets/american"
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/txt"
+	xfs "github.com/richardwilkes/toolbox/xio/fs"
 	"github.com/richardwilkes/toolbox/xmath/rand"
 )
 
@@ -89,8 +92,15 @@ type NameGenerator struct {
 func AvailableNameGenerators(libraries Libraries) []*NameGeneratorRef {
 	var list []*NameGeneratorRef
 	seen := make(map[string]bool)
-	for _, set := range ScanForNamedFileSets(embeddedFS, "embedded_data", true, libraries, NamesExt) {
+	for _, set := range ScanForNamedFileSets(embeddedFS, "embedded_data", true, libraries, NamesExt,
+		path.Ext(NameCorpusExt)) {
 		for _, one := range set.List {
+			if strings.EqualFold(path.Ext(one.FilePath), path.Ext(NameCorpusExt)) {
+				if !isNameCorpus(one.FilePath) {
+					continue
+				}
+				one.Name = xfs.TrimExtension(one.Name)
+			}
 			if seen[one.Name] {
 				continue
 			}
@@ -116,17 +126,49 @@ func NewNameGeneratorFromFS(fileSystem fs.FS, filePath string) (*NameGenerator,
 	return &generator, nil
 }
 
-// Generator returns the NameGenerator, loading it if needed.
+// NewNameGeneratorFromCorpus creates a new NameGenerator that trains a Markov letter chain from a plain text file
+// containing one name per line. Blank lines and lines starting with '#' are ignored. A name that appears more than once
+// is weighted by the number of times it appears.
+func NewNameGeneratorFromCorpus(fileSystem fs.FS, filePath string) (*NameGenerator, error) {
+	data, err := fs.ReadFile(fileSystem, filePath)
+	if err != nil {
+		return nil, errs.NewWithCause(filePath, err)
+	}
+	generator := NameGenerator{
+		Type:         namegen.MarkovLetter,
+		TrainingData: TrainingData{Weighted: make(map[string]int)},
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+			generator.Weighted[line]++
+		}
+	}
+	if err = generator.createNamer(); err != nil {
+		return nil, errs.NewWithCause(filePath, err)
+	}
+	return &generator, nil
+}
+
+// Generator returns the NameGenerator, loading it if needed. Plain text name lists are trained at this point.
 func (n *NameGeneratorRef) Generator() (*NameGenerator, error) {
 	if n.generator == nil {
 		var err error
-		if n.generator, err = NewNameGeneratorFromFS(n.FileRef.FileSystem, n.FileRef.FilePath); err != nil {
+		if isNameCorpus(n.FileRef.FilePath) {
+			n.generator, err = NewNameGeneratorFromCorpus(n.FileRef.FileSystem, n.FileRef.FilePath)
+		} else {
+			n.generator, err = NewNameGeneratorFromFS(n.FileRef.FileSystem, n.FileRef.FilePath)
+		}
+		if err != nil {
 			return nil, err
 		}
 	}
 	return n.generator, nil
 }
 
+func isNameCorpus(filePath string) bool {
+	return strings.HasSuffix(strings.ToLower(filePath), NameCorpusExt)
+}
+
 // GenerateName generates a new random name.
 func (n *NameGenerator) GenerateName() string {
 	return n.namer.GenerateName()
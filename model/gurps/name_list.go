@@ -0,0 +1,206 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"context"
+	"io/fs"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/txt"
+	xfs "github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/toolbox/xmath/rand"
+)
+
+// The tokens that may appear within a NameList pattern. The given name token may be followed by a colon and a gender,
+// e.g. "{given:male}", to draw the name from that gender's list instead, which is useful for patronymics.
+const (
+	NameListGivenToken  = "given"
+	NameListFamilyToken = "family"
+)
+
+// NameList holds lists of names for a culture, along with the patterns used to combine them into a full name.
+type NameList struct {
+	Culture  string            `json:"culture,omitempty"`
+	Genders  []*NameListGender `json:"genders,omitempty"`
+	Family   []string          `json:"family,omitempty"`
+	Patterns []string          `json:"patterns,omitempty"`
+}
+
+// NameListGender holds the given names, and optionally the patterns, for one gender within a NameList. An empty Gender
+// applies to any gender that doesn't have its own entry.
+type NameListGender struct {
+	Gender   string   `json:"gender,omitempty"`
+	Given    []string `json:"given,omitempty"`
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// NewNameListFromFS creates a new NameList from a file. If the file doesn't specify a culture, the file's base name is
+// used.
+func NewNameListFromFS(fileSystem fs.FS, filePath string) (*NameList, error) {
+	var list NameList
+	if err := jio.LoadFromFS(context.Background(), fileSystem, filePath, &list); err != nil {
+		return nil, err
+	}
+	if list.Culture = strings.TrimSpace(list.Culture); list.Culture == "" {
+		list.Culture = xfs.BaseName(filePath)
+	}
+	return &list, nil
+}
+
+// AvailableNameLists scans the libraries and returns the available name lists.
+func AvailableNameLists(libraries Libraries) []*NameList {
+	var lists []*NameList
+	for _, set := range ScanForNamedFileSets(embeddedFS, "embedded_data", false, libraries, NameListExt) {
+		for _, one := range set.List {
+			list, err := NewNameListFromFS(one.FileSystem, one.FilePath)
+			if err != nil {
+				errs.Log(err, "path", one.FilePath)
+				continue
+			}
+			lists = append(lists, list)
+		}
+	}
+	return lists
+}
+
+// AvailableNameCultures returns the cultures provided by the available name lists, sorted by name.
+func AvailableNameCultures(libraries Libraries) []string {
+	var cultures []string
+	for _, one := range AvailableNameLists(libraries) {
+		if !slices.ContainsFunc(cultures, func(s string) bool { return strings.EqualFold(s, one.Culture) }) {
+			cultures = append(cultures, one.Culture)
+		}
+	}
+	slices.SortFunc(cultures, func(a, b string) int { return txt.NaturalCmp(a, b, true) })
+	return cultures
+}
+
+// NameListForCulture returns a NameList that combines all of the available name lists for the culture, or nil if there
+// are none.
+func NameListForCulture(culture string, libraries Libraries) *NameList {
+	var result *NameList
+	for _, one := range AvailableNameLists(libraries) {
+		if !strings.EqualFold(one.Culture, culture) {
+			continue
+		}
+		if result == nil {
+			result = &NameList{Culture: one.Culture}
+		}
+		result.Genders = append(result.Genders, one.Genders...)
+		result.Family = append(result.Family, one.Family...)
+		result.Patterns = append(result.Patterns, one.Patterns...)
+	}
+	return result
+}
+
+// GenerateName generates a new random name for the gender.
+func (n *NameList) GenerateName(gender string) string {
+	return n.GenerateNameWithRandomizer(gender, rand.NewCryptoRand())
+}
+
+// GenerateNameWithRandomizer generates a new random name for the gender using the specified randomizer.
+func (n *NameList) GenerateNameWithRandomizer(gender string, rnd rand.Randomizer) string {
+	entries := n.entriesFor(gender)
+	var patterns []string
+	for _, one := range entries {
+		patterns = append(patterns, one.Patterns...)
+	}
+	if len(patterns) == 0 {
+		patterns = n.Patterns
+	}
+	pattern := pickName(patterns, rnd)
+	if pattern == "" {
+		pattern = "{" + NameListGivenToken + "}"
+		if len(n.Family) != 0 {
+			pattern += " {" + NameListFamilyToken + "}"
+		}
+	}
+	var buffer strings.Builder
+	for {
+		start := strings.IndexByte(pattern, '{')
+		if start == -1 {
+			break
+		}
+		end := strings.IndexByte(pattern[start:], '}')
+		if end == -1 {
+			break
+		}
+		end += start
+		buffer.WriteString(pattern[:start])
+		buffer.WriteString(n.expandToken(pattern[start+1:end], entries, rnd))
+		pattern = pattern[end+1:]
+	}
+	buffer.WriteString(pattern)
+	return strings.Join(strings.Fields(buffer.String()), " ")
+}
+
+func (n *NameList) expandToken(token string, entries []*NameListGender, rnd rand.Randomizer) string {
+	token = strings.TrimSpace(token)
+	name, gender, hasGender := strings.Cut(token, ":")
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case NameListGivenToken:
+		if hasGender {
+			entries = n.entriesFor(strings.TrimSpace(gender))
+		}
+		var given []string
+		for _, one := range entries {
+			given = append(given, one.Given...)
+		}
+		return pickName(given, rnd)
+	case NameListFamilyToken:
+		return pickName(n.Family, rnd)
+	default:
+		return "{" + token + "}"
+	}
+}
+
+// entriesFor returns the entries for the gender, falling back to the entries without a gender and then to all of them.
+func (n *NameList) entriesFor(gender string) []*NameListGender {
+	var matched, generic []*NameListGender
+	for _, one := range n.Genders {
+		switch {
+		case one.Gender == "":
+			generic = append(generic, one)
+		case gender != "" && strings.EqualFold(one.Gender, gender):
+			matched = append(matched, one)
+		}
+	}
+	if len(matched) != 0 {
+		return matched
+	}
+	if len(generic) != 0 {
+		return generic
+	}
+	return n.Genders
+}
+
+func pickName(list []string, rnd rand.Randomizer) string {
+	if len(list) == 0 {
+		return ""
+	}
+	return list[rnd.Intn(len(list))]
+}
+
+// RandomName returns a randomized name for the entity, using the name culture from the general settings. The entity's
+// ancestry is used instead if no culture is set or no name list for it can be found.
+func (e *Entity) RandomName() string {
+	globalSettings := GlobalSettings()
+	libraries := globalSettings.Libraries()
+	if culture := globalSettings.GeneralSettings().NameCulture; culture != "" {
+		if list := NameListForCulture(culture, libraries); list != nil {
+			return list.GenerateName(e.Profile.Gender)
+		}
+	}
+	return e.Ancestry().RandomName(AvailableNameGenerators(libraries), e.Profile.Gender)
+}
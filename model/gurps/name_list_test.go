@@ -0,0 +1,76 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+type firstRandomizer struct{}
+
+func (firstRandomizer) Intn(_ int) int {
+	return 0
+}
+
+func TestNameList(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"Test.namelist": &fstest.MapFile{Data: []byte(`{
+  "genders": [
+    { "gender": "Male", "given": ["Bjorn"], "patterns": ["{given} {given:Female}sson"] },
+    { "gender": "Female", "given": ["Astrid"] },
+    { "given": ["Sam"] }
+  ],
+  "family": ["Smith"]
+}`)},
+	}
+	list, err := gurps.NewNameListFromFS(fileSystem, "Test.namelist")
+	check.NoError(t, err)
+	check.Equal(t, "Test", list.Culture, "culture defaults to the file name")
+	rnd := firstRandomizer{}
+	check.Equal(t, "Bjorn Astridsson", list.GenerateNameWithRandomizer("male", rnd), "gender pattern")
+	check.Equal(t, "Astrid Smith", list.GenerateNameWithRandomizer("Female", rnd), "default pattern")
+	check.Equal(t, "Sam Smith", list.GenerateNameWithRandomizer("", rnd), "no gender uses the generic entry")
+	list.Patterns = []string{"{family}, {given} {unknown}"}
+	check.Equal(t, "Smith, Sam {unknown}", list.GenerateNameWithRandomizer("Other", rnd), "list pattern")
+}
+
+func TestNameCorpus(t *testing.T) {
+	fileSystem := fstest.MapFS{
+		"Elvish.names.txt": &fstest.MapFile{Data: []byte("# Elvish names\r\nAlara\n\nAlara\nEleniel\r\n  Thalion  \n")},
+	}
+	generator, err := gurps.NewNameGeneratorFromCorpus(fileSystem, "Elvish.names.txt")
+	check.NoError(t, err)
+	check.Equal(t, map[string]int{"Alara": 2, "Eleniel": 1, "Thalion": 1}, generator.Weighted)
+	check.NotEqual(t, "", generator.GenerateName())
+
+	fileSystem["Empty.names.txt"] = &fstest.MapFile{Data: []byte("# nothing here\n")}
+	_, err = gurps.NewNameGeneratorFromCorpus(fileSystem, "Empty.names.txt")
+	check.Error(t, err, "a corpus must contain names")
+
+	dir := t.TempDir()
+	settingsDir := filepath.Join(dir, "Settings")
+	check.NoError(t, os.MkdirAll(settingsDir, 0o750))
+	check.NoError(t, os.WriteFile(filepath.Join(settingsDir, "Elvish.names.txt"), []byte("Alara\nEleniel\n"), 0o600))
+	check.NoError(t, os.WriteFile(filepath.Join(settingsDir, "README.txt"), []byte("Not names\n"), 0o600))
+	lib := &gurps.Library{GitHubAccountName: "test", RepoName: "lib", PathOnDisk: dir}
+	var found []string
+	for _, one := range gurps.AvailableNameGenerators(gurps.Libraries{lib.Key(): lib}) {
+		found = append(found, one.FileRef.Name)
+	}
+	check.True(t, slices.Contains(found, "Elvish"), "name corpus files are listed without their extension")
+	check.False(t, slices.Contains(found, "README"), "other text files are not name corpus files")
+}
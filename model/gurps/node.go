@@ -32,7 +32,7 @@ type DataOwnerProvider interface {
 
 // NodeTypes is a constraint that defines the types that may be nodes.
 type NodeTypes interface {
-	*ConditionalModifier | *Equipment | *EquipmentModifier | *Note | *Skill | *Spell | *Trait | *TraitModifier | *Weapon
+	*ConditionalModifier | *Equipment | *EquipmentModifier | *Language | *Note | *Skill | *Spell | *Trait | *TraitModifier | *Weapon
 	nameable.Applier
 	fmt.Stringer
 }
@@ -0,0 +1,212 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/picker"
+	"github.com/richardwilkes/toolbox/xmath/rand"
+)
+
+const npcPickerAttempts = 25
+
+// NPCGenerator holds the parameters for generating a random character.
+type NPCGenerator struct {
+	Budget    fxp.Int
+	Ancestry  string
+	Templates []*Template
+}
+
+// Generate creates a new character from the ancestry and templates. Template choices are made at random, then skills
+// and spells are raised or lowered at random, weighted toward those with more points already in them, to bring the
+// character as close to the point budget as possible.
+func (g *NPCGenerator) Generate(rnd rand.Randomizer) *Entity {
+	e := NewEntity()
+	e.TotalPoints = g.Budget
+	if len(e.PointsRecord) != 0 {
+		e.PointsRecord[0].Points = g.Budget
+	}
+	var traits []*Trait
+	var ancestryTrait *Trait
+	if g.Ancestry != "" {
+		ancestryTrait = NewTrait(e, nil, true)
+		ancestryTrait.Name = g.Ancestry
+		ancestryTrait.ContainerType = container.Ancestry
+		ancestryTrait.Ancestry = g.Ancestry
+		traits = append(traits, ancestryTrait)
+	}
+	for _, tmpl := range g.Templates {
+		traits = append(traits, pickNPCRows(e, rnd, tmpl.Traits)...)
+		e.Skills = append(e.Skills, pickNPCRows(e, rnd, tmpl.Skills)...)
+		e.Spells = append(e.Spells, pickNPCRows(e, rnd, tmpl.Spells)...)
+		e.CarriedEquipment = append(e.CarriedEquipment, pickNPCRows(e, rnd, tmpl.Equipment)...)
+		e.Notes = append(e.Notes, pickNPCRows(e, rnd, tmpl.Notes)...)
+	}
+	e.SetTraitList(traits)
+	e.Recalculate()
+	e.Profile.ApplyRandomizers(e)
+	if ancestryTrait != nil && g.addAncestryTraits(e, rnd, ancestryTrait) {
+		e.Recalculate()
+		e.Profile.RollPhysicalCharacteristics(e)
+	}
+	g.fitToBudget(e, rnd)
+	e.Recalculate()
+	return e
+}
+
+// addAncestryTraits adds the traits bundled with the ancestry to its container, returning true if any were added.
+func (g *NPCGenerator) addAncestryTraits(e *Entity, rnd rand.Randomizer, ancestryTrait *Trait) bool {
+	a := LookupAncestry(g.Ancestry, GlobalSettings().Libraries())
+	if a == nil {
+		return false
+	}
+	for _, one := range a.RandomTraits(rnd, e.Profile.Gender) {
+		ancestryTrait.Children = append(ancestryTrait.Children, one.Clone(LibraryFile{}, e, ancestryTrait, false))
+	}
+	return len(ancestryTrait.Children) != 0
+}
+
+func (g *NPCGenerator) fitToBudget(e *Entity, rnd rand.Randomizer) {
+	candidates := npcSkillAdjusters(e)
+	for e.UnspentPoints() < 0 && len(candidates) != 0 {
+		i := chooseWeightedNPCAdjuster(rnd, candidates)
+		adj := candidates[i]
+		before := adj.RawPoints()
+		adj.DecrementSkillLevel()
+		if adj.RawPoints() == before {
+			candidates = append(candidates[:i], candidates[i+1:]...)
+		}
+	}
+	candidates = npcSkillAdjusters(e)
+	for unspent := e.UnspentPoints(); unspent > 0 && len(candidates) != 0; unspent = e.UnspentPoints() {
+		i := chooseWeightedNPCAdjuster(rnd, candidates)
+		adj := candidates[i]
+		before := adj.RawPoints()
+		adj.IncrementSkillLevel()
+		if after := adj.RawPoints(); after == before || after-before > unspent {
+			adj.SetRawPoints(before)
+			candidates = append(candidates[:i], candidates[i+1:]...)
+		}
+	}
+}
+
+type npcSkillAdjuster interface {
+	RawPoints() fxp.Int
+	SetRawPoints(points fxp.Int) bool
+	IncrementSkillLevel()
+	DecrementSkillLevel()
+}
+
+func npcSkillAdjusters(e *Entity) []npcSkillAdjuster {
+	var list []npcSkillAdjuster
+	Traverse(func(s *Skill) bool {
+		list = append(list, s)
+		return false
+	}, true, true, e.Skills...)
+	Traverse(func(s *Spell) bool {
+		list = append(list, s)
+		return false
+	}, true, true, e.Spells...)
+	return list
+}
+
+func chooseWeightedNPCAdjuster(rnd rand.Randomizer, list []npcSkillAdjuster) int {
+	weights := make([]int, len(list))
+	total := 0
+	for i, one := range list {
+		weights[i] = 1 + fxp.As[int](one.RawPoints().Max(0))
+		total += weights[i]
+	}
+	choice := rnd.Intn(total)
+	for i, w := range weights {
+		if choice < w {
+			return i
+		}
+		choice -= w
+	}
+	return len(list) - 1
+}
+
+func pickNPCRows[T NodeTypes](e *Entity, rnd rand.Randomizer, rows []T) []T {
+	var zero T
+	var result []T
+	for _, row := range rows {
+		result = append(result, resolveNPCPicker(rnd, AsNode(row).Clone(LibraryFile{}, e, zero, false))...)
+	}
+	return result
+}
+
+func resolveNPCPicker[T NodeTypes](rnd rand.Randomizer, row T) []T {
+	n := AsNode(row)
+	if !n.Container() {
+		return []T{row}
+	}
+	children := n.NodeChildren()
+	tpp, ok := n.(TemplatePickerProvider)
+	if !ok || tpp.TemplatePickerData().ShouldOmit() {
+		rowChildren := make([]T, 0, len(children))
+		for _, child := range children {
+			rowChildren = append(rowChildren, resolveNPCPicker(rnd, child)...)
+		}
+		n.SetChildren(rowChildren)
+		for _, child := range rowChildren {
+			AsNode(child).SetParent(row)
+		}
+		return []T{row}
+	}
+	var result []T
+	for _, child := range pickNPCChildren(rnd, tpp.TemplatePickerData(), children) {
+		for _, one := range resolveNPCPicker(rnd, child) {
+			AsNode(one).SetParent(n.Parent())
+			result = append(result, one)
+		}
+	}
+	return result
+}
+
+// pickNPCChildren makes a random selection from the children that satisfies the picker. If no selection can be found
+// that satisfies it, nothing is picked.
+func pickNPCChildren[T NodeTypes](rnd rand.Randomizer, tp *TemplatePicker, children []T) []T {
+	if tp.Type == picker.Optional {
+		var picked []T
+		for _, child := range children {
+			if rnd.Intn(2) == 0 {
+				picked = append(picked, child)
+			}
+		}
+		return picked
+	}
+	for attempt := 0; attempt < npcPickerAttempts; attempt++ {
+		order := make([]int, len(children))
+		for i := range order {
+			j := rnd.Intn(i + 1)
+			order[i] = order[j]
+			order[j] = i
+		}
+		var picked []T
+		var total fxp.Int
+		for _, i := range order {
+			if tp.Qualifier.Matches(total) {
+				break
+			}
+			picked = append(picked, children[i])
+			if tp.Type == picker.Points {
+				total += TemplatePickerPoints(children[i])
+			} else {
+				total += fxp.One
+			}
+		}
+		if tp.Qualifier.Matches(total) {
+			return picked
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/xio/fs"
+)
+
+// Keys recognized in the hints that may be appended to a path, e.g. "Basic Set.pdf#page=345" or
+// "Bob.gcs#item=Broadsword&window=new".
+const (
+	openHintSeparator = "#"
+	openHintPage      = "page"
+	openHintItem      = "item"
+	openHintWindow    = "window"
+	openHintNewWindow = "new"
+)
+
+// OpenRequest holds a path to be opened along with hints about how it should be presented.
+type OpenRequest struct {
+	Path      string `json:"path"`
+	Page      int    `json:"page,omitempty"`
+	Item      string `json:"item,omitempty"`
+	NewWindow bool   `json:"new_window,omitempty"`
+}
+
+// ParseOpenRequests parses each of the arguments with ParseOpenRequest.
+func ParseOpenRequests(args []string) []OpenRequest {
+	list := make([]OpenRequest, len(args))
+	for i, arg := range args {
+		list[i] = ParseOpenRequest(arg)
+	}
+	return list
+}
+
+// ParseOpenRequest parses an argument of the form "path#key=value&key=value". The recognized keys are "page", holding
+// the 1-based page number to show, "item", holding the name of an item to reveal, and "window", which, when set to
+// "new", requests the file be opened in its own window. If the argument names an existing file or none of the keys are
+// present, it is taken as-is, even if it contains a '#'. The path is made absolute.
+func ParseOpenRequest(arg string) OpenRequest {
+	req := OpenRequest{Path: arg}
+	if i := strings.LastIndex(arg, openHintSeparator); i != -1 && !fs.FileExists(arg) {
+		if values, err := url.ParseQuery(arg[i+1:]); err == nil &&
+			(values.Has(openHintPage) || values.Has(openHintItem) || values.Has(openHintWindow)) {
+			req.Path = arg[:i]
+			if page, err2 := strconv.Atoi(values.Get(openHintPage)); err2 == nil && page > 0 {
+				req.Page = page
+			}
+			req.Item = strings.TrimSpace(values.Get(openHintItem))
+			req.NewWindow = strings.EqualFold(values.Get(openHintWindow), openHintNewWindow)
+		}
+	}
+	if p, err := filepath.Abs(req.Path); err == nil {
+		req.Path = p
+	}
+	return req
+}
+
+// OpenRequestsForPaths returns requests without any hints for the paths.
+func OpenRequestsForPaths(paths []string) []OpenRequest {
+	list := make([]OpenRequest, len(paths))
+	for i, p := range paths {
+		list[i] = OpenRequest{Path: p}
+	}
+	return list
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestParseOpenRequest(t *testing.T) {
+	dir := t.TempDir()
+	pdf := filepath.Join(dir, "Basic.pdf")
+	req := gurps.ParseOpenRequest(pdf + "#page=345")
+	check.Equal(t, pdf, req.Path)
+	check.Equal(t, 345, req.Page)
+	check.False(t, req.NewWindow)
+
+	sheet := filepath.Join(dir, "Bob.gcs")
+	req = gurps.ParseOpenRequest(sheet + "#item=Broad%20Sword&window=new")
+	check.Equal(t, sheet, req.Path)
+	check.Equal(t, 0, req.Page)
+	check.Equal(t, "Broad Sword", req.Item)
+	check.True(t, req.NewWindow)
+
+	odd := filepath.Join(dir, "Issue #3.gcs")
+	check.NoError(t, os.WriteFile(odd, nil, 0o600))
+	req = gurps.ParseOpenRequest(odd)
+	check.Equal(t, odd, req.Path)
+	check.Equal(t, "", req.Item)
+
+	missing := filepath.Join(dir, "Missing #4.gcs")
+	check.Equal(t, missing, gurps.ParseOpenRequest(missing).Path)
+}
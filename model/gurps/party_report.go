@@ -0,0 +1,331 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	htmltmpl "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+const partyReportHTML = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: sans-serif; font-size: 10pt; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #999; padding: 2px 6px; }
+th { background: #ddd; }
+td.num { text-align: right; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<h2>{{.MembersHeading}}</h2>
+<table>
+<tr>{{range .MemberColumns}}<th>{{.}}</th>{{end}}</tr>
+{{range .MemberRows}}<tr>{{range $i, $v := .}}<td{{if gt $i 1}} class="num"{{end}}>{{$v}}</td>{{end}}</tr>
+{{end}}</table>
+<h2>{{.HighlightsHeading}}</h2>
+<table>
+{{range .Highlights}}<tr><th>{{index . 0}}</th><td>{{index . 1}}</td></tr>
+{{end}}</table>
+<h2>{{.SkillsHeading}}</h2>
+<table>
+<tr><th></th>{{range .Names}}<th>{{.}}</th>{{end}}</tr>
+{{range .Skills}}<tr><th>{{.Name}}</th>{{range .Values}}<td class="num">{{.}}</td>{{end}}</tr>
+{{end}}</table>
+<h2>{{.LanguagesHeading}}</h2>
+<table>
+<tr><th></th>{{range .Names}}<th>{{.}}</th>{{end}}</tr>
+{{range .Languages}}<tr><th>{{.Name}}</th>{{range .Values}}<td>{{.}}</td>{{end}}</tr>
+{{end}}</table>
+</body>
+</html>
+`
+
+// PartyReport holds aggregate data for a set of characters.
+type PartyReport struct {
+	Members           []*PartyReportMember
+	Skills            []*PartyReportRow
+	Languages         []*PartyReportRow
+	TotalWealth       fxp.Int
+	HighestPerception *PartyReportMember
+	HighestStealth    *PartyReportMember
+}
+
+// PartyReportMember holds the per-character data for a PartyReport.
+type PartyReportMember struct {
+	Name       string
+	Player     string
+	Points     fxp.Int
+	Wealth     fxp.Int
+	Perception fxp.Int
+	Stealth    fxp.Int
+}
+
+// PartyReportRow holds a single row of a PartyReport matrix, with one value per member. Empty values indicate the
+// member does not have the item.
+type PartyReportRow struct {
+	Name   string
+	Values []string
+}
+
+// ExportPartyReport loads the character sheets found in the given paths and writes an aggregate report of them to
+// reportPath. The format of the report is determined by the extension of reportPath and may be either HTML or CSV.
+func ExportPartyReport(reportPath string, fileList []string) error {
+	var write func(report *PartyReport, w io.Writer) error
+	switch strings.ToLower(filepath.Ext(reportPath)) {
+	case ".csv":
+		write = (*PartyReport).WriteCSV
+	case ".html", ".htm":
+		write = (*PartyReport).WriteHTML
+	default:
+		return errs.New(i18n.Text("Report file must have a .csv or .html extension"))
+	}
+	list, err := SheetPaths(fileList...)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return errs.New(i18n.Text("No character sheets to report on"))
+	}
+	entities := make([]*Entity, 0, len(list))
+	for _, p := range list {
+		var entity *Entity
+		if entity, err = NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			CLI.Failed(p, err)
+			return errs.NewWithCause(p, err)
+		}
+		CLI.File(p, CLIStatusProcessed, fmt.Sprintf(i18n.Text("Processed %s"), p))
+		entities = append(entities, entity)
+	}
+	report := NewPartyReport(entities)
+	var file *os.File
+	if file, err = os.Create(reportPath); err != nil {
+		return errs.Wrap(err)
+	}
+	w := bufio.NewWriter(file)
+	err = write(report, w)
+	if flushErr := w.Flush(); flushErr != nil && err == nil {
+		err = errs.Wrap(flushErr)
+	}
+	if closeErr := file.Close(); closeErr != nil && err == nil {
+		err = errs.Wrap(closeErr)
+	}
+	return err
+}
+
+// NewPartyReport creates a new PartyReport for the given entities.
+func NewPartyReport(entities []*Entity) *PartyReport {
+	r := &PartyReport{}
+	skills := make(map[string][]fxp.Int)
+	languages := make(map[string][]string)
+	for i, entity := range entities {
+		entity.Recalculate()
+		m := &PartyReportMember{
+			Name:       entity.Profile.Name,
+			Player:     entity.Profile.PlayerName,
+			Points:     entity.TotalPoints,
+			Wealth:     entity.WealthCarried() + entity.WealthNotCarried(),
+			Perception: entity.ResolveAttributeCurrent(PerceptionID),
+			Stealth:    fxp.Min,
+		}
+		if m.Name == "" {
+			m.Name = i18n.Text("Unnamed")
+		}
+		if sk := entity.BestSkillNamed("Stealth", "", false, nil); sk != nil {
+			m.Stealth = sk.LevelData.Level
+		}
+		r.Members = append(r.Members, m)
+		r.TotalWealth += m.Wealth
+		if m.Perception != fxp.Min && (r.HighestPerception == nil || m.Perception > r.HighestPerception.Perception) {
+			r.HighestPerception = m
+		}
+		if m.Stealth != fxp.Min && (r.HighestStealth == nil || m.Stealth > r.HighestStealth.Stealth) {
+			r.HighestStealth = m
+		}
+		Traverse(func(sk *Skill) bool {
+			if sk.LevelData.Level > 0 {
+				levels, ok := skills[sk.String()]
+				if !ok {
+					levels = make([]fxp.Int, len(entities))
+					for j := range levels {
+						levels[j] = fxp.Min
+					}
+					skills[sk.String()] = levels
+				}
+				levels[i] = max(levels[i], sk.LevelData.Level)
+			}
+			return false
+		}, true, true, entity.Skills...)
+		Traverse(func(t *Trait) bool {
+			if HasTag("Language", t.Tags) {
+				values, ok := languages[t.String()]
+				if !ok {
+					values = make([]string, len(entities))
+					languages[t.String()] = values
+				}
+				values[i] = partyReportLanguageLevel(t)
+			}
+			return false
+		}, true, true, entity.Traits...)
+		for _, lang := range entity.Languages {
+			values, ok := languages[lang.String()]
+			if !ok {
+				values = make([]string, len(entities))
+				languages[lang.String()] = values
+			}
+			values[i] = lang.ComprehensionText()
+		}
+	}
+	skillValues := make(map[string][]string, len(skills))
+	for k, levels := range skills {
+		values := make([]string, len(levels))
+		for j, level := range levels {
+			values[j] = partyReportNumber(level)
+		}
+		skillValues[k] = values
+	}
+	r.Skills = partyReportRows(skillValues)
+	r.Languages = partyReportRows(languages)
+	return r
+}
+
+func partyReportLanguageLevel(t *Trait) string {
+	var list []string
+	Traverse(func(mod *TraitModifier) bool {
+		list = append(list, mod.String())
+		return false
+	}, true, true, t.Modifiers...)
+	if len(list) == 0 {
+		return "✓"
+	}
+	return strings.Join(list, "; ")
+}
+
+func partyReportRows(m map[string][]string) []*PartyReportRow {
+	rows := make([]*PartyReportRow, 0, len(m))
+	for k, v := range m {
+		rows = append(rows, &PartyReportRow{Name: k, Values: v})
+	}
+	slices.SortFunc(rows, func(a, b *PartyReportRow) int { return txt.NaturalCmp(a.Name, b.Name, true) })
+	return rows
+}
+
+func (r *PartyReport) names() []string {
+	names := make([]string, len(r.Members))
+	for i, m := range r.Members {
+		names[i] = m.Name
+	}
+	return names
+}
+
+func (r *PartyReport) memberColumns() []string {
+	return []string{
+		i18n.Text("Name"),
+		i18n.Text("Player"),
+		i18n.Text("Points"),
+		i18n.Text("Wealth"),
+		i18n.Text("Perception"),
+		i18n.Text("Stealth"),
+	}
+}
+
+func (r *PartyReport) memberRows() [][]string {
+	rows := make([][]string, 0, len(r.Members))
+	for _, m := range r.Members {
+		rows = append(rows, []string{
+			m.Name,
+			m.Player,
+			m.Points.Comma(),
+			"$" + m.Wealth.Comma(),
+			partyReportNumber(m.Perception),
+			partyReportNumber(m.Stealth),
+		})
+	}
+	return rows
+}
+
+func (r *PartyReport) highlights() [][]string {
+	return [][]string{
+		{i18n.Text("Total Wealth"), "$" + r.TotalWealth.Comma()},
+		{i18n.Text("Highest Perception"), partyReportBest(r.HighestPerception, func(m *PartyReportMember) fxp.Int { return m.Perception })},
+		{i18n.Text("Highest Stealth"), partyReportBest(r.HighestStealth, func(m *PartyReportMember) fxp.Int { return m.Stealth })},
+	}
+}
+
+func partyReportNumber(value fxp.Int) string {
+	if value == fxp.Min {
+		return ""
+	}
+	return value.Trunc().String()
+}
+
+func partyReportBest(m *PartyReportMember, value func(*PartyReportMember) fxp.Int) string {
+	if m == nil {
+		return ""
+	}
+	return m.Name + " (" + partyReportNumber(value(m)) + ")"
+}
+
+// WriteCSV writes the report as a series of CSV tables, separated by blank lines.
+func (r *PartyReport) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	names := r.names()
+	records := [][]string{r.memberColumns()}
+	records = append(records, r.memberRows()...)
+	records = append(records, nil)
+	records = append(records, r.highlights()...)
+	records = append(records, nil, append([]string{i18n.Text("Skill")}, names...))
+	for _, row := range r.Skills {
+		records = append(records, append([]string{row.Name}, row.Values...))
+	}
+	records = append(records, nil, append([]string{i18n.Text("Language")}, names...))
+	for _, row := range r.Languages {
+		records = append(records, append([]string{row.Name}, row.Values...))
+	}
+	// Empty records produce the blank lines that separate the tables.
+	return errs.Wrap(cw.WriteAll(records))
+}
+
+// WriteHTML writes the report as an HTML document.
+func (r *PartyReport) WriteHTML(w io.Writer) error {
+	t, err := htmltmpl.New("").Parse(partyReportHTML)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	return errs.Wrap(t.Execute(w, map[string]any{
+		"Title":             i18n.Text("Party Report"),
+		"MembersHeading":    i18n.Text("Characters"),
+		"HighlightsHeading": i18n.Text("Highlights"),
+		"SkillsHeading":     i18n.Text("Skill Coverage"),
+		"LanguagesHeading":  i18n.Text("Languages Known"),
+		"MemberColumns":     r.memberColumns(),
+		"MemberRows":        r.memberRows(),
+		"Highlights":        r.highlights(),
+		"Names":             r.names(),
+		"Skills":            r.Skills,
+		"Languages":         r.Languages,
+	}))
+}
@@ -35,3 +35,8 @@ func DefaultMasterLibraryPath() string {
 func DefaultUserLibraryPath() string {
 	return filepath.Join(DefaultRootLibraryPath(), "User Library")
 }
+
+// DefaultArchivePath returns the default path for character archives.
+func DefaultArchivePath() string {
+	return filepath.Join(DefaultRootLibraryPath(), "Archives")
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+// Point budget categories, matching the rows of the points breakdown.
+const (
+	AncestryBudgetCategory      = "ancestry"
+	AttributesBudgetCategory    = "attributes"
+	AdvantagesBudgetCategory    = "advantages"
+	DisadvantagesBudgetCategory = "disadvantages"
+	QuirksBudgetCategory        = "quirks"
+	SkillsBudgetCategory        = "skills"
+	SpellsBudgetCategory        = "spells"
+)
+
+// PointBudgetCategories holds the valid point budget categories, in the order they appear in the points breakdown.
+var PointBudgetCategories = []string{
+	AncestryBudgetCategory,
+	AttributesBudgetCategory,
+	AdvantagesBudgetCategory,
+	DisadvantagesBudgetCategory,
+	QuirksBudgetCategory,
+	SkillsBudgetCategory,
+	SpellsBudgetCategory,
+}
+
+// PointBudget holds the bounds on the points that may be spent in one category of the points breakdown. Points spent
+// on disadvantages and quirks are compared as positive values. A bound of zero means that side is unbounded.
+type PointBudget struct {
+	Category string  `json:"category"`
+	Minimum  fxp.Int `json:"minimum,omitempty"`
+	Maximum  fxp.Int `json:"maximum,omitempty"`
+}
+
+// PointBudgetStatus holds the state of a character's spending against a PointBudget.
+type PointBudgetStatus struct {
+	Budget    *PointBudget
+	Spent     fxp.Int
+	Remaining fxp.Int
+}
+
+// ClonePointBudgets creates a clone of the provided PointBudget list.
+func ClonePointBudgets(list []*PointBudget) []*PointBudget {
+	if list == nil {
+		return nil
+	}
+	clone := make([]*PointBudget, len(list))
+	for i, one := range list {
+		b := *one
+		clone[i] = &b
+	}
+	return clone
+}
+
+// NewPointBudgetsFromString creates a PointBudget list from a string, one bound per line in the form
+// "category <= value" or "category >= value", e.g. "attributes <= 100". Bounds for the same category are combined.
+// Lines that can't be parsed are dropped and inputWasValid will be false.
+func NewPointBudgetsFromString(str string) (list []*PointBudget, inputWasValid bool) {
+	inputWasValid = true
+	for _, line := range strings.Split(str, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		category, value, isMax, ok := parsePointBudget(line)
+		if !ok {
+			inputWasValid = false
+			continue
+		}
+		i := slices.IndexFunc(list, func(b *PointBudget) bool { return b.Category == category })
+		if i == -1 {
+			list = append(list, &PointBudget{Category: category})
+			i = len(list) - 1
+		}
+		if isMax {
+			list[i].Maximum = value
+		} else {
+			list[i].Minimum = value
+		}
+	}
+	return list, inputWasValid
+}
+
+func parsePointBudget(line string) (category string, value fxp.Int, isMax, ok bool) {
+	var left, right string
+	for _, op := range []string{"<=", "≤", ">=", "≥"} {
+		if before, after, found := strings.Cut(line, op); found {
+			left = before
+			right = after
+			isMax = op == "<=" || op == "≤"
+			break
+		}
+	}
+	category = strings.ToLower(strings.TrimSpace(left))
+	if !slices.Contains(PointBudgetCategories, category) {
+		return "", 0, false, false
+	}
+	var err error
+	if value, err = fxp.FromString(strings.TrimSpace(right)); err != nil || value < 0 {
+		return "", 0, false, false
+	}
+	return category, value, isMax, true
+}
+
+// PointBudgetsToString returns the PointBudget list in the form accepted by NewPointBudgetsFromString.
+func PointBudgetsToString(list []*PointBudget) string {
+	var buffer strings.Builder
+	for _, one := range list {
+		if one.Maximum > 0 {
+			fmt.Fprintf(&buffer, "%s <= %s\n", one.Category, one.Maximum.String())
+		}
+		if one.Minimum > 0 {
+			fmt.Fprintf(&buffer, "%s >= %s\n", one.Category, one.Minimum.String())
+		}
+	}
+	return buffer.String()
+}
+
+// Category returns the points spent in the given point budget category.
+func (pb *PointsBreakdown) Category(category string) fxp.Int {
+	switch category {
+	case AncestryBudgetCategory:
+		return pb.Ancestry
+	case AttributesBudgetCategory:
+		return pb.Attributes
+	case AdvantagesBudgetCategory:
+		return pb.Advantages
+	case DisadvantagesBudgetCategory:
+		return -pb.Disadvantages
+	case QuirksBudgetCategory:
+		return -pb.Quirks
+	case SkillsBudgetCategory:
+		return pb.Skills
+	case SpellsBudgetCategory:
+		return pb.Spells
+	default:
+		return 0
+	}
+}
+
+// PointBudgetStatus returns the status of the budget for the given category, or nil if the category has no budget.
+func (e *Entity) PointBudgetStatus(category string) *PointBudgetStatus {
+	for _, one := range e.SheetSettings.PointBudgets {
+		if one.Category == category && (one.Minimum > 0 || one.Maximum > 0) {
+			status := &PointBudgetStatus{
+				Budget: one,
+				Spent:  e.PointsBreakdown().Category(category),
+			}
+			if one.Maximum > 0 {
+				status.Remaining = one.Maximum - status.Spent
+			}
+			return status
+		}
+	}
+	return nil
+}
+
+// Exceeded returns true if more points have been spent than the budget's maximum allows.
+func (s *PointBudgetStatus) Exceeded() bool {
+	return s != nil && s.Budget.Maximum > 0 && s.Spent > s.Budget.Maximum
+}
+
+// Short returns true if fewer points have been spent than the budget's minimum requires.
+func (s *PointBudgetStatus) Short() bool {
+	return s != nil && s.Budget.Minimum > 0 && s.Spent < s.Budget.Minimum
+}
+
+// Violated returns true if the budget's maximum has been exceeded or its minimum has not been met.
+func (s *PointBudgetStatus) Violated() bool {
+	return s.Exceeded() || s.Short()
+}
+
+// PointBudgetViolations returns a description of each point budget the character fails to meet.
+func (e *Entity) PointBudgetViolations() []string {
+	var list []string
+	for _, category := range PointBudgetCategories {
+		status := e.PointBudgetStatus(category)
+		switch {
+		case status.Exceeded():
+			list = append(list, fmt.Sprintf(i18n.Text("%s total %s points, exceeding the budget of %s"),
+				txt.FirstToUpper(category), status.Spent.Comma(), status.Budget.Maximum.Comma()))
+		case status.Short():
+			list = append(list, fmt.Sprintf(i18n.Text("%s total %s points, short of the required %s"),
+				txt.FirstToUpper(category), status.Spent.Comma(), status.Budget.Minimum.Comma()))
+		}
+	}
+	return list
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+const (
+	pointsJournalAttributePrefix = "attribute:"
+	pointsJournalCulturesKey     = "cultures"
+)
+
+// PointsJournalEntry records a change in the points spent on a single item.
+type PointsJournalEntry struct {
+	When        jio.Time `json:"when"`
+	Key         string   `json:"key"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Delta       fxp.Int  `json:"delta"`
+}
+
+type pointsJournalItem struct {
+	key    string
+	name   string
+	points fxp.Int
+}
+
+// ClonePointsJournal creates a clone of the provided PointsJournalEntry list.
+func ClonePointsJournal(list []*PointsJournalEntry) []*PointsJournalEntry {
+	clone := make([]*PointsJournalEntry, len(list))
+	for i, one := range list {
+		entry := *one
+		clone[i] = &entry
+	}
+	return clone
+}
+
+// PointsJournalChanges returns the entries needed to bring the points journal up to date with the points currently spent
+// on each item. The journal itself is not modified.
+func (e *Entity) PointsJournalChanges(when jio.Time) []*PointsJournalEntry {
+	recorded := make(map[string]fxp.Int)
+	names := make(map[string]string)
+	var order []string
+	for _, entry := range e.PointsJournal {
+		if _, exists := recorded[entry.Key]; !exists {
+			order = append(order, entry.Key)
+		}
+		recorded[entry.Key] += entry.Delta
+		names[entry.Key] = entry.Name
+	}
+	var changes []*PointsJournalEntry
+	current := make(map[string]bool)
+	for _, item := range e.pointsJournalItems() {
+		current[item.key] = true
+		prior, exists := recorded[item.key]
+		if item.points == prior {
+			continue
+		}
+		var format string
+		switch {
+		case !exists:
+			format = i18n.Text("Added %s")
+		case item.points > prior:
+			format = i18n.Text("Increased %s")
+		default:
+			format = i18n.Text("Decreased %s")
+		}
+		changes = append(changes, &PointsJournalEntry{
+			When:        when,
+			Key:         item.key,
+			Name:        item.name,
+			Description: fmt.Sprintf(format, item.name),
+			Delta:       item.points - prior,
+		})
+	}
+	for _, key := range order {
+		if !current[key] && recorded[key] != 0 {
+			changes = append(changes, &PointsJournalEntry{
+				When:        when,
+				Key:         key,
+				Name:        names[key],
+				Description: fmt.Sprintf(i18n.Text("Removed %s"), names[key]),
+				Delta:       -recorded[key],
+			})
+		}
+	}
+	return changes
+}
+
+// RecordPointsJournal appends any changes in the points spent on each item to the points journal.
+func (e *Entity) RecordPointsJournal(when jio.Time) {
+	e.PointsJournal = append(e.PointsJournal, e.PointsJournalChanges(when)...)
+}
+
+// pointsJournalItems returns the items that points are currently spent on, using the same granularity as the points
+// breakdown.
+func (e *Entity) pointsJournalItems() []pointsJournalItem {
+	var list []pointsJournalItem
+	if e.Attributes != nil {
+		keys := make([]string, 0, len(e.Attributes.Set))
+		for k := range e.Attributes.Set {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			attr := e.Attributes.Set[k]
+			if def := attr.AttributeDef(); def != nil && !def.IsSeparator() {
+				list = append(list, pointsJournalItem{
+					key:    pointsJournalAttributePrefix + attr.AttrID,
+					name:   def.Name,
+					points: attr.PointCost(),
+				})
+			}
+		}
+	}
+	for _, one := range e.Traits {
+		list = appendPointsJournalTrait(list, one)
+	}
+	Traverse(func(s *Skill) bool {
+		list = append(list, pointsJournalItem{key: string(s.TID), name: s.String(), points: s.Points})
+		return false
+	}, false, true, e.Skills...)
+	Traverse(func(s *Spell) bool {
+		list = append(list, pointsJournalItem{key: string(s.TID), name: s.String(), points: s.Points})
+		return false
+	}, false, true, e.Spells...)
+	for _, one := range e.Languages {
+		list = append(list, pointsJournalItem{key: string(one.TID), name: one.String(), points: one.AdjustedPoints()})
+	}
+	if pts := e.Cultures.Points(); pts != 0 {
+		list = append(list, pointsJournalItem{key: pointsJournalCulturesKey, name: i18n.Text("Cultural Familiarities"),
+			points: pts})
+	}
+	return list
+}
+
+func appendPointsJournalTrait(list []pointsJournalItem, t *Trait) []pointsJournalItem {
+	if t.Disabled {
+		return list
+	}
+	if t.Container() && t.ContainerType == container.Group {
+		for _, child := range t.Children {
+			list = appendPointsJournalTrait(list, child)
+		}
+		return list
+	}
+	return append(list, pointsJournalItem{key: string(t.TID), name: t.String(), points: t.AdjustedPoints()})
+}
+
+// PointsJournalReport returns a Markdown report of the points awarded and spent over time, including any spending not
+// yet recorded in the points journal.
+func (e *Entity) PointsJournalReport(when jio.Time) string {
+	type line struct {
+		when        jio.Time
+		description string
+		awarded     fxp.Int
+		spent       fxp.Int
+	}
+	lines := make([]line, 0, len(e.PointsRecord)+len(e.PointsJournal))
+	for _, rec := range e.PointsRecord {
+		description := rec.Reason
+		if description == "" {
+			description = i18n.Text("Points awarded")
+		}
+		lines = append(lines, line{when: rec.When, description: description, awarded: rec.Points})
+	}
+	for _, entry := range append(ClonePointsJournal(e.PointsJournal), e.PointsJournalChanges(when)...) {
+		lines = append(lines, line{when: entry.When, description: entry.Description, spent: entry.Delta})
+	}
+	slices.SortStableFunc(lines, func(a, b line) int {
+		if c := a.when.Compare(b.when); c != 0 {
+			return c
+		}
+		// Awards come before the spending they fund
+		if (a.awarded != 0) != (b.awarded != 0) {
+			if a.awarded != 0 {
+				return -1
+			}
+			return 1
+		}
+		return 0
+	})
+	var buffer strings.Builder
+	name := e.Profile.Name
+	if name == "" {
+		name = i18n.Text("Unnamed Character")
+	}
+	fmt.Fprintf(&buffer, "# %s\n\n", fmt.Sprintf(i18n.Text("%s Points Journal"), name))
+	fmt.Fprintf(&buffer, "| %s | %s | %s | %s | %s |\n|---|---|--:|--:|--:|\n", i18n.Text("Date"),
+		i18n.Text("Description"), i18n.Text("Awarded"), i18n.Text("Spent"), i18n.Text("Unspent"))
+	var unspent fxp.Int
+	for _, one := range lines {
+		unspent += one.awarded - one.spent
+		fmt.Fprintf(&buffer, "| %s | %s | %s | %s | %s |\n", time.Time(one.when).Format(time.DateOnly),
+			strings.ReplaceAll(one.description, "|", `\|`), pointsJournalAmount(one.awarded),
+			pointsJournalAmount(one.spent), unspent.Comma())
+	}
+	fmt.Fprintf(&buffer, "\n%s\n", fmt.Sprintf(i18n.Text("Total awarded: %s, total spent: %s, unspent: %s"),
+		e.TotalPoints.Comma(), e.PointsBreakdown().Total().Comma(), e.UnspentPoints().Comma()))
+	return buffer.String()
+}
+
+func pointsJournalAmount(value fxp.Int) string {
+	if value == 0 {
+		return ""
+	}
+	return value.Comma()
+}
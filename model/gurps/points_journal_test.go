@@ -0,0 +1,58 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestPointsJournal(t *testing.T) {
+	e := gurps.NewEntity()
+	e.RecordPointsJournal(jio.Now())
+	baseline := len(e.PointsJournal)
+
+	trait := gurps.NewTrait(e, nil, false)
+	trait.Name = "Combat Reflexes"
+	trait.BasePoints = fxp.From(15)
+	skill := gurps.NewSkill(e, nil, false)
+	skill.Name = "Broadsword"
+	skill.Points = fxp.Four
+	e.SetTraitList([]*gurps.Trait{trait})
+	e.SetSkillList([]*gurps.Skill{skill})
+
+	first := jio.Time(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC))
+	e.RecordPointsJournal(first)
+	check.Equal(t, baseline+2, len(e.PointsJournal))
+	check.Equal(t, "Added Combat Reflexes", e.PointsJournal[baseline].Description)
+	check.Equal(t, fxp.From(15), e.PointsJournal[baseline].Delta)
+	check.Equal(t, fxp.Four, e.PointsJournal[baseline+1].Delta)
+	check.Equal(t, 0, len(e.PointsJournalChanges(first)), "nothing changed since the last recording")
+
+	skill.Points = fxp.Eight
+	e.SetTraitList(nil)
+	second := jio.Time(time.Date(2024, 3, 8, 0, 0, 0, 0, time.UTC))
+	changes := e.PointsJournalChanges(second)
+	check.Equal(t, 2, len(changes))
+	check.Equal(t, "Increased Broadsword", changes[0].Description)
+	check.Equal(t, fxp.Four, changes[0].Delta)
+	check.Equal(t, "Removed Combat Reflexes", changes[1].Description)
+	check.Equal(t, -fxp.From(15), changes[1].Delta)
+
+	report := e.PointsJournalReport(second)
+	check.True(t, strings.Contains(report, "| 2024-03-08 | Increased Broadsword |  | 4 |"))
+	check.True(t, strings.Contains(report, "Removed Combat Reflexes"))
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/hashhelper"
+)
+
+// PoolRecord holds information about when and why a point pool's current value was adjusted through damage, healing,
+// or temporary points. Damage and Temporary hold the changes actually made to the pool's damage and temporary points,
+// so that the record can be undone later.
+type PoolRecord struct {
+	When      jio.Time `json:"when"`
+	Damage    fxp.Int  `json:"damage,omitempty"`
+	Temporary fxp.Int  `json:"temporary,omitempty"`
+	Note      string   `json:"note,omitempty"`
+}
+
+// ClonePoolRecordList creates a clone of the provided PoolRecord list.
+func ClonePoolRecordList(list []*PoolRecord) []*PoolRecord {
+	if list == nil {
+		return nil
+	}
+	clone := make([]*PoolRecord, len(list))
+	for i, one := range list {
+		record := *one
+		clone[i] = &record
+	}
+	return clone
+}
+
+// Description returns a description of the change the record made.
+func (r *PoolRecord) Description() string {
+	var parts []string
+	switch {
+	case r.Damage > 0:
+		parts = append(parts, fmt.Sprintf(i18n.Text("%s damage"), r.Damage.Comma()))
+	case r.Damage < 0:
+		parts = append(parts, fmt.Sprintf(i18n.Text("%s healed"), (-r.Damage).Comma()))
+	}
+	switch {
+	case r.Temporary > 0:
+		parts = append(parts, fmt.Sprintf(i18n.Text("%s temporary added"), r.Temporary.Comma()))
+	case r.Temporary < 0:
+		parts = append(parts, fmt.Sprintf(i18n.Text("%s temporary lost"), (-r.Temporary).Comma()))
+	}
+	if len(parts) == 0 {
+		return i18n.Text("No change")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// Hash writes this object's contents into the hasher.
+func (r *PoolRecord) Hash(h hash.Hash) {
+	hashhelper.Num64(h, time.Time(r.When).UnixNano())
+	hashhelper.Num64(h, r.Damage)
+	hashhelper.Num64(h, r.Temporary)
+	hashhelper.String(h, r.Note)
+}
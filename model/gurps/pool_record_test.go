@@ -0,0 +1,44 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestPoolRecords(t *testing.T) {
+	e := NewEntity()
+	hp := e.Attributes.Set["hp"]
+	check.NotNil(t, hp, "hit points")
+	check.Equal(t, fxp.From(10), hp.Current(), "starting hit points")
+
+	added := hp.AddTemporary(fxp.From(3), "Shield spell")
+	hp.ApplyDamage(fxp.From(5), "Sword cut")
+	check.Equal(t, fxp.From(8), hp.Current(), "temporary points are used up first")
+	check.Equal(t, fxp.From(0), hp.Temporary, "temporary points remaining")
+
+	healed := hp.ApplyHealing(fxp.From(4), "First aid")
+	check.Equal(t, fxp.From(10), hp.Current(), "healing can't exceed the maximum")
+	check.Equal(t, fxp.From(-2), healed.Damage, "only the healing actually done is recorded")
+
+	check.True(t, hp.UndoPoolRecord(healed), "undo healing")
+	check.Equal(t, fxp.From(8), hp.Current(), "after undoing the healing")
+	check.True(t, hp.UndoPoolRecord(added), "undo temporary points")
+	check.Equal(t, fxp.From(0), hp.Temporary, "temporary points can't go negative")
+	check.Equal(t, 1, len(hp.Log), "records remaining")
+	check.False(t, hp.UndoPoolRecord(added), "undoing a record only works once")
+
+	clone := hp.Clone(e)
+	check.Equal(t, len(hp.Log), len(clone.Log), "cloned log")
+	check.True(t, clone.Log[0] != hp.Log[0], "cloned log records are copies")
+}
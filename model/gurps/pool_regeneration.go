@@ -0,0 +1,131 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"bytes"
+	"fmt"
+	"hash"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/eval"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/hashhelper"
+)
+
+var _ Hashable = &PoolRegeneration{}
+
+// PoolRegeneration holds a named rule for restoring points to an attribute pool, such as "Hour of Rest" or "Day".
+type PoolRegeneration struct {
+	PoolRegenerationData
+	KeyPrefix string
+}
+
+// PoolRegenerationData holds the data that will be serialized for the PoolRegeneration.
+type PoolRegenerationData struct {
+	Name       string `json:"name"`
+	Expression string `json:"expression"`
+}
+
+// MarshalJSON implements json.Marshaler.
+func (p *PoolRegeneration) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	e := json.NewEncoder(&buffer)
+	e.SetEscapeHTML(false)
+	err := e.Encode(&p.PoolRegenerationData)
+	return buffer.Bytes(), err
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (p *PoolRegeneration) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &p.PoolRegenerationData)
+}
+
+// Clone a copy of this.
+func (p *PoolRegeneration) Clone() *PoolRegeneration {
+	clone := *p
+	return &clone
+}
+
+// Amount returns the number of points restored by a single application of this rule. May be negative for pools that
+// drain over time.
+func (p *PoolRegeneration) Amount(resolver eval.VariableResolver) fxp.Int {
+	return fxp.EvaluateToNumber(p.Expression, resolver)
+}
+
+// Hash writes this object's contents into the hasher.
+func (p *PoolRegeneration) Hash(h hash.Hash) {
+	hashhelper.String(h, p.Name)
+	hashhelper.String(h, p.Expression)
+}
+
+func (p *PoolRegeneration) String() string {
+	return p.Name
+}
+
+// Regenerate applies the pool's regeneration rule with the given name the specified number of times, returning the
+// resulting record from the pool's log, or nil if the pool has no such rule or its value didn't change.
+func (a *Attribute) Regenerate(name string, times int) *PoolRecord {
+	def := a.AttributeDef()
+	if def == nil || !def.Pool() || def.IsSeparator() || times < 1 {
+		return nil
+	}
+	for _, one := range def.Regeneration {
+		if strings.EqualFold(one.Name, name) {
+			amount := one.Amount(a.Entity).Mul(fxp.From(times))
+			note := one.Name
+			if times > 1 {
+				note = fmt.Sprintf(i18n.Text("%s (x%d)"), one.Name, times)
+			}
+			var record *PoolRecord
+			switch {
+			case amount > 0 && a.Damage > 0:
+				record = a.ApplyHealing(amount, note)
+			case amount < 0 && a.Current() > 0:
+				record = a.ApplyDamage((-amount).Min(a.Current()), note)
+			}
+			return record
+		}
+	}
+	return nil
+}
+
+// PoolRegenerationNames returns the distinct names of the regeneration rules defined for the entity's pools.
+func (e *Entity) PoolRegenerationNames() []string {
+	var names []string
+	for _, def := range e.SheetSettings.Attributes.List(true) {
+		if !def.Pool() {
+			continue
+		}
+		for _, one := range def.Regeneration {
+			if name := strings.TrimSpace(one.Name); name != "" && !slices.ContainsFunc(names, func(s string) bool {
+				return strings.EqualFold(s, name)
+			}) {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// ApplyPoolRegeneration applies the regeneration rule with the given name the specified number of times to each pool
+// that defines it. Returns true if any pool changed.
+func (e *Entity) ApplyPoolRegeneration(name string, times int) bool {
+	changed := false
+	for _, attr := range e.Attributes.List() {
+		if attr.Regenerate(name, times) != nil {
+			changed = true
+		}
+	}
+	return changed
+}
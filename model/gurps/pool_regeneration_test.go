@@ -0,0 +1,54 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestPoolRegeneration(t *testing.T) {
+	e := gurps.NewEntity()
+	def := e.SheetSettings.Attributes.Set[gurps.FatiguePointsID]
+	check.NotNil(t, def)
+	def.Regeneration = []*gurps.PoolRegeneration{
+		{PoolRegenerationData: gurps.PoolRegenerationData{Name: "Hour of Rest", Expression: "2"}},
+		{PoolRegenerationData: gurps.PoolRegenerationData{Name: "Exertion", Expression: "-3"}},
+	}
+	check.Equal(t, []string{"Hour of Rest", "Exertion"}, e.PoolRegenerationNames())
+
+	fp := e.Attributes.Set[gurps.FatiguePointsID]
+	maximum := fp.Maximum()
+	fp.ApplyDamage(fxp.Five, "")
+	check.True(t, e.ApplyPoolRegeneration("hour of rest", 2))
+	check.Equal(t, maximum-fxp.One, fp.Current())
+	record := fp.Regenerate("Hour of Rest", 1)
+	check.NotNil(t, record)
+	check.Equal(t, -fxp.One, record.Damage, "healing is limited to the damage taken")
+	check.Nil(t, fp.Regenerate("Hour of Rest", 1), "nothing to restore")
+	check.False(t, e.ApplyPoolRegeneration("Meditation", 1))
+
+	check.True(t, e.ApplyPoolRegeneration("Exertion", 1))
+	check.Equal(t, maximum-fxp.Three, fp.Current())
+}
+
+func TestPoolRegenerationSelfReference(t *testing.T) {
+	var def gurps.AttributeDef
+	check.NoError(t, json.Unmarshal([]byte(`{"id":"mp","type":"pool","name":"MP","attribute_base":"10","regeneration":[{"name":"Meditation","expression":"$self/5"}]}`), &def))
+	check.Equal(t, 1, len(def.Regeneration))
+	check.Equal(t, "$mp/5", def.Regeneration[0].Expression)
+	clone := def.Clone()
+	check.Equal(t, def.Regeneration[0].Expression, clone.Regeneration[0].Expression)
+	check.False(t, def.Regeneration[0] == clone.Regeneration[0])
+}
@@ -131,6 +131,24 @@ func (p *Profile) ApplyRandomizers(entity *Entity) {
 	p.Weight = a.RandomWeight(entity, p.Gender, 0)
 	globalSettings := GlobalSettings()
 	generalSettings := globalSettings.GeneralSettings()
-	p.Name = a.RandomName(AvailableNameGenerators(globalSettings.Libraries()), p.Gender)
+	p.Name = entity.RandomName()
 	p.Birthday = generalSettings.CalendarRef(globalSettings.Libraries()).RandomBirthday(p.Birthday)
 }
+
+// RollPhysicalCharacteristics randomizes the height, weight and age together using the current ancestry, whose formulas
+// are typically driven by ST. The ancestry describes members of its normal size, so any SM set directly on the profile
+// (rather than granted by traits) scales the height by the linear measurement for that SM and the weight by the cube of
+// that scale.
+func (p *Profile) RollPhysicalCharacteristics(entity *Entity) {
+	a := entity.Ancestry()
+	height := fxp.Int(a.RandomHeight(entity, p.Gender, 0))
+	weight := fxp.Int(a.RandomWeight(entity, p.Gender, 0))
+	if p.SizeModifier != 0 {
+		scale := valueToYards(p.SizeModifier).Div(valueToYards(0))
+		height = height.Mul(scale)
+		weight = weight.Mul(scale).Mul(scale).Mul(scale)
+	}
+	p.Height = fxp.Length(max(height, fxp.One))
+	p.Weight = fxp.Weight(max(weight, fxp.One))
+	p.Age = strconv.Itoa(max(a.RandomAge(entity, p.Gender, 0), 1))
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestRollPhysicalCharacteristics(t *testing.T) {
+	e := NewEntity()
+	for i := 0; i < 20; i++ {
+		e.Profile.RollPhysicalCharacteristics(e)
+		check.True(t, e.Profile.Height >= fxp.LengthFromInteger(63, fxp.Inch) &&
+			e.Profile.Height <= fxp.LengthFromInteger(73, fxp.Inch), "height for ST 10")
+		check.True(t, e.Profile.Weight >= fxp.WeightFromInteger(115, fxp.Pound) &&
+			e.Profile.Weight <= fxp.WeightFromInteger(175, fxp.Pound), "weight for ST 10")
+		age, err := strconv.Atoi(e.Profile.Age)
+		check.NoError(t, err)
+		check.True(t, age >= 15 && age <= 26, "human age")
+	}
+
+	e.Profile.SizeModifier = 1
+	for i := 0; i < 20; i++ {
+		e.Profile.RollPhysicalCharacteristics(e)
+		check.True(t, e.Profile.Height >= fxp.LengthFromInteger(94, fxp.Inch) &&
+			e.Profile.Height <= fxp.LengthFromInteger(110, fxp.Inch), "height scaled by SM +1")
+		check.True(t, e.Profile.Weight >= fxp.WeightFromInteger(388, fxp.Pound) &&
+			e.Profile.Weight <= fxp.WeightFromInteger(591, fxp.Pound), "weight scaled by SM +1")
+	}
+}
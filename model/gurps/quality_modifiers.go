@@ -0,0 +1,75 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/criteria"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/emcost"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wsel"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// NewQualityModifiers creates a new container of equipment modifiers for weapon and armor quality and materials. The
+// modifiers are all disabled, so that the ones that apply can be enabled as needed. Their features adjust the damage of
+// the equipment's own weapons and the DR of the equipment itself, so there is no need to mirror them by hand.
+func NewQualityModifiers(owner DataOwner) *EquipmentModifier {
+	c := NewEquipmentModifier(owner, nil, true)
+	c.Name = i18n.Text("Quality & Materials")
+	c.SetChildren([]*EquipmentModifier{
+		newQualityModifier(owner, c, i18n.Text("Cheap"), "B274", "-0.6", 0, 0,
+			i18n.Text("Breaks more easily when parrying or being parried (B485)")),
+		newQualityModifier(owner, c, i18n.Text("Fine"), "B274", "+3", 1, 0,
+			i18n.Text("Damage bonus applies only to cutting and impaling attacks; less likely to break (B485)")),
+		newQualityModifier(owner, c, i18n.Text("Very Fine"), "B274", "+19", 2, 0,
+			i18n.Text("Swords only; damage bonus applies only to cutting and impaling attacks; very unlikely to break (B485)")),
+		newQualityModifier(owner, c, i18n.Text("Silvered"), "", "+2", 0, 0,
+			i18n.Text("Counts as silver against those vulnerable to it; breaks as a cheap weapon if the silver is solid")),
+		newQualityModifier(owner, c, i18n.Text("Solid Silver"), "", "+19", 0, 0,
+			i18n.Text("Counts as silver against those vulnerable to it; breaks as a cheap weapon (B485)")),
+		newQualityModifier(owner, c, i18n.Text("Orichalcum"), "", "+29", 2, 0,
+			i18n.Text("Counts as very fine; never breaks")),
+		newQualityModifier(owner, c, i18n.Text("Cheap Armor"), "", "-0.6", 0, -1,
+			i18n.Text("Lower quality materials and workmanship")),
+		newQualityModifier(owner, c, i18n.Text("Reinforced Armor"), "", "+1", 0, 1,
+			i18n.Text("Heavier construction with extra protection")),
+	})
+	return c
+}
+
+func newQualityModifier(owner DataOwner, parent *EquipmentModifier, name, pageRef, costFactor string, damage, dr int, notes string) *EquipmentModifier {
+	m := NewEquipmentModifier(owner, parent, false)
+	m.Name = name
+	m.PageRef = pageRef
+	m.LocalNotes = notes
+	m.Disabled = true
+	m.CostType = emcost.Base
+	m.CostAmount = costFactor + " " + emcost.CostFactor.String()
+	if damage != 0 {
+		// Quality only improves cutting and impaling damage (B274). A weapon has a single damage type, so at most one
+		// of these will apply to it.
+		for _, damageType := range []string{"cut", "imp"} {
+			bonus := NewWeaponDamageBonus()
+			bonus.SelectionType = wsel.ThisWeapon
+			bonus.DamageTypeCriteria.Compare = criteria.IsText
+			bonus.DamageTypeCriteria.Qualifier = damageType
+			bonus.Amount = fxp.From(damage)
+			m.Features = append(m.Features, bonus)
+		}
+	}
+	if dr != 0 {
+		bonus := NewDRBonus()
+		// An empty set of locations applies the bonus to the locations covered by the equipment itself.
+		bonus.Locations = nil
+		bonus.Amount = fxp.From(dr)
+		m.Features = append(m.Features, bonus)
+	}
+	return m
+}
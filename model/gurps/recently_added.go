@@ -0,0 +1,27 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import "slices"
+
+// RecentlyAddedMax is the maximum number of library items added to sheets that are remembered.
+const RecentlyAddedMax = 15
+
+// AddRecentlyAdded records that the library item was added to a sheet. The most recent additions come first and an
+// item that was already present is moved to the front.
+func (s *Settings) AddRecentlyAdded(item *Favorite) {
+	s.RecentlyAdded = slices.DeleteFunc(s.RecentlyAdded, func(one *Favorite) bool {
+		return one.From == item.From && one.ID == item.ID
+	})
+	s.RecentlyAdded = slices.Insert(s.RecentlyAdded, 0, item)
+	if len(s.RecentlyAdded) > RecentlyAddedMax {
+		s.RecentlyAdded = s.RecentlyAdded[:RecentlyAddedMax]
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+func TestRecentlyAdded(t *testing.T) {
+	from := gurps.LibraryFile{Library: "test/lib", Path: "Traits.adq"}
+	var settings gurps.Settings
+	for i := range gurps.RecentlyAddedMax + 2 {
+		settings.AddRecentlyAdded(&gurps.Favorite{
+			From: from,
+			ID:   tid.TID(fmt.Sprintf("t%016d", i)),
+			Name: fmt.Sprintf("Trait %d", i),
+		})
+	}
+	check.Equal(t, gurps.RecentlyAddedMax, len(settings.RecentlyAdded))
+	check.Equal(t, fmt.Sprintf("Trait %d", gurps.RecentlyAddedMax+1), settings.RecentlyAdded[0].Name)
+	check.Equal(t, "Trait 2", settings.RecentlyAdded[gurps.RecentlyAddedMax-1].Name, "the oldest are dropped")
+
+	settings.AddRecentlyAdded(&gurps.Favorite{From: from, ID: tid.TID(fmt.Sprintf("t%016d", 5)), Name: "Trait 5"})
+	check.Equal(t, gurps.RecentlyAddedMax, len(settings.RecentlyAdded))
+	check.Equal(t, "Trait 5", settings.RecentlyAdded[0].Name, "adding again moves it to the front")
+	count := 0
+	for _, one := range settings.RecentlyAdded {
+		if one.Name == "Trait 5" {
+			count++
+		}
+	}
+	check.Equal(t, 1, count)
+}
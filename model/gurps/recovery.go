@@ -0,0 +1,102 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xio/fs"
+)
+
+const (
+	recoveryDirName     = "recovery"
+	recoveryJournalName = "journal.json"
+)
+
+// RecoveryEntry holds information about a document with unsaved changes that was written to the recovery directory.
+type RecoveryEntry struct {
+	Path  string   `json:"path"`
+	File  string   `json:"file"`
+	Saved jio.Time `json:"saved"`
+}
+
+// RecoveryJournal holds the documents that were written to the recovery directory by the most recent autosave.
+type RecoveryJournal struct {
+	Entries []*RecoveryEntry `json:"entries,omitempty"`
+}
+
+// RecoveryDir returns the directory that autosaved copies of documents with unsaved changes are written to.
+func RecoveryDir() string {
+	return filepath.Join(filepath.Dir(SettingsPath), recoveryDirName)
+}
+
+// RecoveryFileName returns the name to use within the recovery directory for the index'th document.
+func RecoveryFileName(index int, originalPath string) string {
+	return fmt.Sprintf("%d%s", index, filepath.Ext(originalPath))
+}
+
+// LoadRecoveryJournal loads the journal from the directory. Entries whose files are missing are dropped. Returns an
+// empty journal if none exists.
+func LoadRecoveryJournal(dir string) (*RecoveryJournal, error) {
+	var journal RecoveryJournal
+	journalPath := filepath.Join(dir, recoveryJournalName)
+	if !fs.FileExists(journalPath) {
+		return &journal, nil
+	}
+	if err := jio.LoadFromFile(context.Background(), journalPath, &journal); err != nil {
+		return nil, err
+	}
+	entries := journal.Entries[:0]
+	for _, entry := range journal.Entries {
+		if entry != nil && entry.File != "" && entry.File == filepath.Base(entry.File) &&
+			fs.FileExists(filepath.Join(dir, entry.File)) {
+			entries = append(entries, entry)
+		}
+	}
+	journal.Entries = entries
+	return &journal, nil
+}
+
+// Save writes the journal into the directory and then removes any files within it that the journal doesn't reference.
+func (j *RecoveryJournal) Save(dir string) error {
+	if err := jio.SaveToFile(context.Background(), filepath.Join(dir, recoveryJournalName), j); err != nil {
+		return err
+	}
+	keep := make(map[string]bool, len(j.Entries)+1)
+	keep[recoveryJournalName] = true
+	for _, entry := range j.Entries {
+		keep[entry.File] = true
+	}
+	list, err := os.ReadDir(dir)
+	if err != nil {
+		return errs.Wrap(err)
+	}
+	for _, one := range list {
+		if !keep[one.Name()] {
+			if err = os.RemoveAll(filepath.Join(dir, one.Name())); err != nil {
+				return errs.Wrap(err)
+			}
+		}
+	}
+	return nil
+}
+
+// ClearRecovery removes the recovery directory and everything in it.
+func ClearRecovery(dir string) error {
+	if err := os.RemoveAll(dir); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
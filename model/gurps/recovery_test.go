@@ -0,0 +1,49 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/toolbox/xio/fs"
+)
+
+func TestRecoveryJournal(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "recovery")
+	journal, err := gurps.LoadRecoveryJournal(dir)
+	check.NoError(t, err)
+	check.Equal(t, 0, len(journal.Entries))
+
+	kept := gurps.RecoveryFileName(0, "/some/where/Bob.gcs")
+	check.Equal(t, "0.gcs", kept)
+	check.NoError(t, os.MkdirAll(dir, 0o750))
+	check.NoError(t, os.WriteFile(filepath.Join(dir, kept), []byte("{}"), 0o600))
+	check.NoError(t, os.WriteFile(filepath.Join(dir, "1.gct"), []byte("{}"), 0o600))
+	journal.Entries = []*gurps.RecoveryEntry{
+		{Path: "/some/where/Bob.gcs", File: kept, Saved: jio.Now()},
+		{Path: "/some/where/Gone.gcs", File: "2.gcs", Saved: jio.Now()},
+		{Path: "/some/where/Escape.gcs", File: "../Escape.gcs", Saved: jio.Now()},
+	}
+	check.NoError(t, journal.Save(dir))
+	check.False(t, fs.FileExists(filepath.Join(dir, "1.gct")))
+
+	journal, err = gurps.LoadRecoveryJournal(dir)
+	check.NoError(t, err)
+	check.Equal(t, 1, len(journal.Entries))
+	check.Equal(t, "/some/where/Bob.gcs", journal.Entries[0].Path)
+
+	check.NoError(t, gurps.ClearRecovery(dir))
+	check.False(t, fs.IsDir(dir))
+}
@@ -26,9 +26,18 @@ type Release struct {
 	Version     string
 	Notes       string
 	ZipFileURL  string
+	Assets      []ReleaseAsset
+	Prerelease  bool
 	CheckFailed bool
 }
 
+// ReleaseAsset holds information about a single downloadable file attached to a release.
+type ReleaseAsset struct {
+	Name string
+	URL  string
+	Size int64
+}
+
 // HasUpdate returns true if there is an update available.
 func (r *Release) HasUpdate() bool {
 	return !r.CheckFailed && r.Version != ""
@@ -65,6 +74,12 @@ func LoadReleases(ctx context.Context, client *http.Client, githubAccountName, a
 		TagName    string `json:"tag_name"`
 		Body       string `json:"body"`
 		ZipBallURL string `json:"zipball_url"`
+		Assets     []struct {
+			Name string `json:"name"`
+			URL  string `json:"browser_download_url"`
+			Size int64  `json:"size"`
+		} `json:"assets"`
+		Prerelease bool `json:"prerelease"`
 	}
 	if err = json.NewDecoder(rsp.Body).Decode(&releases); err != nil {
 		return nil, errs.NewWithCause("unable to decode response from GitHub API "+uri, err)
@@ -74,11 +89,20 @@ func LoadReleases(ctx context.Context, client *http.Client, githubAccountName, a
 			if version := strings.TrimSpace(one.TagName[1:]); version != "" &&
 				(currentVersion == version || txt.NaturalLess(currentVersion, version, true)) {
 				if filter == nil || !filter(version, one.Body) {
-					versions = append(versions, Release{
+					rel := Release{
 						Version:    version,
 						Notes:      one.Body,
 						ZipFileURL: one.ZipBallURL,
-					})
+						Prerelease: one.Prerelease,
+					}
+					for _, asset := range one.Assets {
+						rel.Assets = append(rel.Assets, ReleaseAsset{
+							Name: asset.Name,
+							URL:  asset.URL,
+							Size: asset.Size,
+						})
+					}
+					versions = append(versions, rel)
 				}
 			}
 		}
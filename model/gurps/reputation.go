@@ -0,0 +1,85 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/reputation"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Limits for the reaction modifier of a Reputation.
+const (
+	MinReputationModifier = -4
+	MaxReputationModifier = 4
+)
+
+// ReputationTraitName is the name given to traits created by the Reputation builder.
+const ReputationTraitName = "Reputation"
+
+// Reputation holds the data used to build a Reputation trait.
+type Reputation struct {
+	Group       string
+	Modifier    int
+	Audience    reputation.Audience
+	Recognition reputation.Recognition
+}
+
+// Points returns the point cost of the reputation. Each +1 of reaction modifier costs 5 points, which is then
+// multiplied by the fractions for the size of the audience and the frequency of recognition. Fractions are dropped.
+func (r *Reputation) Points() fxp.Int {
+	an, ad := r.Audience.Multiplier()
+	fn, fd := r.Recognition.Multiplier()
+	return fxp.From(5 * r.clampedModifier() * an * fn / (ad * fd))
+}
+
+// Situation returns the situation text used for the reaction bonus granted by the reputation.
+func (r *Reputation) Situation() string {
+	group := strings.TrimSpace(r.Group)
+	var situation string
+	switch {
+	case r.Audience == reputation.Everyone || group == "":
+		situation = i18n.Text("from others")
+	case r.Audience == reputation.EveryoneButOneGroup:
+		situation = fmt.Sprintf(i18n.Text("from everyone except %s"), group)
+	default:
+		situation = fmt.Sprintf(i18n.Text("from %s"), group)
+	}
+	if roll := r.Recognition.Roll(); roll != 0 {
+		situation += fmt.Sprintf(i18n.Text(" when recognized (%d or less)"), roll)
+	}
+	return situation
+}
+
+// NewTrait creates a new trait for the reputation, with its cost and a reaction bonus already set up.
+func (r *Reputation) NewTrait(owner DataOwner) *Trait {
+	t := NewTrait(owner, nil, false)
+	t.Name = ReputationTraitName
+	modifier := r.clampedModifier()
+	t.LocalNotes = fmt.Sprintf(i18n.Text("%+d reaction %s"), modifier, r.Situation())
+	t.BasePoints = r.Points()
+	if modifier < 0 {
+		t.Tags = []string{i18n.Text("Disadvantage"), i18n.Text("Social")}
+	} else {
+		t.Tags = []string{i18n.Text("Advantage"), i18n.Text("Social")}
+	}
+	bonus := NewReactionBonus()
+	bonus.Situation = r.Situation()
+	bonus.Amount = fxp.From(modifier)
+	t.Features = Features{bonus}
+	return t
+}
+
+func (r *Reputation) clampedModifier() int {
+	return min(max(r.Modifier, MinReputationModifier), MaxReputationModifier)
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/reputation"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestReputationPoints(t *testing.T) {
+	r := Reputation{Modifier: 2}
+	check.Equal(t, fxp.From(10), r.Points(), "+2 from everyone, all the time")
+	r.Audience = reputation.SmallGroup
+	r.Recognition = reputation.Sometimes
+	check.Equal(t, fxp.From(1), r.Points(), "+2 from a small group, sometimes")
+	r.Modifier = -3
+	r.Audience = reputation.LargeGroup
+	r.Recognition = reputation.Occasionally
+	check.Equal(t, fxp.From(-2), r.Points(), "-3 from a large group, occasionally")
+	r.Modifier = 9
+	r.Audience = reputation.EveryoneButOneGroup
+	r.Recognition = reputation.Always
+	check.Equal(t, fxp.From(13), r.Points(), "modifier is clamped to +4")
+}
+
+func TestReputationReactions(t *testing.T) {
+	e := NewEntity()
+	r := Reputation{Group: "the Police", Modifier: -2, Audience: reputation.LargeGroup, Recognition: reputation.Sometimes}
+	trait := r.NewTrait(e)
+	e.SetTraitList([]*Trait{trait})
+	check.Equal(t, fxp.From(-2), trait.AdjustedPoints(), "trait points")
+	reactions := e.Reactions()
+	check.Equal(t, 1, len(reactions), "reaction count")
+	check.Equal(t, r.Situation(), reactions[0].From, "reaction situation")
+	check.Equal(t, fxp.From(-2), reactions[0].Total(), "reaction amount")
+}
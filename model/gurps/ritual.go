@@ -0,0 +1,214 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/difficulty"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/rpm"
+	"github.com/richardwilkes/gcs/v5/model/kinds"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// Names used when resolving Ritual Path Magic skills and traits.
+const (
+	MageryTraitName        = "Magery"
+	ThaumatologySkillName  = "Thaumatology"
+	ritualConditionalCost  = 5
+	ritualPathSkillPattern = "Path of %s"
+)
+
+// RitualEffect holds a single effect of a Ritual Path Magic ritual.
+type RitualEffect struct {
+	Effect  rpm.Effect `json:"effect"`
+	Path    rpm.Path   `json:"path"`
+	Greater bool       `json:"greater,omitempty"`
+}
+
+// RitualModifier holds a modifier that adjusts the energy cost of a Ritual Path Magic ritual, e.g. "Duration, 1 day" or
+// "Bestows a Bonus, +2".
+type RitualModifier struct {
+	Name   string `json:"name"`
+	Energy int    `json:"energy"`
+}
+
+// Ritual holds a Ritual Path Magic ritual in a character's grimoire, along with the energy gathered toward casting it.
+// A conditional ritual has already been cast and is held until its trigger occurs.
+type Ritual struct {
+	ID          tid.TID           `json:"id"`
+	Name        string            `json:"name"`
+	Effects     []*RitualEffect   `json:"effects,omitempty"`
+	Modifiers   []*RitualModifier `json:"modifiers,omitempty"`
+	Notes       string            `json:"notes,omitempty"`
+	Gathered    int               `json:"gathered,omitempty"`
+	Conditional bool              `json:"conditional,omitempty"`
+	Trigger     string            `json:"trigger,omitempty"`
+}
+
+// NewRitual creates a new, empty ritual.
+func NewRitual(name string) *Ritual {
+	return &Ritual{
+		ID:   tid.MustNewTID(kinds.Ritual),
+		Name: name,
+	}
+}
+
+// CloneRituals creates a clone of the provided Ritual list.
+func CloneRituals(list []*Ritual) []*Ritual {
+	if list == nil {
+		return nil
+	}
+	clone := make([]*Ritual, len(list))
+	for i, one := range list {
+		clone[i] = one.Clone()
+	}
+	return clone
+}
+
+// Clone creates a copy of this ritual.
+func (r *Ritual) Clone() *Ritual {
+	other := *r
+	if r.Effects != nil {
+		other.Effects = make([]*RitualEffect, len(r.Effects))
+		for i, one := range r.Effects {
+			effect := *one
+			other.Effects[i] = &effect
+		}
+	}
+	if r.Modifiers != nil {
+		other.Modifiers = make([]*RitualModifier, len(r.Modifiers))
+		for i, one := range r.Modifiers {
+			modifier := *one
+			other.Modifiers[i] = &modifier
+		}
+	}
+	return &other
+}
+
+// String implements fmt.Stringer.
+func (r *Ritual) String() string {
+	return r.Name
+}
+
+// EffectsText returns a description of the effects of this ritual, e.g. "Lesser Sense Mind + Greater Control Body".
+func (r *Ritual) EffectsText() string {
+	parts := make([]string, 0, len(r.Effects))
+	for _, one := range r.Effects {
+		parts = append(parts, one.String())
+	}
+	return strings.Join(parts, " + ")
+}
+
+// EnergyCost returns the energy required to cast this ritual. Each greater effect adds twice the base cost again.
+func (r *Ritual) EnergyCost() int {
+	cost := 0
+	greater := 0
+	for _, one := range r.Effects {
+		cost += one.Effect.BaseEnergy()
+		if one.Greater {
+			greater++
+		}
+	}
+	for _, one := range r.Modifiers {
+		cost += one.Energy
+	}
+	if r.Conditional {
+		cost += ritualConditionalCost
+	}
+	return max(cost, 0) * (1 + 2*greater)
+}
+
+// EnergyRemaining returns the energy that must still be gathered before this ritual can be cast.
+func (r *Ritual) EnergyRemaining() int {
+	return max(r.EnergyCost()-r.Gathered, 0)
+}
+
+// Level returns the skill level the ritual is cast and its energy gathered at, which is the lowest of the Path skills
+// its effects draw upon. Returns 0 if the entity lacks any of the required Path skills.
+func (r *Ritual) Level(e *Entity) fxp.Int {
+	if len(r.Effects) == 0 {
+		return 0
+	}
+	level := fxp.Max
+	for _, one := range r.Effects {
+		level = min(level, e.PathLevel(one.Path))
+	}
+	return level
+}
+
+// EnergyPerGatheringRoll returns the energy gathered with each successful gathering roll.
+func (r *Ritual) EnergyPerGatheringRoll(e *Entity) int {
+	return max(fxp.As[int](e.MageryLevel()), 1)
+}
+
+// GatheringRollsNeeded returns the number of successful gathering rolls still needed before this ritual can be cast.
+func (r *Ritual) GatheringRollsNeeded(e *Entity) int {
+	perRoll := r.EnergyPerGatheringRoll(e)
+	return (r.EnergyRemaining() + perRoll - 1) / perRoll
+}
+
+// Gather accumulates the energy from the given number of successful gathering rolls. Energy beyond the ritual's cost is
+// not retained.
+func (r *Ritual) Gather(e *Entity, successes int) {
+	r.Gathered = min(r.Gathered+max(successes, 0)*r.EnergyPerGatheringRoll(e), r.EnergyCost())
+}
+
+// String implements fmt.Stringer.
+func (r *RitualEffect) String() string {
+	strength := i18n.Text("Lesser")
+	if r.Greater {
+		strength = i18n.Text("Greater")
+	}
+	return fmt.Sprintf(i18n.Text("%s %s %s"), strength, r.Effect, r.Path)
+}
+
+// PathSkillName returns the name of the skill for the Path.
+func PathSkillName(path rpm.Path) string {
+	return fmt.Sprintf(ritualPathSkillPattern, path.String())
+}
+
+// NewPathSkill creates a new Path skill for Ritual Path Magic.
+func NewPathSkill(owner DataOwner, parent *Skill, path rpm.Path) *Skill {
+	s := NewSkill(owner, parent, false)
+	s.Name = PathSkillName(path)
+	s.Difficulty.Attribute = AttributeIDFor(EntityFromNode(s), IntelligenceID)
+	s.Difficulty.Difficulty = difficulty.VeryHard
+	return s
+}
+
+// PathLevel returns the entity's level with the skill for the Path, which may not exceed its Thaumatology level. Returns
+// 0 if the entity lacks the skill.
+func (e *Entity) PathLevel(path rpm.Path) fxp.Int {
+	sk := e.BestSkillNamed(PathSkillName(path), "", false, nil)
+	if sk == nil || sk.LevelData.Level <= 0 {
+		return 0
+	}
+	level := sk.LevelData.Level
+	if thaum := e.BestSkillNamed(ThaumatologySkillName, "", false, nil); thaum != nil && thaum.LevelData.Level > 0 {
+		level = min(level, thaum.LevelData.Level)
+	}
+	return level
+}
+
+// MageryLevel returns the entity's level of Magery, or 0 if it has none.
+func (e *Entity) MageryLevel() fxp.Int {
+	var level fxp.Int
+	Traverse(func(t *Trait) bool {
+		if strings.EqualFold(t.NameWithReplacements(), MageryTraitName) {
+			level = max(level, t.CurrentLevel())
+		}
+		return false
+	}, true, true, e.Traits...)
+	return level
+}
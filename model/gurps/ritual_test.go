@@ -0,0 +1,62 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/rpm"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestRitualEnergy(t *testing.T) {
+	e := gurps.NewEntity()
+	r := gurps.NewRitual("Lightning Strike")
+	r.Effects = []*gurps.RitualEffect{
+		{Effect: rpm.Create, Path: rpm.Energy},
+		{Effect: rpm.Sense, Path: rpm.Crossroads},
+	}
+	r.Modifiers = []*gurps.RitualModifier{{Name: "Damage, 3d burning", Energy: 4}}
+	check.Equal(t, 12, r.EnergyCost())
+	check.Equal(t, "Lesser Create Energy + Lesser Sense Crossroads", r.EffectsText())
+
+	r.Effects[0].Greater = true
+	check.Equal(t, 36, r.EnergyCost(), "a greater effect triples the cost")
+	r.Conditional = true
+	check.Equal(t, 51, r.EnergyCost())
+	r.Conditional = false
+
+	check.Equal(t, fxp.Int(0), e.MageryLevel())
+	check.Equal(t, 1, r.EnergyPerGatheringRoll(e))
+	check.Equal(t, 36, r.GatheringRollsNeeded(e))
+
+	magery := gurps.NewTrait(e, nil, false)
+	magery.Name = gurps.MageryTraitName
+	magery.CanLevel = true
+	magery.Levels = fxp.Three
+	e.SetTraitList([]*gurps.Trait{magery})
+	check.Equal(t, fxp.Three, e.MageryLevel())
+	check.Equal(t, 12, r.GatheringRollsNeeded(e))
+
+	r.Gather(e, 5)
+	check.Equal(t, 15, r.Gathered)
+	check.Equal(t, 21, r.EnergyRemaining())
+	check.Equal(t, 7, r.GatheringRollsNeeded(e))
+	r.Gather(e, 10)
+	check.Equal(t, 36, r.Gathered, "excess energy is not retained")
+	check.Equal(t, 0, r.GatheringRollsNeeded(e))
+
+	clone := r.Clone()
+	clone.Effects[0].Greater = false
+	check.True(t, r.Effects[0].Greater)
+	check.Equal(t, fxp.Int(0), r.Level(e), "no Path skills known")
+}
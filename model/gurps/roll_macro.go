@@ -0,0 +1,230 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/rpgtools/dice"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/eval"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/rand"
+)
+
+// A roll macro is embedded in text by enclosing it in square brackets, e.g. "[2d+2 cut]" or "[roll vs skill-4]".
+// Several steps may be chained together with semicolons, e.g. "[roll vs HT-2; 1d tox]". Steps following a success
+// roll are only performed if that roll succeeds, which allows riders such as poison to be attached to an attack.
+const (
+	rollMacroStepSeparator = ";"
+	rollMacroVersusPrefix  = "roll vs "
+	rollMacroSkillKeyword  = "skill"
+)
+
+var (
+	rollMacroRegex           = regexp.MustCompile(`\[([^\[\]]+)]`)
+	rollTargetIdentiferRegex = regexp.MustCompile(`[A-Za-z][A-Za-z0-9_]*`)
+	successRollDice          = dice.New("3d")
+)
+
+// RollTargetResolver resolves the target number for a success roll from an expression.
+type RollTargetResolver interface {
+	ResolveRollTarget(expr string) (int, error)
+}
+
+// RollMacro holds a parsed roll macro.
+type RollMacro struct {
+	Text  string
+	Steps []*RollMacroStep
+}
+
+// RollMacroStep holds a single step of a roll macro. Either Dice or Target will be set, but not both.
+type RollMacroStep struct {
+	Dice       *dice.Dice
+	DamageType string
+	Target     string
+}
+
+// RollMacroResult holds the result of performing a single step of a roll macro.
+type RollMacroResult struct {
+	Step     *RollMacroStep
+	Rolled   int
+	Target   int
+	Success  bool
+	Critical bool
+	Skipped  bool
+	Err      error
+}
+
+// ExtractRollMacros returns the roll macros embedded in the text, in the order they appear.
+func ExtractRollMacros(text string) []*RollMacro {
+	var list []*RollMacro
+	for _, match := range rollMacroRegex.FindAllStringSubmatch(text, -1) {
+		if m, ok := ParseRollMacro(match[1]); ok {
+			list = append(list, m)
+		}
+	}
+	return list
+}
+
+// ParseRollMacro parses the content of a roll macro, without its enclosing brackets.
+func ParseRollMacro(text string) (*RollMacro, bool) {
+	m := &RollMacro{Text: strings.TrimSpace(text)}
+	for _, part := range strings.Split(m.Text, rollMacroStepSeparator) {
+		part = strings.TrimSpace(part)
+		if len(part) > len(rollMacroVersusPrefix) && strings.EqualFold(part[:len(rollMacroVersusPrefix)], rollMacroVersusPrefix) {
+			m.Steps = append(m.Steps, &RollMacroStep{Target: strings.TrimSpace(part[len(rollMacroVersusPrefix):])})
+			continue
+		}
+		start, end := dice.ExtractDicePosition(part)
+		if start != 0 {
+			return nil, false
+		}
+		m.Steps = append(m.Steps, &RollMacroStep{
+			Dice:       dice.New(part[:end]),
+			DamageType: strings.TrimSpace(part[end:]),
+		})
+	}
+	return m, len(m.Steps) != 0
+}
+
+// Roll performs the steps of the macro, stopping at the first success roll that fails. Steps that were not performed
+// are returned with Skipped set.
+func (m *RollMacro) Roll(resolver RollTargetResolver, rnd rand.Randomizer) []*RollMacroResult {
+	results := make([]*RollMacroResult, 0, len(m.Steps))
+	skip := false
+	for _, step := range m.Steps {
+		result := &RollMacroResult{Step: step, Skipped: skip}
+		results = append(results, result)
+		if skip {
+			continue
+		}
+		if step.Dice != nil {
+			result.Rolled = step.Dice.RollWithRandomizer(rnd, false)
+			result.Rolled = max(result.Rolled, MinimumDamage(step.DamageType))
+			continue
+		}
+		if result.Target, result.Err = resolver.ResolveRollTarget(step.Target); result.Err != nil {
+			skip = true
+			continue
+		}
+		result.Rolled = successRollDice.RollWithRandomizer(rnd, false)
+		result.Success, result.Critical = SuccessRollOutcome(result.Rolled, result.Target)
+		skip = !result.Success
+	}
+	return results
+}
+
+// MinimumDamage returns the minimum damage a roll of the given damage type can do: 0 for crushing and 1 for anything
+// else.
+func MinimumDamage(damageType string) int {
+	if damageType == "" || strings.EqualFold(damageType, "cr") {
+		return 0
+	}
+	return 1
+}
+
+// SuccessRollOutcome determines whether a 3d6 roll against the target succeeds and whether that result is critical.
+func SuccessRollOutcome(rolled, target int) (success, critical bool) {
+	switch {
+	case rolled <= 4 || (rolled == 5 && target >= 15) || (rolled == 6 && target >= 16):
+		return true, true
+	case rolled == 18 || (rolled == 17 && target <= 15) || rolled-target >= 10:
+		return false, true
+	case rolled == 17:
+		return false, false
+	default:
+		return rolled <= target, false
+	}
+}
+
+// ResolveRollTarget resolves an expression for the target of a success roll, such as "skill-4" or "HT+2". The word
+// "skill" is replaced with the provided skill level, while attribute IDs and names are replaced with the current value
+// of that attribute.
+func ResolveRollTarget(entity *Entity, skillLevel fxp.Int, expr string) (int, error) {
+	var unknown string
+	text := rollTargetIdentiferRegex.ReplaceAllStringFunc(expr, func(name string) string {
+		if strings.EqualFold(name, rollMacroSkillKeyword) {
+			return skillLevel.String()
+		}
+		if entity != nil {
+			for _, def := range entity.SheetSettings.Attributes.List(true) {
+				if strings.EqualFold(def.DefID, name) || strings.EqualFold(def.Name, name) {
+					return entity.ResolveAttributeCurrent(def.DefID).String()
+				}
+			}
+		}
+		if unknown == "" {
+			unknown = name
+		}
+		return name
+	})
+	if unknown != "" {
+		return 0, errs.Newf(i18n.Text("unknown roll target: %s"), unknown)
+	}
+	var resolver eval.VariableResolver
+	if entity != nil {
+		resolver = entity
+	}
+	result, err := fxp.NewEvaluator(resolver).Evaluate(text)
+	if err != nil {
+		return 0, errs.Wrap(err)
+	}
+	value, ok := result.(fxp.Int)
+	if !ok {
+		return 0, errs.Newf(i18n.Text("invalid roll target: %s"), expr)
+	}
+	return fxp.As[int](value), nil
+}
+
+// String implements fmt.Stringer.
+func (s *RollMacroStep) String() string {
+	if s.Dice != nil {
+		if s.DamageType != "" {
+			return s.Dice.String() + " " + s.DamageType
+		}
+		return s.Dice.String()
+	}
+	return strings.TrimSpace(rollMacroVersusPrefix) + " " + s.Target
+}
+
+// String implements fmt.Stringer.
+func (r *RollMacroResult) String() string {
+	switch {
+	case r.Skipped:
+		return fmt.Sprintf(i18n.Text("%s: not rolled"), r.Step)
+	case r.Err != nil:
+		return fmt.Sprintf(i18n.Text("%s: %s"), r.Step, r.Err.Error())
+	case r.Step.Dice != nil:
+		if r.Step.DamageType != "" {
+			return fmt.Sprintf(i18n.Text("%s: %d %s"), r.Step, r.Rolled, r.Step.DamageType)
+		}
+		return fmt.Sprintf(i18n.Text("%s: %d"), r.Step, r.Rolled)
+	}
+	var outcome string
+	switch {
+	case r.Success && r.Critical:
+		outcome = i18n.Text("critical success")
+	case r.Success:
+		outcome = i18n.Text("success")
+	case r.Critical:
+		outcome = i18n.Text("critical failure")
+	default:
+		outcome = i18n.Text("failure")
+	}
+	margin := r.Target - r.Rolled
+	if margin < 0 {
+		margin = -margin
+	}
+	return fmt.Sprintf(i18n.Text("%s (%d): rolled %d, %s by %d"), r.Step, r.Target, r.Rolled, outcome, margin)
+}
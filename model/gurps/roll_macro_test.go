@@ -0,0 +1,91 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+type fixedRoll int
+
+func (f fixedRoll) Intn(n int) int {
+	return min(int(f), n-1)
+}
+
+type skillResolver int
+
+func (s skillResolver) ResolveRollTarget(expr string) (int, error) {
+	return gurps.ResolveRollTarget(nil, fxp.From(int(s)), expr)
+}
+
+func TestExtractRollMacros(t *testing.T) {
+	list := gurps.ExtractRollMacros("Follow-up [roll vs HT-2; 1d tox] and [2d+2 cut], but not [Ranged].")
+	check.Equal(t, 2, len(list))
+	check.Equal(t, 2, len(list[0].Steps))
+	check.Equal(t, "HT-2", list[0].Steps[0].Target)
+	check.Equal(t, 1, list[0].Steps[1].Dice.Count)
+	check.Equal(t, "tox", list[0].Steps[1].DamageType)
+	check.Equal(t, 2, list[1].Steps[0].Dice.Count)
+	check.Equal(t, 2, list[1].Steps[0].Dice.Modifier)
+	check.Equal(t, "cut", list[1].Steps[0].DamageType)
+}
+
+func TestSuccessRollOutcome(t *testing.T) {
+	success, critical := gurps.SuccessRollOutcome(4, 3)
+	check.True(t, success)
+	check.True(t, critical)
+	success, critical = gurps.SuccessRollOutcome(6, 16)
+	check.True(t, success)
+	check.True(t, critical)
+	success, critical = gurps.SuccessRollOutcome(6, 15)
+	check.True(t, success)
+	check.False(t, critical)
+	success, critical = gurps.SuccessRollOutcome(17, 16)
+	check.False(t, success)
+	check.False(t, critical)
+	success, critical = gurps.SuccessRollOutcome(17, 15)
+	check.False(t, success)
+	check.True(t, critical)
+	success, critical = gurps.SuccessRollOutcome(15, 5)
+	check.False(t, success)
+	check.True(t, critical)
+}
+
+func TestRollMacroFollowUps(t *testing.T) {
+	m, ok := gurps.ParseRollMacro("roll vs skill-4; 1d-3 cut")
+	check.True(t, ok)
+	results := m.Roll(skillResolver(14), fixedRoll(0))
+	check.Equal(t, 10, results[0].Target)
+	check.Equal(t, 3, results[0].Rolled)
+	check.True(t, results[0].Success)
+	check.False(t, results[1].Skipped)
+	check.Equal(t, 1, results[1].Rolled)
+
+	results = m.Roll(skillResolver(14), fixedRoll(5))
+	check.Equal(t, 18, results[0].Rolled)
+	check.False(t, results[0].Success)
+	check.True(t, results[1].Skipped)
+
+	m, ok = gurps.ParseRollMacro("roll vs Bogus; 1d")
+	check.True(t, ok)
+	results = m.Roll(skillResolver(14), fixedRoll(0))
+	check.NotNil(t, results[0].Err)
+	check.True(t, results[1].Skipped)
+}
+
+func TestMinimumDamage(t *testing.T) {
+	check.Equal(t, 0, gurps.MinimumDamage("cr"))
+	check.Equal(t, 0, gurps.MinimumDamage(""))
+	check.Equal(t, 1, gurps.MinimumDamage("imp"))
+}
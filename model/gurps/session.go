@@ -0,0 +1,48 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+)
+
+// Session holds the documents that were open when the workspace was last closed.
+type Session struct {
+	Documents []*SessionDocument   `json:"documents,omitempty"`
+	Layout    *WorkspaceLayoutNode `json:"layout,omitempty"`
+}
+
+// SessionDocument holds the state of a single document within a session.
+type SessionDocument struct {
+	Path     string       `json:"path"`
+	Frame    *unison.Rect `json:"frame,omitempty"`
+	Page     int          `json:"page,omitempty"`
+	InWindow bool         `json:"in_window,omitempty"`
+	Current  bool         `json:"current,omitempty"`
+}
+
+// Restorable returns the documents that can be reopened, i.e. those whose files still exist. Each path is only returned
+// once.
+func (s *Session) Restorable() []*SessionDocument {
+	if s == nil {
+		return nil
+	}
+	seen := make(map[string]bool, len(s.Documents))
+	list := make([]*SessionDocument, 0, len(s.Documents))
+	for _, doc := range s.Documents {
+		if doc == nil || doc.Path == "" || seen[doc.Path] || !fs.FileExists(doc.Path) {
+			continue
+		}
+		seen[doc.Path] = true
+		list = append(list, doc)
+	}
+	return list
+}
@@ -0,0 +1,40 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestSessionRestorable(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "Bob.gcs")
+	check.NoError(t, os.WriteFile(existing, []byte("{}"), 0o600))
+	session := &gurps.Session{
+		Documents: []*gurps.SessionDocument{
+			{Path: filepath.Join(dir, "Missing.gcs")},
+			nil,
+			{Path: existing, Current: true},
+			{Path: existing},
+		},
+	}
+	list := session.Restorable()
+	check.Equal(t, 1, len(list))
+	check.Equal(t, existing, list[0].Path)
+	check.True(t, list[0].Current)
+
+	var empty *gurps.Session
+	check.Equal(t, 0, len(empty.Restorable()))
+}
@@ -77,10 +77,12 @@ type Settings struct {
 	LibrarySet         Libraries                  `json:"libraries,omitempty"`
 	LibraryExplorer    NavigatorSettings          `json:"library_explorer"`
 	ThemeMode          thememode.Enum             `json:"theme_mode,alt=color_mode"`
+	ThemeSchedule      *ThemeSchedule             `json:"theme_schedule,omitempty"`
 	RecentFiles        []string                   `json:"recent_files,omitempty"`
 	DeepSearch         []string                   `json:"deep_search,omitempty"`
 	LastDirs           map[string]string          `json:"last_dirs,omitempty"`
 	ColumnSizing       map[string]map[int]float32 `json:"column_sizing,omitempty"`
+	ColumnLayouts      map[string]*ColumnLayout   `json:"column_layouts,omitempty"`
 	PageRefs           PageRefs                   `json:"page_refs,omitempty"`
 	KeyBindings        KeyBindings                `json:"key_bindings,omitempty"`
 	WorkspaceFrame     *unison.Rect               `json:"workspace_frame,omitempty"`
@@ -91,6 +93,12 @@ type Settings struct {
 	WebServer          *websettings.Settings      `json:"web,omitempty"` // Do not use "web_server" as the key, as an earlier release used that name and it will cause a failure to load the settings file.
 	OpenNodes          map[tid.TID]int64          `json:"open_nodes,omitempty"`
 	PDFs               map[string]*PDFInfo        `json:"pdfs,omitempty"`
+	Session            *Session                   `json:"session,omitempty"`
+	WorkspaceLayouts   []*WorkspaceLayout         `json:"workspace_layouts,omitempty"`
+	Favorites          []*Favorite                `json:"favorites,omitempty"`
+	RecentlyAdded      []*Favorite                `json:"recently_added,omitempty"`
+	LibrarySorts       map[string]*LibrarySort    `json:"library_sorts,omitempty"`
+	PersonalDictionary []string                   `json:"personal_dictionary,omitempty"`
 }
 
 // IDer defines the methods required of objects that have an ID.
@@ -123,7 +131,7 @@ func GlobalSettings() *Settings {
 		}
 		globalSettings.EnsureValidity()
 		InstallEvaluatorFunctions(fxp.EvalFuncs)
-		unison.SetThemeMode(globalSettings.ThemeMode)
+		unison.SetThemeMode(globalSettings.EffectiveThemeMode(time.Now()))
 		globalSettings.Colors.MakeCurrent()
 		globalSettings.Fonts.MakeCurrent()
 		unison.DefaultScrollPanelTheme.MouseWheelMultiplier = func() float32 {
@@ -172,6 +180,14 @@ func (s *Settings) Save() error {
 	if len(s.PDFs) == 0 {
 		s.PDFs = nil
 	}
+	for k, v := range s.LibrarySorts {
+		if v.LastUsed < cutoff {
+			delete(s.LibrarySorts, k)
+		}
+	}
+	if len(s.LibrarySorts) == 0 {
+		s.LibrarySorts = nil
+	}
 	return jio.SaveToFile(context.Background(), SettingsPath, s)
 }
 
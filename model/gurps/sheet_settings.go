@@ -12,8 +12,11 @@ package gurps
 import (
 	"context"
 	"io/fs"
+	"slices"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/control"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/defense"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/display"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/progression"
 	"github.com/richardwilkes/gcs/v5/model/jio"
@@ -30,26 +33,42 @@ type SheetSettingsResponder interface {
 
 // SheetSettingsData holds the SheetSettings data that is written to disk.
 type SheetSettingsData struct {
-	Page                          *PageSettings      `json:"page,omitempty"`
-	BlockLayout                   *BlockLayout       `json:"block_layout,omitempty"`
-	Attributes                    *AttributeDefs     `json:"attributes,omitempty"`
-	BodyType                      *Body              `json:"body_type,alt=hit_locations,omitempty"`
-	DamageProgression             progression.Option `json:"damage_progression"`
-	DefaultLengthUnits            fxp.LengthUnit     `json:"default_length_units"`
-	DefaultWeightUnits            fxp.WeightUnit     `json:"default_weight_units"`
-	UserDescriptionDisplay        display.Option     `json:"user_description_display"`
-	ModifiersDisplay              display.Option     `json:"modifiers_display"`
-	NotesDisplay                  display.Option     `json:"notes_display"`
-	SkillLevelAdjDisplay          display.Option     `json:"skill_level_adj_display"`
-	UseMultiplicativeModifiers    bool               `json:"use_multiplicative_modifiers,omitempty"`
-	UseModifyingDicePlusAdds      bool               `json:"use_modifying_dice_plus_adds,omitempty"`
-	UseHalfStatDefaults           bool               `json:"use_half_stat_defaults,omitempty"`
-	ShowTraitModifierAdj          bool               `json:"show_trait_modifier_adj,alt=show_advantage_modifier_adj,omitempty"`
-	ShowEquipmentModifierAdj      bool               `json:"show_equipment_modifier_adj,omitempty"`
-	ShowSpellAdj                  bool               `json:"show_spell_adj,omitempty"`
-	HideSourceMismatch            bool               `json:"hide_source_mismatch,omitempty"`
-	UseTitleInFooter              bool               `json:"use_title_in_footer,omitempty"`
-	ExcludeUnspentPointsFromTotal bool               `json:"exclude_unspent_points_from_total"`
+	Page                          *PageSettings           `json:"page,omitempty"`
+	BlockLayout                   *BlockLayout            `json:"block_layout,omitempty"`
+	Attributes                    *AttributeDefs          `json:"attributes,omitempty"`
+	BodyType                      *Body                   `json:"body_type,alt=hit_locations,omitempty"`
+	DamageProgression             progression.Option      `json:"damage_progression"`
+	DefaultLengthUnits            fxp.LengthUnit          `json:"default_length_units"`
+	DefaultWeightUnits            fxp.WeightUnit          `json:"default_weight_units"`
+	UserDescriptionDisplay        display.Option          `json:"user_description_display"`
+	ModifiersDisplay              display.Option          `json:"modifiers_display"`
+	NotesDisplay                  display.Option          `json:"notes_display"`
+	SkillLevelAdjDisplay          display.Option          `json:"skill_level_adj_display"`
+	ControlRating                 control.Rating          `json:"control_rating,omitempty"`
+	DefenseBonusRule              defense.BonusRule       `json:"defense_bonus_rule,omitempty"`
+	CombatReflexesRule            defense.ReflexesRule    `json:"combat_reflexes_rule,omitempty"`
+	CrossParryRule                defense.CrossParryRule  `json:"cross_parry_rule,omitempty"`
+	UseMultiplicativeModifiers    bool                    `json:"use_multiplicative_modifiers,omitempty"`
+	UseModifyingDicePlusAdds      bool                    `json:"use_modifying_dice_plus_adds,omitempty"`
+	UseHalfStatDefaults           bool                    `json:"use_half_stat_defaults,omitempty"`
+	WaiveCinematicSkillTraining   bool                    `json:"waive_cinematic_skill_training,omitempty"`
+	TechnicalGrappling            bool                    `json:"technical_grappling,omitempty"`
+	ShowTraitModifierAdj          bool                    `json:"show_trait_modifier_adj,alt=show_advantage_modifier_adj,omitempty"`
+	ShowEquipmentModifierAdj      bool                    `json:"show_equipment_modifier_adj,omitempty"`
+	ShowSpellAdj                  bool                    `json:"show_spell_adj,omitempty"`
+	HideSourceMismatch            bool                    `json:"hide_source_mismatch,omitempty"`
+	UseTitleInFooter              bool                    `json:"use_title_in_footer,omitempty"`
+	ExcludeUnspentPointsFromTotal bool                    `json:"exclude_unspent_points_from_total"`
+	Currencies                    []*Currency             `json:"currencies,omitempty"`
+	DisadvantageLimit             fxp.Int                 `json:"disadvantage_limit,omitempty"`
+	QuirkLimit                    fxp.Int                 `json:"quirk_limit,omitempty"`
+	SkillPointLimit               fxp.Int                 `json:"skill_point_limit,omitempty"`
+	EnforceLimits                 bool                    `json:"enforce_limits,omitempty"`
+	PointBudgets                  []*PointBudget          `json:"point_budgets,omitempty"`
+	StartingWealthByTL            []fxp.Int               `json:"starting_wealth_by_tl,omitempty"`
+	LimitEquipmentToWealth        bool                    `json:"limit_equipment_to_wealth,omitempty"`
+	DerivedFields                 []*DerivedField         `json:"derived_fields,omitempty"`
+	ColumnSorts                   map[string][]ColumnSort `json:"column_sorts,omitempty"`
 }
 
 // SheetSettings holds sheet settings.
@@ -131,6 +150,10 @@ func (s *SheetSettings) EnsureValidity() {
 	s.ModifiersDisplay = s.ModifiersDisplay.EnsureValid()
 	s.NotesDisplay = s.NotesDisplay.EnsureValid()
 	s.SkillLevelAdjDisplay = s.SkillLevelAdjDisplay.EnsureValid()
+	s.ControlRating = s.ControlRating.EnsureValid()
+	s.DefenseBonusRule = s.DefenseBonusRule.EnsureValid()
+	s.CombatReflexesRule = s.CombatReflexesRule.EnsureValid()
+	s.CrossParryRule = s.CrossParryRule.EnsureValid()
 }
 
 // MarshalJSON implements json.Marshaler.
@@ -155,6 +178,11 @@ func (s *SheetSettings) Clone(entity *Entity) *SheetSettings {
 	clone.BlockLayout = s.BlockLayout.Clone()
 	clone.Attributes = s.Attributes.Clone()
 	clone.BodyType = s.BodyType.Clone(entity, nil)
+	clone.Currencies = CloneCurrencies(s.Currencies)
+	clone.PointBudgets = ClonePointBudgets(s.PointBudgets)
+	clone.StartingWealthByTL = slices.Clone(s.StartingWealthByTL)
+	clone.DerivedFields = CloneDerivedFields(s.DerivedFields)
+	clone.ColumnSorts = CloneColumnSorts(s.ColumnSorts)
 	return &clone
 }
 
@@ -0,0 +1,70 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+// MaxShockPenalty is the largest shock penalty that can be in effect at once, regardless of how many times the
+// character was injured during the turn.
+const MaxShockPenalty = 4
+
+// ShockPenaltyFor returns the shock penalty (B419) caused by the given injury to a character with the given maximum hit
+// points. The result is zero or negative.
+func ShockPenaltyFor(injury, hitPoints fxp.Int) int {
+	if injury <= 0 {
+		return 0
+	}
+	per := fxp.One
+	if hitPoints >= fxp.Twenty {
+		// Characters with 20 or more HP suffer -1 per full HP/10 of injury, rather than per HP
+		per = hitPoints.Div(fxp.Ten).Trunc()
+	}
+	return -min(fxp.As[int](injury.Div(per).Trunc()), MaxShockPenalty)
+}
+
+// ApplyShock adds the shock penalty caused by the injury to the shock penalty currently in effect and returns the
+// amount it changed by. Nothing happens if the entity has High Pain Threshold.
+func (e *Entity) ApplyShock(injury fxp.Int) int {
+	if e.HighPainThreshold {
+		return 0
+	}
+	var hitPoints fxp.Int
+	if attr, ok := e.Attributes.Set[HitPointsID]; ok {
+		hitPoints = attr.Maximum()
+	}
+	before := e.ShockPenalty
+	e.ShockPenalty = max(e.ShockPenalty+ShockPenaltyFor(injury, hitPoints), -MaxShockPenalty)
+	return e.ShockPenalty - before
+}
+
+// ClearShock removes any shock penalty currently in effect, which should be done once the turn following the injury
+// has passed.
+func (e *Entity) ClearShock() {
+	e.ShockPenalty = 0
+}
+
+// shockPenaltyForAttribute returns the shock penalty to apply to skills and spells based on the attribute. Shock only
+// affects those based on DX or IQ.
+func (e *Entity) shockPenaltyForAttribute(attrID string, tooltip *xio.ByteBuffer) fxp.Int {
+	if e == nil || e.ShockPenalty == 0 || (attrID != DexterityID && attrID != IntelligenceID) {
+		return 0
+	}
+	penalty := fxp.From(e.ShockPenalty)
+	if tooltip != nil {
+		fmt.Fprintf(tooltip, i18n.Text("\nShock [%s]"), penalty.StringWithSign())
+	}
+	return penalty
+}
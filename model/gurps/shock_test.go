@@ -0,0 +1,38 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestShockPenaltyFor(t *testing.T) {
+	check.Equal(t, 0, ShockPenaltyFor(0, fxp.Ten), "no injury")
+	check.Equal(t, -3, ShockPenaltyFor(fxp.Three, fxp.Ten), "-1 per HP of injury")
+	check.Equal(t, -4, ShockPenaltyFor(fxp.Ten, fxp.Ten), "capped at -4")
+	check.Equal(t, -1, ShockPenaltyFor(fxp.From(5), fxp.From(30)), "-1 per 3 HP of injury with 30 HP")
+	check.Equal(t, 0, ShockPenaltyFor(fxp.Two, fxp.From(30)), "less than HP/10 of injury")
+}
+
+func TestApplyShock(t *testing.T) {
+	e := NewEntity()
+	check.Equal(t, -2, e.ApplyShock(fxp.Two), "first injury")
+	check.Equal(t, -2, e.ApplyShock(fxp.Three), "second injury is limited by the cap")
+	check.Equal(t, -MaxShockPenalty, e.ShockPenalty, "total shock")
+	penalty := e.shockPenaltyForAttribute(DexterityID, nil)
+	check.Equal(t, fxp.From(-MaxShockPenalty), penalty, "applies to DX")
+	check.Equal(t, fxp.Int(0), e.shockPenaltyForAttribute(HitPointsID, nil), "doesn't apply to HT")
+	e.ClearShock()
+	e.HighPainThreshold = true
+	check.Equal(t, 0, e.ApplyShock(fxp.Three), "high pain threshold")
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+// LibraryEquipment holds a piece of equipment found in a library, along with the file it came from.
+type LibraryEquipment struct {
+	From      LibraryFile
+	Equipment *Equipment
+}
+
+// LoadLibraryEquipment loads all of the equipment from the equipment files in the libraries, sorted by name. Containers
+// are not returned, but their contents are. Files that can't be loaded are logged and skipped.
+func LoadLibraryEquipment(libraries Libraries) []*LibraryEquipment {
+	var list []*LibraryEquipment
+	for _, lib := range libraries.List() {
+		fileSystem := os.DirFS(lib.Path())
+		_ = fs.WalkDir(fileSystem, ".", func(p string, d fs.DirEntry, err error) error { //nolint:errcheck // Intentionally ignored the error result
+			if err != nil {
+				return nil
+			}
+			if strings.HasPrefix(d.Name(), ".") && p != "." {
+				if d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() || !strings.EqualFold(path.Ext(p), EquipmentExt) {
+				return nil
+			}
+			var equipment []*Equipment
+			if equipment, err = NewEquipmentFromFile(fileSystem, p); err != nil {
+				errs.Log(err, "library", lib.Title, "path", p)
+				return nil
+			}
+			from := LibraryFile{Library: lib.Key(), Path: p}
+			Traverse(func(e *Equipment) bool {
+				list = append(list, &LibraryEquipment{From: from, Equipment: e})
+				return false
+			}, false, true, equipment...)
+			return nil
+		})
+	}
+	slices.SortStableFunc(list, func(a, b *LibraryEquipment) int {
+		return txt.NaturalCmp(a.Equipment.String(), b.Equipment.String(), true)
+	})
+	return list
+}
+
+// EquipmentAvailable returns true if the equipment can be bought by the entity, which requires that its tech level not
+// exceed the entity's and that its Legality Class be permitted by the Control Rating of the entity's sheet settings.
+func (e *Entity) EquipmentAvailable(eqp *Equipment) bool {
+	if lc, ok := eqp.LegalityClassLevel(); ok && !SheetSettingsFor(e).ControlRating.Permits(lc) {
+		return false
+	}
+	if strings.TrimSpace(eqp.TechLevel) == "" || strings.TrimSpace(e.Profile.TechLevel) == "" {
+		return true
+	}
+	eqpTL, start, _ := ExtractTechLevel(eqp.TechLevel)
+	if start == -1 {
+		return true
+	}
+	entityTL, start, _ := ExtractTechLevel(e.Profile.TechLevel)
+	return start == -1 || eqpTL <= entityTL
+}
+
+// ShoppingCart accumulates equipment to be purchased by an entity.
+type ShoppingCart struct {
+	Entity *Entity
+	Items  []*Equipment
+}
+
+// NewShoppingCart creates a new, empty ShoppingCart for the entity.
+func NewShoppingCart(entity *Entity) *ShoppingCart {
+	return &ShoppingCart{Entity: entity}
+}
+
+// Add a copy of the equipment to the cart. If the same equipment is already in the cart, its quantity is increased
+// instead.
+func (c *ShoppingCart) Add(item *LibraryEquipment) {
+	quantity := item.Equipment.Quantity.Max(fxp.One)
+	for _, one := range c.Items {
+		if one.Source.LibraryFile == item.From && one.Source.TID == item.Equipment.TID {
+			one.Quantity += quantity
+			return
+		}
+	}
+	eqp := item.Equipment.Clone(item.From, c.Entity, nil, false)
+	eqp.Quantity = quantity
+	c.Items = append(c.Items, eqp)
+}
+
+// Remove the item at the given index from the cart.
+func (c *ShoppingCart) Remove(index int) {
+	if index >= 0 && index < len(c.Items) {
+		c.Items = slices.Delete(c.Items, index, index+1)
+	}
+}
+
+// Total returns the total cost of the items in the cart.
+func (c *ShoppingCart) Total() fxp.Int {
+	var total fxp.Int
+	for _, one := range c.Items {
+		total += one.ExtendedValue()
+	}
+	return total
+}
+
+// Remaining returns the funds the entity will have left after paying for the items in the cart.
+func (c *ShoppingCart) Remaining() fxp.Int {
+	return c.Entity.AvailableFunds() - c.Total()
+}
+
+// Purchase adds the items in the cart to the entity's carried equipment and pays for them from its funds and coins,
+// then empties the cart. Nothing is purchased if the entity can't afford everything in the cart.
+func (c *ShoppingCart) Purchase() error {
+	if len(c.Items) == 0 {
+		return nil
+	}
+	total := c.Total()
+	if total > c.Entity.AvailableFunds() {
+		return errs.New(i18n.Text("insufficient funds"))
+	}
+	c.Entity.SetCarriedEquipmentList(append(slices.Clone(c.Entity.CarriedEquipment), c.Items...))
+	c.Entity.Spend(total)
+	c.Items = nil
+	return nil
+}
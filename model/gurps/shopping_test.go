@@ -0,0 +1,71 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/control"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestEquipmentAvailable(t *testing.T) {
+	e := gurps.NewEntity()
+	e.Profile.TechLevel = "3"
+	eqp := gurps.NewEquipment(nil, nil, false)
+	eqp.TechLevel = "3"
+	eqp.LegalityClass = "2"
+	check.True(t, e.EquipmentAvailable(eqp), "same TL, CR0")
+	eqp.TechLevel = "4"
+	check.False(t, e.EquipmentAvailable(eqp), "TL above the character's")
+	eqp.TechLevel = "^"
+	check.True(t, e.EquipmentAvailable(eqp), "no numeric TL")
+	e.SheetSettings.ControlRating = control.CR3
+	check.False(t, e.EquipmentAvailable(eqp), "LC2 is not permitted at CR3")
+	eqp.LegalityClass = ""
+	check.True(t, e.EquipmentAvailable(eqp), "no LC")
+}
+
+func TestShoppingCart(t *testing.T) {
+	e := gurps.NewEntity()
+	e.Funds = fxp.Hundred
+	eqp := gurps.NewEquipment(nil, nil, false)
+	eqp.Name = "Rope"
+	eqp.Value = fxp.Ten
+	item := &gurps.LibraryEquipment{
+		From:      gurps.LibraryFile{Library: "test", Path: "basic.eqp"},
+		Equipment: eqp,
+	}
+	cart := gurps.NewShoppingCart(e)
+	cart.Add(item)
+	cart.Add(item)
+	check.Equal(t, 1, len(cart.Items), "same item merges")
+	check.Equal(t, fxp.Two, cart.Items[0].Quantity)
+	check.Equal(t, fxp.From(20), cart.Total())
+	check.Equal(t, fxp.From(80), cart.Remaining())
+
+	check.NoError(t, cart.Purchase())
+	check.Equal(t, 0, len(cart.Items), "cart emptied")
+	check.Equal(t, fxp.From(80), e.Funds)
+	check.Equal(t, 1, len(e.CarriedEquipment))
+	check.Equal(t, fxp.Two, e.CarriedEquipment[0].Quantity)
+
+	for range 9 {
+		cart.Add(item)
+	}
+	check.Equal(t, fxp.From(-10), cart.Remaining())
+	check.Error(t, cart.Purchase(), "insufficient funds")
+	check.Equal(t, fxp.From(80), e.Funds, "funds unchanged")
+	check.Equal(t, 1, len(e.CarriedEquipment), "nothing added")
+	cart.Remove(0)
+	check.Equal(t, 0, len(cart.Items))
+}
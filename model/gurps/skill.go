@@ -46,6 +46,12 @@ var (
 	_ EditorData[*Skill]              = &SkillEditData{}
 )
 
+// Names of the traits that permit the use of cinematic skills.
+const (
+	TrainedByAMasterTraitName = "Trained by a Master"
+	WeaponMasterTraitName     = "Weapon Master"
+)
+
 // Columns that can be used with the skill method .CellData()
 const (
 	SkillDescriptionColumn = iota
@@ -64,6 +70,7 @@ type Skill struct {
 	owner             DataOwner
 	LevelData         Level
 	UnsatisfiedReason string
+	defaultChain      *SkillDefaultChain
 }
 
 // SkillData holds the Skill data that is written to disk.
@@ -87,30 +94,38 @@ type SkillEditData struct {
 // SkillNonContainerOnlyEditData holds the Skill data that is only applicable to skills that aren't containers.
 type SkillNonContainerOnlyEditData struct {
 	SkillNonContainerOnlySyncData
-	TechLevel        *string       `json:"tech_level,omitempty"`
-	Points           fxp.Int       `json:"points,omitempty"`
-	DefaultedFrom    *SkillDefault `json:"defaulted_from,omitempty"`
-	Study            []*Study      `json:"study,omitempty"`
-	StudyHoursNeeded study.Level   `json:"study_hours_needed,omitempty"`
+	TechLevel        *string           `json:"tech_level,omitempty"`
+	Points           fxp.Int           `json:"points,omitempty"`
+	DefaultedFrom    *SkillDefault     `json:"defaulted_from,omitempty"`
+	PinnedDefault    []SkillDefaultRef `json:"pinned_default,omitempty"`
+	Study            []*Study          `json:"study,omitempty"`
+	StudyHoursNeeded study.Level       `json:"study_hours_needed,omitempty"`
 }
 
 // SkillSyncData holds the skill sync data that is common to both containers and non-containers.
 type SkillSyncData struct {
-	Name             string   `json:"name,omitempty"`
-	PageRef          string   `json:"reference,omitempty"`
-	PageRefHighlight string   `json:"reference_highlight,omitempty"`
-	LocalNotes       string   `json:"notes,omitempty"`
-	Tags             []string `json:"tags,omitempty"`
+	Name             string       `json:"name,omitempty"`
+	PageRef          string       `json:"reference,omitempty"`
+	PageRefHighlight string       `json:"reference_highlight,omitempty"`
+	LocalNotes       string       `json:"notes,omitempty"`
+	Translations     Translations `json:"translations,omitempty"`
+	Tags             []string     `json:"tags,omitempty"`
 }
 
 // SkillNonContainerOnlySyncData holds the sskll sync data that is only applicable to traits that aren't containers.
 type SkillNonContainerOnlySyncData struct {
 	Specialization               string              `json:"specialization,omitempty"`
+	SpecializationRequired       bool                `json:"specialization_required,omitempty"`
+	SpecializationChoices        []string            `json:"specialization_choices,omitempty"`
+	Cinematic                    bool                `json:"cinematic,omitempty"`
 	Difficulty                   AttributeDifficulty `json:"difficulty,omitempty"`
 	EncumbrancePenaltyMultiplier fxp.Int             `json:"encumbrance_penalty_multiplier,omitempty"`
+	WildcardCovers               []string            `json:"wildcard_covers,omitempty"`
 	Defaults                     []*SkillDefault     `json:"defaults,omitempty"`
 	TechniqueDefault             *SkillDefault       `json:"default,omitempty"`
 	TechniqueLimitModifier       *fxp.Int            `json:"limit,omitempty"`
+	TechniqueAltDefaults         []*SkillDefault     `json:"alt_defaults,omitempty"`
+	Combination                  bool                `json:"combination,omitempty"`
 	Prereq                       *PrereqList         `json:"prereqs,omitempty"`
 	Weapons                      []*Weapon           `json:"weapons,omitempty"`
 	Features                     Features            `json:"features,omitempty"`
@@ -252,6 +267,26 @@ func (s *Skill) IsTechnique() bool {
 	return tid.IsKind(s.TID, kinds.Technique)
 }
 
+// IsWildcard returns true if this is a wildcard skill, e.g. "Guns!".
+func (s *Skill) IsWildcard() bool {
+	return !s.Container() && !s.IsTechnique() && s.Difficulty.Difficulty == difficulty.Wildcard
+}
+
+// CoversSkill returns true if this is a wildcard skill that may be used in place of any skill with the given name,
+// regardless of specialization.
+func (s *Skill) CoversSkill(name string) bool {
+	if !s.IsWildcard() {
+		return false
+	}
+	name = strings.TrimSpace(name)
+	for _, one := range s.WildcardCovers {
+		if strings.EqualFold(strings.TrimSpace(one), name) {
+			return true
+		}
+	}
+	return false
+}
+
 // Clone implements Node.
 func (s *Skill) Clone(from LibraryFile, owner DataOwner, parent *Skill, preserveID bool) *Skill {
 	var other *Skill
@@ -422,20 +457,46 @@ func (s *Skill) CellData(columnID int, data *CellData) {
 		if !s.Container() {
 			data.Type = cell.Text
 			level := s.CalculateLevel(nil)
+			adj := s.CulturalUnfamiliarityAdjustment()
+			if level.Level > 0 {
+				level.Level += adj
+			}
 			data.Primary = level.LevelAsString(s.Container())
 			if level.Tooltip != "" {
 				data.Tooltip = IncludesModifiersFrom() + ":" + level.Tooltip
 			}
+			if adj != 0 {
+				if data.Tooltip != "" {
+					data.Tooltip += "\n\n"
+				}
+				data.Tooltip += UnfamiliarityTooltip()
+			}
+			if def := s.BestAvailableDefaultText(); def != "" {
+				if data.Tooltip != "" {
+					data.Tooltip += "\n\n"
+				}
+				data.Tooltip += i18n.Text("Best default: ") + def
+			}
 			data.Alignment = align.End
 		}
 	case SkillRelativeLevelColumn:
 		if !s.Container() {
 			data.Type = cell.Text
-			data.Primary = FormatRelativeSkill(EntityFromNode(s), s.IsTechnique(), s.Difficulty,
-				s.AdjustedRelativeLevel())
+			rsl := s.AdjustedRelativeLevel()
+			adj := s.CulturalUnfamiliarityAdjustment()
+			if rsl != fxp.Min {
+				rsl += adj
+			}
+			data.Primary = FormatRelativeSkill(EntityFromNode(s), s.IsTechnique(), s.Difficulty, rsl)
 			if tooltip := s.CalculateLevel(nil).Tooltip; tooltip != "" {
 				data.Tooltip = IncludesModifiersFrom() + ":" + tooltip
 			}
+			if adj != 0 {
+				if data.Tooltip != "" {
+					data.Tooltip += "\n\n"
+				}
+				data.Tooltip += UnfamiliarityTooltip()
+			}
 		}
 	case SkillPointsColumn:
 		data.Type = cell.Text
@@ -564,7 +625,7 @@ func (s *Skill) RatedStrength() fxp.Int {
 
 // Description implements WeaponOwner.
 func (s *Skill) Description() string {
-	return s.String()
+	return s.describe(s.LocalizedNameWithReplacements())
 }
 
 // SecondaryText returns the less important information that should be displayed with the description.
@@ -580,6 +641,9 @@ func (s *Skill) SecondaryText(optionChecker func(display.Option) bool) string {
 	if optionChecker(prefs.NotesDisplay) {
 		AppendStringOntoNewLine(&buffer, strings.TrimSpace(s.Notes()))
 		AppendStringOntoNewLine(&buffer, StudyHoursProgressText(ResolveStudyHours(s.Study), s.StudyHoursNeeded, false))
+		if st := s.TrainedST(); st > 0 {
+			AppendStringOntoNewLine(&buffer, fmt.Sprintf(i18n.Text("Trained ST: %s"), st.String()))
+		}
 	}
 	addTooltipForSkillLevelAdj(optionChecker, prefs, s.LevelData, &buffer)
 	return buffer.String()
@@ -602,8 +666,12 @@ func addTooltipForSkillLevelAdj(optionChecker func(display.Option) bool, prefs *
 }
 
 func (s *Skill) String() string {
+	return s.describe(s.NameWithReplacements())
+}
+
+func (s *Skill) describe(name string) string {
 	var buffer strings.Builder
-	buffer.WriteString(s.NameWithReplacements())
+	buffer.WriteString(name)
 	if !s.Container() {
 		if s.TechLevel != nil {
 			buffer.WriteString("/TL")
@@ -648,6 +716,18 @@ func (s *Skill) AdjustedRelativeLevel() fxp.Int {
 	return fxp.Min
 }
 
+// CulturalUnfamiliarityAdjustment returns the adjustment to apply to the displayed level of this skill when the
+// character is dealing with an unfamiliar culture. Only skills tagged as social are affected.
+func (s *Skill) CulturalUnfamiliarityAdjustment() fxp.Int {
+	if s.Container() || !HasTag(SocialSkillTag, s.Tags) {
+		return 0
+	}
+	if e := EntityFromNode(s); e != nil && e.Cultures.Unfamiliar {
+		return CulturalUnfamiliarityPenalty
+	}
+	return 0
+}
+
 // RawPoints returns the unadjusted points.
 func (s *Skill) RawPoints() fxp.Int {
 	return s.Points
@@ -736,9 +816,9 @@ func (s *Skill) DecrementSkillLevel() {
 func (s *Skill) CalculateLevel(excludes map[string]bool) Level {
 	points := s.AdjustedPoints(nil)
 	if s.IsTechnique() {
-		return CalculateTechniqueLevel(EntityFromNode(s), s.Replacements, s.NameWithReplacements(),
-			s.SpecializationWithReplacements(), s.Tags, s.TechniqueDefault, s.Difficulty.Difficulty, points, true,
-			s.TechniqueLimitModifier, excludes)
+		return CalculateTechniqueLevelFromDefaults(EntityFromNode(s), s.Replacements, s.NameWithReplacements(),
+			s.SpecializationWithReplacements(), s.Tags, s.TechniqueDefaults(), s.Combination, s.Difficulty.Difficulty,
+			points, true, s.TechniqueLimitModifier, excludes)
 	}
 	return CalculateSkillLevel(EntityFromNode(s), s.NameWithReplacements(), s.SpecializationWithReplacements(), s.Tags,
 		s.DefaultedFrom, s.Difficulty, points, s.EncumbrancePenaltyMultiplier)
@@ -786,6 +866,8 @@ func CalculateSkillLevel(e *Entity, name, specialization string, tags []string,
 				if bonus != 0 {
 					fmt.Fprintf(&tooltip, i18n.Text("\nEncumbrance [%s]"), bonus.StringWithSign())
 				}
+				level += e.shockPenaltyForAttribute(attrDiff.Attribute, &tooltip)
+				level += e.grapplingPenaltyForAttribute(attrDiff.Attribute, &tooltip)
 			}
 		}
 	}
@@ -835,6 +917,10 @@ func CalculateTechniqueLevel(e *Entity, replacements map[string]string, name, sp
 		} else {
 			// Take the modifier back out, as we wanted the base, not the final value.
 			level = def.SkillLevelFast(e, replacements, true, nil, false) - def.Modifier
+			if level != fxp.Min {
+				level += e.shockPenaltyForAttribute(def.DefaultType, &tooltip)
+				level += e.grapplingPenaltyForAttribute(def.DefaultType, &tooltip)
+			}
 		}
 		if level != fxp.Min {
 			baseLevel := level
@@ -867,16 +953,21 @@ func CalculateTechniqueLevel(e *Entity, replacements map[string]string, name, sp
 // UpdateLevel updates the level of the skill, returning true if it has changed.
 func (s *Skill) UpdateLevel() bool {
 	saved := s.LevelData
-	s.DefaultedFrom = s.bestDefaultWithPoints(nil)
+	s.defaultChain = s.bestDefaultChain(nil)
+	s.DefaultedFrom = s.defaultWithPoints(s.defaultChain)
 	s.LevelData = s.CalculateLevel(nil)
 	return saved != s.LevelData
 }
 
 func (s *Skill) bestDefaultWithPoints(excluded *SkillDefault) *SkillDefault {
+	return s.defaultWithPoints(s.bestDefaultChain(excluded))
+}
+
+func (s *Skill) defaultWithPoints(chain *SkillDefaultChain) *SkillDefault {
 	if s.IsTechnique() {
 		return nil
 	}
-	best := s.bestDefault(excluded)
+	best := chainToDefault(chain)
 	if best != nil {
 		baseLine := (EntityFromNode(s).ResolveAttributeCurrent(s.Difficulty.Attribute) +
 			s.Difficulty.Difficulty.BaseRelativeLevel()).Trunc()
@@ -897,25 +988,16 @@ func (s *Skill) bestDefaultWithPoints(excluded *SkillDefault) *SkillDefault {
 }
 
 func (s *Skill) bestDefault(excluded *SkillDefault) *SkillDefault {
-	if EntityFromNode(s) == nil || len(s.Defaults) == 0 {
+	return chainToDefault(s.bestDefaultChain(excluded))
+}
+
+func chainToDefault(chain *SkillDefaultChain) *SkillDefault {
+	if chain == nil {
 		return nil
 	}
-	excludes := make(map[string]bool)
-	excludes[s.String()] = true
-	var bestDef *SkillDefault
-	best := fxp.Min
-	for _, def := range s.resolveToSpecificDefaults() {
-		// For skill-based defaults, prune out any that already use a default that we are involved with
-		if def.Equivalent(s.Replacements, excluded) || s.inDefaultChain(def, make(map[*Skill]bool)) {
-			continue
-		}
-		if level := s.calcSkillDefaultLevel(def, excludes); best < level {
-			best = level
-			bestDef = def.CloneWithoutLevelOrPoints()
-			bestDef.Level = level
-		}
-	}
-	return bestDef
+	def := chain.Links[0].CloneWithoutLevelOrPoints()
+	def.Level = chain.Level
+	return def
 }
 
 func (s *Skill) calcSkillDefaultLevel(def *SkillDefault, excludes map[string]bool) fxp.Int {
@@ -971,25 +1053,107 @@ func (s *Skill) resolveToSpecificDefaults() []*SkillDefault {
 	return result
 }
 
-// TechniqueSatisfied returns true if the Technique is satisfied.
+// TechniqueSatisfied returns true if the Technique is satisfied. A combination requires all of the skills it defaults
+// to, while other techniques require just one of them.
 func (s *Skill) TechniqueSatisfied(tooltip *xio.ByteBuffer, prefix string) bool {
-	if !s.IsTechnique() || !s.TechniqueDefault.SkillBased() {
+	if !s.IsTechnique() {
 		return true
 	}
 	e := EntityFromNode(s)
-	sk := e.BestSkillNamed(s.TechniqueDefault.NameWithReplacements(s.Replacements),
-		s.TechniqueDefault.SpecializationWithReplacements(s.Replacements), false, nil)
-	satisfied := sk != nil && (sk.IsTechnique() || sk.Points > 0)
-	if !satisfied && tooltip != nil {
-		tooltip.WriteString(prefix)
-		if sk == nil {
-			tooltip.WriteString(i18n.Text("Requires a skill named "))
-		} else {
-			tooltip.WriteString(i18n.Text("Requires at least 1 point in the skill named "))
+	var unsatisfied []*SkillDefault
+	var missing []bool
+	for _, def := range s.TechniqueDefaults() {
+		if !def.SkillBased() {
+			if !s.Combination {
+				return true
+			}
+			continue
 		}
-		tooltip.WriteString(s.TechniqueDefault.FullName(e, s.Replacements))
+		sk := e.BestSkillNamed(def.NameWithReplacements(s.Replacements),
+			def.SpecializationWithReplacements(s.Replacements), false, nil)
+		if sk != nil && (sk.IsTechnique() || sk.Points > 0) {
+			if !s.Combination {
+				return true
+			}
+			continue
+		}
+		unsatisfied = append(unsatisfied, def)
+		missing = append(missing, sk == nil)
+	}
+	if len(unsatisfied) == 0 {
+		return true
 	}
-	return satisfied
+	if tooltip != nil {
+		for i, def := range unsatisfied {
+			tooltip.WriteString(prefix)
+			if missing[i] {
+				tooltip.WriteString(i18n.Text("Requires a skill named "))
+			} else {
+				tooltip.WriteString(i18n.Text("Requires at least 1 point in the skill named "))
+			}
+			tooltip.WriteString(def.FullName(e, s.Replacements))
+			if !s.Combination {
+				break
+			}
+		}
+	}
+	return false
+}
+
+// SpecializationSatisfied returns true if the specialization requirements of this skill have been met. If not, a
+// description of the problem will be written to the tooltip, if one is provided.
+func (s *Skill) SpecializationSatisfied(tooltip *xio.ByteBuffer, prefix string) bool {
+	if s.Container() || s.IsTechnique() {
+		return true
+	}
+	specialization := strings.TrimSpace(s.SpecializationWithReplacements())
+	if specialization == "" {
+		if !s.SpecializationRequired {
+			return true
+		}
+		if tooltip != nil {
+			tooltip.WriteString(prefix)
+			tooltip.WriteString(i18n.Text("A specialization must be chosen"))
+			if len(s.SpecializationChoices) != 0 {
+				tooltip.WriteString(i18n.Text(" from: "))
+				tooltip.WriteString(strings.Join(s.SpecializationChoices, ", "))
+			}
+		}
+		return false
+	}
+	if len(s.SpecializationChoices) == 0 {
+		return true
+	}
+	for _, one := range s.SpecializationChoices {
+		if strings.EqualFold(strings.TrimSpace(one), specialization) {
+			return true
+		}
+	}
+	if tooltip != nil {
+		tooltip.WriteString(prefix)
+		fmt.Fprintf(tooltip, i18n.Text("The specialization must be one of: %s"), strings.Join(s.SpecializationChoices,
+			", "))
+	}
+	return false
+}
+
+// CinematicTrainingSatisfied returns true if this skill is not cinematic, the sheet settings waive the training
+// requirement for cinematic skills, or the character has a trait that permits their use. If not, a description of the
+// problem will be written to the tooltip, if one is provided.
+func (s *Skill) CinematicTrainingSatisfied(tooltip *xio.ByteBuffer, prefix string) bool {
+	if s.Container() || !s.Cinematic {
+		return true
+	}
+	e := EntityFromNode(s)
+	if e == nil || e.SheetSettings.WaiveCinematicSkillTraining || e.HasCinematicSkillTraining() {
+		return true
+	}
+	if tooltip != nil {
+		tooltip.WriteString(prefix)
+		fmt.Fprintf(tooltip, i18n.Text("Cinematic skills require %s or %s"), TrainedByAMasterTraitName,
+			WeaponMasterTraitName)
+	}
+	return false
 }
 
 // TL implements TechLevelProvider.
@@ -1027,9 +1191,16 @@ func (s *Skill) SpecializationWithReplacements() string {
 	return nameable.Apply(s.Specialization, s.Replacements)
 }
 
-// LocalNotesWithReplacements returns the local notes with any replacements applied.
+// LocalizedNameWithReplacements returns the name in the user's language, if a translation is available, with any
+// replacements applied. Use NameWithReplacements() when matching against the name.
+func (s *Skill) LocalizedNameWithReplacements() string {
+	return nameable.Apply(s.Translations.Name(s.Name), s.Replacements)
+}
+
+// LocalNotesWithReplacements returns the local notes, in the user's language if a translation is available, with any
+// replacements applied.
 func (s *Skill) LocalNotesWithReplacements() string {
-	return nameable.Apply(s.LocalNotes, s.Replacements)
+	return nameable.Apply(s.Translations.Notes(s.LocalNotes), s.Replacements)
 }
 
 // Notes implements WeaponOwner.
@@ -1040,13 +1211,19 @@ func (s *Skill) Notes() string {
 // ModifierNotes returns the notes due to modifiers.
 func (s *Skill) ModifierNotes() string {
 	if s.IsTechnique() {
-		return i18n.Text("Default: ") + s.TechniqueDefault.FullName(EntityFromNode(s), s.Replacements) +
-			s.TechniqueDefault.ModifierAsString()
+		return i18n.Text("Default: ") + s.TechniqueDefaultsText()
 	}
 	if s.Difficulty.Difficulty != difficulty.Wildcard {
+		label := i18n.Text("Default: ")
+		if s.defaultChain != nil && s.defaultChain.Matches(s.PinnedDefault) {
+			label = i18n.Text("Pinned Default: ")
+		}
+		if s.defaultChain != nil && len(s.defaultChain.Links) > 1 {
+			return label + s.defaultChain.Text
+		}
 		defSkill := s.DefaultSkill()
 		if defSkill != nil && s.DefaultedFrom != nil {
-			return i18n.Text("Default: ") + defSkill.String() + s.DefaultedFrom.ModifierAsString()
+			return label + defSkill.String() + s.DefaultedFrom.ModifierAsString()
 		}
 	}
 	return ""
@@ -1078,6 +1255,9 @@ func (s *Skill) FillWithNameableKeys(m, existing map[string]string) {
 	if s.TechniqueDefault != nil {
 		s.TechniqueDefault.FillWithNameableKeys(m, existing)
 	}
+	for _, one := range s.TechniqueAltDefaults {
+		one.FillWithNameableKeys(m, existing)
+	}
 	for _, one := range s.Defaults {
 		one.FillWithNameableKeys(m, existing)
 	}
@@ -1137,6 +1317,75 @@ func (s *Skill) SwapDefaults() {
 	}
 }
 
+// BestAvailableDefault returns the best default currently available to this skill from the character's other skills
+// and attributes, or nil if there isn't one. If a default has been pinned and is still available, it is returned
+// instead. The returned default has its level and point credit filled in.
+func (s *Skill) BestAvailableDefault() *SkillDefault {
+	if s.IsTechnique() || s.Container() || s.Difficulty.Difficulty == difficulty.Wildcard || EntityFromNode(s) == nil {
+		return nil
+	}
+	def := s.bestDefaultWithPoints(nil)
+	if def == nil || def.Level == fxp.Min {
+		return nil
+	}
+	return def
+}
+
+// BestAvailableDefaultText returns a description of the best default currently available to this skill and the level
+// it provides, or an empty string if there isn't one.
+func (s *Skill) BestAvailableDefaultText() string {
+	if s.BestAvailableDefault() == nil {
+		return ""
+	}
+	chain := s.bestDefaultChain(nil)
+	return fmt.Sprintf(i18n.Text("%s at level %s"), chain.Text, chain.Level.Trunc().String())
+}
+
+// CanBuyUpFromDefault returns true if this skill's best default provides a point credit that may be applied towards
+// raising it and the points currently spent on it differ from the reduced cost of the first level above that default.
+func (s *Skill) CanBuyUpFromDefault() bool {
+	cost := s.BuyUpFromDefaultCost()
+	return cost > 0 && cost != s.Points
+}
+
+// BuyUpFromDefaultCost returns the number of points needed to raise this skill one level above the level provided by
+// its best default, taking the point credit from that default into account. Returns 0 if the skill cannot be bought up
+// from its default.
+func (s *Skill) BuyUpFromDefaultCost() fxp.Int {
+	def := s.BestAvailableDefault()
+	if def == nil || def.Points <= 0 {
+		return 0
+	}
+	oldLevel := s.levelForRawPoints(def, 0)
+	for points := fxp.One; points <= fxp.Four; points += fxp.One {
+		if s.levelForRawPoints(def, points) > oldLevel {
+			return points
+		}
+	}
+	return 0
+}
+
+// levelForRawPoints returns the level this skill would have if the given number of points were spent on it, without
+// modifying the skill.
+func (s *Skill) levelForRawPoints(def *SkillDefault, points fxp.Int) fxp.Int {
+	e := EntityFromNode(s)
+	name := s.NameWithReplacements()
+	specialization := s.SpecializationWithReplacements()
+	points = AdjustedPointsForNonContainerSkillOrTechnique(e, points, name, specialization, s.Tags, nil)
+	return CalculateSkillLevel(e, name, specialization, s.Tags, def, s.Difficulty, points,
+		s.EncumbrancePenaltyMultiplier).Level
+}
+
+// BuyUpFromDefault sets the points spent on this skill to the reduced cost needed to raise it one level above the level
+// provided by its best default. Returns true if the skill was modified.
+func (s *Skill) BuyUpFromDefault() bool {
+	if cost := s.BuyUpFromDefaultCost(); cost > 0 && cost != s.Points {
+		s.SetRawPoints(cost)
+		return true
+	}
+	return false
+}
+
 // Kind returns the kind of data.
 func (s *Skill) Kind() string {
 	if s.IsTechnique() {
@@ -1185,6 +1434,8 @@ func (s *Skill) SyncWithSource() {
 					s.TemplatePicker = other.TemplatePicker.Clone()
 				} else {
 					s.SkillNonContainerOnlySyncData = other.SkillNonContainerOnlySyncData
+					s.SpecializationChoices = slices.Clone(other.SpecializationChoices)
+					s.WildcardCovers = slices.Clone(other.WildcardCovers)
 					if len(other.Defaults) != 0 {
 						s.Defaults = make([]*SkillDefault, len(other.Defaults))
 						for i, def := range other.Defaults {
@@ -1204,6 +1455,8 @@ func (s *Skill) SyncWithSource() {
 						mod := *other.TechniqueLimitModifier
 						s.TechniqueLimitModifier = &mod
 					}
+					s.TechniqueAltDefaults = cloneSkillDefaults(other.TechniqueAltDefaults)
+					s.PinnedDefault = slices.Clone(other.PinnedDefault)
 					s.Prereq = other.Prereq.CloneResolvingEmpty(false, true)
 					s.Weapons = CloneWeapons(other.Weapons, false)
 					s.Features = other.Features.Clone()
@@ -1229,6 +1482,7 @@ func (s *SkillSyncData) hash(h hash.Hash) {
 	hashhelper.String(h, s.PageRef)
 	hashhelper.String(h, s.PageRefHighlight)
 	hashhelper.String(h, s.LocalNotes)
+	s.Translations.hash(h)
 	hashhelper.Num64(h, len(s.Tags))
 	for _, tag := range s.Tags {
 		hashhelper.String(h, tag)
@@ -1241,8 +1495,24 @@ func (s *SkillContainerOnlySyncData) hash(h hash.Hash) {
 
 func (s *SkillNonContainerOnlySyncData) hash(h hash.Hash) {
 	hashhelper.String(h, s.Specialization)
+	if s.SpecializationRequired || len(s.SpecializationChoices) != 0 {
+		hashhelper.Bool(h, s.SpecializationRequired)
+		hashhelper.Num64(h, len(s.SpecializationChoices))
+		for _, one := range s.SpecializationChoices {
+			hashhelper.String(h, one)
+		}
+	}
+	if s.Cinematic {
+		hashhelper.Bool(h, s.Cinematic)
+	}
 	s.Difficulty.Hash(h)
 	hashhelper.Num64(h, s.EncumbrancePenaltyMultiplier)
+	if len(s.WildcardCovers) != 0 {
+		hashhelper.Num64(h, len(s.WildcardCovers))
+		for _, one := range s.WildcardCovers {
+			hashhelper.String(h, one)
+		}
+	}
 	hashhelper.Num64(h, len(s.Defaults))
 	for _, one := range s.Defaults {
 		one.Hash(h)
@@ -1257,6 +1527,15 @@ func (s *SkillNonContainerOnlySyncData) hash(h hash.Hash) {
 	} else {
 		hashhelper.Num8(h, uint8(255))
 	}
+	if len(s.TechniqueAltDefaults) != 0 {
+		hashhelper.Num64(h, len(s.TechniqueAltDefaults))
+		for _, one := range s.TechniqueAltDefaults {
+			one.Hash(h)
+		}
+	}
+	if s.Combination {
+		hashhelper.Bool(h, s.Combination)
+	}
 	s.Prereq.Hash(h)
 	hashhelper.Num64(h, len(s.Weapons))
 	for _, weapon := range s.Weapons {
@@ -1281,7 +1560,10 @@ func (s *SkillEditData) ApplyTo(other *Skill) {
 func (s *SkillEditData) copyFrom(other *SkillEditData, isContainer, isApply bool) {
 	*s = *other
 	s.Tags = txt.CloneStringSlice(other.Tags)
+	s.SpecializationChoices = txt.CloneStringSlice(other.SpecializationChoices)
+	s.WildcardCovers = txt.CloneStringSlice(other.WildcardCovers)
 	s.Replacements = maps.Clone(other.Replacements)
+	s.Translations = maps.Clone(other.Translations)
 	if other.TechLevel != nil {
 		tl := *other.TechLevel
 		s.TechLevel = &tl
@@ -1310,6 +1592,7 @@ func (s *SkillEditData) copyFrom(other *SkillEditData, isContainer, isApply bool
 		mod := *other.TechniqueLimitModifier
 		s.TechniqueLimitModifier = &mod
 	}
+	s.TechniqueAltDefaults = cloneSkillDefaults(other.TechniqueAltDefaults)
 	s.Prereq = s.Prereq.CloneResolvingEmpty(isContainer, isApply)
 	s.Weapons = CloneWeapons(other.Weapons, isApply)
 	s.Features = other.Features.Clone()
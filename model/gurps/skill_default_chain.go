@@ -0,0 +1,143 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/difficulty"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// SkillDefaultChain holds a sequence of defaults leading from a skill to the skill or attribute its default level is
+// ultimately based upon. A chain with a single link is a normal default, while a chain with two links is a double
+// default through a skill that is itself only known at default.
+type SkillDefaultChain struct {
+	Links []*SkillDefault
+	Refs  []SkillDefaultRef
+	Level fxp.Int
+	Text  string
+}
+
+// SkillDefaultRef identifies one link of a chain of defaults by its type, name and specialization. The modifier isn't
+// included, so a pinned default still matches after the modifier of the default it refers to has been changed.
+type SkillDefaultRef struct {
+	Type           string `json:"type"`
+	Name           string `json:"name,omitempty"`
+	Specialization string `json:"specialization,omitempty"`
+}
+
+func newSkillDefaultChain(e *Entity, replacements map[string]string, level fxp.Int, links ...*SkillDefault) *SkillDefaultChain {
+	text := links[len(links)-1].FullName(e, replacements) + links[len(links)-1].ModifierAsString()
+	for i := len(links) - 2; i >= 0; i-- {
+		text = fmt.Sprintf(i18n.Text("%s (from %s)"), links[i].FullName(e, replacements)+links[i].ModifierAsString(),
+			text)
+	}
+	refs := make([]SkillDefaultRef, len(links))
+	for i, link := range links {
+		refs[i].Type = link.Type()
+		if link.SkillBased() {
+			refs[i].Name = link.NameWithReplacements(replacements)
+			refs[i].Specialization = link.SpecializationWithReplacements(replacements)
+		}
+	}
+	return &SkillDefaultChain{
+		Links: links,
+		Refs:  refs,
+		Level: level,
+		Text:  text,
+	}
+}
+
+// Matches returns true if the chain is the one identified by the refs.
+func (c *SkillDefaultChain) Matches(refs []SkillDefaultRef) bool {
+	return len(refs) != 0 && slices.Equal(c.Refs, refs)
+}
+
+// DefaultChains returns the chains of defaults currently available to this skill, best first.
+func (s *Skill) DefaultChains() []*SkillDefaultChain {
+	if s.IsTechnique() || s.Container() || s.Difficulty.Difficulty == difficulty.Wildcard {
+		return nil
+	}
+	return s.defaultChains(nil)
+}
+
+func (s *Skill) defaultChains(excluded *SkillDefault) []*SkillDefaultChain {
+	e := EntityFromNode(s)
+	if e == nil || s.IsTechnique() || len(s.Defaults) == 0 {
+		return nil
+	}
+	excludes := make(map[string]bool)
+	excludes[s.String()] = true
+	var chains []*SkillDefaultChain
+	for _, def := range s.resolveToSpecificDefaults() {
+		// For skill-based defaults, prune out any that already use a default that we are involved with
+		if def.Equivalent(s.Replacements, excluded) || s.inDefaultChain(def, make(map[*Skill]bool)) {
+			continue
+		}
+		if level := s.calcSkillDefaultLevel(def, excludes); level != fxp.Min {
+			chains = append(chains, newSkillDefaultChain(e, s.Replacements, level, def))
+		}
+	}
+	chains = append(chains, s.doubleDefaultChains(excluded)...)
+	slices.SortStableFunc(chains, func(a, b *SkillDefaultChain) int { return cmp.Compare(b.Level, a.Level) })
+	return chains
+}
+
+// doubleDefaultChains returns the chains that default through another skill the character only knows at default. Only
+// defaults from that skill to a third skill with points in it are considered, as a double default to an attribute is
+// never better than the attribute default itself.
+func (s *Skill) doubleDefaultChains(excluded *SkillDefault) []*SkillDefaultChain {
+	e := EntityFromNode(s)
+	var chains []*SkillDefaultChain
+	for _, def := range s.Defaults {
+		if def == nil || def.Type() != SkillID || def.Equivalent(s.Replacements, excluded) {
+			continue
+		}
+		for _, middle := range e.SkillNamed(def.NameWithReplacements(s.Replacements),
+			def.SpecializationWithReplacements(s.Replacements), false, map[string]bool{s.String(): true}) {
+			if middle == s || middle.IsTechnique() || middle.Points > 0 {
+				continue
+			}
+			excludes := map[string]bool{
+				s.String():      true,
+				middle.String(): true,
+			}
+			for _, def2 := range middle.resolveToSpecificDefaults() {
+				if def2.Type() != SkillID || s.inDefaultChain(def2, make(map[*Skill]bool)) {
+					continue
+				}
+				if level := middle.calcSkillDefaultLevel(def2, excludes); level != fxp.Min {
+					first := *def
+					first.Name = middle.NameWithReplacements()
+					first.Specialization = middle.SpecializationWithReplacements()
+					chains = append(chains, newSkillDefaultChain(e, s.Replacements, level+def.Modifier, &first, def2))
+				}
+			}
+		}
+	}
+	return chains
+}
+
+func (s *Skill) bestDefaultChain(excluded *SkillDefault) *SkillDefaultChain {
+	chains := s.defaultChains(excluded)
+	if len(chains) == 0 {
+		return nil
+	}
+	for _, chain := range chains {
+		if chain.Matches(s.PinnedDefault) {
+			return chain
+		}
+	}
+	return chains[0]
+}
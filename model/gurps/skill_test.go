@@ -0,0 +1,239 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/difficulty"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestSkillBuyUpFromDefault(t *testing.T) {
+	e := NewEntity()
+	base := NewSkill(e, nil, false)
+	base.Name = "Base"
+	base.Points = fxp.Eight
+	other := NewSkill(e, nil, false)
+	other.Name = "Other"
+	other.Points = fxp.One
+	other.Defaults = []*SkillDefault{{DefaultType: SkillID, Name: "Base", Modifier: -fxp.Two}}
+	e.SetSkillList([]*Skill{base, other})
+	e.Recalculate()
+	check.Equal(t, fxp.Twelve, base.LevelData.Level, "base skill level")
+
+	def := other.BestAvailableDefault()
+	check.NotNil(t, def, "best default")
+	check.Equal(t, fxp.Ten, def.Level, "best default level")
+	check.Equal(t, "Base-2 at level 10", other.BestAvailableDefaultText())
+	levelData := other.LevelData
+	check.Equal(t, fxp.Two, other.BuyUpFromDefaultCost(), "buy up cost")
+	check.Equal(t, fxp.One, other.Points, "points untouched when computing cost")
+	check.Equal(t, levelData, other.LevelData, "level untouched when computing cost")
+	check.True(t, other.CanBuyUpFromDefault())
+	check.True(t, other.BuyUpFromDefault())
+	check.Equal(t, fxp.Two, other.Points, "points after buying up")
+	check.Equal(t, fxp.Eleven, other.CalculateLevel(nil).Level, "level after buying up")
+	check.False(t, other.CanBuyUpFromDefault())
+
+	plain := NewSkill(e, nil, false)
+	e.SetSkillList([]*Skill{base, other, plain})
+	e.Recalculate()
+	check.Nil(t, plain.BestAvailableDefault(), "no defaults")
+	check.Equal(t, fxp.Int(0), plain.BuyUpFromDefaultCost(), "no defaults to buy up from")
+}
+
+func TestSkillDefaultChains(t *testing.T) {
+	e := NewEntity()
+	base := NewSkill(e, nil, false)
+	base.Name = "Base"
+	base.Points = fxp.Eight
+	middle := NewSkill(e, nil, false)
+	middle.Name = "Middle"
+	middle.Points = 0
+	middle.Defaults = []*SkillDefault{{DefaultType: SkillID, Name: "Base", Modifier: -fxp.Two}}
+	top := NewSkill(e, nil, false)
+	top.Name = "Top"
+	top.Points = 0
+	top.Defaults = []*SkillDefault{
+		{DefaultType: "dx", Modifier: -fxp.Five},
+		{DefaultType: SkillID, Name: "Middle", Modifier: -fxp.Three},
+	}
+	e.SetSkillList([]*Skill{base, middle, top})
+	e.Recalculate()
+
+	chains := top.DefaultChains()
+	check.Equal(t, 2, len(chains))
+	check.Equal(t, "Middle-3 (from Base-2)", chains[0].Text)
+	check.Equal(t, fxp.Seven, chains[0].Level, "double default level")
+	check.Equal(t, "DX-5", chains[1].Text)
+	check.Equal(t, fxp.Five, chains[1].Level, "attribute default level")
+	check.Equal(t, fxp.Seven, top.LevelData.Level, "uses the double default")
+	check.Equal(t, "Default: Middle-3 (from Base-2)", top.ModifierNotes())
+
+	top.PinnedDefault = chains[1].Refs
+	e.Recalculate()
+	check.Equal(t, fxp.Five, top.LevelData.Level, "uses the pinned default")
+	check.Equal(t, "DX-5 at level 5", top.BestAvailableDefaultText())
+
+	top.PinnedDefault = chains[0].Refs
+	e.Recalculate()
+	check.Equal(t, "Pinned Default: Middle-3 (from Base-2)", top.ModifierNotes())
+
+	top.PinnedDefault = chains[1].Refs
+	top.Defaults[0].Modifier = -fxp.Four
+	e.Recalculate()
+	check.Equal(t, fxp.Six, top.LevelData.Level, "the pinned default still matches after its modifier changes")
+
+	top.PinnedDefault = []SkillDefaultRef{{Type: SkillID, Name: "Gone"}}
+	e.Recalculate()
+	check.Equal(t, fxp.Seven, top.LevelData.Level, "falls back when the pinned default is unavailable")
+}
+
+func TestSkillRequiredSpecialization(t *testing.T) {
+	e := NewEntity()
+	s := NewSkill(e, nil, false)
+	s.Name = "Artist"
+	s.SpecializationRequired = true
+	s.SpecializationChoices = []string{"Painting", "Sculpting"}
+	e.SetSkillList([]*Skill{s})
+	e.Recalculate()
+	check.False(t, s.SpecializationSatisfied(nil, ""), "no specialization chosen")
+	check.NotEqual(t, "", s.UnsatisfiedReason, "flagged without specialization")
+	report := NewValidationReport(e)
+	check.False(t, report.Valid(), "report catches missing specialization")
+	check.Equal(t, 1, len(report.Issues))
+
+	s.Specialization = "Pottery"
+	check.False(t, s.SpecializationSatisfied(nil, ""), "specialization not in choices")
+
+	s.Specialization = "painting"
+	check.True(t, s.SpecializationSatisfied(nil, ""), "specialization in choices")
+	check.True(t, NewValidationReport(e).Valid(), "report is clean once satisfied")
+
+	s.SpecializationChoices = nil
+	s.Specialization = "Pottery"
+	check.True(t, s.SpecializationSatisfied(nil, ""), "free-form specialization")
+}
+
+func TestSkillCinematicTraining(t *testing.T) {
+	e := NewEntity()
+	s := NewSkill(e, nil, false)
+	s.Name = "Power Blow"
+	s.Cinematic = true
+	e.SetSkillList([]*Skill{s})
+	e.Recalculate()
+	check.False(t, s.CinematicTrainingSatisfied(nil, ""), "no training")
+	check.NotEqual(t, "", s.UnsatisfiedReason, "flagged without training")
+
+	e.SheetSettings.WaiveCinematicSkillTraining = true
+	e.Recalculate()
+	check.Equal(t, "", s.UnsatisfiedReason, "requirement waived")
+
+	e.SheetSettings.WaiveCinematicSkillTraining = false
+	trait := NewTrait(e, nil, false)
+	trait.Name = TrainedByAMasterTraitName
+	e.SetTraitList([]*Trait{trait})
+	e.Recalculate()
+	check.Equal(t, "", s.UnsatisfiedReason, "has training")
+}
+
+func TestSkillCulturalUnfamiliarity(t *testing.T) {
+	e := NewEntity()
+	social := NewSkill(e, nil, false)
+	social.Name = "Diplomacy"
+	social.Tags = []string{SocialSkillTag}
+	other := NewSkill(e, nil, false)
+	other.Name = "Climbing"
+	e.SetSkillList([]*Skill{social, other})
+	check.Equal(t, fxp.Int(0), social.CulturalUnfamiliarityAdjustment(), "familiar culture")
+
+	e.Cultures.Unfamiliar = true
+	check.Equal(t, CulturalUnfamiliarityPenalty, social.CulturalUnfamiliarityAdjustment(), "unfamiliar culture")
+	check.Equal(t, fxp.Int(0), other.CulturalUnfamiliarityAdjustment(), "non-social skill")
+
+	before := e.PointsBreakdown().Advantages
+	e.Cultures.Familiar = []string{"Japanese"}
+	e.Cultures.AlienFamiliar = []string{"Bug"}
+	check.Equal(t, before+fxp.Three, e.PointsBreakdown().Advantages, "familiarity points")
+}
+
+func TestTechniqueMultipleDefaults(t *testing.T) {
+	e := NewEntity()
+	karate := NewSkill(e, nil, false)
+	karate.Name = "Karate"
+	karate.Points = fxp.Eight
+	brawling := NewSkill(e, nil, false)
+	brawling.Name = "Brawling"
+	kick := NewTechnique(e, nil, "Karate")
+	kick.Name = "Kick"
+	kick.TechniqueDefault.Modifier = -fxp.Two
+	kick.TechniqueAltDefaults = []*SkillDefault{{DefaultType: SkillID, Name: "Brawling", Modifier: -fxp.One}}
+	e.SetSkillList([]*Skill{karate, brawling, kick})
+	e.Recalculate()
+	check.Equal(t, fxp.Nine, brawling.LevelData.Level)
+	check.Equal(t, fxp.Eleven, kick.LevelData.Level, "uses the best default")
+	check.Equal(t, "-1", kick.RelativeLevel())
+	check.Equal(t, "Karate-2 or Brawling-1", kick.TechniqueDefaultsText())
+
+	brawling.Points = fxp.Sixteen
+	e.Recalculate()
+	check.Equal(t, fxp.From(14), kick.LevelData.Level, "switches to the better default")
+	check.Equal(t, "+0", kick.RelativeLevel(), "relative to the default used")
+
+	brawling.Points = fxp.One
+	combo := NewTechnique(e, nil, "Karate")
+	combo.Name = "Punch and Kick"
+	combo.Combination = true
+	combo.Difficulty.Difficulty = difficulty.Hard
+	combo.Points = fxp.Two
+	combo.TechniqueAltDefaults = []*SkillDefault{{DefaultType: SkillID, Name: "Brawling"}}
+	e.SetSkillList([]*Skill{karate, brawling, combo})
+	e.Recalculate()
+	check.Equal(t, -fxp.Six, CombinationPenalty(2))
+	check.Equal(t, -fxp.Eight, CombinationPenalty(3))
+	check.Equal(t, fxp.Four, combo.LevelData.Level, "uses the worst default with the combination penalty")
+	check.Equal(t, "Karate + Brawling (combination -6)", combo.TechniqueDefaultsText())
+	check.True(t, combo.TechniqueSatisfied(nil, ""))
+
+	combo.TechniqueAltDefaults[0].Name = "Judo"
+	e.Recalculate()
+	check.False(t, combo.TechniqueSatisfied(nil, ""), "combinations require every skill")
+	check.True(t, combo.LevelData.Level <= 0)
+}
+
+func TestWildcardSkillCoversOthers(t *testing.T) {
+	e := NewEntity()
+	guns := NewSkill(e, nil, false)
+	guns.Name = "Guns!"
+	guns.Difficulty.Difficulty = difficulty.Wildcard
+	guns.Points = fxp.Twelve
+	guns.WildcardCovers = []string{"Guns", "Beam Weapons"}
+	draw := NewTechnique(e, nil, "Guns")
+	draw.Name = "Quick-Shot"
+	draw.TechniqueDefault.Specialization = "Pistol"
+	draw.TechniqueDefault.Modifier = -fxp.Two
+	e.SetSkillList([]*Skill{guns, draw})
+	e.Recalculate()
+	check.Equal(t, fxp.Nine, guns.LevelData.Level, "wildcard skills cost triple")
+	check.True(t, guns.CoversSkill("guns"))
+	check.False(t, guns.CoversSkill("Bow"))
+	check.Equal(t, guns, e.BestSkillNamed("Beam Weapons", "Rifle", true, nil))
+	check.Nil(t, e.BestSkillNamed("Bow", "", true, nil))
+
+	def := &SkillDefault{DefaultType: SkillID, Name: "Guns", Specialization: "Rifle"}
+	check.Equal(t, fxp.Nine, def.SkillLevel(e, nil, true, nil, false), "weapons default to the wildcard")
+	check.Equal(t, fxp.Eight, draw.LevelData.Level, "techniques default to the wildcard")
+	check.True(t, draw.TechniqueSatisfied(nil, ""))
+
+	guns.Difficulty.Difficulty = difficulty.VeryHard
+	check.False(t, guns.CoversSkill("Guns"), "only wildcard skills cover others")
+}
@@ -1,12 +1,15 @@
 package gurps
 
 import (
+	"archive/zip"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"time"
 
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/srcstate"
 	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/toolbox/tid"
 )
@@ -45,6 +48,7 @@ type SrcProvider interface {
 // SrcMatcher provides Source matching for a given ListProvider.
 type SrcMatcher struct {
 	libHashes map[LibraryFile]libSrcData
+	archive   *CharacterArchive
 }
 
 // ShouldOmit implements json.Omitter.
@@ -68,8 +72,8 @@ func (l LibraryFile) String() string {
 	return i18n.Text("Library: ") + l.Library + "\n" + i18n.Text("Path: ") + l.Path
 }
 
-// PrepareHashes for the given ListProvider.
-func (sm *SrcMatcher) PrepareHashes(provider ListProvider) {
+// ReferencedLibraryFiles returns the library files that the data in the ListProvider was sourced from.
+func ReferencedLibraryFiles(provider ListProvider) map[LibraryFile]struct{} {
 	neededLibs := make(map[LibraryFile]struct{})
 	Traverse(func(t *Trait) bool {
 		t.Source.collectInto(neededLibs)
@@ -107,10 +111,27 @@ func (sm *SrcMatcher) PrepareHashes(provider ListProvider) {
 		n.Source.collectInto(neededLibs)
 		return false
 	}, false, false, provider.NoteList()...)
-	libs := GlobalSettings().Libraries()
+	return neededLibs
+}
+
+// UseArchivedLibraries causes the matcher to compare against the copies of the library files stored in the character
+// archive rather than the current content of the libraries.
+func (sm *SrcMatcher) UseArchivedLibraries(archive *CharacterArchive) {
+	sm.archive = archive
+	sm.libHashes = nil
+}
+
+// PrepareHashes for the given ListProvider.
+func (sm *SrcMatcher) PrepareHashes(provider ListProvider) {
+	neededLibs := ReferencedLibraryFiles(provider)
 	if sm.libHashes == nil {
 		sm.libHashes = make(map[LibraryFile]libSrcData)
 	}
+	if sm.archive != nil {
+		sm.prepareArchivedHashes(neededLibs)
+		return
+	}
+	libs := GlobalSettings().Libraries()
 	for libFile := range neededLibs {
 		lib, ok := libs[libFile.Library]
 		if !ok {
@@ -129,62 +150,88 @@ func (sm *SrcMatcher) PrepareHashes(provider ListProvider) {
 			}
 			delete(sm.libHashes, libFile)
 		}
-		var srcData libSrcData
-		srcData.timestamp = modTime
-		srcData.dataHashes = make(map[tid.TID]HashAndData)
-		dir := os.DirFS(filepath.Dir(p))
-		file := filepath.Base(p)
-		fi := FileInfoFor(p)
-		if fi == nil || len(fi.Extensions) == 0 {
-			continue
-		}
-		switch fi.Extensions[0] {
-		case TraitsExt:
-			var data []*Trait
-			if data, err = NewTraitsFromFile(dir, file); err == nil {
-				NodesToHashesByID(srcData.dataHashes, data...)
-				Traverse(func(t *Trait) bool {
-					NodesToHashesByID(srcData.dataHashes, t.Modifiers...)
-					return false
-				}, false, false, data...)
-			}
-		case TraitModifiersExt:
-			var data []*TraitModifier
-			if data, err = NewTraitModifiersFromFile(dir, file); err == nil {
-				NodesToHashesByID(srcData.dataHashes, data...)
-			}
-		case SkillsExt:
-			var data []*Skill
-			if data, err = NewSkillsFromFile(dir, file); err == nil {
-				NodesToHashesByID(srcData.dataHashes, data...)
+		if dataHashes := hashLibraryFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); dataHashes != nil {
+			sm.libHashes[libFile] = libSrcData{
+				timestamp:  modTime,
+				dataHashes: dataHashes,
 			}
-		case SpellsExt:
-			var data []*Spell
-			if data, err = NewSpellsFromFile(dir, file); err == nil {
-				NodesToHashesByID(srcData.dataHashes, data...)
-			}
-		case EquipmentExt:
-			var data []*Equipment
-			if data, err = NewEquipmentFromFile(dir, file); err == nil {
-				NodesToHashesByID(srcData.dataHashes, data...)
-				Traverse(func(e *Equipment) bool {
-					NodesToHashesByID(srcData.dataHashes, e.Modifiers...)
-					return false
-				}, false, false, data...)
-			}
-		case EquipmentModifiersExt:
-			var data []*EquipmentModifier
-			if data, err = NewEquipmentModifiersFromFile(dir, file); err == nil {
-				NodesToHashesByID(srcData.dataHashes, data...)
-			}
-		case NotesExt:
-			var data []*Note
-			if data, err = NewNotesFromFile(dir, file); err == nil {
-				NodesToHashesByID(srcData.dataHashes, data...)
+		}
+	}
+}
+
+func (sm *SrcMatcher) prepareArchivedHashes(neededLibs map[LibraryFile]struct{}) {
+	archived := make(map[LibraryFile]bool, len(sm.archive.Manifest.LibraryFiles))
+	for _, libFile := range sm.archive.Manifest.LibraryFiles {
+		archived[libFile] = true
+	}
+	var toLoad []LibraryFile
+	for libFile := range neededLibs {
+		if _, exists := sm.libHashes[libFile]; !exists && archived[libFile] {
+			toLoad = append(toLoad, libFile)
+		}
+	}
+	if len(toLoad) == 0 {
+		return // The archive never changes, so anything already loaded is still current.
+	}
+	if err := sm.archive.read(func(r *zip.Reader) error {
+		for _, libFile := range toLoad {
+			if dataHashes := hashLibraryFile(r, archiveLibraryPath(libFile)); dataHashes != nil {
+				sm.libHashes[libFile] = libSrcData{dataHashes: dataHashes}
 			}
 		}
-		sm.libHashes[libFile] = srcData
+		return nil
+	}); err != nil {
+		errs.Log(err, "path", sm.archive.Path)
+	}
+}
+
+// hashLibraryFile loads the library file and returns the hashes of its data by ID. Returns nil if the file isn't a
+// type of library file that data is sourced from.
+func hashLibraryFile(fileSystem fs.FS, filePath string) map[tid.TID]HashAndData {
+	fi := FileInfoFor(filePath)
+	if fi == nil || len(fi.Extensions) == 0 {
+		return nil
+	}
+	dataHashes := make(map[tid.TID]HashAndData)
+	switch fi.Extensions[0] {
+	case TraitsExt:
+		if data, err := NewTraitsFromFile(fileSystem, filePath); err == nil {
+			NodesToHashesByID(dataHashes, data...)
+			Traverse(func(t *Trait) bool {
+				NodesToHashesByID(dataHashes, t.Modifiers...)
+				return false
+			}, false, false, data...)
+		}
+	case TraitModifiersExt:
+		if data, err := NewTraitModifiersFromFile(fileSystem, filePath); err == nil {
+			NodesToHashesByID(dataHashes, data...)
+		}
+	case SkillsExt:
+		if data, err := NewSkillsFromFile(fileSystem, filePath); err == nil {
+			NodesToHashesByID(dataHashes, data...)
+		}
+	case SpellsExt:
+		if data, err := NewSpellsFromFile(fileSystem, filePath); err == nil {
+			NodesToHashesByID(dataHashes, data...)
+		}
+	case EquipmentExt:
+		if data, err := NewEquipmentFromFile(fileSystem, filePath); err == nil {
+			NodesToHashesByID(dataHashes, data...)
+			Traverse(func(e *Equipment) bool {
+				NodesToHashesByID(dataHashes, e.Modifiers...)
+				return false
+			}, false, false, data...)
+		}
+	case EquipmentModifiersExt:
+		if data, err := NewEquipmentModifiersFromFile(fileSystem, filePath); err == nil {
+			NodesToHashesByID(dataHashes, data...)
+		}
+	case NotesExt:
+		if data, err := NewNotesFromFile(fileSystem, filePath); err == nil {
+			NodesToHashesByID(dataHashes, data...)
+		}
 	}
+	return dataHashes
 }
 
 // Match returns the source state of the given data.
@@ -0,0 +1,62 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/srcstate"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestSrcMatcherUsesArchivedLibraries(t *testing.T) {
+	if _, registered := fileTypeRegistry[NotesExt]; !registered {
+		// Normally registered by the user interface
+		(&FileInfo{Name: "Notes", Extensions: []string{NotesExt}, IsGCSData: true}).Register()
+	}
+	libDir := t.TempDir()
+	libPath := filepath.Join(libDir, "Notes"+NotesExt)
+	check.NoError(t, os.WriteFile(libPath, []byte(`{"version":5,"rows":[
+  {"id":"naaaaaaaaaaaaaaaa","text":"Archived text"}
+]}`), 0o600))
+	lib := &Library{GitHubAccountName: "test", RepoName: "archive", PathOnDisk: libDir}
+	from := LibraryFile{Library: lib.Key(), Path: "Notes" + NotesExt}
+	notes, err := NewNotesFromFile(os.DirFS(libDir), filepath.Base(libPath))
+	check.NoError(t, err)
+
+	e := NewEntity()
+	e.Notes = []*Note{notes[0].Clone(from, e, nil, false)}
+	archivePath := filepath.Join(t.TempDir(), "Test"+CharacterArchiveExt)
+	var f *os.File
+	f, err = os.Create(archivePath)
+	check.NoError(t, err)
+	check.NoError(t, writeCharacterArchive(f, e, nil, "", "", Libraries{lib.Key(): lib}))
+	check.NoError(t, f.Close())
+
+	// The library changing after the character was archived must not affect the archived character
+	check.NoError(t, os.WriteFile(libPath, []byte(`{"version":5,"rows":[
+  {"id":"naaaaaaaaaaaaaaaa","text":"Updated text"}
+]}`), 0o600))
+
+	var a *CharacterArchive
+	a, err = OpenCharacterArchive(archivePath)
+	check.NoError(t, err)
+	var restored *Entity
+	restored, err = a.Entity()
+	check.NoError(t, err)
+	check.Equal(t, 1, len(restored.Notes))
+	state, match := restored.SourceMatcher().Match(restored.Notes[0])
+	check.Equal(t, srcstate.Matched, state)
+	matched, ok := match.(*Note)
+	check.True(t, ok)
+	check.Equal(t, "Archived text", matched.Text)
+}
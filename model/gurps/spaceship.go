@@ -0,0 +1,242 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"bytes"
+	"context"
+	"hash"
+	"io/fs"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/shipsys"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/gcs/v5/model/kinds"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// Spaceship size modifier limits and hull layout, as given in GURPS Spaceships.
+const (
+	SpaceshipMinSM     = 5
+	SpaceshipMaxSM     = 15
+	SpaceshipHullSlots = 6
+)
+
+// Per size modifier values, starting at SpaceshipMinSM.
+var (
+	spaceshipLoadedWeight = []int{10, 30, 100, 300, 1000, 3000, 10000, 30000, 100000, 300000, 1000000}
+	spaceshipStructure    = []int{20, 30, 50, 70, 100, 150, 200, 300, 500, 700, 1000}
+	spaceshipLength       = []int{15, 20, 30, 50, 70, 100, 150, 200, 300, 500, 700}
+)
+
+var (
+	spaceshipStreamlinedAirSpeed   = fxp.From(1500)
+	spaceshipUnstreamlinedAirSpeed = fxp.OneHundredFifty
+)
+
+// Spaceship holds the design of a spaceship, laid out using the hull system of GURPS Spaceships.
+type Spaceship struct {
+	SpaceshipData
+}
+
+// SpaceshipData holds the spaceship file data.
+type SpaceshipData struct {
+	Version     int                                 `json:"version"`
+	ID          tid.TID                             `json:"id"`
+	Name        string                              `json:"name,omitempty"`
+	Class       string                              `json:"class,omitempty"`
+	TechLevel   string                              `json:"tech_level,omitempty"`
+	SM          int                                 `json:"sm"`
+	Streamlined bool                                `json:"streamlined,omitempty"`
+	Front       [SpaceshipHullSlots]SpaceshipSystem `json:"front"`
+	Central     [SpaceshipHullSlots]SpaceshipSystem `json:"central"`
+	Core        SpaceshipSystem                     `json:"core"`
+	Rear        [SpaceshipHullSlots]SpaceshipSystem `json:"rear"`
+	Crew        []*CrewStation                      `json:"crew,omitempty"`
+	Notes       string                              `json:"notes,omitempty"`
+}
+
+// SpaceshipSystem holds the system installed in one hull location.
+type SpaceshipSystem struct {
+	System      shipsys.System `json:"system"`
+	Description string         `json:"description,omitempty"`
+}
+
+// CrewStation holds a crew position aboard a spaceship and the character, if any, assigned to it.
+type CrewStation struct {
+	Role      string `json:"role,omitempty"`
+	Character string `json:"character,omitempty"`
+	Notes     string `json:"notes,omitempty"`
+}
+
+// SpaceshipPerformance holds the values derived from a spaceship's design.
+type SpaceshipPerformance struct {
+	LoadedWeight    int
+	Structure       int
+	Length          int
+	Acceleration    fxp.Int
+	DeltaV          fxp.Int
+	UnlimitedDeltaV bool
+	AirSpeed        fxp.Int
+}
+
+// NewSpaceshipFromFile loads a Spaceship from a file.
+func NewSpaceshipFromFile(fileSystem fs.FS, filePath string) (*Spaceship, error) {
+	var ship Spaceship
+	if err := jio.LoadFromFS(context.Background(), fileSystem, filePath, &ship); err != nil {
+		return nil, errs.NewWithCause(InvalidFileData(), err)
+	}
+	if err := jio.CheckVersion(ship.Version); err != nil {
+		return nil, err
+	}
+	if !tid.IsKindAndValid(ship.ID, kinds.Spaceship) {
+		ship.ID = tid.MustNewTID(kinds.Spaceship)
+	}
+	ship.SM = min(max(ship.SM, SpaceshipMinSM), SpaceshipMaxSM)
+	return &ship, nil
+}
+
+// NewSpaceship creates a new Spaceship.
+func NewSpaceship() *Spaceship {
+	ship := &Spaceship{
+		SpaceshipData: SpaceshipData{
+			ID: tid.MustNewTID(kinds.Spaceship),
+			SM: SpaceshipMinSM,
+		},
+	}
+	ship.Core.System = shipsys.ControlRoom
+	return ship
+}
+
+// Save the Spaceship to a file as JSON.
+func (s *Spaceship) Save(filePath string) error {
+	return jio.SaveToFile(context.Background(), filePath, s)
+}
+
+// MarshalJSON implements json.Marshaler.
+func (s *Spaceship) MarshalJSON() ([]byte, error) {
+	s.Version = jio.CurrentDataVersion
+	return json.Marshal(&s.SpaceshipData)
+}
+
+// Hash writes this object's contents into the hasher.
+func (s *Spaceship) Hash(h hash.Hash) {
+	var buffer bytes.Buffer
+	if err := jio.Save(context.Background(), &buffer, s); err != nil {
+		errs.Log(err)
+		return
+	}
+	_, _ = h.Write(buffer.Bytes())
+}
+
+// Systems returns all of the installed systems, front to rear, with the core last.
+func (s *Spaceship) Systems() []SpaceshipSystem {
+	list := make([]SpaceshipSystem, 0, SpaceshipHullSlots*3+1)
+	list = append(list, s.Front[:]...)
+	list = append(list, s.Central[:]...)
+	list = append(list, s.Rear[:]...)
+	return append(list, s.Core)
+}
+
+// SearchText returns the text that should be matched against when searching for content.
+func (s *Spaceship) SearchText() string {
+	var buffer strings.Builder
+	for _, one := range []string{s.Name, s.Class, s.Notes} {
+		AppendStringOntoNewLine(&buffer, one)
+	}
+	for _, one := range s.Systems() {
+		if one.System != shipsys.Empty {
+			AppendStringOntoNewLine(&buffer, one.System.String())
+		}
+		AppendStringOntoNewLine(&buffer, one.Description)
+	}
+	for _, one := range s.Crew {
+		AppendStringOntoNewLine(&buffer, one.Role)
+		AppendStringOntoNewLine(&buffer, one.Notes)
+	}
+	return buffer.String()
+}
+
+// Count returns the number of hull locations holding the given system.
+func (s *Spaceship) Count(system shipsys.System) int {
+	count := 0
+	for _, one := range s.Systems() {
+		if one.System == system {
+			count++
+		}
+	}
+	return count
+}
+
+// Performance derives the performance of the spaceship from its size and installed systems. Each engine adds its
+// acceleration. Rocket delta-V is the number of fuel tanks times the delta-V per tank of the least efficient rocket
+// installed, while any reactionless engine removes the delta-V limit. Air speed requires at least 1G of acceleration.
+func (s *Spaceship) Performance() SpaceshipPerformance {
+	index := min(max(s.SM, SpaceshipMinSM), SpaceshipMaxSM) - SpaceshipMinSM
+	perf := SpaceshipPerformance{
+		LoadedWeight: spaceshipLoadedWeight[index],
+		Structure:    spaceshipStructure[index],
+		Length:       spaceshipLength[index],
+	}
+	var perTank fxp.Int
+	for _, one := range s.Systems() {
+		perf.Acceleration += one.System.Acceleration()
+		if one.System.IsReactionless() {
+			perf.UnlimitedDeltaV = true
+		}
+		if v := one.System.DeltaVPerFuelTank(); v > 0 && (perTank == 0 || v < perTank) {
+			perTank = v
+		}
+	}
+	if !perf.UnlimitedDeltaV {
+		perf.DeltaV = perTank.Mul(fxp.From(s.Count(shipsys.FuelTank)))
+	}
+	if perf.Acceleration >= fxp.One {
+		if s.Streamlined {
+			perf.AirSpeed = perf.Acceleration.Mul(spaceshipStreamlinedAirSpeed)
+		} else {
+			perf.AirSpeed = perf.Acceleration.Mul(spaceshipUnstreamlinedAirSpeed)
+		}
+	}
+	return perf
+}
+
+// CharacterPath returns the path to the character assigned to this station, resolving it relative to the directory
+// holding the spaceship file. Returns an empty string if no character has been assigned.
+func (c *CrewStation) CharacterPath(shipPath string) string {
+	if c.Character == "" {
+		return ""
+	}
+	p := filepath.FromSlash(c.Character)
+	if filepath.IsAbs(p) {
+		return p
+	}
+	return filepath.Join(filepath.Dir(shipPath), p)
+}
+
+// SetCharacterPath assigns the character at the path to this station. The path is stored relative to the directory
+// holding the spaceship file when possible, so that the two may be moved together.
+func (c *CrewStation) SetCharacterPath(shipPath, characterPath string) {
+	if characterPath == "" {
+		c.Character = ""
+		return
+	}
+	if filepath.IsAbs(shipPath) {
+		if rel, err := filepath.Rel(filepath.Dir(shipPath), characterPath); err == nil {
+			c.Character = filepath.ToSlash(rel)
+			return
+		}
+	}
+	c.Character = filepath.ToSlash(characterPath)
+}
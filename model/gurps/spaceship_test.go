@@ -0,0 +1,60 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/shipsys"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestSpaceshipPerformance(t *testing.T) {
+	ship := gurps.NewSpaceship()
+	ship.SM = 7
+	ship.Streamlined = true
+	ship.Rear[0].System = shipsys.ChemicalRocket
+	ship.Central[0].System = shipsys.FuelTank
+	ship.Central[1].System = shipsys.FuelTank
+	ship.Central[2].System = shipsys.FuelTank
+	perf := ship.Performance()
+	check.Equal(t, 100, perf.LoadedWeight)
+	check.Equal(t, 50, perf.Structure)
+	check.Equal(t, fxp.Three, perf.Acceleration)
+	check.Equal(t, fxp.FromStringForced("0.45"), perf.DeltaV)
+	check.False(t, perf.UnlimitedDeltaV)
+	check.Equal(t, fxp.From(4500), perf.AirSpeed)
+
+	ship.Rear[1].System = shipsys.RotaryReactionless
+	ship.Streamlined = false
+	perf = ship.Performance()
+	check.True(t, perf.UnlimitedDeltaV)
+	check.Equal(t, fxp.FromStringForced("3.1"), perf.Acceleration)
+	check.Equal(t, fxp.From(465), perf.AirSpeed)
+
+	ship.Rear[0].System = shipsys.Empty
+	check.Equal(t, fxp.Tenth, ship.Performance().Acceleration)
+	check.Equal(t, fxp.Int(0), ship.Performance().AirSpeed)
+}
+
+func TestCrewStationCharacterPath(t *testing.T) {
+	root := t.TempDir()
+	shipPath := filepath.Join(root, "ships", "Valiant.ship")
+	charPath := filepath.Join(root, "crew", "Pilot.gcs")
+	var station gurps.CrewStation
+	station.SetCharacterPath(shipPath, charPath)
+	check.Equal(t, "../crew/Pilot.gcs", station.Character)
+	check.Equal(t, charPath, station.CharacterPath(shipPath))
+	station.SetCharacterPath(shipPath, "")
+	check.Equal(t, "", station.CharacterPath(shipPath))
+}
@@ -105,11 +105,12 @@ type SpellNonContainerOnlyEditData struct {
 
 // SpellSyncData holds the spell sync data that is common to both containers and non-containers.
 type SpellSyncData struct {
-	Name             string   `json:"name,omitempty"`
-	PageRef          string   `json:"reference,omitempty"`
-	PageRefHighlight string   `json:"reference_highlight,omitempty"`
-	LocalNotes       string   `json:"notes,omitempty"`
-	Tags             []string `json:"tags,omitempty"`
+	Name             string       `json:"name,omitempty"`
+	PageRef          string       `json:"reference,omitempty"`
+	PageRefHighlight string       `json:"reference_highlight,omitempty"`
+	LocalNotes       string       `json:"notes,omitempty"`
+	Translations     Translations `json:"translations,omitempty"`
+	Tags             []string     `json:"tags,omitempty"`
 }
 
 // SpellNonContainerOnlySyncData holds the spell sync data that is only applicable to traits that aren't containers.
@@ -703,7 +704,8 @@ func CalculateSpellLevel(e *Entity, name, powerSource string, colleges, tags []s
 		if level != fxp.Min {
 			relativeLevel += e.SpellBonusFor(name, powerSource, colleges, tags, &tooltip)
 			relativeLevel = relativeLevel.Trunc()
-			level += relativeLevel
+			level += relativeLevel + e.shockPenaltyForAttribute(attrDiff.Attribute, &tooltip) +
+				e.grapplingPenaltyForAttribute(attrDiff.Attribute, &tooltip)
 		}
 	}
 	return Level{
@@ -880,7 +882,7 @@ func (s *Spell) RatedStrength() fxp.Int {
 
 // Description implements WeaponOwner.
 func (s *Spell) Description() string {
-	return s.String()
+	return s.describe(s.LocalizedNameWithReplacements())
 }
 
 // SecondaryText returns the less important information that should be displayed with the description.
@@ -897,8 +899,12 @@ func (s *Spell) SecondaryText(optionChecker func(display.Option) bool) string {
 }
 
 func (s *Spell) String() string {
+	return s.describe(s.NameWithReplacements())
+}
+
+func (s *Spell) describe(name string) string {
 	var buffer strings.Builder
-	buffer.WriteString(s.NameWithReplacements())
+	buffer.WriteString(name)
 	if !s.Container() {
 		if s.TechLevel != nil {
 			buffer.WriteString("/TL")
@@ -980,9 +986,16 @@ func (s *Spell) NameWithReplacements() string {
 	return nameable.Apply(s.Name, s.Replacements)
 }
 
-// LocalNotesWithReplacements returns the local notes with any replacements applied.
+// LocalizedNameWithReplacements returns the name in the user's language, if a translation is available, with any
+// replacements applied. Use NameWithReplacements() when matching against the name.
+func (s *Spell) LocalizedNameWithReplacements() string {
+	return nameable.Apply(s.Translations.Name(s.Name), s.Replacements)
+}
+
+// LocalNotesWithReplacements returns the local notes, in the user's language if a translation is available, with any
+// replacements applied.
 func (s *Spell) LocalNotesWithReplacements() string {
-	return nameable.Apply(s.LocalNotes, s.Replacements)
+	return nameable.Apply(s.Translations.Notes(s.LocalNotes), s.Replacements)
 }
 
 // PowerSourceWithReplacements returns the power source with any replacements applied.
@@ -1136,6 +1149,7 @@ func (s *SpellSyncData) hash(h hash.Hash) {
 	hashhelper.String(h, s.PageRef)
 	hashhelper.String(h, s.PageRefHighlight)
 	hashhelper.String(h, s.LocalNotes)
+	s.Translations.hash(h)
 	hashhelper.Num64(h, len(s.Tags))
 	for _, tag := range s.Tags {
 		hashhelper.String(h, tag)
@@ -1178,6 +1192,7 @@ func (s *SpellEditData) copyFrom(other *SpellEditData, isContainer, isApply bool
 	*s = *other
 	s.Tags = txt.CloneStringSlice(other.Tags)
 	s.Replacements = maps.Clone(other.Replacements)
+	s.Translations = maps.Clone(other.Translations)
 	if other.TechLevel != nil {
 		tl := *other.TechLevel
 		s.TechLevel = &tl
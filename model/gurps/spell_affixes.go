@@ -0,0 +1,184 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"bufio"
+	"io/fs"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// spellAffixes holds the prefix and suffix rules from a Hunspell affix file. Other affix file features, such as
+// compounding and replacement tables, aren't supported.
+type spellAffixes struct {
+	flagType string
+	affixes  map[string]*spellAffix
+}
+
+type spellAffix struct {
+	rules  []spellAffixRule
+	prefix bool
+	cross  bool
+}
+
+type spellAffixRule struct {
+	cond  *regexp.Regexp
+	strip string
+	add   string
+}
+
+func loadSpellAffixes(fileSystem fs.FS, filePath string) (*spellAffixes, error) {
+	f, err := fileSystem.Open(filePath)
+	if err != nil {
+		return nil, errs.NewWithCause(filePath, err)
+	}
+	defer func() { _ = f.Close() }() //nolint:errcheck // Nothing useful can be done with a close failure on a read
+	a := &spellAffixes{affixes: make(map[string]*spellAffix)}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		switch fields[0] {
+		case "FLAG":
+			a.flagType = fields[1]
+		case "PFX", "SFX":
+			if len(fields) < 4 {
+				continue
+			}
+			affix, exists := a.affixes[fields[1]]
+			if !exists {
+				// The first line for a flag is its header: the cross product setting and the rule count
+				a.affixes[fields[1]] = &spellAffix{
+					prefix: fields[0] == "PFX",
+					cross:  fields[2] == "Y",
+				}
+				continue
+			}
+			if len(fields) < 5 {
+				fields = append(fields, ".")
+			}
+			if rule, ok := newSpellAffixRule(affix.prefix, fields[2], fields[3], fields[4]); ok {
+				affix.rules = append(affix.rules, rule)
+			}
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, errs.NewWithCause(filePath, err)
+	}
+	return a, nil
+}
+
+func newSpellAffixRule(prefix bool, strip, add, cond string) (spellAffixRule, bool) {
+	if strip == "0" {
+		strip = ""
+	}
+	if i := strings.IndexByte(add, '/'); i != -1 {
+		add = add[:i] // Continuation flags aren't supported
+	}
+	if add == "0" {
+		add = ""
+	}
+	if prefix {
+		cond = "^" + cond
+	} else {
+		cond += "$"
+	}
+	re, err := regexp.Compile(cond)
+	if err != nil {
+		return spellAffixRule{}, false
+	}
+	return spellAffixRule{
+		cond:  re,
+		strip: strip,
+		add:   add,
+	}, true
+}
+
+// flags splits the affix flags of a dictionary entry into the individual flags, according to the flag type of the
+// affix file.
+func (a *spellAffixes) flags(flags string) []string {
+	var list []string
+	switch a.flagType {
+	case "long":
+		for len(flags) > 1 {
+			list = append(list, flags[:2])
+			flags = flags[2:]
+		}
+	case "num":
+		for _, one := range strings.Split(flags, ",") {
+			if _, err := strconv.Atoi(one); err == nil {
+				list = append(list, one)
+			}
+		}
+	default:
+		for flags != "" {
+			_, size := utf8.DecodeRuneInString(flags)
+			list = append(list, flags[:size])
+			flags = flags[size:]
+		}
+	}
+	return list
+}
+
+// expand returns the forms of the word produced by the affix rules for the flags, not including the word itself.
+// Prefixes and suffixes that allow it are also combined with each other.
+func (a *spellAffixes) expand(word, flags string) []string {
+	var prefixes, suffixes []*spellAffix
+	for _, flag := range a.flags(flags) {
+		if affix, ok := a.affixes[flag]; ok {
+			if affix.prefix {
+				prefixes = append(prefixes, affix)
+			} else {
+				suffixes = append(suffixes, affix)
+			}
+		}
+	}
+	var list []string
+	for _, suffix := range suffixes {
+		for _, one := range suffix.apply(word) {
+			list = append(list, one)
+			if suffix.cross {
+				for _, prefix := range prefixes {
+					if prefix.cross {
+						list = append(list, prefix.apply(one)...)
+					}
+				}
+			}
+		}
+	}
+	for _, prefix := range prefixes {
+		list = append(list, prefix.apply(word)...)
+	}
+	return list
+}
+
+func (a *spellAffix) apply(word string) []string {
+	var list []string
+	for _, rule := range a.rules {
+		if !rule.cond.MatchString(word) {
+			continue
+		}
+		if a.prefix {
+			if rest, ok := strings.CutPrefix(word, rule.strip); ok {
+				list = append(list, rule.add+rest)
+			}
+		} else if rest, ok := strings.CutSuffix(word, rule.strip); ok {
+			list = append(list, rest+rule.add)
+		}
+	}
+	return list
+}
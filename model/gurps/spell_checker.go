@@ -0,0 +1,309 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"bufio"
+	"io/fs"
+	"path"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/txt"
+)
+
+const suggestionLetters = "abcdefghijklmnopqrstuvwxyz'"
+
+// Misspelling holds the location of a misspelled word within some text. Start and End are rune indexes.
+type Misspelling struct {
+	Word  string
+	Start int
+	End   int
+}
+
+// SpellChecker checks words against the dictionaries for a language, along with a personal dictionary.
+type SpellChecker struct {
+	Language string
+	words    map[string]struct{}
+}
+
+// AvailableDictionaryLanguages scans the libraries for dictionaries and returns the languages they provide, sorted by
+// name. Dictionaries are found in the Settings folder of each library and the language of a dictionary is its file's
+// base name, e.g. "en_US".
+func AvailableDictionaryLanguages(libraries Libraries) []string {
+	var languages []string
+	for _, set := range ScanForNamedFileSets(nil, "", false, libraries, DictionaryExt) {
+		for _, one := range set.List {
+			name := one.Name
+			if !slices.ContainsFunc(languages, func(s string) bool { return strings.EqualFold(s, name) }) {
+				languages = append(languages, name)
+			}
+		}
+	}
+	slices.SortFunc(languages, func(a, b string) int { return txt.NaturalCmp(a, b, true) })
+	return languages
+}
+
+// NewSpellChecker creates a new SpellChecker that combines all of the dictionaries for the language that are found in
+// the libraries with the personal dictionary. Returns nil if there are no dictionaries for the language.
+func NewSpellChecker(language string, libraries Libraries, personal []string) *SpellChecker {
+	if language == "" {
+		return nil
+	}
+	var checker *SpellChecker
+	for _, set := range ScanForNamedFileSets(nil, "", false, libraries, DictionaryExt) {
+		for _, one := range set.List {
+			if !strings.EqualFold(one.Name, language) {
+				continue
+			}
+			if checker == nil {
+				checker = &SpellChecker{Language: language}
+			}
+			if err := checker.LoadDictionary(one.FileSystem, one.FilePath); err != nil {
+				errs.Log(err, "path", one.FilePath)
+			}
+		}
+	}
+	if checker != nil {
+		checker.AddWords(personal...)
+	}
+	return checker
+}
+
+// LoadDictionary adds the words from a dictionary file. The file should hold one word per line. Hunspell dictionaries
+// may be used directly: the leading word count line is ignored and, if an affix file with the same base name sits
+// beside the dictionary, the prefix and suffix rules it defines are applied to the words that carry their flags. Other
+// affix features aren't supported, so without an affix file the dictionary must list every form of a word.
+func (s *SpellChecker) LoadDictionary(fileSystem fs.FS, filePath string) error {
+	f, err := fileSystem.Open(filePath)
+	if err != nil {
+		return errs.NewWithCause(filePath, err)
+	}
+	defer func() { _ = f.Close() }() //nolint:errcheck // Nothing useful can be done with a close failure on a read
+	var affixes *spellAffixes
+	affixPath := strings.TrimSuffix(filePath, path.Ext(filePath)) + AffixExt
+	if _, err = fs.Stat(fileSystem, affixPath); err == nil {
+		if affixes, err = loadSpellAffixes(fileSystem, affixPath); err != nil {
+			errs.Log(err)
+		}
+	}
+	scanner := bufio.NewScanner(f)
+	first := true
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if first {
+			first = false
+			if line != "" && strings.IndexFunc(line, func(r rune) bool { return !unicode.IsDigit(r) }) == -1 {
+				continue
+			}
+		}
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if i := strings.IndexAny(line, "\t "); i != -1 {
+			line = line[:i]
+		}
+		word, flags, _ := strings.Cut(line, "/")
+		s.AddWords(word)
+		if affixes != nil && flags != "" {
+			s.AddWords(affixes.expand(word, flags)...)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return errs.NewWithCause(filePath, err)
+	}
+	return nil
+}
+
+// AddWords adds words to the set of known words.
+func (s *SpellChecker) AddWords(words ...string) {
+	if s.words == nil {
+		s.words = make(map[string]struct{})
+	}
+	for _, word := range words {
+		if word = normalizeSpelling(word); word != "" {
+			s.words[word] = struct{}{}
+		}
+	}
+}
+
+// IsCorrect returns true if the word is known. Words that contain digits, are a single letter, or are all capitals
+// (most likely an abbreviation, such as "DX") are always considered correct.
+func (s *SpellChecker) IsCorrect(word string) bool {
+	if s.shouldSkip(word) {
+		return true
+	}
+	word = normalizeSpelling(word)
+	if _, ok := s.words[word]; ok {
+		return true
+	}
+	if base, ok := strings.CutSuffix(word, "'s"); ok {
+		_, ok = s.words[base]
+		return ok
+	}
+	return false
+}
+
+func (s *SpellChecker) shouldSkip(word string) bool {
+	letters := 0
+	upper := 0
+	for _, r := range word {
+		switch {
+		case unicode.IsDigit(r):
+			return true
+		case unicode.IsLetter(r):
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	return letters < 2 || letters == upper
+}
+
+// Misspellings returns the locations of the misspelled words within the text.
+func (s *SpellChecker) Misspellings(text string) []*Misspelling {
+	var list []*Misspelling
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		if !unicode.IsLetter(runes[i]) && !unicode.IsDigit(runes[i]) {
+			i++
+			continue
+		}
+		start := i
+		for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) ||
+			(isApostrophe(runes[i]) && i+1 < len(runes) && unicode.IsLetter(runes[i+1]))) {
+			i++
+		}
+		if word := string(runes[start:i]); !s.IsCorrect(word) {
+			list = append(list, &Misspelling{
+				Word:  word,
+				Start: start,
+				End:   i,
+			})
+		}
+	}
+	return list
+}
+
+// Suggestions returns up to maxCount known words that are close to the given word, with the capitalization of the
+// original word applied to them.
+func (s *SpellChecker) Suggestions(word string, maxCount int) []string {
+	normalized := normalizeSpelling(word)
+	if normalized == "" {
+		return nil
+	}
+	edits := spellingEdits(normalized)
+	var list []string
+	for _, one := range edits {
+		if _, ok := s.words[one]; ok && !slices.Contains(list, one) {
+			list = append(list, one)
+		}
+	}
+	if len(list) == 0 && len([]rune(normalized)) <= 12 {
+		for _, edit := range edits {
+			for _, one := range spellingEdits(edit) {
+				if _, ok := s.words[one]; ok && !slices.Contains(list, one) {
+					list = append(list, one)
+				}
+			}
+		}
+	}
+	slices.SortStableFunc(list, func(a, b string) int {
+		if result := spellingDistance(normalized, a) - spellingDistance(normalized, b); result != 0 {
+			return result
+		}
+		return txt.NaturalCmp(a, b, true)
+	})
+	if len(list) > maxCount {
+		list = list[:maxCount]
+	}
+	runes := []rune(word)
+	switch {
+	case len(runes) > 1 && strings.ToUpper(word) == word:
+		for i, one := range list {
+			list[i] = strings.ToUpper(one)
+		}
+	case len(runes) != 0 && unicode.IsUpper(runes[0]):
+		for i, one := range list {
+			list[i] = txt.FirstToUpper(one)
+		}
+	}
+	return list
+}
+
+// spellingEdits returns the strings that are one deletion, transposition, replacement or insertion away from the word.
+func spellingEdits(word string) []string {
+	runes := []rune(word)
+	letters := []rune(suggestionLetters)
+	edits := make([]string, 0, len(runes)*(2*len(letters)+2)+len(letters))
+	for i := 0; i <= len(runes); i++ {
+		if i < len(runes) {
+			edits = append(edits, string(runes[:i])+string(runes[i+1:]))
+			if i+1 < len(runes) {
+				swapped := slices.Clone(runes)
+				swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+				edits = append(edits, string(swapped))
+			}
+			for _, r := range letters {
+				if r != runes[i] {
+					edits = append(edits, string(runes[:i])+string(r)+string(runes[i+1:]))
+				}
+			}
+		}
+		for _, r := range letters {
+			edits = append(edits, string(runes[:i])+string(r)+string(runes[i:]))
+		}
+	}
+	return edits
+}
+
+// spellingDistance returns the Levenshtein distance between the two strings.
+func spellingDistance(a, b string) int {
+	ra := []rune(a)
+	rb := []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func normalizeSpelling(word string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(word), "’", "'"))
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’'
+}
+
+// LearnSpelling adds the word to the personal dictionary. Returns true if it wasn't already present.
+func (s *Settings) LearnSpelling(word string) bool {
+	if word = normalizeSpelling(word); word == "" || slices.Contains(s.PersonalDictionary, word) {
+		return false
+	}
+	s.PersonalDictionary = append(s.PersonalDictionary, word)
+	slices.Sort(s.PersonalDictionary)
+	return true
+}
@@ -0,0 +1,82 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestSpellChecker(t *testing.T) {
+	dir := t.TempDir()
+	settingsDir := filepath.Join(dir, "Settings")
+	check.NoError(t, os.MkdirAll(settingsDir, 0o750))
+	check.NoError(t, os.WriteFile(filepath.Join(settingsDir, "en_US.dic"), []byte(`7
+the
+sword/SM
+is
+sharp
+shield
+parry/S
+armored/U
+`), 0o600))
+	check.NoError(t, os.WriteFile(filepath.Join(settingsDir, "en_US.aff"), []byte(`SET UTF-8
+
+SFX S Y 2
+SFX S   y     ies        [^aeiou]y
+SFX S   0     s          [^y]
+
+SFX M Y 1
+SFX M   0     's         .
+
+PFX U Y 1
+PFX U   0     un         .
+`), 0o600))
+	lib := &gurps.Library{GitHubAccountName: "test", RepoName: "lib", PathOnDisk: dir}
+	libraries := gurps.Libraries{lib.Key(): lib}
+
+	check.Equal(t, []string{"en_US"}, gurps.AvailableDictionaryLanguages(libraries))
+	check.Nil(t, gurps.NewSpellChecker("fr_FR", libraries, nil))
+	checker := gurps.NewSpellChecker("en_us", libraries, []string{"Grog"})
+	check.NotNil(t, checker)
+
+	check.True(t, checker.IsCorrect("Sword"))
+	check.True(t, checker.IsCorrect("sword's"))
+	check.True(t, checker.IsCorrect("grog"), "personal dictionary words are known")
+	check.True(t, checker.IsCorrect("DX"), "abbreviations are skipped")
+	check.True(t, checker.IsCorrect("3d6"), "words with digits are skipped")
+	check.False(t, checker.IsCorrect("swrod"))
+	check.True(t, checker.IsCorrect("swords"), "suffix rules from the affix file are applied")
+	check.True(t, checker.IsCorrect("parries"))
+	check.False(t, checker.IsCorrect("parrys"), "suffix conditions are honored")
+	check.False(t, checker.IsCorrect("shields"), "only words with the flag are affected")
+	check.True(t, checker.IsCorrect("unarmored"), "prefix rules from the affix file are applied")
+
+	list := checker.Misspellings("The swrod is sharp; Grog's sheild too.")
+	check.Equal(t, 3, len(list))
+	check.Equal(t, &gurps.Misspelling{Word: "swrod", Start: 4, End: 9}, list[0])
+	check.Equal(t, "sheild", list[1].Word)
+	check.Equal(t, "too", list[2].Word)
+
+	check.Equal(t, []string{"sword"}, checker.Suggestions("swrod", 5))
+	check.Equal(t, []string{"Shield"}, checker.Suggestions("Sheild", 5))
+	check.Equal(t, []string{"PARRY"}, checker.Suggestions("PARY", 5))
+	check.Equal(t, 0, len(checker.Suggestions("xyzzy", 5)))
+
+	var settings gurps.Settings
+	check.True(t, settings.LearnSpelling("Orc"))
+	check.False(t, settings.LearnSpelling("orc"))
+	check.True(t, settings.LearnSpelling("goblin"))
+	check.Equal(t, []string{"goblin", "orc"}, settings.PersonalDictionary)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/stlimit"
+)
+
+// Names of the traits that raise one of the limited forms of ST. Leveled traits with these names that don't already
+// carry a matching attribute bonus feed their levels into the limited ST automatically.
+const (
+	LiftingSTTraitName  = "Lifting ST"
+	StrikingSTTraitName = "Striking ST"
+)
+
+func (e *Entity) strengthBonusFor(limitation stlimit.Option) fxp.Int {
+	bonus := e.AttributeBonusFor(StrengthID, limitation, nil)
+	var name string
+	switch limitation {
+	case stlimit.LiftingOnly:
+		name = LiftingSTTraitName
+	case stlimit.StrikingOnly:
+		name = StrikingSTTraitName
+	default:
+		return bonus.Trunc()
+	}
+	Traverse(func(t *Trait) bool {
+		if t.IsLeveled() && strings.EqualFold(t.NameWithReplacements(), name) && !hasStrengthBonus(t, limitation) {
+			bonus += t.CurrentLevel()
+		}
+		return false
+	}, true, true, e.Traits...)
+	return bonus.Trunc()
+}
+
+func hasStrengthBonus(t *Trait, limitation stlimit.Option) bool {
+	found := featuresHaveStrengthBonus(t.Features, limitation)
+	Traverse(func(mod *TraitModifier) bool {
+		found = found || featuresHaveStrengthBonus(mod.Features, limitation)
+		return found
+	}, false, true, t.Modifiers...)
+	return found
+}
+
+func featuresHaveStrengthBonus(features Features, limitation stlimit.Option) bool {
+	for _, f := range features {
+		if bonus, ok := f.(*AttributeBonus); ok && bonus.Attribute == StrengthID &&
+			bonus.ActualLimitation() == limitation {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveStrengthVariable returns the value of one of the limited forms of ST when the character's attributes don't
+// define it, allowing them to be used in expressions like any other attribute.
+func (e *Entity) resolveStrengthVariable(variableName string) (fxp.Int, bool) {
+	switch variableName {
+	case LiftingStrengthID:
+		return e.LiftingStrength(), true
+	case StrikingStrengthID:
+		return e.StrikingStrength(), true
+	case ThrowingStrengthID:
+		return e.ThrowingStrength(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/cmdline"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+const (
+	crashTraceFileName = "last_crash.txt"
+	redactedValue      = "<redacted>"
+)
+
+// Settings keys whose values identify the user or grant access to something and so must not leave the machine.
+var sensitiveSettingsKeys = map[string]bool{
+	"access_token":        true,
+	"acme_domains":        true,
+	"acme_email":          true,
+	"api_tokens":          true,
+	"deep_search":         true,
+	"default_player_name": true,
+	"embeds":              true,
+	"favorites":           true,
+	"key":                 true,
+	"last_dirs":           true,
+	"library_sorts":       true,
+	"pdfs":                true,
+	"personal_dictionary": true,
+	"recent_files":        true,
+	"recently_added":      true,
+	"session":             true,
+	"sessions":            true,
+	"users":               true,
+	"workspace_layouts":   true,
+}
+
+// CrashTracePath returns the path to the file holding the trace of the last crash.
+func CrashTracePath() string {
+	return filepath.Join(filepath.Dir(SettingsPath), crashTraceFileName)
+}
+
+// RecordCrash writes the error, along with its stack trace, to the crash trace file, replacing any prior trace.
+func RecordCrash(err error) {
+	var buffer strings.Builder
+	fmt.Fprintf(&buffer, "%s %s\n%s\n\n", cmdline.AppName, cmdline.AppVersion, time.Now().Format(time.RFC3339))
+	fmt.Fprintf(&buffer, "%+v\n", err)
+	if writeErr := os.WriteFile(CrashTracePath(), []byte(buffer.String()), 0o640); writeErr != nil {
+		errs.Log(writeErr, "path", CrashTracePath())
+	}
+}
+
+// WriteSupportBundle writes a zip file containing the information needed to diagnose a problem: version and
+// platform information, the logs, the settings with identifying details removed, a listing of the installed
+// libraries and the trace of the last crash, if any.
+func WriteSupportBundle(filePath, logPath string) (err error) {
+	var f *os.File
+	if f, err = os.Create(filePath); err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = errs.Wrap(closeErr)
+		}
+	}()
+	return writeSupportBundle(f, logPath, GlobalSettings())
+}
+
+func writeSupportBundle(w io.Writer, logPath string, settings *Settings) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		home = ""
+	}
+	zw := zip.NewWriter(w)
+	if err = writeArchiveBytes(zw, "info.txt", []byte(supportInfo())); err != nil {
+		return err
+	}
+	if logPath != "" {
+		candidates, globErr := filepath.Glob(logPath + "-*")
+		if globErr != nil {
+			errs.Log(globErr, "path", logPath)
+		}
+		for _, one := range append([]string{logPath}, candidates...) {
+			if err = addSupportFile(zw, path.Join("logs", filepath.Base(one)), one, home); err != nil {
+				return err
+			}
+		}
+	}
+	if err = addSupportFile(zw, crashTraceFileName, CrashTracePath(), home); err != nil {
+		return err
+	}
+	var data any
+	if data, err = AnonymizedSettings(settings, home); err != nil {
+		return err
+	}
+	if err = writeArchiveEntry(zw, "settings.json", func(w io.Writer) error {
+		return jio.Save(context.Background(), w, data)
+	}); err != nil {
+		return err
+	}
+	if err = writeArchiveEntry(zw, "libraries.json", func(w io.Writer) error {
+		return jio.Save(context.Background(), w, libraryManifests(settings.Libraries(), home))
+	}); err != nil {
+		return err
+	}
+	return errs.Wrap(zw.Close())
+}
+
+func supportInfo() string {
+	var buffer strings.Builder
+	fmt.Fprintf(&buffer, "Application: %s %s\n", cmdline.AppName, cmdline.AppVersion)
+	if cmdline.GitVersion != "" {
+		fmt.Fprintf(&buffer, "Git Version: %s\n", cmdline.GitVersion)
+	}
+	if cmdline.BuildNumber != "" {
+		fmt.Fprintf(&buffer, "Build Number: %s\n", cmdline.BuildNumber)
+	}
+	fmt.Fprintf(&buffer, "Data Versions: %d to %d\n", jio.MinimumDataVersion, jio.CurrentDataVersion)
+	fmt.Fprintf(&buffer, "Platform: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&buffer, "Go Version: %s\n", runtime.Version())
+	fmt.Fprintf(&buffer, "Created: %s\n", time.Now().Format(time.RFC3339))
+	return buffer.String()
+}
+
+// addSupportFile adds the file to the zip, replacing the user's home directory with "~". Missing files are skipped.
+func addSupportFile(zw *zip.Writer, name, filePath, home string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil
+		}
+		return errs.Wrap(err)
+	}
+	if home != "" {
+		data = []byte(strings.ReplaceAll(string(data), home, "~"))
+	}
+	return writeArchiveBytes(zw, name, data)
+}
+
+// AnonymizedSettings returns a generic form of the settings with the values that identify the user or grant access
+// to something redacted and with the user's home directory replaced by "~".
+func AnonymizedSettings(settings *Settings, home string) (any, error) {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	var generic any
+	if err = json.Unmarshal(data, &generic); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return anonymize(generic, home), nil
+}
+
+func anonymize(data any, home string) any {
+	switch v := data.(type) {
+	case map[string]any:
+		for key, value := range v {
+			if sensitiveSettingsKeys[key] {
+				v[key] = redactedValue
+			} else {
+				v[key] = anonymize(value, home)
+			}
+		}
+		return v
+	case []any:
+		for i, value := range v {
+			v[i] = anonymize(value, home)
+		}
+		return v
+	case string:
+		if home != "" {
+			return strings.ReplaceAll(v, home, "~")
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+type libraryManifest struct {
+	Title   string   `json:"title"`
+	Repo    string   `json:"repo,omitempty"`
+	Version string   `json:"version,omitempty"`
+	Path    string   `json:"path"`
+	Files   []string `json:"files,omitempty"`
+}
+
+func libraryManifests(libraries Libraries, home string) []*libraryManifest {
+	list := libraries.List()
+	manifests := make([]*libraryManifest, 0, len(list))
+	for _, lib := range list {
+		m := &libraryManifest{
+			Title:   lib.Title,
+			Version: lib.VersionOnDisk(),
+			Path:    lib.Path(),
+		}
+		if lib.GitHubAccountName != "" {
+			m.Repo = lib.GitHubAccountName + "/" + lib.RepoName
+		}
+		if err := fs.WalkDir(os.DirFS(m.Path), ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() {
+				if p != "." && strings.HasPrefix(d.Name(), ".") {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if info, infoErr := d.Info(); infoErr == nil {
+				m.Files = append(m.Files, fmt.Sprintf("%s (%d bytes)", p, info.Size()))
+			}
+			return nil
+		}); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			errs.Log(err, "path", m.Path)
+		}
+		if home != "" {
+			m.Path = strings.ReplaceAll(m.Path, home, "~")
+		}
+		manifests = append(manifests, m)
+	}
+	return manifests
+}
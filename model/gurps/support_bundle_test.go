@@ -0,0 +1,45 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestAnonymizedSettings(t *testing.T) {
+	home := "/home/someone"
+	settings := &gurps.Settings{
+		General:     gurps.NewGeneralSettings(),
+		RecentFiles: []string{home + "/Characters/Bob.gcs"},
+		LastDirs:    map[string]string{gurps.DefaultLastDirKey: home + "/Characters"},
+		Session:     &gurps.Session{Documents: []*gurps.SessionDocument{{Path: home + "/Characters/Bob.gcs"}}},
+		WorkspaceLayouts: []*gurps.WorkspaceLayout{
+			{Name: "Prep", Root: &gurps.WorkspaceLayoutNode{Documents: []string{home + "/Characters/Bob.gcs"}}},
+		},
+	}
+	settings.General.DefaultPlayerName = "Someone"
+	settings.General.ExternalPDFCmdLine = home + "/bin/viewer $FILE"
+	data, err := gurps.AnonymizedSettings(settings, home)
+	check.NoError(t, err)
+	m, ok := data.(map[string]any)
+	check.True(t, ok)
+	check.Equal(t, "<redacted>", m["recent_files"])
+	check.Equal(t, "<redacted>", m["last_dirs"])
+	check.Equal(t, "<redacted>", m["session"])
+	check.Equal(t, "<redacted>", m["workspace_layouts"])
+	var general map[string]any
+	general, ok = m["general"].(map[string]any)
+	check.True(t, ok)
+	check.Equal(t, "<redacted>", general["default_player_name"])
+	check.Equal(t, "~/bin/viewer $FILE", general["external_pdf_cmd_line"])
+}
@@ -36,34 +36,35 @@ func SyncSheetsAndTemplates(paths ...string) error {
 	list := pathSet.Values()
 	txt.SortStringsNaturalAscending(list)
 	for _, p := range list {
-		fmt.Printf(i18n.Text("Processing %s\n"), p)
-		switch strings.ToLower(filepath.Ext(p)) {
-		case TemplatesExt:
-			var tmpl *Template
-			if tmpl, err = NewTemplateFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			tmpl.SyncWithLibrarySources()
-			tmpl.EnsureAttachments()
-			if err = tmpl.Save(p); err != nil {
-				return err
-			}
-		case SheetExt:
-			var entity *Entity
-			if entity, err = NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
-				return err
-			}
-			entity.SyncWithLibrarySources()
-			entity.Recalculate()
-			if err = entity.Save(p); err != nil {
-				return err
-			}
+		if err = syncSheetOrTemplate(p); err != nil {
+			CLI.Failed(p, err)
+			return err
 		}
+		CLI.File(p, CLIStatusProcessed, fmt.Sprintf(i18n.Text("Processed %s"), p))
 	}
-	if len(list) == 1 {
-		fmt.Println(i18n.Text("Processed 1 file"))
-	} else {
-		fmt.Printf(i18n.Text("Processed %d files\n"), len(list))
-	}
+	reportProcessedCount(len(list))
 	return nil
 }
+
+func syncSheetOrTemplate(p string) error {
+	switch strings.ToLower(filepath.Ext(p)) {
+	case TemplatesExt:
+		tmpl, err := NewTemplateFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p))
+		if err != nil {
+			return err
+		}
+		tmpl.SyncWithLibrarySources()
+		tmpl.EnsureAttachments()
+		return tmpl.Save(p)
+	case SheetExt:
+		entity, err := NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p))
+		if err != nil {
+			return err
+		}
+		entity.SyncWithLibrarySources()
+		entity.Recalculate()
+		return entity.Save(p)
+	default:
+		return nil
+	}
+}
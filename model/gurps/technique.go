@@ -0,0 +1,91 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/difficulty"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// TechniqueDefaults returns the defaults a technique may be based upon: its primary default followed by any
+// alternatives. For a combination, these are the component attacks.
+func (s *SkillNonContainerOnlySyncData) TechniqueDefaults() []*SkillDefault {
+	defs := make([]*SkillDefault, 0, 1+len(s.TechniqueAltDefaults))
+	if s.TechniqueDefault != nil {
+		defs = append(defs, s.TechniqueDefault)
+	}
+	return append(defs, s.TechniqueAltDefaults...)
+}
+
+// TechniqueDefaultsText returns a description of the defaults of a technique.
+func (s *Skill) TechniqueDefaultsText() string {
+	e := EntityFromNode(s)
+	defs := s.TechniqueDefaults()
+	parts := make([]string, 0, len(defs))
+	for _, def := range defs {
+		parts = append(parts, def.FullName(e, s.Replacements)+def.ModifierAsString())
+	}
+	if s.Combination {
+		return fmt.Sprintf(i18n.Text("%s (combination %s)"), strings.Join(parts, " + "),
+			CombinationPenalty(len(defs)).StringWithSign())
+	}
+	return strings.Join(parts, i18n.Text(" or "))
+}
+
+// CombinationPenalty returns the penalty applied to a Martial Arts combination of the given number of attacks: -6 for
+// two attacks and a further -2 for each attack beyond that.
+func CombinationPenalty(attacks int) fxp.Int {
+	return -fxp.From(6 + 2*(max(attacks, 2)-2))
+}
+
+// CalculateTechniqueLevelFromDefaults returns the calculated level for a technique that may default to more than one
+// skill. An ordinary technique uses the best of its defaults, while a combination uses the worst of them, adjusted by
+// the combination penalty. The returned relative level is relative to the primary default, so that adding the primary
+// default's modifier yields the level relative to the default actually used.
+func CalculateTechniqueLevelFromDefaults(e *Entity, replacements map[string]string, name, specialization string, tags []string, defs []*SkillDefault, combination bool, diffLevel difficulty.Level, points fxp.Int, requirePoints bool, limitModifier *fxp.Int, excludes map[string]bool) Level {
+	if len(defs) == 0 {
+		return Level{Level: fxp.Min}
+	}
+	var penalty fxp.Int
+	if combination {
+		penalty = CombinationPenalty(len(defs))
+	}
+	var best Level
+	for i, def := range defs {
+		adjusted := *def
+		adjusted.Modifier += penalty
+		level := CalculateTechniqueLevel(e, replacements, name, specialization, tags, &adjusted, diffLevel, points,
+			requirePoints, limitModifier, maps.Clone(excludes))
+		if level.Level != fxp.Min {
+			level.RelativeLevel += adjusted.Modifier - defs[0].Modifier
+		}
+		if i == 0 || (combination && level.Level < best.Level) || (!combination && level.Level > best.Level) {
+			best = level
+		}
+	}
+	return best
+}
+
+func cloneSkillDefaults(defs []*SkillDefault) []*SkillDefault {
+	if len(defs) == 0 {
+		return nil
+	}
+	clone := make([]*SkillDefault, len(defs))
+	for i, def := range defs {
+		def2 := *def
+		clone[i] = &def2
+	}
+	return clone
+}
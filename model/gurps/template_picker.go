@@ -62,6 +62,8 @@ func (t *TemplatePicker) Description() string {
 			points = i18n.Text("point")
 		}
 		return fmt.Sprintf(i18n.Text("Pick %s %s worth"), t.Qualifier.AltString(), points)
+	case picker.Optional:
+		return i18n.Text("Pick any")
 	default:
 		return ""
 	}
@@ -76,3 +78,30 @@ func (t *TemplatePicker) Hash(h hash.Hash) {
 	hashhelper.Num8(h, t.Type)
 	t.Qualifier.Hash(h)
 }
+
+// TemplatePickerPoints returns the points the child of a template picker counts for when the picker's type is
+// picker.Points.
+func TemplatePickerPoints(child any) fxp.Int {
+	switch nc := child.(type) {
+	case *Skill:
+		if nc.Container() && nc.TemplatePicker != nil && nc.TemplatePicker.Type == picker.Points &&
+			nc.TemplatePicker.Qualifier.Compare == criteria.EqualsNumber {
+			return nc.TemplatePicker.Qualifier.Qualifier
+		}
+		return nc.RawPoints()
+	case *Spell:
+		if nc.Container() && nc.TemplatePicker != nil && nc.TemplatePicker.Type == picker.Points &&
+			nc.TemplatePicker.Qualifier.Compare == criteria.EqualsNumber {
+			return nc.TemplatePicker.Qualifier.Qualifier
+		}
+		return nc.RawPoints()
+	case *Trait:
+		if nc.Container() && nc.TemplatePicker != nil && nc.TemplatePicker.Type == picker.Points &&
+			nc.TemplatePicker.Qualifier.Compare == criteria.EqualsNumber {
+			return nc.TemplatePicker.Qualifier.Qualifier
+		}
+		return nc.AdjustedPoints()
+	default:
+		return 0
+	}
+}
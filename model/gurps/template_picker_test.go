@@ -0,0 +1,80 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/criteria"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/picker"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestTemplatePickerDescription(t *testing.T) {
+	var tp *gurps.TemplatePicker
+	check.Equal(t, "", tp.Description())
+	tp = &gurps.TemplatePicker{Type: picker.Optional}
+	check.False(t, tp.ShouldOmit())
+	check.Equal(t, "Pick any", tp.Description())
+	tp.Type = picker.Count
+	tp.Qualifier = criteria.Number{NumberData: criteria.NumberData{Compare: criteria.AtLeastNumber, Qualifier: fxp.Two}}
+	check.Equal(t, "Pick at least 2", tp.Description())
+	check.Equal(t, picker.Optional, picker.ExtractType("optional"))
+}
+
+func TestEquipmentTemplatePicker(t *testing.T) {
+	kit := gurps.NewEquipment(nil, nil, true)
+	check.NotNil(t, kit.TemplatePickerData())
+	kit.TemplatePicker.Type = picker.Optional
+	clone := kit.Clone(gurps.LibraryFile{}, nil, nil, false)
+	check.Equal(t, picker.Optional, clone.TemplatePickerData().Type)
+	check.False(t, kit.TemplatePicker == clone.TemplatePicker, "picker must not be shared")
+	check.Nil(t, gurps.NewEquipment(nil, nil, false).TemplatePickerData())
+}
+
+type firstChoice struct{}
+
+func (firstChoice) Intn(_ int) int {
+	return 0
+}
+
+func TestGenerateNPC(t *testing.T) {
+	group := gurps.NewSkill(nil, nil, true)
+	group.TemplatePicker = &gurps.TemplatePicker{
+		Type: picker.Count,
+		Qualifier: criteria.Number{NumberData: criteria.NumberData{
+			Compare:   criteria.EqualsNumber,
+			Qualifier: fxp.One,
+		}},
+	}
+	for i, name := range []string{"Brawling", "Stealth"} {
+		skill := gurps.NewSkill(nil, group, false)
+		skill.Name = name
+		skill.Points = fxp.From(i + 1)
+		group.Children = append(group.Children, skill)
+	}
+	tmpl := gurps.NewTemplate()
+	tmpl.Skills = []*gurps.Skill{group}
+
+	generator := gurps.NPCGenerator{Budget: fxp.Ten, Templates: []*gurps.Template{tmpl}}
+	e := generator.Generate(firstChoice{})
+	check.Equal(t, 1, len(e.Skills), "one choice picked from the group")
+	check.Equal(t, "Stealth", e.Skills[0].Name)
+	check.Equal(t, fxp.Eight, e.Skills[0].Points, "leftover points raise the skill")
+	check.Equal(t, fxp.Two, e.UnspentPoints(), "not enough left for another level")
+	check.Equal(t, 2, len(group.Children), "the template is left untouched")
+
+	generator.Budget = fxp.One
+	e = generator.Generate(firstChoice{})
+	check.Equal(t, fxp.One, e.Skills[0].Points, "lowered to fit the budget")
+	check.Equal(t, fxp.Int(0), e.UnspentPoints())
+}
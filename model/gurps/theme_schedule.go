@@ -0,0 +1,97 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/unison/enums/thememode"
+)
+
+const minutesPerDay = 24 * 60
+
+// ThemeSchedule holds the times of day at which to switch between the light and dark color modes. Times are in minutes
+// after midnight.
+type ThemeSchedule struct {
+	Enabled    bool `json:"enabled,omitempty"`
+	LightStart int  `json:"light_start"`
+	DarkStart  int  `json:"dark_start"`
+}
+
+// DefaultThemeSchedule returns the default theme schedule, which switches to light mode at 7:00 and dark mode at 19:00.
+func DefaultThemeSchedule() *ThemeSchedule {
+	return &ThemeSchedule{
+		LightStart: 7 * 60,
+		DarkStart:  19 * 60,
+	}
+}
+
+// ModeAt returns the color mode the schedule calls for at the given time.
+func (s *ThemeSchedule) ModeAt(t time.Time) thememode.Enum {
+	minute := t.Hour()*60 + t.Minute()
+	light := s.LightStart % minutesPerDay
+	dark := s.DarkStart % minutesPerDay
+	switch {
+	case light == dark:
+		return thememode.Light
+	case light < dark:
+		if minute >= light && minute < dark {
+			return thememode.Light
+		}
+		return thememode.Dark
+	default:
+		if minute >= dark && minute < light {
+			return thememode.Dark
+		}
+		return thememode.Light
+	}
+}
+
+// ParseTimeOfDay parses a 24-hour time of day in the form "hh:mm", returning the number of minutes after midnight.
+func ParseTimeOfDay(text string) (minutes int, ok bool) {
+	hoursText, minutesText, found := strings.Cut(strings.TrimSpace(text), ":")
+	if !found {
+		return 0, false
+	}
+	hours, err := strconv.Atoi(strings.TrimSpace(hoursText))
+	if err != nil || hours < 0 || hours > 23 {
+		return 0, false
+	}
+	if minutes, err = strconv.Atoi(strings.TrimSpace(minutesText)); err != nil || minutes < 0 || minutes > 59 {
+		return 0, false
+	}
+	return hours*60 + minutes, true
+}
+
+// FormatTimeOfDay returns the number of minutes after midnight as a 24-hour time of day in the form "hh:mm".
+func FormatTimeOfDay(minutes int) string {
+	minutes %= minutesPerDay
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// ColorModeSchedule returns the theme schedule, creating the default one if needed.
+func (s *Settings) ColorModeSchedule() *ThemeSchedule {
+	if s.ThemeSchedule == nil {
+		s.ThemeSchedule = DefaultThemeSchedule()
+	}
+	return s.ThemeSchedule
+}
+
+// EffectiveThemeMode returns the color mode that should be in use at the given time, taking the theme schedule into
+// account.
+func (s *Settings) EffectiveThemeMode(t time.Time) thememode.Enum {
+	if s.ThemeSchedule != nil && s.ThemeSchedule.Enabled {
+		return s.ThemeSchedule.ModeAt(t)
+	}
+	return s.ThemeMode
+}
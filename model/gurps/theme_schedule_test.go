@@ -0,0 +1,57 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/unison/enums/thememode"
+)
+
+func TestThemeSchedule(t *testing.T) {
+	at := func(hour, minute int) time.Time { return time.Date(2024, 6, 1, hour, minute, 0, 0, time.Local) }
+	s := gurps.DefaultThemeSchedule()
+	check.Equal(t, thememode.Dark, s.ModeAt(at(6, 59)))
+	check.Equal(t, thememode.Light, s.ModeAt(at(7, 0)))
+	check.Equal(t, thememode.Light, s.ModeAt(at(18, 59)))
+	check.Equal(t, thememode.Dark, s.ModeAt(at(19, 0)))
+	check.Equal(t, thememode.Dark, s.ModeAt(at(0, 0)))
+
+	// Dark during the day, for night shift workers
+	s.LightStart = 20 * 60
+	s.DarkStart = 8 * 60
+	check.Equal(t, thememode.Light, s.ModeAt(at(3, 0)))
+	check.Equal(t, thememode.Dark, s.ModeAt(at(12, 0)))
+	check.Equal(t, thememode.Light, s.ModeAt(at(20, 0)))
+
+	var settings gurps.Settings
+	settings.ThemeMode = thememode.Auto
+	check.Equal(t, thememode.Auto, settings.EffectiveThemeMode(at(12, 0)))
+	settings.ColorModeSchedule().Enabled = true
+	check.Equal(t, thememode.Light, settings.EffectiveThemeMode(at(12, 0)))
+}
+
+func TestTimeOfDay(t *testing.T) {
+	minutes, ok := gurps.ParseTimeOfDay("7:05")
+	check.True(t, ok)
+	check.Equal(t, 7*60+5, minutes)
+	minutes, ok = gurps.ParseTimeOfDay(" 23:59 ")
+	check.True(t, ok)
+	check.Equal(t, 23*60+59, minutes)
+	for _, bad := range []string{"", "7", "24:00", "12:60", "a:b", "-1:00"} {
+		_, ok = gurps.ParseTimeOfDay(bad)
+		check.False(t, ok, bad)
+	}
+	check.Equal(t, "07:05", gurps.FormatTimeOfDay(7*60+5))
+	check.Equal(t, "00:00", gurps.FormatTimeOfDay(24*60), "a full day wraps around")
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/study"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/timeuse"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// Time-use constants.
+var (
+	HoursPerWeek  = fxp.From(168)
+	DaysPerWeek   = fxp.From(7)
+	WeeksPerMonth = fxp.From(52).Div(fxp.Twelve)
+)
+
+// TimeUseEntry holds one activity in a character's time-use plan for downtime (B569).
+type TimeUseEntry struct {
+	Kind        timeuse.Kind   `json:"kind"`
+	Name        string         `json:"name,omitempty"`
+	Hours       fxp.Int        `json:"hours"`
+	Period      timeuse.Period `json:"period,omitempty"`
+	MonthlyPay  fxp.Int        `json:"monthly_pay,omitempty"`  // Only valid for timeuse.Job
+	StudyType   study.Type     `json:"study_type,omitempty"`   // Only valid for timeuse.Study
+	StudyTarget tid.TID        `json:"study_target,omitempty"` // Only valid for timeuse.Study
+}
+
+// Clone creates a copy of the TimeUseEntry.
+func (t *TimeUseEntry) Clone() *TimeUseEntry {
+	clone := *t
+	return &clone
+}
+
+// HoursPerWeek returns the number of hours per week spent on this activity.
+func (t *TimeUseEntry) HoursPerWeek() fxp.Int {
+	if t.Period == timeuse.PerDay {
+		return t.Hours.Mul(DaysPerWeek)
+	}
+	return t.Hours
+}
+
+func (t *TimeUseEntry) String() string {
+	if t.Name != "" {
+		return t.Name
+	}
+	return t.Kind.String()
+}
+
+// TimeUsePlan holds the activities a character spends their downtime on.
+type TimeUsePlan []*TimeUseEntry
+
+// Clone creates a copy of the TimeUsePlan.
+func (p TimeUsePlan) Clone() TimeUsePlan {
+	if len(p) == 0 {
+		return nil
+	}
+	clone := make(TimeUsePlan, len(p))
+	for i, one := range p {
+		clone[i] = one.Clone()
+	}
+	return clone
+}
+
+// HoursPerWeek returns the number of hours per week allocated by the plan.
+func (p TimeUsePlan) HoursPerWeek() fxp.Int {
+	var total fxp.Int
+	for _, one := range p {
+		total += one.HoursPerWeek()
+	}
+	return total
+}
+
+// MonthlyIncome returns the monthly income from the jobs in the plan.
+func (p TimeUsePlan) MonthlyIncome() fxp.Int {
+	var total fxp.Int
+	for _, one := range p {
+		if one.Kind == timeuse.Job {
+			total += one.MonthlyPay
+		}
+	}
+	return total
+}
+
+// StudyTarget identifies a trait, skill or spell that study time can be recorded against.
+type StudyTarget struct {
+	ID    tid.TID
+	Name  string
+	study *[]*Study
+}
+
+func (s *StudyTarget) String() string {
+	return s.Name
+}
+
+// Study returns the study records of the target.
+func (s *StudyTarget) Study() []*Study {
+	return *s.study
+}
+
+// SetStudy replaces the study records of the target.
+func (s *StudyTarget) SetStudy(records []*Study) {
+	*s.study = records
+}
+
+// StudyTargets returns the traits, skills and spells of the entity that study time can be recorded against.
+func (e *Entity) StudyTargets() []*StudyTarget {
+	var list []*StudyTarget
+	Traverse(func(t *Trait) bool {
+		list = append(list, &StudyTarget{ID: t.TID, Name: t.String(), study: &t.Study})
+		return false
+	}, false, true, e.Traits...)
+	Traverse(func(s *Skill) bool {
+		list = append(list, &StudyTarget{ID: s.TID, Name: s.String(), study: &s.Study})
+		return false
+	}, false, true, e.Skills...)
+	Traverse(func(s *Spell) bool {
+		list = append(list, &StudyTarget{ID: s.TID, Name: s.String(), study: &s.Study})
+		return false
+	}, false, true, e.Spells...)
+	return list
+}
+
+// StudyTargetByID returns the study target with the given ID, or nil.
+func (e *Entity) StudyTargetByID(id tid.TID) *StudyTarget {
+	for _, one := range e.StudyTargets() {
+		if one.ID == id {
+			return one
+		}
+	}
+	return nil
+}
+
+// Downtime holds the outcome of spending a number of weeks following the entity's time-use plan.
+type Downtime struct {
+	Weeks        int
+	Income       fxp.Int
+	CostOfLiving fxp.Int
+	StudyHours   fxp.Int
+}
+
+// Net returns the net change in funds.
+func (d Downtime) Net() fxp.Int {
+	return d.Income - d.CostOfLiving
+}
+
+// PlanDowntime returns the outcome of the entity spending the given number of weeks following the plan, without
+// applying it. Job income and cost of living are prorated from their monthly amounts.
+func (e *Entity) PlanDowntime(plan TimeUsePlan, weeks int) Downtime {
+	d := Downtime{Weeks: max(weeks, 0)}
+	months := fxp.From(d.Weeks).Div(WeeksPerMonth)
+	d.Income = plan.MonthlyIncome().Mul(months)
+	d.CostOfLiving = e.CostOfLiving().Mul(months)
+	for _, one := range plan {
+		if one.Kind == timeuse.Study && e.StudyTargetByID(one.StudyTarget) != nil {
+			d.StudyHours += one.HoursPerWeek().Mul(fxp.From(d.Weeks))
+		}
+	}
+	return d
+}
+
+// ApplyDowntime spends the given number of weeks following the entity's time-use plan: study time is recorded against
+// the study targets and the net of job income and cost of living is added to the funds.
+func (e *Entity) ApplyDowntime(weeks int) Downtime {
+	d := e.PlanDowntime(e.TimeUse, weeks)
+	if d.Weeks == 0 {
+		return d
+	}
+	for _, one := range e.TimeUse {
+		if one.Kind != timeuse.Study {
+			continue
+		}
+		if target := e.StudyTargetByID(one.StudyTarget); target != nil {
+			target.SetStudy(append(target.Study(), &Study{
+				Type:  one.StudyType,
+				Hours: one.HoursPerWeek().Mul(fxp.From(d.Weeks)),
+				Note:  fmt.Sprintf(i18n.Text("Downtime: %s (%d weeks)"), one.String(), d.Weeks),
+			}))
+		}
+	}
+	e.Funds += d.Net()
+	return d
+}
@@ -0,0 +1,46 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/study"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/timeuse"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestTimeUse(t *testing.T) {
+	e := gurps.NewEntity()
+	skill := gurps.NewSkill(e, nil, false)
+	skill.Name = "Blacksmith"
+	e.SetSkillList([]*gurps.Skill{skill})
+	e.Funds = fxp.Thousand
+	e.TimeUse = gurps.TimeUsePlan{
+		{Kind: timeuse.Job, Hours: fxp.Eight, Period: timeuse.PerDay, MonthlyPay: fxp.From(1300)},
+		{Kind: timeuse.Study, Hours: fxp.Ten, Period: timeuse.PerWeek, StudyType: study.Teacher, StudyTarget: skill.TID},
+		{Kind: timeuse.Sleep, Hours: fxp.Eight, Period: timeuse.PerDay},
+	}
+	check.Equal(t, fxp.From(122), e.TimeUse.HoursPerWeek())
+	check.Equal(t, fxp.From(1300), e.TimeUse.MonthlyIncome())
+
+	plan := e.PlanDowntime(e.TimeUse, 52)
+	check.Equal(t, fxp.From(15600), plan.Income, "a year of job income")
+	check.Equal(t, e.CostOfLiving().Mul(fxp.Twelve), plan.CostOfLiving, "a year of cost of living")
+	check.Equal(t, fxp.From(520), plan.StudyHours)
+	check.Equal(t, fxp.Thousand, e.Funds, "planning changes nothing")
+
+	result := e.ApplyDowntime(52)
+	check.Equal(t, fxp.Thousand+result.Net(), e.Funds)
+	check.Equal(t, 1, len(skill.Study))
+	check.Equal(t, fxp.From(520), gurps.ResolveStudyHours(skill.Study))
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio/fs"
+)
+
+// Touch loads the GCS files found in the given paths, migrating them to the current file format, recalculating them
+// and writing them back out in canonical form. Directories are traversed recursively. Each file is reported as either
+// updated or unchanged.
+func Touch(paths ...string) error {
+	var err error
+	paths, err = fs.UniquePaths(paths...)
+	if err != nil {
+		return err
+	}
+	list := collectConvertiblePaths(paths)
+	updated := 0
+	for _, p := range list {
+		var changed bool
+		if changed, err = touchFile(p); err != nil {
+			CLI.Failed(p, err)
+			return err
+		}
+		if changed {
+			updated++
+			CLI.File(p, CLIStatusUpdated, fmt.Sprintf(i18n.Text("Updated %s"), p))
+		} else {
+			CLI.File(p, CLIStatusUnchanged, fmt.Sprintf(i18n.Text("Unchanged %s"), p))
+		}
+	}
+	if len(list) == 1 {
+		CLI.Text(fmt.Sprintf(i18n.Text("Processed 1 file, %d updated"), updated))
+	} else {
+		CLI.Text(fmt.Sprintf(i18n.Text("Processed %d files, %d updated"), len(list), updated))
+	}
+	return nil
+}
+
+func touchFile(p string) (changed bool, err error) {
+	var before, after []byte
+	if before, err = os.ReadFile(p); err != nil {
+		return false, errs.NewWithCause(p, err)
+	}
+	if err = convertFile(p); err != nil {
+		return false, err
+	}
+	if after, err = os.ReadFile(p); err != nil {
+		return false, errs.NewWithCause(p, err)
+	}
+	return !bytes.Equal(before, after), nil
+}
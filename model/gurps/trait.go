@@ -11,6 +11,7 @@ package gurps
 
 import (
 	"context"
+	"fmt"
 	"hash"
 	"io/fs"
 	"maps"
@@ -88,9 +89,10 @@ type TraitEditData struct {
 // TraitNonContainerOnlyEditData holds the Trait data that is only applicable to traits that aren't containers.
 type TraitNonContainerOnlyEditData struct {
 	TraitNonContainerSyncData
-	Levels           fxp.Int     `json:"levels,omitempty"`
-	Study            []*Study    `json:"study,omitempty"`
-	StudyHoursNeeded study.Level `json:"study_hours_needed,omitempty"`
+	Levels           fxp.Int          `json:"levels,omitempty"`
+	Study            []*Study         `json:"study,omitempty"`
+	StudyHoursNeeded study.Level      `json:"study_hours_needed,omitempty"`
+	LinkedCharacter  *LinkedCharacter `json:"linked_character,omitempty"`
 }
 
 // TraitSyncData holds the Trait sync data that is common to both containers and non-containers.
@@ -99,6 +101,7 @@ type TraitSyncData struct {
 	PageRef          string              `json:"reference,omitempty"`
 	PageRefHighlight string              `json:"reference_highlight,omitempty"`
 	LocalNotes       string              `json:"notes,omitempty"`
+	Translations     Translations        `json:"translations,omitempty"`
 	Tags             []string            `json:"tags,omitempty"`
 	Prereq           *PrereqList         `json:"prereqs,omitempty"`
 	CRAdj            selfctrl.Adjustment `json:"cr_adj,omitempty"`
@@ -379,6 +382,10 @@ func (t *Trait) CellData(columnID int, data *CellData) {
 		data.Type = cell.Text
 		data.Primary = t.AdjustedPoints().String()
 		data.Alignment = align.End
+		if pts, ok := t.AlternativeAbilityPoints(); ok && pts != t.AdjustedPoints() {
+			data.Tooltip = fmt.Sprintf(i18n.Text("As an alternative ability, this costs %s points, 1/5 of its full cost"),
+				pts.String())
+		}
 	case TraitTagsColumn:
 		data.Type = cell.Tags
 		data.Primary = CombineTags(t.Tags)
@@ -462,21 +469,8 @@ func (t *Trait) AdjustedPoints() fxp.Int {
 	}
 	var points fxp.Int
 	if t.ContainerType == container.AlternativeAbilities {
-		values := make([]fxp.Int, len(t.Children))
-		for i, one := range t.Children {
-			values[i] = one.AdjustedPoints()
-			if values[i] > points {
-				points = values[i]
-			}
-		}
-		maximum := points
-		found := false
-		for _, v := range values {
-			if !found && maximum == v {
-				found = true
-			} else {
-				points += fxp.ApplyRounding(calculateModifierPoints(v, fxp.Twenty), t.RoundCostDown)
-			}
+		for _, v := range t.alternativeAbilityPoints() {
+			points += v
 		}
 	} else {
 		for _, one := range t.Children {
@@ -486,6 +480,43 @@ func (t *Trait) AdjustedPoints() fxp.Int {
 	return points
 }
 
+// alternativeAbilityPoints returns the points each child of an Alternative Abilities container contributes to it: the
+// most expensive child is paid for in full, while the rest cost 1/5 of their adjusted points.
+func (t *Trait) alternativeAbilityPoints() []fxp.Int {
+	values := make([]fxp.Int, len(t.Children))
+	var maximum fxp.Int
+	for i, one := range t.Children {
+		values[i] = one.AdjustedPoints()
+		if values[i] > maximum {
+			maximum = values[i]
+		}
+	}
+	found := false
+	for i, v := range values {
+		if !found && maximum == v {
+			found = true
+		} else {
+			values[i] = fxp.ApplyRounding(calculateModifierPoints(v, fxp.Twenty), t.RoundCostDown)
+		}
+	}
+	return values
+}
+
+// AlternativeAbilityPoints returns the points this trait contributes to its Alternative Abilities container and true,
+// or its adjusted points and false if its parent is not an Alternative Abilities container.
+func (t *Trait) AlternativeAbilityPoints() (fxp.Int, bool) {
+	if t.parent == nil || !t.parent.Container() || t.parent.ContainerType != container.AlternativeAbilities {
+		return t.AdjustedPoints(), false
+	}
+	values := t.parent.alternativeAbilityPoints()
+	for i, one := range t.parent.Children {
+		if one == t {
+			return values[i], true
+		}
+	}
+	return t.AdjustedPoints(), false
+}
+
 // AllModifiers returns the modifiers plus any inherited from parents.
 func (t *Trait) AllModifiers() []*TraitModifier {
 	all := make([]*TraitModifier, len(t.Modifiers))
@@ -518,9 +549,16 @@ func (t *Trait) NameWithReplacements() string {
 	return nameable.Apply(t.Name, t.Replacements)
 }
 
-// LocalNotesWithReplacements returns the local notes with any replacements applied.
+// LocalizedNameWithReplacements returns the name in the user's language, if a translation is available, with any
+// replacements applied. Use NameWithReplacements() when matching against the name.
+func (t *Trait) LocalizedNameWithReplacements() string {
+	return nameable.Apply(t.Translations.Name(t.Name), t.Replacements)
+}
+
+// LocalNotesWithReplacements returns the local notes, in the user's language if a translation is available, with any
+// replacements applied.
 func (t *Trait) LocalNotesWithReplacements() string {
-	return nameable.Apply(t.LocalNotes, t.Replacements)
+	return nameable.Apply(t.Translations.Notes(t.LocalNotes), t.Replacements)
 }
 
 // UserDescWithReplacements returns the user description with any replacements applied.
@@ -530,7 +568,7 @@ func (t *Trait) UserDescWithReplacements() string {
 
 // Description returns a description, which doesn't include any levels.
 func (t *Trait) Description() string {
-	return t.NameWithReplacements()
+	return t.LocalizedNameWithReplacements()
 }
 
 // String implements fmt.Stringer.
@@ -661,6 +699,7 @@ func (t *Trait) SecondaryText(optionChecker func(display.Option) bool) string {
 	if optionChecker(settings.NotesDisplay) {
 		AppendStringOntoNewLine(&buffer, strings.TrimSpace(t.Notes()))
 		AppendStringOntoNewLine(&buffer, StudyHoursProgressText(ResolveStudyHours(t.Study), t.StudyHoursNeeded, false))
+		AppendStringOntoNewLine(&buffer, t.LinkedCharacter.Summary(EntityFromNode(t)))
 	}
 	return buffer.String()
 }
@@ -849,6 +888,7 @@ func (t *TraitSyncData) hash(h hash.Hash) {
 	hashhelper.String(h, t.PageRef)
 	hashhelper.String(h, t.PageRefHighlight)
 	hashhelper.String(h, t.LocalNotes)
+	t.Translations.hash(h)
 	hashhelper.Num64(h, len(t.Tags))
 	for _, tag := range t.Tags {
 		hashhelper.String(h, tag)
@@ -892,6 +932,7 @@ func (t *TraitEditData) copyFrom(owner DataOwner, other *TraitEditData, isApply
 	*t = *other
 	t.Tags = txt.CloneStringSlice(other.Tags)
 	t.Replacements = maps.Clone(other.Replacements)
+	t.Translations = maps.Clone(other.Translations)
 	t.Modifiers = nil
 	if len(other.Modifiers) != 0 {
 		t.Modifiers = make([]*TraitModifier, 0, len(other.Modifiers))
@@ -909,4 +950,5 @@ func (t *TraitEditData) copyFrom(owner DataOwner, other *TraitEditData, isApply
 		}
 	}
 	t.TemplatePicker = t.TemplatePicker.Clone()
+	t.LinkedCharacter = t.LinkedCharacter.Clone()
 }
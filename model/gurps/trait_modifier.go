@@ -18,6 +18,7 @@ import (
 	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/activation"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/affects"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/cell"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/display"
@@ -97,11 +98,12 @@ type TraitModifierEditDataNonContainerOnly struct {
 
 // TraitModifierSyncData holds the TraitModifier sync data that is common to both containers and non-containers.
 type TraitModifierSyncData struct {
-	Name             string   `json:"name,omitempty"`
-	PageRef          string   `json:"reference,omitempty"`
-	PageRefHighlight string   `json:"reference_highlight,omitempty"`
-	LocalNotes       string   `json:"notes,omitempty"`
-	Tags             []string `json:"tags,omitempty"`
+	Name             string       `json:"name,omitempty"`
+	PageRef          string       `json:"reference,omitempty"`
+	PageRefHighlight string       `json:"reference_highlight,omitempty"`
+	LocalNotes       string       `json:"notes,omitempty"`
+	Translations     Translations `json:"translations,omitempty"`
+	Tags             []string     `json:"tags,omitempty"`
 }
 
 // TraitModifierNonContainerSyncData holds the TraitModifier sync data that is only applicable to TraitModifiers that
@@ -112,6 +114,10 @@ type TraitModifierNonContainerSyncData struct {
 	UseLevelFromTrait bool           `json:"use_level_from_trait,omitempty"`
 	Affects           affects.Option `json:"affects,omitempty"`
 	Features          Features       `json:"features,omitempty"`
+	// Activation controls whether the features are active based on the equipped state of the carried equipment with
+	// the ActivationTag
+	Activation    activation.Condition `json:"activation,omitempty"`
+	ActivationTag string               `json:"activation_tag,omitempty"`
 }
 
 type traitModifierListData struct {
@@ -300,7 +306,7 @@ func (t *TraitModifier) CellData(columnID int, data *CellData) {
 		}
 	case TraitModifierDescriptionColumn:
 		data.Type = cell.Text
-		data.Primary = t.NameWithReplacements()
+		data.Primary = t.LocalizedNameWithReplacements()
 		data.Secondary = t.SecondaryText(func(option display.Option) bool { return option.Inline() })
 		data.Tooltip = t.SecondaryText(func(option display.Option) bool { return option.Tooltip() })
 	case TraitModifierCostColumn:
@@ -417,7 +423,7 @@ func (t *TraitModifier) CurrentLevel() fxp.Int {
 
 func (t *TraitModifier) String() string {
 	var buffer strings.Builder
-	buffer.WriteString(t.NameWithReplacements())
+	buffer.WriteString(t.LocalizedNameWithReplacements())
 	if t.IsLeveled() {
 		buffer.WriteByte(' ')
 		buffer.WriteString(t.CurrentLevel().String())
@@ -483,9 +489,16 @@ func (t *TraitModifier) NameWithReplacements() string {
 	return nameable.Apply(t.Name, t.Replacements)
 }
 
-// LocalNotesWithReplacements returns the local notes with any replacements applied.
+// LocalizedNameWithReplacements returns the name in the user's language, if a translation is available, with any
+// replacements applied. Use NameWithReplacements() when matching against the name.
+func (t *TraitModifier) LocalizedNameWithReplacements() string {
+	return nameable.Apply(t.Translations.Name(t.Name), t.Replacements)
+}
+
+// LocalNotesWithReplacements returns the local notes, in the user's language if a translation is available, with any
+// replacements applied.
 func (t *TraitModifier) LocalNotesWithReplacements() string {
-	return nameable.Apply(t.LocalNotes, t.Replacements)
+	return nameable.Apply(t.Translations.Notes(t.LocalNotes), t.Replacements)
 }
 
 // NameableReplacements returns the replacements to be used with Nameables.
@@ -530,6 +543,15 @@ func (t *TraitModifier) SetEnabled(enabled bool) {
 	}
 }
 
+// FeaturesActive returns true if this modifier's features should be applied, given the equipped state of the entity's
+// carried equipment.
+func (t *TraitModifier) FeaturesActive(entity *Entity) bool {
+	if t.Activation == activation.Always || entity == nil {
+		return true
+	}
+	return entity.HasEquippedWithTag(t.ActivationTag) == (t.Activation == activation.WhileEquipped)
+}
+
 // Kind returns the kind of data.
 func (t *TraitModifier) Kind() string {
 	if t.Container() {
@@ -589,6 +611,7 @@ func (t *TraitModifierSyncData) hash(h hash.Hash) {
 	hashhelper.String(h, t.PageRef)
 	hashhelper.String(h, t.PageRefHighlight)
 	hashhelper.String(h, t.LocalNotes)
+	t.Translations.hash(h)
 	hashhelper.Num64(h, len(t.Tags))
 	for _, tag := range t.Tags {
 		hashhelper.String(h, tag)
@@ -604,6 +627,8 @@ func (t *TraitModifierNonContainerSyncData) hash(h hash.Hash) {
 	for _, feature := range t.Features {
 		feature.Hash(h)
 	}
+	hashhelper.Num8(h, t.Activation)
+	hashhelper.String(h, t.ActivationTag)
 }
 
 // CopyFrom implements node.EditorData.
@@ -620,5 +645,6 @@ func (t *TraitModifierEditData) copyFrom(other *TraitModifierEditData) {
 	*t = *other
 	t.Tags = txt.CloneStringSlice(other.Tags)
 	t.Replacements = maps.Clone(other.Replacements)
+	t.Translations = maps.Clone(other.Translations)
 	t.Features = other.Features.Clone()
 }
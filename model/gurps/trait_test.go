@@ -0,0 +1,120 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestAlternativeAbilities(t *testing.T) {
+	e := gurps.NewEntity()
+	framework := gurps.NewTrait(e, nil, true)
+	framework.ContainerType = container.AlternativeAbilities
+	blast := gurps.NewTrait(e, framework, false)
+	blast.BasePoints = fxp.Twenty
+	stun := gurps.NewTrait(e, framework, false)
+	stun.BasePoints = fxp.Ten
+	wall := gurps.NewTrait(e, framework, false)
+	wall.BasePoints = fxp.Fifteen
+	framework.Children = []*gurps.Trait{stun, blast, wall}
+	e.SetTraitList([]*gurps.Trait{framework})
+
+	check.Equal(t, fxp.From(25), framework.AdjustedPoints(), "most expensive at full cost, the rest at 1/5")
+	pts, ok := blast.AlternativeAbilityPoints()
+	check.True(t, ok)
+	check.Equal(t, fxp.Twenty, pts)
+	pts, ok = stun.AlternativeAbilityPoints()
+	check.True(t, ok)
+	check.Equal(t, fxp.Two, pts)
+	check.Equal(t, fxp.Ten, stun.AdjustedPoints(), "full cost is unchanged")
+
+	framework.ContainerType = container.Group
+	check.Equal(t, fxp.From(45), framework.AdjustedPoints())
+	_, ok = stun.AlternativeAbilityPoints()
+	check.False(t, ok)
+}
+
+func TestMultiplicativeModifiers(t *testing.T) {
+	e := gurps.NewEntity()
+	trait := gurps.NewTrait(e, nil, false)
+	trait.BasePoints = fxp.Ten
+	enhancement := gurps.NewTraitModifier(e, nil, false)
+	enhancement.Cost = fxp.From(50)
+	limitation := gurps.NewTraitModifier(e, nil, false)
+	limitation.Cost = -fxp.From(50)
+	trait.Modifiers = []*gurps.TraitModifier{enhancement, limitation}
+	e.SetTraitList([]*gurps.Trait{trait})
+
+	check.Equal(t, fxp.Ten, trait.AdjustedPoints(), "modifiers are summed by default")
+	e.SheetSettings.UseMultiplicativeModifiers = true
+	check.Equal(t, fxp.Eight, trait.AdjustedPoints(), "enhancements and limitations applied in turn, rounded up")
+
+	limitation.Cost = -fxp.From(90)
+	check.Equal(t, fxp.Three, trait.AdjustedPoints(), "limitations are capped at -80%")
+}
+
+func TestLinkedCharacter(t *testing.T) {
+	e := gurps.NewEntity()
+	e.TotalPoints = fxp.Hundred
+	ally := gurps.NewTrait(e, nil, false)
+	ally.LinkedCharacter = &gurps.LinkedCharacter{Path: "ally.gcs"}
+	e.SetTraitList([]*gurps.Trait{ally})
+
+	linked := gurps.NewEntity()
+	linked.Profile.Name = "Sidekick"
+	linked.TotalPoints = fxp.Fifty
+	check.True(t, ally.SyncLinkedCharacter(linked))
+	check.Equal(t, "Sidekick", ally.LinkedCharacter.Name)
+	check.Equal(t, fxp.Two, ally.BasePoints, "50% of the character's points")
+	check.False(t, ally.SyncLinkedCharacter(linked), "nothing further changed")
+
+	linked.TotalPoints = fxp.From(150)
+	check.True(t, ally.SyncLinkedCharacter(linked))
+	check.Equal(t, fxp.Ten, ally.BasePoints)
+
+	ally.LinkedCharacter.Dependent = true
+	linked.TotalPoints = fxp.Twenty
+	check.True(t, ally.SyncLinkedCharacter(linked))
+	check.Equal(t, -fxp.Five, ally.BasePoints)
+	linked.TotalPoints = 0
+	check.True(t, ally.SyncLinkedCharacter(linked))
+	check.Equal(t, -fxp.Ten, ally.BasePoints)
+
+	clone := ally.Clone(gurps.LibraryFile{}, e, nil, false)
+	check.Equal(t, *ally.LinkedCharacter, *clone.LinkedCharacter)
+	check.True(t, ally.LinkedCharacter != clone.LinkedCharacter, "clone has its own copy")
+}
+
+func TestLeveledFeatureFormula(t *testing.T) {
+	amt := gurps.LeveledAmount{Amount: fxp.Two, PerLevel: true, Level: fxp.Three}
+	check.Equal(t, fxp.From(6), amt.AdjustedAmount())
+	amt.LevelFormula = "2*$level-1"
+	check.Equal(t, fxp.Five, amt.AdjustedAmount(), "formula replaces amount per level")
+	check.Equal(t, "+5 (2*$level-1)", amt.Format(false))
+	amt.PerLevel = false
+	check.Equal(t, fxp.Two, amt.AdjustedAmount(), "formula only applies per level")
+
+	e := gurps.NewEntity()
+	trait := gurps.NewTrait(e, nil, false)
+	trait.CanLevel = true
+	trait.Levels = fxp.Three
+	bonus := gurps.NewAttributeBonus(gurps.DexterityID)
+	bonus.PerLevel = true
+	bonus.LevelFormula = "if($level>2, $level+2, $level)"
+	trait.Features = gurps.Features{bonus}
+	e.SetTraitList([]*gurps.Trait{trait})
+	e.Recalculate()
+	check.Equal(t, fxp.From(15), e.ResolveAttributeCurrent(gurps.DexterityID))
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"hash"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/hashhelper"
+)
+
+// Translation holds the localized text for a row. Empty fields fall back to the original text.
+type Translation struct {
+	Name  string `json:"name,omitempty"`
+	Notes string `json:"notes,omitempty"`
+}
+
+// Translations holds the translations for a row, keyed by locale, e.g. "de" or "pt_br".
+type Translations map[string]Translation
+
+// Name returns the translation of the name for the user's language, or the original if there isn't one.
+func (t Translations) Name(original string) string {
+	if tr, ok := t.Lookup(i18n.Language); ok && tr.Name != "" {
+		return tr.Name
+	}
+	return original
+}
+
+// Notes returns the translation of the notes for the user's language, or the original if there isn't one.
+func (t Translations) Notes(original string) string {
+	if tr, ok := t.Lookup(i18n.Language); ok && tr.Notes != "" {
+		return tr.Notes
+	}
+	return original
+}
+
+// Lookup returns the translation for the locale. If there is no exact match, progressively less specific forms of the
+// locale are tried, so that "de_AT.UTF-8" will find a translation keyed as "de".
+func (t Translations) Lookup(locale string) (Translation, bool) {
+	if len(t) == 0 {
+		return Translation{}, false
+	}
+	key := NormalizeLocale(locale)
+	for key != "" {
+		if tr, ok := t[key]; ok {
+			return tr, true
+		}
+		i := strings.LastIndexByte(key, '_')
+		if i == -1 {
+			break
+		}
+		key = key[:i]
+	}
+	return Translation{}, false
+}
+
+// NormalizeLocale returns the locale in the form used for translation keys: lowercase, with "_" separating the parts
+// and any character set suffix removed.
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, ".@"); i != -1 {
+		locale = locale[:i]
+	}
+	return strings.ReplaceAll(locale, "-", "_")
+}
+
+func (t Translations) hash(h hash.Hash) {
+	keys := make([]string, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	hashhelper.Num64(h, len(keys))
+	for _, k := range keys {
+		tr := t[k]
+		hashhelper.String(h, k)
+		hashhelper.String(h, tr.Name)
+		hashhelper.String(h, tr.Notes)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+func TestTranslations(t *testing.T) {
+	translations := gurps.Translations{
+		"de":    {Name: "Zähigkeit", Notes: "Schadensreduktion"},
+		"pt_br": {Name: "Robustez"},
+	}
+	tr, ok := translations.Lookup("de_AT.UTF-8")
+	check.True(t, ok)
+	check.Equal(t, "Zähigkeit", tr.Name)
+	tr, ok = translations.Lookup("pt-BR")
+	check.True(t, ok)
+	check.Equal(t, "Robustez", tr.Name)
+	_, ok = translations.Lookup("pt")
+	check.False(t, ok)
+	_, ok = gurps.Translations(nil).Lookup("de")
+	check.False(t, ok)
+
+	saved := i18n.Language
+	defer func() { i18n.Language = saved }()
+	i18n.Language = "pt_BR"
+	check.Equal(t, "Robustez", translations.Name("Damage Resistance"))
+	check.Equal(t, "Reduces damage", translations.Notes("Reduces damage"))
+	i18n.Language = "fr_FR"
+	check.Equal(t, "Damage Resistance", translations.Name("Damage Resistance"))
+}
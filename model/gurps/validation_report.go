@@ -0,0 +1,87 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// ValidationIssue holds a single problem found while validating a character.
+type ValidationIssue struct {
+	Kind   string
+	Name   string
+	Reason string
+}
+
+// ValidationReport holds the problems found while validating a character.
+type ValidationReport struct {
+	Issues []*ValidationIssue
+}
+
+// NewValidationReport recalculates the entity and then collects any rows that have been flagged as not meeting their
+// requirements.
+func NewValidationReport(entity *Entity) *ValidationReport {
+	entity.Recalculate()
+	var r ValidationReport
+	Traverse(func(t *Trait) bool {
+		r.add(i18n.Text("Trait"), t.String(), t.UnsatisfiedReason)
+		return false
+	}, true, false, entity.Traits...)
+	Traverse(func(s *Skill) bool {
+		kind := i18n.Text("Skill")
+		if s.IsTechnique() {
+			kind = i18n.Text("Technique")
+		}
+		r.add(kind, s.String(), s.UnsatisfiedReason)
+		return false
+	}, true, true, entity.Skills...)
+	Traverse(func(s *Spell) bool {
+		r.add(i18n.Text("Spell"), s.String(), s.UnsatisfiedReason)
+		return false
+	}, true, true, entity.Spells...)
+	Traverse(func(e *Equipment) bool {
+		r.add(i18n.Text("Equipment"), e.String(), e.UnsatisfiedReason)
+		return false
+	}, false, false, entity.CarriedEquipment...)
+	return &r
+}
+
+func (r *ValidationReport) add(kind, name, reason string) {
+	if reason != "" {
+		r.Issues = append(r.Issues, &ValidationIssue{
+			Kind:   kind,
+			Name:   name,
+			Reason: reason,
+		})
+	}
+}
+
+// Valid returns true if no issues were found.
+func (r *ValidationReport) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+// String returns a plain text version of the report.
+func (r *ValidationReport) String() string {
+	if r.Valid() {
+		return i18n.Text("No issues were found.")
+	}
+	var buffer strings.Builder
+	for i, issue := range r.Issues {
+		if i != 0 {
+			buffer.WriteString("\n\n")
+		}
+		fmt.Fprintf(&buffer, "%s: %s\n%s", issue.Kind, issue.Name, strings.TrimSpace(issue.Reason))
+	}
+	return buffer.String()
+}
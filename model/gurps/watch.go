@@ -0,0 +1,102 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/richardwilkes/toolbox/collection"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/rjeczalik/notify"
+	"github.com/yookoala/realpath"
+)
+
+// watchSettleDelay is the amount of time a sheet must go without further change notifications before it is exported.
+// Saving a sheet typically generates several events in quick succession.
+const watchSettleDelay = 500 * time.Millisecond
+
+// WatchSheets monitors the given paths for changes to character sheets, calling export with the path of each sheet
+// that is created or modified. Directories are watched recursively. Every existing sheet is passed to export once prior
+// to watching for changes; subsequent calls are made one at a time from a background goroutine. This function does not
+// return unless an error occurs while setting up the watch.
+func WatchSheets(paths []string, export func(sheetPath string)) error {
+	var err error
+	if paths, err = fs.UniquePaths(paths...); err != nil {
+		return err
+	}
+	for _, p := range collectPaths(paths, collection.NewSet(SheetExt)) {
+		export(p)
+	}
+	events := make(chan notify.EventInfo, 64)
+	dirs := collection.NewSet[string]()
+	files := collection.NewSet[string]()
+	for _, p := range paths {
+		if p, err = realpath.Realpath(p); err != nil {
+			return errs.Wrap(err)
+		}
+		target := p
+		if fs.IsDir(p) {
+			dirs.Add(p)
+			target += "/..."
+		} else {
+			// Watching a single file requires watching its parent directory, which will also report changes to its
+			// siblings. Those are filtered out below.
+			files.Add(p)
+			target = filepath.Dir(p)
+		}
+		if err = notify.Watch(target, events, notify.Create|notify.Write|notify.Rename); err != nil {
+			notify.Stop(events)
+			return errs.NewWithCause("unable to watch filesystem path", err)
+		}
+	}
+	queue := make(chan string, 64)
+	go func() {
+		for p := range queue {
+			if fs.FileExists(p) {
+				export(p)
+			}
+		}
+	}()
+	var lock sync.Mutex
+	pending := make(map[string]*time.Timer)
+	for evt := range events {
+		p := evt.Path()
+		if !strings.EqualFold(filepath.Ext(p), SheetExt) || strings.HasPrefix(filepath.Base(p), ".") ||
+			!(files.Contains(p) || isWithinAnyDir(p, dirs)) {
+			continue
+		}
+		lock.Lock()
+		if t, exists := pending[p]; exists {
+			t.Reset(watchSettleDelay)
+		} else {
+			pending[p] = time.AfterFunc(watchSettleDelay, func() {
+				lock.Lock()
+				delete(pending, p)
+				lock.Unlock()
+				queue <- p
+			})
+		}
+		lock.Unlock()
+	}
+	return nil
+}
+
+func isWithinAnyDir(p string, dirs collection.Set[string]) bool {
+	for dir := range dirs {
+		if rel, err := filepath.Rel(dir, p); err == nil && !strings.HasPrefix(rel, "..") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+)
+
+// Trait names used to locate the traits that affect wealth and status.
+const (
+	WealthTraitName           = "Wealth"
+	MultimillionaireTraitName = "Multimillionaire"
+	StatusTraitName           = "Status"
+	RankTraitNameSuffix       = "Rank"
+)
+
+// Limits for Status.
+const (
+	MinStatus = -2
+	MaxStatus = 8
+)
+
+// startingWealthByTL holds the standard starting wealth for each tech level, from TL0 through TL12.
+var startingWealthByTL = []int{250, 500, 750, 1000, 2000, 5000, 10000, 15000, 20000, 30000, 50000, 75000, 100000}
+
+// DefaultStartingWealthByTL returns the standard starting wealth for each tech level, from TL0 through TL12.
+func DefaultStartingWealthByTL() []fxp.Int {
+	list := make([]fxp.Int, len(startingWealthByTL))
+	for i, one := range startingWealthByTL {
+		list[i] = fxp.From(one)
+	}
+	return list
+}
+
+// NewStartingWealthByTLFromString creates a starting wealth table from a comma-separated list of amounts, the first
+// being for TL0. An empty string results in a nil table, which means the standard amounts should be used.
+func NewStartingWealthByTLFromString(str string) (list []fxp.Int, inputWasValid bool) {
+	if strings.TrimSpace(str) == "" {
+		return nil, true
+	}
+	for _, part := range strings.Split(str, ",") {
+		value, err := fxp.FromString(strings.TrimPrefix(strings.TrimSpace(part), "$"))
+		if err != nil || value < 0 {
+			return nil, false
+		}
+		list = append(list, value)
+	}
+	return list, true
+}
+
+// StartingWealthByTLToString returns the starting wealth table in the form accepted by
+// NewStartingWealthByTLFromString.
+func StartingWealthByTLToString(list []fxp.Int) string {
+	parts := make([]string, len(list))
+	for i, one := range list {
+		parts[i] = one.String()
+	}
+	return strings.Join(parts, ", ")
+}
+
+// costOfLivingByStatus holds the monthly cost of living for each Status, from MinStatus through MaxStatus.
+var costOfLivingByStatus = []int{100, 300, 600, 1200, 3000, 12000, 60000, 600000, 6000000, 60000000, 600000000}
+
+var wealthLevels = []struct {
+	name       string
+	multiplier fxp.Int
+}{
+	{name: "Dead Broke", multiplier: 0},
+	{name: "Poor", multiplier: fxp.Fifth},
+	{name: "Struggling", multiplier: fxp.Half},
+	{name: "Comfortable", multiplier: fxp.Two},
+	{name: "Very Wealthy", multiplier: fxp.Twenty},
+	{name: "Wealthy", multiplier: fxp.Five},
+	{name: "Filthy Rich", multiplier: fxp.Hundred},
+}
+
+// StatusInfo holds the breakdown of a character's Status.
+type StatusInfo struct {
+	Purchased  int
+	FromWealth int
+	FromRank   int
+}
+
+// Effective returns the Status of the character, including any free Status granted by Wealth or Rank.
+func (si StatusInfo) Effective() int {
+	return min(max(si.Purchased+si.FromWealth+si.FromRank, MinStatus), MaxStatus)
+}
+
+// Tooltip returns a description of where the Status came from.
+func (si StatusInfo) Tooltip() string {
+	var buffer strings.Builder
+	fmt.Fprintf(&buffer, i18n.Text("Purchased: %d"), si.Purchased)
+	if si.FromWealth != 0 {
+		fmt.Fprintf(&buffer, i18n.Text("\nFrom Wealth: %+d"), si.FromWealth)
+	}
+	if si.FromRank != 0 {
+		fmt.Fprintf(&buffer, i18n.Text("\nFrom Rank: %+d"), si.FromRank)
+	}
+	return buffer.String()
+}
+
+// WealthMultiplier returns the multiplier applied to the starting wealth by the character's Wealth traits, along with
+// the name of the wealth level.
+func (e *Entity) WealthMultiplier() (multiplier fxp.Int, name string) {
+	multiplier = fxp.One
+	name = i18n.Text("Average")
+	Traverse(func(t *Trait) bool {
+		traitName := t.NameWithReplacements()
+		if strings.EqualFold(traitName, MultimillionaireTraitName) {
+			levels := max(fxp.As[int](t.CurrentLevel()), 1)
+			multiplier = fxp.Hundred
+			for range levels {
+				multiplier = multiplier.Mul(fxp.Ten)
+			}
+			name = fmt.Sprintf("%s %d", traitName, levels)
+			return true
+		}
+		if !strings.HasPrefix(strings.ToLower(traitName), strings.ToLower(WealthTraitName)) {
+			return false
+		}
+		candidates := []string{traitName}
+		Traverse(func(mod *TraitModifier) bool {
+			candidates = append(candidates, mod.NameWithReplacements())
+			return false
+		}, true, true, t.Modifiers...)
+		for _, candidate := range candidates {
+			lower := strings.ToLower(candidate)
+			for _, level := range wealthLevels {
+				if strings.Contains(lower, strings.ToLower(level.name)) {
+					multiplier = level.multiplier
+					name = level.name
+					return true
+				}
+			}
+		}
+		return false
+	}, true, true, e.Traits...)
+	return multiplier, name
+}
+
+// BaseStartingWealth returns the starting wealth for the character's tech level, using the table from the sheet settings
+// if one has been provided. Tech levels beyond the end of the table use its last entry.
+func (e *Entity) BaseStartingWealth() fxp.Int {
+	tl, start, _ := ExtractTechLevel(e.Profile.TechLevel)
+	if start == -1 {
+		tl, _, _ = ExtractTechLevel(GlobalSettings().General.DefaultTechLevel)
+	}
+	table := SheetSettingsFor(e).StartingWealthByTL
+	if len(table) == 0 {
+		return fxp.From(startingWealthByTL[min(max(fxp.As[int](tl), 0), len(startingWealthByTL)-1)])
+	}
+	return table[min(max(fxp.As[int](tl), 0), len(table)-1)]
+}
+
+// StartingWealth returns the starting wealth for the character, based on their tech level and Wealth traits.
+func (e *Entity) StartingWealth() fxp.Int {
+	multiplier, _ := e.WealthMultiplier()
+	return e.BaseStartingWealth().Mul(multiplier)
+}
+
+// Status returns the breakdown of the character's Status. Each level of Multimillionaire grants a free level of
+// Status. Rank grants free Status of +1 at Rank 3-4, +2 at Rank 5-6 and +3 at Rank 7-8; only the highest Rank is
+// considered.
+func (e *Entity) Status() StatusInfo {
+	var si StatusInfo
+	highestRank := 0
+	Traverse(func(t *Trait) bool {
+		name := t.NameWithReplacements()
+		switch {
+		case strings.EqualFold(name, StatusTraitName):
+			if t.IsLeveled() {
+				levels := fxp.As[int](t.CurrentLevel())
+				if t.PointsPerLevel < 0 {
+					levels = -levels
+				}
+				si.Purchased += levels
+			} else {
+				si.Purchased += fxp.As[int](t.AdjustedPoints().Div(fxp.Five))
+			}
+		case strings.EqualFold(name, MultimillionaireTraitName):
+			si.FromWealth += max(fxp.As[int](t.CurrentLevel()), 1)
+		case strings.HasSuffix(strings.ToLower(name), strings.ToLower(RankTraitNameSuffix)):
+			highestRank = max(highestRank, fxp.As[int](t.CurrentLevel()))
+		}
+		return false
+	}, true, true, e.Traits...)
+	if highestRank >= 3 {
+		si.FromRank = (highestRank - 1) / 2
+	}
+	return si
+}
+
+// CostOfLiving returns the monthly cost of living for the character's Status.
+func (e *Entity) CostOfLiving() fxp.Int {
+	return fxp.From(costOfLivingByStatus[e.Status().Effective()-MinStatus])
+}
+
+// EquipmentCost returns the total value of all of the character's equipment, carried or not.
+func (e *Entity) EquipmentCost() fxp.Int {
+	return e.WealthCarried() + e.WealthNotCarried()
+}
+
+// WealthRemaining returns the amount of the character's starting wealth left after paying for their equipment.
+func (e *Entity) WealthRemaining() fxp.Int {
+	return e.StartingWealth() - e.EquipmentCost()
+}
+
+// EquipmentExceedsWealth returns true if the sheet settings limit equipment to the character's starting wealth and the
+// equipment costs more than that.
+func (e *Entity) EquipmentExceedsWealth() bool {
+	return e.SheetSettings.LimitEquipmentToWealth && e.WealthRemaining() < 0
+}
+
+// CannotAffordCostOfLiving returns true if the character's funds won't cover a month of their cost of living.
+func (e *Entity) CannotAffordCostOfLiving() bool {
+	return e.AvailableFunds() < e.CostOfLiving()
+}
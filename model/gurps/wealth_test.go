@@ -0,0 +1,87 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func newLeveledTrait(e *Entity, name string, pointsPerLevel, levels fxp.Int) *Trait {
+	t := NewTrait(e, nil, false)
+	t.Name = name
+	t.CanLevel = true
+	t.PointsPerLevel = pointsPerLevel
+	t.Levels = levels
+	return t
+}
+
+func TestWealthAndStatus(t *testing.T) {
+	e := NewEntity()
+	e.Profile.TechLevel = "8"
+	check.Equal(t, fxp.From(20000), e.StartingWealth(), "average wealth at TL8")
+	check.Equal(t, 0, e.Status().Effective(), "no status")
+	check.Equal(t, fxp.From(600), e.CostOfLiving(), "status 0 cost of living")
+
+	wealth := NewTrait(e, nil, false)
+	wealth.Name = "Wealth (Comfortable)"
+	e.SetTraitList([]*Trait{wealth})
+	check.Equal(t, fxp.From(40000), e.StartingWealth(), "comfortable wealth at TL8")
+
+	e.SetTraitList([]*Trait{
+		newLeveledTrait(e, StatusTraitName, fxp.Five, fxp.Two),
+		newLeveledTrait(e, MultimillionaireTraitName, fxp.TwentyFive, fxp.One),
+		newLeveledTrait(e, "Military Rank", fxp.Five, fxp.Five),
+	})
+	status := e.Status()
+	check.Equal(t, StatusInfo{Purchased: 2, FromWealth: 1, FromRank: 2}, status, "status breakdown")
+	check.Equal(t, 5, status.Effective(), "effective status")
+	check.Equal(t, fxp.From(600000), e.CostOfLiving(), "status 5 cost of living")
+	check.Equal(t, fxp.From(20000000), e.StartingWealth(), "multimillionaire 1 wealth at TL8")
+
+	e.SetTraitList([]*Trait{newLeveledTrait(e, StatusTraitName, -fxp.Five, fxp.Three)})
+	check.Equal(t, MinStatus, e.Status().Effective(), "status clamps at minimum")
+}
+
+func TestStartingWealthBudget(t *testing.T) {
+	e := NewEntity()
+	e.Profile.TechLevel = "3"
+	check.Equal(t, fxp.From(1000), e.StartingWealth(), "standard wealth at TL3")
+
+	table, valid := NewStartingWealthByTLFromString("100, $200, 300")
+	check.True(t, valid)
+	check.Equal(t, "100, 200, 300", StartingWealthByTLToString(table))
+	_, valid = NewStartingWealthByTLFromString("100, lots")
+	check.False(t, valid)
+	e.SheetSettings.StartingWealthByTL = table
+	check.Equal(t, fxp.From(300), e.StartingWealth(), "tech levels past the end of the table use the last entry")
+
+	sword := NewEquipment(e, nil, false)
+	sword.Value = fxp.From(250)
+	sword.Quantity = fxp.One
+	e.SetCarriedEquipmentList([]*Equipment{sword})
+	e.Recalculate()
+	check.Equal(t, fxp.Fifty, e.WealthRemaining())
+	check.False(t, e.EquipmentExceedsWealth())
+
+	sword.Quantity = fxp.Two
+	e.Recalculate()
+	check.Equal(t, fxp.From(-200), e.WealthRemaining())
+	check.False(t, e.EquipmentExceedsWealth(), "not limited unless the sheet settings ask for it")
+	e.SheetSettings.LimitEquipmentToWealth = true
+	check.True(t, e.EquipmentExceedsWealth())
+	check.Equal(t, 1, len(e.CampaignLimitViolations()))
+
+	check.True(t, e.CannotAffordCostOfLiving(), "no funds")
+	e.Funds = fxp.From(1000)
+	check.False(t, e.CannotAffordCostOfLiving())
+}
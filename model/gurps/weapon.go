@@ -88,6 +88,8 @@ type WeaponData struct {
 	Bulk       WeaponBulk      `json:"bulk,omitempty"`
 	Recoil     WeaponRecoil    `json:"recoil,omitempty"`
 	Defaults   []*SkillDefault `json:"defaults,omitempty"`
+	ShotsUsed  fxp.Int         `json:"shots_used,omitempty"`
+	Target     *WeaponTarget   `json:"target,omitempty"`
 }
 
 // Weapon holds the stats for a weapon.
@@ -179,6 +181,10 @@ func (w *Weapon) Clone(_ LibraryFile, _ DataOwner, _ *Weapon, preserveID bool) *
 		other.TID = tid.MustNewTID(w.TID[0])
 	}
 	other.Damage = *w.Damage.Clone(&other)
+	if w.Target != nil {
+		target := *w.Target
+		other.Target = &target
+	}
 	other.Defaults = nil
 	if len(w.Defaults) != 0 {
 		other.Defaults = make([]*SkillDefault, len(w.Defaults))
@@ -276,6 +282,8 @@ func (w *Weapon) MarshalJSON() ([]byte, error) {
 		data.Range = WeaponRange{}
 		data.RateOfFire = WeaponRoF{}
 		data.Shots = WeaponShots{}
+		data.ShotsUsed = 0
+		data.Target = nil
 		data.Bulk = WeaponBulk{}
 		data.Recoil = WeaponRecoil{}
 		if data.Calc.Parry = w.Parry.Resolve(w, nil).String(); data.Calc.Parry == w.Parry.String() {
@@ -498,6 +506,9 @@ func (w *Weapon) skillLevelPostAdjustment(e *Entity, tooltip *xio.ByteBuffer) fx
 		w.ResolveBoolFlag(wswitch.Fencing, w.Parry.Fencing) {
 		return w.EncumbrancePenalty(e, tooltip)
 	}
+	if w.IsRanged() {
+		return w.Target.SkillAdjustment(tooltip)
+	}
 	return 0
 }
 
@@ -582,11 +593,11 @@ func (w *Weapon) collectWeaponBonuses(dieCount int, tooltip *xio.ByteBuffer, all
 		name = bestDef.NameWithReplacements(replacements)
 		specialization = bestDef.SpecializationWithReplacements(replacements)
 	}
-	entity.AddWeaponWithSkillBonusesFor(name, specialization, w.UsageWithReplacements(), tags, dieCount, tooltip,
-		bonusSet, allowed)
+	entity.AddWeaponWithSkillBonusesFor(name, specialization, w.UsageWithReplacements(), w.Damage.Type, tags, dieCount,
+		tooltip, bonusSet, allowed)
 	nameQualifier := w.String()
-	entity.AddNamedWeaponBonusesFor(nameQualifier, w.UsageWithReplacements(), tags, dieCount, tooltip, bonusSet,
-		allowed)
+	entity.AddNamedWeaponBonusesFor(nameQualifier, w.UsageWithReplacements(), w.Damage.Type, tags, dieCount, tooltip,
+		bonusSet, allowed)
 	for _, f := range w.Owner.FeatureList() {
 		w.extractWeaponBonus(f, bonusSet, allowed, fxp.From(dieCount), tooltip)
 	}
@@ -635,7 +646,8 @@ func (w *Weapon) extractWeaponBonus(f Feature, set map[*WeaponBonus]bool, allowe
 			switch bonus.SelectionType {
 			case wsel.WithRequiredSkill:
 			case wsel.ThisWeapon:
-				if bonus.SpecializationCriteria.Matches(replacements, w.UsageWithReplacements()) {
+				if bonus.SpecializationCriteria.Matches(replacements, w.UsageWithReplacements()) &&
+					bonus.DamageTypeCriteria.Matches(replacements, w.Damage.Type) {
 					if _, exists := set[bonus]; !exists {
 						set[bonus] = true
 						bonus.AddToTooltip(tooltip)
@@ -644,6 +656,7 @@ func (w *Weapon) extractWeaponBonus(f Feature, set map[*WeaponBonus]bool, allowe
 			case wsel.WithName:
 				if bonus.NameCriteria.Matches(replacements, w.String()) &&
 					bonus.SpecializationCriteria.Matches(replacements, w.UsageWithReplacements()) &&
+					bonus.DamageTypeCriteria.Matches(replacements, w.Damage.Type) &&
 					bonus.TagsCriteria.MatchesList(replacements, w.Owner.TagList()...) {
 					if _, exists := set[bonus]; !exists {
 						set[bonus] = true
@@ -784,6 +797,11 @@ func WeaponHeaderData(columnID int, melee, forPage bool) HeaderData {
 	return data
 }
 
+// ResolveRollTarget implements RollTargetResolver.
+func (w *Weapon) ResolveRollTarget(expr string) (int, error) {
+	return ResolveRollTarget(w.Entity(), w.SkillLevel(nil), expr)
+}
+
 // CellData returns the cell data information for the given column.
 func (w *Weapon) CellData(columnID int, data *CellData) {
 	var buffer xio.ByteBuffer
@@ -792,14 +810,25 @@ func (w *Weapon) CellData(columnID int, data *CellData) {
 	case WeaponDescriptionColumn:
 		data.Primary = w.String()
 		data.Secondary = w.Notes()
+		data.RollResolver = w
 	case WeaponUsageColumn:
 		data.Primary = w.UsageWithReplacements()
+		data.RollResolver = w
 	case WeaponSLColumn:
 		data.Primary = w.SkillLevel(&buffer).String()
 	case WeaponParryColumn:
 		parry := w.Parry.Resolve(w, &buffer)
 		data.Primary = parry.String()
 		data.Tooltip = parry.Tooltip()
+		if entity := w.Entity(); entity != nil && parry.CanParry {
+			if crossParry, first, second, ok := entity.CrossParry(); ok && (first == w || second == w) {
+				if data.Tooltip != "" {
+					data.Tooltip += "\n\n"
+				}
+				data.Tooltip += fmt.Sprintf(i18n.Text("Cross parry with %s and %s: %d"), first.String(), second.String(),
+					crossParry)
+			}
+		}
 	case WeaponBlockColumn:
 		data.Primary = w.Block.Resolve(w, &buffer).String()
 	case WeaponDamageColumn:
@@ -824,6 +853,23 @@ func (w *Weapon) CellData(columnID int, data *CellData) {
 		shots := w.Shots.Resolve(w, &buffer)
 		data.Primary = shots.String()
 		data.Tooltip = shots.Tooltip()
+		if entity := w.Entity(); entity != nil && entity.PlayMode && w.TracksShots() {
+			data.Primary = w.ShotsStatus()
+			remaining := fmt.Sprintf(i18n.Text("%s of %s shots remaining"), w.ShotsRemaining().String(),
+				w.ShotCapacity().String())
+			if shots.ReloadTime > 0 {
+				if shots.ReloadTimeIsPerShot {
+					remaining += fmt.Sprintf(i18n.Text("\nReloading takes %s seconds per shot"), shots.ReloadTime.String())
+				} else {
+					remaining += fmt.Sprintf(i18n.Text("\nReloading takes %s seconds"), shots.ReloadTime.String())
+				}
+			}
+			if data.Tooltip != "" {
+				data.Tooltip = remaining + "\n" + data.Tooltip
+			} else {
+				data.Tooltip = remaining
+			}
+		}
 	case WeaponBulkColumn:
 		bulk := w.Bulk.Resolve(w, &buffer)
 		data.Primary = bulk.String()
@@ -866,6 +912,8 @@ func (w *Weapon) Validate() {
 		w.Shots = WeaponShots{}
 		w.Bulk = WeaponBulk{}
 		w.Recoil = WeaponRecoil{}
+		w.ShotsUsed = 0
+		w.Target = nil
 	} else {
 		if w.Accuracy.Jet || w.RateOfFire.Jet {
 			w.Accuracy.Jet = true
@@ -875,6 +923,7 @@ func (w *Weapon) Validate() {
 		w.Range.Validate()
 		w.RateOfFire.Validate()
 		w.Shots.Validate()
+		w.ShotsUsed = w.ShotsUsed.Max(0)
 		w.Bulk.Validate()
 		w.Recoil.Validate()
 		w.Parry = WeaponParry{}
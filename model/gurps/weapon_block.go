@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/defense"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/feature"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wswitch"
 	"github.com/richardwilkes/json"
@@ -109,7 +110,8 @@ func (wb WeaponBlock) Resolve(w *Weapon, modifiersTooltip *xio.ByteBuffer) Weapo
 				result.Modifier += fxp.Three + best + entity.BlockBonus
 				AppendStringOntoNewLine(modifiersTooltip, entity.BlockBonusTooltip)
 				var percentModifier fxp.Int
-				for _, bonus := range w.collectWeaponBonuses(1, modifiersTooltip, feature.WeaponBlockBonus) {
+				bonuses := w.collectWeaponBonuses(1, modifiersTooltip, feature.WeaponBlockBonus)
+				for _, bonus := range bonuses {
 					amt := bonus.AdjustedAmountForWeapon(w)
 					if bonus.Percent {
 						percentModifier += amt
@@ -117,6 +119,10 @@ func (wb WeaponBlock) Resolve(w *Weapon, modifiersTooltip *xio.ByteBuffer) Weapo
 						result.Modifier += amt
 					}
 				}
+				if entity.SheetSettings.CombatReflexesRule == defense.NoStacking {
+					result.Modifier -= overlappingReflexesBonus(entity.blockReflexesBonus,
+						w.enhancedDefenseWeaponBonus(bonuses, BlockID), modifiersTooltip)
+				}
 				if percentModifier != 0 {
 					result.Modifier += result.Modifier.Mul(percentModifier).Div(fxp.Hundred).Trunc()
 				}
@@ -39,6 +39,7 @@ type WeaponBonus struct {
 	RelativeLevelCriteria  criteria.Number `json:"level,omitempty"`
 	UsageCriteria          criteria.Text   `json:"usage,omitempty"`
 	TagsCriteria           criteria.Text   `json:"tags,alt=category,omitempty"`
+	DamageTypeCriteria     criteria.Text   `json:"damage_type,omitempty"`
 	WeaponLeveledAmount
 	BonusOwner
 }
@@ -172,6 +173,7 @@ func newWeaponBonus(t feature.Type) *WeaponBonus {
 	w.RelativeLevelCriteria.Compare = criteria.AtLeastNumber
 	w.UsageCriteria.Compare = criteria.AnyText
 	w.TagsCriteria.Compare = criteria.AnyText
+	w.DamageTypeCriteria.Compare = criteria.AnyText
 	w.WeaponLeveledAmount.Amount = fxp.One
 	return &w
 }
@@ -202,6 +204,7 @@ func (w *WeaponBonus) AdjustedAmountForWeapon(wpn *Weapon) fxp.Int {
 // FillWithNameableKeys implements Feature.
 func (w *WeaponBonus) FillWithNameableKeys(m, existing map[string]string) {
 	nameable.Extract(w.SpecializationCriteria.Qualifier, m, existing)
+	nameable.Extract(w.DamageTypeCriteria.Qualifier, m, existing)
 	if w.SelectionType != wsel.ThisWeapon {
 		nameable.Extract(w.NameCriteria.Qualifier, m, existing)
 		nameable.Extract(w.UsageCriteria.Qualifier, m, existing)
@@ -293,5 +296,8 @@ func (w *WeaponBonus) Hash(h hash.Hash) {
 	w.RelativeLevelCriteria.Hash(h)
 	w.UsageCriteria.Hash(h)
 	w.TagsCriteria.Hash(h)
+	if !w.DamageTypeCriteria.ShouldOmit() {
+		w.DamageTypeCriteria.Hash(h)
+	}
 	w.WeaponLeveledAmount.Hash(h)
 }
@@ -271,6 +271,16 @@ func (w *WeaponDamage) BaseDamageDice() *dice.Dice {
 
 // ResolvedDamage returns the damage, fully resolved for the user's sw or thr, if possible.
 func (w *WeaponDamage) ResolvedDamage(tooltip *xio.ByteBuffer) string {
+	return w.resolvedDamage(tooltip, false)
+}
+
+// ResolvedMightyBlowsDamage returns the damage, fully resolved for the user's sw or thr, if possible, with the extra
+// damage from spending extra effort on Mighty Blows (B357) added in.
+func (w *WeaponDamage) ResolvedMightyBlowsDamage() string {
+	return w.resolvedDamage(nil, true)
+}
+
+func (w *WeaponDamage) resolvedDamage(tooltip *xio.ByteBuffer, mightyBlows bool) string {
 	base := w.BaseDamageDice()
 	if base.Count == 0 && base.Modifier == 0 {
 		return w.String()
@@ -317,6 +327,9 @@ func (w *WeaponDamage) ResolvedDamage(tooltip *xio.ByteBuffer) string {
 	if percentDamageBonus != 0 {
 		base = adjustDiceForPercentBonus(base, percentDamageBonus)
 	}
+	if mightyBlows {
+		base.Modifier += MightyBlowsBonus(base.Count)
+	}
 	if percentDRDivisorBonus != 0 {
 		armorDivisor = armorDivisor.Mul(percentDRDivisorBonus).Div(fxp.Hundred)
 	}
@@ -15,6 +15,7 @@ import (
 	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/defense"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/feature"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wswitch"
 	"github.com/richardwilkes/json"
@@ -119,7 +120,8 @@ func (wp WeaponParry) Resolve(w *Weapon, modifiersTooltip *xio.ByteBuffer) Weapo
 				result.Modifier += fxp.Three + best + entity.ParryBonus
 				AppendStringOntoNewLine(modifiersTooltip, entity.ParryBonusTooltip)
 				var percentModifier fxp.Int
-				for _, bonus := range w.collectWeaponBonuses(1, modifiersTooltip, feature.WeaponParryBonus) {
+				bonuses := w.collectWeaponBonuses(1, modifiersTooltip, feature.WeaponParryBonus)
+				for _, bonus := range bonuses {
 					amt := bonus.AdjustedAmountForWeapon(w)
 					if bonus.Percent {
 						percentModifier += amt
@@ -127,6 +129,10 @@ func (wp WeaponParry) Resolve(w *Weapon, modifiersTooltip *xio.ByteBuffer) Weapo
 						result.Modifier += amt
 					}
 				}
+				if entity.SheetSettings.CombatReflexesRule == defense.NoStacking {
+					result.Modifier -= overlappingReflexesBonus(entity.parryReflexesBonus,
+						w.enhancedDefenseWeaponBonus(bonuses, ParryID), modifiersTooltip)
+				}
 				if percentModifier != 0 {
 					result.Modifier += result.Modifier.Mul(percentModifier).Div(fxp.Hundred).Trunc()
 				}
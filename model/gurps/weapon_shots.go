@@ -184,6 +184,15 @@ func (ws WeaponShots) String() string {
 	return buffer.String()
 }
 
+// Capacity returns the number of shots the weapon holds when fully loaded, including any in the chamber. Call
+// .Resolve() prior to calling this method if you want the capacity to be based on the resolved values.
+func (ws WeaponShots) Capacity() fxp.Int {
+	if ws.Thrown || ws.Count <= 0 {
+		return 0
+	}
+	return ws.Count + ws.InChamber
+}
+
 // Tooltip returns a tooltip for the data, if any. Call .Resolve() prior to calling this method if you want the tooltip
 // to be based on the resolved values.
 func (ws WeaponShots) Tooltip() string {
@@ -12,6 +12,7 @@ package gurps_test
 import (
 	"testing"
 
+	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/toolbox/check"
 )
@@ -60,3 +61,32 @@ func TestWeaponShots(t *testing.T) {
 		check.Equal(t, c.expected, gurps.ParseWeaponShots(c.input).String(), "test %d", i)
 	}
 }
+
+func TestWeaponShotsTracking(t *testing.T) {
+	w := gurps.NewWeapon(gurps.NewEquipment(nil, nil, false), false)
+	w.Shots = gurps.ParseWeaponShots("10+1(3)")
+	w.RateOfFire = gurps.ParseWeaponRoF("3")
+	check.True(t, w.TracksShots())
+	check.Equal(t, fxp.From(11), w.ShotsRemaining())
+	check.False(t, w.CanReload())
+	for range 3 {
+		w.Fire()
+	}
+	check.Equal(t, fxp.From(2), w.ShotsRemaining())
+	check.Equal(t, "2/10+1(3)", w.ShotsStatus())
+	w.Fire()
+	check.Equal(t, fxp.From(0), w.ShotsRemaining())
+	check.False(t, w.CanFire())
+	check.Equal(t, fxp.From(3), w.Reload())
+	check.Equal(t, fxp.From(11), w.ShotsRemaining())
+
+	w.Shots = gurps.ParseWeaponShots("2(2i)")
+	w.RateOfFire = gurps.ParseWeaponRoF("1")
+	w.Fire()
+	w.Fire()
+	check.Equal(t, fxp.From(2), w.Reload())
+	check.Equal(t, fxp.From(1), w.ShotsRemaining())
+
+	w.Shots = gurps.ParseWeaponShots("T(1)")
+	check.False(t, w.TracksShots())
+}
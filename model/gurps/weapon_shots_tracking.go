@@ -0,0 +1,88 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+)
+
+// TracksShots returns true if the weapon is a ranged weapon with a limited number of shots that can be tracked.
+func (w *Weapon) TracksShots() bool {
+	return !w.IsMelee() && w.ShotCapacity() > 0
+}
+
+// ShotCapacity returns the number of shots the weapon holds when fully loaded.
+func (w *Weapon) ShotCapacity() fxp.Int {
+	if w.IsMelee() {
+		return 0
+	}
+	return w.Shots.Resolve(w, nil).Capacity()
+}
+
+// ShotsRemaining returns the number of shots currently loaded in the weapon.
+func (w *Weapon) ShotsRemaining() fxp.Int {
+	return (w.ShotCapacity() - w.ShotsUsed).Max(0)
+}
+
+// ShotsPerAttack returns the number of shots consumed by a single attack with the weapon.
+func (w *Weapon) ShotsPerAttack() fxp.Int {
+	return w.RateOfFire.Resolve(w, nil).Mode1.ShotsPerAttack.Max(fxp.One)
+}
+
+// CanFire returns true if the weapon has at least one shot loaded.
+func (w *Weapon) CanFire() bool {
+	return w.TracksShots() && w.ShotsRemaining() > 0
+}
+
+// Fire expends the shots for a single attack with the weapon. If fewer shots remain than a full attack requires, the
+// remaining shots are expended.
+func (w *Weapon) Fire() {
+	if w.CanFire() {
+		w.ShotsUsed = min(w.ShotsUsed.Max(0)+w.ShotsPerAttack(), w.ShotCapacity())
+	}
+}
+
+// CanReload returns true if the weapon is not fully loaded.
+func (w *Weapon) CanReload() bool {
+	return w.TracksShots() && w.ShotsRemaining() < w.ShotCapacity()
+}
+
+// Reload reloads the weapon and returns the number of seconds the reload takes. Weapons whose reload time is per shot
+// are loaded one shot at a time; all others are fully loaded at once.
+func (w *Weapon) Reload() (seconds fxp.Int) {
+	if !w.CanReload() {
+		return 0
+	}
+	shots := w.Shots.Resolve(w, nil)
+	if shots.ReloadTimeIsPerShot {
+		w.ShotsUsed = (w.ShotsUsed.Min(shots.Capacity()) - fxp.One).Max(0)
+	} else {
+		w.ShotsUsed = 0
+	}
+	return shots.ReloadTime
+}
+
+// ShotsStatus returns the number of shots remaining followed by the resolved shots data, e.g. "7/10+1(3)".
+func (w *Weapon) ShotsStatus() string {
+	return w.ShotsRemaining().String() + "/" + w.Shots.Resolve(w, nil).String()
+}
+
+// ResetShots fully reloads all of the equipped ranged weapons, as is typically done at the start of a new encounter.
+// Returns true if any weapon was changed.
+func (e *Entity) ResetShots() bool {
+	changed := false
+	for _, w := range e.EquippedWeapons(false) {
+		if w.ShotsUsed != 0 {
+			w.ShotsUsed = 0
+			changed = true
+		}
+	}
+	return changed
+}
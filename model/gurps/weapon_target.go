@@ -0,0 +1,63 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio"
+)
+
+// WeaponTarget holds the target currently being aimed at with a ranged weapon, so that the modifiers from the Size and
+// Speed/Range Table (B550) can be applied to the weapon's skill level.
+type WeaponTarget struct {
+	// Distance is the range to the target, in yards.
+	Distance fxp.Int `json:"distance,omitempty"`
+	// Speed is the target's speed relative to the attacker, in yards per second.
+	Speed        fxp.Int `json:"speed,omitempty"`
+	SizeModifier int     `json:"sm,omitempty"`
+}
+
+// RangeModifier returns the speed/range modifier for the target.
+func (t *WeaponTarget) RangeModifier() int {
+	return -yardsToValue(fxp.Length((t.Distance + t.Speed).Mul(fxp.ThirtySix)), false)
+}
+
+// Modifier returns the net modifier to skill for attacking the target.
+func (t *WeaponTarget) Modifier() int {
+	return t.RangeModifier() + t.SizeModifier
+}
+
+// String implements fmt.Stringer.
+func (t *WeaponTarget) String() string {
+	text := fmt.Sprintf(i18n.Text("%s yd"), t.Distance.Comma())
+	if t.Speed != 0 {
+		text += fmt.Sprintf(i18n.Text(" at %s yd/sec"), t.Speed.Comma())
+	}
+	if t.SizeModifier != 0 {
+		text += fmt.Sprintf(i18n.Text(", SM %+d"), t.SizeModifier)
+	}
+	return text
+}
+
+// SkillAdjustment returns the adjustment to skill for attacking the target, adding an explanation to the tooltip, if
+// there is one.
+func (t *WeaponTarget) SkillAdjustment(tooltip *xio.ByteBuffer) fxp.Int {
+	if t == nil {
+		return 0
+	}
+	modifier := t.Modifier()
+	if modifier != 0 && tooltip != nil {
+		fmt.Fprintf(tooltip, i18n.Text("\nTarget at %s [%+d]"), t, modifier)
+	}
+	return fxp.From(modifier)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestWeaponTarget(t *testing.T) {
+	for _, one := range []struct {
+		distance int
+		speed    int
+		sm       int
+		modifier int
+	}{
+		{distance: 2, modifier: 0},
+		{distance: 3, modifier: -1},
+		{distance: 10, modifier: -4},
+		{distance: 100, modifier: -10},
+		{distance: 10, speed: 5, modifier: -5},
+		{distance: 10, speed: 5, sm: 2, modifier: -3},
+	} {
+		target := gurps.WeaponTarget{
+			Distance:     fxp.From(one.distance),
+			Speed:        fxp.From(one.speed),
+			SizeModifier: one.sm,
+		}
+		check.Equal(t, one.modifier, target.Modifier(), target.String())
+	}
+	var target *gurps.WeaponTarget
+	check.Equal(t, fxp.Int(0), target.SkillAdjustment(nil))
+}
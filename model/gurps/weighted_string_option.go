@@ -13,10 +13,12 @@ import (
 	"github.com/richardwilkes/toolbox/xmath/rand"
 )
 
-// WeightedStringOption is a string that has a weight associated with it.
+// WeightedStringOption is a string that has a weight associated with it. The group is only used by lists from which
+// more than one option may be chosen, such as name parts; see ChooseWeightedGroups.
 type WeightedStringOption struct {
 	Weight int    `json:"weight"`
 	Value  string `json:"value"`
+	Group  string `json:"group,omitempty"`
 }
 
 // Valid returns true if this option has a valid weight.
@@ -45,3 +47,42 @@ func ChooseWeightedStringOption(options []*WeightedStringOption, not string) str
 	}
 	return ""
 }
+
+// ChooseWeightedGroups selects options from a list that may have more than one option chosen. Options that share a
+// group are mutually exclusive: exactly one of them is chosen, with the chance of each proportional to its weight.
+// Options without a group are chosen independently, with their weight being the percentage chance of being chosen. The
+// chosen options are returned in the order they appear in the list.
+func ChooseWeightedGroups[T any](rnd rand.Randomizer, options []T, weight func(T) int, group func(T) string) []T {
+	var groups []string
+	totals := make(map[string]int)
+	for _, one := range options {
+		if g := group(one); g != "" {
+			if _, exists := totals[g]; !exists {
+				groups = append(groups, g)
+			}
+			totals[g] += max(weight(one), 0)
+		}
+	}
+	choices := make(map[string]int, len(groups))
+	for _, g := range groups {
+		if total := totals[g]; total > 0 {
+			choices[g] = 1 + rnd.Intn(total)
+		}
+	}
+	var result []T
+	for _, one := range options {
+		w := max(weight(one), 0)
+		if g := group(one); g != "" {
+			if choice, ok := choices[g]; ok && choice > 0 {
+				choice -= w
+				choices[g] = choice
+				if choice < 1 {
+					result = append(result, one)
+				}
+			}
+		} else if w > 0 && rnd.Intn(100) < w {
+			result = append(result, one)
+		}
+	}
+	return result
+}
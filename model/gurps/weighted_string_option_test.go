@@ -0,0 +1,73 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+)
+
+type lastChoice struct{}
+
+func (lastChoice) Intn(n int) int {
+	return n - 1
+}
+
+func TestAncestryNameParts(t *testing.T) {
+	options := &gurps.AncestryOptions{
+		NameParts: []*gurps.WeightedStringOption{
+			{Weight: 0, Value: "Never", Group: "first"},
+			{Weight: 3, Value: "Given", Group: "first"},
+			{Weight: 1, Value: "Nickname", Group: "first"},
+			{Weight: 25, Value: "Epithet"},
+			{Weight: 100, Value: "Family"},
+		},
+	}
+	check.Equal(t, []string{"Given", "Epithet", "Family"}, options.NameGeneratorsToUse(firstChoice{}))
+	check.Equal(t, []string{"Nickname", "Family"}, options.NameGeneratorsToUse(lastChoice{}))
+
+	options.NameParts = nil
+	options.NameGenerators = []string{"First", "Last"}
+	check.Equal(t, []string{"First", "Last"}, options.NameGeneratorsToUse(lastChoice{}), "name generators are all used")
+}
+
+func TestAncestryTraits(t *testing.T) {
+	newTrait := func(name string) *gurps.Trait {
+		trait := gurps.NewTrait(nil, nil, false)
+		trait.Name = name
+		return trait
+	}
+	a := &gurps.Ancestry{
+		CommonOptions: &gurps.AncestryOptions{
+			Traits: []*gurps.WeightedTrait{
+				{Weight: 1, Group: "build", Value: newTrait("Skinny")},
+				{Weight: 1, Group: "build", Value: newTrait("Overweight")},
+				{Weight: 50, Value: newTrait("Night Vision")},
+			},
+		},
+		GenderOptions: []*gurps.WeightedAncestryOptions{
+			{Weight: 1, Value: &gurps.AncestryOptions{
+				Name:   "Female",
+				Traits: []*gurps.WeightedTrait{{Weight: 100, Value: newTrait("Attractive")}},
+			}},
+		},
+	}
+	names := func(list []*gurps.Trait) []string {
+		result := make([]string, 0, len(list))
+		for _, one := range list {
+			result = append(result, one.Name)
+		}
+		return result
+	}
+	check.Equal(t, []string{"Skinny", "Night Vision", "Attractive"}, names(a.RandomTraits(firstChoice{}, "Female")))
+	check.Equal(t, []string{"Overweight"}, names(a.RandomTraits(lastChoice{}, "Male")))
+}
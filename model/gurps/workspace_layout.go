@@ -0,0 +1,214 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps
+
+import (
+	"slices"
+
+	"github.com/richardwilkes/toolbox/txt"
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+)
+
+// WorkspaceLayout holds a named arrangement of documents within the workspace, along with those in their own windows.
+type WorkspaceLayout struct {
+	Name    string               `json:"name"`
+	Root    *WorkspaceLayoutNode `json:"root,omitempty"`
+	Windows []*SessionDocument   `json:"windows,omitempty"`
+}
+
+// WorkspaceLayoutNode is either an area of the workspace holding tabbed documents or a split of an area into two others.
+// Horizontal splits place the second node to the right of the first, otherwise it is placed below. The divider is the
+// fraction of the area given to the first node.
+type WorkspaceLayoutNode struct {
+	Documents  []string             `json:"documents,omitempty"`
+	Current    int                  `json:"current,omitempty"`
+	First      *WorkspaceLayoutNode `json:"first,omitempty"`
+	Second     *WorkspaceLayoutNode `json:"second,omitempty"`
+	Horizontal bool                 `json:"horizontal,omitempty"`
+	Divider    float32              `json:"divider,omitempty"`
+}
+
+// WorkspaceArea holds the documents shown in one area of the workspace and the bounds of that area.
+type WorkspaceArea struct {
+	Frame     unison.Rect
+	Documents []string
+	Current   int
+}
+
+// IsSplit returns true if this node splits its area into two others.
+func (n *WorkspaceLayoutNode) IsSplit() bool {
+	return n.First != nil && n.Second != nil
+}
+
+// Paths returns the paths of the documents within this node and the nodes below it.
+func (n *WorkspaceLayoutNode) Paths() []string {
+	if n == nil {
+		return nil
+	}
+	if n.IsSplit() {
+		return append(n.First.Paths(), n.Second.Paths()...)
+	}
+	return slices.Clone(n.Documents)
+}
+
+// Restorable returns a copy of the node holding only the documents whose files still exist, or nil if none do. Splits
+// that are left with only one side are replaced by that side.
+func (n *WorkspaceLayoutNode) Restorable() *WorkspaceLayoutNode {
+	if n == nil {
+		return nil
+	}
+	if n.IsSplit() {
+		first := n.First.Restorable()
+		second := n.Second.Restorable()
+		switch {
+		case first == nil:
+			return second
+		case second == nil:
+			return first
+		default:
+			return &WorkspaceLayoutNode{
+				First:      first,
+				Second:     second,
+				Horizontal: n.Horizontal,
+				Divider:    n.Divider,
+			}
+		}
+	}
+	other := &WorkspaceLayoutNode{Current: -1}
+	for i, one := range n.Documents {
+		if fs.FileExists(one) {
+			if i == n.Current {
+				other.Current = len(other.Documents)
+			}
+			other.Documents = append(other.Documents, one)
+		}
+	}
+	if len(other.Documents) == 0 {
+		return nil
+	}
+	other.Current = max(other.Current, 0)
+	return other
+}
+
+// NewWorkspaceLayoutNode returns the tree of splits that produces the given areas, which are expected to fill a
+// rectangle in the way the areas of a dock do. Areas without documents are ignored. Returns nil if no area has any
+// documents.
+func NewWorkspaceLayoutNode(areas []*WorkspaceArea) *WorkspaceLayoutNode {
+	list := make([]*WorkspaceArea, 0, len(areas))
+	for _, area := range areas {
+		if len(area.Documents) != 0 {
+			list = append(list, area)
+		}
+	}
+	return buildWorkspaceLayoutNode(list)
+}
+
+func buildWorkspaceLayoutNode(areas []*WorkspaceArea) *WorkspaceLayoutNode {
+	switch len(areas) {
+	case 0:
+		return nil
+	case 1:
+		return &WorkspaceLayoutNode{
+			Documents: slices.Clone(areas[0].Documents),
+			Current:   areas[0].Current,
+		}
+	}
+	bounds := areas[0].Frame
+	for _, area := range areas[1:] {
+		bounds = bounds.Union(area.Frame)
+	}
+	for _, horizontal := range []bool{true, false} {
+		first, second, cut, ok := splitWorkspaceAreas(areas, horizontal)
+		if !ok {
+			continue
+		}
+		origin, extent := bounds.Y, bounds.Height
+		if horizontal {
+			origin, extent = bounds.X, bounds.Width
+		}
+		divider := float32(0.5)
+		if extent > 0 {
+			divider = (cut - origin) / extent
+		}
+		return &WorkspaceLayoutNode{
+			First:      buildWorkspaceLayoutNode(first),
+			Second:     buildWorkspaceLayoutNode(second),
+			Horizontal: horizontal,
+			Divider:    divider,
+		}
+	}
+	// The areas overlap, which a dock never produces, so just place them side by side.
+	return &WorkspaceLayoutNode{
+		First:      buildWorkspaceLayoutNode(areas[:1]),
+		Second:     buildWorkspaceLayoutNode(areas[1:]),
+		Horizontal: true,
+		Divider:    0.5,
+	}
+}
+
+// splitWorkspaceAreas looks for a line that divides the areas into two groups without crossing any of them.
+func splitWorkspaceAreas(areas []*WorkspaceArea, horizontal bool) (first, second []*WorkspaceArea, cut float32, ok bool) {
+	start := func(r unison.Rect) float32 { return r.Y }
+	end := func(r unison.Rect) float32 { return r.Bottom() }
+	if horizontal {
+		start = func(r unison.Rect) float32 { return r.X }
+		end = func(r unison.Rect) float32 { return r.Right() }
+	}
+	cuts := make([]float32, 0, len(areas))
+	for _, area := range areas {
+		cuts = append(cuts, end(area.Frame))
+	}
+	slices.Sort(cuts)
+	for _, cut = range slices.Compact(cuts) {
+		first = first[:0]
+		second = second[:0]
+		ok = true
+		for _, area := range areas {
+			switch {
+			case end(area.Frame) <= cut:
+				first = append(first, area)
+			case start(area.Frame) >= cut:
+				second = append(second, area)
+			default:
+				ok = false
+			}
+			if !ok {
+				break
+			}
+		}
+		if ok && len(first) != 0 && len(second) != 0 {
+			return first, second, cut, true
+		}
+	}
+	return nil, nil, 0, false
+}
+
+// WorkspaceLayout returns the workspace layout with the given name, or nil if there is none.
+func (s *Settings) WorkspaceLayout(name string) *WorkspaceLayout {
+	for _, one := range s.WorkspaceLayouts {
+		if one.Name == name {
+			return one
+		}
+	}
+	return nil
+}
+
+// SetWorkspaceLayout adds the workspace layout, replacing any existing one with the same name.
+func (s *Settings) SetWorkspaceLayout(layout *WorkspaceLayout) {
+	s.RemoveWorkspaceLayout(layout.Name)
+	s.WorkspaceLayouts = append(s.WorkspaceLayouts, layout)
+	slices.SortFunc(s.WorkspaceLayouts, func(a, b *WorkspaceLayout) int { return txt.NaturalCmp(a.Name, b.Name, true) })
+}
+
+// RemoveWorkspaceLayout removes the workspace layout with the given name.
+func (s *Settings) RemoveWorkspaceLayout(name string) {
+	s.WorkspaceLayouts = slices.DeleteFunc(s.WorkspaceLayouts, func(one *WorkspaceLayout) bool { return one.Name == name })
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package gurps_test
+
+import (
+	"testing"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/check"
+	"github.com/richardwilkes/unison"
+)
+
+func TestWorkspaceLayoutNode(t *testing.T) {
+	check.Nil(t, gurps.NewWorkspaceLayoutNode(nil))
+
+	// One area on the left, with two areas stacked on top of each other to its right
+	root := gurps.NewWorkspaceLayoutNode([]*gurps.WorkspaceArea{
+		{Frame: unison.NewRect(104, 102, 100, 98), Documents: []string{"c.gcs"}},
+		{Frame: unison.NewRect(0, 0, 100, 200), Documents: []string{"a.gcs", "b.gcs"}, Current: 1},
+		{Frame: unison.NewRect(104, 0, 100, 98), Documents: []string{"d.gcs"}},
+	})
+	check.NotNil(t, root)
+	check.True(t, root.IsSplit())
+	check.True(t, root.Horizontal)
+	check.Equal(t, float32(100)/204, root.Divider)
+	check.False(t, root.First.IsSplit())
+	check.Equal(t, []string{"a.gcs", "b.gcs"}, root.First.Documents)
+	check.Equal(t, 1, root.First.Current)
+	check.True(t, root.Second.IsSplit())
+	check.False(t, root.Second.Horizontal)
+	check.Equal(t, []string{"d.gcs"}, root.Second.First.Documents)
+	check.Equal(t, []string{"c.gcs"}, root.Second.Second.Documents)
+	check.Equal(t, []string{"a.gcs", "b.gcs", "d.gcs", "c.gcs"}, root.Paths())
+
+	// Areas without documents are dropped
+	root = gurps.NewWorkspaceLayoutNode([]*gurps.WorkspaceArea{
+		{Frame: unison.NewRect(0, 0, 100, 200), Documents: []string{"a.gcs"}},
+		{Frame: unison.NewRect(104, 0, 100, 200)},
+	})
+	check.False(t, root.IsSplit())
+	check.Equal(t, []string{"a.gcs"}, root.Paths())
+}
+
+func TestWorkspaceLayoutSettings(t *testing.T) {
+	var s gurps.Settings
+	s.SetWorkspaceLayout(&gurps.WorkspaceLayout{Name: "Prep"})
+	s.SetWorkspaceLayout(&gurps.WorkspaceLayout{Name: "Library editing"})
+	s.SetWorkspaceLayout(&gurps.WorkspaceLayout{Name: "Prep", Windows: []*gurps.SessionDocument{{Path: "a.gcs"}}})
+	check.Equal(t, 2, len(s.WorkspaceLayouts))
+	check.Equal(t, "Library editing", s.WorkspaceLayouts[0].Name)
+	check.Equal(t, 1, len(s.WorkspaceLayout("Prep").Windows))
+	s.RemoveWorkspaceLayout("Prep")
+	check.Nil(t, s.WorkspaceLayout("Prep"))
+	check.Equal(t, 1, len(s.WorkspaceLayouts))
+}
@@ -10,6 +10,7 @@
 package jio
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/richardwilkes/toolbox/cmdline"
@@ -33,13 +34,16 @@ const (
 	MinimumLibraryVersion = 3 // Note that as of the Go version of GCS, the data and library version are the same.
 )
 
+// ErrVersionMismatch is the underlying cause of errors returned by CheckVersion.
+var ErrVersionMismatch = errors.New("unsupported data version")
+
 // CheckVersion returns an error if the data version is out of the acceptable range.
 func CheckVersion(version int) error {
 	if version > CurrentDataVersion {
-		return errs.New(txt.Wrap("", fmt.Sprintf(i18n.Text("The data was written with a newer version of %[1]s and cannot be loaded. Please update %[1]s and try again."), cmdline.AppName), 76))
+		return errs.NewWithCause(txt.Wrap("", fmt.Sprintf(i18n.Text("The data was written with a newer version of %[1]s and cannot be loaded. Please update %[1]s and try again."), cmdline.AppName), 76), ErrVersionMismatch)
 	}
 	if version < MinimumDataVersion {
-		return errs.New(txt.Wrap("", fmt.Sprintf(i18n.Text("The data was written with an older version of %s and cannot be loaded. You will need to load it with an earlier version that can read this version of the data and write the current format."), cmdline.AppName), 76))
+		return errs.NewWithCause(txt.Wrap("", fmt.Sprintf(i18n.Text("The data was written with an older version of %s and cannot be loaded. You will need to load it with an earlier version that can read this version of the data and write the current format."), cmdline.AppName), 76), ErrVersionMismatch)
 	}
 	return nil
 }
@@ -11,23 +11,30 @@ package kinds
 
 // The various kinds of nodes
 const (
+	APIToken                   = 'K'
 	Campaign                   = 'C'
+	ChangeRequest              = 'R'
 	ConditionalModifier        = 'c'
+	Embed                      = 'X'
 	Entity                     = 'A'
 	Equipment                  = 'e'
 	EquipmentContainer         = 'E'
 	EquipmentModifier          = 'f'
 	EquipmentModifierContainer = 'F'
+	Language                   = 'l'
+	Loadout                    = 'L'
 	NavigatorFavorites         = '0'
 	NavigatorLibrary           = '1'
 	NavigatorDirectory         = '2'
 	NavigatorFile              = '3'
 	Note                       = 'n'
 	NoteContainer              = 'N'
+	Ritual                     = 'g'
 	RitualMagicSpell           = 'r'
 	Session                    = '9'
 	Skill                      = 's'
 	SkillContainer             = 'S'
+	Spaceship                  = 'V'
 	Spell                      = 'p'
 	SpellContainer             = 'P'
 	TableOfContents            = '8'
@@ -0,0 +1,409 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+// Package qrcode provides a minimal QR Code encoder, sufficient for encoding URLs. Only byte mode and the medium error
+// correction level are supported, which covers text up to 213 bytes long.
+package qrcode
+
+import (
+	"image"
+	"image/color"
+
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// QuietZone is the number of light modules that must surround a QR Code for it to be reliably scanned.
+const QuietZone = 4
+
+// versionInfo holds the error correction layout for a version at the medium error correction level.
+type versionInfo struct {
+	ecPerBlock  int
+	blocks1     int
+	dataPerBlk1 int
+	blocks2     int
+	dataPerBlk2 int
+	alignment   []int
+}
+
+var versions = []versionInfo{
+	{}, // Version 0 doesn't exist
+	{ecPerBlock: 10, blocks1: 1, dataPerBlk1: 16},
+	{ecPerBlock: 16, blocks1: 1, dataPerBlk1: 28, alignment: []int{6, 18}},
+	{ecPerBlock: 26, blocks1: 1, dataPerBlk1: 44, alignment: []int{6, 22}},
+	{ecPerBlock: 18, blocks1: 2, dataPerBlk1: 32, alignment: []int{6, 26}},
+	{ecPerBlock: 24, blocks1: 2, dataPerBlk1: 43, alignment: []int{6, 30}},
+	{ecPerBlock: 16, blocks1: 4, dataPerBlk1: 27, alignment: []int{6, 34}},
+	{ecPerBlock: 18, blocks1: 4, dataPerBlk1: 31, alignment: []int{6, 22, 38}},
+	{ecPerBlock: 22, blocks1: 2, dataPerBlk1: 38, blocks2: 2, dataPerBlk2: 39, alignment: []int{6, 24, 42}},
+	{ecPerBlock: 22, blocks1: 3, dataPerBlk1: 36, blocks2: 2, dataPerBlk2: 37, alignment: []int{6, 26, 46}},
+	{ecPerBlock: 26, blocks1: 4, dataPerBlk1: 43, blocks2: 1, dataPerBlk2: 44, alignment: []int{6, 28, 50}},
+}
+
+func (v *versionInfo) dataCodewords() int {
+	return v.blocks1*v.dataPerBlk1 + v.blocks2*v.dataPerBlk2
+}
+
+// QRCode holds an encoded QR Code.
+type QRCode struct {
+	size     int
+	modules  []bool
+	function []bool
+}
+
+// New encodes the text as a QR Code, using the smallest version that can hold it.
+func New(text string) (*QRCode, error) {
+	data := []byte(text)
+	for version := 1; version < len(versions); version++ {
+		countBits := 8
+		if version > 9 {
+			countBits = 16
+		}
+		if 4+countBits+len(data)*8 <= versions[version].dataCodewords()*8 {
+			return encode(version, countBits, data), nil
+		}
+	}
+	return nil, errs.New("text is too long to be encoded as a QR Code")
+}
+
+// Size returns the number of modules along each side of the QR Code, not including the quiet zone.
+func (q *QRCode) Size() int {
+	return q.size
+}
+
+// Dark returns true if the module at the given coordinates is dark. Coordinates outside the QR Code are light.
+func (q *QRCode) Dark(x, y int) bool {
+	if x < 0 || y < 0 || x >= q.size || y >= q.size {
+		return false
+	}
+	return q.modules[y*q.size+x]
+}
+
+// Image returns a grayscale image of the QR Code, including the quiet zone, with each module drawn as a square of the
+// given number of pixels.
+func (q *QRCode) Image(moduleSize int) *image.Gray {
+	moduleSize = max(moduleSize, 1)
+	dim := (q.size + 2*QuietZone) * moduleSize
+	img := image.NewGray(image.Rect(0, 0, dim, dim))
+	for y := range dim {
+		for x := range dim {
+			c := color.Gray{Y: 255}
+			if q.Dark(x/moduleSize-QuietZone, y/moduleSize-QuietZone) {
+				c.Y = 0
+			}
+			img.SetGray(x, y, c)
+		}
+	}
+	return img
+}
+
+func encode(version, countBits int, data []byte) *QRCode {
+	info := &versions[version]
+	var bits bitBuffer
+	bits.append(4, 4) // Byte mode
+	bits.append(len(data), countBits)
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	capacity := info.dataCodewords() * 8
+	bits.append(0, min(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	for pad := 0xEC; len(bits) < capacity; pad ^= 0xEC ^ 0x11 {
+		bits.append(pad, 8)
+	}
+	q := &QRCode{size: version*4 + 17}
+	q.modules = make([]bool, q.size*q.size)
+	q.function = make([]bool, q.size*q.size)
+	q.drawFunctionPatterns(version, info)
+	q.drawCodewords(interleave(info, bits.bytes()))
+	bestMask := 0
+	bestPenalty := -1
+	for mask := range 8 {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if penalty := q.penalty(); bestPenalty < 0 || penalty < bestPenalty {
+			bestMask = mask
+			bestPenalty = penalty
+		}
+		q.applyMask(mask) // Applying the mask a second time removes it
+	}
+	q.applyMask(bestMask)
+	q.drawFormatBits(bestMask)
+	q.function = nil
+	return q
+}
+
+func interleave(info *versionInfo, data []byte) []byte {
+	blockCount := info.blocks1 + info.blocks2
+	dataBlocks := make([][]byte, 0, blockCount)
+	ecBlocks := make([][]byte, 0, blockCount)
+	generator := rsGenerator(info.ecPerBlock)
+	offset := 0
+	for i := range blockCount {
+		size := info.dataPerBlk1
+		if i >= info.blocks1 {
+			size = info.dataPerBlk2
+		}
+		block := data[offset : offset+size]
+		offset += size
+		dataBlocks = append(dataBlocks, block)
+		ecBlocks = append(ecBlocks, rsRemainder(block, generator))
+	}
+	result := make([]byte, 0, len(data)+blockCount*info.ecPerBlock)
+	for i := range max(info.dataPerBlk1, info.dataPerBlk2) {
+		for _, block := range dataBlocks {
+			if i < len(block) {
+				result = append(result, block[i])
+			}
+		}
+	}
+	for i := range info.ecPerBlock {
+		for _, block := range ecBlocks {
+			result = append(result, block[i])
+		}
+	}
+	return result
+}
+
+func (q *QRCode) set(x, y int, dark bool) {
+	q.modules[y*q.size+x] = dark
+	q.function[y*q.size+x] = true
+}
+
+func (q *QRCode) drawFunctionPatterns(version int, info *versionInfo) {
+	for i := range q.size {
+		q.set(6, i, i%2 == 0)
+		q.set(i, 6, i%2 == 0)
+	}
+	q.drawFinder(3, 3)
+	q.drawFinder(q.size-4, 3)
+	q.drawFinder(3, q.size-4)
+	last := len(info.alignment) - 1
+	for i, x := range info.alignment {
+		for j, y := range info.alignment {
+			// Skip the three corners occupied by the finder patterns
+			if (i == 0 && j == 0) || (i == 0 && j == last) || (i == last && j == 0) {
+				continue
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.set(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+				}
+			}
+		}
+	}
+	// Reserve the format areas; the real bits are drawn once the mask has been chosen
+	q.drawFormatBits(0)
+	if version >= 7 {
+		bits := versionBits(version)
+		for i := range 18 {
+			dark := (bits>>i)&1 != 0
+			a := q.size - 11 + i%3
+			b := i / 3
+			q.set(a, b, dark)
+			q.set(b, a, dark)
+		}
+	}
+}
+
+func (q *QRCode) drawFinder(cx, cy int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			x := cx + dx
+			y := cy + dy
+			if x >= 0 && x < q.size && y >= 0 && y < q.size {
+				dist := max(abs(dx), abs(dy))
+				q.set(x, y, dist != 2 && dist != 4)
+			}
+		}
+	}
+}
+
+// versionBits returns the 18 bits of version information, which includes a BCH error correction code.
+func versionBits(version int) int {
+	rem := version
+	for range 12 {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	return version<<12 | rem
+}
+
+// formatBits returns the 15 bits of format information for the mask, which includes a BCH error correction code.
+func formatBits(mask int) int {
+	// The medium error correction level is encoded as 0
+	data := mask
+	rem := data
+	for range 10 {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	return (data<<10 | rem) ^ 0x5412
+}
+
+func (q *QRCode) drawFormatBits(mask int) {
+	bits := formatBits(mask)
+	bit := func(i int) bool { return (bits>>i)&1 != 0 }
+	for i := range 6 {
+		q.set(8, i, bit(i))
+	}
+	q.set(8, 7, bit(6))
+	q.set(8, 8, bit(7))
+	q.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.set(14-i, 8, bit(i))
+	}
+	for i := range 8 {
+		q.set(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.set(8, q.size-15+i, bit(i))
+	}
+	q.set(8, q.size-8, true)
+}
+
+func (q *QRCode) drawCodewords(data []byte) {
+	i := 0
+	total := len(data) * 8
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		upward := (right+1)&2 == 0
+		for vert := range q.size {
+			for j := range 2 {
+				x := right - j
+				y := vert
+				if upward {
+					y = q.size - 1 - vert
+				}
+				if !q.function[y*q.size+x] && i < total {
+					q.modules[y*q.size+x] = (data[i>>3]>>(7-i&7))&1 != 0
+					i++
+				}
+			}
+		}
+	}
+}
+
+func (q *QRCode) applyMask(mask int) {
+	for y := range q.size {
+		for x := range q.size {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			default:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !q.function[y*q.size+x] {
+				q.modules[y*q.size+x] = !q.modules[y*q.size+x]
+			}
+		}
+	}
+}
+
+// penalty scores the symbol using the rules from the specification; lower is better.
+func (q *QRCode) penalty() int {
+	result := 0
+	dark := 0
+	for a := range q.size {
+		rowRun, colRun := 1, 1
+		for b := range q.size {
+			if q.Dark(b, a) {
+				dark++
+			}
+			if b > 0 {
+				if q.Dark(b, a) == q.Dark(b-1, a) {
+					rowRun++
+				} else {
+					rowRun = 1
+				}
+				if rowRun == 5 {
+					result += 3
+				} else if rowRun > 5 {
+					result++
+				}
+				if q.Dark(a, b) == q.Dark(a, b-1) {
+					colRun++
+				} else {
+					colRun = 1
+				}
+				if colRun == 5 {
+					result += 3
+				} else if colRun > 5 {
+					result++
+				}
+			}
+			if a > 0 && b > 0 {
+				c := q.Dark(b, a)
+				if c == q.Dark(b-1, a) && c == q.Dark(b, a-1) && c == q.Dark(b-1, a-1) {
+					result += 3
+				}
+			}
+			if q.finderLike(b, a, 1, 0) {
+				result += 40
+			}
+			if q.finderLike(a, b, 0, 1) {
+				result += 40
+			}
+		}
+	}
+	total := q.size * q.size
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	return result + max(k, 0)*10
+}
+
+// finderLike returns true if the modules starting at x, y and proceeding in the given direction form the 1:1:3:1:1
+// pattern of a finder, with four light modules on one side. Modules outside the symbol count as light.
+func (q *QRCode) finderLike(x, y, dx, dy int) bool {
+	const pattern = 0b10111010000
+	var forward, backward int
+	for i := range 11 {
+		if q.Dark(x+i*dx, y+i*dy) {
+			forward |= 1 << (10 - i)
+			backward |= 1 << i
+		}
+	}
+	return forward == pattern || backward == pattern
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+type bitBuffer []bool
+
+func (b *bitBuffer) append(value, count int) {
+	for i := count - 1; i >= 0; i-- {
+		*b = append(*b, (value>>i)&1 != 0)
+	}
+}
+
+func (b bitBuffer) bytes() []byte {
+	result := make([]byte, len(b)/8)
+	for i, bit := range b {
+		if bit {
+			result[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return result
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package qrcode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestReedSolomon(t *testing.T) {
+	// "HELLO WORLD" encoded as version 1-M
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	check.Equal(t, []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}, rsRemainder(data, rsGenerator(10)))
+}
+
+func TestFormatAndVersionBits(t *testing.T) {
+	for mask, expected := range []int{
+		0b101010000010010,
+		0b101000100100101,
+		0b101111001111100,
+		0b101101101001011,
+		0b100010111111001,
+		0b100000011001110,
+		0b100111110010111,
+		0b100101010100000,
+	} {
+		check.Equal(t, expected, formatBits(mask), "mask %d", mask)
+	}
+	check.Equal(t, 0b000111110010010100, versionBits(7))
+	check.Equal(t, 0b001010010011010011, versionBits(10))
+}
+
+func TestVersionSelection(t *testing.T) {
+	for _, one := range []struct {
+		length int
+		size   int
+	}{
+		{length: 14, size: 21},
+		{length: 15, size: 25},
+		{length: 106, size: 41},
+		{length: 122, size: 45},
+		{length: 213, size: 57},
+	} {
+		q, err := New(strings.Repeat("a", one.length))
+		check.NoError(t, err)
+		check.Equal(t, one.size, q.Size(), "length %d", one.length)
+	}
+	_, err := New(strings.Repeat("a", 214))
+	check.Error(t, err)
+}
+
+func TestFinderPatterns(t *testing.T) {
+	q, err := New("http://192.168.1.10:8422/?token=9abcdefghijklmnop#sheet/Players/Aria.gcs")
+	check.NoError(t, err)
+	for _, corner := range [][2]int{{0, 0}, {q.Size() - 7, 0}, {0, q.Size() - 7}} {
+		for i := range 7 {
+			check.True(t, q.Dark(corner[0]+i, corner[1]), "top edge of finder")
+			check.True(t, q.Dark(corner[0]+i, corner[1]+6), "bottom edge of finder")
+			check.True(t, q.Dark(corner[0], corner[1]+i), "left edge of finder")
+			check.True(t, q.Dark(corner[0]+6, corner[1]+i), "right edge of finder")
+		}
+		check.False(t, q.Dark(corner[0]+1, corner[1]+1), "inner ring of finder")
+		check.True(t, q.Dark(corner[0]+3, corner[1]+3), "center of finder")
+	}
+	check.True(t, q.Dark(8, q.Size()-8), "dark module")
+	img := q.Image(2)
+	check.Equal(t, (q.Size()+2*QuietZone)*2, img.Rect.Dx())
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package server
+
+import (
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/changereq"
+	"github.com/richardwilkes/gcs/v5/server/sheet"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/toolbox/xio/network/xhttp"
+)
+
+type changeRequestUpdate struct {
+	Kind     string
+	TargetID tid.TID
+	Name     string
+	Points   string
+	Reason   string
+}
+
+// changeRequestHandler queues a change proposed by a player for the GM to approve. This is permitted even for users
+// with read-only access, since nothing is applied to the sheet until the request has been approved. The request is
+// written to disk immediately so that it isn't lost if the server is stopped before the sheet is saved.
+func (s *Server) changeRequestHandler(w http.ResponseWriter, r *http.Request) {
+	_, userName, ok := sessionFromRequest(r)
+	if !ok {
+		xhttp.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+	entity, access, ok := s.loadSheet(w, r)
+	if !ok {
+		return
+	}
+	var update changeRequestUpdate
+	if err := JSONFromRequest(r, &update); err != nil {
+		xhttp.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+	points, err := fxp.FromString(update.Points)
+	if err != nil {
+		xhttp.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+	req := gurps.NewChangeRequest(userName, changereq.ExtractKind(update.Kind), update.TargetID, update.Name, points,
+		update.Reason)
+	if err = entity.Entity.AddChangeRequest(req); err != nil {
+		slog.Error("invalid change request", "path", entity.ClientPath, "error", err)
+		xhttp.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+	filePath := filepath.Join(access.Dir, entity.AccessPath)
+	if entity.OriginalHash == entity.CurrentHash {
+		err = entity.Entity.Save(filePath)
+		entity.OriginalHash = gurps.Hash64(entity.Entity)
+		entity.CurrentHash = entity.OriginalHash
+	} else {
+		// The in-memory copy has unsaved edits, so add the request to the copy on disk without saving those edits.
+		var onDisk *gurps.Entity
+		if onDisk, err = gurps.NewEntityFromFile(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath)); err == nil {
+			onDisk.ChangeRequests = append(onDisk.ChangeRequests, req)
+			err = onDisk.Save(filePath)
+		}
+		entity.CurrentHash = gurps.Hash64(entity.Entity)
+	}
+	if err != nil {
+		slog.Error("error saving change request", "path", entity.ClientPath, "error", err)
+		xhttp.ErrorStatus(w, http.StatusInternalServerError)
+		return
+	}
+	s.sheetsLock.Lock()
+	s.entitiesByPath[entity.ClientPath] = entity
+	s.sheetsLock.Unlock()
+	slog.Info("queued change request", "path", entity.ClientPath, "user", userName, "kind", req.Kind.Key())
+	response := sheet.NewSheetFromEntity(entity.Entity, entity.OriginalHash != entity.CurrentHash, access.ReadOnly)
+	PossiblyCompressedJSONResponse(w, r, http.StatusOK, response)
+}
@@ -12,11 +12,14 @@ package server
 import (
 	"net/http"
 
+	"github.com/richardwilkes/gcs/v5/model/colors"
 	"github.com/richardwilkes/toolbox/cmdline"
+	"github.com/richardwilkes/unison"
 )
 
 func (s *Server) installConfigurationHandlers() {
 	s.mux.HandleFunc("GET /api/version", s.versionHandler)
+	s.mux.HandleFunc("GET /api/theme", s.themeHandler)
 }
 
 func (s *Server) versionHandler(w http.ResponseWriter, _ *http.Request) {
@@ -37,3 +40,20 @@ func (s *Server) versionHandler(w http.ResponseWriter, _ *http.Request) {
 		Modified:  cmdline.VCSModified,
 	})
 }
+
+// themeHandler returns the theme colors currently configured in the desktop app, so that the web client matches it.
+func (s *Server) themeHandler(w http.ResponseWriter, _ *http.Request) {
+	type themeResponse struct {
+		Light map[string]unison.Color
+		Dark  map[string]unison.Color
+	}
+	rsp := themeResponse{
+		Light: make(map[string]unison.Color, len(colors.WebColors)),
+		Dark:  make(map[string]unison.Color, len(colors.WebColors)),
+	}
+	for _, one := range colors.WebColors {
+		rsp.Light[one.Name], rsp.Dark[one.Name] = one.LightAndDark()
+	}
+	w.Header().Set("Cache-Control", "no-cache")
+	JSONResponse(w, http.StatusOK, rsp)
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package server
+
+import (
+	_ "embed"
+	"html/template"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/kinds"
+	"github.com/richardwilkes/gcs/v5/server/sheet"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/toolbox/xio/network/xhttp"
+)
+
+// How often, in seconds, an embedded summary reloads itself to pick up changes to the sheet.
+const embedRefreshInterval = 60
+
+var (
+	//go:embed embed.html
+	embedHTML     string
+	embedTemplate = template.Must(template.New("embed").Parse(embedHTML))
+)
+
+type embedPage struct {
+	*sheet.Summary
+	PortraitURL string
+	Refresh     int
+}
+
+func (s *Server) installEmbedHandlers() {
+	s.mux.HandleFunc("GET /embed/{id}", s.embedHandler)
+	s.mux.HandleFunc("GET /embed/{id}/portrait", s.embedPortraitHandler)
+}
+
+func (s *Server) embedHandler(w http.ResponseWriter, r *http.Request) {
+	entity, ok := s.loadEmbeddedEntity(w, r)
+	if !ok {
+		return
+	}
+	page := embedPage{
+		Summary: sheet.NewSummaryFromEntity(entity.Entity),
+		Refresh: embedRefreshInterval,
+	}
+	if page.HasPortrait {
+		page.PortraitURL = r.PathValue("id") + "/portrait?v=" + strconv.FormatUint(entity.CurrentHash, 36)
+	}
+	setEmbedHeaders(w)
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	if err := embedTemplate.Execute(w, &page); err != nil {
+		slog.Error("error writing embedded summary", "path", entity.ClientPath, "error", err)
+	}
+}
+
+func (s *Server) embedPortraitHandler(w http.ResponseWriter, r *http.Request) {
+	entity, ok := s.loadEmbeddedEntity(w, r)
+	if !ok {
+		return
+	}
+	data := entity.Entity.Profile.PortraitData
+	if len(data) == 0 {
+		xhttp.ErrorStatus(w, http.StatusNotFound)
+		return
+	}
+	setEmbedHeaders(w)
+	w.Header().Set("Content-Type", http.DetectContentType(data))
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(data); err != nil {
+		slog.Error("error writing embedded portrait", "path", entity.ClientPath, "error", err)
+	}
+}
+
+// loadEmbeddedEntity returns the entity the embed in the request refers to. No session is required, as knowing the
+// embed's ID is what grants access to its summary.
+func (s *Server) loadEmbeddedEntity(w http.ResponseWriter, r *http.Request) (entity webEntity, ok bool) {
+	id, err := tid.FromStringOfKind(r.PathValue("id"), kinds.Embed)
+	if err != nil {
+		xhttp.ErrorStatus(w, http.StatusNotFound)
+		return entity, false
+	}
+	settings := gurps.GlobalSettings().WebServer
+	embed, userName, found := settings.LookupEmbed(id)
+	if !found {
+		xhttp.ErrorStatus(w, http.StatusNotFound)
+		return entity, false
+	}
+	access, found := settings.AccessList(userName)[embed.Key]
+	if !found {
+		xhttp.ErrorStatus(w, http.StatusNotFound)
+		return entity, false
+	}
+	if entity, ok = s.loadEntity(access, embed.Path); !ok {
+		xhttp.ErrorStatus(w, http.StatusNotFound)
+	}
+	return entity, ok
+}
+
+func setEmbedHeaders(w http.ResponseWriter) {
+	w.Header().Set("Cache-Control", "no-cache")
+	// Allow any site to place the summary in a frame, as that is its whole purpose
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; img-src 'self'; style-src 'unsafe-inline'; frame-ancestors *")
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+// The service worker only caches the application itself. The sheets are cached by the application, since it needs to
+// know when it is working from a stale copy.
+
+const cacheName = 'gcs-v1';
+const shell = ['/', '/manifest.json', '/favicon.webp', '/app.webp'];
+
+self.addEventListener('install', (event) => {
+	event.waitUntil(caches.open(cacheName).then((cache) => cache.addAll(shell)));
+	self.skipWaiting();
+});
+
+self.addEventListener('activate', (event) => {
+	event.waitUntil(
+		caches
+			.keys()
+			.then((keys) => Promise.all(keys.filter((key) => key !== cacheName).map((key) => caches.delete(key))))
+			.then(() => self.clients.claim())
+	);
+});
+
+self.addEventListener('fetch', (event) => {
+	const request = event.request;
+	const url = new URL(request.url);
+	if (
+		request.method !== 'GET' ||
+		url.origin !== self.location.origin ||
+		url.pathname.startsWith('/api/') ||
+		url.pathname.startsWith('/ref/') ||
+		url.pathname.startsWith('/pdf/')
+	) {
+		return;
+	}
+	// Prefer the network so that updates are picked up right away, falling back to the cache when offline.
+	event.respondWith(
+		fetch(request)
+			.then((rsp) => {
+				if (rsp.ok) {
+					const copy = rsp.clone();
+					caches.open(cacheName).then((cache) => cache.put(request.mode === 'navigate' ? '/' : request, copy));
+				}
+				return rsp;
+			})
+			.catch(() =>
+				caches
+					.match(request.mode === 'navigate' ? '/' : request)
+					.then((rsp) => rsp || Response.error())
+			)
+	);
+});
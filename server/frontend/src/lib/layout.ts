@@ -0,0 +1,36 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+import { readable, writable } from 'svelte/store';
+
+const collapsedKey = 'collapsed';
+
+const narrowQuery = window.matchMedia('screen and (max-width: 800px)');
+
+/**
+ * True when the viewport is narrow enough that the sheet should use its single column, mobile-friendly layout. Printed
+ * output always uses the full layout.
+ */
+export const narrow = readable(narrowQuery.matches, (set) => {
+	const listener = () => set(narrowQuery.matches);
+	narrowQuery.addEventListener('change', listener);
+	return () => narrowQuery.removeEventListener('change', listener);
+});
+
+const currentValue = localStorage.getItem(collapsedKey);
+
+/** The keys of the sheet blocks that have been collapsed while in the narrow layout. */
+export const collapsed = writable<string[]>(currentValue ? (JSON.parse(currentValue) as string[]) : []);
+
+collapsed.subscribe((value) => localStorage.setItem(collapsedKey, JSON.stringify(value)));
+
+/** Toggles the collapsed state of the block with the given key. */
+export function toggleCollapsed(key: string) {
+	collapsed.update((keys) => (keys.includes(key) ? keys.filter((k) => k !== key) : [...keys, key]));
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+import { get, writable } from 'svelte/store';
+import { apiPrefix } from '$lib/dev.ts';
+import { session } from '$lib/session.ts';
+import type { Cell, PageRef, Row, Table } from '$lib/sheet.ts';
+
+export const library = writable<Library | undefined>();
+
+export interface Library {
+	Name: string;
+	Table: Table | null;
+	PageRefs: { [k: string]: PageRef };
+}
+
+export async function fetchLibrary(path: string): Promise<Library | undefined> {
+	const rsp = await fetch(apiPrefix(`/library/${path}`), {
+		method: 'GET',
+		headers: { 'X-Session': get(session)?.ID ?? '' },
+		cache: 'no-store',
+	});
+	if (!rsp.ok) {
+		return undefined;
+	}
+	return (await rsp.json()) as Library;
+}
+
+function cellMatches(cell: Cell, terms: string[]): boolean {
+	const text = [cell.Primary, cell.Secondary, cell.InlineTag, cell.Tooltip].join(' ').toLowerCase();
+	return terms.every((term) => text.includes(term));
+}
+
+/**
+ * Returns a copy of the table containing only the rows that match all of the words in the query, along with the
+ * containers they are nested within so that they can still be seen in context.
+ */
+export function filterTable(table: Table | null, query: string): Table | null {
+	const terms = query.toLowerCase().split(/\s+/).filter((term) => term !== '');
+	if (!table || terms.length === 0) {
+		return table;
+	}
+	const rows: Row[] = [];
+	const parents: Row[] = [];
+	for (const row of table.Rows) {
+		parents.length = row.Depth;
+		if (row.Cells.some((cell) => cellMatches(cell, terms))) {
+			for (const parent of parents) {
+				if (parent && !rows.includes(parent)) {
+					rows.push(parent);
+				}
+			}
+			rows.push(row);
+		}
+		parents[row.Depth] = row;
+	}
+	return { Columns: table.Columns, Rows: rows };
+}
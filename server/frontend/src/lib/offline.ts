@@ -0,0 +1,88 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+import { writable } from 'svelte/store';
+
+const cachedSheetsKey = 'cached_sheets';
+const queuedEditsKey = 'queued_edits';
+const maxCachedSheets = 5;
+
+/** An edit to a sheet field that could not be sent to the server and is waiting to be retried. */
+export interface QueuedEdit {
+	Path: string;
+	Kind: string;
+	Key: string;
+	Data: string;
+}
+
+interface CachedSheet {
+	Path: string;
+	Sheet: unknown;
+}
+
+/** True when the server could not be reached on the last attempt. */
+export const offline = writable<boolean>(!navigator.onLine);
+
+/** The edits waiting to be sent to the server. */
+export const queuedEdits = writable<QueuedEdit[]>(load<QueuedEdit[]>(queuedEditsKey) ?? []);
+
+queuedEdits.subscribe((value) => store(queuedEditsKey, value));
+
+/** The queued edits the server rejected when they were finally sent, which the user has yet to be told about. */
+export const discardedEdits = writable<QueuedEdit[]>([]);
+
+/** Remembers the sheet for the given path, discarding the least recently viewed sheets once the limit is reached. */
+export function cacheSheet(path: string, sheet: unknown) {
+	const list = (load<CachedSheet[]>(cachedSheetsKey) ?? []).filter((one) => one.Path !== path);
+	list.unshift({ Path: path, Sheet: sheet });
+	while (list.length > 0) {
+		list.length = Math.min(list.length, maxCachedSheets);
+		if (store(cachedSheetsKey, list)) {
+			break;
+		}
+		// Storage is full, so drop the oldest entry and try again
+		list.pop();
+	}
+}
+
+/** Returns the cached copy of the sheet for the given path, if any. */
+export function cachedSheet(path: string): unknown | undefined {
+	return (load<CachedSheet[]>(cachedSheetsKey) ?? []).find((one) => one.Path === path)?.Sheet;
+}
+
+/** Adds an edit to the end of the queue. */
+export function queueEdit(edit: QueuedEdit) {
+	queuedEdits.update((edits) => [...edits, edit]);
+}
+
+/** Records an edit the server rejected, so that the user can be told it was lost. */
+export function discardEdit(edit: QueuedEdit) {
+	discardedEdits.update((edits) => [...edits, edit]);
+}
+
+function load<T>(key: string): T | undefined {
+	const value = localStorage.getItem(key);
+	if (value) {
+		try {
+			return JSON.parse(value) as T;
+		} catch {
+			localStorage.removeItem(key);
+		}
+	}
+	return undefined;
+}
+
+function store(key: string, value: unknown): boolean {
+	try {
+		localStorage.setItem(key, JSON.stringify(value));
+		return true;
+	} catch {
+		return false;
+	}
+}
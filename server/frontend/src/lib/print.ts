@@ -0,0 +1,30 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+import type { Page } from '$lib/sheet.ts';
+
+let pageStyle: HTMLStyleElement | null = null;
+
+/**
+ * Sets the paper size and margins used when printing. Passing nothing restores the browser's defaults. An @page rule
+ * can't be scoped to a component, so it is placed in a style element of its own in the document head.
+ */
+export function setPrintPage(page?: Page) {
+	if (!page) {
+		pageStyle?.remove();
+		pageStyle = null;
+		return;
+	}
+	if (!pageStyle) {
+		pageStyle = document.createElement('style');
+		document.head.appendChild(pageStyle);
+	}
+	const margins = `${page.TopMargin} ${page.RightMargin} ${page.BottomMargin} ${page.LeftMargin}`;
+	pageStyle.textContent = `@page { size: ${page.Width} ${page.Height}; margin: ${margins}; }`;
+}
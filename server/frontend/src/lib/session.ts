@@ -18,9 +18,24 @@ type Session = {
 	User: string;
 };
 
+// A shared link carries its session as a 'token' query parameter, so that following it is all that is needed to gain
+// access. The parameter is removed so that it doesn't linger in the address bar or the history.
+function sessionFromURL(): Session | null {
+	const url = new URL(window.location.href);
+	const token = url.searchParams.get('token');
+	if (!token) {
+		return null;
+	}
+	url.searchParams.delete('token');
+	history.replaceState(history.state, '', url);
+	return { ID: token, User: '' };
+}
+
 const currentValue = localStorage.getItem(sessionKey);
 
-export const session = writable<Session | null>(currentValue ? (JSON.parse(currentValue) as Session) : null);
+export const session = writable<Session | null>(
+	sessionFromURL() ?? (currentValue ? (JSON.parse(currentValue) as Session) : null),
+);
 
 session.subscribe((value) => localStorage.setItem(sessionKey, JSON.stringify(value)));
 
@@ -10,41 +10,131 @@
 import { get, writable } from 'svelte/store';
 import { apiPrefix } from '$lib/dev.ts';
 import { session } from '$lib/session.ts';
+import { navTo } from '$lib/nav.ts';
+import {
+	cachedSheet,
+	cacheSheet,
+	discardEdit,
+	offline,
+	queuedEdits,
+	queueEdit,
+	type QueuedEdit,
+} from '$lib/offline.ts';
 
 export const sheet = writable<Sheet | undefined>();
 
 export async function fetchSheet(path: string): Promise<Sheet | undefined> {
-	const rsp = await fetch(apiPrefix(`/sheet/${path}`), {
-		method: 'GET',
-		headers: { 'X-Session': get(session)?.ID ?? '' },
-		cache: 'no-store',
-	});
+	let rsp: Response;
+	try {
+		rsp = await fetch(apiPrefix(`/sheet/${path}`), {
+			method: 'GET',
+			headers: { 'X-Session': get(session)?.ID ?? '' },
+			cache: 'no-store',
+		});
+	} catch {
+		// The server can't be reached, so fall back to the last copy we saw
+		offline.set(true);
+		const cached = cachedSheet(path) as Sheet | undefined;
+		if (cached) {
+			cached.Offline = true;
+		}
+		return cached;
+	}
+	offline.set(false);
 	if (!rsp.ok) {
 		return undefined;
 	}
-	return await rsp.json();
+	const s = (await rsp.json()) as Sheet;
+	cacheSheet(path, s);
+	return s;
 }
 
+/**
+ * Sends an update for a field to the server. If the server can't be reached, the edit is queued to be sent later and
+ * undefined is returned.
+ */
 export async function updateSheetField(
 	path: string,
 	kind: string,
 	key: string,
 	data: string
 ): Promise<Sheet | undefined> {
-	const rsp = await fetch(apiPrefix(`/sheet/${path}`), {
+	let rsp: Response;
+	try {
+		rsp = await postSheetField({ Path: path, Kind: kind, Key: key, Data: data });
+	} catch {
+		offline.set(true);
+		queueEdit({ Path: path, Kind: kind, Key: key, Data: data });
+		return undefined;
+	}
+	offline.set(false);
+	if (!rsp.ok) {
+		throw undefined;
+	}
+	const s = (await rsp.json()) as Sheet;
+	cacheSheet(path, s);
+	return s;
+}
+
+let syncing = false;
+
+/**
+ * Sends any queued edits to the server, in the order they were made. Stops early, keeping the remaining edits queued,
+ * if the server can't be reached or reports a temporary problem. If the session is no longer accepted, the user is
+ * asked to log in again, after which syncing resumes. Only edits the server rejects as invalid for the sheet are
+ * discarded, and those are recorded in discardedEdits so the user can be told about them.
+ */
+export async function syncQueuedEdits(currentPath: string | undefined) {
+	if (syncing) {
+		return;
+	}
+	syncing = true;
+	try {
+		let edits = get(queuedEdits);
+		while (edits.length > 0) {
+			const edit = edits[0];
+			let rsp: Response;
+			try {
+				rsp = await postSheetField(edit);
+			} catch {
+				offline.set(true);
+				return;
+			}
+			offline.set(false);
+			if (rsp.ok) {
+				const s = (await rsp.json()) as Sheet;
+				cacheSheet(edit.Path, s);
+				if (edit.Path === currentPath) {
+					sheet.set(s);
+				}
+			} else if (rsp.status === 401 || rsp.status === 403) {
+				session.set(null);
+				navTo('login', 'next', window.location.hash);
+				return;
+			} else if (rsp.status === 400 || rsp.status === 409) {
+				discardEdit(edit);
+			} else {
+				return;
+			}
+			queuedEdits.update((list) => list.slice(1));
+			edits = get(queuedEdits);
+		}
+	} finally {
+		syncing = false;
+	}
+}
+
+function postSheetField(edit: QueuedEdit): Promise<Response> {
+	return fetch(apiPrefix(`/sheet/${edit.Path}`), {
 		method: 'POST',
 		headers: { 'X-Session': get(session)?.ID ?? '' },
 		cache: 'no-store',
 		body: JSON.stringify({
-			Kind: kind,
-			Key: key,
-			Data: data,
+			Kind: edit.Kind,
+			Key: edit.Key,
+			Data: edit.Data,
 		}),
 	});
-	if (!rsp.ok) {
-		throw undefined;
-	}
-	return await rsp.json();
 }
 
 export async function saveSheet(path: string): Promise<Sheet | undefined> {
@@ -56,7 +146,36 @@ export async function saveSheet(path: string): Promise<Sheet | undefined> {
 	if (!rsp.ok) {
 		throw undefined;
 	}
-	return await rsp.json();
+	const s = (await rsp.json()) as Sheet;
+	cacheSheet(path, s);
+	return s;
+}
+
+export interface NewChangeRequest {
+	Kind: string;
+	TargetID: string;
+	Name: string;
+	Points: string;
+	Reason: string;
+}
+
+/**
+ * Asks the GM to approve a change to the sheet. Unlike field edits, requests are never queued while offline, since the
+ * player needs to know whether the request was received.
+ */
+export async function requestChange(path: string, req: NewChangeRequest): Promise<Sheet> {
+	const rsp = await fetch(apiPrefix(`/request/${path}`), {
+		method: 'POST',
+		headers: { 'X-Session': get(session)?.ID ?? '' },
+		cache: 'no-store',
+		body: JSON.stringify(req),
+	});
+	if (!rsp.ok) {
+		throw undefined;
+	}
+	const s = (await rsp.json()) as Sheet;
+	cacheSheet(path, s);
+	return s;
 }
 
 export interface Identity {
@@ -194,6 +313,38 @@ export interface PageRef {
 	Offset: number;
 }
 
+export interface Page {
+	Width: string;
+	Height: string;
+	TopMargin: string;
+	LeftMargin: string;
+	BottomMargin: string;
+	RightMargin: string;
+}
+
+export interface ChangeRequestTarget {
+	ID: string;
+	Name: string;
+}
+
+export interface ChangeRequest {
+	ID: string;
+	State: string;
+	StateTitle: string;
+	Description: string;
+	Requester: string;
+	Reason: string;
+	Comment: string;
+	Created: string;
+	Resolved: string;
+}
+
+export interface ChangeRequests {
+	Requests: ChangeRequest[] | null;
+	Skills: ChangeRequestTarget[] | null;
+	Spells: ChangeRequestTarget[] | null;
+}
+
 export interface Sheet {
 	Identity: Identity;
 	Misc: Misc;
@@ -216,8 +367,11 @@ export interface Sheet {
 	CarriedEquipment: Table | null;
 	OtherEquipment: Table | null;
 	Notes: Table | null;
+	ChangeRequests: ChangeRequests;
 	Portrait: Table | null;
 	PageRefs: { [k: string]: PageRef };
+	Page: Page;
 	Modified: boolean;
 	ReadOnly: boolean;
+	Offline?: boolean;
 }
@@ -8,6 +8,7 @@
 // defined by the Mozilla Public License, version 2.0.
 
 import { get, writable } from 'svelte/store';
+import { apiPrefix } from '$lib/dev.ts';
 
 const themeKey = 'theme';
 
@@ -39,3 +40,48 @@ export function resolvedThemeKind(theme: Theme) {
 export function themeIsDark(theme: Theme) {
 	return theme === Theme.System ? systemIsDark.matches : theme === Theme.Dark;
 }
+
+interface ThemeColors {
+	Light: { [name: string]: string };
+	Dark: { [name: string]: string };
+}
+
+function colorDecls(colors: { [name: string]: string }, indent: string) {
+	return Object.entries(colors)
+		.map(([name, value]) => `${indent}--color-${name}: ${value};`)
+		.join('\n');
+}
+
+/**
+ * Replaces the built-in colors with those currently configured in the desktop app. The built-in colors remain in effect
+ * should the server be unreachable.
+ */
+async function loadThemeColors() {
+	try {
+		const rsp = await fetch(apiPrefix('/theme'), { method: 'GET', cache: 'no-cache' });
+		if (!rsp.ok) {
+			return;
+		}
+		const colors = (await rsp.json()) as ThemeColors;
+		const style = document.createElement('style');
+		style.textContent = `:root[theme='light'] {
+${colorDecls(colors.Light, '\t')}
+}
+
+:root[theme='dark'] {
+${colorDecls(colors.Dark, '\t')}
+}
+
+@media print {
+	:root[theme='dark'] {
+${colorDecls(colors.Light, '\t\t')}
+	}
+}
+`;
+		document.head.appendChild(style);
+	} catch {
+		// Keep the built-in colors
+	}
+}
+
+loadThemeColors();
@@ -37,3 +37,10 @@ export const sheetPath = derived(url, (value) => {
 	}
 	return '';
 });
+
+export const libraryPath = derived(url, (value) => {
+	if (value.hash.startsWith('#library/')) {
+		return decodeURIComponent(value.hash.substring(9));
+	}
+	return '';
+});
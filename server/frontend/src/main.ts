@@ -12,4 +12,8 @@ import App from './App.svelte';
 
 const app = new App({ target: document.getElementById('app') as Element });
 
+if ('serviceWorker' in navigator && !import.meta.env.DEV) {
+	navigator.serviceWorker.register('/sw.js').catch((err) => console.log('unable to register service worker', err));
+}
+
 export default app;
@@ -0,0 +1,120 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package server
+
+import (
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/server/sheet"
+	"github.com/richardwilkes/toolbox/xio/network/xhttp"
+)
+
+func (s *Server) installLibraryHandlers() {
+	s.mux.HandleFunc("GET /api/libraries", s.librariesHandler)
+	s.mux.HandleFunc("GET /api/library/{path...}", s.libraryHandler)
+}
+
+// sharedLibraries returns the libraries keyed by the name used to reference them from the web, in display order. If
+// sharing of the libraries has not been enabled, nothing is returned.
+func sharedLibraries() (names []string, libs map[string]*gurps.Library) {
+	if !gurps.GlobalSettings().WebServer.ShareLibraries {
+		return nil, nil
+	}
+	libs = make(map[string]*gurps.Library)
+	for _, lib := range gurps.GlobalSettings().Libraries().List() {
+		name := strings.ReplaceAll(lib.Title, "/", "-")
+		for i := 2; libs[name] != nil; i++ {
+			name = fmt.Sprintf("%s (%d)", strings.ReplaceAll(lib.Title, "/", "-"), i)
+		}
+		names = append(names, name)
+		libs[name] = lib
+	}
+	return names, libs
+}
+
+func (s *Server) librariesHandler(w http.ResponseWriter, r *http.Request) {
+	if _, _, ok := sessionFromRequest(r); !ok {
+		xhttp.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+	names, libs := sharedLibraries()
+	if libs == nil {
+		xhttp.ErrorStatus(w, http.StatusNotFound)
+		return
+	}
+	rsp := make([]*Dir, 0, len(names))
+	for _, name := range names {
+		lib := libs[name]
+		m := make(map[string]*Dir)
+		m["."] = &Dir{Name: name}
+		if err := fs.WalkDir(os.DirFS(lib.Path()), ".", func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil
+			}
+			fileName := d.Name()
+			if strings.HasPrefix(fileName, ".") {
+				if fileName != "." && d.IsDir() {
+					return fs.SkipDir
+				}
+				return nil
+			}
+			if p == "." {
+				return nil
+			}
+			parent := m[path.Dir(p)]
+			if d.IsDir() {
+				dir := &Dir{Name: fileName}
+				parent.Dirs = append(parent.Dirs, dir)
+				m[p] = dir
+				return nil
+			}
+			if sheet.IsLibraryFile(fileName) {
+				parent.Files = append(parent.Files, fileName)
+			}
+			return nil
+		}); err != nil {
+			slog.Warn("error walking library", "dir", lib.Path(), "error", err)
+		}
+		rsp = append(rsp, m["."])
+	}
+	PossiblyCompressedJSONResponse(w, r, http.StatusOK, prune(rsp))
+}
+
+func (s *Server) libraryHandler(w http.ResponseWriter, r *http.Request) {
+	if _, _, ok := sessionFromRequest(r); !ok {
+		xhttp.ErrorStatus(w, http.StatusUnauthorized)
+		return
+	}
+	_, libs := sharedLibraries()
+	parts := strings.SplitN(r.PathValue("path"), "/", 2)
+	if len(parts) != 2 || !fs.ValidPath(parts[1]) || !sheet.IsLibraryFile(parts[1]) {
+		xhttp.ErrorStatus(w, http.StatusBadRequest)
+		return
+	}
+	lib, ok := libs[parts[0]]
+	if !ok {
+		xhttp.ErrorStatus(w, http.StatusNotFound)
+		return
+	}
+	rsp, err := sheet.NewLibraryFromFile(os.DirFS(lib.Path()), parts[1])
+	if err != nil {
+		slog.Error("error loading library file", "library", parts[0], "path", parts[1], "error", err)
+		xhttp.ErrorStatus(w, http.StatusNotFound)
+		return
+	}
+	PossiblyCompressedJSONResponse(w, r, http.StatusOK, rsp)
+}
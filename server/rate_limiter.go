@@ -0,0 +1,82 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package server
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// rateLimiter limits the number of requests each client may make per minute. Each client may use its full allowance in
+// a burst, after which it is replenished evenly over the course of a minute.
+type rateLimiter struct {
+	lock      sync.Mutex
+	perMinute float64
+	clients   map[string]*rateBucket
+	lastPrune time.Time
+}
+
+type rateBucket struct {
+	available float64
+	updated   time.Time
+}
+
+func newRateLimiter(perMinute int) *rateLimiter {
+	return &rateLimiter{
+		perMinute: float64(perMinute),
+		clients:   make(map[string]*rateBucket),
+		lastPrune: time.Now(),
+	}
+}
+
+// allow returns true if the client may make a request at the given time. If not, also returns how long the client must
+// wait before its next request will be allowed.
+func (l *rateLimiter) allow(client string, now time.Time) (allowed bool, wait time.Duration) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if now.Sub(l.lastPrune) > time.Minute {
+		l.prune(now)
+	}
+	bucket, ok := l.clients[client]
+	if !ok {
+		bucket = &rateBucket{available: l.perMinute, updated: now}
+		l.clients[client] = bucket
+	} else {
+		bucket.available = min(l.perMinute, bucket.available+now.Sub(bucket.updated).Minutes()*l.perMinute)
+		bucket.updated = now
+	}
+	if bucket.available < 1 {
+		return false, time.Duration((1 - bucket.available) / l.perMinute * float64(time.Minute))
+	}
+	bucket.available--
+	return true, 0
+}
+
+// prune removes the clients that would have regained their full allowance by now, since they are indistinguishable
+// from clients that have never been seen.
+func (l *rateLimiter) prune(now time.Time) {
+	for client, bucket := range l.clients {
+		if bucket.available+now.Sub(bucket.updated).Minutes()*l.perMinute >= l.perMinute {
+			delete(l.clients, client)
+		}
+	}
+	l.lastPrune = now
+}
+
+// clientAddress returns the address used to identify the client that made the request.
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestRateLimiter(t *testing.T) {
+	l := newRateLimiter(60)
+	now := time.Now()
+	for range 60 {
+		allowed, _ := l.allow("a", now)
+		check.True(t, allowed)
+	}
+	allowed, wait := l.allow("a", now)
+	check.False(t, allowed)
+	check.Equal(t, time.Second, wait)
+
+	// Other clients have their own allowance
+	allowed, _ = l.allow("b", now)
+	check.True(t, allowed)
+
+	// The allowance is replenished over time
+	now = now.Add(time.Second)
+	allowed, _ = l.allow("a", now)
+	check.True(t, allowed)
+	allowed, _ = l.allow("a", now)
+	check.False(t, allowed)
+
+	// Clients that have regained their full allowance are forgotten
+	now = now.Add(2 * time.Minute)
+	l.allow("c", now)
+	check.Equal(t, 1, len(l.clients))
+}
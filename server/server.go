@@ -13,9 +13,12 @@ import (
 	"compress/flate"
 	"embed"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
@@ -45,6 +48,7 @@ var (
 type Server struct {
 	server         *xhttp.Server
 	mux            *http.ServeMux
+	limiter        *rateLimiter
 	sheetsLock     sync.RWMutex
 	entitiesByPath map[string]webEntity
 }
@@ -56,25 +60,37 @@ func Start(errorCallback func(error)) {
 	if site != nil {
 		return
 	}
-	state.Set(state.Starting)
 	settings := gurps.GlobalSettings().WebServer
 	settings.Validate()
+	certFile, keyFile, tlsConfig, err := tlsSetup(settings)
+	if err != nil {
+		errs.Log(err)
+		if errorCallback != nil {
+			errorCallback(err)
+		}
+		return
+	}
+	state.Set(state.Starting)
 	s := &Server{
 		server: &xhttp.Server{
-			CertFile:            settings.CertFile,
-			KeyFile:             settings.KeyFile,
+			CertFile:            certFile,
+			KeyFile:             keyFile,
 			ShutdownGracePeriod: fxp.SecondsToDuration(settings.ShutdownGracePeriod),
 			WebServer: &http.Server{
 				Addr:         settings.Address,
 				ReadTimeout:  fxp.SecondsToDuration(settings.ReadTimeout),
 				WriteTimeout: fxp.SecondsToDuration(settings.ReadTimeout),
 				IdleTimeout:  fxp.SecondsToDuration(settings.ReadTimeout),
+				TLSConfig:    tlsConfig,
 			},
 		},
 		mux:            http.NewServeMux(),
+		limiter:        newRateLimiter(fxp.As[int](settings.RateLimit)),
 		entitiesByPath: make(map[string]webEntity),
 	}
 	s.installConfigurationHandlers()
+	s.installEmbedHandlers()
+	s.installLibraryHandlers()
 	s.installPageRefHandlers()
 	s.installSessionHandlers()
 	s.installSheetHandlers()
@@ -143,6 +159,12 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if allowed, wait := s.limiter.allow(clientAddress(r), time.Now()); !allowed {
+		header.Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		xhttp.ErrorStatus(w, http.StatusTooManyRequests)
+		return
+	}
+
 	// Add the current session and user to the logger
 	if id, userName, ok := sessionFromRequest(r); ok {
 		if md := xhttp.MetadataFromRequest(r); md != nil {
@@ -11,6 +11,7 @@ package server
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/model/kinds"
@@ -55,17 +56,27 @@ func (s *Server) logoutHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// sessionFromRequest returns the session the request was made under, along with the name of its user. Clients other
+// than the web frontend may instead supply an API token as a bearer token in the Authorization header, in which case
+// the token is returned in place of the session.
 func sessionFromRequest(r *http.Request) (sessionID tid.TID, userName string, ok bool) {
-	rawID := r.Header.Get(sessionIDHeader)
-	if rawID == "" {
-		return "", "", false
-	}
 	var err error
-	if sessionID, err = tid.FromStringOfKind(rawID, kinds.Session); err != nil {
-		return "", "", false
+	settings := gurps.GlobalSettings().WebServer
+	if rawID := r.Header.Get(sessionIDHeader); rawID != "" {
+		if sessionID, err = tid.FromStringOfKind(rawID, kinds.Session); err != nil {
+			return "", "", false
+		}
+		userName, ok = settings.LookupSession(sessionID)
+		return sessionID, userName, ok
+	}
+	if rawID, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); found {
+		if sessionID, err = tid.FromStringOfKind(strings.TrimSpace(rawID), kinds.APIToken); err != nil {
+			return "", "", false
+		}
+		userName, ok = settings.LookupAPIToken(sessionID)
+		return sessionID, userName, ok
 	}
-	userName, ok = gurps.GlobalSettings().WebServer.LookupSession(sessionID)
-	return sessionID, userName, ok
+	return "", "", false
 }
 
 func setSessionHeaders(w http.ResponseWriter, id tid.TID, userName string) {
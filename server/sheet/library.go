@@ -0,0 +1,173 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package sheet
+
+import (
+	"io/fs"
+	"path"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/ux"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// LibraryFileExtensions holds the extensions of the library files that can be browsed from the web.
+var LibraryFileExtensions = []string{
+	gurps.TraitsExt,
+	gurps.SkillsExt,
+	gurps.SpellsExt,
+	gurps.EquipmentExt,
+	gurps.NotesExt,
+}
+
+// IsLibraryFile returns true if the file is one that can be browsed from the web.
+func IsLibraryFile(name string) bool {
+	ext := path.Ext(name)
+	for _, one := range LibraryFileExtensions {
+		if strings.EqualFold(ext, one) {
+			return true
+		}
+	}
+	return false
+}
+
+// Library holds the data needed by the frontend to display the contents of a library file.
+type Library struct {
+	Name     string
+	Table    *Table
+	PageRefs map[string]PageRef
+}
+
+// NewLibraryFromFile loads a library file and creates the data needed to display it.
+func NewLibraryFromFile(fileSystem fs.FS, filePath string) (*Library, error) {
+	var table *Table
+	switch strings.ToLower(path.Ext(filePath)) {
+	case gurps.TraitsExt:
+		traits, err := gurps.NewTraitsFromFile(fileSystem, filePath)
+		if err != nil {
+			return nil, err
+		}
+		table = createLibraryTable(ux.NewTraitsProvider(&libraryListProvider{traits: traits}, false),
+			gurps.TraitsHeaderData)
+	case gurps.SkillsExt:
+		skills, err := gurps.NewSkillsFromFile(fileSystem, filePath)
+		if err != nil {
+			return nil, err
+		}
+		table = createLibraryTable(ux.NewSkillsProvider(&libraryListProvider{skills: skills}, false),
+			gurps.SkillsHeaderData)
+	case gurps.SpellsExt:
+		spells, err := gurps.NewSpellsFromFile(fileSystem, filePath)
+		if err != nil {
+			return nil, err
+		}
+		table = createLibraryTable(ux.NewSpellsProvider(&libraryListProvider{spells: spells}, false),
+			gurps.SpellsHeaderData)
+	case gurps.EquipmentExt:
+		equipment, err := gurps.NewEquipmentFromFile(fileSystem, filePath)
+		if err != nil {
+			return nil, err
+		}
+		table = createLibraryTable(ux.NewEquipmentProvider(&libraryListProvider{equipment: equipment}, false, false),
+			func(id int) gurps.HeaderData { return gurps.EquipmentHeaderData(id, nil, false, false) })
+	case gurps.NotesExt:
+		notes, err := gurps.NewNotesFromFile(fileSystem, filePath)
+		if err != nil {
+			return nil, err
+		}
+		table = createLibraryTable(ux.NewNotesProvider(&libraryListProvider{notes: notes}, false),
+			gurps.NotesHeaderData)
+	default:
+		return nil, errs.New("not a library file: " + filePath)
+	}
+	return &Library{
+		Name:     strings.TrimSuffix(path.Base(filePath), path.Ext(filePath)),
+		Table:    table,
+		PageRefs: createPageRefs(),
+	}, nil
+}
+
+func createLibraryTable[T gurps.NodeTypes](provider ux.TableProvider[T], headerData func(int) gurps.HeaderData) *Table {
+	ids := provider.ColumnIDs()
+	root := provider.RootData()
+	table := &Table{
+		Columns: make([]gurps.HeaderData, len(ids)),
+		Rows:    make([]Row, 0, len(root)),
+	}
+	for i, id := range ids {
+		table.Columns[i] = headerData(id)
+	}
+	for _, one := range root {
+		collectRowData(gurps.AsNode(one), 0, table, provider, ids)
+	}
+	return table
+}
+
+// libraryListProvider provides the lists of a library file. Library data has no owner, just as when it is displayed
+// in the desktop app's library tables.
+type libraryListProvider struct {
+	traits    []*gurps.Trait
+	skills    []*gurps.Skill
+	spells    []*gurps.Spell
+	equipment []*gurps.Equipment
+	notes     []*gurps.Note
+}
+
+func (p *libraryListProvider) DataOwner() gurps.DataOwner {
+	return nil
+}
+
+func (p *libraryListProvider) TraitList() []*gurps.Trait {
+	return p.traits
+}
+
+func (p *libraryListProvider) SetTraitList(list []*gurps.Trait) {
+	p.traits = list
+}
+
+func (p *libraryListProvider) SkillList() []*gurps.Skill {
+	return p.skills
+}
+
+func (p *libraryListProvider) SetSkillList(list []*gurps.Skill) {
+	p.skills = list
+}
+
+func (p *libraryListProvider) SpellList() []*gurps.Spell {
+	return p.spells
+}
+
+func (p *libraryListProvider) SetSpellList(list []*gurps.Spell) {
+	p.spells = list
+}
+
+func (p *libraryListProvider) CarriedEquipmentList() []*gurps.Equipment {
+	return nil
+}
+
+func (p *libraryListProvider) SetCarriedEquipmentList(_ []*gurps.Equipment) {
+}
+
+func (p *libraryListProvider) OtherEquipmentList() []*gurps.Equipment {
+	return p.equipment
+}
+
+func (p *libraryListProvider) SetOtherEquipmentList(list []*gurps.Equipment) {
+	p.equipment = list
+}
+
+func (p *libraryListProvider) NoteList() []*gurps.Note {
+	return p.notes
+}
+
+func (p *libraryListProvider) SetNoteList(list []*gurps.Note) {
+	p.notes = list
+}
@@ -12,6 +12,7 @@ package sheet
 import (
 	"fmt"
 	"path"
+	"slices"
 	"strconv"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
@@ -547,12 +548,102 @@ func collectRowData[T gurps.NodeTypes](node gurps.Node[T], depth int, table *Tab
 	}
 }
 
+// ChangeRequestTarget holds the data needed by the frontend to offer a skill or spell as the target of a change request.
+type ChangeRequestTarget struct {
+	ID   tid.TID
+	Name string
+}
+
+// ChangeRequests holds the data needed by the frontend to display the change request queue and to make new requests.
+type ChangeRequests struct {
+	Requests []ChangeRequest
+	Skills   []ChangeRequestTarget
+	Spells   []ChangeRequestTarget
+}
+
+// ChangeRequest holds the data needed by the frontend to display a change request.
+type ChangeRequest struct {
+	ID          tid.TID
+	State       string
+	StateTitle  string
+	Description string
+	Requester   string
+	Reason      string
+	Comment     string
+	Created     string
+	Resolved    string
+}
+
+func createChangeRequests(entity *gurps.Entity) ChangeRequests {
+	var result ChangeRequests
+	for _, one := range entity.ChangeRequests {
+		req := ChangeRequest{
+			ID:          one.ID,
+			State:       one.State.Key(),
+			StateTitle:  one.State.String(),
+			Description: one.Description(entity),
+			Requester:   one.Requester,
+			Reason:      one.Reason,
+			Comment:     one.Comment,
+			Created:     one.Created.String(),
+		}
+		if one.Resolved != nil {
+			req.Resolved = one.Resolved.String()
+		}
+		result.Requests = append(result.Requests, req)
+	}
+	gurps.Traverse(func(s *gurps.Skill) bool {
+		result.Skills = append(result.Skills, ChangeRequestTarget{ID: s.TID, Name: s.String()})
+		return false
+	}, true, true, entity.Skills...)
+	gurps.Traverse(func(s *gurps.Spell) bool {
+		result.Spells = append(result.Spells, ChangeRequestTarget{ID: s.TID, Name: s.String()})
+		return false
+	}, true, true, entity.Spells...)
+	return result
+}
+
 // PageRef holds the data needed by the frontend to display and use a page reference.
 type PageRef struct {
 	Name   string
 	Offset int
 }
 
+func createPageRefs() map[string]PageRef {
+	refs := make(map[string]PageRef)
+	for _, one := range gurps.GlobalSettings().PageRefs.List() {
+		refs[one.ID] = PageRef{
+			Name:   path.Base(one.Path),
+			Offset: one.Offset,
+		}
+	}
+	return refs
+}
+
+// Page holds the paper size and margins configured for the sheet, as CSS lengths, so that printing from a browser
+// produces the same page layout as the desktop app.
+type Page struct {
+	Width        string
+	Height       string
+	TopMargin    string
+	LeftMargin   string
+	BottomMargin string
+	RightMargin  string
+}
+
+func createPage(entity *gurps.Entity) Page {
+	page := entity.SheetSettings.Page
+	w, h := page.Orientation.Dimensions(page.Size.Dimensions())
+	return Page{
+		Width:        w.CSSString(),
+		Height:       h.CSSString(),
+		TopMargin:    page.TopMargin.CSSString(),
+		LeftMargin:   page.LeftMargin.CSSString(),
+		BottomMargin: page.BottomMargin.CSSString(),
+		RightMargin:  page.RightMargin.CSSString(),
+	}
+}
+
 // Sheet holds the data needed by the frontend to display a GURPS character sheet.
 type Sheet struct {
 	Identity               Identity
@@ -576,21 +667,16 @@ type Sheet struct {
 	CarriedEquipment       *Table
 	OtherEquipment         *Table
 	Notes                  *Table
+	ChangeRequests         ChangeRequests
 	Portrait               []byte
 	PageRefs               map[string]PageRef
+	Page                   Page
 	Modified               bool
 	ReadOnly               bool
 }
 
 // NewSheetFromEntity creates a new Sheet from the given entity.
 func NewSheetFromEntity(entity *gurps.Entity, modified, readOnly bool) *Sheet {
-	refs := make(map[string]PageRef)
-	for _, one := range gurps.GlobalSettings().PageRefs.List() {
-		refs[one.ID] = PageRef{
-			Name:   path.Base(one.Path),
-			Offset: one.Offset,
-		}
-	}
 	return &Sheet{
 		Identity:               createIdentity(entity),
 		Misc:                   createMisc(entity),
@@ -613,9 +699,48 @@ func NewSheetFromEntity(entity *gurps.Entity, modified, readOnly bool) *Sheet {
 		CarriedEquipment:       createCarriedEquipment(entity),
 		OtherEquipment:         createOtherEquipment(entity),
 		Notes:                  createNotes(entity),
+		ChangeRequests:         createChangeRequests(entity),
 		Portrait:               entity.Profile.PortraitData,
-		PageRefs:               refs,
+		PageRefs:               createPageRefs(),
+		Page:                   createPage(entity),
 		Modified:               modified,
 		ReadOnly:               readOnly,
 	}
 }
+
+// Summary holds the data needed to display a brief overview of a GURPS character, such as the embeddable summary card.
+type Summary struct {
+	Identity            Identity
+	Misc                Misc
+	Points              Points
+	PrimaryAttributes   []Attribute
+	SecondaryAttributes []Attribute
+	PointPools          []PointPool
+	BasicDamage         BasicDamage
+	HasPortrait         bool
+}
+
+// NewSummaryFromEntity creates a new Summary from the given entity. Separators are omitted from the attribute lists.
+func NewSummaryFromEntity(entity *gurps.Entity) *Summary {
+	return &Summary{
+		Identity:            createIdentity(entity),
+		Misc:                createMisc(entity),
+		Points:              createPoints(entity),
+		PrimaryAttributes:   withoutSeparators(createPrimaryAttributes(entity), func(a Attribute) string { return a.Type }),
+		SecondaryAttributes: withoutSeparators(createSecondaryAttributes(entity), func(a Attribute) string { return a.Type }),
+		PointPools:          withoutSeparators(createPointPools(entity), func(p PointPool) string { return p.Type }),
+		BasicDamage:         createBasicDamage(entity),
+		HasPortrait:         len(entity.Profile.PortraitData) != 0,
+	}
+}
+
+func withoutSeparators[T any](list []T, typeOf func(T) string) []T {
+	return slices.DeleteFunc(list, func(one T) bool {
+		switch typeOf(one) {
+		case attribute.PrimarySeparator.Key(), attribute.SecondarySeparator.Key(), attribute.PoolSeparator.Key():
+			return true
+		default:
+			return false
+		}
+	})
+}
@@ -60,6 +60,7 @@ func (s *Server) installSheetHandlers() {
 	s.mux.HandleFunc("GET /api/sheet/{path...}", s.sheetHandler)
 	s.mux.HandleFunc("POST /api/sheet/{path...}", s.updateSheetHandler)
 	s.mux.HandleFunc("PUT /api/sheet/{path...}", s.saveSheetHandler)
+	s.mux.HandleFunc("POST /api/request/{path...}", s.changeRequestHandler)
 }
 
 func (s *Server) sheetsHandler(w http.ResponseWriter, r *http.Request) {
@@ -385,7 +386,7 @@ func (s *Server) loadSheet(w http.ResponseWriter, r *http.Request) (entity webEn
 		return entity, access, false
 	}
 	accessList := gurps.GlobalSettings().WebServer.AccessList(userName)
-	parts := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/api/sheet/"), "/", 2)
+	parts := strings.SplitN(r.PathValue("path"), "/", 2)
 	if len(parts) != 2 {
 		xhttp.ErrorStatus(w, http.StatusBadRequest)
 		return entity, access, false
@@ -394,12 +395,20 @@ func (s *Server) loadSheet(w http.ResponseWriter, r *http.Request) (entity webEn
 		xhttp.ErrorStatus(w, http.StatusNotFound)
 		return entity, access, false
 	}
-	parts[1] = filepath.Clean(parts[1])
-	if filepath.IsAbs(parts[1]) {
-		xhttp.ErrorStatus(w, http.StatusBadRequest)
+	if entity, ok = s.loadEntity(access, parts[1]); !ok {
+		xhttp.ErrorStatus(w, http.StatusNotFound)
 		return entity, access, false
 	}
-	entityPath := filepath.Join(access.Dir, parts[1])
+	return entity, access, true
+}
+
+// loadEntity returns the entity at the path within the access point, loading it if it isn't already in memory.
+func (s *Server) loadEntity(access websettings.Access, accessPath string) (entity webEntity, ok bool) {
+	accessPath = filepath.Clean(accessPath)
+	if filepath.IsAbs(accessPath) || accessPath == ".." || strings.HasPrefix(accessPath, ".."+string(filepath.Separator)) {
+		return entity, false
+	}
+	entityPath := filepath.Join(access.Dir, accessPath)
 	s.sheetsLock.Lock()
 	entity, ok = s.entitiesByPath[entityPath]
 	s.sheetsLock.Unlock()
@@ -407,13 +416,12 @@ func (s *Server) loadSheet(w http.ResponseWriter, r *http.Request) (entity webEn
 		loadedEntity, err := gurps.NewEntityFromFile(os.DirFS(filepath.Dir(entityPath)), filepath.Base(entityPath))
 		if err != nil {
 			slog.Error("error loading sheet", "path", entityPath, "error", err)
-			xhttp.ErrorStatus(w, http.StatusNotFound)
-			return entity, access, false
+			return entity, false
 		}
 		s.sheetsLock.Lock()
 		if entity, ok = s.entitiesByPath[entityPath]; !ok {
 			entity.ClientPath = entityPath
-			entity.AccessPath = parts[1]
+			entity.AccessPath = accessPath
 			entity.Entity = loadedEntity
 			entity.OriginalHash = gurps.Hash64(loadedEntity)
 			entity.CurrentHash = entity.OriginalHash
@@ -421,5 +429,5 @@ func (s *Server) loadSheet(w http.ResponseWriter, r *http.Request) (entity webEn
 		}
 		s.sheetsLock.Unlock()
 	}
-	return entity, access, true
+	return entity, true
 }
@@ -0,0 +1,150 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package server
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/server/websettings"
+	"github.com/richardwilkes/gcs/v5/server/websettings/tlsmode"
+	"github.com/richardwilkes/toolbox/cmdline"
+	"github.com/richardwilkes/toolbox/errs"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const (
+	selfSignedCertFile     = "self_signed_cert.pem"
+	selfSignedKeyFile      = "self_signed_key.pem"
+	selfSignedValidity     = 365 * 24 * time.Hour
+	selfSignedRenewalAhead = 30 * 24 * time.Hour
+)
+
+// TLSDir returns the directory the web server keeps the certificates it creates or obtains in.
+func TLSDir() string {
+	return filepath.Join(filepath.Dir(gurps.SettingsPath), "web_tls")
+}
+
+// tlsSetup returns the certificate files and TLS configuration the server should use. An empty certFile means the
+// server should not use TLS.
+func tlsSetup(settings *websettings.Settings) (certFile, keyFile string, config *tls.Config, err error) {
+	switch settings.TLSMode {
+	case tlsmode.Files:
+		return settings.CertFile, settings.KeyFile, nil, nil
+	case tlsmode.SelfSigned:
+		certFile, keyFile, err = ensureSelfSignedCert(TLSDir(), settings.Address)
+		return certFile, keyFile, nil, err
+	case tlsmode.Automatic:
+		// The certificate files are only used for clients that don't send the server name they are trying to reach,
+		// which the ACME certificates can't be selected for anyway. Everyone else gets the certificate obtained for
+		// the domain they asked for.
+		if certFile, keyFile, err = ensureSelfSignedCert(TLSDir(), settings.Address); err != nil {
+			return "", "", nil, err
+		}
+		m := &autocert.Manager{
+			Prompt:     autocert.AcceptTOS,
+			Cache:      autocert.DirCache(filepath.Join(TLSDir(), "acme")),
+			HostPolicy: autocert.HostWhitelist(settings.ACMEDomainList()...),
+			Email:      settings.ACMEEmail,
+		}
+		return certFile, keyFile, m.TLSConfig(), nil
+	default:
+		return "", "", nil, nil
+	}
+}
+
+// ensureSelfSignedCert returns the paths to a self-signed certificate and its key, creating them if they don't exist or
+// will expire soon.
+func ensureSelfSignedCert(dir, address string) (certFile, keyFile string, err error) {
+	certFile = filepath.Join(dir, selfSignedCertFile)
+	keyFile = filepath.Join(dir, selfSignedKeyFile)
+	// The parsed leaf isn't populated by tls.LoadX509KeyPair() prior to Go 1.23, so parse it here.
+	if pair, loadErr := tls.LoadX509KeyPair(certFile, keyFile); loadErr == nil && len(pair.Certificate) != 0 {
+		if leaf, parseErr := x509.ParseCertificate(pair.Certificate[0]); parseErr == nil &&
+			time.Until(leaf.NotAfter) > selfSignedRenewalAhead {
+			return certFile, keyFile, nil
+		}
+	}
+	var key *ecdsa.PrivateKey
+	if key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+		return "", "", errs.Wrap(err)
+	}
+	var serial *big.Int
+	if serial, err = rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128)); err != nil {
+		return "", "", errs.Wrap(err)
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{cmdline.AppName}},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(selfSignedValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+	template.DNSNames, template.IPAddresses = selfSignedHosts(address)
+	var certDER []byte
+	if certDER, err = x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key); err != nil {
+		return "", "", errs.Wrap(err)
+	}
+	var keyDER []byte
+	if keyDER, err = x509.MarshalPKCS8PrivateKey(key); err != nil {
+		return "", "", errs.Wrap(err)
+	}
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return "", "", errs.Wrap(err)
+	}
+	if err = os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600); err != nil {
+		return "", "", errs.Wrap(err)
+	}
+	if err = os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}), 0o644); err != nil {
+		return "", "", errs.Wrap(err)
+	}
+	return certFile, keyFile, nil
+}
+
+// selfSignedHosts returns the names and addresses a self-signed certificate should be valid for, which are those this
+// machine is likely to be reached by on a local network.
+func selfSignedHosts(address string) (dnsNames []string, ips []net.IP) {
+	dnsNames = []string{"localhost"}
+	if hostName, err := os.Hostname(); err == nil && hostName != "" {
+		dnsNames = append(dnsNames, hostName)
+	}
+	if host, _, err := net.SplitHostPort(address); err == nil && host != "" {
+		if ip := net.ParseIP(host); ip != nil {
+			ips = append(ips, ip)
+		} else if host != "localhost" {
+			dnsNames = append(dnsNames, host)
+		}
+	}
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLinkLocalUnicast() {
+				ips = append(ips, ipNet.IP)
+			}
+		}
+	}
+	if len(ips) == 0 {
+		ips = []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback}
+	}
+	return dnsNames, ips
+}
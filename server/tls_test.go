@@ -0,0 +1,33 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package server
+
+import (
+	"os"
+	"testing"
+
+	"github.com/richardwilkes/toolbox/check"
+)
+
+func TestSelfSignedCertReused(t *testing.T) {
+	dir := t.TempDir()
+	certFile, _, err := ensureSelfSignedCert(dir, "localhost:8422")
+	check.NoError(t, err)
+	var original []byte
+	original, err = os.ReadFile(certFile)
+	check.NoError(t, err)
+
+	_, _, err = ensureSelfSignedCert(dir, "localhost:8422")
+	check.NoError(t, err)
+	var current []byte
+	current, err = os.ReadFile(certFile)
+	check.NoError(t, err)
+	check.Equal(t, original, current, "a certificate that isn't close to expiring should be kept")
+}
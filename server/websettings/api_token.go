@@ -0,0 +1,41 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package websettings
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// APIToken holds an API token's information. An API token lets a client other than the web frontend act on behalf of a
+// user. Unlike a session, it does not expire and remains valid until it is revoked.
+type APIToken struct {
+	ID       tid.TID   `json:"id"`
+	Client   string    `json:"client"`
+	UserKey  string    `json:"user"`
+	Issued   time.Time `json:"issued"`
+	LastUsed time.Time `json:"last_used"`
+}
+
+// Clone creates a copy of this API token.
+func (t *APIToken) Clone() *APIToken {
+	other := *t
+	return &other
+}
+
+func (t *APIToken) String() string {
+	if t.LastUsed.Equal(t.Issued) {
+		return fmt.Sprintf(i18n.Text("%s [%s, never used]"), t.Client, t.UserKey)
+	}
+	return fmt.Sprintf(i18n.Text("%s [%s, last used %s]"), t.Client, t.UserKey, t.LastUsed.Format(time.DateOnly))
+}
@@ -0,0 +1,39 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package websettings
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+)
+
+// Embed holds an embedded summary's information. An embed publishes a read-only summary of a single character sheet at
+// a stable URL that anyone who knows it can view, so that it can be placed in other web pages. The sheet is located
+// through the user's access list at the time of each request, so removing the user's access also disables the embed.
+type Embed struct {
+	ID      tid.TID   `json:"id"`
+	UserKey string    `json:"user"`
+	Key     string    `json:"key"`
+	Path    string    `json:"path"`
+	Created time.Time `json:"created"`
+}
+
+// Clone creates a copy of this embed.
+func (e *Embed) Clone() *Embed {
+	other := *e
+	return &other
+}
+
+func (e *Embed) String() string {
+	return fmt.Sprintf(i18n.Text("%s/%s [%s]"), e.Key, e.Path, e.UserKey)
+}
@@ -18,10 +18,12 @@ import (
 	"strings"
 	"sync"
 	"time"
+	"unicode"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/jio"
 	"github.com/richardwilkes/gcs/v5/model/kinds"
+	"github.com/richardwilkes/gcs/v5/server/websettings/tlsmode"
 	"github.com/richardwilkes/json"
 	"github.com/richardwilkes/toolbox/tid"
 	"github.com/richardwilkes/toolbox/txt"
@@ -35,33 +37,49 @@ var (
 	DefaultIdleTimeout         = fxp.Sixty
 	MinimumTimeout             = fxp.One
 	MaximumTimeout             = fxp.SixHundred
+	DefaultSessionExpiry       = fxp.Thirty
+	MinimumSessionExpiry       = fxp.One
+	MaximumSessionExpiry       = fxp.From(365)
+	DefaultRateLimit           = fxp.From(600)
+	MinimumRateLimit           = fxp.Ten
+	MaximumRateLimit           = fxp.From(100000)
 	DefaultAddress             = "localhost:8422"
 )
 
 // Server holds the settings for the embedded web server.
 type Server struct {
-	Enabled             bool    `json:"enabled"`
-	Address             string  `json:"address"`
-	CertFile            string  `json:"cert_file,omitempty"`
-	KeyFile             string  `json:"key_file,omitempty"`
-	ShutdownGracePeriod fxp.Int `json:"shutdown_grace_period"`
-	ReadTimeout         fxp.Int `json:"read_timeout"`
-	WriteTimeout        fxp.Int `json:"write_timeout"`
-	IdleTimeout         fxp.Int `json:"idle_timeout"`
+	Enabled             bool         `json:"enabled"`
+	Address             string       `json:"address"`
+	TLSMode             tlsmode.Mode `json:"tls_mode,omitempty"`
+	CertFile            string       `json:"cert_file,omitempty"`
+	KeyFile             string       `json:"key_file,omitempty"`
+	ACMEDomains         string       `json:"acme_domains,omitempty"` // Separated by commas and/or spaces
+	ACMEEmail           string       `json:"acme_email,omitempty"`
+	ShutdownGracePeriod fxp.Int      `json:"shutdown_grace_period"`
+	ReadTimeout         fxp.Int      `json:"read_timeout"`
+	WriteTimeout        fxp.Int      `json:"write_timeout"`
+	IdleTimeout         fxp.Int      `json:"idle_timeout"`
+	SessionExpiry       fxp.Int      `json:"session_expiry,omitempty"` // In days
+	RateLimit           fxp.Int      `json:"rate_limit,omitempty"`     // In requests per minute per client
+	ShareLibraries      bool         `json:"share_libraries,omitempty"`
 }
 
 type wrapper struct {
 	Server
-	Users    map[string]*User     `json:"users,omitempty"`
-	Sessions map[tid.TID]*Session `json:"sessions,omitempty"`
+	Users     map[string]*User      `json:"users,omitempty"`
+	Sessions  map[tid.TID]*Session  `json:"sessions,omitempty"`
+	APITokens map[tid.TID]*APIToken `json:"api_tokens,omitempty"`
+	Embeds    map[tid.TID]*Embed    `json:"embeds,omitempty"`
 }
 
 // Settings holds the settings for the embedded web server.
 type Settings struct {
 	Server
-	lock     sync.RWMutex
-	users    map[string]*User
-	sessions map[tid.TID]*Session
+	lock      sync.RWMutex
+	users     map[string]*User
+	sessions  map[tid.TID]*Session
+	apiTokens map[tid.TID]*APIToken
+	embeds    map[tid.TID]*Embed
 }
 
 // Default returns the default settings.
@@ -73,9 +91,13 @@ func Default() *Settings {
 			ReadTimeout:         DefaultReadTimeout,
 			WriteTimeout:        DefaultWriteTimeout,
 			IdleTimeout:         DefaultIdleTimeout,
+			SessionExpiry:       DefaultSessionExpiry,
+			RateLimit:           DefaultRateLimit,
 		},
-		users:    make(map[string]*User),
-		sessions: make(map[tid.TID]*Session),
+		users:     make(map[string]*User),
+		sessions:  make(map[tid.TID]*Session),
+		apiTokens: make(map[tid.TID]*APIToken),
+		embeds:    make(map[tid.TID]*Embed),
 	}
 }
 
@@ -101,6 +123,17 @@ func (s *Settings) Valid() bool {
 	if _, _, err := net.SplitHostPort(s.Address); err != nil {
 		return false
 	}
+	switch s.TLSMode {
+	case tlsmode.Files:
+		if s.CertFile == "" || s.KeyFile == "" {
+			return false
+		}
+	case tlsmode.Automatic:
+		if len(s.ACMEDomainList()) == 0 {
+			return false
+		}
+	default:
+	}
 	if s.ShutdownGracePeriod < MinimumTimeout || s.ShutdownGracePeriod > MaximumTimeout {
 		return false
 	}
@@ -113,6 +146,12 @@ func (s *Settings) Valid() bool {
 	if s.IdleTimeout < MinimumTimeout || s.IdleTimeout > MaximumTimeout {
 		return false
 	}
+	if s.SessionExpiry < MinimumSessionExpiry || s.SessionExpiry > MaximumSessionExpiry {
+		return false
+	}
+	if s.RateLimit < MinimumRateLimit || s.RateLimit > MaximumRateLimit {
+		return false
+	}
 	return true
 }
 
@@ -121,10 +160,17 @@ func (s *Settings) Validate() {
 	if s.Address = strings.TrimSpace(s.Address); s.Address == "" {
 		s.Address = DefaultAddress
 	}
+	s.TLSMode = s.TLSMode.EnsureValid()
+	if s.TLSMode == tlsmode.None && s.CertFile != "" && s.KeyFile != "" {
+		// Settings from before the TLS mode existed only had certificate files
+		s.TLSMode = tlsmode.Files
+	}
 	s.ShutdownGracePeriod = validateWebTimeout(s.ShutdownGracePeriod, DefaultShutdownGracePeriod)
 	s.ReadTimeout = validateWebTimeout(s.ReadTimeout, DefaultReadTimeout)
 	s.WriteTimeout = validateWebTimeout(s.WriteTimeout, DefaultWriteTimeout)
 	s.IdleTimeout = validateWebTimeout(s.IdleTimeout, DefaultIdleTimeout)
+	s.SessionExpiry = validateInRange(s.SessionExpiry, DefaultSessionExpiry, MinimumSessionExpiry, MaximumSessionExpiry)
+	s.RateLimit = validateInRange(s.RateLimit, DefaultRateLimit, MinimumRateLimit, MaximumRateLimit)
 	s.lock.Lock()
 	if s.users == nil {
 		s.users = make(map[string]*User)
@@ -132,14 +178,24 @@ func (s *Settings) Validate() {
 	if s.sessions == nil {
 		s.sessions = make(map[tid.TID]*Session)
 	}
+	if s.apiTokens == nil {
+		s.apiTokens = make(map[tid.TID]*APIToken)
+	}
+	if s.embeds == nil {
+		s.embeds = make(map[tid.TID]*Embed)
+	}
 	s.lock.Unlock()
 }
 
 func validateWebTimeout(value, def fxp.Int) fxp.Int {
+	return validateInRange(value, def, MinimumTimeout, MaximumTimeout)
+}
+
+func validateInRange(value, def, minimum, maximum fxp.Int) fxp.Int {
 	if value == 0 {
 		return def
 	}
-	return min(max(value, MinimumTimeout), MaximumTimeout)
+	return min(max(value, minimum), maximum)
 }
 
 // CopyFrom copies the settings from the other Settings to this Settings object. If 'other' is nil, the default settings
@@ -158,10 +214,20 @@ func (s *Settings) CopyFrom(other *Settings) {
 	for id, session := range other.sessions {
 		sessions[id] = session.Clone()
 	}
+	apiTokens := make(map[tid.TID]*APIToken, len(other.apiTokens))
+	for id, token := range other.apiTokens {
+		apiTokens[id] = token.Clone()
+	}
+	embeds := make(map[tid.TID]*Embed, len(other.embeds))
+	for id, embed := range other.embeds {
+		embeds[id] = embed.Clone()
+	}
 	other.lock.RUnlock()
 	s.lock.Lock()
 	s.users = users
-	s.sessions = other.sessions
+	s.sessions = sessions
+	s.apiTokens = apiTokens
+	s.embeds = embeds
 	s.lock.Unlock()
 }
 
@@ -171,9 +237,11 @@ func (s *Settings) MarshalJSON() ([]byte, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 	return json.Marshal(&wrapper{
-		Server:   s.Server,
-		Users:    s.users,
-		Sessions: s.sessions,
+		Server:    s.Server,
+		Users:     s.users,
+		Sessions:  s.sessions,
+		APITokens: s.apiTokens,
+		Embeds:    s.embeds,
 	})
 }
 
@@ -189,10 +257,17 @@ func (s *Settings) UnmarshalJSON(data []byte) error {
 	s.Server = w.Server
 	s.users = w.Users
 	s.sessions = w.Sessions
+	s.apiTokens = w.APITokens
+	s.embeds = w.Embeds
 	s.pruneSessions()
 	return nil
 }
 
+// ACMEDomainList returns the domains to obtain certificates for when using automatic certificates.
+func (s *Server) ACMEDomainList() []string {
+	return strings.FieldsFunc(s.ACMEDomains, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+}
+
 // PruneSessions removes expired sessions.
 func (s *Settings) PruneSessions() {
 	s.lock.Lock()
@@ -202,8 +277,9 @@ func (s *Settings) PruneSessions() {
 
 func (s *Settings) pruneSessions() {
 	var sessionsToDelete []tid.TID
+	expiry := s.sessionExpiry()
 	for id, session := range s.sessions {
-		if session.Expired() {
+		if session.Expired(expiry) {
 			sessionsToDelete = append(sessionsToDelete, id)
 		}
 	}
@@ -268,6 +344,24 @@ func (s *Settings) RemoveUser(name string) {
 	for _, id := range keysToDelete {
 		delete(s.sessions, id)
 	}
+	keysToDelete = keysToDelete[:0]
+	for id, token := range s.apiTokens {
+		if token.UserKey == key {
+			keysToDelete = append(keysToDelete, id)
+		}
+	}
+	for _, id := range keysToDelete {
+		delete(s.apiTokens, id)
+	}
+	keysToDelete = keysToDelete[:0]
+	for id, embed := range s.embeds {
+		if embed.UserKey == key {
+			keysToDelete = append(keysToDelete, id)
+		}
+	}
+	for _, id := range keysToDelete {
+		delete(s.embeds, id)
+	}
 }
 
 // RenameUser renames a user. Returns true on success, false if the new name already exists or the user can't be found.
@@ -289,6 +383,16 @@ func (s *Settings) RenameUser(oldName, newName string) bool {
 				session.UserKey = newKey
 			}
 		}
+		for _, token := range s.apiTokens {
+			if token.UserKey == oldKey {
+				token.UserKey = newKey
+			}
+		}
+		for _, embed := range s.embeds {
+			if embed.UserKey == oldKey {
+				embed.UserKey = newKey
+			}
+		}
 	}
 	return true
 }
@@ -336,7 +440,7 @@ func (s *Settings) LookupSession(id tid.TID) (string, bool) {
 		return "", false
 	}
 	var user *User
-	if user, ok = s.users[session.UserKey]; !ok || session.Expired() {
+	if user, ok = s.users[session.UserKey]; !ok || session.Expired(s.sessionExpiry()) {
 		delete(s.sessions, id)
 		return "", false
 	}
@@ -364,3 +468,134 @@ func (s *Settings) RemoveSession(id tid.TID) {
 	defer s.lock.Unlock()
 	delete(s.sessions, id)
 }
+
+func (s *Settings) sessionExpiry() time.Duration {
+	return time.Duration(fxp.As[int64](validateInRange(s.SessionExpiry, DefaultSessionExpiry, MinimumSessionExpiry,
+		MaximumSessionExpiry))) * time.Hour * 24
+}
+
+// APITokens returns the API tokens, sorted by client name.
+func (s *Settings) APITokens() []*APIToken {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	tokens := make([]*APIToken, 0, len(s.apiTokens))
+	for _, token := range s.apiTokens {
+		tokens = append(tokens, token.Clone())
+	}
+	slices.SortStableFunc(tokens, func(a, b *APIToken) int {
+		if result := txt.NaturalCmp(a.Client, b.Client, true); result != 0 {
+			return result
+		}
+		return a.Issued.Compare(b.Issued)
+	})
+	return tokens
+}
+
+// CreateAPIToken creates an API token for a client to use on behalf of the user. Returns false if the user can't be
+// found.
+func (s *Settings) CreateAPIToken(userName, client string) (tid.TID, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	key := UserNameToKey(userName)
+	if _, exists := s.users[key]; !exists {
+		return "", false
+	}
+	now := time.Now()
+	token := &APIToken{
+		ID:       tid.MustNewTID(kinds.APIToken),
+		Client:   client,
+		UserKey:  key,
+		Issued:   now,
+		LastUsed: now,
+	}
+	s.apiTokens[token.ID] = token
+	return token.ID, true
+}
+
+// LookupAPIToken looks up an API token, updating its last used time and returning the user's name if found.
+func (s *Settings) LookupAPIToken(id tid.TID) (string, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	token, ok := s.apiTokens[id]
+	if !ok {
+		return "", false
+	}
+	var user *User
+	if user, ok = s.users[token.UserKey]; !ok {
+		delete(s.apiTokens, id)
+		return "", false
+	}
+	token.LastUsed = time.Now()
+	return user.Name, true
+}
+
+// RevokeAPIToken removes an API token.
+func (s *Settings) RevokeAPIToken(id tid.TID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.apiTokens, id)
+}
+
+// Embeds returns the embeds, sorted by path.
+func (s *Settings) Embeds() []*Embed {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	embeds := make([]*Embed, 0, len(s.embeds))
+	for _, embed := range s.embeds {
+		embeds = append(embeds, embed.Clone())
+	}
+	slices.SortStableFunc(embeds, func(a, b *Embed) int {
+		if result := txt.NaturalCmp(a.Key+"/"+a.Path, b.Key+"/"+b.Path, true); result != 0 {
+			return result
+		}
+		return txt.NaturalCmp(a.UserKey, b.UserKey, true)
+	})
+	return embeds
+}
+
+// EmbedFor returns the embed for the target, creating it if it doesn't exist yet. Returns false if the target's user
+// can't be found.
+func (s *Settings) EmbedFor(target *ShareTarget) (tid.TID, bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	key := UserNameToKey(target.UserName)
+	if _, exists := s.users[key]; !exists {
+		return "", false
+	}
+	for _, embed := range s.embeds {
+		if embed.UserKey == key && embed.Key == target.Key && embed.Path == target.Path {
+			return embed.ID, true
+		}
+	}
+	embed := &Embed{
+		ID:      tid.MustNewTID(kinds.Embed),
+		UserKey: key,
+		Key:     target.Key,
+		Path:    target.Path,
+		Created: time.Now(),
+	}
+	s.embeds[embed.ID] = embed
+	return embed.ID, true
+}
+
+// LookupEmbed looks up an embed, returning a copy of it along with the user's name if found.
+func (s *Settings) LookupEmbed(id tid.TID) (embed *Embed, userName string, ok bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if embed, ok = s.embeds[id]; !ok {
+		return nil, "", false
+	}
+	var user *User
+	if user, ok = s.users[embed.UserKey]; !ok {
+		delete(s.embeds, id)
+		return nil, "", false
+	}
+	return embed.Clone(), user.Name, true
+}
+
+// RemoveEmbed removes an embed.
+func (s *Settings) RemoveEmbed(id tid.TID) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	delete(s.embeds, id)
+}
@@ -15,11 +15,9 @@ import (
 	"github.com/richardwilkes/toolbox/tid"
 )
 
-// Constants
-const (
-	MaxSessionDuration = time.Hour * 24 * 30
-	SessionGracePeriod = time.Minute * 10
-)
+// SessionGracePeriod is the amount of time a session that has reached its expiry may continue to be used, provided it
+// is in active use.
+const SessionGracePeriod = time.Minute * 10
 
 // Session holds a session's information.
 type Session struct {
@@ -35,7 +33,7 @@ func (s *Session) Clone() *Session {
 	return &other
 }
 
-// Expired returns true if this session has expired.
-func (s *Session) Expired() bool {
-	return time.Since(s.Issued) > MaxSessionDuration && time.Since(s.LastUsed) > SessionGracePeriod
+// Expired returns true if this session has expired, given the maximum amount of time it may exist.
+func (s *Session) Expired(expiry time.Duration) bool {
+	return time.Since(s.Issued) > expiry && time.Since(s.LastUsed) > SessionGracePeriod
 }
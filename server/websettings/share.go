@@ -0,0 +1,127 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package websettings
+
+import (
+	"net"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/server/websettings/tlsmode"
+)
+
+// ShareTarget identifies a user that has access to a file, along with the path by which that user refers to it.
+type ShareTarget struct {
+	UserName string
+	Key      string
+	Path     string
+	ReadOnly bool
+}
+
+func (t *ShareTarget) String() string {
+	return t.UserName
+}
+
+// ShareTargets returns the users that have access to the file, sorted by name. A user with more than one access point
+// that contains the file will only be returned once.
+func (s *Settings) ShareTargets(filePath string) []*ShareTarget {
+	if filePath == "" {
+		return nil
+	}
+	var targets []*ShareTarget
+	for _, user := range s.Users() {
+		for _, access := range user.AccessListWithKeys() {
+			rel, err := filepath.Rel(access.Dir, filePath)
+			if err != nil || filepath.IsAbs(rel) || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				continue
+			}
+			targets = append(targets, &ShareTarget{
+				UserName: user.Name,
+				Key:      access.Key,
+				Path:     filepath.ToSlash(rel),
+				ReadOnly: access.ReadOnly,
+			})
+			break
+		}
+	}
+	return targets
+}
+
+// ShareURL creates a new session for the target's user and returns a URL that will open the file in the web client
+// using it. Also returns true if the server is only listening on the loopback interface, in which case the URL will not
+// be reachable from other devices.
+func (s *Settings) ShareURL(target *ShareTarget) (shareURL string, localOnly bool) {
+	var u url.URL
+	u, localOnly = s.baseURL()
+	u.RawQuery = url.Values{"token": {string(s.CreateSession(target.UserName))}}.Encode()
+	u.Fragment = "sheet/" + target.Key + "/" + target.Path
+	return u.String(), localOnly
+}
+
+// EmbedURL returns the URL of the embeddable summary of the target's file, creating the embed if needed. The URL stays
+// the same for as long as the embed exists. Also returns true if the server is only listening on the loopback
+// interface, in which case the URL will not be reachable from other devices.
+func (s *Settings) EmbedURL(target *ShareTarget) (embedURL string, localOnly bool) {
+	id, ok := s.EmbedFor(target)
+	if !ok {
+		return "", false
+	}
+	var u url.URL
+	u, localOnly = s.baseURL()
+	u.Path = "/embed/" + string(id)
+	return u.String(), localOnly
+}
+
+// baseURL returns the URL of the server's root, along with whether the server is only listening on the loopback
+// interface.
+func (s *Settings) baseURL() (u url.URL, localOnly bool) {
+	host, port, err := net.SplitHostPort(s.Address)
+	if err != nil {
+		host = s.Address
+	}
+	switch {
+	case host == "" || host == "0.0.0.0" || host == "::":
+		host = outboundHost()
+	case host == "localhost":
+		localOnly = true
+	default:
+		if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+			localOnly = true
+		}
+	}
+	if port != "" {
+		host = net.JoinHostPort(host, port)
+	}
+	u = url.URL{
+		Scheme: "http",
+		Host:   host,
+		Path:   "/",
+	}
+	if s.TLSMode != tlsmode.None {
+		u.Scheme = "https"
+	}
+	return u, localOnly
+}
+
+// outboundHost returns the first non-loopback IPv4 address of this machine, falling back to "localhost" if there isn't
+// one.
+func outboundHost() string {
+	if addrs, err := net.InterfaceAddrs(); err == nil {
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && !ipNet.IP.IsLoopback() && !ipNet.IP.IsLinkLocalUnicast() {
+				if ip4 := ipNet.IP.To4(); ip4 != nil {
+					return ip4.String()
+				}
+			}
+		}
+	}
+	return "localhost"
+}
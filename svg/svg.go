@@ -111,6 +111,10 @@ var (
 	gcsSkillsData string
 	GCSSkills     = unison.MustSVGFromContentString(gcsSkillsData)
 
+	//go:embed gcs_spaceship.svg
+	gcsSpaceshipData string
+	GCSSpaceship     = unison.MustSVGFromContentString(gcsSpaceshipData)
+
 	//go:embed gcs_spells.svg
 	gcsSpellsData string
 	GCSSpells     = unison.MustSVGFromContentString(gcsSpellsData)
@@ -30,32 +30,52 @@ var licenseMarkdownContent string
 // These actions are registered for key bindings.
 var (
 	addNaturalAttacksAction        *unison.Action
+	addQualityModifiersAction      *unison.Action
+	addReputationAction            *unison.Action
+	addToFavoritesAction           *unison.Action
 	applyTemplateAction            *unison.Action
+	archiveCharacterAction         *unison.Action
+	areaAttackAction               *unison.Action
+	bulkEditAction                 *unison.Action
+	buyUpFromDefaultAction         *unison.Action
+	characterArchivesAction        *unison.Action
 	clearPortraitAction            *unison.Action
 	clearSourceAction              *unison.Action
 	closeTabAction                 *unison.Action
 	colorSettingsAction            *unison.Action
+	commandPaletteAction           *unison.Action
+	compareWithFileAction          *unison.Action
+	compareWithSavedAction         *unison.Action
 	convertToContainerAction       *unison.Action
 	convertToNonContainerAction    *unison.Action
 	copyToSheetAction              *unison.Action
 	copyToTemplateAction           *unison.Action
+	countCoinsAction               *unison.Action
 	decreaseEquipmentLevelAction   *unison.Action
 	decreaseSkillLevelAction       *unison.Action
 	decreaseTechLevelAction        *unison.Action
 	decreaseUsesAction             *unison.Action
 	decrementAction                *unison.Action
+	deductCostOfLivingAction       *unison.Action
 	defaultAttributeSettingsAction *unison.Action
 	defaultBodyTypeSettingsAction  *unison.Action
 	defaultSheetSettingsAction     *unison.Action
+	deleteWorkspaceLayoutAction    *unison.Action
 	dockUnDockAction               *unison.Action
 	duplicateAction                *unison.Action
+	editCulturesAction             *unison.Action
+	exportAsCardsAction            *unison.Action
 	exportAsJPEGAction             *unison.Action
 	exportAsPDFAction              *unison.Action
 	exportAsPNGAction              *unison.Action
 	exportAsWEBPAction             *unison.Action
 	exportPortraitAction           *unison.Action
+	findLibraryDuplicatesAction    *unison.Action
+	findReplaceAction              *unison.Action
+	fireWeaponAction               *unison.Action
 	fontSettingsAction             *unison.Action
 	generalSettingsAction          *unison.Action
+	generateNPCAction              *unison.Action
 	increaseEquipmentLevelAction   *unison.Action
 	increaseSkillLevelAction       *unison.Action
 	increaseTechLevelAction        *unison.Action
@@ -63,6 +83,11 @@ var (
 	incrementAction                *unison.Action
 	jumpToSearchFilterAction       *unison.Action
 	menuKeySettingsAction          *unison.Action
+	addToLoadoutAction             *unison.Action
+	deleteLoadoutAction            *unison.Action
+	newLoadoutAction               *unison.Action
+	removeFromLoadoutAction        *unison.Action
+	openLinkedCharacterAction      *unison.Action
 	moveToCarriedEquipmentAction   *unison.Action
 	moveToOtherEquipmentAction     *unison.Action
 	// TODO: Re-enable Campaign files
@@ -75,6 +100,7 @@ var (
 	newEquipmentLibraryAction           *unison.Action
 	newEquipmentModifierAction          *unison.Action
 	newEquipmentModifiersLibraryAction  *unison.Action
+	newLanguageAction                   *unison.Action
 	newMarkdownFileAction               *unison.Action
 	newMeleeWeaponAction                *unison.Action
 	newNoteAction                       *unison.Action
@@ -88,6 +114,7 @@ var (
 	newSkillAction                      *unison.Action
 	newSkillContainerAction             *unison.Action
 	newSkillsLibraryAction              *unison.Action
+	newSpaceshipAction                  *unison.Action
 	newSpellAction                      *unison.Action
 	newSpellContainerAction             *unison.Action
 	newSpellsLibraryAction              *unison.Action
@@ -106,10 +133,14 @@ var (
 	perSheetAttributeSettingsAction     *unison.Action
 	perSheetBodyTypeSettingsAction      *unison.Action
 	perSheetSettingsAction              *unison.Action
+	planTimeUseAction                   *unison.Action
 	printAction                         *unison.Action
 	redoAction                          *unison.Action
+	reloadWeaponAction                  *unison.Action
+	reviewChangeRequestsAction          *unison.Action
 	saveAction                          *unison.Action
 	saveAsAction                        *unison.Action
+	saveWorkspaceLayoutAction           *unison.Action
 	scale100Action                      *unison.Action
 	scale200Action                      *unison.Action
 	scale25Action                       *unison.Action
@@ -122,24 +153,37 @@ var (
 	scaleDefaultAction                  *unison.Action
 	scaleDownAction                     *unison.Action
 	scaleUpAction                       *unison.Action
+	searchLibrariesAction               *unison.Action
+	shareAsEmbedAction                  *unison.Action
+	shareAsQRCodeAction                 *unison.Action
+	showFavoritesAction                 *unison.Action
+	splitDownAction                     *unison.Action
+	splitRightAction                    *unison.Action
+	startNewEncounterAction             *unison.Action
 	syncWithSourceAction                *unison.Action
 	swapDefaultsAction                  *unison.Action
+	targetWeaponAction                  *unison.Action
+	togglePlayModeAction                *unison.Action
 	toggleStateAction                   *unison.Action
+	toggleUnfamiliarCultureAction       *unison.Action
 	undoAction                          *unison.Action
+	undoHistoryAction                   *unison.Action
+	validateCharacterAction             *unison.Action
 	webSettingsAction                   *unison.Action
 )
 
 // These actions aren't registered for key bindings.
 var (
-	checkForAppUpdatesAction *unison.Action
-	licenseAction            *unison.Action
-	mailingListAction        *unison.Action
-	makeDonationAction       *unison.Action
-	releaseNotesAction       *unison.Action
-	sponsorDevelopmentAction *unison.Action
-	updateAppStatusAction    *unison.Action
-	webSiteAction            *unison.Action
-	userGuideAction          *unison.Action
+	checkForAppUpdatesAction  *unison.Action
+	createSupportBundleAction *unison.Action
+	licenseAction             *unison.Action
+	mailingListAction         *unison.Action
+	makeDonationAction        *unison.Action
+	releaseNotesAction        *unison.Action
+	sponsorDevelopmentAction  *unison.Action
+	updateAppStatusAction     *unison.Action
+	webSiteAction             *unison.Action
+	userGuideAction           *unison.Action
 )
 
 func registerActions() {
@@ -157,6 +201,24 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	addQualityModifiersAction = registerKeyBindableAction("add.quality.modifiers", &unison.Action{
+		ID:              AddQualityModifiersItemID,
+		Title:           i18n.Text("Add Quality & Material Modifiers"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	addReputationAction = registerKeyBindableAction("add.reputation", &unison.Action{
+		ID:              AddReputationItemID,
+		Title:           i18n.Text("Add Reputation…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	addToFavoritesAction = registerKeyBindableAction("add.favorites", &unison.Action{
+		ID:              AddToFavoritesItemID,
+		Title:           i18n.Text("Add to Favorites"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	applyTemplateAction = registerKeyBindableAction("apply.template", &unison.Action{
 		ID:              ApplyTemplateItemID,
 		Title:           i18n.Text("Apply Template to Character Sheet"),
@@ -164,6 +226,18 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	archiveCharacterAction = registerKeyBindableAction("archive.character", &unison.Action{
+		ID:              ArchiveCharacterItemID,
+		Title:           i18n.Text("Archive Character…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	areaAttackAction = registerKeyBindableAction("area.attack", &unison.Action{
+		ID:              AreaAttackItemID,
+		Title:           i18n.Text("Explosion & Area Attack Calculator…"),
+		EnabledCallback: func(_ *unison.Action, _ any) bool { return len(OpenSheets(nil)) != 0 },
+		ExecuteCallback: func(_ *unison.Action, _ any) { displayAreaAttack() },
+	})
 	newSheetFromTemplateAction = registerKeyBindableAction("new.sheet.from.template", &unison.Action{
 		ID:              NewSheetFromTemplateItemID,
 		Title:           i18n.Text("New Character Sheet from Template"),
@@ -177,6 +251,11 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	characterArchivesAction = registerKeyBindableAction("character.archives", &unison.Action{
+		ID:              CharacterArchivesItemID,
+		Title:           i18n.Text("Character Archives…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowCharacterArchives() },
+	})
 	clearPortraitAction = registerKeyBindableAction("clear.portrait", &unison.Action{
 		ID:              ClearPortraitItemID,
 		Title:           i18n.Text("Clear Portrait"),
@@ -224,6 +303,24 @@ func registerActions() {
 		Title:           i18n.Text("Colors…"),
 		ExecuteCallback: func(_ *unison.Action, _ any) { ShowColorSettings() },
 	})
+	commandPaletteAction = registerKeyBindableAction("command.palette", &unison.Action{
+		ID:              CommandPaletteItemID,
+		Title:           i18n.Text("Command Palette…"),
+		KeyBinding:      unison.KeyBinding{KeyCode: unison.KeyP, Modifiers: unison.OptionModifier | unison.OSMenuCmdModifier()},
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowCommandPalette() },
+	})
+	compareWithFileAction = registerKeyBindableAction("compare.with.file", &unison.Action{
+		ID:              CompareWithFileItemID,
+		Title:           i18n.Text("Compare with File…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	compareWithSavedAction = registerKeyBindableAction("compare.with.saved", &unison.Action{
+		ID:              CompareWithSavedItemID,
+		Title:           i18n.Text("Compare with Saved Version"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	convertToContainerAction = registerKeyBindableAction("convert.to_container", &unison.Action{
 		ID:              ConvertToContainerItemID,
 		Title:           i18n.Text("Convert to Container"),
@@ -284,6 +381,18 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	deductCostOfLivingAction = registerKeyBindableAction("deduct.cost.of.living", &unison.Action{
+		ID:              DeductCostOfLivingItemID,
+		Title:           i18n.Text("Deduct Cost of Living…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	countCoinsAction = registerKeyBindableAction("count.coins", &unison.Action{
+		ID:              CountCoinsItemID,
+		Title:           i18n.Text("Count Coins…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	defaultAttributeSettingsAction = registerKeyBindableAction("settings.attributes.default", &unison.Action{
 		ID:              DefaultAttributeSettingsItemID,
 		Title:           i18n.Text("Default Attributes…"),
@@ -300,6 +409,12 @@ func registerActions() {
 		KeyBinding:      unison.KeyBinding{KeyCode: unison.KeyComma, Modifiers: unison.OSMenuCmdModifier()},
 		ExecuteCallback: func(_ *unison.Action, _ any) { ShowSheetSettings(nil) },
 	})
+	deleteWorkspaceLayoutAction = registerKeyBindableAction("workspace.layout.delete", &unison.Action{
+		ID:              DeleteWorkspaceLayoutItemID,
+		Title:           i18n.Text("Delete Workspace Layout…"),
+		EnabledCallback: func(_ *unison.Action, _ any) bool { return len(gurps.GlobalSettings().WorkspaceLayouts) != 0 },
+		ExecuteCallback: func(_ *unison.Action, _ any) { deleteWorkspaceLayout() },
+	})
 	dockUnDockAction = registerKeyBindableAction("dock_undock", &unison.Action{
 		ID:              DockUnDockItemID,
 		Title:           i18n.Text("Undock From Workspace"),
@@ -314,6 +429,18 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	editCulturesAction = registerKeyBindableAction("edit.cultures", &unison.Action{
+		ID:              EditCulturesItemID,
+		Title:           i18n.Text("Cultural Familiarities…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	exportAsCardsAction = registerKeyBindableAction("export.cards", &unison.Action{
+		ID:              ExportAsCardsItemID,
+		Title:           i18n.Text("Cards for Selection (PDF)"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	exportAsJPEGAction = registerKeyBindableAction("export.jpeg", &unison.Action{
 		ID:              ExportAsJPEGItemID,
 		Title:           i18n.Text("JPEG"),
@@ -346,6 +473,12 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	fireWeaponAction = registerKeyBindableAction("fire.weapon", &unison.Action{
+		ID:              FireWeaponItemID,
+		Title:           i18n.Text("Fire Weapon"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	fontSettingsAction = registerKeyBindableAction("settings.fonts", &unison.Action{
 		ID:              FontSettingsItemID,
 		Title:           i18n.Text("Fonts…"),
@@ -356,6 +489,11 @@ func registerActions() {
 		Title:           i18n.Text("General Settings…"),
 		ExecuteCallback: func(_ *unison.Action, _ any) { ShowGeneralSettings() },
 	})
+	generateNPCAction = registerKeyBindableAction("generate.npc", &unison.Action{
+		ID:              GenerateNPCItemID,
+		Title:           i18n.Text("Generate NPC…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { GenerateNPC() },
+	})
 	webSettingsAction = registerKeyBindableAction("settings.web", &unison.Action{
 		ID:              WebSettingsItemID,
 		Title:           i18n.Text("Web Server Settings…"),
@@ -412,6 +550,36 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	newLoadoutAction = registerKeyBindableAction("loadout.new", &unison.Action{
+		ID:              NewLoadoutItemID,
+		Title:           i18n.Text("New Loadout from Selection…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	addToLoadoutAction = registerKeyBindableAction("loadout.add", &unison.Action{
+		ID:              AddToLoadoutItemID,
+		Title:           i18n.Text("Add to Current Loadout"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	removeFromLoadoutAction = registerKeyBindableAction("loadout.remove", &unison.Action{
+		ID:              RemoveFromLoadoutItemID,
+		Title:           i18n.Text("Remove from Current Loadout"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	deleteLoadoutAction = registerKeyBindableAction("loadout.delete", &unison.Action{
+		ID:              DeleteLoadoutItemID,
+		Title:           i18n.Text("Delete Current Loadout"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	openLinkedCharacterAction = registerKeyBindableAction("linked.open", &unison.Action{
+		ID:              OpenLinkedCharacterItemID,
+		Title:           i18n.Text("Open Linked Character"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	newCarriedEquipmentAction = registerKeyBindableAction("new.eqp", &unison.Action{
 		ID:              NewCarriedEquipmentItemID,
 		Title:           i18n.Text("New Carried Equipment"),
@@ -490,6 +658,12 @@ func registerActions() {
 			}
 		},
 	})
+	newLanguageAction = registerKeyBindableAction("new.language", &unison.Action{
+		ID:              NewLanguageItemID,
+		Title:           i18n.Text("New Language"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	newMeleeWeaponAction = registerKeyBindableAction("new.melee", &unison.Action{
 		ID:              NewMeleeWeaponItemID,
 		Title:           i18n.Text("New Melee Weapon"),
@@ -567,6 +741,13 @@ func registerActions() {
 			DisplayNewDockable(NewSkillTableDockable("Skills"+gurps.SkillsExt, nil))
 		},
 	})
+	newSpaceshipAction = registerKeyBindableAction("new.spaceship", &unison.Action{
+		ID:    NewSpaceshipItemID,
+		Title: i18n.Text("New Spaceship"),
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			DisplayNewDockable(NewSpaceship("untitled"+gurps.SpaceshipExt, gurps.NewSpaceship()))
+		},
+	})
 	newSpellAction = registerKeyBindableAction("new.spl", &unison.Action{
 		ID:              NewSpellItemID,
 		Title:           i18n.Text("New Spell"),
@@ -686,7 +867,7 @@ func registerActions() {
 	perSheetAttributeSettingsAction = registerKeyBindableAction("settings.attributes.per_sheet", &unison.Action{
 		ID:              PerSheetAttributeSettingsItemID,
 		Title:           i18n.Text("Attributes…"),
-		EnabledCallback: actionEnabledForSheet,
+		EnabledCallback: actionEnabledForEditableSheet,
 		ExecuteCallback: func(_ *unison.Action, _ any) {
 			if s := ActiveSheet(); s != nil {
 				ShowAttributeSettings(s)
@@ -696,7 +877,7 @@ func registerActions() {
 	perSheetBodyTypeSettingsAction = registerKeyBindableAction("settings.body_type.per_sheet", &unison.Action{
 		ID:              PerSheetBodyTypeSettingsItemID,
 		Title:           i18n.Text("Body Type…"),
-		EnabledCallback: actionEnabledForSheet,
+		EnabledCallback: actionEnabledForEditableSheet,
 		ExecuteCallback: func(_ *unison.Action, _ any) {
 			if s := ActiveSheet(); s != nil {
 				ShowBodySettings(s)
@@ -707,13 +888,19 @@ func registerActions() {
 		ID:              PerSheetSettingsItemID,
 		Title:           i18n.Text("Sheet Settings…"),
 		KeyBinding:      unison.KeyBinding{KeyCode: unison.KeyComma, Modifiers: unison.ShiftModifier | unison.OSMenuCmdModifier()},
-		EnabledCallback: actionEnabledForSheet,
+		EnabledCallback: actionEnabledForEditableSheet,
 		ExecuteCallback: func(_ *unison.Action, _ any) {
 			if s := ActiveSheet(); s != nil {
 				ShowSheetSettings(s)
 			}
 		},
 	})
+	planTimeUseAction = registerKeyBindableAction("plan.time.use", &unison.Action{
+		ID:              PlanTimeUseItemID,
+		Title:           i18n.Text("Plan Time Use…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	printAction = registerKeyBindableAction("print", &unison.Action{
 		ID:              PrintItemID,
 		Title:           i18n.Text("Print…"),
@@ -743,6 +930,18 @@ func registerActions() {
 			}
 		},
 	})
+	reloadWeaponAction = registerKeyBindableAction("reload.weapon", &unison.Action{
+		ID:              ReloadWeaponItemID,
+		Title:           i18n.Text("Reload Weapon"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	reviewChangeRequestsAction = registerKeyBindableAction("review.change.requests", &unison.Action{
+		ID:              ReviewChangeRequestsItemID,
+		Title:           i18n.Text("Review Change Requests…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	saveAction = registerKeyBindableAction("save", &unison.Action{
 		ID:              SaveItemID,
 		Title:           i18n.Text("Save"),
@@ -757,6 +956,11 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	saveWorkspaceLayoutAction = registerKeyBindableAction("workspace.layout.save", &unison.Action{
+		ID:              SaveWorkspaceLayoutItemID,
+		Title:           i18n.Text("Save Workspace Layout…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { saveWorkspaceLayout() },
+	})
 	scale25Action = registerKeyBindableAction("scale.25", &unison.Action{
 		ID:              Scale25ItemID,
 		Title:           i18n.Text("25% Scale"),
@@ -841,6 +1045,51 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	searchLibrariesAction = registerKeyBindableAction("search.libraries", &unison.Action{
+		ID:              SearchLibrariesItemID,
+		Title:           i18n.Text("Search Libraries…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowLibrarySearch() },
+	})
+	findLibraryDuplicatesAction = registerKeyBindableAction("find.library.duplicates", &unison.Action{
+		ID:              FindLibraryDuplicatesItemID,
+		Title:           i18n.Text("Find Library Duplicates"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowLibraryDuplicates() },
+	})
+	shareAsEmbedAction = registerKeyBindableAction("share.embed", &unison.Action{
+		ID:              ShareAsEmbedItemID,
+		Title:           i18n.Text("Share as Embeddable Summary…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	shareAsQRCodeAction = registerKeyBindableAction("share.qr_code", &unison.Action{
+		ID:              ShareAsQRCodeItemID,
+		Title:           i18n.Text("Share as QR Code…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	showFavoritesAction = registerKeyBindableAction("show.favorites", &unison.Action{
+		ID:              ShowFavoritesItemID,
+		Title:           i18n.Text("Favorites"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowFavorites() },
+	})
+	splitDownAction = registerKeyBindableAction("split.down", &unison.Action{
+		ID:              SplitDownItemID,
+		Title:           i18n.Text("Split Down"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	splitRightAction = registerKeyBindableAction("split.right", &unison.Action{
+		ID:              SplitRightItemID,
+		Title:           i18n.Text("Split Right"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	startNewEncounterAction = registerKeyBindableAction("start.new.encounter", &unison.Action{
+		ID:              StartNewEncounterItemID,
+		Title:           i18n.Text("Start New Encounter"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	syncWithSourceAction = registerKeyBindableAction("clear.sync", &unison.Action{
 		ID:              SyncWithSourceItemID,
 		Title:           i18n.Text("Sync with Source"),
@@ -854,6 +1103,42 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	targetWeaponAction = registerKeyBindableAction("target.weapon", &unison.Action{
+		ID:              TargetWeaponItemID,
+		Title:           i18n.Text("Set Range & Size Modifiers…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	bulkEditAction = registerKeyBindableAction("bulk.edit", &unison.Action{
+		ID:              BulkEditItemID,
+		Title:           i18n.Text("Bulk Edit…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	findReplaceAction = registerKeyBindableAction("find.replace", &unison.Action{
+		ID:              FindReplaceItemID,
+		Title:           i18n.Text("Find & Replace…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	buyUpFromDefaultAction = registerKeyBindableAction("buy.up.from.default", &unison.Action{
+		ID:              BuyUpFromDefaultItemID,
+		Title:           i18n.Text("Buy Up From Default"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	validateCharacterAction = registerKeyBindableAction("validate.character", &unison.Action{
+		ID:              ValidateCharacterItemID,
+		Title:           i18n.Text("Validate Character…"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
+	togglePlayModeAction = registerKeyBindableAction("toggle.play.mode", &unison.Action{
+		ID:              TogglePlayModeItemID,
+		Title:           i18n.Text("Toggle Play Mode"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	toggleStateAction = registerKeyBindableAction("toggle", &unison.Action{
 		ID:              ToggleStateItemID,
 		Title:           i18n.Text("Toggle State"),
@@ -861,6 +1146,12 @@ func registerActions() {
 		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
 		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
 	})
+	toggleUnfamiliarCultureAction = registerKeyBindableAction("toggle.unfamiliar.culture", &unison.Action{
+		ID:              ToggleUnfamiliarCultureItemID,
+		Title:           i18n.Text("Toggle Unfamiliar Culture Penalty"),
+		EnabledCallback: unison.RouteActionToFocusEnabledFunc,
+		ExecuteCallback: unison.RouteActionToFocusExecuteFunc,
+	})
 	undoAction = registerKeyBindableAction("undo", &unison.Action{
 		ID:         UndoItemID,
 		Title:      unison.CannotUndoTitle(),
@@ -883,6 +1174,12 @@ func registerActions() {
 			}
 		},
 	})
+	undoHistoryAction = registerKeyBindableAction("undo.history", &unison.Action{
+		ID:              UndoHistoryItemID,
+		Title:           i18n.Text("Undo History…"),
+		EnabledCallback: func(_ *unison.Action, _ any) bool { return CanShowUndoHistory(ActiveDockable()) },
+		ExecuteCallback: func(_ *unison.Action, _ any) { ShowUndoHistory(ActiveDockable()) },
+	})
 
 	// Actions that may not be assigned a key binding
 	checkForAppUpdatesAction = &unison.Action{
@@ -897,6 +1194,11 @@ func registerActions() {
 			CheckForAppUpdates()
 		},
 	}
+	createSupportBundleAction = &unison.Action{
+		ID:              CreateSupportBundleItemID,
+		Title:           i18n.Text("Create Support Bundle…"),
+		ExecuteCallback: func(_ *unison.Action, _ any) { createSupportBundle() },
+	}
 	licenseAction = &unison.Action{
 		ID:    LicenseItemID,
 		Title: i18n.Text("License"),
@@ -973,6 +1275,11 @@ func actionEnabledForSheet(_ *unison.Action, _ any) bool {
 	return ActiveSheet() != nil
 }
 
+func actionEnabledForEditableSheet(_ *unison.Action, _ any) bool {
+	s := ActiveSheet()
+	return s != nil && s.archive == nil
+}
+
 func showWebPage(uri string) {
 	if err := desktop.Open(uri); err != nil {
 		unison.ErrorDialogWithError(i18n.Text("Unable to open link"), err)
@@ -87,7 +87,7 @@ func adjustRawPoints[T gurps.NodeTypes](owner Rebuildable, table *unison.Table[*
 			} else {
 				name = i18n.Text("Decrement Points")
 			}
-			mgr.Add(&unison.UndoEdit[*adjustRawPointsList[T]]{
+			addUndo(mgr, &unison.UndoEdit[*adjustRawPointsList[T]]{
 				ID:         unison.NextUndoID(),
 				EditName:   name,
 				UndoFunc:   func(edit *unison.UndoEdit[*adjustRawPointsList[T]]) { edit.BeforeData.Apply() },
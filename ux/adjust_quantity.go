@@ -89,7 +89,7 @@ func adjustQuantity(owner Rebuildable, table *unison.Table[*Node[*gurps.Equipmen
 			} else {
 				name = i18n.Text("Decrement Quantity")
 			}
-			mgr.Add(&unison.UndoEdit[*adjustQuantityList]{
+			addUndo(mgr, &unison.UndoEdit[*adjustQuantityList]{
 				ID:         unison.NextUndoID(),
 				EditName:   name,
 				UndoFunc:   func(edit adjustQuantityListUndoEdit) { edit.BeforeData.Apply() },
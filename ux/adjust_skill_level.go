@@ -49,7 +49,7 @@ func adjustSkillLevel[T gurps.NodeTypes](owner Rebuildable, table *unison.Table[
 			} else {
 				name = decreaseSkillLevelAction.Title
 			}
-			mgr.Add(&unison.UndoEdit[*adjustRawPointsList[T]]{
+			addUndo(mgr, &unison.UndoEdit[*adjustRawPointsList[T]]{
 				ID:         unison.NextUndoID(),
 				EditName:   name,
 				UndoFunc:   func(edit *unison.UndoEdit[*adjustRawPointsList[T]]) { edit.BeforeData.Apply() },
@@ -83,7 +83,7 @@ func adjustTechLevel[T gurps.NodeTypes](owner Rebuildable, table *unison.Table[*
 			} else {
 				name = increaseTechLevelAction.Title
 			}
-			mgr.Add(&unison.UndoEdit[*adjustTechLevelList[T]]{
+			addUndo(mgr, &unison.UndoEdit[*adjustTechLevelList[T]]{
 				ID:         unison.NextUndoID(),
 				EditName:   name,
 				UndoFunc:   func(edit *unison.UndoEdit[*adjustTechLevelList[T]]) { edit.BeforeData.Apply() },
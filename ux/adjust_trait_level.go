@@ -89,7 +89,7 @@ func adjustTraitLevel(owner Rebuildable, table *unison.Table[*Node[*gurps.Trait]
 			} else {
 				name = i18n.Text("Decrement Level")
 			}
-			mgr.Add(&unison.UndoEdit[*adjustTraitLevelList]{
+			addUndo(mgr, &unison.UndoEdit[*adjustTraitLevelList]{
 				ID:         unison.NextUndoID(),
 				EditName:   name,
 				UndoFunc:   func(edit adjustTraitLevelListUndoEdit) { edit.BeforeData.Apply() },
@@ -77,7 +77,7 @@ func adjustUses(owner Rebuildable, table *unison.Table[*Node[*gurps.Equipment]],
 			} else {
 				name = increaseUsesAction.Title
 			}
-			mgr.Add(&unison.UndoEdit[*adjustUsesList]{
+			addUndo(mgr, &unison.UndoEdit[*adjustUsesList]{
 				ID:         unison.NextUndoID(),
 				EditName:   name,
 				UndoFunc:   func(edit adjustUsesListUndoEdit) { edit.BeforeData.Apply() },
@@ -13,6 +13,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
@@ -90,6 +93,7 @@ func CheckForAppUpdates() {
 				errs.Log(err)
 				return
 			}
+			releases = gurps.ReleasesForChannel(releases, gurps.GlobalSettings().General.UpdateChannel)
 			if len(releases) == 0 || releases[0].Version == cmdline.AppVersion {
 				appUpdate.SetResult(fmt.Sprintf(i18n.Text("No %s updates are available"), cmdline.AppName))
 				return
@@ -105,10 +109,12 @@ func NotifyOfAppUpdate() {
 	if title, releases, _ := appUpdate.Result(); releases != nil {
 		var buffer strings.Builder
 		fmt.Fprintf(&buffer, "# %s\n", title)
-		for i, rel := range releases {
-			if i != 0 {
-				buffer.WriteString("---\n")
+		fmt.Fprintf(&buffer, i18n.Text("Changes since v%s, the version you are running:")+"\n", filterVersion(cmdline.AppVersion))
+		for _, rel := range releases {
+			if rel.Version == cmdline.AppVersion {
+				continue
 			}
+			buffer.WriteString("---\n")
 			fmt.Fprintf(&buffer, "## Release Notes for %s v%s\n", cmdline.AppName, filterVersion(rel.Version))
 			buffer.WriteString(rel.Notes)
 			buffer.WriteByte('\n')
@@ -121,26 +127,100 @@ func NotifyOfAppUpdate() {
 		scroll := unison.NewScrollPanel()
 		scroll.SetContent(md, behavior.Unmodified, behavior.Unmodified)
 
+		buttons := []*unison.DialogButtonInfo{unison.NewCancelButtonInfo()}
+		latest := releases[0]
+		asset, delta, canInstall := latest.SelectUpdateAsset(cmdline.AppVersion, runtime.GOOS, runtime.GOARCH)
+		var checksumAsset gurps.ReleaseAsset
+		if canInstall {
+			checksumAsset, canInstall = latest.ChecksumAsset(asset)
+		}
+		if canInstall {
+			installTitle := i18n.Text("Download & Install")
+			if delta {
+				installTitle = i18n.Text("Download & Install Changes")
+			}
+			buttons = append(buttons, &unison.DialogButtonInfo{
+				Title:        installTitle,
+				ResponseCode: installAppUpdateResponse,
+			})
+		}
+		buttons = append(buttons, unison.NewOKButtonInfoWithTitle(i18n.Text("Open Download Page")))
 		dialog, err := unison.NewDialog(
 			&unison.DrawableSVG{
 				SVG:  svg.Download,
 				Size: unison.NewSize(48, 48),
 			},
-			unison.DefaultLabelTheme.OnBackgroundInk, scroll,
-			[]*unison.DialogButtonInfo{
-				unison.NewCancelButtonInfo(),
-				unison.NewOKButtonInfoWithTitle(i18n.Text("Download")),
-			})
+			unison.DefaultLabelTheme.OnBackgroundInk, scroll, buttons)
 		if err != nil {
 			errs.Log(err)
 			return
 		}
-		gurps.GlobalSettings().LastSeenGCSVersion = releases[0].Version
-		if dialog.RunModal() == unison.ModalResponseOK {
+		gurps.GlobalSettings().LastSeenGCSVersion = latest.Version
+		switch dialog.RunModal() {
+		case unison.ModalResponseOK:
 			if err = desktop.Open("https://" + WebSiteDomain); err != nil {
 				unison.ErrorDialogWithError(i18n.Text("Unable to open web page for download"), err)
 			}
+		case installAppUpdateResponse:
+			installAppUpdate(latest, asset, checksumAsset)
+		}
+	}
+}
+
+const installAppUpdateResponse = unison.ModalResponseUserBase
+
+func installAppUpdate(release gurps.Release, asset, checksumAsset gurps.ReleaseAsset) {
+	appUpdate.lock.Lock()
+	if appUpdate.updating {
+		appUpdate.lock.Unlock()
+		return
+	}
+	appUpdate.updating = true
+	appUpdate.result = fmt.Sprintf(i18n.Text("Downloading %s v%s…"), cmdline.AppName, filterVersion(release.Version))
+	appUpdate.lock.Unlock()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute*30)
+		defer cancel()
+		client := &http.Client{}
+		var filePath string
+		checksum, err := gurps.FetchReleaseChecksum(ctx, client, checksumAsset)
+		if err == nil {
+			filePath, err = gurps.DownloadReleaseAsset(ctx, client, asset, checksum,
+				filepath.Join(os.TempDir(), cmdline.AppName+" Update"))
 		}
+		appUpdate.lock.Lock()
+		appUpdate.updating = false
+		appUpdate.result = fmt.Sprintf(i18n.Text("%s v%s is available!"), cmdline.AppName, filterVersion(release.Version))
+		appUpdate.lock.Unlock()
+		unison.InvokeTask(func() {
+			if err != nil {
+				unison.ErrorDialogWithError(i18n.Text("Unable to download the update"), err)
+				return
+			}
+			finishAppUpdate(release, asset, filePath)
+		})
+	}()
+}
+
+func finishAppUpdate(release gurps.Release, asset gurps.ReleaseAsset, filePath string) {
+	if gurps.CanApplyUpdateInPlace(asset, runtime.GOOS) {
+		if installDir, err := gurps.InstallDir(); err == nil && gurps.IsDirWritable(installDir) {
+			if err = gurps.ApplyUpdateArchive(filePath, installDir); err != nil {
+				unison.ErrorDialogWithError(i18n.Text("Unable to apply the update"), err)
+				return
+			}
+			if err = os.Remove(filePath); err != nil {
+				errs.Log(err, "path", filePath)
+			}
+			unison.WarningDialogWithMessage(fmt.Sprintf(i18n.Text("%s v%s has been installed."), cmdline.AppName,
+				filterVersion(release.Version)),
+				fmt.Sprintf(i18n.Text("Quit and restart %s to begin using it."), cmdline.AppName))
+			return
+		}
+	}
+	// The platform doesn't permit replacing the installation ourselves, so hand the download off to the system.
+	if err := desktop.Open(filePath); err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to open the downloaded update"), err)
 	}
 }
 
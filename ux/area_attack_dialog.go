@@ -0,0 +1,303 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/aoe"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wound"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/txt"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+type areaAttackDialog struct {
+	attack       gurps.AreaAttack
+	targets      []*areaAttackTarget
+	radiusFld    *DecimalField
+	coneWidthFld *DecimalField
+	applyBtn     *unison.Button
+}
+
+type areaAttackTarget struct {
+	sheet     *Sheet
+	selected  bool
+	target    gurps.AreaTarget
+	injury    fxp.Int
+	offsetFld *DecimalField
+	result    *unison.Label
+}
+
+// displayAreaAttack displays a calculator for explosions and area effect attacks, which can apply the resulting injury
+// to any number of the open sheets at once.
+func displayAreaAttack() {
+	sheets := OpenSheets(nil)
+	if len(sheets) == 0 {
+		return
+	}
+	slices.SortFunc(sheets, func(a, b *Sheet) int { return txt.NaturalCmp(a.Title(), b.Title(), true) })
+	d := &areaAttackDialog{
+		attack: gurps.AreaAttack{
+			Shape: aoe.Explosion,
+			Type:  wound.Crushing,
+		},
+	}
+	for _, sheet := range sheets {
+		entity := sheet.Entity()
+		t := &areaAttackTarget{
+			sheet: sheet,
+			target: gurps.AreaTarget{
+				DR:           torsoDR(entity),
+				SizeModifier: entity.Profile.AdjustedSizeModifier(),
+			},
+		}
+		d.targets = append(d.targets, t)
+	}
+
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(600, 0),
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	panel.AddChild(d.createAttackPanel())
+	panel.AddChild(d.createTargetsPanel())
+
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Apply Injury")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	dialog.Window().SetTitle(i18n.Text("Explosion & Area Attack Calculator"))
+	d.applyBtn = dialog.Button(unison.ModalResponseOK)
+	d.update()
+	if dialog.RunModal() == unison.ModalResponseOK {
+		d.apply()
+	}
+}
+
+func torsoDR(entity *gurps.Entity) int {
+	if loc := gurps.BodyFor(entity).LookupLocationByID(entity, gurps.TorsoID); loc != nil {
+		return loc.DR(entity, nil, nil)[gurps.AllID]
+	}
+	return 0
+}
+
+func (d *areaAttackDialog) createAttackPanel() *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  4,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Shape"), false))
+	shapePopup := unison.NewPopupMenu[aoe.Shape]()
+	shapePopup.AddItem(aoe.Shapes...)
+	shapePopup.Select(d.attack.Shape)
+	shapePopup.Tooltip = newWrappedTooltip(d.attack.Shape.AltString())
+	shapePopup.SelectionChangedCallback = func(p *unison.PopupMenu[aoe.Shape]) {
+		if shape, ok := p.Selected(); ok {
+			d.attack.Shape = shape
+			p.Tooltip = newWrappedTooltip(shape.AltString())
+			d.update()
+		}
+	}
+	panel.AddChild(shapePopup)
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Type"), false))
+	typePopup := unison.NewPopupMenu[wound.Type]()
+	typePopup.AddItem(wound.Types...)
+	typePopup.Select(d.attack.Type)
+	typePopup.SelectionChangedCallback = func(p *unison.PopupMenu[wound.Type]) {
+		if dmgType, ok := p.Selected(); ok {
+			d.attack.Type = dmgType
+			d.update()
+		}
+	}
+	panel.AddChild(typePopup)
+
+	title := i18n.Text("Damage")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	field := NewDecimalField(nil, "", title, func() fxp.Int { return d.attack.Damage },
+		func(v fxp.Int) {
+			d.attack.Damage = v
+			d.update()
+		}, 0, fxp.Max, false, false)
+	field.Tooltip = newWrappedTooltip(i18n.Text("The damage rolled for the attack"))
+	panel.AddChild(field)
+
+	title = i18n.Text("Fragment Dice")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	fragments := NewIntegerField(nil, "", title, func() int { return d.attack.FragmentDice },
+		func(v int) {
+			d.attack.FragmentDice = v
+			d.update()
+		}, 0, 99, false, false)
+	fragments.Tooltip = newWrappedTooltip(i18n.Text("The number of dice of fragmentation damage, which attack everyone within 5 yards per die (B414)"))
+	panel.AddChild(fragments)
+
+	title = i18n.Text("Radius")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	d.radiusFld = NewDecimalField(nil, "", title, func() fxp.Int { return d.attack.Radius },
+		func(v fxp.Int) {
+			d.attack.Radius = v
+			d.update()
+		}, 0, fxp.Max, false, false)
+	d.radiusFld.Tooltip = newWrappedTooltip(i18n.Text("The radius of an area, or the maximum range of a cone, in yards"))
+	panel.AddChild(d.radiusFld)
+
+	title = i18n.Text("Cone Width")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	d.coneWidthFld = NewDecimalField(nil, "", title, func() fxp.Int { return d.attack.ConeWidth },
+		func(v fxp.Int) {
+			d.attack.ConeWidth = v
+			d.update()
+		}, 0, fxp.Max, false, false)
+	d.coneWidthFld.Tooltip = newWrappedTooltip(i18n.Text("The width of a cone at its maximum range, in yards"))
+	panel.AddChild(d.coneWidthFld)
+	return panel
+}
+
+func (d *areaAttackDialog) createTargetsPanel() *unison.ScrollPanel {
+	panel := unison.NewPanel()
+	panel.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing)))
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  5,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	for _, title := range []string{
+		i18n.Text("Target"),
+		i18n.Text("Distance"),
+		i18n.Text("Offset"),
+		i18n.Text("DR"),
+		i18n.Text("Result"),
+	} {
+		label := NewFieldInteriorLeadingLabel(title, false)
+		label.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Start})
+		panel.AddChild(label)
+	}
+	for _, t := range d.targets {
+		checkbox := unison.NewCheckBox()
+		checkbox.SetTitle(t.sheet.Title())
+		checkbox.ClickCallback = func() {
+			t.selected = checkbox.State == check.On
+			d.update()
+		}
+		panel.AddChild(checkbox)
+		distance := NewDecimalField(nil, "", i18n.Text("Distance"), func() fxp.Int { return t.target.Distance },
+			func(v fxp.Int) {
+				t.target.Distance = v
+				d.update()
+			}, 0, fxp.Max, false, false)
+		distance.Tooltip = newWrappedTooltip(i18n.Text("The distance in yards from the center of the explosion or area, or from the origin of the cone"))
+		panel.AddChild(distance)
+		t.offsetFld = NewDecimalField(nil, "", i18n.Text("Offset"), func() fxp.Int { return t.target.Offset },
+			func(v fxp.Int) {
+				t.target.Offset = v
+				d.update()
+			}, 0, fxp.Max, false, false)
+		t.offsetFld.Tooltip = newWrappedTooltip(i18n.Text("The distance in yards from the center line of the cone"))
+		panel.AddChild(t.offsetFld)
+		panel.AddChild(NewIntegerField(nil, "", i18n.Text("DR"), func() int { return t.target.DR },
+			func(v int) {
+				t.target.DR = v
+				d.update()
+			}, 0, 99999, false, false))
+		t.result = unison.NewLabel()
+		t.result.SetLayoutData(&unison.FlexLayoutData{HGrab: true})
+		panel.AddChild(t.result)
+	}
+	scroll := unison.NewScrollPanel()
+	scroll.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	scroll.SetContent(panel, behavior.HintedFill, behavior.Fill)
+	scroll.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(0, 150),
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	return scroll
+}
+
+func (d *areaAttackDialog) update() {
+	d.radiusFld.SetEnabled(d.attack.Shape != aoe.Explosion)
+	d.coneWidthFld.SetEnabled(d.attack.Shape == aoe.Cone)
+	canApply := false
+	for _, t := range d.targets {
+		t.offsetFld.SetEnabled(d.attack.Shape == aoe.Cone)
+		entity := t.sheet.Entity()
+		hit := d.attack.Resolve(&t.target)
+		t.injury = 0
+		var parts []string
+		if d.attack.Inside(&t.target) {
+			if hit.Wound.Penetrating > 0 {
+				t.injury = entity.Injury(&hit.Wound)
+			}
+			parts = append(parts, fmt.Sprintf(i18n.Text("%s damage, %s injury"), hit.Damage.Comma(), t.injury.Comma()))
+		} else {
+			parts = append(parts, i18n.Text("outside the area"))
+		}
+		if hit.InFragmentRange {
+			parts = append(parts, fmt.Sprintf(i18n.Text("fragments attack at %d"), hit.FragmentSkill))
+		}
+		t.result.SetTitle(strings.Join(parts, "; "))
+		t.result.MarkForLayoutRecursivelyUpward()
+		if t.selected && t.injury > 0 {
+			canApply = true
+		}
+	}
+	if d.applyBtn != nil {
+		d.applyBtn.SetEnabled(canApply)
+	}
+}
+
+func (d *areaAttackDialog) apply() {
+	for _, t := range d.targets {
+		if !t.selected || t.injury <= 0 {
+			continue
+		}
+		entity := t.sheet.Entity()
+		hp, ok := entity.Attributes.Set[gurps.HitPointsID]
+		if !ok {
+			continue
+		}
+		before := newPoolTrackerState(hp)
+		hit := d.attack.Resolve(&t.target)
+		record := hp.ApplyDamage(t.injury, d.attack.Shape.String()+": "+entity.InjuryExplanation(&hit.Wound))
+		if record.Damage > 0 {
+			entity.ApplyShock(record.Damage)
+		}
+		addPoolUndo(t.sheet, hp, before, fmt.Sprintf(i18n.Text("%s Damage"), d.attack.Shape))
+		t.sheet.Rebuild(true)
+	}
+}
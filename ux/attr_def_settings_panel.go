@@ -110,7 +110,7 @@ func (p *attrDefSettingsPanel) deleteAttrDef() {
 	undo.BeforeData = p.dockable.defs.Clone()
 	delete(p.dockable.defs.Set, p.def.DefID)
 	undo.AfterData = p.dockable.defs.Clone()
-	p.dockable.UndoManager().Add(undo)
+	addUndo(p.dockable.UndoManager(), undo)
 	p.dockable.MarkModified(nil)
 }
 
@@ -216,6 +216,7 @@ func (p *attrDefSettingsPanel) createContent() *unison.Panel {
 	if p.def.Type == attribute.Pool || p.def.Type == attribute.PoolRef {
 		p.poolPanel = newPoolSettingsPanel(p.dockable, p.def)
 		content.AddChild(p.poolPanel)
+		content.AddChild(newRegenSettingsPanel(p.dockable, p.def))
 	} else {
 		p.poolPanel = nil
 	}
@@ -246,6 +247,7 @@ func (p *attrDefSettingsPanel) applyAttributeType(attrType attribute.Type) {
 		p.def.CostPerPoint = 0
 		p.def.CostAdjPercentPerSM = 0
 		p.def.Thresholds = nil
+		p.def.Regeneration = nil
 	}
 	p.dockable.sync()
 }
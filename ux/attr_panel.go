@@ -11,11 +11,13 @@ package ux
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/richardwilkes/gcs/v5/model/fonts"
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/attribute"
+	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/fatal"
 	"github.com/richardwilkes/toolbox/i18n"
@@ -47,6 +49,8 @@ type AttrPanel struct {
 	rowStarts   []int
 	kind        int
 	stateLabels map[string]*unison.Label
+	shockLabel  *unison.Label
+	cpLabel     *unison.Label
 }
 
 // NewPrimaryAttrPanel creates a new primary attributes panel.
@@ -132,7 +136,7 @@ func (a *AttrPanel) fatalKind() {
 
 func (a *AttrPanel) columns() int {
 	if a.kind == poolAttrKind {
-		return 6
+		return 7
 	}
 	return 3
 }
@@ -233,6 +237,8 @@ func (a *AttrPanel) rebuild(attrs *gurps.AttributeDefs) {
 					} else {
 						a.AddChild(unison.NewPanel())
 					}
+
+					a.AddChild(a.createTrackerButton(attr))
 				} else {
 					if def.Type == attribute.IntegerRef || def.Type == attribute.DecimalRef {
 						field := NewNonEditablePageFieldEnd(func(field *NonEditablePageField) {
@@ -261,6 +267,14 @@ func (a *AttrPanel) rebuild(attrs *gurps.AttributeDefs) {
 			}
 		}
 	}
+	a.shockLabel = nil
+	if a.kind == poolAttrKind && a.entity.ShockPenalty != 0 {
+		a.addShockRow()
+	}
+	a.cpLabel = nil
+	if a.kind == poolAttrKind && a.entity.TechnicalGrappling() {
+		a.addControlPointsRow()
+	}
 	if a.targetMgr != nil {
 		if sheet := unison.Ancestor[*Sheet](a); sheet != nil {
 			a.targetMgr.ReacquireFocus(focusRefKey, sheet.toolbar, sheet.scroll.Content())
@@ -268,6 +282,131 @@ func (a *AttrPanel) rebuild(attrs *gurps.AttributeDefs) {
 	}
 }
 
+func (a *AttrPanel) addShockRow() {
+	a.rowStarts = append(a.rowStarts, len(a.Children()))
+	a.shockLabel = NewPageLabel("")
+	a.shockLabel.Tooltip = newWrappedTooltip(i18n.Text("Shock from injury applies to DX, IQ, and skills and spells based on them until the end of the next turn (B419)"))
+	a.shockLabel.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  a.columns() - 1,
+		HAlign: align.Middle,
+	})
+	a.updateShockLabel()
+	a.AddChild(a.shockLabel)
+	height := fonts.PageLabelPrimary.Baseline() - 2
+	button := unison.NewSVGButton(svg.Not)
+	button.Font = fonts.PageLabelPrimary
+	button.Drawable.(*unison.DrawableSVG).Size = unison.NewSize(height, height)
+	button.Tooltip = newWrappedTooltip(i18n.Text("Clear the shock penalty"))
+	button.ClickCallback = func() {
+		owner := unison.AncestorOrSelf[Rebuildable](a)
+		before := a.entity.ShockPenalty
+		a.entity.ClearShock()
+		if mgr := unison.UndoManagerFor(a); mgr != nil {
+			entity := a.entity
+			addUndo(mgr, &unison.UndoEdit[int]{
+				ID:       unison.NextUndoID(),
+				EditName: i18n.Text("Clear Shock"),
+				UndoFunc: func(edit *unison.UndoEdit[int]) {
+					entity.ShockPenalty = edit.BeforeData
+					owner.Rebuild(true)
+				},
+				RedoFunc: func(edit *unison.UndoEdit[int]) {
+					entity.ShockPenalty = edit.AfterData
+					owner.Rebuild(true)
+				},
+				BeforeData: before,
+				AfterData:  0,
+			})
+		}
+		owner.Rebuild(true)
+	}
+	a.AddChild(button)
+}
+
+func (a *AttrPanel) updateShockLabel() {
+	a.shockLabel.Text = unison.NewSmallCapsText(fmt.Sprintf(i18n.Text("[Shock %d]"), a.entity.ShockPenalty),
+		&unison.TextDecoration{
+			Font:            fonts.PageLabelPrimary,
+			OnBackgroundInk: unison.ThemeOnSurface,
+		})
+}
+
+func (a *AttrPanel) addControlPointsRow() {
+	a.rowStarts = append(a.rowStarts, len(a.Children()))
+	a.AddChild(unison.NewPanel())
+	a.AddChild(NewIntegerPageField(a.targetMgr, a.prefix+"control_points", i18n.Text("Control Points"),
+		func() int { return a.entity.ControlPoints },
+		func(v int) { a.entity.ControlPoints = v }, 0, 9999, false, true))
+	a.AddChild(NewPageLabel(i18n.Text("of")))
+	a.AddChild(NewNonEditablePageFieldEnd(func(field *NonEditablePageField) {
+		field.SetTitle(strconv.Itoa(a.entity.ControlMaximum()))
+	}))
+	name := NewPageLabel(i18n.Text("CP"))
+	name.Tooltip = newWrappedTooltip(i18n.Text("Control points applied to this character by grapplers, out of the higher of ST and HP (TG5)"))
+	a.AddChild(name)
+	a.cpLabel = NewPageLabel("")
+	a.cpLabel.Tooltip = newWrappedTooltip(i18n.Text("Control points inflict a penalty to DX and skills and spells based on it"))
+	a.updateControlPointsLabel()
+	a.AddChild(a.cpLabel)
+	height := fonts.PageLabelPrimary.Baseline() - 2
+	button := unison.NewSVGButton(svg.Not)
+	button.Font = fonts.PageLabelPrimary
+	button.Drawable.(*unison.DrawableSVG).Size = unison.NewSize(height, height)
+	button.Tooltip = newWrappedTooltip(i18n.Text("Clear the control points"))
+	button.ClickCallback = func() {
+		before := a.entity.ControlPoints
+		if before == 0 {
+			return
+		}
+		owner := unison.AncestorOrSelf[Rebuildable](a)
+		a.entity.ClearControlPoints()
+		if mgr := unison.UndoManagerFor(a); mgr != nil {
+			entity := a.entity
+			addUndo(mgr, &unison.UndoEdit[int]{
+				ID:       unison.NextUndoID(),
+				EditName: i18n.Text("Clear Control Points"),
+				UndoFunc: func(edit *unison.UndoEdit[int]) {
+					entity.ControlPoints = edit.BeforeData
+					owner.Rebuild(true)
+				},
+				RedoFunc: func(edit *unison.UndoEdit[int]) {
+					entity.ControlPoints = edit.AfterData
+					owner.Rebuild(true)
+				},
+				BeforeData: before,
+				AfterData:  0,
+			})
+		}
+		owner.Rebuild(true)
+	}
+	a.AddChild(button)
+}
+
+func (a *AttrPanel) updateControlPointsLabel() {
+	var text string
+	if penalty := a.entity.GrapplingPenalty(); penalty != 0 {
+		text = fmt.Sprintf(i18n.Text("[DX %d]"), penalty)
+	}
+	a.cpLabel.Text = unison.NewSmallCapsText(text, &unison.TextDecoration{
+		Font:            fonts.PageLabelPrimary,
+		OnBackgroundInk: unison.ThemeOnSurface,
+	})
+}
+
+func (a *AttrPanel) createTrackerButton(attr *gurps.Attribute) unison.Paneler {
+	height := fonts.PageLabelPrimary.Baseline() - 2
+	button := unison.NewSVGButton(svg.FirstAidKit)
+	button.Font = fonts.PageLabelPrimary
+	button.Drawable.(*unison.DrawableSVG).Size = unison.NewSize(height, height)
+	if def := attr.AttributeDef(); def != nil {
+		button.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("Apply damage or healing to %s"), def.CombinedName()))
+	}
+	button.ClickCallback = func() {
+		displayPoolTracker(unison.AncestorOrSelf[Rebuildable](a), attr)
+	}
+	return button
+}
+
 func (a *AttrPanel) createPointsField(attr *gurps.Attribute) unison.Paneler {
 	field := NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
 		if text := "[" + attr.PointCost().String() + "]"; text != f.Text.String() {
@@ -290,10 +429,18 @@ func (a *AttrPanel) createPointsField(attr *gurps.Attribute) unison.Paneler {
 // Sync the panel to the current data.
 func (a *AttrPanel) Sync() {
 	attrs := gurps.SheetSettingsFor(a.entity).Attributes
-	if hash := gurps.Hash64(attrs); hash != a.hash {
+	if hash := gurps.Hash64(attrs); hash != a.hash || (a.kind == poolAttrKind &&
+		((a.entity.ShockPenalty != 0) != (a.shockLabel != nil) ||
+			a.entity.TechnicalGrappling() != (a.cpLabel != nil))) {
 		a.hash = hash
 		a.rebuild(attrs)
 	} else if a.kind == poolAttrKind {
+		if a.shockLabel != nil {
+			a.updateShockLabel()
+		}
+		if a.cpLabel != nil {
+			a.updateControlPointsLabel()
+		}
 		for _, def := range attrs.List(false) {
 			if def.Pool() && def.Type != attribute.PoolSeparator {
 				id := def.ID()
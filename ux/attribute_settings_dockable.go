@@ -180,7 +180,7 @@ func (d *attributeSettingsDockable) addToStartToolbar(toolbar *unison.Panel) {
 		p := newAttrDefSettingsPanel(d, attrDef)
 		d.content.AddChild(p)
 		undo.AfterData = d.defs.Clone()
-		d.UndoManager().Add(undo)
+		addUndo(d.UndoManager(), undo)
 		d.MarkModified(nil)
 		d.MarkForLayoutAndRedraw()
 		d.ValidateLayout()
@@ -231,7 +231,7 @@ func (d *attributeSettingsDockable) reset() {
 	}
 	d.defs.ResetTargetKeyPrefixes(d.targetMgr.NextPrefix)
 	undo.AfterData = d.defs.Clone()
-	d.UndoManager().Add(undo)
+	addUndo(d.UndoManager(), undo)
 	d.sync()
 }
 
@@ -266,7 +266,7 @@ func (d *attributeSettingsDockable) load(fileSystem fs.FS, filePath string) erro
 	}
 	d.defs = defs
 	undo.AfterData = d.defs.Clone()
-	d.UndoManager().Add(undo)
+	addUndo(d.UndoManager(), undo)
 	d.sync()
 	return nil
 }
@@ -406,7 +406,7 @@ func (d *attributeSettingsDockable) dataDragDrop(_ unison.Point, data map[string
 				}
 				undo.AfterData = d.defs.Clone()
 				d.applyAttrDefs(undo.AfterData)
-				d.UndoManager().Add(undo)
+				addUndo(d.UndoManager(), undo)
 				d.MarkModified(nil)
 				d.MarkForLayoutAndRedraw()
 			}
@@ -166,7 +166,7 @@ func (d *bodySettingsDockable) prepareUndo(title string) *unison.UndoEdit[*gurps
 
 func (d *bodySettingsDockable) finishAndPostUndo(undo *unison.UndoEdit[*gurps.Body]) {
 	undo.AfterData = d.body.Clone(d.Entity(), nil)
-	d.UndoManager().Add(undo)
+	addUndo(d.UndoManager(), undo)
 }
 
 func (d *bodySettingsDockable) applyBodyType(bodyType *gurps.Body) {
@@ -243,20 +243,25 @@ func (d *bodySettingsDockable) dataDragOver(where unison.Point, data map[string]
 	if dragData, ok := data[hitLocationDragDataKey]; ok {
 		var dd *hitLocationSettingsPanel
 		if dd, ok = dragData.(*hitLocationSettingsPanel); ok && dd.dockable == d {
-			parent := dd.Parent()
-			where = parent.PointFromRoot(d.content.PointToRoot(where))
-			for i, child := range parent.Children() {
-				rect := child.FrameRect()
-				if where.In(rect) {
-					d.dragTarget = parent
-					if rect.CenterY() <= where.Y {
-						d.dragInsert = i + 1
-					} else {
-						d.dragInsert = i
+			rootPt := d.content.PointToRoot(where)
+			if target, body := d.locationDropTarget(d.content, rootPt); target != nil && !locationOwnsBody(dd.loc, body) {
+				d.dragTarget = target
+				d.dragTargetBody = body
+				pt := target.PointFromRoot(rootPt)
+				children := target.Children()
+				d.dragInsert = len(children)
+				for i, child := range children {
+					rect := child.FrameRect()
+					if pt.Y < rect.Bottom() {
+						if rect.CenterY() <= pt.Y {
+							d.dragInsert = i + 1
+						} else {
+							d.dragInsert = i
+						}
+						break
 					}
-					d.inDragOver = true
-					break
 				}
+				d.inDragOver = true
 			}
 		}
 	}
@@ -266,6 +271,50 @@ func (d *bodySettingsDockable) dataDragOver(where unison.Point, data map[string]
 	return true
 }
 
+// locationDropTarget returns the innermost panel of hit locations under the root point, along with the table it
+// displays.
+func (d *bodySettingsDockable) locationDropTarget(panel *unison.Panel, rootPt unison.Point) (target *unison.Panel,
+	body *gurps.Body,
+) {
+	for _, child := range panel.Children() {
+		var locations *unison.Panel
+		var table *gurps.Body
+		switch p := child.Self.(type) {
+		case *bodySettingsPanel:
+			locations = p.locations
+			table = d.body
+		case *bodySettingsSubTablePanel:
+			locations = p.locations
+			table = p.body
+		}
+		if locations != nil && locations.PointFromRoot(rootPt).In(locations.ContentRect(true)) {
+			if inner, innerBody := d.locationDropTarget(locations, rootPt); inner != nil {
+				return inner, innerBody
+			}
+			return locations, table
+		}
+		if inner, innerBody := d.locationDropTarget(child, rootPt); inner != nil {
+			return inner, innerBody
+		}
+	}
+	return nil, nil
+}
+
+// locationOwnsBody returns true if the body is the sub-table of the hit location, or is nested somewhere within it.
+func locationOwnsBody(loc *gurps.HitLocation, body *gurps.Body) bool {
+	for body != nil {
+		owner := body.OwningLocation()
+		if owner == nil {
+			return false
+		}
+		if owner == loc {
+			return true
+		}
+		body = owner.OwningTable()
+	}
+	return false
+}
+
 func (d *bodySettingsDockable) dataDragExit() {
 	d.inDragOver = false
 	d.dragInsert = -1
@@ -283,11 +332,15 @@ func (d *bodySettingsDockable) dataDragDrop(_ unison.Point, data map[string]any)
 				table := dd.loc.OwningTable()
 				i := slices.Index(table.Locations, dd.loc)
 				table.Locations = slices.Delete(table.Locations, i, i+1)
-				if i < d.dragInsert {
+				if table == d.dragTargetBody && i < d.dragInsert {
 					d.dragInsert--
 				}
-				table.Locations = slices.Insert(table.Locations, d.dragInsert, dd.loc)
+				d.dragTargetBody.Locations = slices.Insert(d.dragTargetBody.Locations, d.dragInsert, dd.loc)
 				table.Update(d.Entity())
+				if table != d.dragTargetBody {
+					d.dragTargetBody.Update(d.Entity())
+					d.body.Update(d.Entity())
+				}
 				d.finishAndPostUndo(undo)
 				d.sync()
 			}
@@ -300,10 +353,13 @@ func (d *bodySettingsDockable) drawOver(gc *unison.Canvas, rect unison.Rect) {
 	if d.inDragOver && d.dragInsert != -1 {
 		children := d.dragTarget.Children()
 		var y float32
-		if d.dragInsert < len(children) {
+		switch {
+		case d.dragInsert < len(children):
 			y = children[d.dragInsert].FrameRect().Y
-		} else {
+		case len(children) != 0:
 			y = children[len(children)-1].FrameRect().Bottom()
+		default:
+			y = d.dragTarget.ContentRect(false).Y
 		}
 		pt := d.content.PointFromRoot(d.dragTarget.PointToRoot(unison.Point{Y: y}))
 		paint := unison.ThemeWarning.Paint(gc, rect, paintstyle.Stroke)
@@ -22,7 +22,8 @@ const hitLocationDragDataKey = "drag.body"
 
 type bodySettingsPanel struct {
 	unison.Panel
-	dockable *bodySettingsDockable
+	dockable  *bodySettingsDockable
+	locations *unison.Panel
 }
 
 func newBodySettingsPanel(d *bodySettingsDockable) *bodySettingsPanel {
@@ -111,6 +112,7 @@ func (p *bodySettingsPanel) createContent() *unison.Panel {
 	})
 	wrapper.SetLayout(&unison.FlexLayout{Columns: 1})
 	content.AddChild(wrapper)
+	p.locations = wrapper
 
 	for _, loc := range p.dockable.body.Locations {
 		wrapper.AddChild(newHitLocationSettingsPanel(p.dockable, loc))
@@ -21,6 +21,7 @@ type bodySettingsSubTablePanel struct {
 	unison.Panel
 	dockable     *bodySettingsDockable
 	body         *gurps.Body
+	locations    *unison.Panel
 	addButton    *unison.Button
 	deleteButton *unison.Button
 }
@@ -95,6 +96,7 @@ func (p *bodySettingsSubTablePanel) createContent() *unison.Panel {
 	})
 	content.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Fill})
 	content.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	p.locations = content
 
 	for _, loc := range p.body.Locations {
 		content.AddChild(newHitLocationSettingsPanel(p.dockable, loc))
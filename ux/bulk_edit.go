@@ -0,0 +1,147 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+func canBulkEdit[T gurps.NodeTypes](table *unison.Table[*Node[T]]) bool {
+	for _, row := range table.SelectedRows(false) {
+		if gurps.CanBulkEdit(row.Data()) {
+			return true
+		}
+	}
+	return false
+}
+
+// bulkEdit asks for changes to make and then applies them to each of the selected rows.
+func bulkEdit[T gurps.NodeTypes](table *unison.Table[*Node[T]]) {
+	rows := table.SelectedRows(false)
+	if len(rows) == 0 {
+		return
+	}
+	edit, ok := promptForBulkEdit(len(rows))
+	if !ok || edit.IsEmpty() {
+		return
+	}
+	mgr := unison.UndoManagerFor(table)
+	var before *TableUndoEditData[T]
+	if mgr != nil {
+		before = NewTableUndoEditData(table)
+	}
+	changed := false
+	for _, row := range rows {
+		if edit.Apply(row.Data()) {
+			changed = true
+		}
+	}
+	if !changed {
+		return
+	}
+	table.SyncToModel()
+	if rebuilder := unison.AncestorOrSelf[Rebuildable](table); rebuilder != nil {
+		rebuilder.Rebuild(true)
+	}
+	MarkModified(table)
+	if mgr != nil && before != nil {
+		addUndo(mgr, &unison.UndoEdit[*TableUndoEditData[T]]{
+			ID:         unison.NextUndoID(),
+			EditName:   bulkEditAction.Title,
+			UndoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.BeforeData.Apply() },
+			RedoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.AfterData.Apply() },
+			AbsorbFunc: func(_ *unison.UndoEdit[*TableUndoEditData[T]], _ unison.Undoable) bool { return false },
+			BeforeData: before,
+			AfterData:  NewTableUndoEditData(table),
+		})
+	}
+}
+
+func promptForBulkEdit(count int) (edit *gurps.BulkEdit, ok bool) {
+	edit = &gurps.BulkEdit{}
+	var setTechLevel bool
+	var techLevel string
+	multiplier := fxp.One
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	label := unison.NewLabel()
+	label.SetTitle(fmt.Sprintf(i18n.Text("Changes to make to the %d selected rows:"), count))
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	panel.AddChild(label)
+
+	techLevelField := NewStringField(nil, "", "", func() string { return techLevel }, func(v string) { techLevel = v })
+	techLevelField.Tooltip = newWrappedTooltip(i18n.Text("The tech level to give to equipment and to skills and spells that have one"))
+	techLevelField.SetMinimumTextWidthUsing("12^")
+	techLevelField.SetEnabled(false)
+	panel.AddChild(NewCheckBox(nil, "", i18n.Text("Set Tech Level"),
+		func() check.Enum { return check.FromBool(setTechLevel) },
+		func(state check.Enum) {
+			setTechLevel = state == check.On
+			techLevelField.SetEnabled(setTechLevel)
+		}))
+	panel.AddChild(techLevelField)
+
+	addTitle := i18n.Text("Add Tag")
+	panel.AddChild(NewFieldLeadingLabel(addTitle, false))
+	addField := NewStringField(nil, "", addTitle, func() string { return edit.AddTag }, func(v string) { edit.AddTag = v })
+	addField.SetMinimumTextWidthUsing(minTextWidthCandidate)
+	panel.AddChild(addField)
+
+	removeTitle := i18n.Text("Remove Tag")
+	panel.AddChild(NewFieldLeadingLabel(removeTitle, false))
+	removeField := NewStringField(nil, "", removeTitle, func() string { return edit.RemoveTag },
+		func(v string) { edit.RemoveTag = v })
+	removeField.SetMinimumTextWidthUsing(minTextWidthCandidate)
+	panel.AddChild(removeField)
+
+	multiplierTitle := i18n.Text("Multiply Value By")
+	panel.AddChild(NewFieldLeadingLabel(multiplierTitle, false))
+	multiplierField := NewDecimalField(nil, "", multiplierTitle, func() fxp.Int { return multiplier },
+		func(v fxp.Int) { multiplier = v }, 0, fxp.Thousand, false, false)
+	multiplierField.Tooltip = newWrappedTooltip(i18n.Text("The value of equipment is multiplied by this amount"))
+	panel.AddChild(multiplierField)
+
+	oldPrefixField := NewStringField(nil, "", "", func() string { return edit.OldPageRefPrefix },
+		func(v string) { edit.OldPageRefPrefix = v })
+	oldPrefixField.Tooltip = newWrappedTooltip(i18n.Text("The prefix to replace. Leave empty to add the new prefix to every page reference."))
+	oldPrefixField.SetEnabled(false)
+	newPrefixField := NewStringField(nil, "", "", func() string { return edit.NewPageRefPrefix },
+		func(v string) { edit.NewPageRefPrefix = v })
+	newPrefixField.SetEnabled(false)
+	panel.AddChild(NewCheckBox(nil, "", i18n.Text("Replace Page Reference Prefix"),
+		func() check.Enum { return check.FromBool(edit.ReplacePageRefPrefix) },
+		func(state check.Enum) {
+			edit.ReplacePageRefPrefix = state == check.On
+			oldPrefixField.SetEnabled(edit.ReplacePageRefPrefix)
+			newPrefixField.SetEnabled(edit.ReplacePageRefPrefix)
+		}))
+	panel.AddChild(oldPrefixField)
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("With"), false))
+	panel.AddChild(newPrefixField)
+
+	if unison.QuestionDialogWithPanel(panel) != unison.ModalResponseOK {
+		return nil, false
+	}
+	if setTechLevel {
+		edit.TechLevel = &techLevel
+	}
+	edit.ValueMultiplier = multiplier
+	return edit, true
+}
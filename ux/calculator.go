@@ -13,11 +13,14 @@ import (
 	"fmt"
 	"math"
 	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/effort"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wound"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
@@ -95,12 +98,27 @@ type Calculator struct {
 	throwingDistanceResult     *unison.Label
 	throwingDamageResult       *unison.Label
 	hikingResult               *unison.Label
+	reactionsPanel             *unison.Panel
+	reactionResult             *unison.Label
+	injuryResult               *unison.Label
+	injuryExplanation          *unison.Label
+	injuryResistance           *unison.Label
+	effortDescription          *unison.Label
+	effortAttacksField         *IntegerField
+	effortCost                 *unison.Label
+	effortPanel                *unison.Panel
+	effortSpendButton          *unison.Button
+	selectedReactions          map[string]bool
+	injuryWound                gurps.Wound
+	effortOption               effort.Option
+	effortAttacks              int
 	scale                      int
 	jumpingRunningStartYards   fxp.Int
 	throwingObjectWeight       fxp.Weight
 	jumpingExtraEffortPenalty  int
 	throwingExtraEffortPenalty int
 	hikingExtraEffortPenalty   int
+	reactionModifier           int
 	terrainIndex               int
 	weatherIndex               int
 	usingSkis                  bool
@@ -125,6 +143,8 @@ func DisplayCalculator(sheet *Sheet) {
 		throwingObjectWeight: fxp.Weight(fxp.One),
 		terrainIndex:         slices.IndexFunc(terrain, func(t terrainModifier) bool { return t.Default }),
 		weatherIndex:         slices.IndexFunc(weather, func(t terrainModifier) bool { return t.Default }),
+		selectedReactions:    make(map[string]bool),
+		effortAttacks:        2,
 	}
 	c.Self = c
 
@@ -169,6 +189,10 @@ func UpdateCalculator(sheet *Sheet) {
 		c.updateJumpingResult()
 		c.updateThrowingResult()
 		c.updateHikingResult()
+		c.rebuildReactions()
+		c.updateReactionResult()
+		c.updateInjuryResult()
+		c.updateExtraEffortResult()
 		c.content.MarkForLayoutRecursively()
 		c.content.MarkForRedraw()
 		break
@@ -217,6 +241,9 @@ func (c *Calculator) createContent() {
 	c.addJumpingSection()
 	c.addThrowingSection()
 	c.addHikingSection()
+	c.addReactionsSection()
+	c.addInjurySection()
+	c.addExtraEffortSection()
 }
 
 func (c *Calculator) addJumpingSection() {
@@ -504,6 +531,345 @@ func (c *Calculator) addHikingSection() {
 	c.content.AddChild(wrapper)
 }
 
+func (c *Calculator) addReactionsSection() {
+	c.content.AddChild(c.createHeader(i18n.Text("Reactions"), "B559", "Reaction Rolls", unison.StdVSpacing*3))
+
+	c.reactionsPanel = unison.NewPanel()
+	c.reactionsPanel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	c.reactionsPanel.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+	c.rebuildReactions()
+	c.content.AddChild(c.reactionsPanel)
+
+	wrapper := unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	wrapper.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+	wrapper.AddChild(NewIntegerField(nil, "", i18n.Text("Other Reaction Modifiers"),
+		func() int { return c.reactionModifier },
+		func(v int) {
+			c.reactionModifier = v
+			c.updateReactionResult()
+		},
+		-99, 99, true, false))
+	label := unison.NewLabel()
+	label.SetTitle(i18n.Text("other modifiers for the situation."))
+	wrapper.AddChild(label)
+	c.content.AddChild(wrapper)
+
+	wrapper = unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		VSpacing: unison.StdVSpacing,
+	})
+	wrapper.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+	divider := unison.NewSeparator()
+	divider.SetBorder(unison.NewEmptyBorder(unison.NewVerticalInsets(unison.StdVSpacing * 2)))
+	divider.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	wrapper.AddChild(divider)
+	c.reactionResult = c.createResultLabel()
+	c.updateReactionResult()
+	wrapper.AddChild(c.reactionResult)
+	label = unison.NewLabel()
+	label.SetTitle(i18n.Text(" to the reaction roll"))
+	wrapper.AddChild(label)
+	c.content.AddChild(wrapper)
+}
+
+func (c *Calculator) rebuildReactions() {
+	c.reactionsPanel.RemoveAllChildren()
+	reactions := c.sheet.Entity().Reactions()
+	if len(reactions) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text("The character has no reaction modifiers."))
+		c.reactionsPanel.AddChild(label)
+		return
+	}
+	for _, one := range reactions {
+		situation := one.From
+		checkbox := unison.NewCheckBox()
+		checkbox.SetTitle(one.Total().StringWithSign() + " " + situation)
+		checkbox.Tooltip = newWrappedTooltip(strings.Join(one.Sources, "\n"))
+		checkbox.State = check.FromBool(c.selectedReactions[situation])
+		checkbox.ClickCallback = func() {
+			c.selectedReactions[situation] = checkbox.State == check.On
+			c.updateReactionResult()
+		}
+		c.reactionsPanel.AddChild(checkbox)
+	}
+}
+
+func (c *Calculator) updateReactionResult() {
+	total := fxp.From(c.reactionModifier)
+	for _, one := range c.sheet.Entity().Reactions() {
+		if c.selectedReactions[one.From] {
+			total += one.Total()
+		}
+	}
+	c.reactionResult.SetTitle(total.StringWithSign())
+	c.reactionResult.MarkForLayoutRecursivelyUpward()
+}
+
+func (c *Calculator) addInjurySection() {
+	c.content.AddChild(c.createHeader(i18n.Text("Injury"), "B379", "Wounding Modifiers and Injury",
+		unison.StdVSpacing*3))
+
+	wrapper := unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	wrapper.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+	wrapper.AddChild(NewDecimalField(nil, "", i18n.Text("Penetrating Damage"),
+		func() fxp.Int { return c.injuryWound.Penetrating },
+		func(v fxp.Int) {
+			c.injuryWound.Penetrating = v
+			c.updateInjuryResult()
+		},
+		0, fxp.Max, false, false))
+	label := unison.NewLabel()
+	label.SetTitle(i18n.Text("points of damage penetrated DR"))
+	wrapper.AddChild(label)
+	c.content.AddChild(wrapper)
+
+	wrapper = unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  4,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	wrapper.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+	typePopup := unison.NewPopupMenu[wound.Type]()
+	typePopup.AddItem(wound.Types...)
+	typePopup.Select(c.injuryWound.Type)
+	typePopup.SelectionChangedCallback = func(p *unison.PopupMenu[wound.Type]) {
+		if dmgType, ok := p.Selected(); ok {
+			c.injuryWound.Type = dmgType
+			c.updateInjuryResult()
+		}
+	}
+	wrapper.AddChild(typePopup)
+	label = unison.NewLabel()
+	label.SetTitle(i18n.Text("damage to the"))
+	wrapper.AddChild(label)
+	locationPopup := unison.NewPopupMenu[wound.Location]()
+	locationPopup.AddItem(wound.Locations...)
+	locationPopup.Select(c.injuryWound.Location)
+	locationPopup.SelectionChangedCallback = func(p *unison.PopupMenu[wound.Location]) {
+		if location, ok := p.Selected(); ok {
+			c.injuryWound.Location = location
+			c.updateInjuryResult()
+		}
+	}
+	wrapper.AddChild(locationPopup)
+	fromWeakness := unison.NewCheckBox()
+	fromWeakness.SetTitle(i18n.Text("from the character's weakness"))
+	fromWeakness.Tooltip = newWrappedTooltip(i18n.Text("Only applies to characters with Supernatural Durability, which can only be killed by their weakness."))
+	fromWeakness.ClickCallback = func() {
+		c.injuryWound.FromWeakness = fromWeakness.State == check.On
+		c.updateInjuryResult()
+	}
+	wrapper.AddChild(fromWeakness)
+	c.content.AddChild(wrapper)
+
+	wrapper = unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	wrapper.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+	divider := unison.NewSeparator()
+	divider.SetBorder(unison.NewEmptyBorder(unison.NewVerticalInsets(unison.StdVSpacing * 2)))
+	divider.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	wrapper.AddChild(divider)
+	label = unison.NewLabel()
+	label.SetTitle(i18n.Text("Injury:"))
+	wrapper.AddChild(label)
+	c.injuryResult = c.createResultLabel()
+	wrapper.AddChild(c.injuryResult)
+	c.injuryExplanation = unison.NewLabel()
+	c.injuryExplanation.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	wrapper.AddChild(c.injuryExplanation)
+	c.injuryResistance = unison.NewLabel()
+	c.injuryResistance.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	wrapper.AddChild(c.injuryResistance)
+	c.updateInjuryResult()
+	c.content.AddChild(wrapper)
+}
+
+func (c *Calculator) updateInjuryResult() {
+	entity := c.sheet.Entity()
+	c.injuryResult.SetTitle(entity.Injury(&c.injuryWound).Comma())
+	if c.injuryWound.Penetrating > 0 {
+		c.injuryExplanation.SetTitle(entity.InjuryExplanation(&c.injuryWound))
+	} else {
+		c.injuryExplanation.SetTitle("")
+	}
+	resistance := entity.InjuryResistance()
+	if text := resistance.String(); text != "" {
+		c.injuryResistance.SetTitle(fmt.Sprintf(i18n.Text("Injury Resistance: %s"), text))
+	} else {
+		c.injuryResistance.SetTitle(i18n.Text("The character has no traits that reduce injury."))
+	}
+	c.injuryResult.MarkForLayoutRecursivelyUpward()
+}
+
+func (c *Calculator) addExtraEffortSection() {
+	c.content.AddChild(c.createHeader(i18n.Text("Extra Effort"), "B357", "Extra Effort in Combat",
+		unison.StdVSpacing*3))
+
+	wrapper := unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  4,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	wrapper.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+	optionPopup := unison.NewPopupMenu[effort.Option]()
+	optionPopup.AddItem(effort.Options...)
+	optionPopup.Select(c.effortOption)
+	optionPopup.SelectionChangedCallback = func(p *unison.PopupMenu[effort.Option]) {
+		if option, ok := p.Selected(); ok {
+			c.effortOption = option
+			c.updateExtraEffortResult()
+		}
+	}
+	wrapper.AddChild(optionPopup)
+	label := unison.NewLabel()
+	label.SetTitle(i18n.Text("with"))
+	wrapper.AddChild(label)
+	c.effortAttacksField = NewIntegerField(nil, "", i18n.Text("Rapid Strike Attacks"),
+		func() int { return c.effortAttacks },
+		func(v int) {
+			c.effortAttacks = v
+			c.updateExtraEffortResult()
+		},
+		2, 99, false, false)
+	wrapper.AddChild(c.effortAttacksField)
+	label = unison.NewLabel()
+	label.SetTitle(i18n.Text("attacks in the Rapid Strike"))
+	wrapper.AddChild(label)
+	c.content.AddChild(wrapper)
+
+	wrapper = unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	wrapper.SetBorder(unison.NewEmptyBorder(unison.Insets{Left: unison.StdHSpacing * 2}))
+	c.effortDescription = unison.NewLabel()
+	c.effortDescription.SetLayoutData(&unison.FlexLayoutData{HSpan: 3})
+	wrapper.AddChild(c.effortDescription)
+	divider := unison.NewSeparator()
+	divider.SetBorder(unison.NewEmptyBorder(unison.NewVerticalInsets(unison.StdVSpacing * 2)))
+	divider.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  3,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	wrapper.AddChild(divider)
+	label = unison.NewLabel()
+	label.SetTitle(i18n.Text("FP Cost:"))
+	wrapper.AddChild(label)
+	c.effortCost = c.createResultLabel()
+	wrapper.AddChild(c.effortCost)
+	c.effortSpendButton = unison.NewButton()
+	c.effortSpendButton.SetTitle(i18n.Text("Spend FP"))
+	c.effortSpendButton.Tooltip = newWrappedTooltip(i18n.Text("Deducts the FP cost from the character's fatigue points and records it in the pool's log"))
+	c.effortSpendButton.ClickCallback = c.spendExtraEffort
+	wrapper.AddChild(c.effortSpendButton)
+	c.effortPanel = unison.NewPanel()
+	c.effortPanel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	c.effortPanel.SetLayoutData(&unison.FlexLayoutData{HSpan: 3})
+	wrapper.AddChild(c.effortPanel)
+	c.updateExtraEffortResult()
+	c.content.AddChild(wrapper)
+}
+
+func (c *Calculator) updateExtraEffortResult() {
+	entity := c.sheet.Entity()
+	c.effortAttacksField.SetEnabled(c.effortOption == effort.FlurryOfBlows)
+	c.effortDescription.SetTitle(c.effortOption.AltString())
+	cost := c.effortOption.FatigueCost(c.effortAttacks)
+	fp, hasFP := entity.Attributes.Set[gurps.FatiguePointsID]
+	if hasFP {
+		c.effortCost.SetTitle(fmt.Sprintf(i18n.Text("%d (of %s remaining)"), cost, fp.Current().Comma()))
+	} else {
+		c.effortCost.SetTitle(strconv.Itoa(cost))
+	}
+	c.effortSpendButton.SetEnabled(hasFP)
+	c.effortPanel.RemoveAllChildren()
+	var lines []string
+	switch c.effortOption {
+	case effort.MightyBlows:
+		for _, w := range entity.EquippedWeapons(true) {
+			if damage := w.Damage.ResolvedDamage(nil); damage != "" {
+				lines = append(lines, fmt.Sprintf(i18n.Text("%s (%s): %s becomes %s"), w.String(),
+					w.UsageWithReplacements(), damage, w.Damage.ResolvedMightyBlowsDamage()))
+			}
+		}
+	case effort.FeverishDefense:
+		lines = append(lines, fmt.Sprintf(i18n.Text("Dodge: %d becomes %d"), entity.Dodge(entity.EncumbranceLevel(false)),
+			entity.Dodge(entity.EncumbranceLevel(false))+gurps.FeverishDefenseBonus))
+		for _, w := range entity.EquippedWeapons(true) {
+			if parry := w.Parry.Resolve(w, nil); parry.CanParry {
+				lines = append(lines, fmt.Sprintf(i18n.Text("Parry with %s (%s): %s becomes %s"), w.String(),
+					w.UsageWithReplacements(), parry.Modifier.String(),
+					(parry.Modifier+fxp.From(gurps.FeverishDefenseBonus)).String()))
+			}
+			if block := w.Block.Resolve(w, nil); block.CanBlock {
+				lines = append(lines, fmt.Sprintf(i18n.Text("Block with %s (%s): %s becomes %s"), w.String(),
+					w.UsageWithReplacements(), block.Modifier.String(),
+					(block.Modifier+fxp.From(gurps.FeverishDefenseBonus)).String()))
+			}
+		}
+	case effort.FlurryOfBlows:
+		lines = append(lines, fmt.Sprintf(i18n.Text("Rapid Strike penalty: %d per attack becomes %d per attack"),
+			entity.RapidStrikePenalty(false), entity.RapidStrikePenalty(true)))
+	}
+	for _, line := range lines {
+		label := unison.NewLabel()
+		label.SetTitle(line)
+		c.effortPanel.AddChild(label)
+	}
+	c.effortPanel.MarkForLayoutRecursivelyUpward()
+}
+
+func (c *Calculator) spendExtraEffort() {
+	entity := c.sheet.Entity()
+	fp, ok := entity.Attributes.Set[gurps.FatiguePointsID]
+	if !ok {
+		return
+	}
+	before := newPoolTrackerState(fp)
+	if entity.SpendExtraEffort(c.effortOption, c.effortAttacks) == nil {
+		return
+	}
+	addPoolUndo(c.sheet, fp, before, fmt.Sprintf(i18n.Text("Extra Effort: %s"), c.effortOption))
+	c.sheet.Rebuild(true)
+}
+
 func (c *Calculator) createResultLabel() *unison.Label {
 	label := unison.NewLabel()
 	label.Font = &unison.DynamicFont{
@@ -147,6 +147,16 @@ func (c *Campaign) Modified() bool {
 	return c.hash != gurps.Hash64(c.campaign)
 }
 
+func (c *Campaign) writeRecoveryFile(filePath string) error {
+	return c.campaign.Save(filePath)
+}
+
+func (c *Campaign) markRecovered(originalPath string) {
+	c.hash = 0
+	c.needsSaveAsPrompt = !fs.FileExists(originalPath)
+	c.SetBackingFilePath(originalPath)
+}
+
 // MayAttemptClose implements unison.TabCloser
 func (c *Campaign) MayAttemptClose() bool {
 	return MayAttemptCloseOfGroup(c)
@@ -0,0 +1,60 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+type campaignLimitsGuard[T gurps.NodeTypes] struct {
+	entity     *gurps.Entity
+	before     *TableUndoEditData[T]
+	violations int
+}
+
+// newCampaignLimitsGuard returns a guard that can reject changes to the table that would cause the owning character
+// to exceed more of its campaign limits than it already does. Returns nil if the table has no owning character or the
+// character doesn't enforce its limits.
+func newCampaignLimitsGuard[T gurps.NodeTypes](table *unison.Table[*Node[T]]) *campaignLimitsGuard[T] {
+	provider := DetermineDataOwnerProvider(table)
+	if provider == nil {
+		return nil
+	}
+	entity := provider.DataOwner().OwningEntity()
+	if entity == nil || !entity.SheetSettings.EnforceLimits {
+		return nil
+	}
+	return &campaignLimitsGuard[T]{
+		entity:     entity,
+		before:     NewTableUndoEditData(table),
+		violations: len(entity.CampaignLimitViolations()),
+	}
+}
+
+// rejected returns true if the changes made since the guard was created caused new campaign limit violations. If so,
+// the table is restored to its prior state and the user is informed.
+func (g *campaignLimitsGuard[T]) rejected() bool {
+	if g == nil || g.before == nil {
+		return false
+	}
+	g.entity.Recalculate()
+	violations := g.entity.CampaignLimitViolations()
+	if len(violations) <= g.violations {
+		return false
+	}
+	g.before.Apply()
+	g.entity.Recalculate()
+	unison.WarningDialogWithMessage(i18n.Text("Campaign limits exceeded"), strings.Join(violations, "\n"))
+	return true
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fonts"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+	"github.com/richardwilkes/unison/enums/pathop"
+)
+
+const cardInset = 6
+
+var _ unison.PageProvider = &cardExporter{}
+
+// cardExporter lays out poker-sized reference cards as many to a page as will fit within the sheet's margins.
+type cardExporter struct {
+	entity  *gurps.Entity
+	cards   []*gurps.Card
+	columns int
+	rows    int
+}
+
+func newCardExporter(entity *gurps.Entity, cards []*gurps.Card) *cardExporter {
+	c := &cardExporter{
+		entity: entity,
+		cards:  cards,
+	}
+	r := c.printableRect()
+	c.columns, c.rows = gurps.CardsPerPage(r.Width, r.Height)
+	return c
+}
+
+func (c *cardExporter) exportAsPDFFile(filePath string) error {
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return errs.Wrap(err)
+	}
+	stream, err := unison.NewFileStream(filePath)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+	return unison.CreatePDF(stream, &unison.PDFMetaData{
+		Title:           c.entity.Profile.Name,
+		Author:          toolbox.CurrentUserName(),
+		Subject:         c.entity.Profile.Name,
+		Keywords:        "GCS Cards",
+		Creator:         "GCS",
+		RasterDPI:       300,
+		EncodingQuality: 101,
+	}, c)
+}
+
+func (c *cardExporter) perPage() int {
+	return c.columns * c.rows
+}
+
+func (c *cardExporter) printableRect() unison.Rect {
+	page := c.entity.SheetSettings.Page
+	size := c.PageSize()
+	left := page.LeftMargin.Pixels()
+	top := page.TopMargin.Pixels()
+	return unison.NewRect(left, top, size.Width-(left+page.RightMargin.Pixels()),
+		size.Height-(top+page.BottomMargin.Pixels()))
+}
+
+// HasPage implements unison.PageProvider.
+func (c *cardExporter) HasPage(pageNumber int) bool {
+	return pageNumber > 0 && (pageNumber-1)*c.perPage() < len(c.cards)
+}
+
+// PageSize implements unison.PageProvider.
+func (c *cardExporter) PageSize() unison.Size {
+	w, h := c.entity.SheetSettings.Page.Orientation.Dimensions(c.entity.SheetSettings.Page.Size.Dimensions())
+	return unison.NewSize(w.Pixels(), h.Pixels())
+}
+
+// DrawPage implements unison.PageProvider.
+func (c *cardExporter) DrawPage(canvas *unison.Canvas, pageNumber int) error {
+	if !c.HasPage(pageNumber) {
+		return errs.New("invalid page number")
+	}
+	r := c.printableRect()
+	width := gurps.CardWidth.Pixels()
+	height := gurps.CardHeight.Pixels()
+	// Center the grid of cards within the margins
+	r.X += max(r.Width-width*float32(c.columns), 0) / 2
+	r.Y += max(r.Height-height*float32(c.rows), 0) / 2
+	start := (pageNumber - 1) * c.perPage()
+	for i, card := range c.cards[start:min(start+c.perPage(), len(c.cards))] {
+		drawCard(canvas, unison.NewRect(r.X+float32(i%c.columns)*width, r.Y+float32(i/c.columns)*height, width,
+			height), card)
+	}
+	return nil
+}
+
+func drawCard(canvas *unison.Canvas, rect unison.Rect, card *gurps.Card) {
+	canvas.Save()
+	defer canvas.Restore()
+	canvas.DrawRect(rect, unison.White.Paint(canvas, rect, paintstyle.Fill))
+	canvas.DrawRect(rect, unison.Black.Paint(canvas, rect, paintstyle.Stroke))
+	rect = rect.Inset(unison.NewUniformInsets(cardInset))
+	canvas.ClipRect(rect, pathop.Intersect, false)
+	titleDec := &unison.TextDecoration{Font: fonts.PageFieldPrimary, OnBackgroundInk: unison.Black}
+	labelDec := &unison.TextDecoration{Font: fonts.PageFieldSecondary, OnBackgroundInk: unison.Black}
+	valueDec := &unison.TextDecoration{Font: fonts.PageLabelSecondary, OnBackgroundInk: unison.Black}
+	y := rect.Y
+	for _, line := range unison.NewTextWrappedLines(card.Title, titleDec, rect.Width) {
+		y = drawCardLine(canvas, line, rect.X, y)
+	}
+	kind := card.Kind
+	if card.Cost != "" {
+		kind += " • " + card.Cost
+	}
+	y = drawCardLine(canvas, unison.NewText(kind, valueDec), rect.X, y)
+	y += 2
+	canvas.DrawLine(rect.X, y, rect.Right(), y, unison.Black.Paint(canvas, rect, paintstyle.Stroke))
+	y += 2
+	for _, field := range card.Fields {
+		text := unison.NewText(field.Label+": ", labelDec)
+		text.AddString(field.Value, valueDec)
+		for _, line := range text.BreakToWidth(rect.Width) {
+			y = drawCardLine(canvas, line, rect.X, y)
+		}
+	}
+	if notes := strings.TrimSpace(card.Notes); notes != "" {
+		y += 2
+		for _, line := range unison.NewTextWrappedLines(notes, valueDec, rect.Width) {
+			y = drawCardLine(canvas, line, rect.X, y)
+		}
+	}
+	if card.PageRef != "" {
+		text := unison.NewText(card.PageRef, &unison.TextDecoration{
+			Font:            fonts.PageFooterSecondary,
+			OnBackgroundInk: unison.Black,
+		})
+		text.Draw(canvas, rect.Right()-text.Width(), rect.Bottom()-text.Height()+text.Baseline())
+	}
+}
+
+func drawCardLine(canvas *unison.Canvas, text *unison.Text, x, y float32) float32 {
+	text.Draw(canvas, x, y+text.Baseline())
+	return y + text.Height()
+}
+
+// selectedCards returns cards for the spells, techniques and traits selected in the sheet.
+func (s *Sheet) selectedCards() []*gurps.Card {
+	var cards []*gurps.Card
+	cards = append(cards, gurps.CardsForTraits(ExtractNodeDataFromList(s.Traits.Table.SelectedRows(false)))...)
+	cards = append(cards, gurps.CardsForTechniques(ExtractNodeDataFromList(s.Skills.Table.SelectedRows(false)))...)
+	return append(cards, gurps.CardsForSpells(ExtractNodeDataFromList(s.Spells.Table.SelectedRows(false)))...)
+}
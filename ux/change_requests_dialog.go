@@ -0,0 +1,148 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/changereq"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+type changeRequestsUndoData struct {
+	tables   *sheetTablesUndoData
+	requests []*gurps.ChangeRequest
+}
+
+func newChangeRequestsUndoData(s *Sheet) *changeRequestsUndoData {
+	return &changeRequestsUndoData{
+		tables:   newSheetTablesUndoData(s),
+		requests: gurps.CloneChangeRequestList(s.entity.ChangeRequests),
+	}
+}
+
+func (d *changeRequestsUndoData) apply(s *Sheet) {
+	d.tables.Apply()
+	s.entity.ChangeRequests = gurps.CloneChangeRequestList(d.requests)
+	s.MarkModified(s)
+	s.Rebuild(true)
+}
+
+type changeRequestDecision struct {
+	request *gurps.ChangeRequest
+	state   changereq.State
+	comment string
+}
+
+func (s *Sheet) canReviewChangeRequests(_ any) bool {
+	return len(s.entity.PendingChangeRequests()) != 0
+}
+
+func (s *Sheet) reviewChangeRequests(_ any) {
+	pending := s.entity.PendingChangeRequests()
+	if len(pending) == 0 {
+		return
+	}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(600, 0),
+		HAlign:  align.Fill,
+		HGrab:   true,
+	})
+	decisions := make([]*changeRequestDecision, len(pending))
+	for i, req := range pending {
+		decision := &changeRequestDecision{request: req}
+		decisions[i] = decision
+		label := unison.NewLabel()
+		label.SetTitle(req.Description(s.entity))
+		var tip strings.Builder
+		if req.Requester != "" {
+			fmt.Fprintf(&tip, i18n.Text("Requested by %s on %s"), req.Requester, req.Created.String())
+		} else {
+			fmt.Fprintf(&tip, i18n.Text("Requested on %s"), req.Created.String())
+		}
+		if req.Reason != "" {
+			tip.WriteString("\n")
+			tip.WriteString(req.Reason)
+		}
+		label.Tooltip = newWrappedTooltip(tip.String())
+		panel.AddChild(label)
+		popup := unison.NewPopupMenu[changereq.State]()
+		popup.AddItem(changereq.States...)
+		popup.Select(decision.state)
+		popup.SelectionChangedCallback = func(p *unison.PopupMenu[changereq.State]) {
+			if item, ok := p.Selected(); ok {
+				decision.state = item
+			}
+		}
+		panel.AddChild(popup)
+		commentField := NewStringField(nil, "", i18n.Text("Comment"), func() string { return decision.comment },
+			func(value string) { decision.comment = value })
+		commentField.Watermark = i18n.Text("Comment for the player")
+		panel.AddChild(commentField)
+	}
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Apply")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	before := newChangeRequestsUndoData(s)
+	changed := false
+	var problems []string
+	for _, decision := range decisions {
+		switch decision.state {
+		case changereq.Approved:
+			err = s.entity.ApproveChangeRequest(decision.request.ID, decision.comment)
+		case changereq.Rejected:
+			err = s.entity.RejectChangeRequest(decision.request.ID, decision.comment)
+		default:
+			continue
+		}
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", decision.request.Description(s.entity), err.Error()))
+			continue
+		}
+		changed = true
+	}
+	if changed {
+		s.Traits.Table.SyncToModel()
+		s.Skills.Table.SyncToModel()
+		s.Spells.Table.SyncToModel()
+		addUndo(s.undoMgr, &unison.UndoEdit[*changeRequestsUndoData]{
+			ID:         unison.NextUndoID(),
+			EditName:   reviewChangeRequestsAction.Title,
+			UndoFunc:   func(edit *unison.UndoEdit[*changeRequestsUndoData]) { edit.BeforeData.apply(s) },
+			RedoFunc:   func(edit *unison.UndoEdit[*changeRequestsUndoData]) { edit.AfterData.apply(s) },
+			BeforeData: before,
+			AfterData:  newChangeRequestsUndoData(s),
+		})
+		s.MarkModified(s)
+		s.Rebuild(true)
+	}
+	if len(problems) != 0 {
+		unison.ErrorDialogWithMessage(i18n.Text("Unable to approve some change requests"), strings.Join(problems, "\n"))
+	}
+}
@@ -0,0 +1,396 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/desktop"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var _ unison.Dockable = &CharacterArchivesDockable{}
+
+// CharacterArchivesDockable lists the character archives and allows them to be reopened.
+type CharacterArchivesDockable struct {
+	unison.Panel
+	list         *unison.List[*characterArchiveItem]
+	details      *unison.Panel
+	sheetButton  *unison.Button
+	pdfButton    *unison.Button
+	revealButton *unison.Button
+}
+
+type characterArchiveItem struct {
+	archive *gurps.CharacterArchive
+}
+
+func (c *characterArchiveItem) String() string {
+	var buffer strings.Builder
+	name := c.archive.Manifest.Name
+	if name == "" {
+		name = fs.BaseName(c.archive.Path)
+	}
+	buffer.WriteString(name)
+	if c.archive.Manifest.Reason != "" {
+		buffer.WriteString(" (")
+		buffer.WriteString(c.archive.Manifest.Reason)
+		buffer.WriteString(")")
+	}
+	buffer.WriteString(" — ")
+	buffer.WriteString(c.archive.Manifest.ArchivedOn.String())
+	return buffer.String()
+}
+
+func (s *Sheet) archiveCharacter() {
+	var reason, note string
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(400, 0),
+		HAlign:  align.Fill,
+		HGrab:   true,
+	})
+	reasonLabel := i18n.Text("Reason")
+	panel.AddChild(NewFieldLeadingLabel(reasonLabel, false))
+	reasonField := NewStringField(nil, "", reasonLabel, func() string { return reason },
+		func(value string) { reason = value })
+	reasonField.Tooltip = newWrappedTooltip(i18n.Text("Why the character is being archived, e.g. retired or dead"))
+	panel.AddChild(reasonField)
+	noteLabel := i18n.Text("Note")
+	panel.AddChild(NewFieldLeadingLabel(noteLabel, false))
+	panel.AddChild(NewMultiLineStringField(nil, "", noteLabel, func() string { return note },
+		func(value string) { note = value }))
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Archive")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	dir := gurps.DefaultArchivePath()
+	if err = os.MkdirAll(dir, 0o750); err != nil {
+		errs.Log(err, "path", dir)
+	}
+	s.Window().ShowCursor()
+	saveDialog := unison.NewSaveDialog()
+	saveDialog.SetInitialDirectory(dir)
+	saveDialog.SetAllowedExtensions(gurps.CharacterArchiveExt[1:])
+	saveDialog.SetInitialFileName(fs.SanitizeName(fs.BaseName(s.BackingFilePath())))
+	if !saveDialog.RunModal() {
+		return
+	}
+	filePath, ok := unison.ValidateSaveFilePath(saveDialog.Path(), gurps.CharacterArchiveExt, false)
+	if !ok {
+		return
+	}
+	var pdf []byte
+	if pdf, err = newPageExporter(s.entity).exportAsPDFBytes(); err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create the PDF for the archive!"), err)
+		return
+	}
+	if err = gurps.WriteCharacterArchive(filePath, s.entity, pdf, reason, note); err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to archive the character!"), err)
+		return
+	}
+	if filepath.Dir(filePath) == filepath.Clean(dir) {
+		ShowCharacterArchives()
+	}
+}
+
+// ShowCharacterArchives shows the character archives found in the default archive location.
+func ShowCharacterArchives() {
+	for _, d := range AllDockables() {
+		if a, ok := d.(*CharacterArchivesDockable); ok {
+			a.sync()
+			ActivateDockable(a)
+			return
+		}
+	}
+	d := &CharacterArchivesDockable{}
+	d.Self = d
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+	d.AddChild(d.createToolbar())
+	d.AddChild(d.createContent())
+	d.sync()
+	PlaceInDock(d, dgroup.Editors, false)
+	d.list.RequestFocus()
+}
+
+func (d *CharacterArchivesDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	d.sheetButton = unison.NewButton()
+	d.sheetButton.SetTitle(i18n.Text("Open Sheet"))
+	d.sheetButton.Tooltip = newWrappedTooltip(i18n.Text("Open the archived sheet for viewing. Its library sources are compared against the library files stored in the archive."))
+	d.sheetButton.ClickCallback = d.openSheet
+	toolbar.AddChild(d.sheetButton)
+	d.pdfButton = unison.NewButton()
+	d.pdfButton.SetTitle(i18n.Text("View PDF"))
+	d.pdfButton.Tooltip = newWrappedTooltip(i18n.Text("View the PDF of the sheet made at the time it was archived"))
+	d.pdfButton.ClickCallback = d.viewPDF
+	toolbar.AddChild(d.pdfButton)
+	d.revealButton = unison.NewButton()
+	d.revealButton.SetTitle(i18n.Text("Show in Folder"))
+	d.revealButton.ClickCallback = d.reveal
+	toolbar.AddChild(d.revealButton)
+	spacer := unison.NewPanel()
+	spacer.SetLayoutData(&unison.FlexLayoutData{HGrab: true})
+	toolbar.AddChild(spacer)
+	refreshButton := unison.NewSVGButton(svg.Reset)
+	refreshButton.Tooltip = newWrappedTooltip(i18n.Text("Rescan the archive folder"))
+	refreshButton.ClickCallback = d.sync
+	toolbar.AddChild(refreshButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return toolbar
+}
+
+func (d *CharacterArchivesDockable) createContent() *unison.Panel {
+	content := unison.NewPanel()
+	content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	content.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		VSpacing: unison.StdVSpacing * 2,
+	})
+	content.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	d.list = unison.NewList[*characterArchiveItem]()
+	d.list.BackgroundInk = unison.ThemeSurface
+	d.list.DoubleClickCallback = d.openSheet
+	d.list.NewSelectionCallback = d.adjustForSelection
+	scroller := unison.NewScrollPanel()
+	scroller.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	scroller.SetContent(d.list, behavior.Fill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(400, 200),
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	content.AddChild(scroller)
+	d.details = unison.NewPanel()
+	d.details.SetLayout(&unison.FlexLayout{Columns: 1})
+	d.details.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	content.AddChild(d.details)
+	return content
+}
+
+func (d *CharacterArchivesDockable) sync() {
+	d.list.Clear()
+	for _, one := range gurps.ScanForCharacterArchives(gurps.DefaultArchivePath()) {
+		d.list.Append(&characterArchiveItem{archive: one})
+	}
+	d.list.Pack()
+	d.list.MarkForLayoutRecursivelyUpward()
+	d.adjustForSelection()
+	d.MarkForLayoutAndRedraw()
+}
+
+func (d *CharacterArchivesDockable) selected() *gurps.CharacterArchive {
+	if i := d.list.Selection.FirstSet(); i != -1 {
+		return d.list.DataAtIndex(i).archive
+	}
+	return nil
+}
+
+func (d *CharacterArchivesDockable) adjustForSelection() {
+	a := d.selected()
+	d.sheetButton.SetEnabled(a != nil)
+	d.pdfButton.SetEnabled(a != nil && a.Manifest.PDF != "")
+	d.revealButton.SetEnabled(a != nil)
+	d.details.RemoveAllChildren()
+	if a != nil {
+		if a.Manifest.PlayerName != "" {
+			d.addDetail(i18n.Text("Player: ") + a.Manifest.PlayerName)
+		}
+		d.addDetail(i18n.Text("Archived Library Files: ") + strconv.Itoa(len(a.Manifest.LibraryFiles)))
+		if a.Manifest.Note != "" {
+			for _, line := range strings.Split(a.Manifest.Note, "\n") {
+				d.addDetail(line)
+			}
+		}
+	}
+	d.details.MarkForLayoutRecursivelyUpward()
+	d.MarkForRedraw()
+}
+
+func (d *CharacterArchivesDockable) addDetail(text string) {
+	label := unison.NewLabel()
+	label.SetTitle(text)
+	d.details.AddChild(label)
+}
+
+func (d *CharacterArchivesDockable) openSheet() {
+	a := d.selected()
+	if a == nil {
+		return
+	}
+	for _, one := range AllDockables() {
+		if s, ok := one.(*Sheet); ok && s.archive != nil && s.archive.Path == a.Path {
+			ActivateDockable(s)
+			return
+		}
+	}
+	entity, err := a.Entity()
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to open the archived sheet"), err)
+		return
+	}
+	DisplayNewDockable(newArchivedSheet(a, entity))
+}
+
+// newArchivedSheet creates a read-only sheet for the character from the archive.
+func newArchivedSheet(a *gurps.CharacterArchive, entity *gurps.Entity) *Sheet {
+	s := NewSheet(fs.BaseName(a.Path)+gurps.SheetExt, entity)
+	s.archive = a
+	for _, id := range []int{
+		SaveItemID, SaveAsItemID, NewTraitItemID, NewTraitContainerItemID, NewSkillItemID, NewSkillContainerItemID,
+		NewTechniqueItemID, NewSpellItemID, NewSpellContainerItemID, NewRitualMagicSpellItemID, NewLanguageItemID,
+		NewCarriedEquipmentItemID, NewCarriedEquipmentContainerItemID, NewOtherEquipmentItemID,
+		NewOtherEquipmentContainerItemID, NewNoteItemID, NewNoteContainerItemID, AddNaturalAttacksItemID,
+		AddReputationItemID, SwapDefaultsItemID, BuyUpFromDefaultItemID, FindReplaceItemID, EditCulturesItemID,
+		ToggleUnfamiliarCultureItemID, DeductCostOfLivingItemID, PlanTimeUseItemID, StartNewEncounterItemID,
+		TogglePlayModeItemID, ReviewChangeRequestsItemID, ArchiveCharacterItemID, ClearPortraitItemID,
+	} {
+		s.InstallCmdHandlers(id, func(_ any) bool { return false }, func(_ any) {})
+	}
+	s.disableEditing()
+	UpdateTitleForDockable(s)
+	return s
+}
+
+// disableEditing disables the controls of the sheet that are able to change the character.
+func (s *Sheet) disableEditing() {
+	for _, p := range s.editControls {
+		p.SetEnabled(false)
+	}
+	for _, b := range s.playModeButtons {
+		b.SetEnabled(false)
+	}
+	var disable func(p *unison.Panel)
+	disable = func(p *unison.Panel) {
+		if p.MouseDownCallback != nil || p.KeyDownCallback != nil || p.RuneTypedCallback != nil ||
+			p.FileDropCallback != nil {
+			p.SetEnabled(false)
+		}
+		for _, child := range p.Children() {
+			disable(child)
+		}
+	}
+	disable(s.content)
+}
+
+func (d *CharacterArchivesDockable) viewPDF() {
+	a := d.selected()
+	if a == nil || a.Manifest.PDF == "" {
+		return
+	}
+	data, err := a.PDF()
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to read the archived PDF"), err)
+		return
+	}
+	dir := filepath.Join(os.TempDir(), "GCS Archives")
+	if err = os.MkdirAll(dir, 0o750); err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to extract the archived PDF"), err)
+		return
+	}
+	filePath := filepath.Join(dir, fs.BaseName(a.Path)+".pdf")
+	if err = os.WriteFile(filePath, data, 0o640); err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to extract the archived PDF"), err)
+		return
+	}
+	var dockable unison.Dockable
+	if dockable, err = NewPDFDockable(filePath, 0); err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to open the archived PDF"), err)
+		return
+	}
+	DisplayNewDockable(dockable)
+}
+
+func (d *CharacterArchivesDockable) reveal() {
+	if a := d.selected(); a != nil {
+		if err := desktop.Open(filepath.Dir(a.Path)); err != nil {
+			unison.ErrorDialogWithError(i18n.Text("Unable to show location on disk"), err)
+		}
+	}
+}
+
+// TitleIcon implements unison.Dockable
+func (d *CharacterArchivesDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Database,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *CharacterArchivesDockable) Title() string {
+	return i18n.Text("Character Archives")
+}
+
+func (d *CharacterArchivesDockable) String() string {
+	return d.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (d *CharacterArchivesDockable) Tooltip() string {
+	return gurps.DefaultArchivePath()
+}
+
+// Modified implements unison.Dockable
+func (d *CharacterArchivesDockable) Modified() bool {
+	return false
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (d *CharacterArchivesDockable) MayAttemptClose() bool {
+	return true
+}
+
+// AttemptClose implements unison.TabCloser
+func (d *CharacterArchivesDockable) AttemptClose() bool {
+	return AttemptCloseForDockable(d)
+}
@@ -0,0 +1,104 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+func (s *Sheet) countCoins() {
+	settings := s.entity.SheetSettings
+	coins := maps.Clone(s.entity.Coins)
+	if coins == nil {
+		coins = make(map[string]int)
+	}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(300, 0),
+		HAlign:  align.Fill,
+		HGrab:   true,
+	})
+	totalField := NewNonEditableField(func(field *NonEditableField) {
+		field.SetTitle(fmt.Sprintf(i18n.Text("$%s, %s"), settings.CoinValue(coins).Comma(),
+			settings.DefaultWeightUnits.Format(settings.CoinWeight(coins))))
+		field.MarkForLayoutAndRedraw()
+	})
+	for _, one := range settings.Currencies {
+		name := one.Name
+		panel.AddChild(NewFieldLeadingLabel(name, false))
+		field := NewIntegerField(nil, "", name, func() int { return coins[name] },
+			func(value int) {
+				if value == 0 {
+					delete(coins, name)
+				} else {
+					coins[name] = value
+				}
+				totalField.Sync()
+			}, 0, 999999999, false, false)
+		field.Tooltip = newWrappedTooltip(one.String())
+		panel.AddChild(field)
+	}
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Total"), false))
+	panel.AddChild(totalField)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfo(),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	if dialog.RunModal() != unison.ModalResponseOK || maps.Equal(coins, s.entity.Coins) {
+		return
+	}
+	if len(coins) == 0 {
+		coins = nil
+	}
+	addUndo(s.undoMgr, &unison.UndoEdit[map[string]int]{
+		ID:         unison.NextUndoID(),
+		EditName:   countCoinsAction.Title,
+		UndoFunc:   func(edit *unison.UndoEdit[map[string]int]) { s.updateCoins(edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[map[string]int]) { s.updateCoins(edit.AfterData) },
+		BeforeData: maps.Clone(s.entity.Coins),
+		AfterData:  coins,
+	})
+	s.updateCoins(coins)
+}
+
+func (s *Sheet) updateCoins(coins map[string]int) {
+	s.entity.Coins = maps.Clone(coins)
+	s.MarkModified(s)
+	s.Rebuild(true)
+}
+
+func coinsTooltip(entity *gurps.Entity) string {
+	var buffer strings.Builder
+	for _, one := range entity.SheetSettings.Currencies {
+		if count := entity.Coins[one.Name]; count != 0 {
+			fmt.Fprintf(&buffer, "%d %s\n", count, one.Name)
+		}
+	}
+	fmt.Fprintf(&buffer, i18n.Text("Weight: %s"),
+		entity.SheetSettings.DefaultWeightUnits.Format(entity.CoinWeight()))
+	return buffer.String()
+}
@@ -12,14 +12,18 @@ package ux
 import (
 	"fmt"
 	"io/fs"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/colors"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox/i18n"
+	xfs "github.com/richardwilkes/toolbox/xio/fs"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/check"
 	"github.com/richardwilkes/unison/enums/thememode"
 )
 
@@ -44,7 +48,7 @@ func ShowColorSettings() {
 	d.Loader = d.load
 	d.Saver = d.save
 	d.Resetter = d.reset
-	d.Setup(d.addToStartToolbar, nil, d.initContent)
+	d.Setup(d.addToStartToolbar, d.addToEndToolbar, d.initContent)
 }
 
 func (d *colorSettingsDockable) initContent(content *unison.Panel) {
@@ -58,6 +62,8 @@ func (d *colorSettingsDockable) initContent(content *unison.Panel) {
 }
 
 func (d *colorSettingsDockable) addToStartToolbar(toolbar *unison.Panel) {
+	global := gurps.GlobalSettings()
+	schedule := global.ColorModeSchedule()
 	label := unison.NewLabel()
 	label.SetTitle(i18n.Text("Color Mode"))
 	toolbar.AddChild(label)
@@ -65,14 +71,106 @@ func (d *colorSettingsDockable) addToStartToolbar(toolbar *unison.Panel) {
 	for _, mode := range thememode.All {
 		p.AddItem(mode)
 	}
-	p.Select(gurps.GlobalSettings().ThemeMode)
+	p.Select(global.ThemeMode)
+	p.SetEnabled(!schedule.Enabled)
 	p.SelectionChangedCallback = func(popup *unison.PopupMenu[thememode.Enum]) {
 		if mode, ok := popup.Selected(); ok {
-			gurps.GlobalSettings().ThemeMode = mode
-			unison.SetThemeMode(mode)
+			global.ThemeMode = mode
+			applyScheduledThemeMode()
 		}
 	}
 	toolbar.AddChild(p)
+
+	lightField := d.createTimeOfDayField(i18n.Text("The time of day at which to switch to the light color mode"),
+		&schedule.LightStart)
+	darkField := d.createTimeOfDayField(i18n.Text("The time of day at which to switch to the dark color mode"),
+		&schedule.DarkStart)
+	checkbox := unison.NewCheckBox()
+	checkbox.SetTitle(i18n.Text("Switch on a schedule"))
+	checkbox.Tooltip = newWrappedTooltip(i18n.Text("Switch between the light and dark color modes at set times of day rather than using the Color Mode"))
+	checkbox.State = check.FromBool(schedule.Enabled)
+	checkbox.ClickCallback = func() {
+		schedule.Enabled = checkbox.State == check.On
+		p.SetEnabled(!schedule.Enabled)
+		lightField.SetEnabled(schedule.Enabled)
+		darkField.SetEnabled(schedule.Enabled)
+		applyScheduledThemeMode()
+	}
+	lightField.SetEnabled(schedule.Enabled)
+	darkField.SetEnabled(schedule.Enabled)
+	toolbar.AddChild(checkbox)
+	toolbar.AddChild(NewFieldLeadingLabel(i18n.Text("Light from"), false))
+	toolbar.AddChild(lightField)
+	toolbar.AddChild(NewFieldLeadingLabel(i18n.Text("Dark from"), false))
+	toolbar.AddChild(darkField)
+}
+
+func (d *colorSettingsDockable) createTimeOfDayField(tooltip string, minutes *int) *StringField {
+	field := NewStringField(nil, "", "", func() string { return gurps.FormatTimeOfDay(*minutes) }, func(v string) {
+		if m, ok := gurps.ParseTimeOfDay(v); ok && m != *minutes {
+			*minutes = m
+			applyScheduledThemeMode()
+		}
+	})
+	field.Tooltip = newWrappedTooltip(tooltip)
+	field.SetMinimumTextWidthUsing("00:00")
+	field.ValidateCallback = func() bool {
+		_, ok := gurps.ParseTimeOfDay(field.Text())
+		return ok
+	}
+	return field
+}
+
+func (d *colorSettingsDockable) addToEndToolbar(toolbar *unison.Panel) {
+	b := unison.NewSVGButton(svg.Bookmark)
+	b.Tooltip = newWrappedTooltip(i18n.Text("Save as a named theme in the user library"))
+	b.ClickCallback = d.saveNamedTheme
+	toolbar.AddChild(b)
+}
+
+// saveNamedTheme saves the current colors into the Themes folder of the user library, from where they can be loaded
+// again via the menu.
+func (d *colorSettingsDockable) saveNamedTheme() {
+	var name string
+	field := NewStringField(nil, "", "", func() string { return name }, func(v string) { name = v })
+	field.SetMinimumTextWidthUsing(minTextWidthCandidate)
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Theme Name"), false))
+	panel.AddChild(field)
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon,
+		unison.DefaultDialogTheme.QuestionIconInk, panel,
+		[]*unison.DialogButtonInfo{unison.NewCancelButtonInfo(), unison.NewOKButtonInfoWithTitle(i18n.Text("Save"))})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create theme dialog"), err)
+		return
+	}
+	field.ValidateCallback = func() bool {
+		valid := xfs.SanitizeName(strings.TrimSpace(name)) != ""
+		dialog.Button(unison.ModalResponseOK).SetEnabled(valid)
+		return valid
+	}
+	field.Validate()
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	global := gurps.GlobalSettings()
+	dir := filepath.Join(global.Libraries().User().Path(), "Themes")
+	filePath := filepath.Join(dir, xfs.SanitizeName(strings.TrimSpace(name))+gurps.ColorSettingsExt)
+	if xfs.FileExists(filePath) && unison.QuestionDialog(fmt.Sprintf(i18n.Text("Replace the theme %q?"),
+		strings.TrimSpace(name)), i18n.Text("A theme with that name already exists.")) != unison.ModalResponseOK {
+		return
+	}
+	if err = os.MkdirAll(dir, 0o750); err == nil {
+		err = global.Colors.Save(filePath)
+	}
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to save theme"), err)
+	}
 }
 
 func (d *colorSettingsDockable) reset() {
@@ -0,0 +1,181 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+type commandPaletteItem struct {
+	title  string
+	detail string
+	run    func()
+	score  int
+}
+
+func (c *commandPaletteItem) String() string {
+	return fmt.Sprintf("%s — %s", c.title, c.detail)
+}
+
+// ShowCommandPalette shows a dialog listing every command that can currently be used, every open document and every
+// settings page. Typing narrows the list using fuzzy matching and pressing return runs the selected entry.
+func ShowCommandPalette() {
+	all := commandPaletteItems()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		VSpacing: unison.StdVSpacing,
+	})
+	list := unison.NewList[*commandPaletteItem]()
+	list.BackgroundInk = unison.ThemeSurface
+	var dialog *unison.Dialog
+	searchField := NewSearchField(i18n.Text("Type to search commands and documents"), func(_, after *unison.FieldState) {
+		filterCommandPalette(list, all, after.Text)
+		if dialog != nil {
+			dialog.Button(unison.ModalResponseOK).SetEnabled(list.Count() != 0)
+		}
+	})
+	searchField.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, repeat bool) bool {
+		switch keyCode {
+		case unison.KeyUp, unison.KeyDown:
+			moveCommandPaletteSelection(list, keyCode == unison.KeyUp)
+			return true
+		default:
+			return searchField.DefaultKeyDown(keyCode, mod, repeat)
+		}
+	}
+	searchField.SetMinimumTextWidthUsing(strings.Repeat("M", 40))
+	panel.AddChild(searchField)
+	scroller := unison.NewScrollPanel()
+	scroller.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	scroller.SetContent(list, behavior.Fill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(400, 300),
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	panel.AddChild(scroller)
+	filterCommandPalette(list, all, "")
+	var err error
+	dialog, err = unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Run")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	list.DoubleClickCallback = func() { dialog.StopModal(unison.ModalResponseOK) }
+	dialog.Button(unison.ModalResponseOK).SetEnabled(list.Count() != 0)
+	searchField.RequestFocus()
+	if dialog.RunModal() == unison.ModalResponseOK {
+		if i := list.Selection.FirstSet(); i != -1 {
+			unison.InvokeTask(list.DataAtIndex(i).run)
+		}
+	}
+}
+
+// commandPaletteItems collects the entries for the palette. Commands are checked for availability now, while the
+// window the palette was opened from still has the focus, since that is what most of them act upon.
+func commandPaletteItems() []*commandPaletteItem {
+	var focus *unison.Panel
+	if wnd := unison.ActiveWindow(); wnd != nil {
+		focus = wnd.Focus()
+	}
+	var list []*commandPaletteItem
+	for _, d := range AllDockables() {
+		detail := i18n.Text("Window")
+		if _, ok := d.(FileBackedDockable); ok {
+			detail = i18n.Text("Document")
+		}
+		list = append(list, &commandPaletteItem{
+			title:  d.Title(),
+			detail: detail,
+			run:    func() { ActivateDockable(d) },
+		})
+	}
+	for _, b := range gurps.CurrentBindings() {
+		action := b.Action
+		if action.ID == CommandPaletteItemID || action.ExecuteCallback == nil || !action.Enabled(nil) {
+			continue
+		}
+		detail := i18n.Text("Command")
+		if strings.HasPrefix(b.ID, "settings.") {
+			detail = i18n.Text("Settings")
+		}
+		if !action.KeyBinding.ShouldOmit() {
+			detail += " (" + action.KeyBinding.String() + ")"
+		}
+		list = append(list, &commandPaletteItem{
+			title:  strings.TrimSuffix(action.Title, "…"),
+			detail: detail,
+			run: func() {
+				if focus != nil && focus.Window() != nil {
+					focus.Window().ToFront()
+					if focus.CanPerformCmd(nil, action.ID) {
+						focus.PerformCmd(nil, action.ID)
+						return
+					}
+				}
+				action.Execute(nil)
+			},
+		})
+	}
+	return list
+}
+
+func filterCommandPalette(list *unison.List[*commandPaletteItem], all []*commandPaletteItem, pattern string) {
+	matches := make([]*commandPaletteItem, 0, len(all))
+	for _, one := range all {
+		if score, ok := gurps.FuzzyMatch(pattern, one.title); ok {
+			one.score = score
+			matches = append(matches, one)
+		}
+	}
+	slices.SortStableFunc(matches, func(a, b *commandPaletteItem) int { return cmp.Compare(b.score, a.score) })
+	list.Clear()
+	list.Append(matches...)
+	if len(matches) != 0 {
+		list.Select(false, 0)
+	}
+	list.Pack()
+	list.MarkForLayoutRecursivelyUpward()
+	list.MarkForRedraw()
+}
+
+func moveCommandPaletteSelection(list *unison.List[*commandPaletteItem], up bool) {
+	count := list.Count()
+	if count == 0 {
+		return
+	}
+	i := list.Selection.FirstSet()
+	switch {
+	case i == -1:
+		i = 0
+	case up:
+		i = max(i-1, 0)
+	default:
+		i = min(i+1, count-1)
+	}
+	list.Select(false, i)
+	list.ScrollRectIntoView(list.RowRect(i))
+}
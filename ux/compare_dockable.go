@@ -0,0 +1,235 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/diff"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var (
+	_ unison.Dockable = &CompareDockable{}
+	_ GroupedCloser   = &CompareDockable{}
+)
+
+// CompareDockable shows the differences between a sheet and another version of the same character side-by-side.
+type CompareDockable struct {
+	unison.Panel
+	owner       *Sheet
+	before      *gurps.Entity
+	beforeTitle string
+	content     *unison.Panel
+}
+
+func (s *Sheet) canCompareWithSaved(_ any) bool {
+	backingFilePath := s.BackingFilePath()
+	return backingFilePath != "" && fs.FileExists(backingFilePath)
+}
+
+func (s *Sheet) compareWithSaved(_ any) {
+	s.compareWithFile(s.BackingFilePath(), i18n.Text("Saved"))
+}
+
+func (s *Sheet) compareWithOtherFile(_ any) {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(false)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(gurps.SheetExt)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return
+	}
+	p := dialog.Path()
+	global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(p))
+	s.compareWithFile(p, fs.BaseName(p))
+}
+
+func (s *Sheet) compareWithFile(p, title string) {
+	entity, err := gurps.NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p))
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to load character for comparison"), err)
+		return
+	}
+	d := &CompareDockable{
+		owner:       s,
+		before:      entity,
+		beforeTitle: title,
+	}
+	d.Self = d
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+	d.AddChild(d.createToolbar())
+	d.content = unison.NewPanel()
+	d.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	d.content.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing * 2,
+		VSpacing: unison.StdVSpacing,
+	})
+	scroller := unison.NewScrollPanel()
+	scroller.SetContent(d.content, behavior.Fill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(500, 300),
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	d.AddChild(scroller)
+	d.refresh()
+	group := dgroup.Editors
+	for panel := s.AsPanel(); panel != nil; panel = panel.Parent() {
+		if _, exists := panel.ClientData()[AssociatedIDKey]; exists {
+			group = dgroup.SubEditors
+			break
+		}
+	}
+	PlaceInDock(d, group, false)
+}
+
+func (d *CompareDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	refreshButton := unison.NewSVGButton(svg.Reset)
+	refreshButton.Tooltip = newWrappedTooltip(i18n.Text("Compare again using the current state of the sheet"))
+	refreshButton.ClickCallback = d.refresh
+	toolbar.AddChild(refreshButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return toolbar
+}
+
+func (d *CompareDockable) refresh() {
+	d.content.RemoveAllChildren()
+	d.addHeader(i18n.Text("Item"))
+	d.addHeader(d.beforeTitle)
+	d.addHeader(i18n.Text("Current"))
+	diffs := gurps.CompareEntities(d.before, d.owner.entity)
+	if len(diffs) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text("No differences found"))
+		label.SetLayoutData(&unison.FlexLayoutData{HSpan: 3})
+		d.content.AddChild(label)
+	}
+	section := ""
+	for _, one := range diffs {
+		if one.Section != section {
+			section = one.Section
+			label := unison.NewLabel()
+			label.Font = unison.SystemFont
+			label.SetTitle(section)
+			label.SetBorder(unison.NewEmptyBorder(unison.Insets{Top: unison.StdVSpacing * 2}))
+			label.SetLayoutData(&unison.FlexLayoutData{HSpan: 3})
+			d.content.AddChild(label)
+		}
+		var ink unison.Ink
+		switch one.Kind {
+		case diff.Added:
+			ink = unison.ThemeFocus
+		case diff.Removed:
+			ink = unison.ThemeError
+		default:
+			ink = unison.ThemeWarning
+		}
+		d.addCell(one.Name, ink)
+		d.addCell(one.Before, ink)
+		d.addCell(one.After, ink)
+	}
+	d.content.MarkForLayoutRecursivelyUpward()
+	d.MarkForLayoutAndRedraw()
+}
+
+func (d *CompareDockable) addHeader(title string) {
+	label := unison.NewLabel()
+	label.Font = unison.SystemFont
+	label.SetTitle(title)
+	label.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1}, false))
+	label.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	d.content.AddChild(label)
+}
+
+func (d *CompareDockable) addCell(text string, ink unison.Ink) {
+	label := unison.NewLabel()
+	label.OnBackgroundInk = ink
+	label.SetTitle(text)
+	label.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	d.content.AddChild(label)
+}
+
+// TitleIcon implements unison.Dockable
+func (d *CompareDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  gurps.FileInfoFor(d.owner.BackingFilePath()).SVG,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *CompareDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Compare %s"), d.owner.Title())
+}
+
+func (d *CompareDockable) String() string {
+	return d.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (d *CompareDockable) Tooltip() string {
+	return fmt.Sprintf(i18n.Text("%s compared with %s"), d.owner.Title(), d.beforeTitle)
+}
+
+// Modified implements unison.Dockable
+func (d *CompareDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (d *CompareDockable) CloseWithGroup(other unison.Paneler) bool {
+	return d.owner != nil && d.owner == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (d *CompareDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(d)
+}
+
+// AttemptClose implements GroupedCloser
+func (d *CompareDockable) AttemptClose() bool {
+	if !CloseGroup(d) {
+		return false
+	}
+	return AttemptCloseForDockable(d)
+}
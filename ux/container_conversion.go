@@ -105,7 +105,7 @@ func ConvertToContainer[T ConvertableNodeTypes](owner Rebuildable, table *unison
 	}
 	if len(before.List) > 0 {
 		if mgr := unison.UndoManagerFor(table); mgr != nil {
-			mgr.Add(&unison.UndoEdit[*containerConversionList[T]]{
+			addUndo(mgr, &unison.UndoEdit[*containerConversionList[T]]{
 				ID:         unison.NextUndoID(),
 				EditName:   convertToContainerAction.Title,
 				UndoFunc:   func(edit *unison.UndoEdit[*containerConversionList[T]]) { edit.BeforeData.Apply() },
@@ -131,7 +131,7 @@ func ConvertToNonContainer[T ConvertableNodeTypes](owner Rebuildable, table *uni
 	}
 	if len(before.List) > 0 {
 		if mgr := unison.UndoManagerFor(table); mgr != nil {
-			mgr.Add(&unison.UndoEdit[*containerConversionList[T]]{
+			addUndo(mgr, &unison.UndoEdit[*containerConversionList[T]]{
 				ID:         unison.NextUndoID(),
 				EditName:   convertToNonContainerAction.Title,
 				UndoFunc:   func(edit *unison.UndoEdit[*containerConversionList[T]]) { edit.BeforeData.Apply() },
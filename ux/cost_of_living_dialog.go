@@ -0,0 +1,83 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+func (s *Sheet) deductCostOfLiving() {
+	months := fxp.One
+	costOfLiving := s.entity.CostOfLiving()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(300, 0),
+		HAlign:  align.Fill,
+		HGrab:   true,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Cost of Living"), false))
+	panel.AddChild(NewNonEditableField(func(field *NonEditableField) {
+		field.SetTitle(fmt.Sprintf(i18n.Text("$%s/month"), costOfLiving.Comma()))
+		field.MarkForLayoutAndRedraw()
+	}))
+	deductionField := NewNonEditableField(func(field *NonEditableField) {
+		field.SetTitle("$" + costOfLiving.Mul(months).Comma())
+		field.MarkForLayoutAndRedraw()
+	})
+	monthsLabel := i18n.Text("Months")
+	panel.AddChild(NewFieldLeadingLabel(monthsLabel, false))
+	monthsField := NewDecimalField(nil, "", monthsLabel, func() fxp.Int { return months },
+		func(value fxp.Int) {
+			months = value
+			deductionField.Sync()
+		}, 0, fxp.Thousand, false, false)
+	monthsField.Tooltip = newWrappedTooltip(i18n.Text("The number of months of downtime"))
+	panel.AddChild(monthsField)
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Deduction"), false))
+	panel.AddChild(deductionField)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Deduct")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	if dialog.RunModal() != unison.ModalResponseOK || months <= 0 {
+		return
+	}
+	funds := s.entity.Funds - costOfLiving.Mul(months)
+	addUndo(s.undoMgr, &unison.UndoEdit[fxp.Int]{
+		ID:         unison.NextUndoID(),
+		EditName:   deductCostOfLivingAction.Title,
+		UndoFunc:   func(edit *unison.UndoEdit[fxp.Int]) { s.updateFunds(edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[fxp.Int]) { s.updateFunds(edit.AfterData) },
+		BeforeData: s.entity.Funds,
+		AfterData:  funds,
+	})
+	s.updateFunds(funds)
+}
+
+func (s *Sheet) updateFunds(funds fxp.Int) {
+	s.entity.Funds = funds
+	s.MarkModified(s)
+	s.Rebuild(true)
+}
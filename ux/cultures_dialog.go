@@ -0,0 +1,83 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+func (s *Sheet) editCultures() {
+	cultures := s.entity.Cultures.Clone()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(400, 0),
+		HAlign:  align.Fill,
+		HGrab:   true,
+	})
+	addLabelAndStringField(panel, i18n.Text("Native Culture"),
+		i18n.Text("The culture the character was raised in; familiarity with it is free"), &cultures.Native)
+	addLabelAndListField(panel, i18n.Text("Familiar Cultures"), i18n.Text("cultures"), &cultures.Familiar)
+	addLabelAndListField(panel, i18n.Text("Familiar Alien Cultures"), i18n.Text("cultures"),
+		&cultures.AlienFamiliar)
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Points"), false))
+	panel.AddChild(NewNonEditableField(func(field *NonEditableField) {
+		field.SetTitle(cultures.Points().String())
+		field.MarkForLayoutAndRedraw()
+	}))
+	panel.AddChild(unison.NewPanel())
+	addCheckBox(panel, fmt.Sprintf(i18n.Text("Dealing with an unfamiliar culture (%s to social skills)"),
+		gurps.CulturalUnfamiliarityPenalty.StringWithSign()), &cultures.Unfamiliar)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Apply")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	if dialog.RunModal() == unison.ModalResponseOK {
+		s.applyCultures(editCulturesAction.Title, cultures)
+	}
+}
+
+func (s *Sheet) toggleUnfamiliarCulture() {
+	cultures := s.entity.Cultures.Clone()
+	cultures.Unfamiliar = !cultures.Unfamiliar
+	s.applyCultures(toggleUnfamiliarCultureAction.Title, cultures)
+}
+
+func (s *Sheet) applyCultures(editName string, cultures gurps.Cultures) {
+	addUndo(s.undoMgr, &unison.UndoEdit[gurps.Cultures]{
+		ID:         unison.NextUndoID(),
+		EditName:   editName,
+		UndoFunc:   func(edit *unison.UndoEdit[gurps.Cultures]) { s.updateCultures(edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[gurps.Cultures]) { s.updateCultures(edit.AfterData) },
+		BeforeData: s.entity.Cultures.Clone(),
+		AfterData:  cultures.Clone(),
+	})
+	s.updateCultures(cultures)
+}
+
+func (s *Sheet) updateCultures(cultures gurps.Cultures) {
+	s.entity.Cultures = cultures.Clone()
+	s.MarkModified(s)
+	s.Rebuild(true)
+}
@@ -43,6 +43,12 @@ func NewDamagePanel(entity *gurps.Entity) *DamagePanel {
 	})))
 	p.DrawCallback = func(gc *unison.Canvas, rect unison.Rect) { drawBandedBackground(p, gc, rect, 0, 2, nil) }
 
+	p.AddChild(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
+		f.SetTitle(p.entity.StrikingStrength().String())
+		MarkForLayoutWithinDockable(f)
+	}))
+	p.AddChild(NewPageLabel(i18n.Text("Striking ST")))
+
 	p.AddChild(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
 		f.SetTitle(p.entity.Thrust().String())
 		MarkForLayoutWithinDockable(f)
@@ -30,6 +30,10 @@ type defaultsPanel struct {
 }
 
 func newDefaultsPanel(entity *gurps.Entity, defaults *[]*gurps.SkillDefault) *defaultsPanel {
+	return newTitledDefaultsPanel(entity, i18n.Text("Defaults"), defaults)
+}
+
+func newTitledDefaultsPanel(entity *gurps.Entity, title string, defaults *[]*gurps.SkillDefault) *defaultsPanel {
 	p := &defaultsPanel{
 		entity:   entity,
 		defaults: defaults,
@@ -47,7 +51,7 @@ func newDefaultsPanel(entity *gurps.Entity, defaults *[]*gurps.SkillDefault) *de
 	})
 	p.SetBorder(unison.NewCompoundBorder(
 		&TitledBorder{
-			Title: i18n.Text("Defaults"),
+			Title: title,
 			Font:  unison.LabelFont,
 		},
 		unison.NewEmptyBorder(unison.NewUniformInsets(2))))
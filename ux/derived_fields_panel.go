@@ -0,0 +1,81 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+// DerivedFieldsPanel holds the contents of the derived fields block on the sheet. It takes up no space when the sheet
+// settings don't define any derived fields.
+type DerivedFieldsPanel struct {
+	unison.Panel
+	entity *gurps.Entity
+	fields string
+}
+
+// NewDerivedFieldsPanel creates a new derived fields panel.
+func NewDerivedFieldsPanel(entity *gurps.Entity) *DerivedFieldsPanel {
+	p := &DerivedFieldsPanel{entity: entity}
+	p.Self = p
+	p.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: 4,
+		HAlign:   align.Middle,
+	})
+	p.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+	})
+	p.DrawCallback = func(gc *unison.Canvas, rect unison.Rect) { drawBandedBackground(p, gc, rect, 0, 2, nil) }
+	p.rebuild()
+	return p
+}
+
+func (p *DerivedFieldsPanel) rebuild() {
+	list := gurps.SheetSettingsFor(p.entity).DerivedFields
+	p.fields = gurps.DerivedFieldsToString(list)
+	p.RemoveAllChildren()
+	if len(list) == 0 {
+		p.SetBorder(nil)
+		return
+	}
+	p.SetBorder(unison.NewCompoundBorder(&TitledBorder{Title: i18n.Text("Derived Fields")}, unison.NewEmptyBorder(unison.Insets{
+		Top:    1,
+		Left:   2,
+		Bottom: 1,
+		Right:  2,
+	})))
+	for _, one := range list {
+		field := one
+		p.AddChild(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
+			if value, err := field.Value(p.entity); err != nil {
+				f.SetTitle("?")
+				f.Tooltip = newWrappedTooltip(err.Error())
+			} else {
+				f.SetTitle(value)
+				f.Tooltip = newWrappedTooltip(field.Formula)
+			}
+			MarkForLayoutWithinDockable(f)
+		}))
+		p.AddChild(NewPageLabel(field.Name))
+	}
+}
+
+// Sync the panel to the current data.
+func (p *DerivedFieldsPanel) Sync() {
+	if gurps.DerivedFieldsToString(gurps.SheetSettingsFor(p.entity).DerivedFields) != p.fields {
+		p.rebuild()
+		MarkForLayoutWithinDockable(p)
+	}
+}
@@ -12,8 +12,10 @@ package ux
 import (
 	"strconv"
 
+	"github.com/richardwilkes/gcs/v5/model/fonts"
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
@@ -36,9 +38,12 @@ const (
 // DescriptionPanel holds the contents of the description block on the sheet.
 type DescriptionPanel struct {
 	unison.Panel
-	entity    *gurps.Entity
-	targetMgr *TargetMgr
-	prefix    string
+	entity      *gurps.Entity
+	targetMgr   *TargetMgr
+	prefix      string
+	ageField    *StringField
+	heightField *LengthField
+	weightField *WeightField
 }
 
 // NewDescriptionPanel creates a new description panel.
@@ -50,7 +55,7 @@ func NewDescriptionPanel(entity *gurps.Entity, targetMgr *TargetMgr) *Descriptio
 	}
 	d.Self = d
 	d.SetLayout(&unison.FlexLayout{
-		Columns:  3,
+		Columns:  4,
 		HSpacing: 4,
 	})
 	d.SetLayoutData(&unison.FlexLayoutData{
@@ -68,6 +73,7 @@ func NewDescriptionPanel(entity *gurps.Entity, targetMgr *TargetMgr) *Descriptio
 	d.AddChild(d.createColumn1())
 	d.AddChild(d.createColumn2())
 	d.AddChild(d.createColumn3())
+	d.AddChild(d.createPhysicalRoller())
 	return d
 }
 
@@ -129,6 +135,7 @@ func (d *DescriptionPanel) createColumn1() *unison.Panel {
 		}))
 	ageField.ClientData()[SkipDeepSync] = true
 	column.AddChild(ageField)
+	d.ageField = ageField
 
 	title = i18n.Text("Birthday")
 	birthdayField := NewStringPageField(d.targetMgr, descriptionPanelBirthdayFieldRefKey, title,
@@ -168,6 +175,7 @@ func (d *DescriptionPanel) createColumn2() *unison.Panel {
 		}))
 	heightField.ClientData()[SkipDeepSync] = true
 	column.AddChild(heightField)
+	d.heightField = heightField
 
 	title = i18n.Text("Weight")
 	weightField := NewWeightPageField(d.targetMgr, descriptionPanelWeightFieldRefKey, title, d.entity,
@@ -180,6 +188,7 @@ func (d *DescriptionPanel) createColumn2() *unison.Panel {
 		}))
 	weightField.ClientData()[SkipDeepSync] = true
 	column.AddChild(weightField)
+	d.weightField = weightField
 
 	title = i18n.Text("Size")
 	column.AddChild(NewPageLabelEnd(title))
@@ -200,6 +209,21 @@ func (d *DescriptionPanel) createColumn2() *unison.Panel {
 	return column
 }
 
+func (d *DescriptionPanel) createPhysicalRoller() *unison.Panel {
+	b := NewSVGButtonForFont(svg.Randomize, fonts.PageLabelPrimary, -2)
+	b.SetFocusable(false)
+	b.Tooltip = newWrappedTooltip(i18n.Text("Roll the physical characteristics, generating the height, weight and age together using the ST, SM and current ancestry"))
+	b.ClickCallback = func() {
+		profile := d.entity.Profile
+		profile.RollPhysicalCharacteristics(d.entity)
+		d.heightField.SetText(profile.Height.String())
+		d.weightField.SetText(profile.Weight.String())
+		SetTextAndMarkModified(d.ageField.Field, profile.Age)
+	}
+	b.SetLayoutData(&unison.FlexLayoutData{VAlign: align.Start})
+	return b.AsPanel()
+}
+
 func (d *DescriptionPanel) createColumn3() *unison.Panel {
 	column := createColumn()
 
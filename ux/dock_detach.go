@@ -0,0 +1,78 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/unison"
+)
+
+// installTabDetaching allows the tabs of the document dock to be dragged out of the workspace window. A tab dropped
+// outside of it has its dockable moved into its own window at the drop location. Since documents in their own windows
+// are part of the session, they reopen there the next time.
+func installTabDetaching(wnd *unison.Window) {
+	var dragged unison.Dockable
+	wnd.DragIntoWindowWillStart = func() {
+		dragged = documentDockableForTabAt(wnd, wnd.MouseLocation())
+	}
+	wnd.DragIntoWindowFinished = func() {
+		d := dragged
+		dragged = nil
+		if d == nil {
+			return
+		}
+		where := wnd.MouseLocation()
+		if where.In(unison.Rect{Size: wnd.ContentRect().Size}) {
+			return
+		}
+		screenWhere := where.Add(wnd.ContentRect().Point)
+		// Let the drag finish unwinding before the dockable is moved
+		unison.InvokeTask(func() {
+			if d.AsPanel().Window() != wnd {
+				return
+			}
+			detached, err := MoveDockableToWindow(d)
+			if err != nil {
+				errs.Log(err)
+				return
+			}
+			frame := detached.FrameRect()
+			frame.Point = screenWhere
+			detached.SetFrameRect(unison.BestDisplayForRect(frame).FitRectOnto(frame))
+		})
+	}
+}
+
+// documentDockableForTabAt returns the dockable whose tab in the document dock is at the location, which is in root
+// coordinates of the window. Returns nil if there is no such tab there.
+func documentDockableForTabAt(wnd *unison.Window, where unison.Point) unison.Dockable {
+	content := wnd.Content()
+	for p := content.PanelAt(content.PointFromRoot(where)); p != nil; p = p.Parent() {
+		header := p.Parent()
+		if header == nil || header.Parent() == nil {
+			break
+		}
+		dc, ok := header.Parent().Self.(*unison.DockContainer)
+		if !ok {
+			continue
+		}
+		if dc.Dock != Workspace.DocumentDock.Dock {
+			break
+		}
+		// The tabs of a dock container are the first children of its header, in the same order as its dockables.
+		// The dockables themselves live in a sibling of the header, which must not be mistaken for it.
+		dockables := dc.Dockables()
+		if i := header.IndexOfChild(p); i >= 0 && i < len(dockables) && dockables[i].AsPanel() != p {
+			return dockables[i]
+		}
+		break
+	}
+	return nil
+}
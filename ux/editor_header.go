@@ -71,6 +71,16 @@ func NewEditorListSVGPairHeader[T gurps.NodeTypes](leftSVG, rightSVG *unison.SVG
 }
 
 func headerFromData[T gurps.NodeTypes](data gurps.HeaderData, forPage bool) unison.TableColumnHeader[*Node[T]] {
+	header := headerFromDataWithoutName[T](data, forPage)
+	name := data.Detail
+	if name == "" {
+		name = data.Title
+	}
+	header.AsPanel().ClientData()[columnNameClientKey] = name
+	return header
+}
+
+func headerFromDataWithoutName[T gurps.NodeTypes](data gurps.HeaderData, forPage bool) unison.TableColumnHeader[*Node[T]] {
 	if data.TitleIsImageKey {
 		var img1, img2 *unison.SVG
 		switch data.Title {
@@ -101,11 +111,91 @@ func headerFromData[T gurps.NodeTypes](data gurps.HeaderData, forPage bool) unis
 	return NewEditorListHeader[T](data.Title, data.Detail, forPage)
 }
 
+var _ unison.TableColumnHeader[*Node[*gurps.Trait]] = &NodeTableColumnHeader[*gurps.Trait]{}
+
+// NodeTableColumnHeader is the column header used by the tables of library files. Unlike the default header, it shows
+// the sort direction of every column the table is sorted on, not just the primary one.
+type NodeTableColumnHeader[T gurps.NodeTypes] struct {
+	*unison.DefaultTableColumnHeader[*Node[T]]
+	sortIndicator *unison.DrawableSVG
+	sortState     unison.SortState
+}
+
 // NewTableColumnHeader creates a new table column header panel with the given title in small caps.
-func NewTableColumnHeader[T gurps.NodeTypes](title, tooltip string) *unison.DefaultTableColumnHeader[*Node[T]] {
-	header := unison.NewTableColumnHeader[*Node[T]](title, tooltip)
-	header.Text = unison.NewSmallCapsText(title, &header.TextDecoration)
-	return header
+func NewTableColumnHeader[T gurps.NodeTypes](title, tooltip string) *NodeTableColumnHeader[T] {
+	h := &NodeTableColumnHeader[T]{
+		DefaultTableColumnHeader: unison.NewTableColumnHeader[*Node[T]](title, tooltip),
+		sortState: unison.SortState{
+			Order:     -1,
+			Ascending: true,
+			Sortable:  true,
+		},
+	}
+	h.Text = unison.NewSmallCapsText(title, &h.TextDecoration)
+	h.DrawCallback = h.DefaultDraw
+	h.MouseUpCallback = h.DefaultMouseUp
+	return h
+}
+
+// DefaultDraw provides the default drawing.
+func (h *NodeTableColumnHeader[T]) DefaultDraw(canvas *unison.Canvas, _ unison.Rect) {
+	r := h.ContentRect(false)
+	if h.sortIndicator != nil {
+		r.Width -= h.LabelTheme.Gap + h.sortIndicator.LogicalSize().Width
+	}
+	unison.DrawLabel(canvas, r, h.HAlign, h.VAlign, h.Font, h.Text, h.OnBackgroundInk, nil, h.Drawable, h.Side,
+		h.Gap, !h.Enabled())
+	if h.sortIndicator != nil {
+		size := h.sortIndicator.LogicalSize()
+		r.X = r.Right() + h.LabelTheme.Gap
+		r.Y += (r.Height - size.Height) / 2
+		r.Size = size
+		ink := h.OnBackgroundInk
+		if h.sortState.Order > 0 {
+			// Secondary sort columns get a dimmer indicator than the primary one
+			ink = unison.ThemeSurfaceEdge
+		}
+		paint := ink.Paint(canvas, r, paintstyle.Fill)
+		if !h.Enabled() {
+			paint.SetColorFilter(unison.Grayscale30Filter())
+		}
+		h.sortIndicator.DrawInRect(canvas, r, nil, paint)
+	}
+}
+
+// SortState returns the current SortState.
+func (h *NodeTableColumnHeader[T]) SortState() unison.SortState {
+	return h.sortState
+}
+
+// SetSortState sets the SortState.
+func (h *NodeTableColumnHeader[T]) SetSortState(state unison.SortState) {
+	if h.sortState != state {
+		h.sortState = state
+		if h.sortState.Sortable && h.sortState.Order >= 0 {
+			baseline := h.Font.Baseline()
+			h.sortIndicator = &unison.DrawableSVG{
+				SVG:  unison.SortAscendingSVG,
+				Size: unison.Size{Width: baseline, Height: baseline},
+			}
+			if !h.sortState.Ascending {
+				h.sortIndicator.SVG = unison.SortDescendingSVG
+			}
+		} else {
+			h.sortIndicator = nil
+		}
+		h.MarkForRedraw()
+	}
+}
+
+// DefaultMouseUp provides the default mouse up handling.
+func (h *NodeTableColumnHeader[T]) DefaultMouseUp(where unison.Point, _ int, mod unison.Modifiers) bool {
+	if h.sortState.Sortable && where.In(h.ContentRect(false)) {
+		if header, ok := h.Parent().Self.(*unison.TableHeader[*Node[T]]); ok {
+			sortOnColumn(header, h, mod.ShiftDown())
+		}
+	}
+	return true
 }
 
 // PageTableColumnHeaderTheme holds the theme values for PageTableColumnHeaders. Modifying this data will not alter
@@ -163,7 +253,8 @@ func (h *PageTableColumnHeader[T]) DefaultSizes(hint unison.Size) (minSize, pref
 
 // DefaultDraw provides the default drawing.
 func (h *PageTableColumnHeader[T]) DefaultDraw(gc *unison.Canvas, dirty unison.Rect) {
-	if h.sortState.Order == 0 {
+	switch {
+	case h.sortState.Order == 0:
 		r := h.ContentRect(false)
 		y := r.Y
 		if h.sortState.Ascending {
@@ -174,7 +265,16 @@ func (h *PageTableColumnHeader[T]) DefaultDraw(gc *unison.Canvas, dirty unison.R
 		h.OnBackgroundInk = unison.ThemeFocus
 		h.Label.DefaultDraw(gc, dirty)
 		h.OnBackgroundInk = save
-	} else {
+	case h.sortState.Order > 0:
+		// Secondary sort columns are marked with a line, but don't get the highlighted text of the primary one
+		r := h.ContentRect(false)
+		y := r.Y
+		if h.sortState.Ascending {
+			y = r.Bottom() - 1
+		}
+		gc.DrawLine(r.X, y, r.Right(), y, unison.ThemeSurfaceEdge.Paint(gc, r, paintstyle.Stroke))
+		h.Label.DefaultDraw(gc, dirty)
+	default:
 		h.Label.DefaultDraw(gc, dirty)
 	}
 }
@@ -193,11 +293,10 @@ func (h *PageTableColumnHeader[T]) SetSortState(state unison.SortState) {
 }
 
 // DefaultMouseUp provides the default mouse up handling.
-func (h *PageTableColumnHeader[T]) DefaultMouseUp(where unison.Point, _ int, _ unison.Modifiers) bool {
+func (h *PageTableColumnHeader[T]) DefaultMouseUp(where unison.Point, _ int, mod unison.Modifiers) bool {
 	if h.sortState.Sortable && where.In(h.ContentRect(false)) {
 		if header, ok := h.Parent().Self.(*unison.TableHeader[*Node[T]]); ok {
-			header.SortOn(h)
-			header.ApplySort()
+			sortOnColumn(header, h, mod.ShiftDown())
 		}
 	}
 	return true
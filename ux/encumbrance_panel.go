@@ -177,14 +177,24 @@ func (p *EncumbrancePanel) createMoveField(enc encumbrance.Level, rowColor *encR
 }
 
 func (p *EncumbrancePanel) createDodgeField(enc encumbrance.Level, rowColor *encRowColor) *NonEditablePageField {
+	baseTooltip := fmt.Sprintf(i18n.Text("The dodge for the %s encumbrance level"), enc.String())
+	var lastBonusTooltip string
 	field := NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
 		if text := strconv.Itoa(p.entity.Dodge(enc)); text != f.Text.String() {
 			f.SetTitle(text)
 			MarkForLayoutWithinDockable(f)
 		}
+		if p.entity.DodgeBonusTooltip != lastBonusTooltip {
+			lastBonusTooltip = p.entity.DodgeBonusTooltip
+			tooltip := baseTooltip
+			if lastBonusTooltip != "" {
+				tooltip += "\n\n" + i18n.Text("Includes modifiers from:") + lastBonusTooltip
+			}
+			f.Tooltip = newWrappedTooltip(tooltip)
+		}
 	})
 	field.OnBackgroundInk = rowColor
-	field.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("The dodge for the %s encumbrance level"), enc.String()))
+	field.Tooltip = newWrappedTooltip(baseTooltip)
 	field.SetBorder(unison.NewEmptyBorder(unison.Insets{Right: 4}))
 	field.Text.AdjustDecorations(func(d *unison.TextDecoration) { d.OnBackgroundInk = field.OnBackgroundInk })
 	return field
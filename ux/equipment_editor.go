@@ -84,6 +84,9 @@ func EditEquipment(owner Rebuildable, equipment *gurps.Equipment, carried bool)
 			addLabelAndDecimalField(content, nil, "", i18n.Text("Rated ST"), i18n.Text("Equipment with a rated ST use this value instead of the user's ST"), &e.editorData.RatedST, 0, fxp.Max)
 			addLabelAndDecimalField(content, nil, "", i18n.Text("Level"), i18n.Text("Level can be used with features and modifiers that have per-level effects"), &e.editorData.Level, 0, fxp.Max)
 			addTagsLabelAndField(content, &e.editorData.Tags)
+			if e.target.Container() {
+				addTemplateChoices(content, nil, "", &e.editorData.TemplatePicker)
+			}
 			addPageRefLabelAndField(content, &e.editorData.PageRef)
 			addPageRefHighlightLabelAndField(content, &e.editorData.PageRefHighlight)
 			addSourceFields(content, &e.target.SourcedID)
@@ -98,6 +101,7 @@ func EditEquipment(owner Rebuildable, equipment *gurps.Equipment, carried bool)
 				func(_ any) { modifiersPanel.provider.CreateItem(e, modifiersPanel.table, NoItemVariant) })
 			e.InstallCmdHandlers(NewEquipmentContainerModifierItemID, unison.AlwaysEnabled,
 				func(_ any) { modifiersPanel.provider.CreateItem(e, modifiersPanel.table, ContainerItemVariant) })
+			installAddQualityModifiersCmdHandler(e, e, modifiersPanel.table, modifiersPanel.provider, modifiersPanel)
 			return func() {
 				if e.editorData.Uses > e.editorData.MaxUses {
 					usesField.SetText(strconv.Itoa(e.editorData.MaxUses))
@@ -11,6 +11,7 @@ package ux
 
 import (
 	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/activation"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/emcost"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/emweight"
 	"github.com/richardwilkes/gcs/v5/svg"
@@ -39,6 +40,7 @@ func initEquipmentModifierEditor(e *editor[*gurps.EquipmentModifier, *gurps.Equi
 		addInvertedCheckBox(content, i18n.Text("Enabled"), &e.editorData.Disabled)
 		addEquipmentCostFields(content, e)
 		addEquipmentWeightFields(content, e)
+		addEquipmentActivationField(content, e)
 	}
 	addTagsLabelAndField(content, &e.editorData.Tags)
 	addPageRefLabelAndField(content, &e.editorData.PageRef)
@@ -104,3 +106,28 @@ func addEquipmentWeightFields(parent *unison.Panel, e *editor[*gurps.EquipmentMo
 		func() check.Enum { return check.FromBool(e.editorData.WeightIsPerLevel) },
 		func(in check.Enum) { e.editorData.WeightIsPerLevel = in == check.On }))
 }
+
+func addEquipmentActivationField(parent *unison.Panel, e *editor[*gurps.EquipmentModifier, *gurps.EquipmentModifierEditData]) {
+	label := NewFieldLeadingLabel(i18n.Text("Features Active"), false)
+	label.Tooltip = newWrappedTooltip(i18n.Text("The equipped state of the equipment required for the features of this modifier to be active"))
+	parent.AddChild(label)
+	popup := unison.NewPopupMenu[activation.Condition]()
+	popup.AddItem(activation.WhileEquipped, activation.WhileNotEquipped)
+	if e.editorData.Activation == activation.WhileNotEquipped {
+		popup.Select(activation.WhileNotEquipped)
+	} else {
+		popup.Select(activation.WhileEquipped)
+	}
+	popup.SelectionChangedCallback = func(p *unison.PopupMenu[activation.Condition]) {
+		if item, ok := p.Selected(); ok {
+			// The default of Always is equivalent to WhileEquipped for equipment, so keep it for unchanged data
+			if item == activation.WhileNotEquipped {
+				e.editorData.Activation = activation.WhileNotEquipped
+			} else {
+				e.editorData.Activation = activation.Always
+			}
+			MarkModified(parent)
+		}
+	}
+	parent.AddChild(popup)
+}
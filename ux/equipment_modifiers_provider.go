@@ -165,6 +165,15 @@ func (p *eqpModProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node
 	EditEquipmentModifier(owner, item)
 }
 
+func installAddQualityModifiersCmdHandler(target unison.Paneler, owner Rebuildable, table *unison.Table[*Node[*gurps.EquipmentModifier]], provider TableProvider[*gurps.EquipmentModifier], list gurps.EquipmentModifierListProvider) {
+	target.AsPanel().InstallCmdHandlers(AddQualityModifiersItemID, unison.AlwaysEnabled, func(_ any) {
+		InsertItems[*gurps.EquipmentModifier](owner, table, list.EquipmentModifierList, list.SetEquipmentModifierList,
+			func(_ *unison.Table[*Node[*gurps.EquipmentModifier]]) []*Node[*gurps.EquipmentModifier] {
+				return provider.RootRows()
+			}, gurps.NewQualityModifiers(list.DataOwner()))
+	})
+}
+
 func (p *eqpModProvider) Serialize() ([]byte, error) {
 	return jio.SerializeAndCompress(p.provider.EquipmentModifierList())
 }
@@ -183,6 +192,7 @@ func (p *eqpModProvider) ContextMenuItems() []ContextMenuItem {
 	list = append(list,
 		ContextMenuItem{i18n.Text("New Equipment Modifier"), NewEquipmentModifierItemID},
 		ContextMenuItem{i18n.Text("New Equipment Modifier Container"), NewEquipmentContainerModifierItemID},
+		ContextMenuItem{i18n.Text("Add Quality & Material Modifiers"), AddQualityModifiersItemID},
 	)
 	return AppendDefaultContextMenuItems(list)
 }
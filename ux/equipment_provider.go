@@ -270,6 +270,11 @@ func (p *equipmentProvider) ContextMenuItems() []ContextMenuItem {
 		list = append(list,
 			ContextMenuItem{i18n.Text("New Carried Equipment"), NewCarriedEquipmentItemID},
 			ContextMenuItem{i18n.Text("New Carried Equipment Container"), NewCarriedEquipmentContainerItemID},
+			ContextMenuItem{"", -1},
+			ContextMenuItem{newLoadoutAction.Title, NewLoadoutItemID},
+			ContextMenuItem{addToLoadoutAction.Title, AddToLoadoutItemID},
+			ContextMenuItem{removeFromLoadoutAction.Title, RemoveFromLoadoutItemID},
+			ContextMenuItem{deleteLoadoutAction.Title, DeleteLoadoutItemID},
 		)
 	} else {
 		list = append(list,
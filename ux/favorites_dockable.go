@@ -0,0 +1,213 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var _ unison.Dockable = &FavoritesDockable{}
+
+// FavoritesDockable lists the library items that have been pinned with "Add to Favorites". They can be dragged onto a
+// sheet just like the rows of the library they came from.
+type FavoritesDockable struct {
+	unison.Panel
+	removeButton *unison.Button
+	list         *unison.List[*favoriteItem]
+}
+
+type favoriteItem struct {
+	favorite *gurps.Favorite
+	result   *gurps.LibrarySearchResult
+}
+
+func (f *favoriteItem) String() string {
+	if f.result == nil {
+		return fmt.Sprintf(i18n.Text("%s (missing) — %s"), f.favorite.Name, f.favorite.From.Path)
+	}
+	return fmt.Sprintf("%s (%s) — %s", f.result.Name, f.result.Kind, f.result.From.Path)
+}
+
+// ShowFavorites shows the favorites.
+func ShowFavorites() {
+	for _, d := range AllDockables() {
+		if fd, ok := d.(*FavoritesDockable); ok {
+			ActivateDockable(fd)
+			return
+		}
+	}
+	d := &FavoritesDockable{}
+	d.Self = d
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+	d.AddChild(d.createToolbar())
+	d.AddChild(d.createContent())
+	d.reload()
+	PlaceInDock(d, dgroup.Editors, false)
+}
+
+// refreshFavorites reloads the favorites dockable, if it is open.
+func refreshFavorites() {
+	for _, d := range AllDockables() {
+		if fd, ok := d.(*FavoritesDockable); ok {
+			fd.reload()
+		}
+	}
+}
+
+func (d *FavoritesDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	label := unison.NewLabel()
+	label.SetTitle(i18n.Text("Use \"Add to Favorites\" on the rows of a library to pin them here"))
+	label.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.AddChild(label)
+	d.removeButton = unison.NewSVGButton(svg.Trash)
+	d.removeButton.Tooltip = newWrappedTooltip(i18n.Text("Remove the selected favorites"))
+	d.removeButton.ClickCallback = d.removeSelection
+	d.removeButton.SetEnabled(false)
+	toolbar.AddChild(d.removeButton)
+	refreshButton := unison.NewSVGButton(svg.Reset)
+	refreshButton.Tooltip = newWrappedTooltip(i18n.Text("Reload the favorites from their libraries"))
+	refreshButton.ClickCallback = d.reload
+	toolbar.AddChild(refreshButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return toolbar
+}
+
+func (d *FavoritesDockable) createContent() *unison.Panel {
+	content := unison.NewPanel()
+	content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	content.SetLayout(&unison.FlexLayout{Columns: 1})
+	content.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	d.list = unison.NewList[*favoriteItem]()
+	d.list.BackgroundInk = unison.ThemeSurface
+	d.list.MouseDragCallback = d.mouseDrag
+	d.list.NewSelectionCallback = func() { d.removeButton.SetEnabled(d.list.Selection.Count() != 0) }
+	d.list.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, repeat bool) bool {
+		if mod == 0 && (keyCode == unison.KeyBackspace || keyCode == unison.KeyDelete) {
+			d.removeSelection()
+			return true
+		}
+		return d.list.DefaultKeyDown(keyCode, mod, repeat)
+	}
+	scroller := unison.NewScrollPanel()
+	scroller.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	scroller.SetContent(d.list, behavior.Fill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(400, 200),
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	content.AddChild(scroller)
+	return content
+}
+
+func (d *FavoritesDockable) reload() {
+	global := gurps.GlobalSettings()
+	results := gurps.ResolveFavorites(global.Libraries(), global.Favorites)
+	d.list.Clear()
+	for i, one := range global.Favorites {
+		d.list.Append(&favoriteItem{
+			favorite: one,
+			result:   results[i],
+		})
+	}
+	d.list.Pack()
+	d.list.MarkForLayoutRecursivelyUpward()
+	d.removeButton.SetEnabled(false)
+	d.MarkForLayoutAndRedraw()
+}
+
+func (d *FavoritesDockable) removeSelection() {
+	if d.list.Selection.Count() == 0 {
+		return
+	}
+	global := gurps.GlobalSettings()
+	for i := d.list.Selection.FirstSet(); i != -1; i = d.list.Selection.NextSet(i + 1) {
+		favorite := d.list.DataAtIndex(i).favorite
+		global.RemoveFavorite(favorite.From, favorite.ID)
+	}
+	d.reload()
+}
+
+func (d *FavoritesDockable) mouseDrag(where unison.Point, button int, mod unison.Modifiers) bool {
+	if button == unison.ButtonLeft && d.list.IsDragGesture(where) {
+		if i := d.list.Selection.FirstSet(); i != -1 {
+			if result := d.list.DataAtIndex(i).result; result != nil {
+				startLibraryResultDrag(d.list.AsPanel(), result)
+			}
+			return true
+		}
+	}
+	return d.list.DefaultMouseDrag(where, button, mod)
+}
+
+// TitleIcon implements unison.Dockable
+func (d *FavoritesDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Star,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *FavoritesDockable) Title() string {
+	return i18n.Text("Favorites")
+}
+
+func (d *FavoritesDockable) String() string {
+	return d.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (d *FavoritesDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (d *FavoritesDockable) Modified() bool {
+	return false
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (d *FavoritesDockable) MayAttemptClose() bool {
+	return true
+}
+
+// AttemptClose implements unison.TabCloser
+func (d *FavoritesDockable) AttemptClose() bool {
+	return AttemptCloseForDockable(d)
+}
@@ -202,7 +202,7 @@ func (p *featuresPanel) createDRBonusPanel(f *gurps.DRBonus) *unison.Panel {
 	panel.AddChild(unison.NewPanel())
 	wrapper := unison.NewPanel()
 	wrapper.SetLayout(&unison.FlexLayout{
-		Columns:  3,
+		Columns:  4,
 		HSpacing: unison.StdHSpacing,
 		VSpacing: unison.StdVSpacing,
 	})
@@ -217,6 +217,14 @@ func (p *featuresPanel) createDRBonusPanel(f *gurps.DRBonus) *unison.Panel {
 	field.SetMinimumTextWidthUsing("Specialization")
 	wrapper.AddChild(field)
 	wrapper.AddChild(NewFieldTrailingLabel(i18n.Text("attacks"), false))
+	flexible := NewCheckBox(nil, "", i18n.Text("Flexible"),
+		func() check.Enum { return check.FromBool(f.Flexible) },
+		func(state check.Enum) {
+			f.Flexible = state == check.On
+			MarkModified(wrapper)
+		})
+	flexible.Tooltip = newWrappedTooltip(i18n.Text("Flexible armor is subject to blunt trauma, unless rigid armor is layered over it"))
+	wrapper.AddChild(flexible)
 	panel.AddChild(wrapper)
 	return panel
 }
@@ -530,6 +538,12 @@ func (p *featuresPanel) createSecondaryWeaponPanels(parent *unison.Panel, index
 	}
 	index = p.addWrapperAtIndex(parent, wrapper, index, false)
 
+	if f.Type == feature.WeaponBonus || f.Type == feature.WeaponDRDivisorBonus {
+		wrapper, index = p.prepareNewWrapper(parent, index)
+		addDamageTypeCriteriaPanel(wrapper, &f.DamageTypeCriteria, 1, false)
+		index = p.addWrapperAtIndex(parent, wrapper, index, false)
+	}
+
 	if f.SelectionType != wsel.ThisWeapon {
 		wrapper, index = p.prepareNewWrapper(parent, index)
 		addTagCriteriaPanel(wrapper, &f.TagsCriteria, 1, false)
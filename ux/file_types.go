@@ -111,6 +111,8 @@ func RegisterGCSFileTypes() {
 	registerExportableGCSFileInfo("GCS Sheet", gurps.SheetExt, svg.GCSSheet, NewSheetFromFile)
 	registerGCSFileInfo("GCS Template", gurps.TemplatesExt, []string{gurps.TemplatesExt}, svg.GCSTemplate,
 		NewTemplateFromFile)
+	registerGCSFileInfo("GCS Spaceship", gurps.SpaceshipExt, []string{gurps.SpaceshipExt}, svg.GCSSpaceship,
+		NewSpaceshipFromFile)
 	// TODO: Re-enable Campaign files
 	// registerGCSFileInfo("GCS Campaign", gurps.CampaignExt, []string{gurps.CampaignExt}, svg.GCSCampaign,
 	// 	NewCampaignFromFile)
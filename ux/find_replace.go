@@ -0,0 +1,242 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+type findReplaceDialog struct {
+	criteria   gurps.FindReplace
+	rows       []any
+	list       []*gurps.TextReplacement
+	status     *unison.Label
+	preview    *unison.List[*findReplacePreview]
+	replaceBtn *unison.Button
+}
+
+type findReplacePreview struct {
+	replacement *gurps.TextReplacement
+}
+
+func (p *findReplacePreview) String() string {
+	return fmt.Sprintf("%s — %s: %s → %s", p.replacement.Name, p.replacement.Field, p.replacement.Before,
+		p.replacement.After)
+}
+
+// asAnyRows converts the rows into the form needed by the find & replace model.
+func asAnyRows[T gurps.NodeTypes](rows []T) []any {
+	list := make([]any, len(rows))
+	for i, one := range rows {
+		list[i] = one
+	}
+	return list
+}
+
+// promptForFindReplace displays the find & replace dialog for the rows, previewing each change that would be made, and
+// returns the replacements to apply.
+func promptForFindReplace(rows []any) (list []*gurps.TextReplacement, ok bool) {
+	d := &findReplaceDialog{rows: rows}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(600, 0),
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+
+	findTitle := i18n.Text("Find")
+	panel.AddChild(NewFieldLeadingLabel(findTitle, false))
+	findField := NewStringField(nil, "", findTitle, func() string { return d.criteria.Find },
+		func(v string) {
+			d.criteria.Find = v
+			d.update()
+		})
+	findField.Tooltip = newWrappedTooltip(i18n.Text("The text to search for in names, notes, and user descriptions"))
+	findField.SetMinimumTextWidthUsing(minTextWidthCandidate)
+	panel.AddChild(findField)
+
+	replaceTitle := i18n.Text("Replace With")
+	panel.AddChild(NewFieldLeadingLabel(replaceTitle, false))
+	replaceField := NewStringField(nil, "", replaceTitle, func() string { return d.criteria.Replace },
+		func(v string) {
+			d.criteria.Replace = v
+			d.update()
+		})
+	replaceField.Tooltip = newWrappedTooltip(i18n.Text("When using a regular expression, $1, $2, etc. may be used to refer to the captured groups"))
+	replaceField.SetMinimumTextWidthUsing(minTextWidthCandidate)
+	panel.AddChild(replaceField)
+
+	options := unison.NewPanel()
+	options.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing * 2,
+	})
+	options.AddChild(NewCheckBox(nil, "", i18n.Text("Regular Expression"),
+		func() check.Enum { return check.FromBool(d.criteria.UseRegex) },
+		func(state check.Enum) {
+			d.criteria.UseRegex = state == check.On
+			d.update()
+		}))
+	options.AddChild(NewCheckBox(nil, "", i18n.Text("Match Case"),
+		func() check.Enum { return check.FromBool(d.criteria.MatchCase) },
+		func(state check.Enum) {
+			d.criteria.MatchCase = state == check.On
+			d.update()
+		}))
+	panel.AddChild(unison.NewPanel())
+	panel.AddChild(options)
+
+	d.status = unison.NewLabel()
+	d.status.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(d.status)
+
+	d.preview = unison.NewList[*findReplacePreview]()
+	d.preview.BackgroundInk = unison.ThemeSurface
+	scroller := unison.NewScrollPanel()
+	scroller.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	scroller.SetContent(d.preview, behavior.Fill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:   2,
+		MinSize: unison.NewSize(0, 200),
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	panel.AddChild(scroller)
+
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Replace All")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return nil, false
+	}
+	dialog.Window().SetTitle(i18n.Text("Find & Replace"))
+	d.replaceBtn = dialog.Button(unison.ModalResponseOK)
+	d.update()
+	if dialog.RunModal() != unison.ModalResponseOK || len(d.list) == 0 {
+		return nil, false
+	}
+	return d.list, true
+}
+
+func (d *findReplaceDialog) update() {
+	var err error
+	d.list, err = d.criteria.Replacements(d.rows...)
+	switch {
+	case err != nil:
+		d.list = nil
+		d.status.SetTitle(i18n.Text("Invalid regular expression"))
+	case d.criteria.Find == "":
+		d.status.SetTitle(i18n.Text("Enter the text to find"))
+	default:
+		d.status.SetTitle(fmt.Sprintf(i18n.Text("%d replacements will be made:"), len(d.list)))
+	}
+	d.status.MarkForLayoutRecursivelyUpward()
+	d.preview.Clear()
+	for _, one := range d.list {
+		d.preview.Append(&findReplacePreview{replacement: one})
+	}
+	d.preview.Pack()
+	d.preview.MarkForLayoutRecursivelyUpward()
+	d.preview.MarkForRedraw()
+	if d.replaceBtn != nil {
+		d.replaceBtn.SetEnabled(len(d.list) != 0)
+	}
+}
+
+// findReplace asks for the text to find and replace across all of the rows of the table dockable and applies the
+// changes.
+func (d *TableDockable[T]) findReplace() {
+	list, ok := promptForFindReplace(asAnyRows(d.provider.RootData()))
+	if !ok {
+		return
+	}
+	mgr := unison.UndoManagerFor(d)
+	var before *TableUndoEditData[T]
+	if mgr != nil {
+		before = NewTableUndoEditData(d.table)
+	}
+	gurps.ApplyReplacements(list)
+	d.Rebuild(true)
+	d.MarkModified(d)
+	if mgr != nil && before != nil {
+		addUndo(mgr, &unison.UndoEdit[*TableUndoEditData[T]]{
+			ID:         unison.NextUndoID(),
+			EditName:   findReplaceAction.Title,
+			UndoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.BeforeData.Apply() },
+			RedoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.AfterData.Apply() },
+			AbsorbFunc: func(_ *unison.UndoEdit[*TableUndoEditData[T]], _ unison.Undoable) bool { return false },
+			BeforeData: before,
+			AfterData:  NewTableUndoEditData(d.table),
+		})
+	}
+}
+
+// findReplace asks for the text to find and replace across all of the rows of the sheet and applies the changes.
+func (s *Sheet) findReplace() {
+	var rows []any
+	rows = append(rows, asAnyRows(s.entity.Traits)...)
+	rows = append(rows, asAnyRows(s.entity.Skills)...)
+	rows = append(rows, asAnyRows(s.entity.Spells)...)
+	rows = append(rows, asAnyRows(s.entity.CarriedEquipment)...)
+	rows = append(rows, asAnyRows(s.entity.OtherEquipment)...)
+	rows = append(rows, asAnyRows(s.entity.Notes)...)
+	list, ok := promptForFindReplace(rows)
+	if !ok {
+		return
+	}
+	var undo *unison.UndoEdit[*sheetTablesUndoData]
+	mgr := unison.UndoManagerFor(s)
+	if mgr != nil {
+		undo = &unison.UndoEdit[*sheetTablesUndoData]{
+			ID:         unison.NextUndoID(),
+			EditName:   findReplaceAction.Title,
+			UndoFunc:   func(e *unison.UndoEdit[*sheetTablesUndoData]) { e.BeforeData.Apply() },
+			RedoFunc:   func(e *unison.UndoEdit[*sheetTablesUndoData]) { e.AfterData.Apply() },
+			AbsorbFunc: func(_ *unison.UndoEdit[*sheetTablesUndoData], _ unison.Undoable) bool { return false },
+			BeforeData: newSheetTablesUndoData(s),
+		}
+	}
+	gurps.ApplyReplacements(list)
+	s.Traits.Table.SyncToModel()
+	s.Skills.Table.SyncToModel()
+	s.Spells.Table.SyncToModel()
+	s.CarriedEquipment.Table.SyncToModel()
+	s.OtherEquipment.Table.SyncToModel()
+	s.Notes.Table.SyncToModel()
+	if mgr != nil && undo != nil {
+		undo.AfterData = newSheetTablesUndoData(s)
+		addUndo(mgr, undo)
+	}
+	s.MarkModified(s)
+	s.Rebuild(true)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/autoscale"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/updchan"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox/cmdline"
 	"github.com/richardwilkes/toolbox/errs"
@@ -41,9 +42,14 @@ type generalSettingsDockable struct {
 	autoAddNaturalAttacksCheckbox  *CheckBox
 	groupContainersOnSortCheckbox  *CheckBox
 	initialClickSelectsAllCheckbox *CheckBox
+	restoreSessionCheckbox         *CheckBox
 	pointsField                    *DecimalField
 	techLevelField                 *StringField
 	calendarPopup                  *unison.PopupMenu[string]
+	nameCulturePopup               *unison.PopupMenu[string]
+	spellCheckPopup                *unison.PopupMenu[string]
+	updateChannelPopup             *unison.PopupMenu[updchan.Channel]
+	uiScaleField                   *PercentageField
 	initialListScaleField          *PercentageField
 	initialEditorScaleField        *PercentageField
 	initialSheetScaleField         *PercentageField
@@ -54,6 +60,7 @@ type generalSettingsDockable struct {
 	maxAutoColWidthField           *IntegerField
 	monitorResolutionField         *IntegerField
 	exportResolutionField          *IntegerField
+	autosaveIntervalField          *IntegerField
 	tooltipDelayField              *DecimalField
 	tooltipDismissalField          *DecimalField
 	scrollWheelMultiplierField     *DecimalField
@@ -100,6 +107,20 @@ func (d *generalSettingsDockable) initContent(content *unison.Panel) {
 	d.createInitialPointsFields(content)
 	d.createTechLevelField(content)
 	d.createCalendarPopup(content)
+	d.createNameCulturePopup(content)
+	d.createSpellCheckPopup(content)
+	d.createUpdateChannelPopup(content)
+	uiScaleTitle := i18n.Text("Interface Scale")
+	content.AddChild(NewFieldLeadingLabel(uiScaleTitle, false))
+	d.uiScaleField = NewPercentageField(nil, "", uiScaleTitle,
+		func() int { return gurps.GlobalSettings().General.UIScale },
+		func(v int) {
+			gurps.GlobalSettings().General.UIScale = v
+			applyUIScaleToAllWindows()
+		},
+		gurps.UIScaleMin, gurps.UIScaleMax, false, false)
+	d.uiScaleField.Tooltip = newWrappedTooltip(i18n.Text("The scale applied to the text and controls of the entire interface, in addition to the scale of each document. Dialogs, menus and tooltips are not scaled"))
+	content.AddChild(WrapWithSpan(2, d.uiScaleField))
 	initialListScaleTitle := i18n.Text("Initial List Scale")
 	content.AddChild(NewFieldLeadingLabel(initialListScaleTitle, false))
 	d.initialListScaleField = NewPercentageField(nil, "", initialListScaleTitle,
@@ -157,6 +178,7 @@ func (d *generalSettingsDockable) initContent(content *unison.Panel) {
 	d.createCellAutoMaxWidthField(content)
 	d.createMonitorResolutionField(content)
 	d.createImageResolutionField(content)
+	d.createAutosaveIntervalField(content)
 	d.createTooltipDelayField(content)
 	d.createTooltipDismissalField(content)
 	d.createScrollWheelMultiplierField(content)
@@ -225,6 +247,17 @@ func (d *generalSettingsDockable) createCheckboxBlock(content *unison.Panel) {
 	d.initialClickSelectsAllCheckbox.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
 	content.AddChild(NewFieldLeadingLabel("", false))
 	content.AddChild(d.initialClickSelectsAllCheckbox)
+
+	d.restoreSessionCheckbox = NewCheckBox(nil, "", i18n.Text("Reopen documents from the last session"),
+		func() check.Enum {
+			return check.FromBool(gurps.GlobalSettings().General.RestoreSession)
+		},
+		func(state check.Enum) {
+			gurps.GlobalSettings().General.RestoreSession = state == check.On
+		})
+	d.restoreSessionCheckbox.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	content.AddChild(NewFieldLeadingLabel("", false))
+	content.AddChild(d.restoreSessionCheckbox)
 }
 
 func (d *generalSettingsDockable) createInitialPointsFields(content *unison.Panel) {
@@ -270,6 +303,86 @@ func (d *generalSettingsDockable) createCalendarPopup(content *unison.Panel) {
 	content.AddChild(d.calendarPopup)
 }
 
+func (d *generalSettingsDockable) createUpdateChannelPopup(content *unison.Panel) {
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Update Channel"), false))
+	d.updateChannelPopup = unison.NewPopupMenu[updchan.Channel]()
+	for _, one := range updchan.Channels {
+		d.updateChannelPopup.AddItem(one)
+	}
+	d.updateChannelPopup.Select(gurps.GlobalSettings().General.UpdateChannel)
+	d.updateChannelPopup.Tooltip = newWrappedTooltip(i18n.Text("The Beta channel also offers pre-release versions when checking for updates"))
+	d.updateChannelPopup.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	d.updateChannelPopup.SelectionChangedCallback = func(p *unison.PopupMenu[updchan.Channel]) {
+		if item, ok := p.Selected(); ok {
+			gurps.GlobalSettings().General.UpdateChannel = item
+		}
+	}
+	content.AddChild(d.updateChannelPopup)
+}
+
+func (d *generalSettingsDockable) createNameCulturePopup(content *unison.Panel) {
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Name Culture"), false))
+	d.nameCulturePopup = unison.NewPopupMenu[string]()
+	d.nameCulturePopup.AddItem(i18n.Text("Use Ancestry"))
+	d.nameCulturePopup.AddItem(gurps.AvailableNameCultures(gurps.GlobalSettings().Libraries())...)
+	d.syncNameCulturePopup()
+	d.nameCulturePopup.Tooltip = newWrappedTooltip(i18n.Text("The name list used when randomizing names"))
+	d.nameCulturePopup.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	d.nameCulturePopup.SelectionChangedCallback = func(p *unison.PopupMenu[string]) {
+		if item, ok := p.Selected(); ok {
+			if p.SelectedIndex() == 0 {
+				item = ""
+			}
+			gurps.GlobalSettings().General.NameCulture = item
+		}
+	}
+	content.AddChild(d.nameCulturePopup)
+}
+
+func (d *generalSettingsDockable) syncNameCulturePopup() {
+	if culture := gurps.GlobalSettings().General.NameCulture; culture != "" {
+		for i := 1; i < d.nameCulturePopup.ItemCount(); i++ {
+			if item, ok := d.nameCulturePopup.ItemAt(i); ok && strings.EqualFold(item, culture) {
+				d.nameCulturePopup.SelectIndex(i)
+				return
+			}
+		}
+	}
+	d.nameCulturePopup.SelectIndex(0)
+}
+
+func (d *generalSettingsDockable) createSpellCheckPopup(content *unison.Panel) {
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Spell Checking"), false))
+	d.spellCheckPopup = unison.NewPopupMenu[string]()
+	d.spellCheckPopup.AddItem(i18n.Text("Off"))
+	d.spellCheckPopup.AddItem(gurps.AvailableDictionaryLanguages(gurps.GlobalSettings().Libraries())...)
+	d.syncSpellCheckPopup()
+	d.spellCheckPopup.Tooltip = newWrappedTooltip(i18n.Text(`The dictionary used to check the spelling of notes and descriptions. Dictionaries are word lists with a .dic extension placed in the Settings folder of a library and are named for their language, e.g. en_US.dic. Hunspell dictionaries may be used; the prefix and suffix rules from the .aff file with the same name are applied, but other Hunspell features are not supported.`))
+	d.spellCheckPopup.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	d.spellCheckPopup.SelectionChangedCallback = func(p *unison.PopupMenu[string]) {
+		if item, ok := p.Selected(); ok {
+			if p.SelectedIndex() == 0 {
+				item = ""
+			}
+			gurps.GlobalSettings().General.SpellCheckLanguage = item
+			resetSpellChecker()
+		}
+	}
+	content.AddChild(d.spellCheckPopup)
+}
+
+func (d *generalSettingsDockable) syncSpellCheckPopup() {
+	if language := gurps.GlobalSettings().General.SpellCheckLanguage; language != "" {
+		for i := 1; i < d.spellCheckPopup.ItemCount(); i++ {
+			if item, ok := d.spellCheckPopup.ItemAt(i); ok && strings.EqualFold(item, language) {
+				d.spellCheckPopup.SelectIndex(i)
+				return
+			}
+		}
+	}
+	d.spellCheckPopup.SelectIndex(0)
+}
+
 func (d *generalSettingsDockable) createCellAutoMaxWidthField(content *unison.Panel) {
 	title := i18n.Text("Max Auto Column Width")
 	content.AddChild(NewFieldLeadingLabel(title, false))
@@ -303,6 +416,16 @@ func (d *generalSettingsDockable) createImageResolutionField(content *unison.Pan
 	content.AddChild(WrapWithSpan(2, d.exportResolutionField, NewFieldTrailingLabel(i18n.Text("ppi"), false)))
 }
 
+func (d *generalSettingsDockable) createAutosaveIntervalField(content *unison.Panel) {
+	title := i18n.Text("Recovery Autosave Interval")
+	content.AddChild(NewFieldLeadingLabel(title, false))
+	d.autosaveIntervalField = NewIntegerField(nil, "", title,
+		func() int { return gurps.GlobalSettings().General.AutosaveInterval },
+		func(v int) { gurps.GlobalSettings().General.AutosaveInterval = v },
+		gurps.AutosaveIntervalMin, gurps.AutosaveIntervalMax, false, false)
+	content.AddChild(WrapWithSpan(2, d.autosaveIntervalField, NewFieldTrailingLabel(i18n.Text("seconds"), false)))
+}
+
 func (d *generalSettingsDockable) createTooltipDelayField(content *unison.Panel) {
 	title := i18n.Text("Tooltip Delay")
 	content.AddChild(NewFieldLeadingLabel(title, false))
@@ -406,9 +529,16 @@ func (d *generalSettingsDockable) sync() {
 	SetCheckBoxState(d.groupContainersOnSortCheckbox, gs.GroupContainersOnSort)
 	SetCheckBoxState(d.autoAddNaturalAttacksCheckbox, gs.AutoAddNaturalAttacks)
 	SetCheckBoxState(d.initialClickSelectsAllCheckbox, gs.InitialFieldClickSelectsAll)
+	SetCheckBoxState(d.restoreSessionCheckbox, gs.RestoreSession)
 	d.pointsField.SetText(gs.InitialPoints.String())
 	d.techLevelField.SetText(gs.DefaultTechLevel)
 	d.calendarPopup.Select(gs.CalendarRef(s.Libraries()).Name)
+	d.syncNameCulturePopup()
+	d.syncSpellCheckPopup()
+	resetSpellChecker()
+	d.updateChannelPopup.Select(gs.UpdateChannel)
+	SetFieldValue(d.uiScaleField.Field, d.uiScaleField.Format(gs.UIScale))
+	applyUIScaleToAllWindows()
 	SetFieldValue(d.initialListScaleField.Field, d.initialListScaleField.Format(gs.InitialListUIScale))
 	SetFieldValue(d.initialEditorScaleField.Field, d.initialEditorScaleField.Format(gs.InitialEditorUIScale))
 	SetFieldValue(d.initialSheetScaleField.Field, d.initialSheetScaleField.Format(gs.InitialSheetUIScale))
@@ -419,6 +549,7 @@ func (d *generalSettingsDockable) sync() {
 	d.maxAutoColWidthField.SetText(strconv.Itoa(gs.MaximumAutoColWidth))
 	d.monitorResolutionField.SetText(strconv.Itoa(gs.MonitorResolution))
 	d.exportResolutionField.SetText(strconv.Itoa(gs.ImageResolution))
+	d.autosaveIntervalField.SetText(strconv.Itoa(gs.AutosaveInterval))
 	d.tooltipDelayField.SetText(gs.TooltipDelay.String())
 	d.tooltipDismissalField.SetText(gs.TooltipDismissal.String())
 	d.scrollWheelMultiplierField.SetText(gs.ScrollWheelMultiplier.String())
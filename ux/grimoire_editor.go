@@ -0,0 +1,517 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/rpm"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+var (
+	_ unison.Dockable            = &grimoireEditor{}
+	_ unison.TabCloser           = &grimoireEditor{}
+	_ ModifiableRoot             = &grimoireEditor{}
+	_ unison.UndoManagerProvider = &grimoireEditor{}
+	_ GroupedCloser              = &grimoireEditor{}
+	_ Rebuildable                = &grimoireEditor{}
+)
+
+type grimoireEditor struct {
+	unison.Panel
+	owner            Rebuildable
+	entity           *gurps.Entity
+	previousDockable unison.Dockable
+	previousFocusKey string
+	undoMgr          *unison.UndoManager
+	applyButton      *unison.Button
+	cancelButton     *unison.Button
+	content          *unison.Panel
+	before           []*gurps.Ritual
+	current          []*gurps.Ritual
+	promptForSave    bool
+}
+
+func displayGrimoireEditor(owner Rebuildable, entity *gurps.Entity) {
+	if Activate(func(d unison.Dockable) bool {
+		if e, ok := d.AsPanel().Self.(*grimoireEditor); ok {
+			return e.owner == owner && entity == e.entity
+		}
+		return false
+	}) {
+		return
+	}
+	e := &grimoireEditor{
+		owner:   owner,
+		entity:  entity,
+		before:  gurps.CloneRituals(entity.Rituals),
+		current: gurps.CloneRituals(entity.Rituals),
+	}
+	e.Self = e
+
+	if defDC := DefaultDockContainer(); defDC != nil {
+		if e.previousDockable = defDC.CurrentDockable(); !toolbox.IsNil(e.previousDockable) {
+			if focus := e.previousDockable.AsPanel().Window().Focus(); focus != nil {
+				if unison.Ancestor[unison.Dockable](focus) == e.previousDockable {
+					e.previousFocusKey = focus.RefKey
+				}
+			}
+		}
+	}
+
+	e.undoMgr = unison.NewUndoManager(100, func(err error) { errs.Log(err) })
+	e.SetLayout(&unison.FlexLayout{Columns: 1})
+	e.AddChild(e.createToolbar())
+	e.content = unison.NewPanel()
+	e.content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	e.content.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing * 2,
+	})
+	e.content.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, _ bool) bool {
+		switch {
+		case mod.OSMenuCmdModifierDown() && (keyCode == unison.KeyReturn || keyCode == unison.KeyNumPadEnter):
+			if e.applyButton.Enabled() {
+				e.applyButton.Click()
+			}
+			return true
+		case mod == 0 && keyCode == unison.KeyEscape:
+			if e.cancelButton.Enabled() {
+				e.cancelButton.Click()
+			}
+			return true
+		default:
+			return false
+		}
+	}
+	e.initContent()
+	scroller := unison.NewScrollPanel()
+	scroller.SetContent(e.content, behavior.HintedFill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	e.AddChild(scroller)
+	e.ClientData()[AssociatedIDKey] = e.entity.ID
+	e.promptForSave = true
+	scroller.Content().AsPanel().ValidateScrollRoot()
+	PlaceInDock(e, dgroup.Editors, false)
+}
+
+func (e *grimoireEditor) createToolbar() unison.Paneler {
+	toolbar := unison.NewPanel()
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+
+	e.applyButton = unison.NewSVGButton(unison.CheckmarkSVG)
+	e.applyButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Apply Changes"),
+		fmt.Sprintf(i18n.Text("%v%v or %v%v"), unison.OSMenuCmdModifier(), unison.KeyReturn, unison.OSMenuCmdModifier(),
+			unison.KeyNumPadEnter))
+	e.applyButton.SetEnabled(false)
+	e.applyButton.ClickCallback = func() {
+		e.apply()
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.applyButton)
+
+	e.cancelButton = unison.NewSVGButton(svg.Not)
+	e.cancelButton.Tooltip = newWrappedTooltipWithSecondaryText(i18n.Text("Discard Changes"), unison.KeyEscape.String())
+	e.cancelButton.SetEnabled(false)
+	e.cancelButton.ClickCallback = func() {
+		e.promptForSave = false
+		e.AttemptClose()
+	}
+	toolbar.AddChild(e.cancelButton)
+
+	toolbar.AddChild(NewToolbarSeparator())
+
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add Ritual"))
+	addButton.ClickCallback = e.addRitual
+	toolbar.AddChild(addButton)
+
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return toolbar
+}
+
+func (e *grimoireEditor) initContent() {
+	for _, ritual := range e.current {
+		e.content.AddChild(e.createRitualPanel(ritual))
+	}
+}
+
+// rebuildContent recreates the content after rituals, effects or modifiers have been added or removed.
+func (e *grimoireEditor) rebuildContent() {
+	e.content.RemoveAllChildren()
+	e.initContent()
+	e.content.Pack()
+	MarkForLayoutWithinDockable(e.content)
+	e.content.MarkForRedraw()
+	MarkModified(e.content)
+}
+
+func (e *grimoireEditor) createRitualPanel(ritual *gurps.Ritual) *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1),
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(e.createRitualHeader(ritual))
+	panel.AddChild(e.createEffectsPanel(ritual))
+	panel.AddChild(e.createModifiersPanel(ritual))
+	panel.AddChild(e.createCastingPanel(ritual))
+	return panel
+}
+
+func (e *grimoireEditor) createRitualHeader(ritual *gurps.Ritual) *unison.Panel {
+	header := unison.NewPanel()
+	header.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+
+	deleteButton := unison.NewSVGButton(svg.Trash)
+	deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove Ritual"))
+	deleteButton.ClickCallback = func() {
+		if i := slices.Index(e.current, ritual); i != -1 {
+			e.current = slices.Delete(e.current, i, i+1)
+			e.rebuildContent()
+		}
+	}
+	header.AddChild(deleteButton)
+
+	nameText := i18n.Text("Name")
+	name := NewStringField(nil, "", nameText,
+		func() string { return ritual.Name },
+		func(value string) {
+			ritual.Name = value
+			MarkModified(header)
+		})
+	name.Watermark = nameText
+	name.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	header.AddChild(name)
+
+	header.AddChild(NewCheckBox(nil, "", i18n.Text("Conditional"),
+		func() check.Enum { return check.FromBool(ritual.Conditional) },
+		func(in check.Enum) {
+			ritual.Conditional = in == check.On
+			MarkModified(header)
+		}))
+
+	triggerText := i18n.Text("Trigger")
+	trigger := NewStringField(nil, "", triggerText,
+		func() string { return ritual.Trigger },
+		func(value string) {
+			ritual.Trigger = value
+			MarkModified(header)
+		})
+	trigger.Watermark = triggerText
+	trigger.Tooltip = newWrappedTooltip(i18n.Text("The event that releases a conditional ritual"))
+	trigger.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	header.AddChild(trigger)
+
+	header.SetLayout(&unison.FlexLayout{
+		Columns:  len(header.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return header
+}
+
+func (e *grimoireEditor) createEffectsPanel(ritual *gurps.Ritual) *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  5,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	for _, effect := range ritual.Effects {
+		deleteButton := unison.NewSVGButton(svg.Trash)
+		deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove Effect"))
+		deleteButton.ClickCallback = func() {
+			if i := slices.Index(ritual.Effects, effect); i != -1 {
+				ritual.Effects = slices.Delete(ritual.Effects, i, i+1)
+				e.rebuildContent()
+			}
+		}
+		panel.AddChild(deleteButton)
+		panel.AddChild(NewCheckBox(nil, "", i18n.Text("Greater"),
+			func() check.Enum { return check.FromBool(effect.Greater) },
+			func(in check.Enum) {
+				effect.Greater = in == check.On
+				MarkModified(panel)
+			}))
+		addPopup(panel, rpm.Effects, &effect.Effect)
+		addPopup(panel, rpm.Paths, &effect.Path)
+		panel.AddChild(unison.NewPanel())
+	}
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add Effect"))
+	addButton.ClickCallback = func() {
+		ritual.Effects = append(ritual.Effects, &gurps.RitualEffect{})
+		e.rebuildContent()
+	}
+	panel.AddChild(addButton)
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Effects"), false))
+	return panel
+}
+
+func (e *grimoireEditor) createModifiersPanel(ritual *gurps.Ritual) *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	for _, modifier := range ritual.Modifiers {
+		deleteButton := unison.NewSVGButton(svg.Trash)
+		deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove Modifier"))
+		deleteButton.ClickCallback = func() {
+			if i := slices.Index(ritual.Modifiers, modifier); i != -1 {
+				ritual.Modifiers = slices.Delete(ritual.Modifiers, i, i+1)
+				e.rebuildContent()
+			}
+		}
+		panel.AddChild(deleteButton)
+		modifierText := i18n.Text("Modifier")
+		name := NewStringField(nil, "", modifierText,
+			func() string { return modifier.Name },
+			func(value string) {
+				modifier.Name = value
+				MarkModified(panel)
+			})
+		name.Watermark = modifierText
+		name.SetLayoutData(&unison.FlexLayoutData{
+			HAlign: align.Fill,
+			HGrab:  true,
+		})
+		panel.AddChild(name)
+		energy := NewIntegerField(nil, "", i18n.Text("Energy"),
+			func() int { return modifier.Energy },
+			func(value int) {
+				modifier.Energy = value
+				MarkModified(panel)
+			}, -999999, 999999, true, false)
+		energy.Tooltip = newWrappedTooltip(i18n.Text("Energy"))
+		panel.AddChild(energy)
+	}
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add Modifier"))
+	addButton.ClickCallback = func() {
+		ritual.Modifiers = append(ritual.Modifiers, &gurps.RitualModifier{})
+		e.rebuildContent()
+	}
+	panel.AddChild(addButton)
+	label := NewFieldLeadingLabel(i18n.Text("Modifiers"), false)
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	panel.AddChild(label)
+	return panel
+}
+
+func (e *grimoireEditor) createCastingPanel(ritual *gurps.Ritual) *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Gathered"), false))
+	gathered := NewIntegerField(nil, "", i18n.Text("Gathered"),
+		func() int { return ritual.Gathered },
+		func(value int) {
+			ritual.Gathered = value
+			MarkModified(panel)
+		}, 0, 999999, false, false)
+	panel.AddChild(gathered)
+
+	gatherButton := unison.NewButton()
+	gatherButton.SetTitle(i18n.Text("Gather"))
+	gatherButton.Tooltip = newWrappedTooltip(i18n.Text("Record a successful energy gathering roll"))
+	gatherButton.ClickCallback = func() {
+		ritual.Gather(e.entity, 1)
+		MarkModified(panel)
+	}
+	panel.AddChild(gatherButton)
+
+	castButton := unison.NewButton()
+	castButton.SetTitle(i18n.Text("Cast"))
+	castButton.Tooltip = newWrappedTooltip(i18n.Text("Spend the gathered energy to cast the ritual"))
+	castButton.ClickCallback = func() {
+		ritual.Gathered = 0
+		MarkModified(panel)
+	}
+	panel.AddChild(castButton)
+
+	info := NewNonEditableField(func(field *NonEditableField) {
+		field.SetTitle(fmt.Sprintf(i18n.Text("Energy %d, skill %s, %d gathering rolls needed"),
+			ritual.EnergyCost(), ritual.Level(e.entity).String(), ritual.GatheringRollsNeeded(e.entity)))
+		castButton.SetEnabled(ritual.EnergyRemaining() == 0 && ritual.Gathered != 0)
+		gatherButton.SetEnabled(ritual.EnergyRemaining() != 0)
+	})
+	info.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Middle,
+		HGrab:  true,
+	})
+	panel.AddChild(info)
+
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  len(panel.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return panel
+}
+
+func (e *grimoireEditor) addRitual() {
+	e.current = append(e.current, gurps.NewRitual(i18n.Text("Ritual")))
+	e.rebuildContent()
+}
+
+func (e *grimoireEditor) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.GCSSpells,
+		Size: suggestedSize,
+	}
+}
+
+func (e *grimoireEditor) Title() string {
+	return fmt.Sprintf(i18n.Text("Grimoire for %s"), e.owner.String())
+}
+
+func (e *grimoireEditor) String() string {
+	return e.Title()
+}
+
+func (e *grimoireEditor) Tooltip() string {
+	return ""
+}
+
+func (e *grimoireEditor) Modified() bool {
+	modified := !reflect.DeepEqual(e.before, e.current)
+	e.applyButton.SetEnabled(modified)
+	e.cancelButton.SetEnabled(modified)
+	return modified
+}
+
+func (e *grimoireEditor) MarkModified(_ unison.Paneler) {
+	UpdateTitleForDockable(e)
+	DeepSync(e)
+}
+
+func (e *grimoireEditor) Rebuild(_ bool) {
+	e.MarkModified(nil)
+	e.MarkForLayoutRecursively()
+	e.MarkForRedraw()
+}
+
+func (e *grimoireEditor) CloseWithGroup(other unison.Paneler) bool {
+	return e.owner != nil && e.owner == other
+}
+
+func (e *grimoireEditor) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(e)
+}
+
+func (e *grimoireEditor) AttemptClose() bool {
+	if !CloseGroup(e) {
+		return false
+	}
+	if e.promptForSave && !reflect.DeepEqual(e.before, e.current) {
+		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Save changes made to\n%s?"), e.Title()), "") {
+		case unison.ModalResponseDiscard:
+		case unison.ModalResponseOK:
+			e.apply()
+		default:
+			return false
+		}
+	}
+	if dc := unison.Ancestor[*unison.DockContainer](e); dc != nil {
+		dc.Close(e)
+		if !toolbox.IsNil(e.previousDockable) {
+			if dc = unison.Ancestor[*unison.DockContainer](e.previousDockable); dc != nil {
+				dc.SetCurrentDockable(e.previousDockable)
+				if e.previousFocusKey != "" {
+					if p := e.previousDockable.AsPanel().FindRefKey(e.previousFocusKey); p != nil {
+						p.RequestFocus()
+					}
+				}
+			}
+		}
+		return true
+	}
+	return e.Window().AttemptClose()
+}
+
+func (e *grimoireEditor) UndoManager() *unison.UndoManager {
+	return e.undoMgr
+}
+
+func (e *grimoireEditor) apply() {
+	e.Window().FocusNext() // Intentionally move the focus to ensure any pending edits are flushed
+	owner := e.owner
+	entity := e.entity
+	if mgr := unison.UndoManagerFor(owner); mgr != nil {
+		addUndo(mgr, &unison.UndoEdit[[]*gurps.Ritual]{
+			ID:       unison.NextUndoID(),
+			EditName: i18n.Text("Grimoire Changes"),
+			UndoFunc: func(edit *unison.UndoEdit[[]*gurps.Ritual]) {
+				entity.Rituals = gurps.CloneRituals(edit.BeforeData)
+				owner.Rebuild(false)
+			},
+			RedoFunc: func(edit *unison.UndoEdit[[]*gurps.Ritual]) {
+				entity.Rituals = gurps.CloneRituals(edit.AfterData)
+				owner.Rebuild(false)
+			},
+			BeforeData: e.before,
+			AfterData:  gurps.CloneRituals(e.current),
+		})
+	}
+	entity.Rituals = gurps.CloneRituals(e.current)
+	owner.Rebuild(true)
+}
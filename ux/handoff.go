@@ -12,90 +12,261 @@ package ux
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
 	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
 	"log/slog"
 	"net"
+	"os"
 	"path/filepath"
+	"runtime"
 	"time"
 
+	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/atexit"
 	"github.com/richardwilkes/toolbox/cmdline"
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/xio"
+	"github.com/richardwilkes/toolbox/xio/fs/paths"
 )
 
-func startHandoffService(readyChan chan struct{}, pathsChan chan<- []string, paths []string) {
-	const address = "127.0.0.1:13322"
-	var pathsBuffer []byte
-	now := time.Now()
-	for time.Since(now) < 10*time.Second {
-		// First, try to establish our port and become the primary GCS instance
-		if listener, err := net.Listen("tcp4", address); err == nil {
+// Message types that may follow the app identifier exchange.
+const (
+	handoffPaths    byte = 22
+	handoffCommand  byte = 23
+	handoffRequests byte = 24
+)
+
+const (
+	handoffAttempts      = 3
+	handoffTokenSize     = 16
+	handoffMaxBlockSize  = 1 << 20
+	remoteCommandTimeout = 10 * time.Minute
+)
+
+// handoffLock holds the contents of the lockfile published by the primary instance. Secondary instances read it to
+// find the endpoint to connect to and the token they must present. The token is generated anew each time an instance
+// becomes primary, and the lockfile is only readable by the user, so other local users and processes that can't read
+// the user's files are unable to make GCS open files or run commands.
+type handoffLock struct {
+	Network string `json:"network"`
+	Address string `json:"address"`
+	Token   string `json:"token"`
+}
+
+func startHandoffService(readyChan chan struct{}, requestsChan chan<- []gurps.OpenRequest, requests []gurps.OpenRequest) {
+	var requestsBuffer []byte
+	for range handoffAttempts {
+		// First, try to publish our endpoint and become the primary GCS instance
+		if listener, token := claimHandoff(); listener != nil {
 			go waitForReady(readyChan)
-			go acceptHandoff(listener, pathsChan)
+			go acceptHandoff(listener, token, readyChan, requestsChan)
 			return
 		}
-		if pathsBuffer == nil {
+		if requestsBuffer == nil {
 			var err error
-			absPaths := make([]string, len(paths))
-			for i, p := range paths {
-				if absPaths[i], err = filepath.Abs(p); err != nil {
-					absPaths[i] = p
-				}
-			}
-			if pathsBuffer, err = json.Marshal(absPaths); err != nil {
-				errs.Log(err, "paths", absPaths)
+			if requestsBuffer, err = json.Marshal(requests); err != nil {
+				errs.Log(err, "requests", requests)
 				atexit.Exit(1)
 			}
 		}
-		// Port is in use, try connecting as a client and handing off our file list
-		if conn, err := net.DialTimeout("tcp4", address, time.Second); err == nil && handoff(conn, pathsBuffer) {
+		// Another instance is primary, try connecting as a client and handing off our file list
+		if conn, token := dialHandoff(); conn != nil && handoff(conn, token, requestsBuffer) {
 			atexit.Exit(0)
 		}
-		// Client can't reach the server, loop around and start the processHandoff again
+		// Client can't reach the server, so the lockfile was left behind by an instance that didn't exit cleanly
+		removeStaleHandoffLock()
 	}
+	errs.Log(errs.New("unable to establish the handoff service"))
 }
 
-func handoff(conn net.Conn, pathsBuffer []byte) bool {
-	defer xio.CloseIgnoringErrors(conn)
-	buffer := make([]byte, len(cmdline.AppIdentifier))
-	if err := conn.SetDeadline(time.Now().Add(time.Second)); err != nil {
+func handoffLockPath() string {
+	return filepath.Join(paths.AppDataDir(), cmdline.AppCmdName+"_handoff.lock")
+}
+
+// claimHandoff starts listening on a new endpoint and then attempts to publish it in the lockfile. A Unix domain socket
+// unique to this process is used where available, otherwise an ephemeral TCP port on the loopback interface is used.
+// Returns nil if another instance has already published its endpoint.
+func claimHandoff() (listener net.Listener, token string) {
+	dir := paths.AppDataDir()
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		errs.Log(err, "dir", dir)
+		return nil, ""
+	}
+	var err error
+	socketPath := filepath.Join(dir, fmt.Sprintf("%s_%d.sock", cmdline.AppCmdName, os.Getpid()))
+	if listener, err = net.Listen("unix", socketPath); err == nil {
+		// Only the owner needs to connect. The token is still required, as not all platforms honor this.
+		if err = os.Chmod(socketPath, 0o600); err != nil {
+			errs.Log(err, "path", socketPath)
+		}
+	} else if listener, err = net.Listen("tcp4", "127.0.0.1:0"); err != nil {
 		errs.Log(err)
-		return false
+		return nil, ""
 	}
-	n, err := conn.Read(buffer)
-	if err != nil {
+	var tokenBuffer [handoffTokenSize]byte
+	if _, err = rand.Read(tokenBuffer[:]); err != nil {
 		errs.Log(err)
-		return false
+		xio.CloseIgnoringErrors(listener)
+		return nil, ""
 	}
-	if n != len(buffer) || !bytes.Equal(buffer, []byte(cmdline.AppIdentifier)) {
-		errs.Log(errs.New("unexpected app identifier"))
-		return false
+	lock := handoffLock{
+		Network: listener.Addr().Network(),
+		Address: listener.Addr().String(),
+		Token:   hex.EncodeToString(tokenBuffer[:]),
+	}
+	if err = publishHandoffLock(&lock); err != nil {
+		xio.CloseIgnoringErrors(listener)
+		return nil, ""
 	}
-	buffer = make([]byte, 5)
-	buffer[0] = 22
-	binary.LittleEndian.PutUint32(buffer[1:], uint32(len(pathsBuffer))) //nolint:gosec // No, this won't overflow
-	n, err = conn.Write(buffer)
+	lockPath := handoffLockPath()
+	atexit.Register(func() {
+		xio.CloseIgnoringErrors(listener)
+		_ = os.Remove(lockPath)
+	})
+	return listener, lock.Token
+}
+
+// publishHandoffLock writes the lockfile atomically, failing if it already exists.
+func publishHandoffLock(lock *handoffLock) error {
+	data, err := json.Marshal(lock)
 	if err != nil {
-		errs.Log(err)
-		return false
+		return errs.Wrap(err)
+	}
+	lockPath := handoffLockPath()
+	tmpPath := fmt.Sprintf("%s.%d", lockPath, os.Getpid())
+	if err = os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return errs.Wrap(err)
+	}
+	defer func() { _ = os.Remove(tmpPath) }()
+	if err = os.Link(tmpPath, lockPath); err != nil {
+		return errs.Wrap(err)
 	}
-	if n != len(buffer) {
-		errs.Log(errs.Newf("unexpected value for n: %d, len(buffer): %d", n, len(buffer)))
+	return nil
+}
+
+// readHandoffLock returns the contents of the lockfile, or nil if it is missing, invalid or could have been written by
+// another user.
+func readHandoffLock() *handoffLock {
+	lockPath := handoffLockPath()
+	fi, err := os.Stat(lockPath)
+	if err != nil || (runtime.GOOS != toolbox.WindowsOS && fi.Mode().Perm()&0o077 != 0) {
+		return nil
+	}
+	var data []byte
+	if data, err = os.ReadFile(lockPath); err != nil {
+		return nil
+	}
+	var lock handoffLock
+	if err = json.Unmarshal(data, &lock); err != nil || lock.Token == "" {
+		return nil
+	}
+	return &lock
+}
+
+// dialHandoff connects to the endpoint published by the primary instance, returning nil if it can't be reached.
+func dialHandoff() (conn net.Conn, token string) {
+	lock := readHandoffLock()
+	if lock == nil {
+		return nil, ""
+	}
+	var err error
+	if conn, err = net.DialTimeout(lock.Network, lock.Address, time.Second); err != nil {
+		return nil, ""
+	}
+	return conn, lock.Token
+}
+
+// removeStaleHandoffLock removes the lockfile, and the socket it refers to, if nothing is answering on its endpoint.
+func removeStaleHandoffLock() {
+	lock := readHandoffLock()
+	if lock != nil {
+		if conn, err := net.DialTimeout(lock.Network, lock.Address, time.Second); err == nil {
+			xio.CloseIgnoringErrors(conn)
+			return
+		}
+		if lock.Network == "unix" {
+			_ = os.Remove(lock.Address)
+		}
+	}
+	lockPath := handoffLockPath()
+	if err := os.Remove(lockPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		errs.Log(err, "path", lockPath)
+	}
+}
+
+func handoff(conn net.Conn, token string, requestsBuffer []byte) bool {
+	defer xio.CloseIgnoringErrors(conn)
+	if err := conn.SetDeadline(time.Now().Add(time.Second)); err != nil {
+		errs.Log(err)
 		return false
 	}
-	if n, err = conn.Write(pathsBuffer); err != nil {
+	if err := handoffHandshake(conn, token, handoffRequests); err != nil {
 		errs.Log(err)
 		return false
 	}
-	if n != len(pathsBuffer) {
-		errs.Log(errs.Newf("unexpected value for n: %d, len(pathsBuffer): %d", n, len(pathsBuffer)))
+	if err := writeHandoffBlock(conn, requestsBuffer); err != nil {
+		errs.Log(err)
 		return false
 	}
 	return true
 }
 
+// handoffHandshake verifies that the server is GCS and then sends the token and the message type.
+func handoffHandshake(conn net.Conn, token string, msgType byte) error {
+	buffer := make([]byte, len(cmdline.AppIdentifier))
+	if _, err := io.ReadFull(conn, buffer); err != nil {
+		return errs.Wrap(err)
+	}
+	if !bytes.Equal(buffer, []byte(cmdline.AppIdentifier)) {
+		return errs.New("unexpected app identifier")
+	}
+	if err := writeHandoffBlock(conn, []byte(token)); err != nil {
+		return err
+	}
+	if _, err := conn.Write([]byte{msgType}); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+// writeHandoffBlock writes the data, preceded by its length.
+func writeHandoffBlock(conn net.Conn, data []byte) error {
+	var sizeBuffer [4]byte
+	binary.LittleEndian.PutUint32(sizeBuffer[:], uint32(len(data))) //nolint:gosec // No, this won't overflow
+	if _, err := conn.Write(sizeBuffer[:]); err != nil {
+		return errs.Wrap(err)
+	}
+	if _, err := conn.Write(data); err != nil {
+		return errs.Wrap(err)
+	}
+	return nil
+}
+
+// readHandoffBlock reads data written by writeHandoffBlock. The length is checked against maxSize before any space is
+// allocated for the data, so that a misbehaving peer can't force a large allocation.
+func readHandoffBlock(conn net.Conn, maxSize int) ([]byte, error) {
+	var sizeBuffer [4]byte
+	if _, err := io.ReadFull(conn, sizeBuffer[:]); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	size := binary.LittleEndian.Uint32(sizeBuffer[:])
+	if uint64(size) > uint64(maxSize) {
+		return nil, errs.Newf("handoff block of %d bytes exceeds the limit of %d bytes", size, maxSize)
+	}
+	buffer := make([]byte, size)
+	if _, err := io.ReadFull(conn, buffer); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return buffer, nil
+}
+
 func waitForReady(readyChan <-chan struct{}) {
 	tStart := time.Now()
 	select {
@@ -110,18 +281,18 @@ func waitForReady(readyChan <-chan struct{}) {
 	}
 }
 
-func acceptHandoff(listener net.Listener, pathsChan chan<- []string) {
+func acceptHandoff(listener net.Listener, token string, readyChan <-chan struct{}, requestsChan chan<- []gurps.OpenRequest) {
 	for {
 		conn, err := listener.Accept()
 		if err != nil {
 			errs.Log(err)
 			break
 		}
-		go processHandoff(conn, pathsChan)
+		go processHandoff(conn, token, readyChan, requestsChan)
 	}
 }
 
-func processHandoff(conn net.Conn, pathsChan chan<- []string) {
+func processHandoff(conn net.Conn, token string, readyChan <-chan struct{}, requestsChan chan<- []gurps.OpenRequest) {
 	defer xio.CloseIgnoringErrors(conn)
 	if err := conn.SetDeadline(time.Now().Add(time.Second)); err != nil {
 		errs.Log(err)
@@ -131,43 +302,61 @@ func processHandoff(conn net.Conn, pathsChan chan<- []string) {
 		errs.Log(err)
 		return
 	}
-	var single [1]byte
-	n, err := conn.Read(single[:])
+	// The token must be the first thing checked, and is never longer than the one we issued.
+	buffer, err := readHandoffBlock(conn, len(token))
 	if err != nil {
 		errs.Log(err)
 		return
 	}
-	if n != 1 {
-		errs.Log(errs.Newf("unexpected value for n: %d", n))
-		return
-	}
-	if single[0] != 22 {
-		errs.Log(errs.Newf("unexpected value for single[0]: %d", single[0]))
+	if subtle.ConstantTimeCompare(buffer, []byte(token)) != 1 {
+		errs.Log(errs.New("invalid handoff token"))
 		return
 	}
-	var sizeBuffer [4]byte
-	if n, err = conn.Read(sizeBuffer[:]); err != nil {
+	var single [1]byte
+	if _, err = io.ReadFull(conn, single[:]); err != nil {
 		errs.Log(err)
 		return
 	}
-	if n != 4 {
-		errs.Log(errs.Newf("unexpected value for n: %d", n))
-		return
-	}
-	size := int(binary.LittleEndian.Uint32(sizeBuffer[:]))
-	buffer := make([]byte, size)
-	if n, err = conn.Read(buffer); err != nil {
+	buffer, err = readHandoffBlock(conn, handoffMaxBlockSize)
+	if err != nil {
 		errs.Log(err)
 		return
 	}
-	if n != size {
-		errs.Log(errs.Newf("unexpected value for n: %d, size: %d", n, size))
-		return
-	}
-	var paths []string
-	if err = json.Unmarshal(buffer, &paths); err != nil {
-		errs.Log(err)
-		return
+	switch single[0] {
+	case handoffPaths:
+		var paths []string
+		if err = json.Unmarshal(buffer, &paths); err != nil {
+			errs.Log(err)
+			return
+		}
+		requestsChan <- gurps.OpenRequestsForPaths(paths)
+	case handoffRequests:
+		var requests []gurps.OpenRequest
+		if err = json.Unmarshal(buffer, &requests); err != nil {
+			errs.Log(err)
+			return
+		}
+		requestsChan <- requests
+	case handoffCommand:
+		var cmd remoteCommand
+		if err = json.Unmarshal(buffer, &cmd); err != nil {
+			errs.Log(err)
+			return
+		}
+		// Commands may take a while to complete, e.g. when printing, so give them the same allowance the client uses
+		if err = conn.SetDeadline(time.Now().Add(remoteCommandTimeout)); err != nil {
+			errs.Log(err)
+			return
+		}
+		<-readyChan
+		if buffer, err = json.Marshal(cmd.run()); err != nil {
+			errs.Log(err)
+			return
+		}
+		if err = writeHandoffBlock(conn, buffer); err != nil {
+			errs.Log(err)
+		}
+	default:
+		errs.Log(errs.Newf("unexpected value for single[0]: %d", single[0]))
 	}
-	pathsChan <- paths
 }
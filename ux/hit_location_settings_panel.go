@@ -13,6 +13,7 @@ import (
 	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wound"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/rpgtools/dice"
 	"github.com/richardwilkes/toolbox/i18n"
@@ -186,6 +187,41 @@ func (p *hitLocationSettingsPanel) createContent() *unison.Panel {
 	intField.Tooltip = newWrappedTooltip(i18n.Text("The amount of DR this hit location grants due to natural toughness"))
 	content.AddChild(intField)
 
+	text = i18n.Text("Split DR")
+	content.AddChild(NewFieldLeadingLabel(text, false))
+	splitField := NewStringField(p.dockable.targetMgr, p.loc.KeyPrefix+"dr_split", text,
+		func() string { return p.loc.DRSplitString() },
+		func(s string) {
+			if split, ok := gurps.ParseDRSplit(s); ok {
+				p.loc.DRSplit = split
+			}
+		})
+	splitField.ValidateCallback = func() bool {
+		_, ok := gurps.ParseDRSplit(splitField.Text())
+		return ok
+	}
+	splitField.Watermark = "crushing: -2, burning: +1"
+	splitField.SetMinimumTextWidthUsing(prototypeMinNameWidth)
+	splitField.Tooltip = newWrappedTooltip(i18n.Text("Adjustments to the DR this hit location grants against specific types of damage"))
+	content.AddChild(splitField)
+
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Wounding"), false))
+	popup := unison.NewPopupMenu[wound.Location]()
+	for _, one := range wound.Locations {
+		popup.AddItem(one)
+	}
+	popup.Select(p.loc.Wounding)
+	popup.SelectionChangedCallback = func(popup *unison.PopupMenu[wound.Location]) {
+		if item, ok := popup.Selected(); ok && item != p.loc.Wounding {
+			undo := p.dockable.prepareUndo(i18n.Text("Change Wounding"))
+			p.loc.Wounding = item
+			p.dockable.finishAndPostUndo(undo)
+			p.dockable.MarkModified(nil)
+		}
+	}
+	popup.Tooltip = newWrappedTooltip(i18n.Text("The wounding modifiers to apply to hits to this location"))
+	content.AddChild(popup)
+
 	text = i18n.Text("Description")
 	content.AddChild(NewFieldLeadingLabel(text, false))
 	field = NewMultiLineStringField(p.dockable.targetMgr, p.loc.KeyPrefix+"desc", text,
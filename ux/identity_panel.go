@@ -59,9 +59,8 @@ func NewIdentityPanel(entity *gurps.Entity, targetMgr *TargetMgr) *IdentityPanel
 		func() string { return p.entity.Profile.Name },
 		func(s string) { p.entity.Profile.Name = s })
 	p.AddChild(NewPageLabelWithRandomizer(title,
-		i18n.Text("Randomize the name using the current ancestry"), func() {
-			p.entity.Profile.Name = p.entity.Ancestry().RandomName(
-				gurps.AvailableNameGenerators(gurps.GlobalSettings().Libraries()), p.entity.Profile.Gender)
+		i18n.Text("Randomize the name using the name culture from the general settings or the current ancestry"), func() {
+			p.entity.Profile.Name = p.entity.RandomName()
 			SetTextAndMarkModified(nameField.Field, p.entity.Profile.Name)
 		}))
 	nameField.ClientData()[SkipDeepSync] = true
@@ -0,0 +1,294 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+// maxInlineLevelSteps limits the number of levels tried when setting a skill level or trait points directly, guarding
+// against values that can't be reached.
+const maxInlineLevelSteps = 100
+
+// inlineCellEdit holds the current value of a cell that can be edited in place, a description of the values it accepts
+// and the function that applies a new, valid value to it.
+type inlineCellEdit struct {
+	value     string
+	tooltip   string
+	wholeOnly bool
+	commit    func(value fxp.Int)
+}
+
+// parse returns the value entered, or an error if it isn't a number or, for cells that only hold whole numbers, isn't
+// a whole number.
+func (e *inlineCellEdit) parse(text string) (fxp.Int, error) {
+	value, err := fxp.FromString(strings.TrimSpace(text))
+	if err != nil {
+		return 0, err
+	}
+	if e.wholeOnly && value != value.Trunc() {
+		return 0, errs.New(i18n.Text("a whole number is required"))
+	}
+	return value, nil
+}
+
+type undoApplier interface {
+	Apply()
+}
+
+// installInlineCellEditing makes double-clicking the points, level, quantity and uses cells of a table edit their value
+// in place. Double-clicking any other cell opens the full editor, as before.
+func installInlineCellEditing[T gurps.NodeTypes](owner Rebuildable, table *unison.Table[*Node[T]]) {
+	var where unison.Point
+	origMouseDown := table.MouseDownCallback
+	table.MouseDownCallback = func(pt unison.Point, button, clickCount int, mod unison.Modifiers) bool {
+		where = pt
+		return origMouseDown(pt, button, clickCount, mod)
+	}
+	origDoubleClick := table.DoubleClickCallback
+	table.DoubleClickCallback = func() {
+		if !startInlineCellEdit(owner, table, where) {
+			origDoubleClick()
+		}
+	}
+}
+
+func startInlineCellEdit[T gurps.NodeTypes](owner Rebuildable, table *unison.Table[*Node[T]], where unison.Point) bool {
+	rowIndex := table.OverRow(where.Y)
+	colIndex := table.OverColumn(where.X)
+	if rowIndex < 0 || colIndex < 0 || table.SelectionCount() != 1 {
+		return false
+	}
+	row := table.RowFromIndex(rowIndex)
+	if row == nil || !table.IsRowOrAnyParentSelected(rowIndex) {
+		return false
+	}
+	edit := inlineCellEditFor(owner, table, row.Data(), table.Columns[colIndex].ID)
+	if edit == nil {
+		return false
+	}
+	wnd, err := unison.NewWindow("", unison.FloatingWindowOption(), unison.NotResizableWindowOption(),
+		unison.UndecoratedWindowOption(), unison.TransientWindowOption())
+	if err != nil {
+		errs.Log(err)
+		return false
+	}
+	field := unison.NewField()
+	field.SetText(edit.value)
+	field.Tooltip = newWrappedTooltip(edit.tooltip)
+	field.ValidateCallback = func() bool {
+		_, parseErr := edit.parse(field.Text())
+		return parseErr == nil
+	}
+	done := false
+	finish := func(apply bool) {
+		if done {
+			return
+		}
+		done = true
+		text := field.Text()
+		value, parseErr := edit.parse(text)
+		unison.InvokeTask(func() {
+			wnd.Dispose()
+			if apply && text != edit.value {
+				if parseErr != nil {
+					unison.ErrorDialogWithMessage(fmt.Sprintf(i18n.Text("%q is not a valid value"), text),
+						edit.tooltip)
+				} else {
+					edit.commit(value)
+				}
+			}
+			if w := table.Window(); w != nil {
+				w.ToFront()
+				table.RequestFocus()
+			}
+		})
+	}
+	field.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, repeat bool) bool {
+		switch keyCode {
+		case unison.KeyReturn, unison.KeyNumPadEnter:
+			if field.Invalid() {
+				// Keep editing, as the field is already showing that its content isn't valid
+				unison.Beep()
+			} else {
+				finish(true)
+			}
+			return true
+		case unison.KeyEscape:
+			finish(false)
+			return true
+		default:
+			return field.DefaultKeyDown(keyCode, mod, repeat)
+		}
+	}
+	wnd.LostFocusCallback = func() { finish(true) }
+	wnd.SetContent(field)
+	r := table.RectToRoot(table.CellFrame(rowIndex, colIndex))
+	r.Point = r.Point.Add(table.Window().ContentRect().Point)
+	_, pref, _ := field.Sizes(unison.Size{})
+	r.Width = max(r.Width, pref.Width)
+	r.Height = max(r.Height, pref.Height)
+	wnd.SetContentRect(r)
+	wnd.ToFront()
+	field.RequestFocus()
+	field.SelectAll()
+	return true
+}
+
+func inlineCellEditFor[T gurps.NodeTypes](owner Rebuildable, table *unison.Table[*Node[T]], data T, columnID int) *inlineCellEdit {
+	switch item := any(data).(type) {
+	case *gurps.Trait:
+		if columnID == gurps.TraitPointsColumn && !item.Container() && item.IsLeveled() && !item.EffectivelyDisabled() {
+			return inlineTraitPointsEdit(owner, table, item)
+		}
+	case *gurps.Skill:
+		switch columnID {
+		case gurps.SkillPointsColumn:
+			return inlinePointsEdit(owner, table, item)
+		case gurps.SkillLevelColumn:
+			if !item.Container() {
+				adj := item.CulturalUnfamiliarityAdjustment()
+				return inlineSkillLevelEdit(owner, table, item, func() fxp.Int {
+					if level := item.CalculateLevel(nil).Level; level > 0 {
+						return level + adj
+					}
+					return 0
+				})
+			}
+		}
+	case *gurps.Spell:
+		switch columnID {
+		case gurps.SpellPointsColumn:
+			return inlinePointsEdit(owner, table, item)
+		case gurps.SpellLevelColumn:
+			if !item.Container() {
+				return inlineSkillLevelEdit(owner, table, item, func() fxp.Int { return item.CalculateLevel().Level })
+			}
+		}
+	case *gurps.Equipment:
+		switch columnID {
+		case gurps.EquipmentQuantityColumn:
+			return &inlineCellEdit{
+				value:   item.Quantity.String(),
+				tooltip: i18n.Text("The quantity, which may not be negative"),
+				commit: func(qty fxp.Int) {
+					before := &adjustQuantityList{Owner: owner, List: []*quantityAdjuster{newQuantityAdjuster(item)}}
+					item.Quantity = qty.Max(0)
+					after := &adjustQuantityList{Owner: owner, List: []*quantityAdjuster{newQuantityAdjuster(item)}}
+					addInlineCellUndo(table, i18n.Text("Set Quantity"), before, after)
+				},
+			}
+		case gurps.EquipmentUsesColumn:
+			if item.MaxUses > 0 {
+				return &inlineCellEdit{
+					value:     strconv.Itoa(item.Uses),
+					tooltip:   fmt.Sprintf(i18n.Text("The uses remaining, a whole number from 0 to %d"), item.MaxUses),
+					wholeOnly: true,
+					commit: func(uses fxp.Int) {
+						before := &adjustUsesList{Owner: owner, List: []*usesAdjuster{newUsesAdjuster(item)}}
+						item.Uses = min(max(fxp.As[int](uses), 0), item.MaxUses)
+						after := &adjustUsesList{Owner: owner, List: []*usesAdjuster{newUsesAdjuster(item)}}
+						addInlineCellUndo(table, i18n.Text("Set Uses"), before, after)
+					},
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func inlinePointsEdit[T gurps.NodeTypes](owner Rebuildable, table unison.Paneler, target gurps.RawPointsAdjuster[T]) *inlineCellEdit {
+	if target.Container() {
+		return nil
+	}
+	return &inlineCellEdit{
+		value:   target.RawPoints().String(),
+		tooltip: i18n.Text("The points spent, which may not be negative"),
+		commit: func(pts fxp.Int) {
+			before := &adjustRawPointsList[T]{Owner: owner, List: []*rawPointsAdjuster[T]{newRawPointsAdjuster(target)}}
+			target.SetRawPoints(pts.Max(0))
+			after := &adjustRawPointsList[T]{Owner: owner, List: []*rawPointsAdjuster[T]{newRawPointsAdjuster(target)}}
+			addInlineCellUndo(table, i18n.Text("Set Points"), before, after)
+		},
+	}
+}
+
+// inlineSkillLevelEdit edits a skill level by adding or removing the points needed to reach it. Levels that can't be
+// reached exactly end up at the closest level below them.
+func inlineSkillLevelEdit[T gurps.NodeTypes](owner Rebuildable, table unison.Paneler, target gurps.SkillAdjustmentProvider[T], level func() fxp.Int) *inlineCellEdit {
+	return &inlineCellEdit{
+		value:     level().Trunc().String(),
+		tooltip:   i18n.Text("The desired level, a whole number. Points are added or removed to reach it."),
+		wholeOnly: true,
+		commit: func(desired fxp.Int) {
+			before := &adjustRawPointsList[T]{Owner: owner, List: []*rawPointsAdjuster[T]{newRawPointsAdjuster(target)}}
+			for i := 0; i < maxInlineLevelSteps && level() < desired; i++ {
+				pts := target.RawPoints()
+				target.IncrementSkillLevel()
+				if target.RawPoints() == pts {
+					break
+				}
+			}
+			for i := 0; i < maxInlineLevelSteps && level() > desired && target.RawPoints() > 0; i++ {
+				target.DecrementSkillLevel()
+			}
+			after := &adjustRawPointsList[T]{Owner: owner, List: []*rawPointsAdjuster[T]{newRawPointsAdjuster(target)}}
+			addInlineCellUndo(table, i18n.Text("Set Level"), before, after)
+		},
+	}
+}
+
+// inlineTraitPointsEdit edits the points of a leveled trait by choosing the number of levels whose cost comes closest
+// to them.
+func inlineTraitPointsEdit(owner Rebuildable, table unison.Paneler, item *gurps.Trait) *inlineCellEdit {
+	return &inlineCellEdit{
+		value:   item.AdjustedPoints().String(),
+		tooltip: i18n.Text("The desired points. Levels are added or removed to come as close to them as possible."),
+		commit: func(desired fxp.Int) {
+			before := &adjustTraitLevelList{Owner: owner, List: []*traitLevelAdjuster{newTraitLevelAdjuster(item)}}
+			original := item.Levels
+			best := original
+			bestDelta := (item.AdjustedPoints() - desired).Abs()
+			for i := 0; i <= maxInlineLevelSteps; i++ {
+				item.Levels = fxp.From(i)
+				if delta := (item.AdjustedPoints() - desired).Abs(); delta < bestDelta {
+					best = item.Levels
+					bestDelta = delta
+				}
+			}
+			item.Levels = best
+			after := &adjustTraitLevelList{Owner: owner, List: []*traitLevelAdjuster{newTraitLevelAdjuster(item)}}
+			item.Levels = original
+			addInlineCellUndo(table, i18n.Text("Set Points"), before, after)
+		},
+	}
+}
+
+func addInlineCellUndo[D undoApplier](table unison.Paneler, name string, before, after D) {
+	if mgr := unison.UndoManagerFor(table); mgr != nil {
+		addUndo(mgr, &unison.UndoEdit[D]{
+			ID:         unison.NextUndoID(),
+			EditName:   name,
+			UndoFunc:   func(edit *unison.UndoEdit[D]) { edit.BeforeData.Apply() },
+			RedoFunc:   func(edit *unison.UndoEdit[D]) { edit.AfterData.Apply() },
+			BeforeData: before,
+			AfterData:  after,
+		})
+	}
+	after.Apply()
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/comprehension"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+// EditLanguage displays the editor for a language.
+func EditLanguage(owner Rebuildable, language *gurps.Language) {
+	displayEditor[*gurps.Language, *gurps.LanguageEditData](owner, language, svg.GCSTraits, "", nil,
+		initLanguageEditor, nil)
+}
+
+func initLanguageEditor(e *editor[*gurps.Language, *gurps.LanguageEditData], content *unison.Panel) func() {
+	addNameLabelAndField(content, &e.editorData.Name)
+	content.AddChild(unison.NewPanel())
+	addCheckBox(content, i18n.Text("Native language"), &e.editorData.Native)
+	addLabelAndPopup(content, i18n.Text("Spoken"), i18n.Text("Spoken comprehension"), comprehension.Levels,
+		&e.editorData.Spoken)
+	addLabelAndPopup(content, i18n.Text("Written"), i18n.Text("Written comprehension"), comprehension.Levels,
+		&e.editorData.Written)
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("Points"), false))
+	content.AddChild(NewNonEditableField(func(field *NonEditableField) {
+		var lang gurps.Language
+		lang.LanguageEditData = *e.editorData
+		field.SetTitle(lang.AdjustedPoints().String())
+		field.MarkForLayoutAndRedraw()
+	}))
+	addNotesLabelAndField(content, &e.editorData.LocalNotes)
+	addPageRefLabelAndField(content, &e.editorData.PageRef)
+	addPageRefHighlightLabelAndField(content, &e.editorData.PageRefHighlight)
+	return nil
+}
@@ -0,0 +1,160 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+const languageDragKey = "language"
+
+var _ TableProvider[*gurps.Language] = &languagesProvider{}
+
+type languagesProvider struct {
+	table    *unison.Table[*Node[*gurps.Language]]
+	provider gurps.LanguageListProvider
+	forPage  bool
+}
+
+// NewLanguagesProvider creates a new table provider for languages.
+func NewLanguagesProvider(provider gurps.LanguageListProvider, forPage bool) TableProvider[*gurps.Language] {
+	return &languagesProvider{
+		provider: provider,
+		forPage:  forPage,
+	}
+}
+
+func (p *languagesProvider) RefKey() string {
+	return gurps.BlockLayoutLanguagesKey
+}
+
+func (p *languagesProvider) AllTags() []string {
+	return nil
+}
+
+func (p *languagesProvider) SetTable(table *unison.Table[*Node[*gurps.Language]]) {
+	p.table = table
+}
+
+func (p *languagesProvider) RootRowCount() int {
+	return len(p.provider.LanguageList())
+}
+
+func (p *languagesProvider) RootRows() []*Node[*gurps.Language] {
+	data := p.provider.LanguageList()
+	rows := make([]*Node[*gurps.Language], 0, len(data))
+	for _, one := range data {
+		rows = append(rows, NewNode[*gurps.Language](p.table, nil, one, p.forPage))
+	}
+	return rows
+}
+
+func (p *languagesProvider) SetRootRows(rows []*Node[*gurps.Language]) {
+	p.provider.SetLanguageList(ExtractNodeDataFromList(rows))
+}
+
+func (p *languagesProvider) RootData() []*gurps.Language {
+	return p.provider.LanguageList()
+}
+
+func (p *languagesProvider) SetRootData(data []*gurps.Language) {
+	p.provider.SetLanguageList(data)
+}
+
+func (p *languagesProvider) DataOwner() gurps.DataOwner {
+	return p.provider.DataOwner()
+}
+
+func (p *languagesProvider) DragKey() string {
+	return languageDragKey
+}
+
+func (p *languagesProvider) DragSVG() *unison.SVG {
+	return svg.GCSTraits
+}
+
+func (p *languagesProvider) DropShouldMoveData(from, to *unison.Table[*Node[*gurps.Language]]) bool {
+	return from == to
+}
+
+func (p *languagesProvider) ProcessDropData(_, _ *unison.Table[*Node[*gurps.Language]]) {
+}
+
+func (p *languagesProvider) AltDropSupport() *AltDropSupport {
+	return nil
+}
+
+func (p *languagesProvider) ItemNames() (singular, plural string) {
+	return i18n.Text("Language"), i18n.Text("Languages")
+}
+
+func (p *languagesProvider) Headers() []unison.TableColumnHeader[*Node[*gurps.Language]] {
+	ids := p.ColumnIDs()
+	headers := make([]unison.TableColumnHeader[*Node[*gurps.Language]], 0, len(ids))
+	for _, id := range ids {
+		headers = append(headers, headerFromData[*gurps.Language](gurps.LanguagesHeaderData(id), p.forPage))
+	}
+	return headers
+}
+
+func (p *languagesProvider) SyncHeader(_ []unison.TableColumnHeader[*Node[*gurps.Language]]) {
+}
+
+func (p *languagesProvider) ColumnIDs() []int {
+	return []int{
+		gurps.LanguageNameColumn,
+		gurps.LanguageSpokenColumn,
+		gurps.LanguageWrittenColumn,
+		gurps.LanguagePointsColumn,
+		gurps.LanguageReferenceColumn,
+	}
+}
+
+func (p *languagesProvider) HierarchyColumnID() int {
+	return -1
+}
+
+func (p *languagesProvider) ExcessWidthColumnID() int {
+	return gurps.LanguageNameColumn
+}
+
+func (p *languagesProvider) OpenEditor(owner Rebuildable, table *unison.Table[*Node[*gurps.Language]]) {
+	OpenEditor[*gurps.Language](table, func(item *gurps.Language) { EditLanguage(owner, item) })
+}
+
+func (p *languagesProvider) CreateItem(owner Rebuildable, table *unison.Table[*Node[*gurps.Language]], _ ItemVariant) {
+	item := gurps.NewLanguage(p.DataOwner())
+	InsertItems[*gurps.Language](owner, table, p.provider.LanguageList, p.provider.SetLanguageList,
+		func(_ *unison.Table[*Node[*gurps.Language]]) []*Node[*gurps.Language] { return p.RootRows() }, item)
+	EditLanguage(owner, item)
+}
+
+func (p *languagesProvider) Serialize() ([]byte, error) {
+	return jio.SerializeAndCompress(p.provider.LanguageList())
+}
+
+func (p *languagesProvider) Deserialize(data []byte) error {
+	var rows []*gurps.Language
+	if err := jio.DecompressAndDeserialize(data, &rows); err != nil {
+		return err
+	}
+	p.provider.SetLanguageList(rows)
+	return nil
+}
+
+func (p *languagesProvider) ContextMenuItems() []ContextMenuItem {
+	var list []ContextMenuItem
+	list = append(list, ContextMenuItem{i18n.Text("New Language"), NewLanguageItemID})
+	return AppendDefaultContextMenuItems(list)
+}
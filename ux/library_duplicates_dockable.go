@@ -0,0 +1,290 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/collection/dict"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var _ unison.Dockable = &LibraryDuplicatesDockable{}
+
+// idDeleter is implemented by the dockables that can remove items by their IDs.
+type idDeleter interface {
+	DeleteIDs(ids map[tid.TID]bool, undoTitle string) bool
+}
+
+// LibraryDuplicatesDockable reports the items in the libraries that have the same, or nearly the same, names as other
+// items of the same kind, allowing each to be opened or all but one of them to be removed.
+type LibraryDuplicatesDockable struct {
+	unison.Panel
+	libraries  gurps.Libraries
+	countLabel *unison.Label
+	openButton *unison.Button
+	keepButton *unison.Button
+	list       *unison.List[*libraryDuplicateItem]
+}
+
+type libraryDuplicateItem struct {
+	group  *gurps.LibraryDuplicates
+	result *gurps.LibrarySearchResult // nil for the group's header row
+	title  string
+}
+
+func (l *libraryDuplicateItem) String() string {
+	if l.result == nil {
+		if l.group.Identical {
+			return fmt.Sprintf(i18n.Text("%s (%s) — %d identical copies"), l.group.Name, l.group.Kind,
+				len(l.group.Items))
+		}
+		return fmt.Sprintf(i18n.Text("%s (%s) — %d similar items"), l.group.Name, l.group.Kind, len(l.group.Items))
+	}
+	return fmt.Sprintf("      %s — %s: %s", l.result.Name, l.title, l.result.From.Path)
+}
+
+// ShowLibraryDuplicates shows the library duplicates report.
+func ShowLibraryDuplicates() {
+	for _, d := range AllDockables() {
+		if ld, ok := d.(*LibraryDuplicatesDockable); ok {
+			ActivateDockable(ld)
+			ld.rescan()
+			return
+		}
+	}
+	d := &LibraryDuplicatesDockable{}
+	d.Self = d
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+	d.AddChild(d.createToolbar())
+	d.AddChild(d.createContent())
+	d.rescan()
+	PlaceInDock(d, dgroup.Editors, false)
+}
+
+func (d *LibraryDuplicatesDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	d.countLabel = unison.NewLabel()
+	d.countLabel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.AddChild(d.countLabel)
+	d.openButton = unison.NewButton()
+	d.openButton.SetTitle(i18n.Text("Open"))
+	d.openButton.Tooltip = newWrappedTooltip(i18n.Text("Open the file containing the selected item and show the item"))
+	d.openButton.ClickCallback = d.openSelection
+	toolbar.AddChild(d.openButton)
+	d.keepButton = unison.NewButton()
+	d.keepButton.SetTitle(i18n.Text("Keep Only This One"))
+	d.keepButton.Tooltip = newWrappedTooltip(i18n.Text("Merge the duplicates by removing every other item in the group from its library file"))
+	d.keepButton.ClickCallback = d.keepSelection
+	toolbar.AddChild(d.keepButton)
+	refreshButton := unison.NewSVGButton(svg.Reset)
+	refreshButton.Tooltip = newWrappedTooltip(i18n.Text("Rescan the libraries"))
+	refreshButton.ClickCallback = d.rescan
+	toolbar.AddChild(refreshButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return toolbar
+}
+
+func (d *LibraryDuplicatesDockable) createContent() *unison.Panel {
+	content := unison.NewPanel()
+	content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	content.SetLayout(&unison.FlexLayout{Columns: 1})
+	content.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	d.list = unison.NewList[*libraryDuplicateItem]()
+	d.list.BackgroundInk = unison.ThemeSurface
+	d.list.NewSelectionCallback = d.adjustButtons
+	d.list.DoubleClickCallback = d.openSelection
+	scroller := unison.NewScrollPanel()
+	scroller.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	scroller.SetContent(d.list, behavior.Fill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(400, 200),
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	content.AddChild(scroller)
+	return content
+}
+
+func (d *LibraryDuplicatesDockable) rescan() {
+	d.libraries = gurps.GlobalSettings().Libraries()
+	groups := gurps.NewLibrarySearchIndex(d.libraries).FindDuplicates()
+	d.list.Clear()
+	for _, group := range groups {
+		d.list.Append(&libraryDuplicateItem{group: group})
+		for _, one := range group.Items {
+			item := &libraryDuplicateItem{
+				group:  group,
+				result: one,
+				title:  one.From.Library,
+			}
+			if lib, ok := d.libraries[one.From.Library]; ok {
+				item.title = lib.Title
+			}
+			d.list.Append(item)
+		}
+	}
+	d.list.Pack()
+	d.list.MarkForLayoutRecursivelyUpward()
+	d.countLabel.SetTitle(fmt.Sprintf(i18n.Text("%d possible duplicates found"), len(groups)))
+	d.adjustButtons()
+	d.MarkForLayoutAndRedraw()
+}
+
+func (d *LibraryDuplicatesDockable) selectedItem() *libraryDuplicateItem {
+	if d.list.Selection.Count() != 1 {
+		return nil
+	}
+	if item := d.list.DataAtIndex(d.list.Selection.FirstSet()); item.result != nil {
+		return item
+	}
+	return nil
+}
+
+func (d *LibraryDuplicatesDockable) adjustButtons() {
+	enabled := d.selectedItem() != nil
+	d.openButton.SetEnabled(enabled)
+	d.keepButton.SetEnabled(enabled)
+}
+
+func (d *LibraryDuplicatesDockable) openSelection() {
+	item := d.selectedItem()
+	if item == nil {
+		return
+	}
+	lib, ok := d.libraries[item.result.From.Library]
+	if !ok {
+		return
+	}
+	openRequest(gurps.OpenRequest{
+		Path: filepath.Join(lib.Path(), item.result.From.Path),
+		Item: item.result.Name,
+	})
+}
+
+func (d *LibraryDuplicatesDockable) keepSelection() {
+	item := d.selectedItem()
+	if item == nil {
+		return
+	}
+	remove := make(map[gurps.LibraryFile]map[tid.TID]bool)
+	count := 0
+	for _, one := range item.group.Items {
+		if one == item.result {
+			continue
+		}
+		if ider, ok := one.Item.(gurps.IDer); ok {
+			lib, exists := d.libraries[one.From.Library]
+			if !exists {
+				continue
+			}
+			if lib.IsMaster() || !gurps.IsDirWritable(filepath.Dir(filepath.Join(lib.Path(), one.From.Path))) {
+				unison.ErrorDialogWithMessage(i18n.Text("Unable to remove the other items"),
+					fmt.Sprintf(i18n.Text("%s can't be changed, as it is in the %s library, which is either read-only or replaced when it is updated."),
+						one.From.Path, lib.Title))
+				return
+			}
+			ids, exists := remove[one.From]
+			if !exists {
+				ids = make(map[tid.TID]bool)
+				remove[one.From] = ids
+			}
+			ids[ider.ID()] = true
+			count++
+		}
+	}
+	if count == 0 {
+		return
+	}
+	if unison.QuestionDialog(fmt.Sprintf(i18n.Text("Remove the other %d items named %q from their library files?"),
+		count, item.group.Name), i18n.Text("Items in files that are already open are removed there instead, where the change can be undone and must be saved to keep it.")) !=
+		unison.ModalResponseOK {
+		return
+	}
+	for from, ids := range remove {
+		if fbd := LocateFileBackedDockable(filepath.Join(d.libraries[from.Library].Path(), from.Path)); fbd != nil {
+			// Changing the file behind an open editor would be undone the next time it was saved, so the removal is
+			// made within the editor instead.
+			if remover, ok := fbd.(idDeleter); !ok || !remover.DeleteIDs(ids, i18n.Text("Remove Duplicates")) {
+				unison.ErrorDialogWithMessage(fmt.Sprintf(i18n.Text("Unable to remove duplicates from %s"), from.Path),
+					i18n.Text("The items could not be removed from the open editor."))
+			}
+			continue
+		}
+		if err := gurps.RemoveFromLibraryFile(d.libraries, from, dict.Keys(ids)...); err != nil {
+			unison.ErrorDialogWithError(fmt.Sprintf(i18n.Text("Unable to remove duplicates from %s"), from.Path), err)
+		}
+	}
+	d.rescan()
+}
+
+// TitleIcon implements unison.Dockable
+func (d *LibraryDuplicatesDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Copy,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *LibraryDuplicatesDockable) Title() string {
+	return i18n.Text("Library Duplicates")
+}
+
+func (d *LibraryDuplicatesDockable) String() string {
+	return d.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (d *LibraryDuplicatesDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (d *LibraryDuplicatesDockable) Modified() bool {
+	return false
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (d *LibraryDuplicatesDockable) MayAttemptClose() bool {
+	return true
+}
+
+// AttemptClose implements unison.TabCloser
+func (d *LibraryDuplicatesDockable) AttemptClose() bool {
+	return AttemptCloseForDockable(d)
+}
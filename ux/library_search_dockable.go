@@ -0,0 +1,220 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var _ unison.Dockable = &LibrarySearchDockable{}
+
+// LibrarySearchDockable searches the traits, skills, spells, equipment and notes of every library. Results can be
+// dragged onto a sheet.
+type LibrarySearchDockable struct {
+	unison.Panel
+	index       *gurps.LibrarySearchIndex
+	searchField *unison.Field
+	countLabel  *unison.Label
+	list        *unison.List[*librarySearchItem]
+}
+
+type librarySearchItem struct {
+	result *gurps.LibrarySearchResult
+}
+
+func (l *librarySearchItem) String() string {
+	return fmt.Sprintf("%s (%s) — %s", l.result.Name, l.result.Kind, l.result.From.Path)
+}
+
+// ShowLibrarySearch shows the library search.
+func ShowLibrarySearch() {
+	for _, d := range AllDockables() {
+		if s, ok := d.(*LibrarySearchDockable); ok {
+			ActivateDockable(s)
+			s.searchField.RequestFocus()
+			return
+		}
+	}
+	d := &LibrarySearchDockable{}
+	d.Self = d
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+	d.AddChild(d.createToolbar())
+	d.AddChild(d.createContent())
+	d.reindex()
+	PlaceInDock(d, dgroup.Editors, false)
+	d.searchField.RequestFocus()
+}
+
+func (d *LibrarySearchDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	d.searchField = NewSearchField(i18n.Text("Search"), func(_, _ *unison.FieldState) { d.search() })
+	d.searchField.Tooltip = newWrappedTooltip(i18n.Text("Search the names, notes, tags and descriptions of the traits, skills, spells, equipment and notes in all libraries. Every word must match."))
+	d.searchField.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.AddChild(d.searchField)
+	d.countLabel = unison.NewLabel()
+	toolbar.AddChild(d.countLabel)
+	refreshButton := unison.NewSVGButton(svg.Reset)
+	refreshButton.Tooltip = newWrappedTooltip(i18n.Text("Rescan the libraries"))
+	refreshButton.ClickCallback = d.reindex
+	toolbar.AddChild(refreshButton)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return toolbar
+}
+
+func (d *LibrarySearchDockable) createContent() *unison.Panel {
+	content := unison.NewPanel()
+	content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	content.SetLayout(&unison.FlexLayout{Columns: 1})
+	content.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	d.list = unison.NewList[*librarySearchItem]()
+	d.list.BackgroundInk = unison.ThemeSurface
+	d.list.MouseDragCallback = d.mouseDrag
+	scroller := unison.NewScrollPanel()
+	scroller.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	scroller.SetContent(d.list, behavior.Fill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(400, 200),
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	content.AddChild(scroller)
+	return content
+}
+
+func (d *LibrarySearchDockable) reindex() {
+	d.index = gurps.NewLibrarySearchIndex(gurps.GlobalSettings().Libraries())
+	d.search()
+}
+
+func (d *LibrarySearchDockable) search() {
+	results := d.index.Search(d.searchField.Text())
+	d.list.Clear()
+	for _, one := range results {
+		d.list.Append(&librarySearchItem{result: one})
+	}
+	d.list.Pack()
+	d.list.MarkForLayoutRecursivelyUpward()
+	d.countLabel.SetTitle(fmt.Sprintf(i18n.Text("%d of %d"), len(results), d.index.Len()))
+	d.MarkForLayoutAndRedraw()
+}
+
+func (d *LibrarySearchDockable) mouseDrag(where unison.Point, button int, mod unison.Modifiers) bool {
+	if button == unison.ButtonLeft && d.list.IsDragGesture(where) {
+		if i := d.list.Selection.FirstSet(); i != -1 {
+			startLibraryResultDrag(d.list.AsPanel(), d.list.DataAtIndex(i).result)
+			return true
+		}
+	}
+	return d.list.DefaultMouseDrag(where, button, mod)
+}
+
+// startLibraryResultDrag starts a drag of the item found in a library.
+func startLibraryResultDrag(panel *unison.Panel, result *gurps.LibrarySearchResult) {
+	switch item := result.Item.(type) {
+	case *gurps.Trait:
+		startLibrarySearchDrag(panel, result.From, item, traitDragKey, svg.GCSTraits, i18n.Text("Trait"),
+			i18n.Text("Traits"))
+	case *gurps.Skill:
+		startLibrarySearchDrag(panel, result.From, item, gurps.SkillID, svg.GCSSkills, i18n.Text("Skill"),
+			i18n.Text("Skills"))
+	case *gurps.Spell:
+		startLibrarySearchDrag(panel, result.From, item, gurps.SpellID, svg.GCSSpells, i18n.Text("Spell"),
+			i18n.Text("Spells"))
+	case *gurps.Equipment:
+		startLibrarySearchDrag(panel, result.From, item, equipmentDragKey, svg.GCSEquipment,
+			i18n.Text("Equipment Item"), i18n.Text("Equipment Items"))
+	case *gurps.Note:
+		startLibrarySearchDrag(panel, result.From, item, noteDragKey, svg.GCSNotes, i18n.Text("Note"),
+			i18n.Text("Notes"))
+	}
+}
+
+// startLibrarySearchDrag starts a drag of the item using the same data a library table would provide, so that the
+// tables on a sheet accept it as if it had come from the library file itself.
+func startLibrarySearchDrag[T gurps.NodeTypes](panel *unison.Panel, from gurps.LibraryFile, item T, dragKey string, icon *unison.SVG, singular, plural string) {
+	table := unison.NewTable[*Node[T]](&unison.SimpleTableModel[*Node[T]]{})
+	table.ClientData()[libraryFileClientKey] = from
+	data := &unison.TableDragData[*Node[T]]{
+		Table: table,
+		Rows:  []*Node[T]{NewNode(table, nil, item, false)},
+	}
+	drawable := unison.NewTableDragDrawable(data, icon, singular, plural)
+	size := drawable.LogicalSize()
+	panel.StartDataDrag(&unison.DragData{
+		Data:     map[string]any{dragKey: data},
+		Drawable: drawable,
+		Ink:      unison.ThemeOnSurface,
+		Offset:   unison.Point{Y: -size.Height / 2},
+	})
+}
+
+// TitleIcon implements unison.Dockable
+func (d *LibrarySearchDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Database,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *LibrarySearchDockable) Title() string {
+	return i18n.Text("Library Search")
+}
+
+func (d *LibrarySearchDockable) String() string {
+	return d.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (d *LibrarySearchDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (d *LibrarySearchDockable) Modified() bool {
+	return false
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (d *LibrarySearchDockable) MayAttemptClose() bool {
+	return true
+}
+
+// AttemptClose implements unison.TabCloser
+func (d *LibrarySearchDockable) AttemptClose() bool {
+	return AttemptCloseForDockable(d)
+}
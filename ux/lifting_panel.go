@@ -44,6 +44,12 @@ func NewLiftingPanel(entity *gurps.Entity) *LiftingPanel {
 		Right:  2,
 	})))
 	p.DrawCallback = func(gc *unison.Canvas, rect unison.Rect) { drawBandedBackground(p, gc, rect, 0, 2, nil) }
+	p.addFieldAndLabel(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
+		if text := p.entity.LiftingStrength().String(); text != f.Text.String() {
+			f.SetTitle(text)
+			MarkForLayoutWithinDockable(f)
+		}
+	}), i18n.Text("Lifting ST"), i18n.Text("The ST used to determine Basic Lift and encumbrance"))
 	p.addFieldAndLabel(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
 		if text := p.entity.SheetSettings.DefaultWeightUnits.Format(p.entity.BasicLift()); text != f.Text.String() {
 			f.SetTitle(text)
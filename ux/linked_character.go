@@ -0,0 +1,68 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+)
+
+// syncLinkedCharacters refreshes the traits that are linked to other character sheets, preferring the live data of any
+// that are currently open. Returns true if any of them changed.
+func (s *Sheet) syncLinkedCharacters() bool {
+	changed := false
+	gurps.Traverse(func(t *gurps.Trait) bool {
+		if t.LinkedCharacter == nil || t.LinkedCharacter.Path == "" {
+			return false
+		}
+		linked := linkedCharacterEntity(s, t.LinkedCharacter)
+		if linked != nil && t.SyncLinkedCharacter(linked) {
+			changed = true
+		}
+		return false
+	}, false, true, s.entity.Traits...)
+	return changed
+}
+
+func linkedCharacterEntity(self *Sheet, linked *gurps.LinkedCharacter) *gurps.Entity {
+	p := filepath.Clean(linked.Path)
+	for _, sheet := range OpenSheets(self) {
+		if filepath.Clean(sheet.BackingFilePath()) == p {
+			return sheet.entity
+		}
+	}
+	entity, err := linked.Load()
+	if err != nil {
+		errs.Log(err, "path", p)
+		return nil
+	}
+	return entity
+}
+
+// refreshSheetsLinkedTo refreshes any other open sheets that have traits linked to the given sheet.
+func refreshSheetsLinkedTo(s *Sheet) {
+	p := filepath.Clean(s.BackingFilePath())
+	for _, sheet := range OpenSheets(s) {
+		if sheet.linksTo(p) && sheet.syncLinkedCharacters() {
+			sheet.Rebuild(true)
+		}
+	}
+}
+
+func (s *Sheet) linksTo(p string) bool {
+	found := false
+	gurps.Traverse(func(t *gurps.Trait) bool {
+		found = t.LinkedCharacter != nil && t.LinkedCharacter.Path != "" && filepath.Clean(t.LinkedCharacter.Path) == p
+		return found
+	}, false, true, s.entity.Traits...)
+	return found
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
+	"github.com/richardwilkes/unison"
+)
+
+type loadoutChoice struct {
+	ID   tid.TID
+	Name string
+}
+
+func (c loadoutChoice) String() string {
+	return c.Name
+}
+
+type loadoutState struct {
+	Loadouts []*gurps.Loadout
+	Active   tid.TID
+}
+
+func (s *Sheet) createLoadoutPopup() *unison.PopupMenu[loadoutChoice] {
+	s.loadoutPopup = unison.NewPopupMenu[loadoutChoice]()
+	s.loadoutPopup.Tooltip = newWrappedTooltip(i18n.Text("The carried equipment considered for weight, encumbrance and weapons. Loadouts may be created from the selection in the carried equipment list."))
+	s.syncLoadoutPopup()
+	return s.loadoutPopup
+}
+
+func (s *Sheet) syncLoadoutPopup() {
+	if s.loadoutPopup == nil {
+		return
+	}
+	s.loadoutPopup.SelectionChangedCallback = nil
+	s.loadoutPopup.RemoveAllItems()
+	s.loadoutPopup.AddItem(loadoutChoice{Name: i18n.Text("All Carried Equipment")})
+	selected := 0
+	for i, one := range s.entity.Loadouts {
+		s.loadoutPopup.AddItem(loadoutChoice{ID: one.ID, Name: one.Name})
+		if one.ID == s.entity.ActiveLoadout {
+			selected = i + 1
+		}
+	}
+	s.loadoutPopup.SelectIndex(selected)
+	s.loadoutPopup.SelectionChangedCallback = func(popup *unison.PopupMenu[loadoutChoice]) {
+		if choice, ok := popup.Selected(); ok && choice.ID != s.entity.ActiveLoadout {
+			s.changeLoadouts(i18n.Text("Switch Loadout"), func() { s.entity.ActiveLoadout = choice.ID })
+		}
+	}
+	s.loadoutPopup.MarkForLayoutAndRedraw()
+}
+
+func (s *Sheet) currentLoadoutState() *loadoutState {
+	return &loadoutState{
+		Loadouts: gurps.CloneLoadouts(s.entity.Loadouts),
+		Active:   s.entity.ActiveLoadout,
+	}
+}
+
+func (s *Sheet) applyLoadoutState(state *loadoutState) {
+	s.entity.Loadouts = gurps.CloneLoadouts(state.Loadouts)
+	s.entity.ActiveLoadout = state.Active
+	s.MarkModified(s)
+	s.Rebuild(true)
+}
+
+// changeLoadouts calls modifier to alter the loadouts, recording the change for undo.
+func (s *Sheet) changeLoadouts(name string, modifier func()) {
+	before := s.currentLoadoutState()
+	modifier()
+	after := s.currentLoadoutState()
+	addUndo(s.undoMgr, &unison.UndoEdit[*loadoutState]{
+		ID:         unison.NextUndoID(),
+		EditName:   name,
+		UndoFunc:   func(edit *unison.UndoEdit[*loadoutState]) { s.applyLoadoutState(edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[*loadoutState]) { s.applyLoadoutState(edit.AfterData) },
+		BeforeData: before,
+		AfterData:  after,
+	})
+	s.applyLoadoutState(after)
+}
+
+func (s *Sheet) newLoadout(equipment []*gurps.Equipment) {
+	name := fmt.Sprintf(i18n.Text("Loadout %d"), len(s.entity.Loadouts)+1)
+	field := NewStringField(nil, "", "", func() string { return name }, func(v string) { name = v })
+	field.SetMinimumTextWidthUsing(minTextWidthCandidate)
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Loadout Name"), false))
+	panel.AddChild(field)
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon,
+		unison.DefaultDialogTheme.QuestionIconInk, panel,
+		[]*unison.DialogButtonInfo{unison.NewCancelButtonInfo(), unison.NewOKButtonInfo()})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create new loadout dialog"), err)
+		return
+	}
+	field.ValidateCallback = func() bool {
+		valid := strings.TrimSpace(name) != ""
+		dialog.Button(unison.ModalResponseOK).SetEnabled(valid)
+		return valid
+	}
+	if dialog.RunModal() == unison.ModalResponseOK {
+		s.changeLoadouts(newLoadoutAction.Title, func() {
+			loadout := gurps.NewLoadout(strings.TrimSpace(name), equipment)
+			s.entity.Loadouts = append(s.entity.Loadouts, loadout)
+			s.entity.ActiveLoadout = loadout.ID
+		})
+	}
+}
+
+func (s *Sheet) addToCurrentLoadout(equipment []*gurps.Equipment) {
+	if s.entity.CurrentLoadout() != nil {
+		s.changeLoadouts(addToLoadoutAction.Title, func() { s.entity.CurrentLoadout().Add(equipment) })
+	}
+}
+
+func (s *Sheet) removeFromCurrentLoadout(equipment []*gurps.Equipment) {
+	if s.entity.CurrentLoadout() != nil {
+		s.changeLoadouts(removeFromLoadoutAction.Title, func() { s.entity.CurrentLoadout().Remove(equipment) })
+	}
+}
+
+func (s *Sheet) deleteCurrentLoadout() {
+	if loadout := s.entity.CurrentLoadout(); loadout != nil {
+		s.changeLoadouts(deleteLoadoutAction.Title, func() {
+			for i, one := range s.entity.Loadouts {
+				if one == loadout {
+					s.entity.Loadouts = append(s.entity.Loadouts[:i], s.entity.Loadouts[i+1:]...)
+					break
+				}
+			}
+			s.entity.ActiveLoadout = ""
+		})
+	}
+}
@@ -56,9 +56,15 @@ type MarkdownDockable struct {
 	needsSaveAsPrompt bool
 }
 
-// ShowReadOnlyMarkdown attempts to show the given markdown content in a dockable.
+// ShowReadOnlyMarkdown attempts to show the given markdown content in a dockable. If a dockable with the same title is
+// already open, its content is replaced.
 func ShowReadOnlyMarkdown(title, content string) {
 	if d := LocateFileBackedDockable(markdownContentOnlyPrefix + title); d != nil {
+		if md, ok := d.(*MarkdownDockable); ok && !md.allowEditing {
+			md.original = content
+			md.content = txt.NormalizeLineEndings(content)
+			md.markdown.SetContent(md.content, 0)
+		}
 		ActivateDockable(d)
 		return
 	}
@@ -202,7 +208,9 @@ func newMarkdownDockable(filePath, content string, allowEditing, startInEditMode
 	d.AddChild(d.scroller)
 
 	d.InstallCmdHandlers(SaveItemID, func(_ any) bool { return d.Modified() }, func(_ any) { d.save(false) })
-	d.InstallCmdHandlers(SaveAsItemID, func(_ any) bool { return d.allowEditing }, func(_ any) { d.save(true) })
+	d.InstallCmdHandlers(SaveAsItemID, func(_ any) bool {
+		return d.allowEditing || strings.HasPrefix(d.path, markdownContentOnlyPrefix)
+	}, func(_ any) { d.save(true) })
 
 	return d, nil
 }
@@ -12,6 +12,7 @@ package ux
 import (
 	"fmt"
 	"io/fs"
+	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/svg"
@@ -70,13 +71,53 @@ func (d *menuKeySettingsDockable) sync() {
 }
 
 func (d *menuKeySettingsDockable) fill() {
-	for _, b := range gurps.CurrentBindings() {
+	bindings := gurps.CurrentBindings()
+	keys := &gurps.GlobalSettings().KeyBindings
+	for _, b := range bindings {
 		d.createBindingButton(b)
-		d.content.AddChild(NewFieldTrailingLabel(b.Action.Title, false))
+		label := NewFieldTrailingLabel(b.Action.Title, false)
+		if conflicts := keys.Conflicts(b.ID, b.KeyBinding); len(conflicts) != 0 {
+			label.OnBackgroundInk = unison.ThemeError
+			label.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("Also used by: %s"),
+				strings.Join(bindingTitles(bindings, conflicts), ", ")))
+		}
+		d.content.AddChild(label)
 		d.createResetField(b)
 	}
 }
 
+func bindingTitles(bindings []*gurps.Binding, ids []string) []string {
+	titles := make([]string, 0, len(ids))
+	for _, id := range ids {
+		for _, b := range bindings {
+			if b.ID == id {
+				titles = append(titles, b.Action.Title)
+				break
+			}
+		}
+	}
+	return titles
+}
+
+// resolveConflicts asks whether the key binding should be removed from the other commands that use it, returning false
+// if the change should be abandoned.
+func (d *menuKeySettingsDockable) resolveConflicts(binding *gurps.Binding, keyBinding unison.KeyBinding) bool {
+	g := gurps.GlobalSettings()
+	conflicts := g.KeyBindings.Conflicts(binding.ID, keyBinding)
+	if len(conflicts) == 0 {
+		return true
+	}
+	if unison.QuestionDialog(fmt.Sprintf(i18n.Text("%s is already used by:\n\n%s"), keyBinding.String(),
+		strings.Join(bindingTitles(gurps.CurrentBindings(), conflicts), "\n")),
+		i18n.Text("Remove it from those commands?")) != unison.ModalResponseOK {
+		return false
+	}
+	for _, id := range conflicts {
+		g.KeyBindings.Set(id, unison.KeyBinding{})
+	}
+	return true
+}
+
 func (d *menuKeySettingsDockable) createBindingButton(binding *gurps.Binding) {
 	b := unison.NewButton()
 	b.Font = unison.KeyboardFont
@@ -136,12 +177,14 @@ func (d *menuKeySettingsDockable) createBindingButton(binding *gurps.Binding) {
 				localBinding = unison.KeyBinding{}
 				fallthrough
 			case unison.ModalResponseOK:
+				if !d.resolveConflicts(binding, localBinding) {
+					return
+				}
 				binding.KeyBinding = localBinding
 				g := gurps.GlobalSettings()
 				g.KeyBindings.Set(binding.ID, localBinding)
 				g.KeyBindings.MakeCurrent()
-				b.SetTitle(localBinding.String())
-				b.MarkForRedraw()
+				d.sync()
 			default:
 			}
 		}
@@ -155,13 +198,12 @@ func (d *menuKeySettingsDockable) createResetField(binding *gurps.Binding) {
 	b.ClickCallback = func() {
 		if unison.QuestionDialog(fmt.Sprintf(i18n.Text("Are you sure you want to reset '%s'?"), binding.Action.Title), "") == unison.ModalResponseOK {
 			g := gurps.GlobalSettings()
+			if !d.resolveConflicts(binding, gurps.FactoryKeyBinding(binding.ID)) {
+				return
+			}
 			g.KeyBindings.ResetOne(binding.ID)
 			g.KeyBindings.MakeCurrent()
-			binding.KeyBinding = g.KeyBindings.Current(binding.ID)
-			parent := b.Parent()
-			if other, ok := parent.Children()[parent.IndexOfChild(b)-1].Self.(*unison.Button); ok {
-				other.SetTitle(binding.KeyBinding.String())
-			}
+			d.sync()
 		}
 	}
 	b.SetLayoutData(&unison.FlexLayoutData{
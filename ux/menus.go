@@ -33,7 +33,9 @@ import (
 const (
 	NewSheetItemID = unison.UserBaseID + iota
 	NewTemplateItemID
+	GenerateNPCItemID
 	NewCampaignItemID
+	NewSpaceshipItemID
 	NewTraitsLibraryItemID
 	NewTraitModifiersLibraryItemID
 	NewEquipmentLibraryItemID
@@ -42,6 +44,9 @@ const (
 	NewSkillsLibraryItemID
 	NewSpellsLibraryItemID
 	NewMarkdownFileItemID
+	SearchLibrariesItemID
+	ShowFavoritesItemID
+	FindLibraryDuplicatesItemID
 	OpenItemID
 	CloseTabID
 	RecentFilesMenuID
@@ -52,9 +57,13 @@ const (
 	ExportAsWEBPItemID
 	ExportAsPNGItemID
 	ExportAsJPEGItemID
+	ExportAsCardsItemID
+	ArchiveCharacterItemID
+	CharacterArchivesItemID
 	PrintItemID
 	UndoItemID
 	RedoItemID
+	UndoHistoryItemID
 	DuplicateItemID
 	ExportPortraitItemID
 	ClearPortraitItemID
@@ -75,17 +84,46 @@ const (
 	IncrementEquipmentLevelItemID
 	DecrementEquipmentLevelItemID
 	SwapDefaultsItemID
+	BuyUpFromDefaultItemID
 	MoveToOtherEquipmentItemID
 	MoveToCarriedEquipmentItemID
+	NewLoadoutItemID
+	AddToLoadoutItemID
+	RemoveFromLoadoutItemID
+	DeleteLoadoutItemID
+	OpenLinkedCharacterItemID
 	ItemMenuID
+	RecentlyAddedMenuID
 	AddNaturalAttacksItemID
+	AddReputationItemID
+	AddQualityModifiersItemID
 	OpenEditorItemID
+	BulkEditItemID
+	FindReplaceItemID
 	CopyToSheetItemID
 	CopyToTemplateItemID
+	AddToFavoritesItemID
 	ApplyTemplateItemID
 	NewSheetFromTemplateItemID
 	OpenOnePageReferenceItemID
 	OpenEachPageReferenceItemID
+	ValidateCharacterItemID
+	EditCulturesItemID
+	ToggleUnfamiliarCultureItemID
+	DeductCostOfLivingItemID
+	CountCoinsItemID
+	PlanTimeUseItemID
+	FireWeaponItemID
+	ReloadWeaponItemID
+	TargetWeaponItemID
+	StartNewEncounterItemID
+	AreaAttackItemID
+	TogglePlayModeItemID
+	ReviewChangeRequestsItemID
+	CompareWithSavedItemID
+	CompareWithFileItemID
+	ShareAsQRCodeItemID
+	ShareAsEmbedItemID
 	SettingsMenuID
 	PerSheetSettingsItemID
 	PerSheetAttributeSettingsItemID
@@ -105,6 +143,7 @@ const (
 	CheckForAppUpdatesItemID
 	ReleaseNotesItemID
 	LicenseItemID
+	CreateSupportBundleItemID
 	WebSiteItemID
 	MailingListItemID
 	UserGuideItemID
@@ -122,10 +161,17 @@ const (
 	Scale500ItemID
 	Scale600ItemID
 	DockUnDockItemID
+	SplitRightItemID
+	SplitDownItemID
+	CommandPaletteItemID
+	WorkspaceLayoutsMenuID
+	SaveWorkspaceLayoutItemID
+	DeleteWorkspaceLayoutItemID
 
 	FirstNonContainerMarker // Keep this block grouped together
 	NewCarriedEquipmentItemID
 	NewEquipmentModifierItemID
+	NewLanguageItemID
 	NewNoteItemID
 	NewOtherEquipmentItemID
 	NewSkillItemID
@@ -155,11 +201,13 @@ const (
 
 	OpenInWindowMenuID
 
-	LibraryBaseItemID        = OpenInWindowMenuID + int(dgroup.LastGroup) + 2
-	RecentFieldBaseItemID    = LibraryBaseItemID + 500
-	ExportToTextBaseItemID   = RecentFieldBaseItemID + 500
-	DeepSearchableMenuID     = ExportToTextBaseItemID + 500
-	DeepSearchableBaseItemID = DeepSearchableMenuID + 1
+	LibraryBaseItemID         = OpenInWindowMenuID + int(dgroup.LastGroup) + 2
+	RecentFieldBaseItemID     = LibraryBaseItemID + 500
+	ExportToTextBaseItemID    = RecentFieldBaseItemID + 500
+	DeepSearchableMenuID      = ExportToTextBaseItemID + 500
+	DeepSearchableBaseItemID  = DeepSearchableMenuID + 1
+	WorkspaceLayoutBaseItemID = DeepSearchableBaseItemID + 500
+	RecentlyAddedBaseItemID   = WorkspaceLayoutBaseItemID + 500
 )
 
 var registerKeyBindingsOnce sync.Once
@@ -199,8 +247,10 @@ func (s menuBarScope) setupFileMenu(bar unison.Menu) {
 	m := bar.Menu(unison.FileMenuID)
 	i := s.insertMenuItem(m, 0, newCharacterSheetAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, newCharacterTemplateAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, generateNPCAction.NewMenuItem(f))
 	// TODO: Re-enable Campaign files
 	// i = s.insertMenuItem(m, i, newCampaignAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, newSpaceshipAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, newMarkdownFileAction.NewMenuItem(f))
 
 	i = s.insertMenuSeparator(m, i)
@@ -211,6 +261,9 @@ func (s menuBarScope) setupFileMenu(bar unison.Menu) {
 	i = s.insertMenuItem(m, i, newEquipmentLibraryAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, newEquipmentModifiersLibraryAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, newNotesLibraryAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, searchLibrariesAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, showFavoritesAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, findLibraryDuplicatesAction.NewMenuItem(f))
 
 	i = s.insertMenuSeparator(m, i)
 	i = s.insertMenuItem(m, i, openAction.NewMenuItem(f))
@@ -226,6 +279,10 @@ func (s menuBarScope) setupFileMenu(bar unison.Menu) {
 	i = s.insertMenuItem(m, i, exportPortraitAction.NewMenuItem(f))
 	i = s.insertMenu(m, i, f.NewMenu(ExportToMenuID, i18n.Text("Export To…"), s.exportToUpdater))
 
+	i = s.insertMenuSeparator(m, i)
+	i = s.insertMenuItem(m, i, archiveCharacterAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, characterArchivesAction.NewMenuItem(f))
+
 	i = s.insertMenuSeparator(m, i)
 	s.insertMenuItem(m, i, printAction.NewMenuItem(f))
 }
@@ -236,6 +293,7 @@ func (s menuBarScope) setupEditMenu(bar unison.Menu) {
 
 	i := s.insertMenuItem(m, 0, undoAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, redoAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, undoHistoryAction.NewMenuItem(f))
 	s.insertMenuSeparator(m, i)
 
 	deleteIndex := m.Item(unison.DeleteItemID).Index()
@@ -244,6 +302,8 @@ func (s menuBarScope) setupEditMenu(bar unison.Menu) {
 
 	i = s.insertMenuSeparator(m, m.Item(unison.SelectAllItemID).Index()+1)
 	i = s.insertMenuItem(m, i, openEditorAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, bulkEditAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, findReplaceAction.NewMenuItem(f))
 
 	i = s.insertMenuSeparator(m, i)
 	i = s.insertMenuItem(m, i, jumpToSearchFilterAction.NewMenuItem(f))
@@ -271,6 +331,7 @@ func (s menuBarScope) setupEditMenu(bar unison.Menu) {
 	i = s.insertMenuSeparator(m, i)
 	i = s.insertMenuItem(m, i, toggleStateAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, swapDefaultsAction.NewMenuItem(f))
+	i = s.insertMenuItem(m, i, buyUpFromDefaultAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, convertToContainerAction.NewMenuItem(f))
 	i = s.insertMenuItem(m, i, convertToNonContainerAction.NewMenuItem(f))
 
@@ -281,12 +342,15 @@ func (s menuBarScope) setupEditMenu(bar unison.Menu) {
 
 func (s menuBarScope) createItemMenu(f unison.MenuFactory) unison.Menu {
 	m := f.NewMenu(ItemMenuID, i18n.Text("Item"), nil)
+	s.insertMenu(m, -1, f.NewMenu(RecentlyAddedMenuID, i18n.Text("Add Recently Added"), s.recentlyAddedUpdater))
 
+	m.InsertSeparator(-1, false)
 	m.InsertItem(-1, newTraitAction.NewMenuItem(f))
 	m.InsertItem(-1, newTraitContainerAction.NewMenuItem(f))
 	m.InsertItem(-1, newTraitModifierAction.NewMenuItem(f))
 	m.InsertItem(-1, newTraitContainerModifierAction.NewMenuItem(f))
 	m.InsertItem(-1, addNaturalAttacksAction.NewMenuItem(f))
+	m.InsertItem(-1, addReputationAction.NewMenuItem(f))
 
 	m.InsertSeparator(-1, false)
 	m.InsertItem(-1, newSkillAction.NewMenuItem(f))
@@ -298,6 +362,14 @@ func (s menuBarScope) createItemMenu(f unison.MenuFactory) unison.Menu {
 	m.InsertItem(-1, newSpellContainerAction.NewMenuItem(f))
 	m.InsertItem(-1, newRitualMagicSpellAction.NewMenuItem(f))
 
+	m.InsertSeparator(-1, false)
+	m.InsertItem(-1, newLanguageAction.NewMenuItem(f))
+	m.InsertItem(-1, editCulturesAction.NewMenuItem(f))
+	m.InsertItem(-1, toggleUnfamiliarCultureAction.NewMenuItem(f))
+	m.InsertItem(-1, deductCostOfLivingAction.NewMenuItem(f))
+	m.InsertItem(-1, countCoinsAction.NewMenuItem(f))
+	m.InsertItem(-1, planTimeUseAction.NewMenuItem(f))
+
 	m.InsertSeparator(-1, false)
 	m.InsertItem(-1, newCarriedEquipmentAction.NewMenuItem(f))
 	m.InsertItem(-1, newCarriedEquipmentContainerAction.NewMenuItem(f))
@@ -305,6 +377,7 @@ func (s menuBarScope) createItemMenu(f unison.MenuFactory) unison.Menu {
 	m.InsertItem(-1, newOtherEquipmentContainerAction.NewMenuItem(f))
 	m.InsertItem(-1, newEquipmentModifierAction.NewMenuItem(f))
 	m.InsertItem(-1, newEquipmentContainerModifierAction.NewMenuItem(f))
+	m.InsertItem(-1, addQualityModifiersAction.NewMenuItem(f))
 
 	m.InsertSeparator(-1, false)
 	m.InsertItem(-1, newNoteAction.NewMenuItem(f))
@@ -313,10 +386,24 @@ func (s menuBarScope) createItemMenu(f unison.MenuFactory) unison.Menu {
 	m.InsertSeparator(-1, false)
 	m.InsertItem(-1, newMeleeWeaponAction.NewMenuItem(f))
 	m.InsertItem(-1, newRangedWeaponAction.NewMenuItem(f))
+	m.InsertItem(-1, fireWeaponAction.NewMenuItem(f))
+	m.InsertItem(-1, reloadWeaponAction.NewMenuItem(f))
+	m.InsertItem(-1, targetWeaponAction.NewMenuItem(f))
+	m.InsertItem(-1, startNewEncounterAction.NewMenuItem(f))
+	m.InsertItem(-1, areaAttackAction.NewMenuItem(f))
+	m.InsertItem(-1, togglePlayModeAction.NewMenuItem(f))
 
 	m.InsertSeparator(-1, false)
 	m.InsertItem(-1, openOnePageReferenceAction.NewMenuItem(f))
 	m.InsertItem(-1, openEachPageReferenceAction.NewMenuItem(f))
+
+	m.InsertSeparator(-1, false)
+	m.InsertItem(-1, validateCharacterAction.NewMenuItem(f))
+	m.InsertItem(-1, reviewChangeRequestsAction.NewMenuItem(f))
+	m.InsertItem(-1, compareWithSavedAction.NewMenuItem(f))
+	m.InsertItem(-1, compareWithFileAction.NewMenuItem(f))
+	m.InsertItem(-1, shareAsQRCodeAction.NewMenuItem(f))
+	m.InsertItem(-1, shareAsEmbedAction.NewMenuItem(f))
 	return m
 }
 
@@ -394,6 +481,10 @@ func (s menuBarScope) setupWindowMenu(bar unison.Menu) {
 	f := bar.Factory()
 	m := bar.Menu(unison.WindowMenuID)
 	s.insertMenuItem(m, -1, dockUnDockAction.NewMenuItem(f))
+	s.insertMenuItem(m, -1, splitRightAction.NewMenuItem(f))
+	s.insertMenuItem(m, -1, splitDownAction.NewMenuItem(f))
+	s.insertMenuItem(m, -1, commandPaletteAction.NewMenuItem(f))
+	s.insertMenu(m, -1, f.NewMenu(WorkspaceLayoutsMenuID, i18n.Text("Workspace Layouts"), s.workspaceLayoutsUpdater))
 }
 
 func (s menuBarScope) setupHelpMenu(bar unison.Menu) {
@@ -408,6 +499,7 @@ func (s menuBarScope) setupHelpMenu(bar unison.Menu) {
 	m.InsertItem(-1, checkForAppUpdatesAction.NewMenuItem(f))
 	m.InsertItem(-1, releaseNotesAction.NewMenuItem(f))
 	m.InsertItem(-1, licenseAction.NewMenuItem(f))
+	m.InsertItem(-1, createSupportBundleAction.NewMenuItem(f))
 	m.InsertSeparator(-1, false)
 	m.InsertItem(-1, webSiteAction.NewMenuItem(f))
 	m.InsertItem(-1, mailingListAction.NewMenuItem(f))
@@ -441,6 +533,28 @@ func (s menuBarScope) createOpenRecentFileAction(index int, path, title string)
 	}
 }
 
+func (s menuBarScope) workspaceLayoutsUpdater(menu unison.Menu) {
+	menu.RemoveAll()
+	f := menu.Factory()
+	for i, layout := range gurps.GlobalSettings().WorkspaceLayouts {
+		menu.InsertItem(-1, s.createApplyWorkspaceLayoutAction(i, layout).NewMenuItem(f))
+	}
+	if menu.Count() == 0 {
+		s.appendDisabledMenuItem(menu, i18n.Text("No saved layouts available"))
+	}
+	menu.InsertSeparator(-1, false)
+	menu.InsertItem(-1, saveWorkspaceLayoutAction.NewMenuItem(f))
+	menu.InsertItem(-1, deleteWorkspaceLayoutAction.NewMenuItem(f))
+}
+
+func (s menuBarScope) createApplyWorkspaceLayoutAction(index int, layout *gurps.WorkspaceLayout) *unison.Action {
+	return &unison.Action{
+		ID:              WorkspaceLayoutBaseItemID + index,
+		Title:           layout.Name,
+		ExecuteCallback: func(_ *unison.Action, _ any) { applyWorkspaceLayout(layout) },
+	}
+}
+
 func (s menuBarScope) exportToUpdater(menu unison.Menu) {
 	const outputTemplatesDirName = "Output Templates"
 	menu.RemoveAll()
@@ -449,6 +563,7 @@ func (s menuBarScope) exportToUpdater(menu unison.Menu) {
 	menu.InsertItem(-1, exportAsWEBPAction.NewMenuItem(factory))
 	menu.InsertItem(-1, exportAsPNGAction.NewMenuItem(factory))
 	menu.InsertItem(-1, exportAsJPEGAction.NewMenuItem(factory))
+	menu.InsertItem(-1, exportAsCardsAction.NewMenuItem(factory))
 	menu.InsertSeparator(-1, false)
 	index := 0
 	for _, lib := range gurps.GlobalSettings().Libraries().List() {
@@ -574,6 +689,7 @@ func AppendDefaultContextMenuItems(list []ContextMenuItem) []ContextMenuItem {
 	return append(list,
 		ContextMenuItem{"", -1},
 		ContextMenuItem{openEditorAction.Title, OpenEditorItemID},
+		ContextMenuItem{bulkEditAction.Title, BulkEditItemID},
 		ContextMenuItem{"", -1},
 		ContextMenuItem{duplicateAction.Title, DuplicateItemID},
 		ContextMenuItem{unison.DeleteAction().Title, unison.DeleteItemID},
@@ -584,6 +700,7 @@ func AppendDefaultContextMenuItems(list []ContextMenuItem) []ContextMenuItem {
 		ContextMenuItem{moveToOtherEquipmentAction.Title, MoveToOtherEquipmentItemID},
 		ContextMenuItem{copyToSheetAction.Title, CopyToSheetItemID},
 		ContextMenuItem{copyToTemplateAction.Title, CopyToTemplateItemID},
+		ContextMenuItem{addToFavoritesAction.Title, AddToFavoritesItemID},
 		ContextMenuItem{"", -1},
 		ContextMenuItem{incrementAction.Title, IncrementItemID},
 		ContextMenuItem{decrementAction.Title, DecrementItemID},
@@ -598,6 +715,7 @@ func AppendDefaultContextMenuItems(list []ContextMenuItem) []ContextMenuItem {
 		ContextMenuItem{"", -1},
 		ContextMenuItem{toggleStateAction.Title, ToggleStateItemID},
 		ContextMenuItem{swapDefaultsAction.Title, SwapDefaultsItemID},
+		ContextMenuItem{buyUpFromDefaultAction.Title, BuyUpFromDefaultItemID},
 		ContextMenuItem{convertToContainerAction.Title, ConvertToContainerItemID},
 		ContextMenuItem{convertToNonContainerAction.Title, ConvertToNonContainerItemID},
 		ContextMenuItem{"", -1},
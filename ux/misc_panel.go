@@ -10,6 +10,10 @@
 package ux
 
 import (
+	"fmt"
+	"strconv"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/model/jio"
 	"github.com/richardwilkes/toolbox/i18n"
@@ -72,9 +76,90 @@ func NewMiscPanel(entity *gurps.Entity, targetMgr *TargetMgr) *MiscPanel {
 		func() string { return m.entity.Profile.PlayerName },
 		func(s string) { m.entity.Profile.PlayerName = s }))
 
+	m.AddChild(NewPageLabelEnd(i18n.Text("Status")))
+	m.AddChild(NewNonEditablePageField(func(f *NonEditablePageField) {
+		status := m.entity.Status()
+		if text := strconv.Itoa(status.Effective()); text != f.Text.String() {
+			f.SetTitle(text)
+			MarkForLayoutWithinDockable(f)
+		}
+		f.Tooltip = newWrappedTooltip(status.Tooltip())
+	}))
+
+	m.AddChild(NewPageLabelEnd(i18n.Text("Starting Wealth")))
+	m.AddChild(NewNonEditablePageField(func(f *NonEditablePageField) {
+		if text := "$" + m.entity.StartingWealth().Comma(); text != f.Text.String() {
+			f.SetTitle(text)
+			MarkForLayoutWithinDockable(f)
+		}
+		_, name := m.entity.WealthMultiplier()
+		f.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("%s wealth at the character's tech level"), name))
+	}))
+
+	m.AddChild(NewPageLabelEnd(i18n.Text("Wealth Remaining")))
+	m.AddChild(NewNonEditablePageField(func(f *NonEditablePageField) {
+		remaining := m.entity.WealthRemaining()
+		if text := "$" + remaining.Comma(); text != f.Text.String() {
+			f.SetTitle(text)
+			MarkForLayoutWithinDockable(f)
+		}
+		tooltip := fmt.Sprintf(i18n.Text("Starting wealth less the $%s cost of all equipment"),
+			m.entity.EquipmentCost().Comma())
+		if remaining < 0 {
+			tooltip += i18n.Text("\n\nThe equipment costs more than the starting wealth allows")
+		}
+		f.Tooltip = newWrappedTooltip(tooltip)
+		adjustWarningInk(f, remaining < 0)
+	}))
+
+	m.AddChild(NewPageLabelEnd(i18n.Text("Cost of Living")))
+	m.AddChild(NewNonEditablePageField(func(f *NonEditablePageField) {
+		if text := fmt.Sprintf(i18n.Text("$%s/month"), m.entity.CostOfLiving().Comma()); text != f.Text.String() {
+			f.SetTitle(text)
+			MarkForLayoutWithinDockable(f)
+		}
+		short := m.entity.CannotAffordCostOfLiving()
+		if short {
+			f.Tooltip = newWrappedTooltip(i18n.Text("The character's funds won't cover a month of living expenses"))
+		} else {
+			f.Tooltip = nil
+		}
+		adjustWarningInk(f, short)
+	}))
+
+	title = i18n.Text("Funds")
+	m.AddChild(NewPageLabelEnd(title))
+	m.AddChild(NewDecimalPageField(m.targetMgr, m.prefix+"funds", title,
+		func() fxp.Int { return m.entity.Funds },
+		func(v fxp.Int) { m.entity.Funds = v }, -fxp.Max+1, fxp.Max-1, true))
+
+	m.AddChild(NewPageLabelEnd(i18n.Text("Coins")))
+	m.AddChild(NewNonEditablePageField(func(f *NonEditablePageField) {
+		if text := "$" + m.entity.CoinValue().Comma(); text != f.Text.String() {
+			f.SetTitle(text)
+			MarkForLayoutWithinDockable(f)
+		}
+		f.Tooltip = newWrappedTooltip(coinsTooltip(m.entity))
+	}))
+
 	return m
 }
 
+// adjustWarningInk switches the field's text to the error color while the warning applies.
+func adjustWarningInk(f *NonEditablePageField, warn bool) {
+	ink := unison.DefaultLabelTheme.OnBackgroundInk
+	if warn {
+		ink = unison.ThemeError
+	}
+	if f.OnBackgroundInk != ink {
+		f.OnBackgroundInk = ink
+		f.Text.AdjustDecorations(func(decoration *unison.TextDecoration) {
+			decoration.OnBackgroundInk = ink
+		})
+		f.MarkForRedraw()
+	}
+}
+
 // UpdateModified updates the current modification timestamp.
 func (m *MiscPanel) UpdateModified() {
 	m.entity.ModifiedOn = jio.Now()
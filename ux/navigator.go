@@ -963,6 +963,10 @@ func (n *Navigator) search(text string, rows []*NavigatorNode) {
 								prepareForContentCache(data.Notes),
 							}, "\n"))
 						}
+					case gurps.SpaceshipExt:
+						if data, err := gurps.NewSpaceshipFromFile(dir, fileName); err == nil {
+							content = n.addToContentCache(p, strings.ToLower(data.SearchText()))
+						}
 					// TODO: Re-enable Campaign files
 					// case gurps.CampaignExt:
 					// TODO: Implement
@@ -1115,6 +1119,40 @@ func OpenFiles(filePaths []string) {
 	}
 }
 
+// OpenRequests attempts to open the requested files, honoring the hints provided with each of them.
+func OpenRequests(requests []gurps.OpenRequest) {
+	for _, req := range requests {
+		Workspace.Window.ToFront()
+		openRequest(req)
+	}
+}
+
+func openRequest(req gurps.OpenRequest) {
+	if req.Page > 0 && gurps.FileInfoFor(req.Path).IsPDF &&
+		strings.TrimSpace(gurps.GlobalSettings().General.ExternalPDFCmdLine) != "" {
+		openExternalPDF(req.Path, req.Page)
+		return
+	}
+	pageNum := max(req.Page-1, 0) // The pdf package uses 0 for the first page, not 1
+	d, wasOpen := OpenFile(req.Path, pageNum)
+	if d == nil {
+		return
+	}
+	if req.NewWindow && IsDockableInWorkspace(d) {
+		if _, err := MoveDockableToWindow(d); err != nil {
+			errs.Log(err)
+		}
+	}
+	if pdfDockable, ok := d.(*PDFDockable); ok && wasOpen && req.Page > 0 {
+		pdfDockable.LoadPage(pageNum)
+	}
+	if req.Item != "" {
+		if revealer, ok := d.(itemRevealer); ok {
+			revealer.RevealItem(req.Item)
+		}
+	}
+}
+
 // DisplayNewDockable adds the Dockable to the dock and gives it the focus.
 func DisplayNewDockable(dockable unison.Dockable) {
 	InstallDockUndockCmd(dockable)
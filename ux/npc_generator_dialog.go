@@ -0,0 +1,144 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/rand"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+// GenerateNPC prompts for an ancestry, templates and a point budget, then opens a new sheet with a randomly generated
+// character built from them.
+func GenerateNPC() {
+	global := gurps.GlobalSettings()
+	budget := global.GeneralSettings().InitialPoints
+	var templatePaths []string
+
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(400, 0),
+		HAlign:  align.Fill,
+		HGrab:   true,
+	})
+
+	text := i18n.Text("Point Budget")
+	panel.AddChild(NewFieldLeadingLabel(text, false))
+	panel.AddChild(NewDecimalField(nil, "", text, func() fxp.Int { return budget },
+		func(value fxp.Int) { budget = value }, fxp.Min, fxp.Max, false, false))
+
+	noAncestry := i18n.Text("None")
+	ancestryPopup := unison.NewPopupMenu[string]()
+	ancestryPopup.AddItem(noAncestry)
+	for _, lib := range gurps.AvailableAncestries(global.Libraries()) {
+		for _, one := range lib.List {
+			ancestryPopup.AddItem(one.Name)
+		}
+	}
+	ancestryPopup.SelectIndex(0)
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Ancestry"), false))
+	panel.AddChild(ancestryPopup)
+
+	templatesLabel := NewNonEditableField(func(field *NonEditableField) {
+		if len(templatePaths) == 0 {
+			field.SetTitle(i18n.Text("None"))
+		} else {
+			names := make([]string, len(templatePaths))
+			for i, p := range templatePaths {
+				names[i] = strings.TrimSuffix(filepath.Base(p), gurps.TemplatesExt)
+			}
+			field.SetTitle(strings.Join(names, ", "))
+		}
+		field.MarkForLayoutAndRedraw()
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Templates"), false))
+	panel.AddChild(templatesLabel)
+
+	panel.AddChild(unison.NewPanel())
+	buttons := unison.NewPanel()
+	buttons.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+	})
+	addButton := unison.NewButton()
+	addButton.SetTitle(i18n.Text("Add Templates…"))
+	addButton.ClickCallback = func() {
+		dialog := unison.NewOpenDialog()
+		dialog.SetAllowsMultipleSelection(true)
+		dialog.SetResolvesAliases(true)
+		dialog.SetAllowedExtensions(gurps.TemplatesExt[1:])
+		dialog.SetCanChooseDirectories(false)
+		dialog.SetCanChooseFiles(true)
+		dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+		if dialog.RunModal() {
+			paths := dialog.Paths()
+			if len(paths) != 0 {
+				global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(paths[0]))
+			}
+			templatePaths = append(templatePaths, paths...)
+			templatesLabel.Sync()
+		}
+	}
+	buttons.AddChild(addButton)
+	clearButton := unison.NewButton()
+	clearButton.SetTitle(i18n.Text("Clear"))
+	clearButton.ClickCallback = func() {
+		templatePaths = nil
+		templatesLabel.Sync()
+	}
+	buttons.AddChild(clearButton)
+	panel.AddChild(buttons)
+
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfo(),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+
+	generator := gurps.NPCGenerator{Budget: budget}
+	if ancestry, ok := ancestryPopup.Selected(); ok && ancestry != noAncestry {
+		generator.Ancestry = ancestry
+	}
+	for _, p := range templatePaths {
+		var t *gurps.Template
+		if t, err = gurps.NewTemplateFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p)); err != nil {
+			unison.ErrorDialogWithError(fmt.Sprintf(i18n.Text("Unable to load template %s"), p), err)
+			return
+		}
+		generator.Templates = append(generator.Templates, t)
+	}
+	e := generator.Generate(rand.NewCryptoRand())
+	DisplayNewDockable(NewSheet(e.Profile.Name+gurps.SheetExt, e))
+	if unspent := e.UnspentPoints(); unspent < 0 {
+		unison.WarningDialogWithMessage(i18n.Text("The generated character exceeds the point budget"),
+			fmt.Sprintf(i18n.Text("The choices made by the templates cost %s more points than the budget allows."),
+				(-unspent).Comma()))
+	}
+}
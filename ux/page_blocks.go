@@ -85,6 +85,7 @@ func createPageSecondRow(entity *gurps.Entity, targetMgr *TargetMgr) *unison.Pan
 	})
 	endWrapper.AddChild(NewEncumbrancePanel(entity))
 	endWrapper.AddChild(NewLiftingPanel(entity))
+	endWrapper.AddChild(NewDerivedFieldsPanel(entity))
 
 	p.AddChild(NewPrimaryAttrPanel(entity, targetMgr))
 	p.AddChild(NewSecondaryAttrPanel(entity, targetMgr))
@@ -68,6 +68,8 @@ func newPageExporter(entity *gurps.Entity) *pageExporter {
 					addRowPanel(rowPanel, NewSkillsPageList(p, entity), gurps.BlockLayoutSkillsKey, startAt)
 				case gurps.BlockLayoutSpellsKey:
 					addRowPanel(rowPanel, NewSpellsPageList(p, entity), gurps.BlockLayoutSpellsKey, startAt)
+				case gurps.BlockLayoutLanguagesKey:
+					addRowPanel(rowPanel, NewLanguagesPageList(p, entity), gurps.BlockLayoutLanguagesKey, startAt)
 				case gurps.BlockLayoutEquipmentKey:
 					addRowPanel(rowPanel, NewCarriedEquipmentPageList(p, entity), gurps.BlockLayoutEquipmentKey, startAt)
 				case gurps.BlockLayoutOtherEquipmentKey:
@@ -20,8 +20,9 @@ import (
 )
 
 var (
-	_ Syncer     = &PageList[*gurps.Trait]{}
-	_ pageHelper = &PageList[*gurps.Trait]{}
+	_ Syncer             = &PageList[*gurps.Trait]{}
+	_ pageHelper         = &PageList[*gurps.Trait]{}
+	_ columnSortRecorder = &PageList[*gurps.Trait]{}
 )
 
 // PageList holds a list for a sheet page.
@@ -37,6 +38,7 @@ func NewTraitsPageList(owner Rebuildable, provider gurps.ListProvider) *PageList
 	p := newPageList(owner, NewTraitsProvider(provider, true))
 	p.installToggleDisabledHandler(owner)
 	p.installIncrementLevelHandler(owner)
+	p.installOpenLinkedCharacterHandler(owner)
 	p.installDecrementLevelHandler(owner)
 	return p
 }
@@ -49,6 +51,7 @@ func NewCarriedEquipmentPageList(owner Rebuildable, provider gurps.ListProvider)
 	p.installDecrementTechLevelHandler(owner)
 	p.installContainerConversionHandlers(owner)
 	p.installMoveToOtherEquipmentHandler(owner)
+	p.installLoadoutHandlers(owner)
 	installEquipmentLevelHandlers(p, owner)
 	return p
 }
@@ -86,6 +89,11 @@ func NewSpellsPageList(owner Rebuildable, provider gurps.SpellListProvider) *Pag
 	return p
 }
 
+// NewLanguagesPageList creates the languages page list.
+func NewLanguagesPageList(owner Rebuildable, provider gurps.LanguageListProvider) *PageList[*gurps.Language] {
+	return newPageList(owner, NewLanguagesProvider(provider, true))
+}
+
 // NewNotesPageList creates the notes page list.
 func NewNotesPageList(owner Rebuildable, provider gurps.ListProvider) *PageList[*gurps.Note] {
 	p := newPageList(owner, NewNotesProvider(provider, true))
@@ -110,7 +118,17 @@ func NewMeleeWeaponsPageList(entity *gurps.Entity) *PageList[*gurps.Weapon] {
 
 // NewRangedWeaponsPageList creates the ranged weapons page list.
 func NewRangedWeaponsPageList(entity *gurps.Entity) *PageList[*gurps.Weapon] {
-	return newPageList(nil, NewWeaponsProvider(entity, false, true))
+	p := newPageList(nil, NewWeaponsProvider(entity, false, true))
+	p.InstallCmdHandlers(FireWeaponItemID,
+		func(_ any) bool { return canFireWeapon(p.Table) },
+		func(_ any) { fireWeapon(unison.AncestorOrSelf[Rebuildable](p), p.Table) })
+	p.InstallCmdHandlers(ReloadWeaponItemID,
+		func(_ any) bool { return canReloadWeapon(p.Table) },
+		func(_ any) { reloadWeapon(unison.AncestorOrSelf[Rebuildable](p), p.Table) })
+	p.InstallCmdHandlers(TargetWeaponItemID,
+		func(_ any) bool { return canTargetWeapon(p.Table) },
+		func(_ any) { targetWeapon(unison.AncestorOrSelf[Rebuildable](p), p.Table) })
+	return p
 }
 
 func newPageList[T gurps.NodeTypes](owner Rebuildable, provider TableProvider[T]) *PageList[T] {
@@ -127,11 +145,15 @@ func newPageList[T gurps.NodeTypes](owner Rebuildable, provider TableProvider[T]
 	p.SetBorder(unison.NewLineBorder(header.BackgroundInk, 0, unison.NewUniformInsets(1), false))
 
 	p.Table.PreventUserColumnResize = true
+	if entity := p.entity(); entity != nil {
+		restoreColumnSorts(p.tableHeader, p.Table, entity.SheetSettings.ColumnSort(provider.RefKey()))
+	}
 	p.Table.SyncToModel()
 	p.AddChild(p.tableHeader)
 	p.AddChild(p.Table)
 	if owner != nil {
 		InstallTableDropSupport(p.Table, p.provider)
+		installInlineCellEditing(owner, p.Table)
 		p.InstallCmdHandlers(OpenEditorItemID,
 			func(_ any) bool { return p.Table.HasSelection() },
 			func(_ any) { p.provider.OpenEditor(owner, p.Table) })
@@ -157,11 +179,26 @@ func newPageList[T gurps.NodeTypes](owner Rebuildable, provider TableProvider[T]
 	return p
 }
 
+func (p *PageList[T]) entity() *gurps.Entity {
+	if owner := p.provider.DataOwner(); owner != nil {
+		return owner.OwningEntity()
+	}
+	return nil
+}
+
+// recordColumnSort implements columnSortRecorder.
+func (p *PageList[T]) recordColumnSort() {
+	if entity := p.entity(); entity != nil {
+		entity.SheetSettings.SetColumnSort(p.provider.RefKey(), columnSorts(p.tableHeader, p.Table))
+		MarkModified(p)
+	}
+}
+
 func (p *PageList[T]) needReconstruction() bool {
 	if p == nil {
 		return true
 	}
-	ids := p.provider.ColumnIDs()
+	ids := customizedColumnIDs(p.provider, true)
 	if len(ids) != len(p.Table.Columns) {
 		return true
 	}
@@ -195,6 +232,60 @@ func (p *PageList[T]) installMoveToOtherEquipmentHandler(owner Rebuildable) {
 	}
 }
 
+func (p *PageList[T]) installLoadoutHandlers(owner Rebuildable) {
+	if sheet, ok := owner.AsPanel().Self.(*Sheet); ok {
+		var t *unison.Table[*Node[*gurps.Equipment]]
+		if t, ok = (any(p.Table)).(*unison.Table[*Node[*gurps.Equipment]]); ok {
+			p.InstallCmdHandlers(NewLoadoutItemID,
+				func(_ any) bool { return t.HasSelection() },
+				func(_ any) { sheet.newLoadout(selectedEquipment(t)) })
+			p.InstallCmdHandlers(AddToLoadoutItemID,
+				func(_ any) bool { return t.HasSelection() && sheet.entity.CurrentLoadout() != nil },
+				func(_ any) { sheet.addToCurrentLoadout(selectedEquipment(t)) })
+			p.InstallCmdHandlers(RemoveFromLoadoutItemID,
+				func(_ any) bool { return t.HasSelection() && sheet.entity.CurrentLoadout() != nil },
+				func(_ any) { sheet.removeFromCurrentLoadout(selectedEquipment(t)) })
+			p.InstallCmdHandlers(DeleteLoadoutItemID,
+				func(_ any) bool { return sheet.entity.CurrentLoadout() != nil },
+				func(_ any) { sheet.deleteCurrentLoadout() })
+		}
+	}
+}
+
+func (p *PageList[T]) installOpenLinkedCharacterHandler(owner Rebuildable) {
+	if _, ok := owner.AsPanel().Self.(*Sheet); ok {
+		var t *unison.Table[*Node[*gurps.Trait]]
+		if t, ok = (any(p.Table)).(*unison.Table[*Node[*gurps.Trait]]); ok {
+			p.InstallCmdHandlers(OpenLinkedCharacterItemID,
+				func(_ any) bool { return len(selectedLinkedCharacterPaths(t)) != 0 },
+				func(_ any) {
+					for _, one := range selectedLinkedCharacterPaths(t) {
+						OpenFile(one, 0)
+					}
+				})
+		}
+	}
+}
+
+func selectedLinkedCharacterPaths(t *unison.Table[*Node[*gurps.Trait]]) []string {
+	var list []string
+	for _, row := range t.SelectedRows(false) {
+		if linked := row.Data().LinkedCharacter; linked != nil && linked.Path != "" {
+			list = append(list, linked.Path)
+		}
+	}
+	return list
+}
+
+func selectedEquipment(t *unison.Table[*Node[*gurps.Equipment]]) []*gurps.Equipment {
+	rows := t.SelectedRows(false)
+	list := make([]*gurps.Equipment, 0, len(rows))
+	for _, row := range rows {
+		list = append(list, row.Data())
+	}
+	return list
+}
+
 func moveSelectedEquipment(from, to *unison.Table[*Node[*gurps.Equipment]]) {
 	mgr := unison.UndoManagerFor(from)
 	if mgr == nil || mgr != unison.UndoManagerFor(to) {
@@ -213,7 +304,7 @@ func moveSelectedEquipment(from, to *unison.Table[*Node[*gurps.Equipment]]) {
 	CopyRowsTo(to, from.SelectedRows(true), nil, false)
 	DeleteSelection(from, false)
 	undo.AfterData = NewTableDragUndoEditData(from, to)
-	mgr.Add(undo)
+	addUndo(mgr, undo)
 }
 
 func (p *PageList[T]) installOpenPageReferenceHandlers() {
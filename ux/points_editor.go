@@ -166,6 +166,11 @@ func (e *pointsEditor) createToolbar() unison.Paneler {
 	addButton.ClickCallback = e.addEntry
 	toolbar.AddChild(addButton)
 
+	journalButton := unison.NewSVGButton(svg.MarkdownFile)
+	journalButton.Tooltip = newWrappedTooltip(i18n.Text("Show Points Journal"))
+	journalButton.ClickCallback = e.showJournal
+	toolbar.AddChild(journalButton)
+
 	toolbar.SetLayout(&unison.FlexLayout{
 		Columns:  len(toolbar.Children()),
 		HSpacing: unison.StdHSpacing,
@@ -173,6 +178,14 @@ func (e *pointsEditor) createToolbar() unison.Paneler {
 	return toolbar
 }
 
+func (e *pointsEditor) showJournal() {
+	name := e.entity.Profile.Name
+	if name == "" {
+		name = i18n.Text("Unnamed Character")
+	}
+	ShowReadOnlyMarkdown(fmt.Sprintf(i18n.Text("%s Points Journal"), name), e.entity.PointsJournalReport(jio.Now()))
+}
+
 func (e *pointsEditor) initContent() {
 	for _, rec := range e.current {
 		e.createRow(rec, -1)
@@ -396,7 +409,7 @@ func (e *pointsEditor) applyWithoutFocusNext() {
 	owner := e.owner
 	entity := e.entity
 	if mgr := unison.UndoManagerFor(owner); mgr != nil {
-		mgr.Add(&unison.UndoEdit[[]*gurps.PointsRecord]{
+		addUndo(mgr, &unison.UndoEdit[[]*gurps.PointsRecord]{
 			ID:       unison.NextUndoID(),
 			EditName: i18n.Text("Point Record Changes"),
 			UndoFunc: func(edit *unison.UndoEdit[[]*gurps.PointsRecord]) {
@@ -11,9 +11,11 @@ package ux
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/colors"
 	"github.com/richardwilkes/gcs/v5/model/fonts"
+	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox/i18n"
@@ -32,9 +34,17 @@ type PointsPanel struct {
 	ptsList      *unison.Panel
 	unspentField *NonEditablePageField
 	unspentLabel *unison.Label
+	limitRows    []*pointsLimitRow
 	overSpent    int8
 }
 
+type pointsLimitRow struct {
+	field    *NonEditablePageField
+	label    *unison.Label
+	exceeded func() bool
+	over     bool
+}
+
 // NewPointsPanel creates a new points panel.
 func NewPointsPanel(entity *gurps.Entity, targetMgr *TargetMgr) *PointsPanel {
 	p := &PointsPanel{
@@ -125,6 +135,11 @@ func NewPointsPanel(entity *gurps.Entity, targetMgr *TargetMgr) *PointsPanel {
 			if rowIndex == 0 && p.overSpent == -1 {
 				return unison.ThemeError
 			}
+			for _, one := range p.limitRows {
+				if one.over && p.ptsList.IndexOfChild(one.field) == rowIndex*2 {
+					return unison.ThemeError
+				}
+			}
 			return ink
 		})
 	}
@@ -137,49 +152,22 @@ func NewPointsPanel(entity *gurps.Entity, targetMgr *TargetMgr) *PointsPanel {
 		}
 	})
 	p.unspentLabel = p.addPointsField(p.unspentField, i18n.Text("Unspent"), i18n.Text("Points earned but not yet spent"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Ancestry.String(); text != f.Text.String() {
-			f.SetTitle(text)
-			MarkForLayoutWithinDockable(f)
-		}
-	}), i18n.Text("Ancestry"), i18n.Text("Total points spent on an ancestry package"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Attributes.String(); text != f.Text.String() {
-			f.SetTitle(text)
-			MarkForLayoutWithinDockable(f)
-		}
-	}), i18n.Text("Attributes"), i18n.Text("Total points spent on attributes"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Advantages.String(); text != f.Text.String() {
-			f.SetTitle(text)
-			MarkForLayoutWithinDockable(f)
-		}
-	}), i18n.Text("Advantages"), i18n.Text("Total points spent on advantages"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Disadvantages.String(); text != f.Text.String() {
-			f.SetTitle(text)
-			MarkForLayoutWithinDockable(f)
-		}
-	}), i18n.Text("Disadvantages"), i18n.Text("Total points spent on disadvantages"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Quirks.String(); text != f.Text.String() {
-			f.SetTitle(text)
-			MarkForLayoutWithinDockable(f)
-		}
-	}), i18n.Text("Quirks"), i18n.Text("Total points spent on quirks"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Skills.String(); text != f.Text.String() {
-			f.SetTitle(text)
-			MarkForLayoutWithinDockable(f)
-		}
-	}), i18n.Text("Skills"), i18n.Text("Total points spent on skills"))
-	p.addPointsField(NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
-		if text := p.entity.PointsBreakdown().Spells.String(); text != f.Text.String() {
-			f.SetTitle(text)
-			MarkForLayoutWithinDockable(f)
-		}
-	}), i18n.Text("Spells"), i18n.Text("Total points spent on spells"))
+	p.addCategoryPointsField(gurps.AncestryBudgetCategory, i18n.Text("Ancestry"),
+		i18n.Text("Total points spent on an ancestry package"), nil)
+	p.addCategoryPointsField(gurps.AttributesBudgetCategory, i18n.Text("Attributes"),
+		i18n.Text("Total points spent on attributes"), nil)
+	p.addCategoryPointsField(gurps.AdvantagesBudgetCategory, i18n.Text("Advantages"),
+		i18n.Text("Total points spent on advantages"), nil)
+	p.addCategoryPointsField(gurps.DisadvantagesBudgetCategory, i18n.Text("Disadvantages"),
+		i18n.Text("Total points spent on disadvantages"), p.entity.DisadvantagesExceedLimit)
+	p.addCategoryPointsField(gurps.QuirksBudgetCategory, i18n.Text("Quirks"),
+		i18n.Text("Total points spent on quirks"), p.entity.QuirksExceedLimit)
+	p.addCategoryPointsField(gurps.SkillsBudgetCategory, i18n.Text("Skills"),
+		i18n.Text("Total points spent on skills"), func() bool { return len(p.entity.SkillsExceedingPointLimit()) != 0 })
+	p.addCategoryPointsField(gurps.SpellsBudgetCategory, i18n.Text("Spells"),
+		i18n.Text("Total points spent on spells"), nil)
 	p.adjustUnspent()
+	p.adjustLimits()
 	return p
 }
 
@@ -192,6 +180,96 @@ func (p *PointsPanel) addPointsField(field *NonEditablePageField, title, tooltip
 	return label
 }
 
+func (p *PointsPanel) addLimitedPointsField(field *NonEditablePageField, title, tooltip string, exceeded func() bool) {
+	p.limitRows = append(p.limitRows, &pointsLimitRow{
+		field:    field,
+		label:    p.addPointsField(field, title, tooltip),
+		exceeded: exceeded,
+	})
+}
+
+// addCategoryPointsField adds a row for one category of the points breakdown. The row is highlighted when the optional
+// exceeded function returns true or the category's point budget is not met.
+func (p *PointsPanel) addCategoryPointsField(category, title, tooltip string, exceeded func() bool) {
+	field := NewNonEditablePageFieldEnd(func(f *NonEditablePageField) {
+		status := p.entity.PointBudgetStatus(category)
+		if text := pointsCategoryText(p.entity.PointsBreakdown(), category, status); text != f.Text.String() {
+			f.SetTitle(text)
+			MarkForLayoutWithinDockable(f)
+		}
+		f.Tooltip = newWrappedTooltip(pointsCategoryTooltip(tooltip, status))
+	})
+	p.addLimitedPointsField(field, title, tooltip, func() bool {
+		return (exceeded != nil && exceeded()) || p.entity.PointBudgetStatus(category).Violated()
+	})
+}
+
+func pointsCategoryText(pb *gurps.PointsBreakdown, category string, status *gurps.PointBudgetStatus) string {
+	var value fxp.Int
+	switch category {
+	case gurps.DisadvantagesBudgetCategory:
+		value = pb.Disadvantages
+	case gurps.QuirksBudgetCategory:
+		value = pb.Quirks
+	default:
+		value = pb.Category(category)
+	}
+	switch {
+	case status == nil:
+		return value.String()
+	case status.Budget.Maximum > 0:
+		return value.String() + "/" + status.Budget.Maximum.String()
+	default:
+		return value.String() + "/" + status.Budget.Minimum.String() + "+"
+	}
+}
+
+func pointsCategoryTooltip(tooltip string, status *gurps.PointBudgetStatus) string {
+	if status == nil {
+		return tooltip
+	}
+	var buffer strings.Builder
+	buffer.WriteString(tooltip)
+	fmt.Fprintf(&buffer, i18n.Text("\n\nSpent: %s"), status.Spent.Comma())
+	if status.Budget.Maximum > 0 {
+		fmt.Fprintf(&buffer, i18n.Text("\nBudget: no more than %s"), status.Budget.Maximum.Comma())
+		fmt.Fprintf(&buffer, i18n.Text("\nRemaining: %s"), status.Remaining.Comma())
+	}
+	if status.Budget.Minimum > 0 {
+		fmt.Fprintf(&buffer, i18n.Text("\nBudget: at least %s"), status.Budget.Minimum.Comma())
+	}
+	return buffer.String()
+}
+
+// adjustLimits highlights the rows that exceed the campaign limits or miss the point budgets set in the sheet settings.
+func (p *PointsPanel) adjustLimits() {
+	changed := false
+	for _, one := range p.limitRows {
+		over := one.exceeded()
+		if over == one.over {
+			continue
+		}
+		one.over = over
+		changed = true
+		fieldInk := unison.DefaultLabelTheme.OnBackgroundInk
+		labelInk := unison.ThemeOnSurface
+		if over {
+			fieldInk = unison.ThemeOnError
+			labelInk = unison.ThemeOnError
+		}
+		one.field.OnBackgroundInk = fieldInk
+		one.field.Text.AdjustDecorations(func(decoration *unison.TextDecoration) {
+			decoration.OnBackgroundInk = fieldInk
+		})
+		one.label.Text.AdjustDecorations(func(decoration *unison.TextDecoration) {
+			decoration.OnBackgroundInk = labelInk
+		})
+	}
+	if changed {
+		p.MarkForRedraw()
+	}
+}
+
 func (p *PointsPanel) adjustUnspent() {
 	if p.unspentLabel != nil {
 		last := p.overSpent
@@ -238,5 +316,6 @@ func (p *PointsPanel) Sync() {
 		Font:            fonts.PageLabelPrimary,
 		OnBackgroundInk: colors.OnHeader,
 	})
+	p.adjustLimits()
 	p.MarkForLayoutAndRedraw()
 }
@@ -0,0 +1,81 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+func capturePoolData(entity *gurps.Entity) map[string]gurps.AttributeData {
+	data := make(map[string]gurps.AttributeData, len(entity.Attributes.Set))
+	for id, attr := range entity.Attributes.Set {
+		data[id] = cloneAttributeData(&attr.AttributeData)
+	}
+	return data
+}
+
+func (s *Sheet) applyPoolData(data map[string]gurps.AttributeData) {
+	for id, one := range data {
+		if attr, ok := s.entity.Attributes.Set[id]; ok {
+			attr.AttributeData = cloneAttributeData(&one)
+		}
+	}
+	s.MarkModified(s)
+	s.Rebuild(true)
+}
+
+// regeneratePools prompts for a regeneration rule and the number of times to apply it, then applies it to every pool
+// that defines it.
+func (s *Sheet) regeneratePools() {
+	names := s.entity.PoolRegenerationNames()
+	if len(names) == 0 {
+		unison.WarningDialogWithMessage(i18n.Text("No pool regeneration has been defined"),
+			i18n.Text("Regeneration rules may be added to point pools in the attribute settings."))
+		return
+	}
+	popup := unison.NewPopupMenu[string]()
+	for _, name := range names {
+		popup.AddItem(name)
+	}
+	popup.SelectIndex(0)
+	times := 1
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Regeneration"), false))
+	panel.AddChild(popup)
+	title := i18n.Text("Times")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	panel.AddChild(NewIntegerField(nil, "", title, func() int { return times }, func(v int) { times = v }, 1, 9999,
+		false, false))
+	if unison.QuestionDialogWithPanel(panel) != unison.ModalResponseOK {
+		return
+	}
+	name, _ := popup.Selected()
+	before := capturePoolData(s.entity)
+	if !s.entity.ApplyPoolRegeneration(name, times) {
+		return
+	}
+	addUndo(s.undoMgr, &unison.UndoEdit[map[string]gurps.AttributeData]{
+		ID:         unison.NextUndoID(),
+		EditName:   i18n.Text("Pool Regeneration"),
+		UndoFunc:   func(edit *unison.UndoEdit[map[string]gurps.AttributeData]) { s.applyPoolData(edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[map[string]gurps.AttributeData]) { s.applyPoolData(edit.AfterData) },
+		BeforeData: before,
+		AfterData:  capturePoolData(s.entity),
+	})
+	s.MarkModified(s)
+	s.Rebuild(true)
+}
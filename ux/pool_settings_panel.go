@@ -67,7 +67,7 @@ func (p *poolSettingsPanel) addThreshold() {
 		children[0].Self.(*thresholdSettingsPanel).deleteButton.SetEnabled(true)
 	}
 	undo.AfterData = clonePoolThresholds(p.def.Thresholds)
-	p.dockable.UndoManager().Add(undo)
+	addUndo(p.dockable.UndoManager(), undo)
 	p.dockable.MarkModified(nil)
 	p.dockable.MarkForLayoutAndRedraw()
 	p.dockable.ValidateLayout()
@@ -96,7 +96,7 @@ func (p *poolSettingsPanel) deleteThreshold(target *thresholdSettingsPanel) {
 	undo.BeforeData = clonePoolThresholds(p.def.Thresholds)
 	p.def.Thresholds = slices.Delete(p.def.Thresholds, i, i+1)
 	undo.AfterData = clonePoolThresholds(p.def.Thresholds)
-	p.dockable.UndoManager().Add(undo)
+	addUndo(p.dockable.UndoManager(), undo)
 	p.dockable.MarkModified(nil)
 }
 
@@ -0,0 +1,493 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/wound"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+type poolTracker struct {
+	entity     *gurps.Entity
+	attr       *gurps.Attribute
+	amount     fxp.Int
+	note       string
+	status     *unison.Label
+	amountFld  *DecimalField
+	noteFld    *StringField
+	logPanel   *unison.Panel
+	logScroll  *unison.ScrollPanel
+	applyBtns  []*unison.Button
+	shock      *unison.Label
+	clearShock *unison.Button
+	wound      gurps.Wound
+	hadChanges bool
+}
+
+type poolTrackerState struct {
+	Data              gurps.AttributeData
+	ShockPenalty      int
+	HighPainThreshold bool
+}
+
+func newPoolTrackerState(attr *gurps.Attribute) *poolTrackerState {
+	return &poolTrackerState{
+		Data:              cloneAttributeData(&attr.AttributeData),
+		ShockPenalty:      attr.Entity.ShockPenalty,
+		HighPainThreshold: attr.Entity.HighPainThreshold,
+	}
+}
+
+func (s *poolTrackerState) apply(attr *gurps.Attribute) {
+	attr.AttributeData = cloneAttributeData(&s.Data)
+	attr.Entity.ShockPenalty = s.ShockPenalty
+	attr.Entity.HighPainThreshold = s.HighPainThreshold
+}
+
+// displayPoolTracker displays the damage & healing tracker for a point pool.
+func displayPoolTracker(owner Rebuildable, attr *gurps.Attribute) {
+	def := attr.AttributeDef()
+	if def == nil || !def.Pool() || def.IsSeparator() {
+		return
+	}
+	t := &poolTracker{
+		entity: attr.Entity,
+		attr:   attr,
+	}
+	before := newPoolTrackerState(attr)
+
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(500, 0),
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+
+	t.status = unison.NewLabel()
+	t.status.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+	panel.AddChild(t.status)
+	panel.AddChild(t.createEntryPanel())
+	panel.AddChild(t.createButtonPanel())
+	if t.tracksShock() {
+		panel.AddChild(t.createWoundPanel())
+		panel.AddChild(t.createShockPanel())
+	}
+	if t.attr.AttrID == gurps.ActionPointsID {
+		panel.AddChild(t.createRestPanel())
+	}
+	if len(def.Regeneration) != 0 {
+		panel.AddChild(t.createRegenPanel(def))
+	}
+	panel.AddChild(t.createLogPanel())
+	t.sync()
+
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfo(),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	dialog.Window().SetTitle(fmt.Sprintf(i18n.Text("%s Tracker"), def.CombinedName()))
+	t.amountFld.RequestFocus()
+	if dialog.RunModal() != unison.ModalResponseOK || !t.hadChanges {
+		before.apply(attr)
+		return
+	}
+	addPoolUndo(owner, attr, before, fmt.Sprintf(i18n.Text("%s Changes"), def.CombinedName()))
+	owner.Rebuild(true)
+}
+
+// addPoolUndo adds an undo edit for changes made to the pool since the before state was captured.
+func addPoolUndo(owner Rebuildable, attr *gurps.Attribute, before *poolTrackerState, name string) {
+	if mgr := unison.UndoManagerFor(owner); mgr != nil {
+		addUndo(mgr, &unison.UndoEdit[*poolTrackerState]{
+			ID:       unison.NextUndoID(),
+			EditName: name,
+			UndoFunc: func(edit *unison.UndoEdit[*poolTrackerState]) {
+				edit.BeforeData.apply(attr)
+				owner.Rebuild(true)
+			},
+			RedoFunc: func(edit *unison.UndoEdit[*poolTrackerState]) {
+				edit.AfterData.apply(attr)
+				owner.Rebuild(true)
+			},
+			BeforeData: before,
+			AfterData:  newPoolTrackerState(attr),
+		})
+	}
+}
+
+func cloneAttributeData(data *gurps.AttributeData) gurps.AttributeData {
+	clone := *data
+	clone.Log = gurps.ClonePoolRecordList(data.Log)
+	return clone
+}
+
+func (t *poolTracker) createEntryPanel() *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  4,
+		HSpacing: unison.StdHSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	title := i18n.Text("Amount")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	t.amountFld = NewDecimalField(nil, "", title, func() fxp.Int { return t.amount },
+		func(v fxp.Int) {
+			t.amount = v
+			t.adjustButtons()
+		}, 0, fxp.Max, false, false)
+	panel.AddChild(t.amountFld)
+	title = i18n.Text("Note")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	t.noteFld = NewStringField(nil, "", title, func() string { return t.note }, func(s string) { t.note = s })
+	t.noteFld.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(200, 0),
+		HAlign:  align.Fill,
+		HGrab:   true,
+	})
+	panel.AddChild(t.noteFld)
+	return panel
+}
+
+func (t *poolTracker) createButtonPanel() *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:      3,
+		HSpacing:     unison.StdHSpacing,
+		EqualColumns: true,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+	t.applyBtns = nil
+	for _, one := range []struct {
+		title string
+		apply func(amount fxp.Int, note string) *gurps.PoolRecord
+	}{
+		{title: i18n.Text("Apply Damage"), apply: t.attr.ApplyDamage},
+		{title: i18n.Text("Apply Healing"), apply: t.attr.ApplyHealing},
+		{title: i18n.Text("Add Temporary"), apply: t.attr.AddTemporary},
+	} {
+		b := unison.NewButton()
+		b.SetTitle(one.title)
+		b.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Fill})
+		apply := one.apply
+		b.ClickCallback = func() {
+			if t.amount > 0 {
+				t.recordApplied(apply(t.amount, strings.TrimSpace(t.note)))
+			}
+		}
+		panel.AddChild(b)
+		t.applyBtns = append(t.applyBtns, b)
+	}
+	return panel
+}
+
+// recordApplied finishes up after a change to the pool, applying shock for any damage and resetting the entry fields.
+func (t *poolTracker) recordApplied(record *gurps.PoolRecord) {
+	if t.tracksShock() && record.Damage > 0 {
+		t.entity.ApplyShock(record.Damage)
+		t.entity.Recalculate()
+	}
+	t.hadChanges = true
+	t.amount = 0
+	t.note = ""
+	t.amountFld.Sync()
+	t.noteFld.Sync()
+	t.sync()
+	t.amountFld.RequestFocus()
+}
+
+// createWoundPanel creates the controls for applying the amount as penetrating damage, which is converted to injury
+// using the wounding modifiers and the character's Injury Tolerance, Damage Reduction and Supernatural Durability.
+func (t *poolTracker) createWoundPanel() *unison.Panel {
+	resistance := t.entity.InjuryResistance()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  4,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Type"), false))
+	typePopup := unison.NewPopupMenu[wound.Type]()
+	typePopup.AddItem(wound.Types...)
+	typePopup.Select(t.wound.Type)
+	typePopup.SelectionChangedCallback = func(p *unison.PopupMenu[wound.Type]) {
+		if dmgType, ok := p.Selected(); ok {
+			t.wound.Type = dmgType
+		}
+	}
+	panel.AddChild(typePopup)
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Location"), false))
+	locationPopup := unison.NewPopupMenu[wound.Location]()
+	locationPopup.AddItem(wound.Locations...)
+	locationPopup.Select(t.wound.Location)
+	locationPopup.SelectionChangedCallback = func(p *unison.PopupMenu[wound.Location]) {
+		if location, ok := p.Selected(); ok {
+			t.wound.Location = location
+		}
+	}
+	panel.AddChild(locationPopup)
+
+	b := unison.NewButton()
+	b.SetTitle(i18n.Text("Apply Penetrating Damage"))
+	b.Tooltip = newWrappedTooltip(i18n.Text("Apply the amount as damage that penetrated DR, adjusting it for the type of damage and location hit to determine the injury (B379)."))
+	b.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+	})
+	b.ClickCallback = func() {
+		if t.amount <= 0 {
+			return
+		}
+		t.wound.Penetrating = t.amount
+		note := t.entity.InjuryExplanation(&t.wound)
+		if extra := strings.TrimSpace(t.note); extra != "" {
+			note = extra + " (" + note + ")"
+		}
+		t.recordApplied(t.attr.ApplyDamage(t.entity.Injury(&t.wound), note))
+	}
+	panel.AddChild(b)
+	t.applyBtns = append(t.applyBtns, b)
+	if resistance.SupernaturalDurability {
+		fromWeakness := unison.NewCheckBox()
+		fromWeakness.SetTitle(i18n.Text("From Weakness"))
+		fromWeakness.Tooltip = newWrappedTooltip(i18n.Text("Supernatural Durability doesn't stop damage from the character's weakness from killing them."))
+		fromWeakness.ClickCallback = func() { t.wound.FromWeakness = fromWeakness.State == check.On }
+		fromWeakness.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+		panel.AddChild(fromWeakness)
+	}
+	if text := resistance.String(); text != "" {
+		label := unison.NewLabel()
+		label.SetTitle(fmt.Sprintf(i18n.Text("Injury Resistance: %s"), text))
+		label.SetLayoutData(&unison.FlexLayoutData{
+			HSpan:  4,
+			HAlign: align.Middle,
+		})
+		panel.AddChild(label)
+	}
+	return panel
+}
+
+// tracksShock returns true if damage applied through this tracker causes shock, which is only the case for hit points.
+func (t *poolTracker) tracksShock() bool {
+	return t.attr.AttrID == gurps.HitPointsID
+}
+
+func (t *poolTracker) createShockPanel() *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing * 2,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+	t.shock = unison.NewLabel()
+	t.shock.Tooltip = newWrappedTooltip(i18n.Text("Injury causes a penalty to DX, IQ, and skills and spells based on them on the following turn (B419)."))
+	panel.AddChild(t.shock)
+	t.clearShock = unison.NewButton()
+	t.clearShock.SetTitle(i18n.Text("Clear Shock"))
+	t.clearShock.ClickCallback = func() {
+		t.entity.ClearShock()
+		t.entity.Recalculate()
+		t.hadChanges = true
+		t.sync()
+	}
+	panel.AddChild(t.clearShock)
+	highPainThreshold := unison.NewCheckBox()
+	highPainThreshold.SetTitle(i18n.Text("High Pain Threshold"))
+	highPainThreshold.Tooltip = newWrappedTooltip(i18n.Text("Characters with High Pain Threshold never suffer shock penalties."))
+	highPainThreshold.State = check.FromBool(t.entity.HighPainThreshold)
+	highPainThreshold.ClickCallback = func() {
+		t.entity.HighPainThreshold = highPainThreshold.State == check.On
+		if t.entity.HighPainThreshold {
+			t.entity.ClearShock()
+			t.entity.Recalculate()
+		}
+		t.hadChanges = true
+		t.sync()
+	}
+	panel.AddChild(highPainThreshold)
+	return panel
+}
+
+// createRestPanel creates the controls for recovering Action Points by resting (Pyramid 3/44).
+func (t *poolTracker) createRestPanel() *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  4,
+		HSpacing: unison.StdHSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+	seconds := 1
+	title := i18n.Text("Seconds of Rest")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	panel.AddChild(NewIntegerField(nil, "", title, func() int { return seconds }, func(v int) { seconds = v }, 1,
+		3600, false, false))
+	rest := unison.NewButton()
+	rest.SetTitle(i18n.Text("Rest"))
+	rest.Tooltip = newWrappedTooltip(i18n.Text("Recover 1 AP per second of rest"))
+	rest.ClickCallback = func() {
+		if record := t.entity.RecoverActionPoints(seconds); record != nil {
+			t.recordApplied(record)
+		}
+	}
+	panel.AddChild(rest)
+	catchBreath := unison.NewButton()
+	catchBreath.SetTitle(i18n.Text("Catch Breath"))
+	catchBreath.Tooltip = newWrappedTooltip(i18n.Text("Recover all AP"))
+	catchBreath.ClickCallback = func() {
+		if record := t.entity.CatchBreath(); record != nil {
+			t.recordApplied(record)
+		}
+	}
+	panel.AddChild(catchBreath)
+	return panel
+}
+
+func (t *poolTracker) createRegenPanel(def *gurps.AttributeDef) *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  len(def.Regeneration),
+		HSpacing: unison.StdHSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+	for _, one := range def.Regeneration {
+		b := unison.NewButton()
+		b.SetTitle(one.Name)
+		b.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("Restore %s points"), one.Amount(t.entity).String()))
+		b.ClickCallback = func() {
+			if record := t.attr.Regenerate(one.Name, 1); record != nil {
+				t.recordApplied(record)
+			}
+		}
+		panel.AddChild(b)
+	}
+	return panel
+}
+
+func (t *poolTracker) createLogPanel() *unison.ScrollPanel {
+	t.logPanel = unison.NewPanel()
+	t.logPanel.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing)))
+	t.logPanel.SetLayout(&unison.FlexLayout{
+		Columns:  4,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	t.logScroll = unison.NewScrollPanel()
+	t.logScroll.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	t.logScroll.SetContent(t.logPanel, behavior.HintedFill, behavior.Fill)
+	t.logScroll.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(0, 200),
+		SizeHint: unison.Size{
+			Height: 200,
+		},
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	return t.logScroll
+}
+
+func (t *poolTracker) sync() {
+	current := t.attr.Current()
+	maximum := t.attr.Maximum()
+	text := fmt.Sprintf(i18n.Text("Current: %s of %s"), current.Comma(), maximum.Comma())
+	if t.attr.Temporary > 0 {
+		text += fmt.Sprintf(i18n.Text(" (+%s temporary)"), t.attr.Temporary.Comma())
+	}
+	if threshold := t.attr.CurrentThreshold(); threshold != nil {
+		text += " [" + threshold.State + "]"
+	}
+	t.status.SetTitle(text)
+	if t.shock != nil {
+		if t.entity.ShockPenalty != 0 {
+			t.shock.SetTitle(fmt.Sprintf(i18n.Text("Shock: %d"), t.entity.ShockPenalty))
+		} else {
+			t.shock.SetTitle(i18n.Text("No Shock"))
+		}
+		t.clearShock.SetEnabled(t.entity.ShockPenalty != 0)
+	}
+	t.rebuildLog()
+	t.adjustButtons()
+	t.status.MarkForLayoutAndRedraw()
+	if p := t.status.Parent(); p != nil {
+		p.MarkForLayoutRecursively()
+	}
+}
+
+func (t *poolTracker) rebuildLog() {
+	t.logPanel.RemoveAllChildren()
+	if len(t.attr.Log) == 0 {
+		label := unison.NewLabel()
+		label.SetTitle(i18n.Text("No changes have been recorded."))
+		label.SetLayoutData(&unison.FlexLayoutData{
+			HSpan:  4,
+			HAlign: align.Middle,
+		})
+		t.logPanel.AddChild(label)
+	} else {
+		records := slices.Clone(t.attr.Log)
+		slices.Reverse(records)
+		for _, record := range records {
+			when := unison.NewLabel()
+			when.SetTitle(record.When.String())
+			t.logPanel.AddChild(when)
+			change := unison.NewLabel()
+			change.SetTitle(record.Description())
+			t.logPanel.AddChild(change)
+			note := unison.NewLabel()
+			note.SetTitle(record.Note)
+			note.SetLayoutData(&unison.FlexLayoutData{HGrab: true})
+			t.logPanel.AddChild(note)
+			undoButton := unison.NewSVGButton(svg.Reset)
+			undoButton.Tooltip = newWrappedTooltip(i18n.Text("Undo this change"))
+			undoButton.ClickCallback = func() {
+				if t.attr.UndoPoolRecord(record) {
+					t.hadChanges = true
+					t.sync()
+				}
+			}
+			t.logPanel.AddChild(undoButton)
+		}
+	}
+	t.logPanel.MarkForLayoutRecursively()
+	t.logScroll.MarkForLayoutAndRedraw()
+}
+
+func (t *poolTracker) adjustButtons() {
+	for _, b := range t.applyBtns {
+		b.SetEnabled(t.amount > 0)
+	}
+}
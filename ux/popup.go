@@ -57,7 +57,7 @@ func NewPopup[T comparable](targetMgr *TargetMgr, targetKey, undoTitle string, g
 						MarkModified(self)
 					}, p.get())
 					undo.AfterData, _ = p.Selected()
-					mgr.Add(undo)
+					addUndo(mgr, undo)
 				}
 			}
 			p.set(item)
@@ -138,7 +138,7 @@ func (p *PortraitPanel) fileDrop(files []string) {
 			continue
 		}
 		sheet := unison.Ancestor[*Sheet](p)
-		sheet.undoMgr.Add(&unison.UndoEdit[[]byte]{
+		addUndo(sheet.undoMgr, &unison.UndoEdit[[]byte]{
 			ID:         unison.NextUndoID(),
 			EditName:   i18n.Text("Set Portrait"),
 			UndoFunc:   func(edit *unison.UndoEdit[[]byte]) { sheet.updatePortrait(edit.BeforeData) },
@@ -0,0 +1,112 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/atexit"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/printing"
+)
+
+const printerScanTimeout = 10 * time.Second
+
+// PrintSheets starts the UI toolkit without opening any windows, renders each character sheet found in the given paths
+// and sends it to the printer whose name or ID matches printerName. Exits once all sheets have been printed, using the
+// exit code provided by gurps.CLI. Never returns.
+func PrintSheets(paths []string, printerName string) {
+	unison.Start(
+		unison.StartupFinishedCallback(func() {
+			go func() {
+				atexit.Exit(gurps.CLI.Finish(printSheets(paths, printerName)))
+			}()
+		}),
+		unison.QuitAfterLastWindowClosedCallback(func() bool { return false }),
+	) // Never returns
+}
+
+func printSheets(paths []string, printerName string) error {
+	list, err := gurps.SheetPaths(paths...)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return errs.New(i18n.Text("No character sheets to print"))
+	}
+	printer := findPrinter(printerName)
+	if printer == nil {
+		var buffer strings.Builder
+		fmt.Fprintf(&buffer, i18n.Text("Unable to locate printer '%s'. Available printers:"), printerName)
+		for _, one := range printMgr.Printers() {
+			fmt.Fprintf(&buffer, "\n  %s", one.Name)
+		}
+		return errs.New(buffer.String())
+	}
+	for _, p := range list {
+		if err = printSheet(printer, p); err != nil {
+			gurps.CLI.Failed(p, err)
+			return errs.NewWithCause(p, err)
+		}
+		gurps.CLI.File(p, gurps.CLIStatusPrinted, fmt.Sprintf(i18n.Text("Printed %s"), p))
+	}
+	return nil
+}
+
+func printSheet(printer *printing.Printer, p string) error {
+	entity, err := gurps.NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p))
+	if err != nil {
+		return err
+	}
+	var data []byte
+	done := make(chan struct{})
+	unison.InvokeTask(func() {
+		defer close(done)
+		data, err = newPageExporter(entity).exportAsPDFBytes()
+	})
+	<-done
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+	return printer.Print(ctx, entity.Profile.Name, "application/pdf", bytes.NewBuffer(data), len(data), nil)
+}
+
+// findPrinter scans for printers and returns the first one whose name or ID matches, ignoring case. Returns nil if no
+// matching printer is found before the scan times out.
+func findPrinter(name string) *printing.Printer {
+	ctx, cancel := context.WithTimeout(context.Background(), printerScanTimeout)
+	defer cancel()
+	found := make(chan *printing.Printer, 8)
+	go printMgr.ScanForPrinters(ctx, found)
+	for {
+		select {
+		case printer, ok := <-found:
+			if !ok {
+				return nil
+			}
+			if strings.EqualFold(printer.Name, name) || strings.EqualFold(printer.ID, name) {
+				return printer
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
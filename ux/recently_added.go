@@ -0,0 +1,96 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+// recordRecentlyAdded remembers the library items the rows were copied from, so that they can be quickly added to
+// other sheets. Rows that didn't come from a library are ignored.
+func recordRecentlyAdded[T gurps.NodeTypes](rows []*Node[T]) {
+	global := gurps.GlobalSettings()
+	// Go backwards so that the first row ends up as the most recent one
+	for i := len(rows) - 1; i >= 0; i-- {
+		data := any(rows[i].Data())
+		provider, ok := data.(gurps.SrcProvider)
+		if !ok {
+			continue
+		}
+		src := provider.GetSource()
+		if src.ShouldOmit() {
+			continue
+		}
+		var item gurps.IDer
+		if item, ok = data.(gurps.IDer); ok {
+			if ref := gurps.NewFavorite(src.LibraryFile, item); ref != nil {
+				ref.ID = src.TID
+				global.AddRecentlyAdded(ref)
+			}
+		}
+	}
+}
+
+// addRecentlyAddedToSheet adds the library item to the sheet again.
+func addRecentlyAddedToSheet(sheet *Sheet, ref *gurps.Favorite) {
+	result := gurps.ResolveFavorites(gurps.GlobalSettings().Libraries(), []*gurps.Favorite{ref})[0]
+	if result == nil {
+		unison.ErrorDialogWithMessage(fmt.Sprintf(i18n.Text("Unable to add %s"), ref.Name),
+			i18n.Text("It could no longer be found in its library."))
+		return
+	}
+	switch item := result.Item.(type) {
+	case *gurps.Trait:
+		copyRowsToSheet(sheet, libraryRowsForItem(result.From, item))
+	case *gurps.Skill:
+		copyRowsToSheet(sheet, libraryRowsForItem(result.From, item))
+	case *gurps.Spell:
+		copyRowsToSheet(sheet, libraryRowsForItem(result.From, item))
+	case *gurps.Equipment:
+		copyRowsToSheet(sheet, libraryRowsForItem(result.From, item))
+	case *gurps.Note:
+		copyRowsToSheet(sheet, libraryRowsForItem(result.From, item))
+	}
+}
+
+// libraryRowsForItem returns the item as the rows of a table that came from the library file, so that copies made of
+// it record the library as their source.
+func libraryRowsForItem[T gurps.NodeTypes](from gurps.LibraryFile, item T) []*Node[T] {
+	table := unison.NewTable[*Node[T]](&unison.SimpleTableModel[*Node[T]]{})
+	table.ClientData()[libraryFileClientKey] = from
+	return []*Node[T]{NewNode(table, nil, item, false)}
+}
+
+func (s menuBarScope) recentlyAddedUpdater(menu unison.Menu) {
+	menu.RemoveAll()
+	for i, ref := range gurps.GlobalSettings().RecentlyAdded {
+		menu.InsertItem(-1, s.createAddRecentlyAddedAction(i, ref).NewMenuItem(menu.Factory()))
+	}
+	if menu.Count() == 0 {
+		s.appendDisabledMenuItem(menu, i18n.Text("No recently added items available"))
+	}
+}
+
+func (s menuBarScope) createAddRecentlyAddedAction(index int, ref *gurps.Favorite) *unison.Action {
+	return &unison.Action{
+		ID:              RecentlyAddedBaseItemID + index,
+		Title:           ref.Name,
+		EnabledCallback: actionEnabledForEditableSheet,
+		ExecuteCallback: func(_ *unison.Action, _ any) {
+			if sheet := ActiveSheet(); sheet != nil {
+				addRecentlyAddedToSheet(sheet, ref)
+			}
+		},
+	}
+}
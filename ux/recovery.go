@@ -0,0 +1,119 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/jio"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+)
+
+// recoverableDockable defines the methods a dockable must provide for its unsaved changes to be autosaved.
+type recoverableDockable interface {
+	FileBackedDockable
+	Modified() bool
+	writeRecoveryFile(filePath string) error
+	markRecovered(originalPath string)
+}
+
+// startAutosave schedules the next write of documents with unsaved changes to the recovery directory. Reschedules
+// itself after each write.
+func startAutosave() {
+	unison.InvokeTaskAfter(func() {
+		autosave()
+		startAutosave()
+	}, time.Duration(gurps.GlobalSettings().General.AutosaveInterval)*time.Second)
+}
+
+func autosave() {
+	dir := gurps.RecoveryDir()
+	var journal gurps.RecoveryJournal
+	for _, d := range AllDockables() {
+		rd, ok := d.(recoverableDockable)
+		if !ok || !rd.Modified() {
+			continue
+		}
+		entry := &gurps.RecoveryEntry{
+			Path:  rd.BackingFilePath(),
+			File:  gurps.RecoveryFileName(len(journal.Entries), rd.BackingFilePath()),
+			Saved: jio.Now(),
+		}
+		if err := rd.writeRecoveryFile(filepath.Join(dir, entry.File)); err != nil {
+			errs.Log(err, "path", entry.Path)
+			continue
+		}
+		journal.Entries = append(journal.Entries, entry)
+	}
+	if len(journal.Entries) == 0 {
+		clearRecovery()
+		return
+	}
+	if err := journal.Save(dir); err != nil {
+		errs.Log(err)
+	}
+}
+
+// clearRecovery discards any autosaved documents. Should be called once the documents have been dealt with, either by
+// saving them or by the user choosing to discard their changes.
+func clearRecovery() {
+	if err := gurps.ClearRecovery(gurps.RecoveryDir()); err != nil {
+		errs.Log(err)
+	}
+}
+
+// offerRecovery checks for documents that were autosaved by a prior run that didn't exit cleanly and, if any are
+// found, offers to reopen them.
+func offerRecovery() {
+	dir := gurps.RecoveryDir()
+	journal, err := gurps.LoadRecoveryJournal(dir)
+	if err != nil {
+		errs.Log(err)
+		clearRecovery()
+		return
+	}
+	if len(journal.Entries) == 0 {
+		clearRecovery()
+		return
+	}
+	names := make([]string, len(journal.Entries))
+	for i, entry := range journal.Entries {
+		names[i] = fmt.Sprintf(i18n.Text("%s (changed %v)"), fs.BaseName(entry.Path), entry.Saved)
+	}
+	if unison.QuestionDialog(i18n.Text("GCS did not shut down cleanly. Restore the unsaved changes to these documents?"),
+		strings.Join(names, "\n")) == unison.ModalResponseOK {
+		for _, entry := range journal.Entries {
+			restoreRecoveryEntry(dir, entry)
+		}
+	}
+	clearRecovery()
+}
+
+func restoreRecoveryEntry(dir string, entry *gurps.RecoveryEntry) {
+	filePath := filepath.Join(dir, entry.File)
+	d, err := gurps.FileInfoFor(filePath).Load(filePath, 0)
+	if err != nil {
+		unison.ErrorDialogWithError(fmt.Sprintf(i18n.Text("Unable to restore %s"), fs.BaseName(entry.Path)), err)
+		return
+	}
+	rd, ok := d.(recoverableDockable)
+	if !ok {
+		return
+	}
+	rd.markRecovered(entry.Path)
+	DisplayNewDockable(rd)
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+type regenSettingsPanel struct {
+	unison.Panel
+	dockable *attributeSettingsDockable
+	def      *gurps.AttributeDef
+}
+
+func newRegenSettingsPanel(dockable *attributeSettingsDockable, def *gurps.AttributeDef) *regenSettingsPanel {
+	p := &regenSettingsPanel{
+		dockable: dockable,
+		def:      def,
+	}
+	p.Self = p
+	p.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1),
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	p.SetLayout(&unison.FlexLayout{
+		Columns:  4,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	p.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.ClickCallback = p.addRegeneration
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add pool regeneration"))
+	p.AddChild(addButton)
+	label := NewFieldInteriorLeadingLabel(i18n.Text("Regeneration"), false)
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: 3})
+	p.AddChild(label)
+
+	for _, regen := range def.Regeneration {
+		p.addRow(regen)
+	}
+	return p
+}
+
+func (p *regenSettingsPanel) addRow(regen *gurps.PoolRegeneration) {
+	deleteButton := unison.NewSVGButton(svg.Trash)
+	deleteButton.ClickCallback = func() { p.deleteRegeneration(regen) }
+	deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove pool regeneration"))
+	p.AddChild(deleteButton)
+
+	text := i18n.Text("Regeneration Name")
+	field := NewStringField(p.dockable.targetMgr, regen.KeyPrefix+"name", text,
+		func() string { return regen.Name },
+		func(s string) { regen.Name = s })
+	field.Watermark = i18n.Text("Hour of Rest")
+	field.SetMinimumTextWidthUsing(prototypeMinIDWidth)
+	field.Tooltip = newWrappedTooltip(i18n.Text("The name of the period or activity that restores points, such as an hour of rest, a day or a meditation. Rules with the same name on different pools are applied together."))
+	p.AddChild(field)
+
+	p.AddChild(NewFieldInteriorLeadingLabel(i18n.Text("restores"), false))
+
+	text = i18n.Text("Regeneration Amount")
+	field = NewStringField(p.dockable.targetMgr, regen.KeyPrefix+"expression", text,
+		func() string { return regen.Expression },
+		func(s string) { regen.Expression = s })
+	field.SetMinimumTextWidthUsing("round($self/10)")
+	field.Tooltip = newWrappedTooltip(i18n.Text("An expression to calculate the points restored each time the rule is applied. A negative amount drains the pool instead."))
+	field.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	p.AddChild(field)
+}
+
+func (p *regenSettingsPanel) addRegeneration() {
+	regen := &gurps.PoolRegeneration{
+		PoolRegenerationData: gurps.PoolRegenerationData{Expression: "1"},
+		KeyPrefix:            p.dockable.targetMgr.NextPrefix(),
+	}
+	p.changeRegeneration(i18n.Text("Add Pool Regeneration"), append(slices.Clone(p.def.Regeneration), regen))
+	if focus := p.dockable.targetMgr.Find(regen.KeyPrefix + "name"); focus != nil {
+		focus.RequestFocus()
+		focus.ScrollIntoView()
+	}
+}
+
+func (p *regenSettingsPanel) deleteRegeneration(regen *gurps.PoolRegeneration) {
+	if i := slices.Index(p.def.Regeneration, regen); i != -1 {
+		p.changeRegeneration(i18n.Text("Delete Pool Regeneration"),
+			slices.Delete(slices.Clone(p.def.Regeneration), i, i+1))
+	}
+}
+
+func (p *regenSettingsPanel) changeRegeneration(name string, regeneration []*gurps.PoolRegeneration) {
+	def := p.def
+	dockable := p.dockable
+	apply := func(list []*gurps.PoolRegeneration) {
+		def.Regeneration = clonePoolRegeneration(list)
+		dockable.sync()
+	}
+	undo := &unison.UndoEdit[[]*gurps.PoolRegeneration]{
+		ID:         unison.NextUndoID(),
+		EditName:   name,
+		UndoFunc:   func(e *unison.UndoEdit[[]*gurps.PoolRegeneration]) { apply(e.BeforeData) },
+		RedoFunc:   func(e *unison.UndoEdit[[]*gurps.PoolRegeneration]) { apply(e.AfterData) },
+		AbsorbFunc: func(_ *unison.UndoEdit[[]*gurps.PoolRegeneration], _ unison.Undoable) bool { return false },
+		BeforeData: clonePoolRegeneration(def.Regeneration),
+		AfterData:  clonePoolRegeneration(regeneration),
+	}
+	def.Regeneration = regeneration
+	addUndo(dockable.UndoManager(), undo)
+	dockable.sync()
+}
+
+func clonePoolRegeneration(in []*gurps.PoolRegeneration) []*gurps.PoolRegeneration {
+	list := make([]*gurps.PoolRegeneration, len(in))
+	for i, one := range in {
+		list[i] = one.Clone()
+	}
+	return list
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/json"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio"
+	"github.com/richardwilkes/unison"
+)
+
+// Commands that may be sent to a running instance of GCS.
+const (
+	RemoteExportPDF   = "export-pdf"
+	RemotePrint       = "print"
+	RemoteFocusWindow = "focus-window"
+)
+
+// RemoteCommands holds the commands that may be sent to a running instance of GCS.
+var RemoteCommands = []string{RemoteExportPDF, RemotePrint, RemoteFocusWindow}
+
+type remoteCommand struct {
+	Command string   `json:"command"`
+	Paths   []string `json:"paths,omitempty"`
+	Printer string   `json:"printer,omitempty"`
+}
+
+// SendRemoteCommand sends a command to the running instance of GCS and returns its response. The paths are the files
+// the command operates on. printerName is only used by the print command.
+func SendRemoteCommand(command string, paths []string, printerName string) (*gurps.CLIResult, error) {
+	cmd := remoteCommand{
+		Command: command,
+		Paths:   make([]string, len(paths)),
+		Printer: printerName,
+	}
+	for i, p := range paths {
+		var err error
+		if cmd.Paths[i], err = filepath.Abs(p); err != nil {
+			cmd.Paths[i] = p
+		}
+	}
+	buffer, err := json.Marshal(&cmd)
+	if err != nil {
+		return nil, errs.Wrap(err)
+	}
+	conn, token := dialHandoff()
+	if conn == nil {
+		return nil, errs.New(i18n.Text("Unable to reach a running instance of GCS"))
+	}
+	defer xio.CloseIgnoringErrors(conn)
+	if err = conn.SetDeadline(time.Now().Add(remoteCommandTimeout)); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	if err = handoffHandshake(conn, token, handoffCommand); err != nil {
+		return nil, err
+	}
+	if err = writeHandoffBlock(conn, buffer); err != nil {
+		return nil, err
+	}
+	if buffer, err = readHandoffBlock(conn, handoffMaxBlockSize); err != nil {
+		return nil, err
+	}
+	var result gurps.CLIResult
+	if err = json.Unmarshal(buffer, &result); err != nil {
+		return nil, errs.Wrap(err)
+	}
+	return &result, nil
+}
+
+// run performs the command. Must not be called on the UI thread.
+func (c *remoteCommand) run() *gurps.CLIResult {
+	result := &gurps.CLIResult{
+		Command: c.Command,
+		Files:   make([]*gurps.CLIFileResult, 0, len(c.Paths)),
+	}
+	var err error
+	switch c.Command {
+	case RemoteExportPDF:
+		err = c.exportPDF(result)
+	case RemotePrint:
+		err = c.print(result)
+	case RemoteFocusWindow:
+		err = c.focusWindow(result)
+	default:
+		err = errs.Newf(i18n.Text("Unknown command: %s"), c.Command)
+	}
+	result.ExitCode = gurps.ExitCodeForError(err)
+	if err != nil {
+		result.Error = gurps.ErrorMessage(err)
+	}
+	return result
+}
+
+func (c *remoteCommand) exportPDF(result *gurps.CLIResult) error {
+	list, err := gurps.SheetPaths(c.Paths...)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return errs.New(i18n.Text("No character sheets to export"))
+	}
+	for _, p := range list {
+		done := make(chan struct{})
+		unison.InvokeTask(func() {
+			defer close(done)
+			err = ExportSheetFile(p, PDFExportFormat)
+		})
+		<-done
+		if err != nil {
+			result.Files = append(result.Files, &gurps.CLIFileResult{
+				Path:   p,
+				Status: gurps.CLIStatusFailed,
+				Error:  gurps.ErrorMessage(err),
+			})
+			return errs.NewWithCause(p, err)
+		}
+		result.Files = append(result.Files, &gurps.CLIFileResult{Path: p, Status: gurps.CLIStatusExported})
+	}
+	return nil
+}
+
+func (c *remoteCommand) print(result *gurps.CLIResult) error {
+	if c.Printer == "" {
+		return errs.New(i18n.Text("A printer must be specified with --printer"))
+	}
+	list, err := gurps.SheetPaths(c.Paths...)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return errs.New(i18n.Text("No character sheets to print"))
+	}
+	printer := findPrinter(c.Printer)
+	if printer == nil {
+		return errs.Newf(i18n.Text("Unable to locate printer '%s'"), c.Printer)
+	}
+	for _, p := range list {
+		if err = printSheet(printer, p); err != nil {
+			result.Files = append(result.Files, &gurps.CLIFileResult{
+				Path:   p,
+				Status: gurps.CLIStatusFailed,
+				Error:  gurps.ErrorMessage(err),
+			})
+			return errs.NewWithCause(p, err)
+		}
+		result.Files = append(result.Files, &gurps.CLIFileResult{Path: p, Status: gurps.CLIStatusPrinted})
+	}
+	return nil
+}
+
+func (c *remoteCommand) focusWindow(result *gurps.CLIResult) error {
+	if len(c.Paths) != 1 {
+		return errs.Newf(i18n.Text("%s requires exactly one file"), RemoteFocusWindow)
+	}
+	p := c.Paths[0]
+	var ok bool
+	done := make(chan struct{})
+	unison.InvokeTask(func() {
+		defer close(done)
+		if d, _ := OpenFile(p, 0); d != nil {
+			if wnd := d.AsPanel().Window(); wnd != nil {
+				wnd.ToFront()
+			}
+			ok = true
+		}
+	})
+	<-done
+	if !ok {
+		return errs.Newf(i18n.Text("Unable to open %s"), p)
+	}
+	result.Files = append(result.Files, &gurps.CLIFileResult{Path: p, Status: gurps.CLIStatusProcessed})
+	return nil
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/reputation"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+// buildReputation displays the Reputation builder and returns the resulting trait, or nil if the user cancels.
+func buildReputation(owner gurps.DataOwner) *gurps.Trait {
+	r := gurps.Reputation{Modifier: 1}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(400, 0),
+		HAlign:  align.Fill,
+		HGrab:   true,
+	})
+	pointsField := NewNonEditableField(func(field *NonEditableField) {
+		field.SetTitle(r.Points().String())
+		field.MarkForLayoutAndRedraw()
+	})
+	reactionField := NewNonEditableField(func(field *NonEditableField) {
+		field.SetTitle(r.Situation())
+		field.MarkForLayoutAndRedraw()
+	})
+	adjust := func() {
+		pointsField.Sync()
+		reactionField.Sync()
+	}
+
+	modifierLabel := i18n.Text("Reaction Modifier")
+	panel.AddChild(NewFieldLeadingLabel(modifierLabel, false))
+	panel.AddChild(NewIntegerField(nil, "", modifierLabel, func() int { return r.Modifier },
+		func(value int) {
+			r.Modifier = value
+			adjust()
+		}, gurps.MinReputationModifier, gurps.MaxReputationModifier, true, false))
+
+	groupLabel := i18n.Text("Group")
+	panel.AddChild(NewFieldLeadingLabel(groupLabel, false))
+	groupField := NewStringField(nil, "", groupLabel, func() string { return r.Group },
+		func(value string) {
+			r.Group = value
+			adjust()
+		})
+	groupField.Watermark = i18n.Text("Who the reputation is with")
+	panel.AddChild(groupField)
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Audience"), false))
+	audiencePopup := unison.NewPopupMenu[reputation.Audience]()
+	audiencePopup.AddItem(reputation.Audiences...)
+	audiencePopup.Select(r.Audience)
+	audiencePopup.SelectionChangedCallback = func(popup *unison.PopupMenu[reputation.Audience]) {
+		if item, ok := popup.Selected(); ok {
+			r.Audience = item
+			adjust()
+		}
+	}
+	panel.AddChild(audiencePopup)
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Recognized"), false))
+	recognitionPopup := unison.NewPopupMenu[reputation.Recognition]()
+	recognitionPopup.AddItem(reputation.Recognitions...)
+	recognitionPopup.Select(r.Recognition)
+	recognitionPopup.SelectionChangedCallback = func(popup *unison.PopupMenu[reputation.Recognition]) {
+		if item, ok := popup.Selected(); ok {
+			r.Recognition = item
+			adjust()
+		}
+	}
+	panel.AddChild(recognitionPopup)
+
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Reaction"), false))
+	panel.AddChild(reactionField)
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Points"), false))
+	panel.AddChild(pointsField)
+
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Add")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return nil
+	}
+	if dialog.RunModal() != unison.ModalResponseOK || r.Modifier == 0 {
+		return nil
+	}
+	return r.NewTrait(owner)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xmath/rand"
+	"github.com/richardwilkes/unison"
+)
+
+func newRollMacroChip(macro *gurps.RollMacro, resolver gurps.RollTargetResolver, font unison.Font, foreground, background unison.Ink) *unison.Tag {
+	tag := unison.NewTag()
+	tag.BackgroundInk = foreground
+	tag.OnBackgroundInk = background
+	tag.Font = font
+	tag.SetTitle(macro.Text)
+	tag.Tooltip = newWrappedTooltip(i18n.Text("Click to roll"))
+	tag.ClientData()[noInvertColorsMarker] = true
+	tag.MouseDownCallback = func(_ unison.Point, _, _ int, _ unison.Modifiers) bool {
+		return true
+	}
+	tag.MouseUpCallback = func(where unison.Point, _ int, _ unison.Modifiers) bool {
+		if where.In(tag.ContentRect(false)) {
+			showRollMacroResults(macro, resolver)
+		}
+		return true
+	}
+	tag.UpdateCursorCallback = func(_ unison.Point) *unison.Cursor {
+		return unison.PointingCursor()
+	}
+	return tag
+}
+
+func showRollMacroResults(macro *gurps.RollMacro, resolver gurps.RollTargetResolver) {
+	results := macro.Roll(resolver, rand.NewCryptoRand())
+	lines := make([]string, 0, len(results))
+	for _, result := range results {
+		lines = append(lines, result.String())
+	}
+	if dialog, err := unison.NewDialog(nil, nil, unison.NewMessagePanel(macro.Text, strings.Join(lines, "\n")),
+		[]*unison.DialogButtonInfo{unison.NewOKButtonInfo()}); err != nil {
+		errs.Log(err)
+	} else {
+		dialog.RunModal()
+	}
+}
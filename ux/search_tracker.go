@@ -20,6 +20,11 @@ import (
 	"github.com/richardwilkes/unison/enums/check"
 )
 
+// itemRevealer defines the method a dockable must implement to reveal an item by name.
+type itemRevealer interface {
+	RevealItem(name string)
+}
+
 type searchRef struct {
 	table any
 	row   any
@@ -37,7 +42,7 @@ type searchTracker struct {
 	searchIndex          int
 }
 
-func installSearchTracker(toolbar *unison.Panel, clearTableSelections func(), findMatches func(refList *[]*searchRef, text string, namesOnly bool)) {
+func installSearchTracker(toolbar *unison.Panel, clearTableSelections func(), findMatches func(refList *[]*searchRef, text string, namesOnly bool)) *searchTracker {
 	s := &searchTracker{
 		clearTableSelections: clearTableSelections,
 		findMatches:          findMatches,
@@ -84,6 +89,17 @@ func installSearchTracker(toolbar *unison.Panel, clearTableSelections func(), fi
 	toolbar.Parent().InstallCmdHandlers(JumpToSearchFilterItemID,
 		func(any) bool { return !s.searchField.Focused() },
 		func(any) { s.searchField.RequestFocus() })
+	return s
+}
+
+// searchForName searches for the name only and selects the first match.
+func (s *searchTracker) searchForName(name string) {
+	s.namesOnlyCheckBox.State = check.On
+	if s.searchField.Text() == name {
+		s.doSearch(name)
+	} else {
+		s.searchField.SetText(name)
+	}
 }
 
 func (s *searchTracker) searchModified(_, after *unison.FieldState) {
@@ -163,6 +179,8 @@ func showSearchRef(ref *searchRef) {
 		showSearchResolvedRef(table, ref.row.(*Node[*gurps.Skill]))
 	case *unison.Table[*Node[*gurps.Spell]]:
 		showSearchResolvedRef(table, ref.row.(*Node[*gurps.Spell]))
+	case *unison.Table[*Node[*gurps.Language]]:
+		showSearchResolvedRef(table, ref.row.(*Node[*gurps.Language]))
 	case *unison.Table[*Node[*gurps.Equipment]]:
 		showSearchResolvedRef(table, ref.row.(*Node[*gurps.Equipment]))
 	case *unison.Table[*Node[*gurps.Note]]:
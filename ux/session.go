@@ -0,0 +1,96 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/unison"
+)
+
+// sessionCaptured is set once the open documents have been recorded, so that closing them afterwards during the same
+// shutdown doesn't alter the session.
+var sessionCaptured bool
+
+// captureSession records the documents that are currently open, along with how they are arranged within the workspace,
+// into the global settings. Must be called before any of them are closed.
+func captureSession() {
+	if sessionCaptured {
+		return
+	}
+	sessionCaptured = true
+	session := gurps.Session{Layout: captureWorkspaceLayout("").Root}
+	for _, d := range AllDockables() {
+		fbd, ok := d.(FileBackedDockable)
+		if !ok {
+			continue
+		}
+		doc := &gurps.SessionDocument{Path: fbd.BackingFilePath()}
+		if doc.Path == "" {
+			continue
+		}
+		if IsDockableInWorkspace(d) {
+			if dc := unison.Ancestor[*unison.DockContainer](d.AsPanel()); dc != nil {
+				doc.Current = dc.CurrentDockable() == d
+			}
+		} else if wnd := d.AsPanel().Window(); wnd != nil {
+			frame := wnd.FrameRect()
+			doc.Frame = &frame
+			doc.InWindow = true
+		}
+		if pdfDockable, isPDF := d.(*PDFDockable); isPDF {
+			doc.Page = max(pdfDockable.pdf.MostRecentPageNumber(), 0)
+		}
+		session.Documents = append(session.Documents, doc)
+	}
+	gurps.GlobalSettings().Session = &session
+}
+
+// abandonSessionCapture should be called when closing was cancelled, so that the session will be recorded again the
+// next time.
+func abandonSessionCapture() {
+	sessionCaptured = false
+}
+
+// restoreSession reopens the documents that were open when the workspace was last closed, if enabled.
+func restoreSession() {
+	global := gurps.GlobalSettings()
+	if !global.General.RestoreSession {
+		return
+	}
+	var current []unison.Dockable
+	for _, doc := range global.Session.Restorable() {
+		d, _ := OpenFile(doc.Path, doc.Page)
+		if d == nil {
+			continue
+		}
+		if doc.InWindow {
+			wnd, err := MoveDockableToWindow(d)
+			if err != nil {
+				errs.Log(err, "path", doc.Path)
+				continue
+			}
+			if doc.Frame != nil {
+				wnd.SetFrameRect(unison.BestDisplayForRect(*doc.Frame).FitRectOnto(*doc.Frame))
+			}
+		} else if doc.Current {
+			current = append(current, d)
+		}
+	}
+	if global.Session != nil && global.Session.Layout != nil {
+		// Documents are opened above first, so that they retain their state, such as the page of a PDF, and are then
+		// arranged within the workspace as they were.
+		placeWorkspaceLayout(global.Session.Layout.Restorable())
+		return
+	}
+	for _, d := range current {
+		ActivateDockable(d)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"html"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/server/websettings"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+func (s *Sheet) shareAsEmbed(_ any) {
+	webSettings := gurps.GlobalSettings().WebServer
+	var targets []*websettings.ShareTarget
+	if !s.needsSaveAsPrompt {
+		targets = webSettings.ShareTargets(s.path)
+	}
+	if len(targets) == 0 {
+		unison.ErrorDialogWithMessage(i18n.Text("Unable to share this sheet"),
+			i18n.Text("No web user has access to the directory containing this sheet."))
+		return
+	}
+	var embedURL, snippet string
+	var localOnly bool
+	setTarget := func(target *websettings.ShareTarget) {
+		embedURL, localOnly = webSettings.EmbedURL(target)
+		snippet = fmt.Sprintf(`<iframe src="%s" width="480" height="160" style="border:0" loading="lazy"></iframe>`,
+			html.EscapeString(embedURL))
+	}
+	setTarget(targets[0])
+
+	var update func()
+
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+
+	if len(targets) > 1 {
+		panel.AddChild(NewFieldLeadingLabel(i18n.Text("User"), false))
+		popup := unison.NewPopupMenu[*websettings.ShareTarget]()
+		popup.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+		popup.AddItem(targets...)
+		popup.Select(targets[0])
+		popup.SelectionChangedCallback = func(p *unison.PopupMenu[*websettings.ShareTarget]) {
+			if target, ok := p.Selected(); ok {
+				setTarget(target)
+				update()
+			}
+		}
+		panel.AddChild(popup)
+	}
+
+	urlLabel := unison.NewLabel()
+	snippetLabel := unison.NewLabel()
+	addCopyableRow := func(title string, label *unison.Label, text func() string) {
+		panel.AddChild(NewFieldLeadingLabel(title, false))
+		label.SetLayoutData(&unison.FlexLayoutData{HGrab: true})
+		panel.AddChild(label)
+		copyButton := unison.NewSVGButton(svg.Copy)
+		copyButton.Tooltip = newWrappedTooltip(i18n.Text("Copy to clipboard"))
+		copyButton.ClickCallback = func() { unison.GlobalClipboard.SetText(text()) }
+		panel.AddChild(copyButton)
+	}
+	addCopyableRow(i18n.Text("Link"), urlLabel, func() string { return embedURL })
+	addCopyableRow(i18n.Text("HTML"), snippetLabel, func() string { return snippet })
+
+	note := unison.NewLabel()
+	desc := note.Font.Descriptor()
+	desc.Size -= 2
+	note.Font = desc.Font()
+	note.SetTitle(i18n.Text("Anyone with this link can view the summary. It can be revoked from the Web Settings."))
+	note.SetLayoutData(&unison.FlexLayoutData{HSpan: 3, HAlign: align.Middle})
+	panel.AddChild(note)
+
+	warningLabel := unison.NewLabel()
+	warningLabel.OnBackgroundInk = unison.ThemeError
+	warningLabel.SetLayoutData(&unison.FlexLayoutData{HSpan: 3, HAlign: align.Middle})
+	panel.AddChild(warningLabel)
+
+	update = func() {
+		urlLabel.SetTitle(embedURL)
+		snippetLabel.SetTitle(snippet)
+		if localOnly {
+			warningLabel.SetTitle(i18n.Text("The web server only accepts connections from this computer, so other devices won't be able to use this link."))
+		} else {
+			warningLabel.SetTitle("")
+		}
+		panel.MarkForLayoutAndRedraw()
+		if wnd := panel.Window(); wnd != nil {
+			wnd.Pack()
+		}
+	}
+	update()
+
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{unison.NewOKButtonInfo()})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	dialog.RunModal()
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/qrcode"
+	"github.com/richardwilkes/gcs/v5/server/state"
+	"github.com/richardwilkes/gcs/v5/server/websettings"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+)
+
+const qrCodeModuleSize = 6
+
+type shareLink struct {
+	url       string
+	localOnly bool
+	code      *qrcode.QRCode
+}
+
+func (s *Sheet) canShare(_ any) bool {
+	return !s.needsSaveAsPrompt && state.Current() == state.Running &&
+		len(gurps.GlobalSettings().WebServer.ShareTargets(s.path)) != 0
+}
+
+func (s *Sheet) shareAsQRCode(_ any) {
+	webSettings := gurps.GlobalSettings().WebServer
+	var targets []*websettings.ShareTarget
+	if !s.needsSaveAsPrompt {
+		targets = webSettings.ShareTargets(s.path)
+	}
+	if len(targets) == 0 {
+		unison.ErrorDialogWithMessage(i18n.Text("Unable to share this sheet"),
+			i18n.Text("No web user has access to the directory containing this sheet."))
+		return
+	}
+	// Each link carries its own session, so only create one for a user once they are actually chosen.
+	links := make(map[*websettings.ShareTarget]*shareLink)
+	linkFor := func(target *websettings.ShareTarget) (*shareLink, error) {
+		if link, ok := links[target]; ok {
+			return link, nil
+		}
+		link := &shareLink{}
+		link.url, link.localOnly = webSettings.ShareURL(target)
+		var err error
+		if link.code, err = qrcode.New(link.url); err != nil {
+			return nil, err
+		}
+		links[target] = link
+		return link, nil
+	}
+	current, err := linkFor(targets[0])
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create QR code"), err)
+		return
+	}
+
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+		HAlign:   align.Middle,
+	})
+
+	codePanel := unison.NewPanel()
+	codePanel.SetSizer(func(_ unison.Size) (minSize, prefSize, maxSize unison.Size) {
+		side := float32((current.code.Size() + 2*qrcode.QuietZone) * qrCodeModuleSize)
+		prefSize = unison.NewSize(side, side)
+		return prefSize, prefSize, prefSize
+	})
+	codePanel.DrawCallback = func(gc *unison.Canvas, _ unison.Rect) {
+		r := codePanel.ContentRect(false)
+		gc.DrawRect(r, unison.White.Paint(gc, r, paintstyle.Fill))
+		ink := unison.Black.Paint(gc, r, paintstyle.Fill)
+		size := current.code.Size()
+		for y := range size {
+			for x := range size {
+				if current.code.Dark(x, y) {
+					gc.DrawRect(unison.NewRect(r.X+float32((x+qrcode.QuietZone)*qrCodeModuleSize),
+						r.Y+float32((y+qrcode.QuietZone)*qrCodeModuleSize), qrCodeModuleSize, qrCodeModuleSize), ink)
+				}
+			}
+		}
+	}
+	codePanel.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+
+	urlLabel := unison.NewLabel()
+	urlLabel.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+	warningLabel := unison.NewLabel()
+	warningLabel.OnBackgroundInk = unison.ThemeError
+	warningLabel.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+	update := func() {
+		urlLabel.SetTitle(current.url)
+		if current.localOnly {
+			warningLabel.SetTitle(i18n.Text("The web server only accepts connections from this computer, so other devices won't be able to use this link."))
+		} else {
+			warningLabel.SetTitle("")
+		}
+		panel.MarkForLayoutAndRedraw()
+		if wnd := panel.Window(); wnd != nil {
+			wnd.Pack()
+		}
+	}
+
+	if len(targets) > 1 {
+		row := unison.NewPanel()
+		row.SetLayout(&unison.FlexLayout{
+			Columns:  2,
+			HSpacing: unison.StdHSpacing,
+		})
+		row.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+		row.AddChild(NewFieldLeadingLabel(i18n.Text("User"), false))
+		popup := unison.NewPopupMenu[*websettings.ShareTarget]()
+		popup.AddItem(targets...)
+		popup.Select(targets[0])
+		popup.SelectionChangedCallback = func(p *unison.PopupMenu[*websettings.ShareTarget]) {
+			if target, ok := p.Selected(); ok {
+				link, linkErr := linkFor(target)
+				if linkErr != nil {
+					errs.Log(linkErr)
+					return
+				}
+				current = link
+				update()
+			}
+		}
+		row.AddChild(popup)
+		panel.AddChild(row)
+	}
+	panel.AddChild(codePanel)
+
+	row := unison.NewPanel()
+	row.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+	})
+	row.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+	row.AddChild(urlLabel)
+	copyButton := unison.NewSVGButton(svg.Copy)
+	copyButton.Tooltip = newWrappedTooltip(i18n.Text("Copy to clipboard"))
+	copyButton.ClickCallback = func() {
+		unison.GlobalClipboard.SetText(current.url)
+	}
+	row.AddChild(copyButton)
+	panel.AddChild(row)
+	panel.AddChild(warningLabel)
+	update()
+
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{unison.NewOKButtonInfo()})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	dialog.RunModal()
+}
@@ -19,6 +19,7 @@ import (
 	"time"
 
 	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/jio"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox"
 	"github.com/richardwilkes/toolbox/errs"
@@ -27,6 +28,7 @@ import (
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
 	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/check"
 	"github.com/richardwilkes/unison/enums/paintstyle"
 	"github.com/richardwilkes/unison/printing"
 )
@@ -64,6 +66,10 @@ type Sheet struct {
 	targetMgr            *TargetMgr
 	undoMgr              *unison.UndoManager
 	toolbar              *unison.Panel
+	searchTracker        *searchTracker
+	playModeCheckBox     *unison.CheckBox
+	loadoutPopup         *unison.PopupMenu[loadoutChoice]
+	playModeButtons      []*unison.Button
 	scroll               *unison.ScrollPanel
 	entity               *gurps.Entity
 	hash                 uint64
@@ -76,10 +82,13 @@ type Sheet struct {
 	Traits               *PageList[*gurps.Trait]
 	Skills               *PageList[*gurps.Skill]
 	Spells               *PageList[*gurps.Spell]
+	Languages            *PageList[*gurps.Language]
 	CarriedEquipment     *PageList[*gurps.Equipment]
 	OtherEquipment       *PageList[*gurps.Equipment]
 	Notes                *PageList[*gurps.Note]
 	dragReroutePanel     *unison.Panel
+	archive              *gurps.CharacterArchive
+	editControls         []*unison.Panel
 	scale                int
 	awaitingUpdate       bool
 	needsSaveAsPrompt    bool
@@ -97,11 +106,11 @@ func ActiveSheet() *Sheet {
 	return nil
 }
 
-// OpenSheets returns the currently open sheets.
+// OpenSheets returns the currently open sheets that may be changed. Sheets opened from a character archive are omitted.
 func OpenSheets(exclude *Sheet) []*Sheet {
 	var sheets []*Sheet
 	for _, d := range AllDockables() {
-		if sheet, ok := d.(*Sheet); ok && sheet != exclude {
+		if sheet, ok := d.(*Sheet); ok && sheet != exclude && sheet.archive == nil {
 			sheets = append(sheets, sheet)
 		}
 	}
@@ -145,6 +154,9 @@ func NewSheet(filePath string, entity *gurps.Entity) *Sheet {
 	}
 	s.DataDragOverCallback = func(_ unison.Point, data map[string]any) bool {
 		s.dragReroutePanel = nil
+		if s.archive != nil {
+			return false
+		}
 		for _, key := range dropKeys {
 			if _, ok := data[key]; ok {
 				if s.dragReroutePanel = s.keyToPanel(key); s.dragReroutePanel != nil {
@@ -202,6 +214,7 @@ func NewSheet(filePath string, entity *gurps.Entity) *Sheet {
 	s.installNewItemCmdHandlers(NewTechniqueItemID, -1, s.Skills)
 	s.installNewItemCmdHandlers(NewSpellItemID, NewSpellContainerItemID, s.Spells)
 	s.installNewItemCmdHandlers(NewRitualMagicSpellItemID, -1, s.Spells)
+	s.installNewItemCmdHandlers(NewLanguageItemID, -1, s.Languages)
 	s.installNewItemCmdHandlers(NewCarriedEquipmentItemID, NewCarriedEquipmentContainerItemID,
 		s.CarriedEquipment)
 	s.installNewItemCmdHandlers(NewOtherEquipmentItemID, NewOtherEquipmentContainerItemID,
@@ -213,11 +226,39 @@ func NewSheet(filePath string, entity *gurps.Entity) *Sheet {
 				return s.Traits.provider.RootRows()
 			}, gurps.NewNaturalAttacks(s.entity, nil))
 	})
+	s.InstallCmdHandlers(AddReputationItemID, unison.AlwaysEnabled, func(_ any) {
+		if trait := buildReputation(s.entity); trait != nil {
+			InsertItems[*gurps.Trait](s, s.Traits.Table, s.entity.TraitList, s.entity.SetTraitList,
+				func(_ *unison.Table[*Node[*gurps.Trait]]) []*Node[*gurps.Trait] {
+					return s.Traits.provider.RootRows()
+				}, trait)
+		}
+	})
 	s.InstallCmdHandlers(SwapDefaultsItemID, s.canSwapDefaults, s.swapDefaults)
+	s.InstallCmdHandlers(BuyUpFromDefaultItemID, s.canBuyUpFromDefault, s.buyUpFromDefault)
+	s.InstallCmdHandlers(FindReplaceItemID, unison.AlwaysEnabled, func(_ any) { s.findReplace() })
+	s.InstallCmdHandlers(ValidateCharacterItemID, unison.AlwaysEnabled, func(_ any) { s.validateCharacter() })
+	s.InstallCmdHandlers(EditCulturesItemID, unison.AlwaysEnabled, func(_ any) { s.editCultures() })
+	s.InstallCmdHandlers(ToggleUnfamiliarCultureItemID, unison.AlwaysEnabled,
+		func(_ any) { s.toggleUnfamiliarCulture() })
+	s.InstallCmdHandlers(DeductCostOfLivingItemID, unison.AlwaysEnabled, func(_ any) { s.deductCostOfLiving() })
+	s.InstallCmdHandlers(CountCoinsItemID, func(_ any) bool { return len(s.entity.SheetSettings.Currencies) != 0 },
+		func(_ any) { s.countCoins() })
+	s.InstallCmdHandlers(PlanTimeUseItemID, unison.AlwaysEnabled, func(_ any) { s.planTimeUse() })
+	s.InstallCmdHandlers(StartNewEncounterItemID, s.canStartNewEncounter, s.startNewEncounter)
+	s.InstallCmdHandlers(TogglePlayModeItemID, unison.AlwaysEnabled, s.togglePlayMode)
+	s.InstallCmdHandlers(ReviewChangeRequestsItemID, s.canReviewChangeRequests, s.reviewChangeRequests)
+	s.InstallCmdHandlers(CompareWithSavedItemID, s.canCompareWithSaved, s.compareWithSaved)
+	s.InstallCmdHandlers(CompareWithFileItemID, unison.AlwaysEnabled, s.compareWithOtherFile)
+	s.InstallCmdHandlers(ShareAsQRCodeItemID, s.canShare, s.shareAsQRCode)
+	s.InstallCmdHandlers(ShareAsEmbedItemID, s.canShare, s.shareAsEmbed)
 	s.InstallCmdHandlers(ExportAsPDFItemID, unison.AlwaysEnabled, func(_ any) { s.exportToPDF() })
 	s.InstallCmdHandlers(ExportAsWEBPItemID, unison.AlwaysEnabled, func(_ any) { s.exportToWEBP() })
 	s.InstallCmdHandlers(ExportAsPNGItemID, unison.AlwaysEnabled, func(_ any) { s.exportToPNG() })
 	s.InstallCmdHandlers(ExportAsJPEGItemID, unison.AlwaysEnabled, func(_ any) { s.exportToJPEG() })
+	s.InstallCmdHandlers(ExportAsCardsItemID, func(_ any) bool { return len(s.selectedCards()) != 0 },
+		func(_ any) { s.exportCards() })
+	s.InstallCmdHandlers(ArchiveCharacterItemID, unison.AlwaysEnabled, func(_ any) { s.archiveCharacter() })
 	s.InstallCmdHandlers(PrintItemID, unison.AlwaysEnabled, func(_ any) { s.print() })
 	s.InstallCmdHandlers(ClearPortraitItemID, s.canClearPortrait, s.clearPortrait)
 	s.InstallCmdHandlers(ExportPortraitItemID, s.canExportPortrait, s.exportPortrait)
@@ -257,28 +298,70 @@ func (s *Sheet) createToolbar() {
 	sheetSettingsButton.Tooltip = newWrappedTooltip(i18n.Text("Sheet Settings"))
 	sheetSettingsButton.ClickCallback = func() { ShowSheetSettings(s) }
 	s.toolbar.AddChild(sheetSettingsButton)
+	s.editControls = append(s.editControls, sheetSettingsButton.AsPanel())
 
 	attributesButton := unison.NewSVGButton(svg.Attributes)
 	attributesButton.Tooltip = newWrappedTooltip(i18n.Text("Attributes"))
 	attributesButton.ClickCallback = func() { ShowAttributeSettings(s) }
 	s.toolbar.AddChild(attributesButton)
+	s.editControls = append(s.editControls, attributesButton.AsPanel())
 
 	bodyTypeButton := unison.NewSVGButton(svg.BodyType)
 	bodyTypeButton.Tooltip = newWrappedTooltip(i18n.Text("Body Type"))
 	bodyTypeButton.ClickCallback = func() { ShowBodySettings(s) }
 	s.toolbar.AddChild(bodyTypeButton)
+	s.editControls = append(s.editControls, bodyTypeButton.AsPanel())
 
 	syncSourceButton := unison.NewSVGButton(svg.DownToBracket)
 	syncSourceButton.Tooltip = newWrappedTooltip(i18n.Text("Sync with all sources in this sheet"))
 	syncSourceButton.ClickCallback = func() { s.syncWithAllSources() }
 	s.toolbar.AddChild(syncSourceButton)
+	s.editControls = append(s.editControls, syncSourceButton.AsPanel())
 
 	calcButton := unison.NewSVGButton(svg.Calculator)
 	calcButton.Tooltip = newWrappedTooltip(i18n.Text("Calculators (jumping, throwing, hiking, etc.)"))
 	calcButton.ClickCallback = func() { DisplayCalculator(s) }
 	s.toolbar.AddChild(calcButton)
 
-	installSearchTracker(s.toolbar, func() {
+	shoppingButton := unison.NewSVGButton(svg.Coins)
+	shoppingButton.Tooltip = newWrappedTooltip(i18n.Text("Shop for equipment from the libraries"))
+	shoppingButton.ClickCallback = func() { DisplayShopping(s) }
+	s.toolbar.AddChild(shoppingButton)
+	s.editControls = append(s.editControls, shoppingButton.AsPanel())
+
+	grimoireButton := unison.NewSVGButton(svg.GCSSpells)
+	grimoireButton.Tooltip = newWrappedTooltip(i18n.Text("Ritual Path Magic grimoire"))
+	grimoireButton.ClickCallback = func() { displayGrimoireEditor(s, s.entity) }
+	s.toolbar.AddChild(grimoireButton)
+	s.editControls = append(s.editControls, grimoireButton.AsPanel())
+
+	regenButton := unison.NewSVGButton(svg.Reset)
+	regenButton.Tooltip = newWrappedTooltip(i18n.Text("Apply pool regeneration (rest, meditation, etc.)"))
+	regenButton.ClickCallback = s.regeneratePools
+	s.toolbar.AddChild(regenButton)
+	s.editControls = append(s.editControls, regenButton.AsPanel())
+
+	s.toolbar.AddChild(s.createLoadoutPopup())
+	s.editControls = append(s.editControls, s.loadoutPopup.AsPanel())
+
+	s.playModeCheckBox = unison.NewCheckBox()
+	s.playModeCheckBox.SetTitle(i18n.Text("Play Mode"))
+	s.playModeCheckBox.Tooltip = newWrappedTooltip(i18n.Text("Track the shots remaining in ranged weapons"))
+	s.playModeCheckBox.ClickCallback = func() { s.togglePlayMode(nil) }
+	s.toolbar.AddChild(s.playModeCheckBox)
+	s.editControls = append(s.editControls, s.playModeCheckBox.AsPanel())
+	s.addPlayModeButton(i18n.Text("Fire"), i18n.Text("Fire the selected ranged weapons"), func() {
+		s.RangedWeapons.PerformCmd(nil, FireWeaponItemID)
+	})
+	s.addPlayModeButton(i18n.Text("Reload"), i18n.Text("Reload the selected ranged weapons"), func() {
+		s.RangedWeapons.PerformCmd(nil, ReloadWeaponItemID)
+	})
+	s.addPlayModeButton(i18n.Text("New Encounter"), i18n.Text("Fully reload all ranged weapons"), func() {
+		s.PerformCmd(nil, StartNewEncounterItemID)
+	})
+	s.syncPlayModeButtons()
+
+	s.searchTracker = installSearchTracker(s.toolbar, func() {
 		s.Reactions.Table.ClearSelection()
 		s.ConditionalModifiers.Table.ClearSelection()
 		s.MeleeWeapons.Table.ClearSelection()
@@ -286,6 +369,7 @@ func (s *Sheet) createToolbar() {
 		s.Traits.Table.ClearSelection()
 		s.Skills.Table.ClearSelection()
 		s.Spells.Table.ClearSelection()
+		s.Languages.Table.ClearSelection()
 		s.CarriedEquipment.Table.ClearSelection()
 		s.OtherEquipment.Table.ClearSelection()
 		s.Notes.Table.ClearSelection()
@@ -293,6 +377,7 @@ func (s *Sheet) createToolbar() {
 		searchSheetTable(refList, text, namesOnly, s.Traits)
 		searchSheetTable(refList, text, namesOnly, s.Skills)
 		searchSheetTable(refList, text, namesOnly, s.Spells)
+		searchSheetTable(refList, text, namesOnly, s.Languages)
 		searchSheetTable(refList, text, namesOnly, s.CarriedEquipment)
 		searchSheetTable(refList, text, namesOnly, s.OtherEquipment)
 		searchSheetTable(refList, text, namesOnly, s.Notes)
@@ -304,6 +389,27 @@ func (s *Sheet) createToolbar() {
 	})
 }
 
+func (s *Sheet) addPlayModeButton(title, tooltip string, clickCallback func()) {
+	b := unison.NewButton()
+	b.SetTitle(title)
+	b.Tooltip = newWrappedTooltip(tooltip)
+	b.ClickCallback = clickCallback
+	s.playModeButtons = append(s.playModeButtons, b)
+	s.toolbar.AddChild(b)
+}
+
+func (s *Sheet) syncPlayModeButtons() {
+	if s.entity.PlayMode {
+		s.playModeCheckBox.State = check.On
+	} else {
+		s.playModeCheckBox.State = check.Off
+	}
+	s.playModeCheckBox.MarkForRedraw()
+	for _, b := range s.playModeButtons {
+		b.SetEnabled(s.entity.PlayMode && s.archive == nil)
+	}
+}
+
 // DataOwner implements gurps.DataOwnerProvider.
 func (s *Sheet) DataOwner() gurps.DataOwner {
 	return s.entity
@@ -339,7 +445,7 @@ func (s *Sheet) canClearPortrait(_ any) bool {
 
 func (s *Sheet) clearPortrait(_ any) {
 	if s.canClearPortrait(nil) {
-		s.undoMgr.Add(&unison.UndoEdit[[]byte]{
+		addUndo(s.undoMgr, &unison.UndoEdit[[]byte]{
 			ID:         unison.NextUndoID(),
 			EditName:   clearPortraitAction.Title,
 			UndoFunc:   func(edit *unison.UndoEdit[[]byte]) { s.updatePortrait(edit.BeforeData) },
@@ -369,6 +475,8 @@ func (s *Sheet) keyToPanel(key string) *unison.Panel {
 		p = s.Spells.Table
 	case traitDragKey:
 		p = s.Traits.Table
+	case languageDragKey:
+		p = s.Languages.Table
 	case noteDragKey:
 		p = s.Notes.Table
 	default:
@@ -413,6 +521,9 @@ func (s *Sheet) TitleIcon(suggestedSize unison.Size) unison.Drawable {
 
 // Title implements workspace.FileBackedDockable
 func (s *Sheet) Title() string {
+	if s.archive != nil {
+		return fmt.Sprintf(i18n.Text("%s (Archived)"), fs.BaseName(s.archive.Path))
+	}
 	return fs.BaseName(s.BackingFilePath())
 }
 
@@ -422,6 +533,9 @@ func (s *Sheet) String() string {
 
 // Tooltip implements workspace.FileBackedDockable
 func (s *Sheet) Tooltip() string {
+	if s.archive != nil {
+		return s.archive.Path
+	}
 	return s.BackingFilePath()
 }
 
@@ -445,7 +559,17 @@ func (s *Sheet) SetBackingFilePath(p string) {
 
 // Modified implements workspace.FileBackedDockable
 func (s *Sheet) Modified() bool {
-	return s.hash != gurps.Hash64(s.entity)
+	return s.archive == nil && s.hash != gurps.Hash64(s.entity)
+}
+
+func (s *Sheet) writeRecoveryFile(filePath string) error {
+	return s.entity.Save(filePath)
+}
+
+func (s *Sheet) markRecovered(originalPath string) {
+	s.hash = 0
+	s.needsSaveAsPrompt = !fs.FileExists(originalPath)
+	s.SetBackingFilePath(originalPath)
 }
 
 // MarkModified implements widget.ModifiableRoot.
@@ -471,6 +595,7 @@ func (s *Sheet) MarkModified(src unison.Paneler) {
 		s.targetMgr.ReacquireFocus(focusRefKey, s.toolbar, s.scroll.Content())
 		s.scroll.SetPosition(h, v)
 		UpdateCalculator(s)
+		refreshSheetsLinkedTo(s)
 	}
 }
 
@@ -500,13 +625,17 @@ func (s *Sheet) AttemptClose() bool {
 
 func (s *Sheet) save(forceSaveAs bool) bool {
 	success := false
+	saver := func(path string) error {
+		s.entity.RecordPointsJournal(jio.Now())
+		return s.entity.Save(path)
+	}
 	if forceSaveAs || s.needsSaveAsPrompt {
-		success = SaveDockableAs(s, gurps.SheetExt, s.entity.Save, func(path string) {
+		success = SaveDockableAs(s, gurps.SheetExt, saver, func(path string) {
 			s.hash = gurps.Hash64(s.entity)
 			s.path = path
 		})
 	} else {
-		success = SaveDockable(s, s.entity.Save, func() { s.hash = gurps.Hash64(s.entity) })
+		success = SaveDockable(s, saver, func() { s.hash = gurps.Hash64(s.entity) })
 	}
 	if success {
 		s.needsSaveAsPrompt = false
@@ -554,6 +683,27 @@ func (s *Sheet) exportToPDF() {
 	}
 }
 
+func (s *Sheet) exportCards() {
+	cards := s.selectedCards()
+	if len(cards) == 0 {
+		return
+	}
+	s.Window().ShowCursor()
+	dialog := unison.NewSaveDialog()
+	backingFilePath := s.BackingFilePath()
+	dialog.SetInitialDirectory(filepath.Dir(backingFilePath))
+	dialog.SetAllowedExtensions("pdf")
+	dialog.SetInitialFileName(fs.SanitizeName(fs.BaseName(backingFilePath)) + i18n.Text(" Cards"))
+	if dialog.RunModal() {
+		if filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), "pdf", false); ok {
+			gurps.GlobalSettings().SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
+			if err := newCardExporter(s.entity, cards).exportAsPDFFile(filePath); err != nil {
+				unison.ErrorDialogWithError(i18n.Text("Unable to export cards!"), err)
+			}
+		}
+	}
+}
+
 func (s *Sheet) exportToWEBP() {
 	s.Window().ShowCursor()
 	dialog := unison.NewSaveDialog()
@@ -687,6 +837,13 @@ func (s *Sheet) createLists() {
 					s.Spells.Sync()
 				}
 				rowPanel.AddChild(s.Spells)
+			case gurps.BlockLayoutLanguagesKey:
+				if s.Languages.needReconstruction() {
+					s.Languages = NewLanguagesPageList(s, s.entity)
+				} else {
+					s.Languages.Sync()
+				}
+				rowPanel.AddChild(s.Languages)
 			case gurps.BlockLayoutEquipmentKey:
 				if s.CarriedEquipment.needReconstruction() {
 					s.CarriedEquipment = NewCarriedEquipmentPageList(s, s.entity)
@@ -768,7 +925,56 @@ func (s *Sheet) swapDefaults(_ any) {
 	}
 	s.Skills.Sync()
 	undo.AfterData = NewTableUndoEditData(s.Skills.Table)
-	s.UndoManager().Add(undo)
+	addUndo(s.UndoManager(), undo)
+}
+
+func (s *Sheet) canBuyUpFromDefault(_ any) bool {
+	for _, skillNode := range s.Skills.SelectedNodes(true) {
+		if skillNode.Data().CanBuyUpFromDefault() {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Sheet) buyUpFromDefault(_ any) {
+	undo := &unison.UndoEdit[*TableUndoEditData[*gurps.Skill]]{
+		ID:       unison.NextUndoID(),
+		EditName: buyUpFromDefaultAction.Title,
+		UndoFunc: func(e *unison.UndoEdit[*TableUndoEditData[*gurps.Skill]]) { e.BeforeData.Apply() },
+		RedoFunc: func(e *unison.UndoEdit[*TableUndoEditData[*gurps.Skill]]) { e.AfterData.Apply() },
+		AbsorbFunc: func(_ *unison.UndoEdit[*TableUndoEditData[*gurps.Skill]], _ unison.Undoable) bool {
+			return false
+		},
+		BeforeData: NewTableUndoEditData(s.Skills.Table),
+	}
+	changed := false
+	for _, skillNode := range s.Skills.SelectedNodes(true) {
+		if skillNode.Data().BuyUpFromDefault() {
+			changed = true
+		}
+	}
+	if changed {
+		s.Rebuild(true)
+		undo.AfterData = NewTableUndoEditData(s.Skills.Table)
+		addUndo(s.UndoManager(), undo)
+	}
+}
+
+func (s *Sheet) validateCharacter() {
+	report := gurps.NewValidationReport(s.entity)
+	s.Rebuild(true)
+	title := fmt.Sprintf(i18n.Text("Validation of %s"), s.entity.Profile.Name)
+	if report.Valid() {
+		if dialog, err := unison.NewDialog(nil, nil, unison.NewMessagePanel(title, report.String()),
+			[]*unison.DialogButtonInfo{unison.NewOKButtonInfo()}); err != nil {
+			errs.Log(err)
+		} else {
+			dialog.RunModal()
+		}
+		return
+	}
+	unison.WarningDialogWithMessage(title, report.String())
 }
 
 // SheetSettingsUpdated implements gurps.SheetSettingsResponder.
@@ -783,6 +989,7 @@ type sheetTablesUndoData struct {
 	traits           *TableUndoEditData[*gurps.Trait]
 	skills           *TableUndoEditData[*gurps.Skill]
 	spells           *TableUndoEditData[*gurps.Spell]
+	languages        *TableUndoEditData[*gurps.Language]
 	carriedEquipment *TableUndoEditData[*gurps.Equipment]
 	otherEquipment   *TableUndoEditData[*gurps.Equipment]
 	notes            *TableUndoEditData[*gurps.Note]
@@ -793,6 +1000,7 @@ func newSheetTablesUndoData(sheet *Sheet) *sheetTablesUndoData {
 		traits:           NewTableUndoEditData(sheet.Traits.Table),
 		skills:           NewTableUndoEditData(sheet.Skills.Table),
 		spells:           NewTableUndoEditData(sheet.Spells.Table),
+		languages:        NewTableUndoEditData(sheet.Languages.Table),
 		carriedEquipment: NewTableUndoEditData(sheet.CarriedEquipment.Table),
 		otherEquipment:   NewTableUndoEditData(sheet.OtherEquipment.Table),
 		notes:            NewTableUndoEditData(sheet.Notes.Table),
@@ -803,6 +1011,7 @@ func (s *sheetTablesUndoData) Apply() {
 	s.traits.Apply()
 	s.skills.Apply()
 	s.spells.Apply()
+	s.languages.Apply()
 	s.carriedEquipment.Apply()
 	s.otherEquipment.Apply()
 	s.notes.Apply()
@@ -830,7 +1039,7 @@ func (s *Sheet) syncWithAllSources() {
 	s.Notes.Table.SyncToModel()
 	if mgr != nil && undo != nil {
 		undo.AfterData = newSheetTablesUndoData(s)
-		mgr.Add(undo)
+		addUndo(mgr, undo)
 	}
 	s.Rebuild(true)
 }
@@ -839,6 +1048,9 @@ func (s *Sheet) syncWithAllSources() {
 func (s *Sheet) Rebuild(full bool) {
 	h, v := s.scroll.Position()
 	focusRefKey := s.targetMgr.CurrentFocusRef()
+	if full {
+		s.syncLinkedCharacters()
+	}
 	s.entity.Recalculate()
 	if full {
 		reactionsSelMap := s.Reactions.RecordSelection()
@@ -848,6 +1060,7 @@ func (s *Sheet) Rebuild(full bool) {
 		traitsSelMap := s.Traits.RecordSelection()
 		skillsSelMap := s.Skills.RecordSelection()
 		spellsSelMap := s.Spells.RecordSelection()
+		languagesSelMap := s.Languages.RecordSelection()
 		carriedEquipmentSelMap := s.CarriedEquipment.RecordSelection()
 		otherEquipmentSelMap := s.OtherEquipment.RecordSelection()
 		notesSelMap := s.Notes.RecordSelection()
@@ -859,6 +1072,7 @@ func (s *Sheet) Rebuild(full bool) {
 			s.Traits.ApplySelection(traitsSelMap)
 			s.Skills.ApplySelection(skillsSelMap)
 			s.Spells.ApplySelection(spellsSelMap)
+			s.Languages.ApplySelection(languagesSelMap)
 			s.CarriedEquipment.ApplySelection(carriedEquipmentSelMap)
 			s.OtherEquipment.ApplySelection(otherEquipmentSelMap)
 			s.Notes.ApplySelection(notesSelMap)
@@ -866,10 +1080,15 @@ func (s *Sheet) Rebuild(full bool) {
 		s.createLists()
 	}
 	DeepSync(s)
+	s.syncLoadoutPopup()
 	UpdateTitleForDockable(s)
 	s.targetMgr.ReacquireFocus(focusRefKey, s.toolbar, s.scroll.Content())
 	s.scroll.SetPosition(h, v)
 	UpdateCalculator(s)
+	UpdateShopping(s)
+	if s.archive != nil {
+		s.disableEditing()
+	}
 }
 
 func drawBandedBackground(p unison.Paneler, gc *unison.Canvas, rect unison.Rect, start, step int, overrideFunc func(rowIndex int, ink unison.Ink) unison.Ink) {
@@ -896,3 +1115,8 @@ func drawBandedBackground(p unison.Paneler, gc *unison.Canvas, rect unison.Rect,
 		gc.DrawRect(r, ink.Paint(gc, r, paintstyle.Fill))
 	}
 }
+
+// RevealItem implements itemRevealer.
+func (s *Sheet) RevealItem(name string) {
+	s.searchTracker.searchForName(name)
+}
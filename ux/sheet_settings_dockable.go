@@ -10,10 +10,14 @@
 package ux
 
 import (
+	"fmt"
 	"io/fs"
+	"strings"
 
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/control"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/defense"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/display"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/progression"
 	"github.com/richardwilkes/gcs/v5/model/paper"
@@ -37,6 +41,10 @@ type sheetSettingsDockable struct {
 	SettingsDockable
 	owner                              EntityPanel
 	damageProgressionPopup             *unison.PopupMenu[progression.Option]
+	controlRatingPopup                 *unison.PopupMenu[control.Rating]
+	defenseBonusRulePopup              *unison.PopupMenu[defense.BonusRule]
+	combatReflexesRulePopup            *unison.PopupMenu[defense.ReflexesRule]
+	crossParryRulePopup                *unison.PopupMenu[defense.CrossParryRule]
 	showTraitModifier                  *unison.CheckBox
 	showEquipmentModifier              *unison.CheckBox
 	showSpellAdjustments               *unison.CheckBox
@@ -46,6 +54,9 @@ type sheetSettingsDockable struct {
 	useModifyDicePlusAdds              *unison.CheckBox
 	excludeUnspentPointsFromTotal      *unison.CheckBox
 	useHalfStatDefaults                *unison.CheckBox
+	waiveCinematicSkillTraining        *unison.CheckBox
+	technicalGrappling                 *unison.CheckBox
+	actionPoints                       *unison.CheckBox
 	lengthUnitsPopup                   *unison.PopupMenu[fxp.LengthUnit]
 	weightUnitsPopup                   *unison.PopupMenu[fxp.WeightUnit]
 	userDescDisplayPopup               *unison.PopupMenu[display.Option]
@@ -59,6 +70,15 @@ type sheetSettingsDockable struct {
 	bottomMarginField                  *unison.Field
 	rightMarginField                   *unison.Field
 	blockLayoutField                   *unison.Field
+	currenciesField                    *unison.Field
+	disadvantageLimitField             *unison.Field
+	quirkLimitField                    *unison.Field
+	skillPointLimitField               *unison.Field
+	enforceLimits                      *unison.CheckBox
+	startingWealthField                *unison.Field
+	limitEquipmentToWealth             *unison.CheckBox
+	pointBudgetsField                  *unison.Field
+	derivedFieldsField                 *unison.Field
 }
 
 // ShowSheetSettings the Sheet Settings. Pass in nil to edit the defaults or a sheet to edit the sheet's.
@@ -111,11 +131,17 @@ func (d *sheetSettingsDockable) initContent(content *unison.Panel) {
 		VSpacing: unison.DefaultLabelTheme.Font.LineHeight(),
 	})
 	d.createDamageProgression(content)
+	d.createControlRating(content)
+	d.createCampaignLimits(content)
+	d.createPointBudgets(content)
+	d.createDefenses(content)
 	d.createOptions(content)
 	d.createUnitsOfMeasurement(content)
 	d.createWhereToDisplay(content)
 	d.createPageSettings(content)
 	d.createBlockLayout(content)
+	d.createCurrencies(content)
+	d.createDerivedFields(content)
 }
 
 func (d *sheetSettingsDockable) createDamageProgression(content *unison.Panel) {
@@ -142,6 +168,204 @@ func (d *sheetSettingsDockable) createDamageProgression(content *unison.Panel) {
 	content.AddChild(panel)
 }
 
+func (d *sheetSettingsDockable) createControlRating(content *unison.Panel) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	d.controlRatingPopup = createSettingPopup(d, panel, i18n.Text("Control Rating"), control.Ratings,
+		d.settings().ControlRating, func(item control.Rating) { d.settings().ControlRating = item })
+	d.controlRatingPopup.Tooltip = newWrappedTooltip(i18n.Text("Equipment with a Legality Class below the Control Rating will be flagged as illegal"))
+	content.AddChild(panel)
+}
+
+func (d *sheetSettingsDockable) createCampaignLimits(content *unison.Panel) {
+	s := d.settings()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	d.disadvantageLimitField = d.createLimitField(panel, i18n.Text("Disadvantage Limit"),
+		i18n.Text("The most points that may be taken in disadvantages, not counting quirks"), s.DisadvantageLimit,
+		func(value fxp.Int) { d.settings().DisadvantageLimit = value })
+	d.quirkLimitField = d.createLimitField(panel, i18n.Text("Quirk Limit"),
+		i18n.Text("The most points that may be taken in quirks"), s.QuirkLimit,
+		func(value fxp.Int) { d.settings().QuirkLimit = value })
+	d.skillPointLimitField = d.createLimitField(panel, i18n.Text("Skill Point Limit"),
+		i18n.Text("The most points that may be spent on any one skill or spell"), s.SkillPointLimit,
+		func(value fxp.Int) { d.settings().SkillPointLimit = value })
+	text := i18n.Text("Starting Wealth by TL")
+	tooltip := i18n.Text("The starting wealth for each tech level, separated by commas and beginning with TL0. Leave empty to use the standard amounts.")
+	label := NewFieldLeadingLabel(text, false)
+	label.Tooltip = newWrappedTooltip(tooltip)
+	panel.AddChild(label)
+	d.startingWealthField = unison.NewField()
+	d.startingWealthField.Watermark = gurps.StartingWealthByTLToString(gurps.DefaultStartingWealthByTL())
+	d.startingWealthField.Tooltip = newWrappedTooltip(tooltip)
+	d.startingWealthField.SetText(gurps.StartingWealthByTLToString(s.StartingWealthByTL))
+	d.startingWealthField.ValidateCallback = func() bool {
+		_, valid := gurps.NewStartingWealthByTLFromString(d.startingWealthField.Text())
+		return valid
+	}
+	d.startingWealthField.ModifiedCallback = func(_, after *unison.FieldState) {
+		if table, valid := gurps.NewStartingWealthByTLFromString(after.Text); valid {
+			d.settings().StartingWealthByTL = table
+			d.syncSheet(false)
+		}
+	}
+	d.startingWealthField.SetMinimumTextWidthUsing(d.startingWealthField.Watermark)
+	panel.AddChild(d.startingWealthField)
+	panel.AddChild(unison.NewPanel())
+	d.limitEquipmentToWealth = d.addCheckBox(panel, i18n.Text("Equipment may not cost more than the starting wealth"),
+		s.LimitEquipmentToWealth, func() {
+			d.settings().LimitEquipmentToWealth = d.limitEquipmentToWealth.State == check.On
+			d.syncSheet(false)
+		})
+	panel.AddChild(unison.NewPanel())
+	d.enforceLimits = d.addCheckBox(panel, i18n.Text("Prevent additions that exceed these limits"), s.EnforceLimits,
+		func() {
+			d.settings().EnforceLimits = d.enforceLimits.State == check.On
+			d.syncSheet(false)
+		})
+	content.AddChild(panel)
+}
+
+func (d *sheetSettingsDockable) createPointBudgets(content *unison.Panel) {
+	s := d.settings()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Fill})
+	label := unison.NewLabel()
+	desc := label.Font.Descriptor()
+	desc.Weight = weight.Bold
+	label.Font = desc.Font()
+	label.SetTitle(i18n.Text("Point Budgets"))
+	panel.AddChild(label)
+	panel.AddChild(newSettingDescription(fmt.Sprintf(i18n.Text(`One bound per line, giving a category, either "<=" or ">=" and a number of points, e.g. "attributes <= 100" or "skills >= 40". Disadvantages and quirks are given as positive amounts. The categories are: %s.`),
+		strings.Join(gurps.PointBudgetCategories, ", "))))
+	d.pointBudgetsField = unison.NewMultiLineField()
+	lastBudgets := gurps.PointBudgetsToString(s.PointBudgets)
+	d.pointBudgetsField.SetText(lastBudgets)
+	d.pointBudgetsField.ValidateCallback = func() bool {
+		_, valid := gurps.NewPointBudgetsFromString(d.pointBudgetsField.Text())
+		return valid
+	}
+	d.pointBudgetsField.ModifiedCallback = func(_, after *unison.FieldState) {
+		if budgets, valid := gurps.NewPointBudgetsFromString(after.Text); valid {
+			currentBudgets := gurps.PointBudgetsToString(budgets)
+			if lastBudgets != currentBudgets {
+				lastBudgets = currentBudgets
+				d.settings().PointBudgets = budgets
+				d.syncSheet(false)
+			}
+		}
+	}
+	d.pointBudgetsField.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(d.pointBudgetsField)
+	content.AddChild(panel)
+}
+
+func (d *sheetSettingsDockable) createLimitField(panel *unison.Panel, title, tooltip string, current fxp.Int, set func(value fxp.Int)) *unison.Field {
+	label := NewFieldLeadingLabel(title, false)
+	label.Tooltip = newWrappedTooltip(tooltip)
+	panel.AddChild(label)
+	field := unison.NewField()
+	field.Watermark = i18n.Text("None")
+	field.Tooltip = newWrappedTooltip(tooltip)
+	field.SetText(limitText(current))
+	field.ValidateCallback = func() bool {
+		_, valid := parseLimit(field.Text())
+		return valid
+	}
+	field.ModifiedCallback = func(_, after *unison.FieldState) {
+		if value, valid := parseLimit(after.Text); valid {
+			set(value)
+			d.syncSheet(false)
+		}
+	}
+	field.SetMinimumTextWidthUsing("99,999")
+	panel.AddChild(field)
+	return field
+}
+
+func limitText(limit fxp.Int) string {
+	if limit <= 0 {
+		return ""
+	}
+	return limit.String()
+}
+
+func parseLimit(text string) (fxp.Int, bool) {
+	if text = strings.TrimSpace(text); text == "" {
+		return 0, true
+	}
+	value, err := fxp.FromString(text)
+	return value, err == nil && value >= 0
+}
+
+func (d *sheetSettingsDockable) createDefenses(content *unison.Panel) {
+	s := d.settings()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	desc := newSettingDescription(s.DefenseBonusRule.AltString())
+	d.defenseBonusRulePopup = createSettingPopup(d, panel, i18n.Text("Defense Bonus (DB) Applies To"),
+		defense.BonusRules, s.DefenseBonusRule, func(item defense.BonusRule) {
+			d.settings().DefenseBonusRule = item
+			updateSettingDescription(desc, item.AltString())
+		})
+	d.defenseBonusRulePopup.Tooltip = newWrappedTooltip(i18n.Text("Determines which active defenses the bonuses to Dodge, Parry and Block provided by equipment, such as a shield's DB, apply to"))
+	panel.AddChild(unison.NewPanel())
+	panel.AddChild(desc)
+	desc = newSettingDescription(s.CombatReflexesRule.AltString())
+	d.combatReflexesRulePopup = createSettingPopup(d, panel, gurps.CombatReflexesTraitName,
+		defense.ReflexesRules, s.CombatReflexesRule, func(item defense.ReflexesRule) {
+			d.settings().CombatReflexesRule = item
+			updateSettingDescription(desc, item.AltString())
+		})
+	d.combatReflexesRulePopup.Tooltip = newWrappedTooltip(fmt.Sprintf(i18n.Text("Determines whether the %s bonus to active defenses stacks with the bonus from %s, %s and %s"),
+		gurps.CombatReflexesTraitName, gurps.EnhancedDodgeTraitName, gurps.EnhancedParryTraitName,
+		gurps.EnhancedBlockTraitName))
+	panel.AddChild(unison.NewPanel())
+	panel.AddChild(desc)
+	desc = newSettingDescription(s.CrossParryRule.AltString())
+	d.crossParryRulePopup = createSettingPopup(d, panel, i18n.Text("Cross Parry"), defense.CrossParryRules,
+		s.CrossParryRule, func(item defense.CrossParryRule) {
+			d.settings().CrossParryRule = item
+			updateSettingDescription(desc, item.AltString())
+		})
+	d.crossParryRulePopup.Tooltip = newWrappedTooltip(i18n.Text("Determines how the Parry for parrying with two ready weapons at once is calculated"))
+	panel.AddChild(unison.NewPanel())
+	panel.AddChild(desc)
+	content.AddChild(panel)
+}
+
+func newSettingDescription(text string) *unison.Markdown {
+	desc := unison.NewMarkdown(true)
+	desc.SetContent(text, -1)
+	return desc
+}
+
+func updateSettingDescription(desc *unison.Markdown, text string) {
+	desc.SetContent(text, -1)
+	desc.MarkForLayoutRecursivelyUpward()
+	desc.MarkForRedraw()
+}
+
 func (d *sheetSettingsDockable) createOptions(content *unison.Panel) {
 	s := d.settings()
 	panel := unison.NewPanel()
@@ -185,6 +409,27 @@ func (d *sheetSettingsDockable) createOptions(content *unison.Panel) {
 			d.settings().UseHalfStatDefaults = d.useHalfStatDefaults.State == check.On
 			d.syncSheet(false)
 		})
+	d.waiveCinematicSkillTraining = d.addCheckBox(panel,
+		fmt.Sprintf(i18n.Text("Allow cinematic skills without %s or %s"), gurps.TrainedByAMasterTraitName,
+			gurps.WeaponMasterTraitName), s.WaiveCinematicSkillTraining, func() {
+			d.settings().WaiveCinematicSkillTraining = d.waiveCinematicSkillTraining.State == check.On
+			d.syncSheet(false)
+		})
+	d.technicalGrappling = d.addCheckBoxWithLink(panel, i18n.Text("Use Technical Grappling control points and Trained ST"),
+		"TG5", s.TechnicalGrappling, func() {
+			d.settings().TechnicalGrappling = d.technicalGrappling.State == check.On
+			d.syncSheet(false)
+		})
+	d.actionPoints = d.addCheckBox(panel, i18n.Text("Track Action Points (Pyramid 3/44)"),
+		s.Attributes.ActionPointsEnabled(), func() {
+			enabled := d.actionPoints.State == check.On
+			if d.owner != nil {
+				d.owner.Entity().SetActionPointsEnabled(enabled)
+			} else {
+				d.settings().Attributes.SetActionPointsEnabled(enabled)
+			}
+			d.syncSheet(true)
+		})
 	d.useModifyDicePlusAdds = d.addCheckBoxWithLink(panel, i18n.Text("Use Modifying Dice + Adds"), "B269",
 		s.UseModifyingDicePlusAdds, func() {
 			d.settings().UseModifyingDicePlusAdds = d.useModifyDicePlusAdds.State == check.On
@@ -334,6 +579,88 @@ func (d *sheetSettingsDockable) createBlockLayout(content *unison.Panel) {
 	content.AddChild(panel)
 }
 
+func (d *sheetSettingsDockable) createCurrencies(content *unison.Panel) {
+	s := d.settings()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Fill})
+	label := unison.NewLabel()
+	desc := label.Font.Descriptor()
+	desc.Weight = weight.Bold
+	label.Font = desc.Font()
+	label.SetTitle(i18n.Text("Currencies"))
+	panel.AddChild(label)
+	panel.AddChild(newSettingDescription(i18n.Text(`One denomination per line, giving its name, its value in $ and, optionally, the weight of a single coin, e.g. "Gold Piece = 20, 0.02 lb". Coins held are counted toward encumbrance, and equipment costs show their value in each denomination.`)))
+	d.currenciesField = unison.NewMultiLineField()
+	lastCurrencies := gurps.CurrenciesToString(s.Currencies)
+	d.currenciesField.SetText(lastCurrencies)
+	d.currenciesField.ValidateCallback = func() bool {
+		_, valid := gurps.NewCurrenciesFromString(d.currenciesField.Text())
+		return valid
+	}
+	d.currenciesField.ModifiedCallback = func(_, after *unison.FieldState) {
+		if currencies, valid := gurps.NewCurrenciesFromString(after.Text); valid {
+			currentCurrencies := gurps.CurrenciesToString(currencies)
+			if lastCurrencies != currentCurrencies {
+				lastCurrencies = currentCurrencies
+				d.settings().Currencies = currencies
+				d.syncSheet(false)
+			}
+		}
+	}
+	d.currenciesField.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(d.currenciesField)
+	content.AddChild(panel)
+}
+
+func (d *sheetSettingsDockable) createDerivedFields(content *unison.Panel) {
+	s := d.settings()
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Fill})
+	label := unison.NewLabel()
+	desc := label.Font.Descriptor()
+	desc.Weight = weight.Bold
+	label.Font = desc.Font()
+	label.SetTitle(i18n.Text("Derived Fields"))
+	panel.AddChild(label)
+	panel.AddChild(newSettingDescription(i18n.Text(`One field per line, giving its name and the formula used to calculate it, e.g. "Effective Dodge = dodge() + 1". Formulas may use attributes, such as $st, and any of the functions available elsewhere, such as enc(false). Derived fields are shown on the sheet and are available to export templates.`)))
+	d.derivedFieldsField = unison.NewMultiLineField()
+	lastFields := gurps.DerivedFieldsToString(s.DerivedFields)
+	d.derivedFieldsField.SetText(lastFields)
+	d.derivedFieldsField.ValidateCallback = func() bool {
+		_, valid := gurps.NewDerivedFieldsFromString(d.derivedFieldsField.Text())
+		return valid
+	}
+	d.derivedFieldsField.ModifiedCallback = func(_, after *unison.FieldState) {
+		if fields, valid := gurps.NewDerivedFieldsFromString(after.Text); valid {
+			currentFields := gurps.DerivedFieldsToString(fields)
+			if lastFields != currentFields {
+				lastFields = currentFields
+				d.settings().DerivedFields = fields
+				d.syncSheet(false)
+			}
+		}
+	}
+	d.derivedFieldsField.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(d.derivedFieldsField)
+	content.AddChild(panel)
+}
+
 func (d *sheetSettingsDockable) createPaperMarginField(panel *unison.Panel, title string, current paper.Length, set func(value paper.Length)) *unison.Field {
 	panel.AddChild(NewFieldLeadingLabel(title, false))
 	field := unison.NewField()
@@ -403,6 +730,10 @@ func (d *sheetSettingsDockable) reset() {
 func (d *sheetSettingsDockable) sync() {
 	s := d.settings()
 	d.damageProgressionPopup.Select(s.DamageProgression)
+	d.controlRatingPopup.Select(s.ControlRating)
+	d.defenseBonusRulePopup.Select(s.DefenseBonusRule)
+	d.combatReflexesRulePopup.Select(s.CombatReflexesRule)
+	d.crossParryRulePopup.Select(s.CrossParryRule)
 	d.hideSourceMismatch.State = check.FromBool(!s.HideSourceMismatch)
 	d.showTraitModifier.State = check.FromBool(s.ShowTraitModifierAdj)
 	d.showEquipmentModifier.State = check.FromBool(s.ShowEquipmentModifierAdj)
@@ -410,6 +741,9 @@ func (d *sheetSettingsDockable) sync() {
 	d.showTitleInsteadOfNameInPageFooter.State = check.FromBool(s.UseTitleInFooter)
 	d.useMultiplicativeModifiers.State = check.FromBool(s.UseMultiplicativeModifiers)
 	d.useHalfStatDefaults.State = check.FromBool(s.UseHalfStatDefaults)
+	d.waiveCinematicSkillTraining.State = check.FromBool(s.WaiveCinematicSkillTraining)
+	d.technicalGrappling.State = check.FromBool(s.TechnicalGrappling)
+	d.actionPoints.State = check.FromBool(s.Attributes.ActionPointsEnabled())
 	d.useModifyDicePlusAdds.State = check.FromBool(s.UseModifyingDicePlusAdds)
 	d.excludeUnspentPointsFromTotal.State = check.FromBool(s.ExcludeUnspentPointsFromTotal)
 	d.lengthUnitsPopup.Select(s.DefaultLengthUnits)
@@ -425,6 +759,15 @@ func (d *sheetSettingsDockable) sync() {
 	d.bottomMarginField.SetText(s.Page.BottomMargin.String())
 	d.rightMarginField.SetText(s.Page.RightMargin.String())
 	d.blockLayoutField.SetText(s.BlockLayout.String())
+	d.currenciesField.SetText(gurps.CurrenciesToString(s.Currencies))
+	d.disadvantageLimitField.SetText(limitText(s.DisadvantageLimit))
+	d.quirkLimitField.SetText(limitText(s.QuirkLimit))
+	d.skillPointLimitField.SetText(limitText(s.SkillPointLimit))
+	d.enforceLimits.State = check.FromBool(s.EnforceLimits)
+	d.startingWealthField.SetText(gurps.StartingWealthByTLToString(s.StartingWealthByTL))
+	d.limitEquipmentToWealth.State = check.FromBool(s.LimitEquipmentToWealth)
+	d.pointBudgetsField.SetText(gurps.PointBudgetsToString(s.PointBudgets))
+	d.derivedFieldsField.SetText(gurps.DerivedFieldsToString(s.DerivedFields))
 	d.MarkForRedraw()
 }
 
@@ -0,0 +1,415 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+var (
+	_ unison.Dockable = &ShoppingDockable{}
+	_ GroupedCloser   = &ShoppingDockable{}
+)
+
+// ShoppingDockable browses the equipment libraries and accumulates a cart of purchases for a sheet.
+type ShoppingDockable struct {
+	unison.Panel
+	sheet          *Sheet
+	cart           *gurps.ShoppingCart
+	stock          []*gurps.LibraryEquipment
+	filterField    *unison.Field
+	availableOnly  *unison.CheckBox
+	stockList      *unison.List[*shoppingStockItem]
+	cartList       *unison.List[*shoppingCartItem]
+	addButton      *unison.Button
+	removeButton   *unison.Button
+	purchaseButton *unison.Button
+	fundsLabel     *unison.Label
+	totalLabel     *unison.Label
+	remainingLabel *unison.Label
+}
+
+type shoppingStockItem struct {
+	item *gurps.LibraryEquipment
+}
+
+func (s *shoppingStockItem) String() string {
+	return shoppingItemText(s.item.Equipment, s.item.Equipment.AdjustedValue())
+}
+
+type shoppingCartItem struct {
+	eqp *gurps.Equipment
+}
+
+func (s *shoppingCartItem) String() string {
+	return s.eqp.Quantity.Comma() + " × " + shoppingItemText(s.eqp, s.eqp.ExtendedValue())
+}
+
+func shoppingItemText(eqp *gurps.Equipment, value fxp.Int) string {
+	var buffer strings.Builder
+	buffer.WriteString(eqp.String())
+	buffer.WriteString("  $")
+	buffer.WriteString(value.Comma())
+	if tl := strings.TrimSpace(eqp.TechLevel); tl != "" {
+		buffer.WriteString(", TL")
+		buffer.WriteString(tl)
+	}
+	if lc := strings.TrimSpace(eqp.LegalityClass); lc != "" {
+		buffer.WriteString(", LC")
+		buffer.WriteString(lc)
+	}
+	return buffer.String()
+}
+
+type shoppingUndoState struct {
+	funds     fxp.Int
+	coins     map[string]int
+	equipment []*gurps.Equipment
+}
+
+// DisplayShopping displays the shopping dockable for the given Sheet.
+func DisplayShopping(sheet *Sheet) {
+	if Activate(func(d unison.Dockable) bool {
+		if s, ok := d.AsPanel().Self.(*ShoppingDockable); ok {
+			return s.sheet == sheet
+		}
+		return false
+	}) {
+		return
+	}
+	d := &ShoppingDockable{
+		sheet: sheet,
+		cart:  gurps.NewShoppingCart(sheet.Entity()),
+		stock: gurps.LoadLibraryEquipment(gurps.GlobalSettings().Libraries()),
+	}
+	d.Self = d
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+	d.AddChild(d.createToolbar())
+	d.AddChild(d.createContent())
+	d.ClientData()[AssociatedIDKey] = sheet.Entity().ID
+	d.applyFilter()
+	d.sync()
+	group := dgroup.Editors
+	p := sheet.AsPanel()
+	for p != nil {
+		if _, exists := p.ClientData()[AssociatedIDKey]; exists {
+			group = dgroup.SubEditors
+			break
+		}
+		p = p.Parent()
+	}
+	PlaceInDock(d, group, false)
+	d.filterField.RequestFocus()
+}
+
+func (d *ShoppingDockable) createToolbar() *unison.Panel {
+	toolbar := unison.NewPanel()
+	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	d.filterField = NewSearchField(i18n.Text("Filter"), func(_, _ *unison.FieldState) { d.applyFilter() })
+	d.filterField.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.AddChild(d.filterField)
+	d.availableOnly = unison.NewCheckBox()
+	d.availableOnly.SetTitle(i18n.Text("Available Only"))
+	d.availableOnly.Tooltip = newWrappedTooltip(i18n.Text("Hide equipment above the character's tech level or with a Legality Class below the Control Rating set in the sheet settings"))
+	d.availableOnly.State = check.On
+	d.availableOnly.ClickCallback = d.applyFilter
+	toolbar.AddChild(d.availableOnly)
+	toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	return toolbar
+}
+
+func (d *ShoppingDockable) createContent() *unison.Panel {
+	content := unison.NewPanel()
+	content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	content.SetLayout(&unison.FlexLayout{
+		Columns:      2,
+		HSpacing:     unison.StdHSpacing * 2,
+		VSpacing:     unison.StdVSpacing,
+		EqualColumns: true,
+	})
+	content.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+
+	content.AddChild(newShoppingHeader(i18n.Text("Library Equipment")))
+	content.AddChild(newShoppingHeader(i18n.Text("Cart")))
+
+	d.stockList = unison.NewList[*shoppingStockItem]()
+	d.stockList.DoubleClickCallback = d.addSelectionToCart
+	d.stockList.NewSelectionCallback = d.adjustButtons
+	content.AddChild(newShoppingListScroller(d.stockList))
+	d.cartList = unison.NewList[*shoppingCartItem]()
+	d.cartList.NewSelectionCallback = d.adjustButtons
+	d.cartList.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, repeat bool) bool {
+		if keyCode == unison.KeyDelete || keyCode == unison.KeyBackspace {
+			d.removeSelectionFromCart()
+			return true
+		}
+		return d.cartList.DefaultKeyDown(keyCode, mod, repeat)
+	}
+	content.AddChild(newShoppingListScroller(d.cartList))
+
+	d.addButton = unison.NewButton()
+	d.addButton.SetTitle(i18n.Text("Add to Cart"))
+	d.addButton.ClickCallback = d.addSelectionToCart
+	d.addButton.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+	content.AddChild(d.addButton)
+	d.removeButton = unison.NewButton()
+	d.removeButton.SetTitle(i18n.Text("Remove from Cart"))
+	d.removeButton.ClickCallback = d.removeSelectionFromCart
+	d.removeButton.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+	content.AddChild(d.removeButton)
+
+	content.AddChild(unison.NewPanel())
+	totals := unison.NewPanel()
+	totals.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	totals.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Middle})
+	d.fundsLabel = addShoppingTotal(totals, i18n.Text("Funds"))
+	d.totalLabel = addShoppingTotal(totals, i18n.Text("Cart Total"))
+	d.remainingLabel = addShoppingTotal(totals, i18n.Text("Remaining"))
+	d.purchaseButton = unison.NewButton()
+	d.purchaseButton.SetTitle(i18n.Text("Purchase"))
+	d.purchaseButton.Tooltip = newWrappedTooltip(i18n.Text("Add the items in the cart to the carried equipment and deduct their cost from the funds"))
+	d.purchaseButton.ClickCallback = d.purchase
+	d.purchaseButton.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Middle,
+	})
+	totals.AddChild(d.purchaseButton)
+	content.AddChild(totals)
+	return content
+}
+
+func newShoppingHeader(title string) *unison.Label {
+	label := unison.NewLabel()
+	label.SetTitle(title)
+	label.Font = unison.SystemFont
+	return label
+}
+
+func newShoppingListScroller[T any](list *unison.List[T]) *unison.ScrollPanel {
+	list.BackgroundInk = unison.ThemeSurface
+	scroller := unison.NewScrollPanel()
+	scroller.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	scroller.SetContent(list, behavior.Fill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(300, 200),
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	return scroller
+}
+
+func addShoppingTotal(panel *unison.Panel, title string) *unison.Label {
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	label := unison.NewLabel()
+	label.HAlign = align.End
+	label.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Fill})
+	panel.AddChild(label)
+	return label
+}
+
+func (d *ShoppingDockable) applyFilter() {
+	text := strings.ToLower(strings.TrimSpace(d.filterField.Text()))
+	availableOnly := d.availableOnly.State == check.On
+	entity := d.sheet.Entity()
+	d.stockList.Clear()
+	for _, one := range d.stock {
+		if availableOnly && !entity.EquipmentAvailable(one.Equipment) {
+			continue
+		}
+		if text != "" && !strings.Contains(strings.ToLower(one.Equipment.String()), text) {
+			continue
+		}
+		d.stockList.Append(&shoppingStockItem{item: one})
+	}
+	d.stockList.Pack()
+	d.stockList.MarkForLayoutRecursivelyUpward()
+	d.adjustButtons()
+}
+
+func (d *ShoppingDockable) addSelectionToCart() {
+	for i := d.stockList.Selection.FirstSet(); i != -1; i = d.stockList.Selection.NextSet(i + 1) {
+		d.cart.Add(d.stockList.DataAtIndex(i).item)
+	}
+	d.sync()
+}
+
+func (d *ShoppingDockable) removeSelectionFromCart() {
+	for i := d.cartList.Selection.LastSet(); i != -1; i = d.cartList.Selection.PreviousSet(i - 1) {
+		d.cart.Remove(i)
+		if i == 0 {
+			break
+		}
+	}
+	d.sync()
+}
+
+func (d *ShoppingDockable) purchase() {
+	entity := d.sheet.Entity()
+	before := &shoppingUndoState{
+		funds:     entity.Funds,
+		coins:     maps.Clone(entity.Coins),
+		equipment: slices.Clone(entity.CarriedEquipment),
+	}
+	if err := d.cart.Purchase(); err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to complete the purchase"), err)
+		return
+	}
+	after := &shoppingUndoState{
+		funds:     entity.Funds,
+		coins:     maps.Clone(entity.Coins),
+		equipment: slices.Clone(entity.CarriedEquipment),
+	}
+	sheet := d.sheet
+	apply := func(state *shoppingUndoState) {
+		entity.SetCarriedEquipmentList(slices.Clone(state.equipment))
+		entity.Funds = state.funds
+		entity.Coins = maps.Clone(state.coins)
+		sheet.MarkModified(sheet)
+		sheet.Rebuild(true)
+	}
+	addUndo(sheet.undoMgr, &unison.UndoEdit[*shoppingUndoState]{
+		ID:         unison.NextUndoID(),
+		EditName:   i18n.Text("Purchase Equipment"),
+		UndoFunc:   func(edit *unison.UndoEdit[*shoppingUndoState]) { apply(edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[*shoppingUndoState]) { apply(edit.AfterData) },
+		BeforeData: before,
+		AfterData:  after,
+	})
+	sheet.MarkModified(sheet)
+	sheet.Rebuild(true)
+}
+
+// UpdateShopping refreshes the funds and availability shown in the shopping dockable for the given sheet, if one is
+// open.
+func UpdateShopping(sheet *Sheet) {
+	for _, other := range AllDockables() {
+		d, ok := other.(*ShoppingDockable)
+		if !ok || d.sheet != sheet {
+			continue
+		}
+		d.applyFilter()
+		d.sync()
+		break
+	}
+}
+
+func (d *ShoppingDockable) sync() {
+	d.cartList.Clear()
+	for _, one := range d.cart.Items {
+		d.cartList.Append(&shoppingCartItem{eqp: one})
+	}
+	d.cartList.Pack()
+	d.cartList.MarkForLayoutRecursivelyUpward()
+	d.fundsLabel.SetTitle("$" + d.cart.Entity.AvailableFunds().Comma())
+	d.totalLabel.SetTitle("$" + d.cart.Total().Comma())
+	remaining := d.cart.Remaining()
+	d.remainingLabel.SetTitle("$" + remaining.Comma())
+	if remaining < 0 {
+		d.remainingLabel.OnBackgroundInk = unison.ThemeError
+	} else {
+		d.remainingLabel.OnBackgroundInk = unison.DefaultLabelTheme.OnBackgroundInk
+	}
+	d.adjustButtons()
+	d.MarkForLayoutAndRedraw()
+}
+
+func (d *ShoppingDockable) adjustButtons() {
+	if d.addButton == nil {
+		return
+	}
+	d.addButton.SetEnabled(d.stockList.Selection.Count() != 0)
+	d.removeButton.SetEnabled(d.cartList.Selection.Count() != 0)
+	d.purchaseButton.SetEnabled(len(d.cart.Items) != 0 && d.cart.Remaining() >= 0)
+}
+
+// TitleIcon implements unison.Dockable
+func (d *ShoppingDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Coins,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *ShoppingDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Shopping for %s"), d.sheet.String())
+}
+
+func (d *ShoppingDockable) String() string {
+	return d.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (d *ShoppingDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (d *ShoppingDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (d *ShoppingDockable) CloseWithGroup(other unison.Paneler) bool {
+	return d.sheet != nil && d.sheet == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (d *ShoppingDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(d)
+}
+
+// AttemptClose implements GroupedCloser
+func (d *ShoppingDockable) AttemptClose() bool {
+	if !CloseGroup(d) {
+		return false
+	}
+	if len(d.cart.Items) != 0 {
+		if unison.QuestionDialog(i18n.Text("Discard the items in the cart?"), "") != unison.ModalResponseOK {
+			return false
+		}
+	}
+	return AttemptCloseForDockable(d)
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/unison"
+)
+
+type adjustShotsListUndoEdit = *unison.UndoEdit[*adjustShotsList]
+
+type adjustShotsList struct {
+	Owner Rebuildable
+	List  []*shotsAdjuster
+}
+
+func (a *adjustShotsList) Apply() {
+	for _, one := range a.List {
+		one.Apply()
+	}
+	MarkModified(a.Owner)
+}
+
+type shotsAdjuster struct {
+	Target    *gurps.Weapon
+	ShotsUsed fxp.Int
+}
+
+func newShotsAdjuster(target *gurps.Weapon) *shotsAdjuster {
+	return &shotsAdjuster{
+		Target:    target,
+		ShotsUsed: target.ShotsUsed,
+	}
+}
+
+func (a *shotsAdjuster) Apply() {
+	a.Target.ShotsUsed = a.ShotsUsed
+}
+
+func canFireWeapon(table *unison.Table[*Node[*gurps.Weapon]]) bool {
+	for _, row := range table.SelectedRows(false) {
+		if w := row.Data(); w != nil && w.CanFire() {
+			return true
+		}
+	}
+	return false
+}
+
+func fireWeapon(owner Rebuildable, table *unison.Table[*Node[*gurps.Weapon]]) {
+	adjustShots(owner, table, fireWeaponAction.Title, (*gurps.Weapon).CanFire, (*gurps.Weapon).Fire)
+}
+
+func canReloadWeapon(table *unison.Table[*Node[*gurps.Weapon]]) bool {
+	for _, row := range table.SelectedRows(false) {
+		if w := row.Data(); w != nil && w.CanReload() {
+			return true
+		}
+	}
+	return false
+}
+
+func reloadWeapon(owner Rebuildable, table *unison.Table[*Node[*gurps.Weapon]]) {
+	adjustShots(owner, table, reloadWeaponAction.Title, (*gurps.Weapon).CanReload,
+		func(w *gurps.Weapon) { w.Reload() })
+}
+
+func adjustShots(owner Rebuildable, table *unison.Table[*Node[*gurps.Weapon]], name string, can func(*gurps.Weapon) bool, adjust func(*gurps.Weapon)) {
+	before := &adjustShotsList{Owner: owner}
+	after := &adjustShotsList{Owner: owner}
+	for _, row := range table.SelectedRows(false) {
+		if w := row.Data(); w != nil && can(w) {
+			before.List = append(before.List, newShotsAdjuster(w))
+			adjust(w)
+			after.List = append(after.List, newShotsAdjuster(w))
+		}
+	}
+	if len(before.List) > 0 {
+		if mgr := unison.UndoManagerFor(table); mgr != nil {
+			addUndo(mgr, &unison.UndoEdit[*adjustShotsList]{
+				ID:         unison.NextUndoID(),
+				EditName:   name,
+				UndoFunc:   func(edit adjustShotsListUndoEdit) { edit.BeforeData.Apply() },
+				RedoFunc:   func(edit adjustShotsListUndoEdit) { edit.AfterData.Apply() },
+				BeforeData: before,
+				AfterData:  after,
+			})
+		}
+		MarkModified(before.Owner)
+	}
+}
+
+func (s *Sheet) canStartNewEncounter(_ any) bool {
+	for _, w := range s.entity.EquippedWeapons(false) {
+		if w.ShotsUsed != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Sheet) startNewEncounter(_ any) {
+	before := &adjustShotsList{Owner: s}
+	for _, w := range s.entity.EquippedWeapons(false) {
+		if w.ShotsUsed != 0 {
+			before.List = append(before.List, newShotsAdjuster(w))
+		}
+	}
+	if !s.entity.ResetShots() {
+		return
+	}
+	after := &adjustShotsList{Owner: s}
+	for _, one := range before.List {
+		after.List = append(after.List, newShotsAdjuster(one.Target))
+	}
+	addUndo(s.undoMgr, &unison.UndoEdit[*adjustShotsList]{
+		ID:         unison.NextUndoID(),
+		EditName:   startNewEncounterAction.Title,
+		UndoFunc:   func(edit adjustShotsListUndoEdit) { edit.BeforeData.Apply() },
+		RedoFunc:   func(edit adjustShotsListUndoEdit) { edit.AfterData.Apply() },
+		BeforeData: before,
+		AfterData:  after,
+	})
+	MarkModified(s)
+}
+
+func (s *Sheet) togglePlayMode(_ any) {
+	addUndo(s.undoMgr, &unison.UndoEdit[bool]{
+		ID:         unison.NextUndoID(),
+		EditName:   togglePlayModeAction.Title,
+		UndoFunc:   func(edit *unison.UndoEdit[bool]) { s.updatePlayMode(edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[bool]) { s.updatePlayMode(edit.AfterData) },
+		BeforeData: s.entity.PlayMode,
+		AfterData:  !s.entity.PlayMode,
+	})
+	s.updatePlayMode(!s.entity.PlayMode)
+}
+
+func (s *Sheet) updatePlayMode(on bool) {
+	s.entity.PlayMode = on
+	s.syncPlayModeButtons()
+	s.MarkModified(s)
+	s.Rebuild(true)
+}
@@ -10,6 +10,10 @@
 package ux
 
 import (
+	"fmt"
+	"slices"
+	"strings"
+
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/difficulty"
@@ -34,8 +38,17 @@ func initSkillEditor(e *editor[*gurps.Skill, *gurps.SkillEditData], content *uni
 	addNameLabelAndField(content, &e.editorData.Name)
 	if !e.target.Container() && !e.target.IsTechnique() {
 		addSpecializationLabelAndField(content, &e.editorData.Specialization)
+		content.AddChild(unison.NewPanel())
+		addCheckBox(content, i18n.Text("A specialization must be chosen"), &e.editorData.SpecializationRequired)
+		addLabelAndListField(content, i18n.Text("Specialization Choices"), i18n.Text("specializations"),
+			&e.editorData.SpecializationChoices)
 		addTechLevelRequired(content, &e.editorData.TechLevel, ownerIsSheet)
 	}
+	if !e.target.Container() {
+		content.AddChild(unison.NewPanel())
+		addCheckBox(content, fmt.Sprintf(i18n.Text("Cinematic (requires %s or %s)"), gurps.TrainedByAMasterTraitName,
+			gurps.WeaponMasterTraitName), &e.editorData.Cinematic)
+	}
 	addNotesLabelAndField(content, &e.editorData.LocalNotes)
 	addVTTNotesLabelAndField(content, &e.editorData.VTTNotes)
 	addTagsLabelAndField(content, &e.editorData.Tags)
@@ -122,6 +135,9 @@ func initSkillEditor(e *editor[*gurps.Skill, *gurps.SkillEditData], content *uni
 				}))
 			adjustFieldBlank(limitField, e.editorData.TechniqueLimitModifier == nil)
 			wrapper2.AddChild(limitField)
+			content.AddChild(unison.NewPanel())
+			addCheckBox(content, i18n.Text("Combination (uses the worst default, with a penalty per attack)"),
+				&e.editorData.Combination)
 			difficultyPopup := addLabelAndPopup(content, i18n.Text("Difficulty"), "", difficulty.TechniqueLevels,
 				&e.editorData.Difficulty.Difficulty)
 			difficultyPopup.SelectionChangedCallback = func(popup *unison.PopupMenu[difficulty.Level]) {
@@ -139,6 +155,13 @@ func initSkillEditor(e *editor[*gurps.Skill, *gurps.SkillEditData], content *uni
 			}
 		} else {
 			addDifficultyLabelAndFields(content, entity, &e.editorData.Difficulty)
+			content.AddChild(NewFieldLeadingLabel(i18n.Text("Cost Progression"), false))
+			content.AddChild(NewNonEditableField(func(field *NonEditableField) {
+				field.SetTitle(skillCostProgressionText(e.editorData.Difficulty.Difficulty, e.editorData.Name))
+				field.MarkForLayoutAndRedraw()
+			}))
+			addLabelAndListField(content, i18n.Text("Wildcard Covers"), i18n.Text("skill names"),
+				&e.editorData.WildcardCovers)
 			encLabel := i18n.Text("Encumbrance Penalty")
 			wrapper := addFlowWrapper(content, encLabel, 2)
 			addDecimalField(wrapper, nil, "", encLabel, "", &e.editorData.EncumbrancePenaltyMultiplier, 0, fxp.Nine)
@@ -157,9 +180,9 @@ func initSkillEditor(e *editor[*gurps.Skill, *gurps.SkillEditData], content *uni
 					localSpec, e.editorData.Tags, nil)
 				var level gurps.Level
 				if e.target.IsTechnique() {
-					level = gurps.CalculateTechniqueLevel(entity, e.target.NameableReplacements(), localName, localSpec,
-						e.editorData.Tags, e.editorData.TechniqueDefault, e.editorData.Difficulty.Difficulty, points,
-						true, e.editorData.TechniqueLimitModifier, nil)
+					level = gurps.CalculateTechniqueLevelFromDefaults(entity, e.target.NameableReplacements(), localName,
+						localSpec, e.editorData.Tags, e.editorData.TechniqueDefaults(), e.editorData.Combination,
+						e.editorData.Difficulty.Difficulty, points, true, e.editorData.TechniqueLimitModifier, nil)
 				} else {
 					level = gurps.CalculateSkillLevel(entity, localName, localSpec, e.editorData.Tags,
 						e.editorData.DefaultedFrom, e.editorData.Difficulty, points,
@@ -184,6 +207,42 @@ func initSkillEditor(e *editor[*gurps.Skill, *gurps.SkillEditData], content *uni
 					insets.Right, 0),
 			})
 			wrapper.AddChild(levelField)
+			if !e.target.IsTechnique() && e.target.BestAvailableDefault() != nil {
+				content.AddChild(NewFieldLeadingLabel(i18n.Text("Best Default"), false))
+				content.AddChild(NewNonEditableField(func(field *NonEditableField) {
+					text := e.target.BestAvailableDefaultText()
+					if cost := e.target.BuyUpFromDefaultCost(); cost > 0 {
+						text += fmt.Sprintf(i18n.Text("; buying up from default costs %s pts for the next level"),
+							cost.String())
+					}
+					field.SetTitle(text)
+					field.MarkForLayoutAndRedraw()
+				}))
+			}
+			if chains := e.target.DefaultChains(); len(chains) != 0 {
+				content.AddChild(NewFieldLeadingLabel(i18n.Text("Use Default"), false))
+				popup := unison.NewPopupMenu[string]()
+				popup.AddItem(i18n.Text("Automatic"))
+				selected := 0
+				for i, chain := range chains {
+					popup.AddItem(fmt.Sprintf(i18n.Text("%s at level %s"), chain.Text, chain.Level.Trunc().String()))
+					if chain.Matches(e.editorData.PinnedDefault) {
+						selected = i + 1
+					}
+				}
+				popup.SelectIndex(selected)
+				popup.SelectionChangedCallback = func(p *unison.PopupMenu[string]) {
+					if i := p.SelectedIndex(); i == 0 {
+						e.editorData.PinnedDefault = nil
+						MarkModified(content)
+					} else if i > 0 && i <= len(chains) {
+						e.editorData.PinnedDefault = slices.Clone(chains[i-1].Refs)
+						MarkModified(content)
+					}
+				}
+				popup.Tooltip = newWrappedTooltip(i18n.Text("The default to use for this skill, rather than automatically picking the best one available"))
+				content.AddChild(popup)
+			}
 		}
 	}
 	addPageRefLabelAndField(content, &e.editorData.PageRef)
@@ -192,6 +251,10 @@ func initSkillEditor(e *editor[*gurps.Skill, *gurps.SkillEditData], content *uni
 	if !e.target.Container() {
 		content.AddChild(newPrereqPanel(entity, &e.editorData.Prereq))
 		content.AddChild(newDefaultsPanel(entity, &e.editorData.Defaults))
+		if e.target.IsTechnique() {
+			content.AddChild(newTitledDefaultsPanel(entity, i18n.Text("Alternate Technique Defaults"),
+				&e.editorData.TechniqueAltDefaults))
+		}
 		content.AddChild(newFeaturesPanel(entity, e.target, &e.editorData.Features, false))
 		content.AddChild(newWeaponsPanel(e, e.target, true, &e.editorData.Weapons))
 		content.AddChild(newWeaponsPanel(e, e.target, false, &e.editorData.Weapons))
@@ -199,3 +262,14 @@ func initSkillEditor(e *editor[*gurps.Skill, *gurps.SkillEditData], content *uni
 	}
 	return nil
 }
+
+func skillCostProgressionText(diff difficulty.Level, name string) string {
+	if diff == difficulty.Wildcard {
+		return i18n.Text("Wildcard: 3, 6, 12, then +12 points per level (triple the cost of a Very Hard skill)")
+	}
+	text := i18n.Text("1, 2, 4, then +4 points per level")
+	if strings.HasSuffix(strings.TrimSpace(name), "!") {
+		text += i18n.Text("; the name ends with \"!\", so the difficulty should probably be Wildcard")
+	}
+	return text
+}
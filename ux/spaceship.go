@@ -0,0 +1,547 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/shipsys"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/xio/fs"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+var (
+	_ FileBackedDockable         = &Spaceship{}
+	_ unison.UndoManagerProvider = &Spaceship{}
+	_ ModifiableRoot             = &Spaceship{}
+	_ unison.TabCloser           = &Spaceship{}
+)
+
+// Spaceship holds the view for a GURPS Spaceships design.
+type Spaceship struct {
+	unison.Panel
+	path              string
+	targetMgr         *TargetMgr
+	undoMgr           *unison.UndoManager
+	toolbar           *unison.Panel
+	scroll            *unison.ScrollPanel
+	crew              *unison.Panel
+	ship              *gurps.Spaceship
+	hash              uint64
+	scale             int
+	needsSaveAsPrompt bool
+}
+
+// NewSpaceshipFromFile loads a GURPS spaceship file and creates a new unison.Dockable for it.
+func NewSpaceshipFromFile(filePath string) (unison.Dockable, error) {
+	ship, err := gurps.NewSpaceshipFromFile(os.DirFS(filepath.Dir(filePath)), filepath.Base(filePath))
+	if err != nil {
+		return nil, err
+	}
+	s := NewSpaceship(filePath, ship)
+	s.needsSaveAsPrompt = false
+	return s, nil
+}
+
+// NewSpaceship creates a new unison.Dockable for GURPS spaceship files.
+func NewSpaceship(filePath string, ship *gurps.Spaceship) *Spaceship {
+	s := &Spaceship{
+		path:              filePath,
+		undoMgr:           unison.NewUndoManager(200, func(err error) { errs.Log(err) }),
+		scroll:            unison.NewScrollPanel(),
+		ship:              ship,
+		hash:              gurps.Hash64(ship),
+		scale:             gurps.GlobalSettings().General.InitialEditorUIScale,
+		needsSaveAsPrompt: true,
+	}
+	s.Self = s
+	s.targetMgr = NewTargetMgr(s)
+	s.SetLayout(&unison.FlexLayout{
+		Columns: 1,
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+	})
+	s.MouseDownCallback = func(_ unison.Point, _, _ int, _ unison.Modifiers) bool {
+		s.RequestFocus()
+		return false
+	}
+	s.scroll.SetContent(s.createContent(), behavior.Unmodified, behavior.Unmodified)
+	s.scroll.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Fill,
+		HGrab:  true,
+		VGrab:  true,
+	})
+	s.createToolbar()
+	s.AddChild(s.scroll)
+	s.InstallCmdHandlers(SaveItemID, func(_ any) bool { return s.Modified() }, func(_ any) { s.save(false) })
+	s.InstallCmdHandlers(SaveAsItemID, unison.AlwaysEnabled, func(_ any) { s.save(true) })
+	return s
+}
+
+func (s *Spaceship) createToolbar() {
+	s.toolbar = unison.NewPanel()
+	s.toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
+		false), unison.NewEmptyBorder(unison.StdInsets())))
+	s.toolbar.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	s.toolbar.AddChild(NewDefaultInfoPop())
+	s.toolbar.AddChild(
+		NewScaleField(
+			gurps.InitialUIScaleMin,
+			gurps.InitialUIScaleMax,
+			func() int { return gurps.GlobalSettings().General.InitialEditorUIScale },
+			func() int { return s.scale },
+			func(scale int) { s.scale = scale },
+			nil,
+			false,
+			s.scroll,
+		),
+	)
+	s.toolbar.SetLayout(&unison.FlexLayout{
+		Columns:  len(s.toolbar.Children()),
+		HSpacing: unison.StdHSpacing,
+	})
+	s.AddChild(s.toolbar)
+}
+
+func (s *Spaceship) createContent() unison.Paneler {
+	content := unison.NewPanel()
+	content.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	content.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing * 4,
+	})
+	content.AddChild(s.createIdentityPanel())
+	content.AddChild(s.createHullPanel())
+	content.AddChild(s.createPerformancePanel())
+	s.crew = unison.NewPanel()
+	s.crew.SetLayout(&unison.FlexLayout{
+		Columns:  5,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	s.crew.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	content.AddChild(s.crew)
+	s.rebuildCrew()
+	notes := NewMultiLineStringField(s.targetMgr, "notes", i18n.Text("Notes"),
+		func() string { return s.ship.Notes },
+		func(value string) { s.ship.Notes = value })
+	notes.Watermark = i18n.Text("Notes")
+	content.AddChild(notes)
+	return content
+}
+
+func (s *Spaceship) createIdentityPanel() *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  4,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	title := i18n.Text("Name")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	panel.AddChild(NewStringField(s.targetMgr, "name", title,
+		func() string { return s.ship.Name },
+		func(value string) { s.ship.Name = value }))
+	title = i18n.Text("Class")
+	panel.AddChild(NewFieldInteriorLeadingLabel(title, false))
+	panel.AddChild(NewStringField(s.targetMgr, "class", title,
+		func() string { return s.ship.Class },
+		func(value string) { s.ship.Class = value }))
+	title = i18n.Text("Tech Level")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	tlField := NewStringField(s.targetMgr, "tl", title,
+		func() string { return s.ship.TechLevel },
+		func(value string) { s.ship.TechLevel = value })
+	tlField.SetMinimumTextWidthUsing("12^")
+	tlField.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Start})
+	panel.AddChild(tlField)
+	title = i18n.Text("SM")
+	panel.AddChild(NewFieldInteriorLeadingLabel(title, false))
+	sizes := make([]int, 0, gurps.SpaceshipMaxSM-gurps.SpaceshipMinSM+1)
+	for sm := gurps.SpaceshipMinSM; sm <= gurps.SpaceshipMaxSM; sm++ {
+		sizes = append(sizes, sm)
+	}
+	wrapper := unison.NewPanel()
+	wrapper.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing * 2,
+	})
+	wrapper.AddChild(NewPopup[int](s.targetMgr, "sm", title,
+		func() int { return s.ship.SM },
+		func(value int) { s.ship.SM = value }, sizes...))
+	wrapper.AddChild(NewCheckBox(s.targetMgr, "streamlined", i18n.Text("Streamlined"),
+		func() check.Enum { return check.FromBool(s.ship.Streamlined) },
+		func(value check.Enum) { s.ship.Streamlined = value == check.On }))
+	panel.AddChild(wrapper)
+	return panel
+}
+
+func (s *Spaceship) createHullPanel() *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:      3,
+		HSpacing:     unison.StdHSpacing * 4,
+		EqualColumns: true,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(s.createHullSection("front", i18n.Text("Front Hull"), s.ship.Front[:], nil))
+	panel.AddChild(s.createHullSection("central", i18n.Text("Central Hull"), s.ship.Central[:], &s.ship.Core))
+	panel.AddChild(s.createHullSection("rear", i18n.Text("Rear Hull"), s.ship.Rear[:], nil))
+	return panel
+}
+
+func (s *Spaceship) createHullSection(key, title string, slots []gurps.SpaceshipSystem, core *gurps.SpaceshipSystem) *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Start,
+		HGrab:  true,
+	})
+	label := unison.NewLabel()
+	label.SetTitle(title)
+	label.Font = unison.SystemFont
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: 3})
+	panel.AddChild(label)
+	for i := range slots {
+		s.addHullSlot(panel, fmt.Sprintf("%s.%d", key, i), "["+strconv.Itoa(i+1)+"]", &slots[i])
+	}
+	if core != nil {
+		s.addHullSlot(panel, key+".core", i18n.Text("[core]"), core)
+	}
+	return panel
+}
+
+func (s *Spaceship) addHullSlot(panel *unison.Panel, key, location string, slot *gurps.SpaceshipSystem) {
+	panel.AddChild(NewFieldLeadingLabel(location, false))
+	panel.AddChild(NewPopup[shipsys.System](s.targetMgr, key+".system", location,
+		func() shipsys.System { return slot.System },
+		func(value shipsys.System) { slot.System = value }, shipsys.Systems...))
+	field := NewStringField(s.targetMgr, key+".desc", location,
+		func() string { return slot.Description },
+		func(value string) { slot.Description = value })
+	field.Watermark = i18n.Text("Description")
+	field.SetMinimumTextWidthUsing("Spinal Battery")
+	panel.AddChild(field)
+}
+
+func (s *Spaceship) createPerformancePanel() *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  10,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	s.addPerformanceField(panel, i18n.Text("Loaded Weight"), func(p gurps.SpaceshipPerformance) string {
+		return fmt.Sprintf(i18n.Text("%s tons"), fxp.From(p.LoadedWeight).Comma())
+	})
+	s.addPerformanceField(panel, i18n.Text("dST/HP"), func(p gurps.SpaceshipPerformance) string {
+		return strconv.Itoa(p.Structure)
+	})
+	s.addPerformanceField(panel, i18n.Text("Length"), func(p gurps.SpaceshipPerformance) string {
+		return fmt.Sprintf(i18n.Text("%d yards"), p.Length)
+	})
+	s.addPerformanceField(panel, i18n.Text("Move"), func(p gurps.SpaceshipPerformance) string {
+		deltaV := i18n.Text("∞")
+		if !p.UnlimitedDeltaV {
+			deltaV = p.DeltaV.String()
+		}
+		return fmt.Sprintf(i18n.Text("%sG/%s mps"), p.Acceleration.String(), deltaV)
+	})
+	s.addPerformanceField(panel, i18n.Text("Air Speed"), func(p gurps.SpaceshipPerformance) string {
+		if p.AirSpeed == 0 {
+			return "—"
+		}
+		return fmt.Sprintf(i18n.Text("%s mph"), p.AirSpeed.Comma())
+	})
+	return panel
+}
+
+func (s *Spaceship) addPerformanceField(panel *unison.Panel, title string, value func(p gurps.SpaceshipPerformance) string) {
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	panel.AddChild(NewNonEditableField(func(f *NonEditableField) {
+		f.SetTitle(value(s.ship.Performance()))
+	}))
+}
+
+func (s *Spaceship) rebuildCrew() {
+	s.crew.RemoveAllChildren()
+	label := unison.NewLabel()
+	label.SetTitle(i18n.Text("Crew Stations"))
+	label.Font = unison.SystemFont
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: 4})
+	s.crew.AddChild(label)
+	addButton := unison.NewSVGButton(svg.CircledAdd)
+	addButton.Tooltip = newWrappedTooltip(i18n.Text("Add Crew Station"))
+	addButton.ClickCallback = func() {
+		s.changeCrew(i18n.Text("Add Crew Station"), func() {
+			s.ship.Crew = append(s.ship.Crew, &gurps.CrewStation{})
+		})
+	}
+	addButton.SetLayoutData(&unison.FlexLayoutData{HAlign: align.End})
+	s.crew.AddChild(addButton)
+	for i, station := range s.ship.Crew {
+		s.addCrewStation(i, station)
+	}
+	MarkForLayoutWithinDockable(s.crew)
+}
+
+func (s *Spaceship) addCrewStation(index int, station *gurps.CrewStation) {
+	key := "crew." + strconv.Itoa(index)
+	title := i18n.Text("Role")
+	role := NewStringField(s.targetMgr, key+".role", title,
+		func() string { return station.Role },
+		func(value string) { station.Role = value })
+	role.Watermark = title
+	role.SetMinimumTextWidthUsing("Chief Engineer")
+	role.SetLayoutData(&unison.FlexLayoutData{HAlign: align.Fill})
+	s.crew.AddChild(role)
+
+	character := NewNonEditableField(func(f *NonEditableField) {
+		f.SetTitle(s.crewCharacterName(station))
+	})
+	character.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		VAlign: align.Middle,
+		HGrab:  true,
+	})
+	s.crew.AddChild(character)
+
+	linkButton := unison.NewSVGButton(svg.Link)
+	linkButton.Tooltip = newWrappedTooltip(i18n.Text("Assign a character to this station"))
+	linkButton.ClickCallback = func() { s.assignCharacter(station) }
+	s.crew.AddChild(linkButton)
+
+	openButton := unison.NewSVGButton(svg.GCSSheet)
+	openButton.Tooltip = newWrappedTooltip(i18n.Text("Open the character assigned to this station"))
+	openButton.ClickCallback = func() {
+		if p := station.CharacterPath(s.path); p != "" {
+			OpenFile(p, 0)
+		}
+	}
+	openButton.SetEnabled(station.Character != "")
+	s.crew.AddChild(openButton)
+
+	deleteButton := unison.NewSVGButton(svg.Trash)
+	deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove this crew station"))
+	deleteButton.ClickCallback = func() {
+		s.changeCrew(i18n.Text("Remove Crew Station"), func() {
+			s.ship.Crew = slices.Delete(s.ship.Crew, index, index+1)
+		})
+	}
+	s.crew.AddChild(deleteButton)
+}
+
+func (s *Spaceship) crewCharacterName(station *gurps.CrewStation) string {
+	p := station.CharacterPath(s.path)
+	if p == "" {
+		return i18n.Text("Unassigned")
+	}
+	if d, ok := LocateFileBackedDockable(p).(*Sheet); ok {
+		return d.entity.Profile.Name
+	}
+	entity, err := gurps.NewEntityFromFile(os.DirFS(filepath.Dir(p)), filepath.Base(p))
+	if err != nil {
+		return fmt.Sprintf(i18n.Text("%s (missing)"), fs.BaseName(p))
+	}
+	return entity.Profile.Name
+}
+
+func (s *Spaceship) assignCharacter(station *gurps.CrewStation) {
+	dialog := unison.NewOpenDialog()
+	dialog.SetAllowsMultipleSelection(false)
+	dialog.SetResolvesAliases(true)
+	dialog.SetAllowedExtensions(gurps.SheetExt)
+	dialog.SetCanChooseDirectories(false)
+	dialog.SetCanChooseFiles(true)
+	global := gurps.GlobalSettings()
+	dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+	if !dialog.RunModal() {
+		return
+	}
+	p := dialog.Path()
+	global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(p))
+	s.changeCrew(i18n.Text("Assign Character"), func() { station.SetCharacterPath(s.path, p) })
+}
+
+func (s *Spaceship) changeCrew(name string, f func()) {
+	before := cloneCrew(s.ship.Crew)
+	f()
+	addUndo(s.undoMgr, &unison.UndoEdit[[]*gurps.CrewStation]{
+		ID:         unison.NextUndoID(),
+		EditName:   name,
+		UndoFunc:   func(edit *unison.UndoEdit[[]*gurps.CrewStation]) { s.applyCrew(edit.BeforeData) },
+		RedoFunc:   func(edit *unison.UndoEdit[[]*gurps.CrewStation]) { s.applyCrew(edit.AfterData) },
+		BeforeData: before,
+		AfterData:  cloneCrew(s.ship.Crew),
+	})
+	s.rebuildCrew()
+	s.MarkModified(s)
+}
+
+func (s *Spaceship) applyCrew(crew []*gurps.CrewStation) {
+	s.ship.Crew = cloneCrew(crew)
+	s.rebuildCrew()
+	s.MarkModified(s)
+}
+
+func cloneCrew(crew []*gurps.CrewStation) []*gurps.CrewStation {
+	list := make([]*gurps.CrewStation, len(crew))
+	for i, one := range crew {
+		station := *one
+		list[i] = &station
+	}
+	return list
+}
+
+// UndoManager implements undo.Provider
+func (s *Spaceship) UndoManager() *unison.UndoManager {
+	return s.undoMgr
+}
+
+// TitleIcon implements workspace.FileBackedDockable
+func (s *Spaceship) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  gurps.FileInfoFor(s.path).SVG,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements workspace.FileBackedDockable
+func (s *Spaceship) Title() string {
+	return fs.BaseName(s.path)
+}
+
+func (s *Spaceship) String() string {
+	return s.Title()
+}
+
+// Tooltip implements workspace.FileBackedDockable
+func (s *Spaceship) Tooltip() string {
+	return s.path
+}
+
+// BackingFilePath implements workspace.FileBackedDockable
+func (s *Spaceship) BackingFilePath() string {
+	return s.path
+}
+
+// SetBackingFilePath implements workspace.FileBackedDockable
+func (s *Spaceship) SetBackingFilePath(p string) {
+	s.path = p
+	UpdateTitleForDockable(s)
+}
+
+// Modified implements workspace.FileBackedDockable
+func (s *Spaceship) Modified() bool {
+	return s.hash != gurps.Hash64(s.ship)
+}
+
+func (s *Spaceship) writeRecoveryFile(filePath string) error {
+	return s.ship.Save(filePath)
+}
+
+func (s *Spaceship) markRecovered(originalPath string) {
+	s.hash = 0
+	s.needsSaveAsPrompt = !fs.FileExists(originalPath)
+	s.SetBackingFilePath(originalPath)
+}
+
+// MarkModified implements widget.ModifiableRoot.
+func (s *Spaceship) MarkModified(_ unison.Paneler) {
+	DeepSync(s)
+	UpdateTitleForDockable(s)
+}
+
+// MayAttemptClose implements unison.TabCloser
+func (s *Spaceship) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(s)
+}
+
+// AttemptClose implements unison.TabCloser
+func (s *Spaceship) AttemptClose() bool {
+	if !CloseGroup(s) {
+		return false
+	}
+	if s.Modified() {
+		switch unison.YesNoCancelDialog(fmt.Sprintf(i18n.Text("Save changes made to\n%s?"), s.Title()), "") {
+		case unison.ModalResponseDiscard:
+		case unison.ModalResponseOK:
+			if !s.save(false) {
+				return false
+			}
+		case unison.ModalResponseCancel:
+			return false
+		}
+	}
+	return AttemptCloseForDockable(s)
+}
+
+func (s *Spaceship) save(forceSaveAs bool) bool {
+	oldPath := s.path
+	success := false
+	if forceSaveAs || s.needsSaveAsPrompt {
+		success = SaveDockableAs(s, gurps.SpaceshipExt, s.saveTo, func(path string) {
+			s.hash = gurps.Hash64(s.ship)
+			s.path = path
+		})
+	} else {
+		success = SaveDockable(s, s.ship.Save, func() { s.hash = gurps.Hash64(s.ship) })
+	}
+	if success {
+		s.needsSaveAsPrompt = false
+		if oldPath != s.path {
+			s.rebuildCrew()
+		}
+	}
+	return success
+}
+
+// saveTo writes the spaceship to a new location, first re-anchoring any relative links to crew characters so that
+// they continue to refer to the same files.
+func (s *Spaceship) saveTo(filePath string) error {
+	for _, station := range s.ship.Crew {
+		if p := station.CharacterPath(s.path); p != "" {
+			station.SetCharacterPath(filePath, p)
+		}
+	}
+	return s.ship.Save(filePath)
+}
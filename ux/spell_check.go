@@ -0,0 +1,187 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+)
+
+const maxSpellingSuggestions = 8
+
+var (
+	spellChecker           *gurps.SpellChecker
+	spellCheckerLanguage   string
+	spellCheckerLoaded     bool
+	spellCheckerGeneration int
+)
+
+type fieldSpelling struct {
+	checker      *gurps.SpellChecker
+	generation   int
+	text         string
+	misspellings []*gurps.Misspelling
+}
+
+// currentSpellChecker returns the spell checker for the language chosen in the general settings, or nil if spell
+// checking is off or there are no dictionaries for that language.
+func currentSpellChecker() *gurps.SpellChecker {
+	s := gurps.GlobalSettings()
+	if !spellCheckerLoaded || spellCheckerLanguage != s.General.SpellCheckLanguage {
+		spellCheckerLanguage = s.General.SpellCheckLanguage
+		spellChecker = gurps.NewSpellChecker(spellCheckerLanguage, s.Libraries(), s.PersonalDictionary)
+		spellCheckerLoaded = true
+		spellCheckerGeneration++
+	}
+	return spellChecker
+}
+
+// resetSpellChecker causes the dictionaries to be reloaded the next time they are needed and redraws the windows so
+// that the fields pick up the change.
+func resetSpellChecker() {
+	spellCheckerLoaded = false
+	redrawSpelling()
+}
+
+func redrawSpelling() {
+	for _, wnd := range unison.Windows() {
+		wnd.Content().MarkForRedraw()
+	}
+}
+
+func learnSpelling(word string) {
+	if gurps.GlobalSettings().LearnSpelling(word) {
+		if checker := currentSpellChecker(); checker != nil {
+			checker.AddWords(word)
+			spellCheckerGeneration++
+		}
+		redrawSpelling()
+	}
+}
+
+func (f *StringField) installSpellChecking() {
+	f.spelling = &fieldSpelling{}
+	f.DrawCallback = f.drawWithSpelling
+	f.MouseDownCallback = f.mouseDownWithSpelling
+}
+
+func (f *StringField) currentMisspellings() []*gurps.Misspelling {
+	checker := currentSpellChecker()
+	if checker == nil {
+		return nil
+	}
+	text := f.Text()
+	if f.spelling.checker != checker || f.spelling.generation != spellCheckerGeneration || f.spelling.text != text {
+		f.spelling.checker = checker
+		f.spelling.generation = spellCheckerGeneration
+		f.spelling.text = text
+		f.spelling.misspellings = checker.Misspellings(text)
+	}
+	return f.spelling.misspellings
+}
+
+func (f *StringField) drawWithSpelling(gc *unison.Canvas, rect unison.Rect) {
+	f.DefaultDraw(gc, rect)
+	if !f.Enabled() {
+		return
+	}
+	list := f.currentMisspellings()
+	if len(list) == 0 {
+		return
+	}
+	paint := unison.ThemeError.Paint(gc, rect, paintstyle.Stroke)
+	paint.SetStrokeWidth(1)
+	baseline := f.Font.Baseline() + 1
+	for _, one := range list {
+		start := f.FromSelectionIndex(one.Start)
+		end := f.FromSelectionIndex(one.End)
+		if start.Y != end.Y {
+			// The word was split across lines when wrapping, so there isn't a single run to underline.
+			continue
+		}
+		y := start.Y + baseline
+		path := unison.NewPath()
+		path.MoveTo(start.X, y)
+		for i, x := 1, start.X+2; x <= end.X; i, x = i+1, x+2 {
+			path.LineTo(x, y+float32(i%2)*2)
+		}
+		gc.DrawPath(path, paint)
+	}
+}
+
+func (f *StringField) mouseDownWithSpelling(where unison.Point, button, clickCount int, mod unison.Modifiers) bool {
+	if button == unison.ButtonRight && clickCount == 1 && f.Enabled() {
+		index := f.ToSelectionIndex(where)
+		for _, one := range f.currentMisspellings() {
+			if index >= one.Start && index <= one.End {
+				if !f.Focused() {
+					f.RequestFocus()
+				}
+				f.showSpellingMenu(where, one)
+				return true
+			}
+		}
+	}
+	return f.DefaultMouseDown(where, button, clickCount, mod)
+}
+
+func (f *StringField) showSpellingMenu(where unison.Point, misspelling *gurps.Misspelling) {
+	checker := currentSpellChecker()
+	if checker == nil {
+		return
+	}
+	factory := unison.DefaultMenuFactory()
+	cm := factory.NewMenu(unison.PopupMenuTemporaryBaseID|unison.ContextMenuIDFlag, "", nil)
+	id := 1
+	suggestions := checker.Suggestions(misspelling.Word, maxSpellingSuggestions)
+	for _, suggestion := range suggestions {
+		replacement := suggestion
+		cm.InsertItem(-1, factory.NewItem(unison.PopupMenuTemporaryBaseID+id, suggestion, unison.KeyBinding{}, nil,
+			func(_ unison.MenuItem) { f.replaceMisspelling(misspelling, replacement) }))
+		id++
+	}
+	if len(suggestions) == 0 {
+		cm.InsertItem(-1, factory.NewItem(unison.PopupMenuTemporaryBaseID+id, i18n.Text("No Suggestions"),
+			unison.KeyBinding{}, func(_ unison.MenuItem) bool { return false }, nil))
+		id++
+	}
+	cm.InsertSeparator(-1, true)
+	cm.InsertItem(-1, factory.NewItem(unison.PopupMenuTemporaryBaseID+id,
+		fmt.Sprintf(i18n.Text("Learn Spelling of \"%s\""), misspelling.Word), unison.KeyBinding{}, nil,
+		func(_ unison.MenuItem) { learnSpelling(misspelling.Word) }))
+	f.FlushDrawing()
+	cm.Popup(unison.Rect{
+		Point: f.PointToRoot(where),
+		Size: unison.Size{
+			Width:  1,
+			Height: 1,
+		},
+	}, 0)
+	cm.Dispose()
+}
+
+func (f *StringField) replaceMisspelling(misspelling *gurps.Misspelling, replacement string) {
+	before := f.GetFieldState()
+	runes := []rune(before.Text)
+	if misspelling.End > len(runes) || string(runes[misspelling.Start:misspelling.End]) != misspelling.Word {
+		return
+	}
+	after := *before
+	after.Text = string(runes[:misspelling.Start]) + replacement + string(runes[misspelling.End:])
+	after.SelectionStart = misspelling.Start + len([]rune(replacement))
+	after.SelectionEnd = after.SelectionStart
+	after.SelectionAnchor = after.SelectionStart
+	f.setWithoutUndo(&after, true)
+	f.addUndo(unison.NextUndoID(), before, &after)
+}
@@ -27,8 +27,9 @@ var appIconBytes []byte
 // Start the UI.
 func Start(files []string, afterStartup func()) {
 	readyChan := make(chan struct{})
-	pathsChan := make(chan []string, 32)
-	startHandoffService(readyChan, pathsChan, files)
+	requestsChan := make(chan []gurps.OpenRequest, 32)
+	requests := gurps.ParseOpenRequests(files)
+	startHandoffService(readyChan, requestsChan, requests)
 	libs := gurps.GlobalSettings().LibrarySet
 	go libs.PerformUpdateChecks()
 	unison.Start(
@@ -47,10 +48,14 @@ func Start(files []string, afterStartup func()) {
 			fatal.IfErr(err)
 			SetupMenuBar(wnd)
 			InitWorkspace(wnd)
-			OpenFiles(files)
+			offerRecovery()
+			restoreSession()
+			OpenRequests(requests)
+			startAutosave()
+			startThemeScheduler()
 			go func() {
-				for paths := range pathsChan {
-					unison.InvokeTask(func() { OpenFiles(paths) })
+				for list := range requestsChan {
+					unison.InvokeTask(func() { OpenRequests(list) })
 				}
 			}()
 			unison.InvokeTask(performPlatformLateStartup)
@@ -62,9 +67,15 @@ func Start(files []string, afterStartup func()) {
 			})
 		}),
 		unison.OpenFilesCallback(OpenFiles),
+		unison.RecoveryCallback(func(err error) {
+			gurps.RecordCrash(err)
+			errs.Log(err)
+		}),
 		unison.AllowQuitCallback(func() bool {
+			captureSession()
 			for _, wnd := range unison.Windows() {
 				if !wnd.AttemptClose() || wnd.IsValid() {
+					abandonSessionCapture()
 					return false
 				}
 			}
@@ -24,11 +24,15 @@ type StringField struct {
 	get       func() string
 	set       func(string)
 	useGet    bool
+	spelling  *fieldSpelling
 }
 
-// NewMultiLineStringField creates a new field for editing a string.
+// NewMultiLineStringField creates a new field for editing a string. Misspellings within the field are underlined when
+// spell checking has been enabled.
 func NewMultiLineStringField(targetMgr *TargetMgr, targetKey, undoTitle string, get func() string, set func(string)) *StringField {
-	return newStringField(unison.NewMultiLineField(), targetMgr, targetKey, undoTitle, get, set)
+	f := newStringField(unison.NewMultiLineField(), targetMgr, targetKey, undoTitle, get, set)
+	f.installSpellChecking()
+	return f
 }
 
 // NewStringField creates a new field for editing a string.
@@ -79,24 +83,28 @@ func (f *StringField) getData() string {
 
 func (f *StringField) modified(before, after *unison.FieldState) {
 	if f.CurrentUndoID() != unison.NoUndoID {
-		if mgr := unison.UndoManagerFor(f); mgr != nil {
-			undo := NewTargetUndo(f.targetMgr, f.targetKey, f.undoTitle, f.CurrentUndoID(),
-				func(target *unison.Panel, data *unison.FieldState) {
-					self := f
-					if target != nil {
-						if field, ok := target.Self.(*StringField); ok {
-							self = field
-						}
-					}
-					self.setWithoutUndo(data, true)
-				}, before)
-			undo.AfterData = after
-			mgr.Add(undo)
-		}
+		f.addUndo(f.CurrentUndoID(), before, after)
 	}
 	f.adjustForText()
 }
 
+func (f *StringField) addUndo(undoID int64, before, after *unison.FieldState) {
+	if mgr := unison.UndoManagerFor(f); mgr != nil {
+		undo := NewTargetUndo(f.targetMgr, f.targetKey, f.undoTitle, undoID,
+			func(target *unison.Panel, data *unison.FieldState) {
+				self := f
+				if target != nil {
+					if field, ok := target.Self.(*StringField); ok {
+						self = field
+					}
+				}
+				self.setWithoutUndo(data, true)
+			}, before)
+		undo.AfterData = after
+		addUndo(mgr, undo)
+	}
+}
+
 func (f *StringField) adjustForText() {
 	text := f.Text()
 	if f.last != text {
@@ -0,0 +1,50 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"path/filepath"
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/cmdline"
+	"github.com/richardwilkes/toolbox/desktop"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/log/rotation"
+	"github.com/richardwilkes/unison"
+)
+
+func createSupportBundle() {
+	dialog := unison.NewSaveDialog()
+	dialog.SetInitialDirectory(gurps.GlobalSettings().LastDir(gurps.DefaultLastDirKey))
+	dialog.SetAllowedExtensions("zip")
+	dialog.SetInitialFileName(cmdline.AppName + " Support " + time.Now().Format("2006-01-02"))
+	if !dialog.RunModal() {
+		return
+	}
+	filePath, ok := unison.ValidateSaveFilePath(dialog.Path(), "zip", false)
+	if !ok {
+		return
+	}
+	gurps.GlobalSettings().SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(filePath))
+	if err := gurps.WriteSupportBundle(filePath, rotation.PathToLog); err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create the support bundle!"), err)
+		return
+	}
+	if unison.QuestionDialog(i18n.Text("The support bundle has been created."),
+		i18n.Text(`Player names, access tokens, recent files and other identifying
+settings have been removed. Attach it to your bug report.
+
+Show it on disk now?`)) == unison.ModalResponseOK {
+		if err := desktop.Open(filepath.Dir(filePath)); err != nil {
+			unison.ErrorDialogWithError(i18n.Text("Unable to show location on disk"), err)
+		}
+	}
+}
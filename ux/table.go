@@ -85,19 +85,7 @@ func NewNodeTable[T gurps.NodeTypes](provider TableProvider[T], font unison.Font
 	}
 	table.SetLayoutData(layoutData)
 
-	ids := provider.ColumnIDs()
-	headers := provider.Headers()
-	table.Columns = make([]unison.ColumnInfo, len(headers))
-	for i := range table.Columns {
-		_, pref, _ := headers[i].AsPanel().Sizes(unison.Size{})
-		pref.Width += table.Padding.Left + table.Padding.Right
-		table.Columns[i].ID = ids[i]
-		table.Columns[i].AutoMinimum = pref.Width
-		table.Columns[i].AutoMaximum = max(float32(gurps.GlobalSettings().General.MaximumAutoColWidth), pref.Width)
-		table.Columns[i].Minimum = pref.Width
-		table.Columns[i].Maximum = 10000
-	}
-	header = unison.NewTableHeader(table, headers...)
+	header = unison.NewTableHeader(table, setupTableColumns(table, provider, font != nil)...)
 	header.Less = flexibleLess
 	header.BackgroundInk = colors.Header
 	header.InteriorDividerColor = colors.Header
@@ -107,6 +95,7 @@ func NewNodeTable[T gurps.NodeTypes](provider TableProvider[T], font unison.Font
 		VAlign: align.Fill,
 		HGrab:  true,
 	})
+	installColumnLayoutMenu(header, table, provider, font != nil)
 
 	table.DoubleClickCallback = func() { table.PerformCmd(nil, OpenEditorItemID) }
 	table.KeyDownCallback = func(keyCode unison.KeyCode, mod unison.Modifiers, repeat bool) bool {
@@ -116,8 +105,6 @@ func NewNodeTable[T gurps.NodeTypes](provider TableProvider[T], font unison.Font
 		}
 		return table.DefaultKeyDown(keyCode, mod, repeat)
 	}
-	singular, plural := provider.ItemNames()
-	table.InstallDragSupport(provider.DragSVG(), provider.DragKey(), singular, plural)
 	if font != nil {
 		table.FrameChangeCallback = func() {
 			table.SizeColumnsToFitWithExcessIn(provider.ExcessWidthColumnID())
@@ -156,11 +143,16 @@ func NewNodeTable[T gurps.NodeTypes](provider TableProvider[T], font unison.Font
 		}
 		return stop
 	}
+	installTableDragSupport(table, provider)
 
 	table.InstallCmdHandlers(CopyToSheetItemID, func(_ any) bool { return canCopySelectionToSheet(table) },
 		func(_ any) { copySelectionToSheet(table) })
 	table.InstallCmdHandlers(CopyToTemplateItemID, func(_ any) bool { return canCopySelectionToTemplate(table) },
 		func(_ any) { copySelectionToTemplate(table) })
+	table.InstallCmdHandlers(BulkEditItemID, func(_ any) bool { return canBulkEdit(table) },
+		func(_ any) { bulkEdit(table) })
+	table.InstallCmdHandlers(AddToFavoritesItemID, func(_ any) bool { return canAddSelectionToFavorites(table) },
+		func(_ any) { addSelectionToFavorites(table) })
 	if t, ok := (any(table)).(*unison.Table[*Node[*gurps.Equipment]]); ok {
 		t.InstallCmdHandlers(IncrementItemID,
 			func(_ any) bool { return canAdjustQuantity(t, true) },
@@ -198,7 +190,14 @@ func canCopySelectionToTemplate[T gurps.NodeTypes](table *unison.Table[*Node[T]]
 	return table.HasSelection() && len(OpenTemplates(unison.Ancestor[*Template](table))) > 0 && isAcceptableTypeForSheetOrTemplate(t)
 }
 
+// libraryFileClientKey may be set in a table's client data to identify the library file its rows came from when the
+// table isn't held by a library's TableDockable.
+const libraryFileClientKey = "library-file"
+
 func libraryFileFromTable[T gurps.NodeTypes](table *unison.Table[*Node[T]]) gurps.LibraryFile {
+	if from, ok := table.ClientData()[libraryFileClientKey].(gurps.LibraryFile); ok {
+		return from
+	}
 	if d := unison.Ancestor[*TableDockable[T]](table); d != nil {
 		for _, lib := range gurps.GlobalSettings().Libraries() {
 			libPathOnDisk := lib.PathOnDisk + string(filepath.Separator)
@@ -219,47 +218,52 @@ func copySelectionToSheet[T gurps.NodeTypes](table *unison.Table[*Node[T]]) {
 		if sheets := PromptForDestination(OpenSheets(unison.Ancestor[*Sheet](table))); len(sheets) > 0 {
 			sel := table.SelectedRows(true)
 			for _, s := range sheets {
-				var targetTable *unison.Table[*Node[T]]
-				var postProcessor func(rows []*Node[T])
-				switch any(sel[0].Data()).(type) {
-				case *gurps.Trait:
-					targetTable = convertTable[T](s.Traits.Table)
-					postProcessor = func(_ []*Node[T]) {
-						s.Traits.provider.ProcessDropData(nil, s.Traits.Table)
-					}
-				case *gurps.Skill:
-					targetTable = convertTable[T](s.Skills.Table)
-					postProcessor = func(_ []*Node[T]) {
-						s.Skills.provider.ProcessDropData(nil, s.Skills.Table)
-					}
-				case *gurps.Spell:
-					targetTable = convertTable[T](s.Spells.Table)
-					postProcessor = func(_ []*Node[T]) {
-						s.Spells.provider.ProcessDropData(nil, s.Spells.Table)
-					}
-				case *gurps.Equipment:
-					targetTable = convertTable[T](s.CarriedEquipment.Table)
-					postProcessor = func(_ []*Node[T]) {
-						s.CarriedEquipment.provider.ProcessDropData(nil, s.CarriedEquipment.Table)
-					}
-				case *gurps.Note:
-					targetTable = convertTable[T](s.Notes.Table)
-					postProcessor = func(_ []*Node[T]) {
-						s.Notes.provider.ProcessDropData(nil, s.Notes.Table)
-					}
-				default:
-					continue
-				}
-				if targetTable != nil {
-					CopyRowsTo(targetTable, sel, postProcessor, true)
-					ProcessModifiersForSelection(targetTable)
-					ProcessNameablesForSelection(targetTable)
-				}
+				copyRowsToSheet(s, sel)
 			}
 		}
 	}
 }
 
+func copyRowsToSheet[T gurps.NodeTypes](s *Sheet, rows []*Node[T]) {
+	var targetTable *unison.Table[*Node[T]]
+	var postProcessor func(rows []*Node[T])
+	switch any(rows[0].Data()).(type) {
+	case *gurps.Trait:
+		targetTable = convertTable[T](s.Traits.Table)
+		postProcessor = func(_ []*Node[T]) {
+			s.Traits.provider.ProcessDropData(nil, s.Traits.Table)
+		}
+	case *gurps.Skill:
+		targetTable = convertTable[T](s.Skills.Table)
+		postProcessor = func(_ []*Node[T]) {
+			s.Skills.provider.ProcessDropData(nil, s.Skills.Table)
+		}
+	case *gurps.Spell:
+		targetTable = convertTable[T](s.Spells.Table)
+		postProcessor = func(_ []*Node[T]) {
+			s.Spells.provider.ProcessDropData(nil, s.Spells.Table)
+		}
+	case *gurps.Equipment:
+		targetTable = convertTable[T](s.CarriedEquipment.Table)
+		postProcessor = func(_ []*Node[T]) {
+			s.CarriedEquipment.provider.ProcessDropData(nil, s.CarriedEquipment.Table)
+		}
+	case *gurps.Note:
+		targetTable = convertTable[T](s.Notes.Table)
+		postProcessor = func(_ []*Node[T]) {
+			s.Notes.provider.ProcessDropData(nil, s.Notes.Table)
+		}
+	default:
+		return
+	}
+	if targetTable != nil {
+		CopyRowsTo(targetTable, rows, postProcessor, true)
+		ProcessModifiersForSelection(targetTable)
+		ProcessNameablesForSelection(targetTable)
+		recordRecentlyAdded(targetTable.SelectedRows(true))
+	}
+}
+
 func copySelectionToTemplate[T gurps.NodeTypes](table *unison.Table[*Node[T]]) {
 	if table.HasSelection() {
 		if templates := PromptForDestination(OpenTemplates(unison.Ancestor[*Template](table))); len(templates) > 0 {
@@ -282,6 +286,30 @@ func copySelectionToTemplate[T gurps.NodeTypes](table *unison.Table[*Node[T]]) {
 	}
 }
 
+func canAddSelectionToFavorites[T gurps.NodeTypes](table *unison.Table[*Node[T]]) bool {
+	var t T
+	return table.HasSelection() && isAcceptableTypeForSheetOrTemplate(t) && libraryFileFromTable(table).Library != ""
+}
+
+func addSelectionToFavorites[T gurps.NodeTypes](table *unison.Table[*Node[T]]) {
+	from := libraryFileFromTable(table)
+	if from.Library == "" {
+		return
+	}
+	global := gurps.GlobalSettings()
+	added := false
+	for _, row := range table.SelectedRows(false) {
+		if item, ok := any(row.Data()).(gurps.IDer); ok {
+			if favorite := gurps.NewFavorite(from, item); favorite != nil && global.AddFavorite(favorite) {
+				added = true
+			}
+		}
+	}
+	if added {
+		refreshFavorites()
+	}
+}
+
 func convertTable[T gurps.NodeTypes](table any) *unison.Table[*Node[T]] {
 	// This is here just to get around limitations in the way Go generics behave
 	if t, ok := table.(*unison.Table[*Node[T]]); ok {
@@ -347,57 +375,88 @@ func DeleteSelection[T gurps.NodeTypes](table *unison.Table[*Node[T]], recordUnd
 				list = append(list, target)
 			}
 		}
-		if !CloseID(ids) {
-			return
+		deleteNodes(table, provider, list, ids, i18n.Text("Delete Selection"), recordUndo)
+	}
+}
+
+// DeleteIDs removes the nodes with the given IDs, along with any children they have, from the table, recording an undo
+// edit with the given title. Returns false if the nodes could not be removed.
+func DeleteIDs[T gurps.NodeTypes](table *unison.Table[*Node[T]], ids map[tid.TID]bool, undoTitle string) bool {
+	provider, ok := any(table.Model).(TableProvider[T])
+	if !ok {
+		return false
+	}
+	var list []T
+	closeIDs := make(map[tid.TID]bool)
+	gurps.Traverse(func(node T) bool {
+		if ids[gurps.AsNode(node).ID()] {
+			list = append(list, node)
+			gurps.Traverse(func(child T) bool {
+				closeIDs[gurps.AsNode(child).ID()] = true
+				return false
+			}, false, false, node)
 		}
-		var undo *unison.UndoEdit[*TableUndoEditData[T]]
-		var mgr *unison.UndoManager
-		if recordUndo {
-			if mgr = unison.UndoManagerFor(table); mgr != nil {
-				undo = &unison.UndoEdit[*TableUndoEditData[T]]{
-					ID:         unison.NextUndoID(),
-					EditName:   i18n.Text("Delete Selection"),
-					UndoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.BeforeData.Apply() },
-					RedoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.AfterData.Apply() },
-					AbsorbFunc: func(_ *unison.UndoEdit[*TableUndoEditData[T]], _ unison.Undoable) bool { return false },
-					BeforeData: NewTableUndoEditData(table),
-				}
+		return false
+	}, false, false, provider.RootData()...)
+	if len(list) == 0 {
+		return true
+	}
+	return deleteNodes(table, provider, list, closeIDs, undoTitle, true)
+}
+
+func deleteNodes[T gurps.NodeTypes](table *unison.Table[*Node[T]], provider TableProvider[T], list []T, ids map[tid.TID]bool, undoTitle string, recordUndo bool) bool {
+	if !CloseID(ids) {
+		return false
+	}
+	var undo *unison.UndoEdit[*TableUndoEditData[T]]
+	var mgr *unison.UndoManager
+	if recordUndo {
+		if mgr = unison.UndoManagerFor(table); mgr != nil {
+			undo = &unison.UndoEdit[*TableUndoEditData[T]]{
+				ID:         unison.NextUndoID(),
+				EditName:   undoTitle,
+				UndoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.BeforeData.Apply() },
+				RedoFunc:   func(e *unison.UndoEdit[*TableUndoEditData[T]]) { e.AfterData.Apply() },
+				AbsorbFunc: func(_ *unison.UndoEdit[*TableUndoEditData[T]], _ unison.Undoable) bool { return false },
+				BeforeData: NewTableUndoEditData(table),
 			}
 		}
-		needSet := false
-		topLevelData := provider.RootData()
-		for _, target := range list {
-			parent := gurps.AsNode(target).Parent()
-			if parent == zero {
-				for i, one := range topLevelData {
-					if one == target {
-						topLevelData = slices.Delete(topLevelData, i, i+1)
-						needSet = true
-						break
-					}
+	}
+	var zero T
+	needSet := false
+	topLevelData := provider.RootData()
+	for _, target := range list {
+		parent := gurps.AsNode(target).Parent()
+		if parent == zero {
+			for i, one := range topLevelData {
+				if one == target {
+					topLevelData = slices.Delete(topLevelData, i, i+1)
+					needSet = true
+					break
 				}
-			} else {
-				pNode := gurps.AsNode(parent)
-				children := pNode.NodeChildren()
-				for i, one := range children {
-					if one == target {
-						pNode.SetChildren(slices.Delete(children, i, i+1))
-						break
-					}
+			}
+		} else {
+			pNode := gurps.AsNode(parent)
+			children := pNode.NodeChildren()
+			for i, one := range children {
+				if one == target {
+					pNode.SetChildren(slices.Delete(children, i, i+1))
+					break
 				}
 			}
 		}
-		if needSet {
-			provider.SetRootData(topLevelData)
-		}
-		if recordUndo && mgr != nil && undo != nil {
-			undo.AfterData = NewTableUndoEditData(table)
-			mgr.Add(undo)
-		}
-		if builder := unison.AncestorOrSelf[Rebuildable](table); builder != nil {
-			builder.Rebuild(true)
-		}
 	}
+	if needSet {
+		provider.SetRootData(topLevelData)
+	}
+	if recordUndo && mgr != nil && undo != nil {
+		undo.AfterData = NewTableUndoEditData(table)
+		addUndo(mgr, undo)
+	}
+	if builder := unison.AncestorOrSelf[Rebuildable](table); builder != nil {
+		builder.Rebuild(true)
+	}
+	return true
 }
 
 // DuplicateSelection duplicates the selected nodes in the table.
@@ -455,7 +514,7 @@ func DuplicateSelection[T gurps.NodeTypes](table *unison.Table[*Node[T]]) {
 		table.SetSelectionMap(selMap)
 		if mgr != nil && undo != nil {
 			undo.AfterData = NewTableUndoEditData(table)
-			mgr.Add(undo)
+			addUndo(mgr, undo)
 		}
 		if builder := unison.AncestorOrSelf[Rebuildable](table); builder != nil {
 			builder.Rebuild(true)
@@ -493,7 +552,7 @@ func ClearSourceFromSelection[T gurps.NodeTypes](table *unison.Table[*Node[T]])
 		table.SyncToModel()
 		if mgr != nil && undo != nil {
 			undo.AfterData = NewTableUndoEditData(table)
-			mgr.Add(undo)
+			addUndo(mgr, undo)
 		}
 		if builder := unison.AncestorOrSelf[Rebuildable](table); builder != nil {
 			builder.Rebuild(true)
@@ -526,7 +585,7 @@ func SyncWithSourceForSelection[T gurps.NodeTypes](table *unison.Table[*Node[T]]
 		table.SyncToModel()
 		if mgr != nil && undo != nil {
 			undo.AfterData = NewTableUndoEditData(table)
-			mgr.Add(undo)
+			addUndo(mgr, undo)
 		}
 		if builder := unison.AncestorOrSelf[Rebuildable](table); builder != nil {
 			builder.Rebuild(true)
@@ -557,6 +616,7 @@ func CopyRowsTo[T gurps.NodeTypes](table *unison.Table[*Node[T]], rows []*Node[T
 			}
 		}
 	}
+	guard := newCampaignLimitsGuard(table)
 	table.SetRootRows(append(slices.Clone(table.RootRows()), rows...))
 	selMap := make(map[tid.TID]bool, len(rows))
 	for _, row := range rows {
@@ -566,11 +626,15 @@ func CopyRowsTo[T gurps.NodeTypes](table *unison.Table[*Node[T]], rows []*Node[T
 	if postProcessor != nil {
 		postProcessor(rows)
 	}
+	if guard.rejected() {
+		unison.Ancestor[Rebuildable](table).Rebuild(true)
+		return
+	}
 	table.ScrollRowCellIntoView(table.LastSelectedRowIndex(), 0)
 	table.ScrollRowCellIntoView(table.FirstSelectedRowIndex(), 0)
 	if recordUndo && mgr != nil && undo != nil {
 		undo.AfterData = NewTableUndoEditData(table)
-		mgr.Add(undo)
+		addUndo(mgr, undo)
 	}
 	unison.Ancestor[Rebuildable](table).Rebuild(true)
 }
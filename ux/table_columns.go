@@ -0,0 +1,189 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/check"
+)
+
+// columnNameClientKey holds the name of a column in its header's client data, for use in menus.
+const columnNameClientKey = "column-name"
+
+// columnLayoutRefresher is implemented by dockables that need to rebuild their tables when a column layout changes.
+type columnLayoutRefresher interface {
+	refreshColumnLayout()
+}
+
+func columnLayoutKey[T gurps.NodeTypes](provider TableProvider[T], forPage bool) string {
+	return gurps.ColumnLayoutKey(provider.RefKey(), forPage)
+}
+
+// customizedColumnIDs returns the provider's column IDs as arranged by the user.
+func customizedColumnIDs[T gurps.NodeTypes](provider TableProvider[T], forPage bool) []int {
+	layout := gurps.GlobalSettings().ColumnLayout(columnLayoutKey(provider, forPage))
+	return layout.Apply(provider.ColumnIDs(), provider.HierarchyColumnID(), provider.ExcessWidthColumnID())
+}
+
+// setupTableColumns sets the table's columns to those of the provider, as arranged by the user, and returns the
+// matching headers.
+func setupTableColumns[T gurps.NodeTypes](table *unison.Table[*Node[T]], provider TableProvider[T], forPage bool) []unison.TableColumnHeader[*Node[T]] {
+	allIDs := provider.ColumnIDs()
+	allHeaders := provider.Headers()
+	ids := customizedColumnIDs(provider, forPage)
+	headers := make([]unison.TableColumnHeader[*Node[T]], len(ids))
+	table.Columns = make([]unison.ColumnInfo, len(ids))
+	for i, id := range ids {
+		headers[i] = allHeaders[slices.Index(allIDs, id)]
+		_, pref, _ := headers[i].AsPanel().Sizes(unison.Size{})
+		pref.Width += table.Padding.Left + table.Padding.Right
+		table.Columns[i].ID = id
+		table.Columns[i].AutoMinimum = pref.Width
+		table.Columns[i].AutoMaximum = max(float32(gurps.GlobalSettings().General.MaximumAutoColWidth), pref.Width)
+		table.Columns[i].Minimum = pref.Width
+		table.Columns[i].Maximum = 10000
+	}
+	return headers
+}
+
+// applyColumnWidths sets the widths of the table's columns to those last used for tables of the same kind, returning
+// true if any were changed.
+func applyColumnWidths[T gurps.NodeTypes](table *unison.Table[*Node[T]], provider TableProvider[T], forPage bool) bool {
+	changed := false
+	if layout := gurps.GlobalSettings().ColumnLayout(columnLayoutKey(provider, forPage)); layout != nil {
+		for id, width := range layout.Widths {
+			if i := table.ColumnIndexForID(id); i != -1 && table.Columns[i].Current != width {
+				table.Columns[i].Current = width
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// preserveColumnWidths records the widths of the table's columns for use by tables of the same kind.
+func preserveColumnWidths[T gurps.NodeTypes](table *unison.Table[*Node[T]], provider TableProvider[T], forPage bool) {
+	key := columnLayoutKey(provider, forPage)
+	settings := gurps.GlobalSettings()
+	layout := settings.ColumnLayout(key).Clone()
+	if layout == nil {
+		layout = &gurps.ColumnLayout{}
+	}
+	layout.Widths = make(map[int]float32, len(table.Columns))
+	for _, col := range table.Columns {
+		layout.Widths[col.ID] = col.Current
+	}
+	settings.SetColumnLayout(key, layout)
+}
+
+// installColumnLayoutMenu adds a context menu to the table header that allows columns to be hidden, shown and moved.
+// Changes apply to every table of the same kind.
+func installColumnLayoutMenu[T gurps.NodeTypes](header *unison.TableHeader[*Node[T]], table *unison.Table[*Node[T]], provider TableProvider[T], forPage bool) {
+	orig := header.MouseDownCallback
+	header.MouseDownCallback = func(where unison.Point, button, clickCount int, mod unison.Modifiers) bool {
+		if button != unison.ButtonRight || clickCount != 1 {
+			if orig != nil {
+				return orig(where, button, clickCount, mod)
+			}
+			return header.DefaultMouseDown(where, button, clickCount, mod)
+		}
+		showColumnLayoutMenu(header, table, provider, forPage, where)
+		return true
+	}
+}
+
+func showColumnLayoutMenu[T gurps.NodeTypes](header *unison.TableHeader[*Node[T]], table *unison.Table[*Node[T]], provider TableProvider[T], forPage bool, where unison.Point) {
+	key := columnLayoutKey(provider, forPage)
+	layout := gurps.GlobalSettings().ColumnLayout(key).Clone()
+	if layout == nil {
+		layout = &gurps.ColumnLayout{}
+	}
+	allIDs := provider.ColumnIDs()
+	allHeaders := provider.Headers()
+	required := []int{provider.HierarchyColumnID(), provider.ExcessWidthColumnID()}
+	current := layout.Apply(allIDs, required...)
+	f := unison.DefaultMenuFactory()
+	cm := f.NewMenu(unison.PopupMenuTemporaryBaseID|unison.ContextMenuIDFlag, "", nil)
+	id := unison.PopupMenuTemporaryBaseID + 1
+	for i, colID := range allIDs {
+		name, _ := allHeaders[i].AsPanel().ClientData()[columnNameClientKey].(string) //nolint:errcheck // Default is fine
+		if name == "" {
+			continue
+		}
+		isRequired := slices.Contains(required, colID)
+		item := f.NewItem(id, name, unison.KeyBinding{}, func(_ unison.MenuItem) bool { return !isRequired },
+			func(_ unison.MenuItem) {
+				if i := slices.Index(layout.Hidden, colID); i != -1 {
+					layout.Hidden = slices.Delete(layout.Hidden, i, i+1)
+				} else {
+					layout.Hidden = append(layout.Hidden, colID)
+				}
+				updateColumnLayout(key, layout)
+			})
+		id++
+		item.SetCheckState(check.FromBool(!layout.IsHidden(colID) || isRequired))
+		cm.InsertItem(-1, item)
+	}
+	if col := table.OverColumn(where.X); col != -1 {
+		colID := table.Columns[col].ID
+		if pos := slices.Index(current, colID); pos != -1 {
+			cm.InsertSeparator(-1, true)
+			cm.InsertItem(-1, f.NewItem(id, i18n.Text("Move Column Left"), unison.KeyBinding{},
+				func(_ unison.MenuItem) bool { return pos > 0 },
+				func(_ unison.MenuItem) {
+					layout.Order = withColumnSwapped(allIDs, current, pos, pos-1)
+					updateColumnLayout(key, layout)
+				}))
+			id++
+			cm.InsertItem(-1, f.NewItem(id, i18n.Text("Move Column Right"), unison.KeyBinding{},
+				func(_ unison.MenuItem) bool { return pos < len(current)-1 },
+				func(_ unison.MenuItem) {
+					layout.Order = withColumnSwapped(allIDs, current, pos, pos+1)
+					updateColumnLayout(key, layout)
+				}))
+			id++
+		}
+	}
+	cm.InsertSeparator(-1, true)
+	cm.InsertItem(-1, f.NewItem(id, i18n.Text("Reset Columns"), unison.KeyBinding{},
+		func(_ unison.MenuItem) bool { return !layout.Empty() },
+		func(_ unison.MenuItem) { updateColumnLayout(key, nil) }))
+	header.FlushDrawing()
+	cm.Popup(unison.Rect{
+		Point: header.PointToRoot(where),
+		Size:  unison.Size{Width: 1, Height: 1},
+	}, 0)
+	cm.Dispose()
+}
+
+// withColumnSwapped returns the full column order, including hidden columns, with the two visible columns swapped.
+func withColumnSwapped(allIDs, visible []int, a, b int) []int {
+	order := (&gurps.ColumnLayout{Order: visible}).Apply(allIDs)
+	i := slices.Index(order, visible[a])
+	j := slices.Index(order, visible[b])
+	order[i], order[j] = order[j], order[i]
+	return order
+}
+
+func updateColumnLayout(key string, layout *gurps.ColumnLayout) {
+	gurps.GlobalSettings().SetColumnLayout(key, layout)
+	for _, d := range AllDockables() {
+		switch one := d.(type) {
+		case columnLayoutRefresher:
+			one.refreshColumnLayout()
+		case Rebuildable:
+			one.Rebuild(true)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
 	"github.com/richardwilkes/toolbox/xio/fs"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
@@ -37,6 +38,8 @@ var (
 	_ unison.TabCloser           = &TableDockable[*gurps.Trait]{}
 	_ TagProvider                = &TableDockable[*gurps.Trait]{}
 	_ gurps.Hashable             = &TableDockable[*gurps.Trait]{}
+	_ columnSortRecorder         = &TableDockable[*gurps.Trait]{}
+	_ idDeleter                  = &TableDockable[*gurps.Trait]{}
 )
 
 // TableDockable holds the view for a file that contains a (potentially hierarchical) list of data.
@@ -53,6 +56,7 @@ type TableDockable[T gurps.NodeTypes] struct {
 	filterPopup       *unison.PopupMenu[string]
 	filterField       *unison.Field
 	namesOnlyCheckBox *unison.CheckBox
+	legalOnlyCheckBox *unison.CheckBox
 	scroll            *unison.ScrollPanel
 	tableHeader       *unison.TableHeader[*Node[T]]
 	table             *unison.Table[*Node[T]]
@@ -86,7 +90,11 @@ func NewTableDockable[T gurps.NodeTypes](filePath, extension string, provider Ta
 
 	d.table.SyncToModel()
 	d.table.SizeColumnsToFit(true)
-	if columnSizing, ok := gurps.GlobalSettings().ColumnSizing[filePath]; ok {
+	if columnSizing, ok := gurps.GlobalSettings().ColumnSizing[filePath]; !ok {
+		if applyColumnWidths(d.table, d.provider, false) {
+			d.table.SyncToModel()
+		}
+	} else {
 		needSync := false
 		for id, width := range columnSizing {
 			if id != -1 {
@@ -103,6 +111,8 @@ func NewTableDockable[T gurps.NodeTypes](filePath, extension string, provider Ta
 		}
 	}
 
+	restoreColumnSorts(d.tableHeader, d.table, gurps.GlobalSettings().LibrarySort(filePath))
+
 	InstallTableDropSupport(d.table, d.provider)
 
 	d.scroll.SetColumnHeader(d.tableHeader)
@@ -145,6 +155,7 @@ func NewTableDockable[T gurps.NodeTypes](filePath, extension string, provider Ta
 	d.InstallCmdHandlers(JumpToSearchFilterItemID,
 		func(any) bool { return !d.filterField.Focused() },
 		func(any) { d.filterField.RequestFocus() })
+	d.InstallCmdHandlers(FindReplaceItemID, unison.AlwaysEnabled, func(_ any) { d.findReplace() })
 	for _, id := range canCreateIDs {
 		variant := ItemVariant(-1)
 		switch {
@@ -183,6 +194,14 @@ func (d *TableDockable[T]) createToolbar() *unison.Panel {
 	d.namesOnlyCheckBox.SetTitle(i18n.Text("Names Only"))
 	d.namesOnlyCheckBox.ClickCallback = func() { d.ApplyFilter(SelectedTags(d.filterPopup)) }
 
+	var zero T
+	if _, ok := any(zero).(*gurps.Equipment); ok {
+		d.legalOnlyCheckBox = unison.NewCheckBox()
+		d.legalOnlyCheckBox.SetTitle(i18n.Text("Legal Only"))
+		d.legalOnlyCheckBox.Tooltip = newWrappedTooltip(i18n.Text("Hide equipment with a Legality Class below the Control Rating set in the default sheet settings"))
+		d.legalOnlyCheckBox.ClickCallback = func() { d.ApplyFilter(SelectedTags(d.filterPopup)) }
+	}
+
 	toolbar := unison.NewPanel()
 	toolbar.SetBorder(unison.NewCompoundBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.Insets{Bottom: 1},
 		false), unison.NewEmptyBorder(unison.StdInsets())))
@@ -203,6 +222,9 @@ func (d *TableDockable[T]) createToolbar() *unison.Panel {
 	toolbar.AddChild(d.sizeToFitButton)
 	toolbar.AddChild(d.filterField)
 	toolbar.AddChild(d.namesOnlyCheckBox)
+	if d.legalOnlyCheckBox != nil {
+		toolbar.AddChild(d.legalOnlyCheckBox)
+	}
 	toolbar.AddChild(d.filterPopup)
 	toolbar.SetLayoutData(&unison.FlexLayoutData{
 		HAlign: align.Fill,
@@ -252,6 +274,12 @@ func (d *TableDockable[T]) Tooltip() string {
 	return d.path
 }
 
+// DeleteIDs removes the items with the given IDs, along with any children they have, recording an undo edit with the
+// given title. Returns false if the items could not be removed.
+func (d *TableDockable[T]) DeleteIDs(ids map[tid.TID]bool, undoTitle string) bool {
+	return DeleteIDs(d.table, ids, undoTitle)
+}
+
 // BackingFilePath implements workspace.FileBackedDockable
 func (d *TableDockable[T]) BackingFilePath() string {
 	return d.path
@@ -268,6 +296,16 @@ func (d *TableDockable[T]) Modified() bool {
 	return d.hash != gurps.Hash64(d)
 }
 
+func (d *TableDockable[T]) writeRecoveryFile(filePath string) error {
+	return d.saver(filePath)
+}
+
+func (d *TableDockable[T]) markRecovered(originalPath string) {
+	d.hash = 0
+	d.needsSaveAsPrompt = !fs.FileExists(originalPath)
+	d.SetBackingFilePath(originalPath)
+}
+
 // MarkModified implements widget.ModifiableRoot.
 func (d *TableDockable[T]) MarkModified(_ unison.Paneler) {
 	UpdateTitleForDockable(d)
@@ -309,6 +347,23 @@ func (d *TableDockable[T]) preserveColumns() {
 		settings.ColumnSizing = make(map[string]map[int]float32)
 	}
 	settings.ColumnSizing[d.BackingFilePath()] = m
+	preserveColumnWidths(d.table, d.provider, false)
+}
+
+// recordColumnSort implements columnSortRecorder.
+func (d *TableDockable[T]) recordColumnSort() {
+	gurps.GlobalSettings().SetLibrarySort(d.BackingFilePath(), columnSorts(d.tableHeader, d.table))
+}
+
+func (d *TableDockable[T]) refreshColumnLayout() {
+	d.tableHeader.ColumnHeaders = setupTableColumns(d.table, d.provider, false)
+	restoreColumnSorts(d.tableHeader, d.table, gurps.GlobalSettings().LibrarySort(d.BackingFilePath()))
+	d.table.SyncToModel()
+	d.table.SizeColumnsToFit(true)
+	applyColumnWidths(d.table, d.provider, false)
+	d.table.SyncToModel()
+	d.tableHeader.MarkForLayoutAndRedraw()
+	d.MarkForLayoutAndRedraw()
 }
 
 func (d *TableDockable[T]) save(forceSaveAs bool) bool {
@@ -390,9 +445,15 @@ func (d *TableDockable[T]) AllTags() []string {
 func (d *TableDockable[T]) ApplyFilter(tags []string) {
 	if d.filterField != nil {
 		text := strings.ToLower(strings.TrimSpace(d.filterField.GetFieldState().Text))
+		legalOnly := d.legalOnlyCheckBox != nil && d.legalOnlyCheckBox.State == check.On
 		var f func(row *Node[T]) bool
-		if len(tags) != 0 || text != "" {
+		if len(tags) != 0 || text != "" || legalOnly {
 			f = func(row *Node[T]) bool {
+				if legalOnly {
+					if eqp, ok := any(row.Data()).(*gurps.Equipment); ok && eqp.IsIllegal() {
+						return true
+					}
+				}
 				match := false
 				if d.namesOnlyCheckBox.State == check.On {
 					match = strings.Contains(strings.ToLower(row.dataAsNode.String()), text)
@@ -413,3 +474,13 @@ func (d *TableDockable[T]) ApplyFilter(tags []string) {
 		d.table.ApplyFilter(f)
 	}
 }
+
+// RevealItem implements itemRevealer.
+func (d *TableDockable[T]) RevealItem(name string) {
+	d.namesOnlyCheckBox.State = check.On
+	if d.filterField.Text() == name {
+		d.ApplyFilter(SelectedTags(d.filterPopup))
+	} else {
+		d.filterField.SetText(name)
+	}
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/paintstyle"
+)
+
+const (
+	// dragSelectionClientKey holds a function in a table's client data that adds the table's selected rows to the
+	// data of a drag started in another table of the same dockable.
+	dragSelectionClientKey = "drag-selection"
+	// multiTableSelectionClientKey is present in a table's client data when its selection is part of a selection the
+	// user extended across several tables of the same dockable.
+	multiTableSelectionClientKey = "multi-table-selection"
+	// dragOriginKey holds the dockable a drag of rows from several tables was started in.
+	dragOriginKey = "drag-origin"
+	// dropAtEndY is a y coordinate below the last row of any table, used to have forwarded rows added at the end.
+	dropAtEndY = 100000000
+)
+
+// dropRerouter is implemented by dockables that can route dropped rows to the table that holds their type.
+type dropRerouter interface {
+	unison.Dockable
+	keyToPanel(key string) *unison.Panel
+}
+
+// installTableDragSupport installs drag support into a table. When the user extends a selection across several tables
+// of the same dockable by holding down shift or the discontiguous selection modifier while clicking, the selected rows
+// of those tables are carried along in the drag, so that rows of several types can be moved to another sheet or
+// template in one operation. A click without those modifiers limits the selection to the clicked table again.
+func installTableDragSupport[T gurps.NodeTypes](table *unison.Table[*Node[T]], provider TableProvider[T]) {
+	dragKey := provider.DragKey()
+	table.ClientData()[dragSelectionClientKey] = func(data map[string]any) int {
+		if _, joined := table.ClientData()[multiTableSelectionClientKey]; !joined || !table.HasSelection() {
+			return 0
+		}
+		dragData := &unison.TableDragData[*Node[T]]{
+			Table: table,
+			Rows:  table.SelectedRows(true),
+		}
+		data[unusedDragKey(data, dragKey)] = dragData
+		return unison.CountTableRows(dragData.Rows)
+	}
+	pendingSingleTableSelection := false
+	dragStarted := false
+	origMouseDown := table.MouseDownCallback
+	table.MouseDownCallback = func(where unison.Point, button, clickCount int, mod unison.Modifiers) bool {
+		pendingSingleTableSelection = false
+		dragStarted = false
+		if button == unison.ButtonLeft {
+			switch {
+			case mod.ShiftDown() || mod.DiscontiguousSelectionDown():
+				joinMultiTableSelection(table)
+			case table.IsRowSelected(table.OverRow(where.Y)):
+				// The user may be about to drag the existing selection, so wait until the mouse is released to see
+				// whether the selection is replaced.
+				pendingSingleTableSelection = true
+			default:
+				leaveMultiTableSelection(table)
+			}
+		}
+		return origMouseDown(where, button, clickCount, mod)
+	}
+	origMouseUp := table.MouseUpCallback
+	table.MouseUpCallback = func(where unison.Point, button int, mod unison.Modifiers) bool {
+		if pendingSingleTableSelection && !dragStarted {
+			leaveMultiTableSelection(table)
+		}
+		pendingSingleTableSelection = false
+		return origMouseUp(where, button, mod)
+	}
+	singular, plural := provider.ItemNames()
+	orig := table.MouseDragCallback
+	table.MouseDragCallback = func(where unison.Point, button int, mod unison.Modifiers) bool {
+		if orig != nil && orig(where, button, mod) {
+			return true
+		}
+		if button == unison.ButtonLeft && table.HasSelection() && table.IsDragGesture(where) {
+			dragStarted = true
+			dragData := &unison.TableDragData[*Node[T]]{
+				Table: table,
+				Rows:  table.SelectedRows(true),
+			}
+			data := map[string]any{dragKey: dragData}
+			count := unison.CountTableRows(dragData.Rows)
+			extra := 0
+			if _, joined := table.ClientData()[multiTableSelectionClientKey]; joined {
+				if dockable := unison.Ancestor[unison.Dockable](table); dockable != nil {
+					forEachTableInDockable(dockable, func(p *unison.Panel) {
+						if p == table.AsPanel() {
+							return
+						}
+						if f, ok := p.ClientData()[dragSelectionClientKey].(func(map[string]any) int); ok {
+							extra += f(data)
+						}
+					})
+					if extra != 0 {
+						data[dragOriginKey] = dockable
+					}
+				}
+			}
+			var drawable unison.Drawable
+			if extra == 0 {
+				drawable = unison.NewTableDragDrawable(dragData, provider.DragSVG(), singular, plural)
+			} else {
+				drawable = newMultiTableDragDrawable(count + extra)
+			}
+			size := drawable.LogicalSize()
+			table.StartDataDrag(&unison.DragData{
+				Data:     data,
+				Drawable: drawable,
+				Ink:      table.OnBackgroundInk,
+				Offset:   unison.Point{Y: -size.Height / 2},
+			})
+		}
+		return false
+	}
+}
+
+// forEachTableInDockable calls f for each panel within the dockable that has drag support installed.
+func forEachTableInDockable(dockable unison.Dockable, f func(p *unison.Panel)) {
+	var walk func(p *unison.Panel)
+	walk = func(p *unison.Panel) {
+		if _, ok := p.ClientData()[dragSelectionClientKey]; ok {
+			f(p)
+		}
+		for _, child := range p.Children() {
+			walk(child)
+		}
+	}
+	walk(dockable.AsPanel())
+}
+
+// joinMultiTableSelection makes the table, along with every other table in its dockable that currently has a
+// selection, part of a selection that spans several tables.
+func joinMultiTableSelection[T gurps.NodeTypes](table *unison.Table[*Node[T]]) {
+	table.ClientData()[multiTableSelectionClientKey] = true
+	if dockable := unison.Ancestor[unison.Dockable](table); dockable != nil {
+		forEachTableInDockable(dockable, func(p *unison.Panel) {
+			if hasSelection, ok := p.Self.(interface{ HasSelection() bool }); ok && hasSelection.HasSelection() {
+				p.ClientData()[multiTableSelectionClientKey] = true
+			}
+		})
+	}
+}
+
+// leaveMultiTableSelection limits the selection that will be dragged to the table again.
+func leaveMultiTableSelection[T gurps.NodeTypes](table *unison.Table[*Node[T]]) {
+	if dockable := unison.Ancestor[unison.Dockable](table); dockable != nil {
+		forEachTableInDockable(dockable, func(p *unison.Panel) {
+			delete(p.ClientData(), multiTableSelectionClientKey)
+		})
+	}
+	delete(table.ClientData(), multiTableSelectionClientKey)
+}
+
+// unusedDragKey returns the drag key to use for the rows of another table. Some tables, such as the carried and other
+// equipment tables of a sheet, share a drag key, so additional keys are formed from it for the second and subsequent
+// tables.
+func unusedDragKey(data map[string]any, dragKey string) string {
+	key := dragKey
+	for i := 2; ; i++ {
+		if _, exists := data[key]; !exists {
+			return key
+		}
+		key = sharedDragKey(dragKey, i)
+	}
+}
+
+func sharedDragKey(dragKey string, index int) string {
+	return fmt.Sprintf("%s#%d", dragKey, index)
+}
+
+// dropRemainingDragData drops the rows of a drag from several tables that weren't handled by the table they were
+// dropped on into the matching tables of the target. Nothing is done when the drag started in the target itself.
+func dropRemainingDragData(target dropRerouter, handledKey string, data map[string]any) {
+	origin, ok := data[dragOriginKey].(unison.Dockable)
+	if !ok || origin == target {
+		return
+	}
+	// Only the first table to receive the drop forwards the remaining rows
+	delete(data, dragOriginKey)
+	where := unison.Point{Y: dropAtEndY}
+	for _, key := range dropKeys {
+		panel := target.keyToPanel(key)
+		if panel == nil {
+			continue
+		}
+		if _, exists := data[key]; exists && key != handledKey {
+			panel.DataDragOverCallback(where, data)
+			panel.DataDragDropCallback(where, data)
+		}
+		// Rows from tables that share the key are presented to the panel under the key it expects, one table at a
+		// time.
+		original, hadOriginal := data[key]
+		for i := 2; ; i++ {
+			shared, exists := data[sharedDragKey(key, i)]
+			if !exists {
+				break
+			}
+			data[key] = shared
+			panel.DataDragOverCallback(where, data)
+			panel.DataDragDropCallback(where, data)
+		}
+		if hadOriginal {
+			data[key] = original
+		} else {
+			delete(data, key)
+		}
+	}
+}
+
+type multiTableDragDrawable struct {
+	label *unison.Label
+}
+
+func newMultiTableDragDrawable(count int) unison.Drawable {
+	label := unison.NewLabel()
+	label.DrawCallback = func(gc *unison.Canvas, rect unison.Rect) {
+		r := rect.Inset(unison.NewUniformInsets(1))
+		corner := r.Height / 2
+		gc.SaveWithOpacity(0.7)
+		gc.DrawRoundedRect(r, corner, corner, unison.ThemeFocus.Paint(gc, r, paintstyle.Fill))
+		gc.DrawRoundedRect(r, corner, corner, unison.ThemeOnFocus.Paint(gc, r, paintstyle.Stroke))
+		gc.Restore()
+		label.DefaultDraw(gc, rect)
+	}
+	label.OnBackgroundInk = unison.ThemeOnFocus
+	label.SetBorder(unison.NewEmptyBorder(unison.Insets{
+		Top:    4,
+		Left:   label.Font.LineHeight(),
+		Bottom: 4,
+		Right:  label.Font.LineHeight(),
+	}))
+	label.SetTitle(fmt.Sprintf(i18n.Text("%d Items"), count))
+	_, pref, _ := label.Sizes(unison.Size{})
+	label.SetFrameRect(unison.Rect{Size: pref})
+	return &multiTableDragDrawable{label: label}
+}
+
+func (d *multiTableDragDrawable) LogicalSize() unison.Size {
+	return d.label.FrameRect().Size
+}
+
+func (d *multiTableDragDrawable) DrawInRect(canvas *unison.Canvas, rect unison.Rect, _ *unison.SamplingOptions, _ *unison.Paint) {
+	d.label.Draw(canvas, rect)
+}
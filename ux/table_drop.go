@@ -32,6 +32,13 @@ func InstallTableDropSupport[T gurps.NodeTypes](table *unison.Table[*Node[T]], p
 	table.ClientData()[TableProviderClientKey] = provider
 	unison.InstallDropSupport[*Node[T], *TableDragUndoEditData[T]](table, provider.DragKey(),
 		provider.DropShouldMoveData, willDropCallback[T], didDropCallback[T])
+	originalDataDragDropCallback := table.DataDragDropCallback
+	table.DataDragDropCallback = func(where unison.Point, data map[string]any) {
+		originalDataDragDropCallback(where, data)
+		if target := unison.Ancestor[dropRerouter](table); target != nil {
+			dropRemainingDragData(target, provider.DragKey(), data)
+		}
+	}
 	table.DragRemovedRowsCallback = func() { MarkModified(table) }
 	table.DropOccurredCallback = func() {
 		// We need to defer this to give newly added skills a chance to choose their defaults first, before the normal
@@ -81,6 +88,11 @@ func InstallTableDropSupport[T gurps.NodeTypes](table *unison.Table[*Node[T]], p
 			}
 		}
 	}
+	dataDragOverCallback := table.DataDragOverCallback
+	table.DataDragOverCallback = func(where unison.Point, data map[string]any) bool {
+		// Tables that have been disabled, such as those of a sheet opened from a character archive, accept no drops
+		return table.Enabled() && dataDragOverCallback(where, data)
+	}
 }
 
 func willDropCallback[T gurps.NodeTypes](from, to *unison.Table[*Node[T]], move bool) *unison.UndoEdit[*TableDragUndoEditData[T]] {
@@ -117,6 +129,9 @@ func didDropCallback[T gurps.NodeTypes](undo *unison.UndoEdit[*TableDragUndoEdit
 			if toolbox.IsNil(unison.Ancestor[gurps.DataOwnerProvider](from)) {
 				ProcessModifiersForSelection(to)
 				ProcessNameablesForSelection(to)
+				if unison.Ancestor[*Sheet](to) != nil {
+					recordRecentlyAdded(to.SelectedRows(true))
+				}
 			}
 		}
 	}
@@ -135,5 +150,5 @@ func finishDidDrop[T gurps.NodeTypes](undo *unison.UndoEdit[*TableDragUndoEditDa
 		from = nil
 	}
 	undo.AfterData = NewTableDragUndoEditData(from, to)
-	mgr.Add(undo)
+	addUndo(mgr, undo)
 }
@@ -330,6 +330,11 @@ func (n *Node[T]) createLabelCell(c *gurps.CellData, width float32, foreground,
 		tag.ClientData()[noInvertColorsMarker] = true
 		p.AddChild(tag)
 	}
+	if c.RollResolver != nil {
+		for _, macro := range gurps.ExtractRollMacros(c.Primary + "\n" + c.Secondary) {
+			p.AddChild(newRollMacroChip(macro, c.RollResolver, n.secondaryFieldFont(), foreground, background))
+		}
+	}
 	if tooltip != "" {
 		p.Tooltip = newWrappedTooltip(tooltip)
 	}
@@ -442,7 +447,7 @@ func handleCheck(data any, check unison.Paneler, checked bool) {
 		item.Equipped = checked
 		if mgr := unison.UndoManagerFor(check); mgr != nil {
 			owner := unison.AncestorOrSelf[Rebuildable](check)
-			mgr.Add(&unison.UndoEdit[*equipmentAdjuster]{
+			addUndo(mgr, &unison.UndoEdit[*equipmentAdjuster]{
 				ID:       unison.NextUndoID(),
 				EditName: i18n.Text("Toggle Equipped"),
 				UndoFunc: func(edit *unison.UndoEdit[*equipmentAdjuster]) { edit.BeforeData.Apply() },
@@ -464,7 +469,7 @@ func handleCheck(data any, check unison.Paneler, checked bool) {
 		item.Disabled = !checked
 		if mgr := unison.UndoManagerFor(check); mgr != nil {
 			owner := unison.AncestorOrSelf[Rebuildable](check)
-			mgr.Add(&unison.UndoEdit[*traitModifierAdjuster]{
+			addUndo(mgr, &unison.UndoEdit[*traitModifierAdjuster]{
 				ID:       unison.NextUndoID(),
 				EditName: i18n.Text("Toggle Trait Modifier"),
 				UndoFunc: func(edit *unison.UndoEdit[*traitModifierAdjuster]) { edit.BeforeData.Apply() },
@@ -486,7 +491,7 @@ func handleCheck(data any, check unison.Paneler, checked bool) {
 		item.Disabled = !checked
 		if mgr := unison.UndoManagerFor(check); mgr != nil {
 			owner := unison.AncestorOrSelf[Rebuildable](check)
-			mgr.Add(&unison.UndoEdit[*equipmentModifierAdjuster]{
+			addUndo(mgr, &unison.UndoEdit[*equipmentModifierAdjuster]{
 				ID:       unison.NextUndoID(),
 				EditName: i18n.Text("Toggle Equipment Modifier"),
 				UndoFunc: func(edit *unison.UndoEdit[*equipmentModifierAdjuster]) { edit.BeforeData.Apply() },
@@ -650,6 +655,7 @@ func InsertItems[T gurps.NodeTypes](owner Rebuildable, table *unison.Table[*Node
 			BeforeData: NewTableUndoEditData(table),
 		}
 	}
+	guard := newCampaignLimitsGuard(table)
 	var target, zero T
 	i := table.FirstSelectedRowIndex()
 	if i != -1 {
@@ -680,6 +686,10 @@ func InsertItems[T gurps.NodeTypes](owner Rebuildable, table *unison.Table[*Node
 		SetParents(items, zero)
 		setTopList(append(topList(), items...))
 	}
+	if guard.rejected() {
+		owner.Rebuild(true)
+		return
+	}
 	MarkModified(table)
 	table.SetRootRows(rowData(table))
 	table.ValidateScrollRoot()
@@ -693,7 +703,7 @@ func InsertItems[T gurps.NodeTypes](owner Rebuildable, table *unison.Table[*Node
 	table.ScrollRowCellIntoView(table.FirstSelectedRowIndex(), 0)
 	if mgr != nil && undo != nil {
 		undo.AfterData = NewTableUndoEditData(table)
-		mgr.Add(undo)
+		addUndo(mgr, undo)
 	}
 	owner.Rebuild(true)
 }
@@ -0,0 +1,108 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/unison"
+)
+
+// columnSortRecorder is implemented by the panels that keep the sort state of their table in a document's settings.
+type columnSortRecorder interface {
+	recordColumnSort()
+}
+
+// sortOnColumn sorts the table on the column. If addToSort is true, the column is added to the columns already being
+// sorted on as the least significant one, or has its direction flipped if it is already one of them. Otherwise, the
+// table is sorted on just the column, flipping its direction if it was already the only one.
+func sortOnColumn[T gurps.NodeTypes](header *unison.TableHeader[*Node[T]], column unison.TableColumnHeader[*Node[T]], addToSort bool) {
+	state := column.SortState()
+	if !state.Sortable {
+		return
+	}
+	others := 0
+	for _, hdr := range header.ColumnHeaders {
+		if hdr == column {
+			continue
+		}
+		if s := hdr.SortState(); s.Order >= 0 {
+			if addToSort {
+				others++
+			} else {
+				s.Order = -1
+				hdr.SetSortState(s)
+			}
+		}
+	}
+	switch {
+	case addToSort && state.Order >= 0, !addToSort && state.Order == 0 && others == 0:
+		state.Ascending = !state.Ascending
+	case addToSort:
+		state.Order = others
+		state.Ascending = true
+	default:
+		state.Order = 0
+		state.Ascending = true
+	}
+	column.SetSortState(state)
+	header.ApplySort()
+	if recorder := unison.Ancestor[columnSortRecorder](header); recorder != nil {
+		recorder.recordColumnSort()
+	}
+}
+
+// columnSorts returns the columns the table is sorted on, primary sort column first.
+func columnSorts[T gurps.NodeTypes](header *unison.TableHeader[*Node[T]], table *unison.Table[*Node[T]]) []gurps.ColumnSort {
+	type sortedColumn struct {
+		order int
+		sort  gurps.ColumnSort
+	}
+	var list []sortedColumn
+	for i, hdr := range header.ColumnHeaders {
+		if s := hdr.SortState(); s.Sortable && s.Order >= 0 && i < len(table.Columns) {
+			list = append(list, sortedColumn{
+				order: s.Order,
+				sort: gurps.ColumnSort{
+					ID:         table.Columns[i].ID,
+					Descending: !s.Ascending,
+				},
+			})
+		}
+	}
+	slices.SortStableFunc(list, func(a, b sortedColumn) int { return a.order - b.order })
+	sorts := make([]gurps.ColumnSort, len(list))
+	for i, one := range list {
+		sorts[i] = one.sort
+	}
+	return sorts
+}
+
+// restoreColumnSorts sets the sort state of the table's column headers to the recorded column sorts. The rows
+// themselves are not re-sorted, as their order was saved along with the sort state.
+func restoreColumnSorts[T gurps.NodeTypes](header *unison.TableHeader[*Node[T]], table *unison.Table[*Node[T]], sorts []gurps.ColumnSort) {
+	order := 0
+	for _, one := range sorts {
+		i := table.ColumnIndexForID(one.ID)
+		if i == -1 || i >= len(header.ColumnHeaders) {
+			continue
+		}
+		hdr := header.ColumnHeaders[i]
+		s := hdr.SortState()
+		if !s.Sortable {
+			continue
+		}
+		s.Order = order
+		s.Ascending = !one.Descending
+		hdr.SetSortState(s)
+		order++
+	}
+}
@@ -15,7 +15,6 @@ import (
 	"path/filepath"
 	"slices"
 
-	"github.com/richardwilkes/gcs/v5/model/criteria"
 	"github.com/richardwilkes/gcs/v5/model/fonts"
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
@@ -47,6 +46,7 @@ type Template struct {
 	targetMgr         *TargetMgr
 	undoMgr           *unison.UndoManager
 	toolbar           *unison.Panel
+	searchTracker     *searchTracker
 	scroll            *unison.ScrollPanel
 	template          *gurps.Template
 	hash              uint64
@@ -166,6 +166,14 @@ func NewTemplate(filePath string, template *gurps.Template) *Template {
 				return t.Traits.provider.RootRows()
 			}, gurps.NewNaturalAttacks(nil, nil))
 	})
+	t.InstallCmdHandlers(AddReputationItemID, unison.AlwaysEnabled, func(_ any) {
+		if trait := buildReputation(nil); trait != nil {
+			InsertItems[*gurps.Trait](t, t.Traits.Table, t.template.TraitList, t.template.SetTraitList,
+				func(_ *unison.Table[*Node[*gurps.Trait]]) []*Node[*gurps.Trait] {
+					return t.Traits.provider.RootRows()
+				}, trait)
+		}
+	})
 	t.InstallCmdHandlers(ApplyTemplateItemID, t.canApplyTemplate, t.applyTemplate)
 	t.InstallCmdHandlers(NewSheetFromTemplateItemID, unison.AlwaysEnabled, t.newSheetFromTemplate)
 
@@ -217,7 +225,7 @@ func (t *Template) createToolbar() {
 	syncSourceButton.ClickCallback = func() { t.syncWithAllSources() }
 	t.toolbar.AddChild(syncSourceButton)
 
-	installSearchTracker(t.toolbar, func() {
+	t.searchTracker = installSearchTracker(t.toolbar, func() {
 		t.Traits.Table.ClearSelection()
 		t.Skills.Table.ClearSelection()
 		t.Spells.Table.ClearSelection()
@@ -356,6 +364,9 @@ Disable your character's existing Ancestry (%s)?`),
 	if spells, abort = processPickerRows(spells); abort {
 		return false
 	}
+	if equipment, abort = processPickerRows(equipment); abort {
+		return false
+	}
 	appendRows(sheet.Traits.Table, traits)
 	appendRows(sheet.Skills.Table, skills)
 	appendRows(sheet.Spells.Table, spells)
@@ -388,7 +399,7 @@ Disable your character's existing Ancestry (%s)?`),
 		if undo.AfterData, err = NewApplyTemplateUndoEditData(sheet); err != nil {
 			errs.Log(err)
 		} else {
-			mgr.Add(undo)
+			addUndo(mgr, undo)
 		}
 	}
 	sheet.Window().ToFront()
@@ -517,21 +528,21 @@ func processPickerRow[T gurps.NodeTypes](row T) (revised []T, abort bool) {
 		for i, box := range boxes {
 			if box.State == check.On {
 				switch tp.Type {
-				case picker.NotApplicable:
+				case picker.NotApplicable, picker.Optional:
 				case picker.Count:
 					total += fxp.One
 				case picker.Points:
-					total += rawPoints(children[i])
+					total += gurps.TemplatePickerPoints(children[i])
 				}
 			}
 		}
-		dialog.Button(unison.ModalResponseOK).SetEnabled(tp.Qualifier.Matches(total))
+		dialog.Button(unison.ModalResponseOK).SetEnabled(tp.Type == picker.Optional || tp.Qualifier.Matches(total))
 	}
 	for _, child := range children {
 		checkBox := unison.NewCheckBox()
 		title := child.String()
 		if tp.Type == picker.Points {
-			points := rawPoints(child)
+			points := gurps.TemplatePickerPoints(child)
 			pointsLabel := i18n.Text("points")
 			if points == fxp.One {
 				pointsLabel = i18n.Text("point")
@@ -608,31 +619,6 @@ func processPickerRow[T gurps.NodeTypes](row T) (revised []T, abort bool) {
 	return rowChildren, false
 }
 
-func rawPoints(child any) fxp.Int {
-	switch nc := child.(type) {
-	case *gurps.Skill:
-		if nc.Container() && nc.TemplatePicker != nil && nc.TemplatePicker.Type == picker.Points &&
-			nc.TemplatePicker.Qualifier.Compare == criteria.EqualsNumber {
-			return nc.TemplatePicker.Qualifier.Qualifier
-		}
-		return nc.RawPoints()
-	case *gurps.Spell:
-		if nc.Container() && nc.TemplatePicker != nil && nc.TemplatePicker.Type == picker.Points &&
-			nc.TemplatePicker.Qualifier.Compare == criteria.EqualsNumber {
-			return nc.TemplatePicker.Qualifier.Qualifier
-		}
-		return nc.RawPoints()
-	case *gurps.Trait:
-		if nc.Container() && nc.TemplatePicker != nil && nc.TemplatePicker.Type == picker.Points &&
-			nc.TemplatePicker.Qualifier.Compare == criteria.EqualsNumber {
-			return nc.TemplatePicker.Qualifier.Qualifier
-		}
-		return nc.AdjustedPoints()
-	default:
-		return 0
-	}
-}
-
 func (t *Template) installNewItemCmdHandlers(itemID, containerID int, creator itemCreator) {
 	variant := NoItemVariant
 	if containerID == -1 {
@@ -697,6 +683,16 @@ func (t *Template) Modified() bool {
 	return t.hash != gurps.Hash64(t.template)
 }
 
+func (t *Template) writeRecoveryFile(filePath string) error {
+	return t.template.Save(filePath)
+}
+
+func (t *Template) markRecovered(originalPath string) {
+	t.hash = 0
+	t.needsSaveAsPrompt = !fs.FileExists(originalPath)
+	t.SetBackingFilePath(originalPath)
+}
+
 // MarkModified implements widget.ModifiableRoot.
 func (t *Template) MarkModified(_ unison.Paneler) {
 	UpdateTitleForDockable(t)
@@ -777,7 +773,7 @@ func (t *Template) createLists() {
 		for _, c := range col {
 			switch c {
 			case gurps.BlockLayoutTraitsKey:
-				if t.Traits == nil {
+				if t.Traits.needReconstruction() {
 					t.Traits = NewTraitsPageList(t, t.template)
 				} else {
 					t.Traits.Sync()
@@ -787,7 +783,7 @@ func (t *Template) createLists() {
 					refocusOn = t.Traits.Table
 				}
 			case gurps.BlockLayoutSkillsKey:
-				if t.Skills == nil {
+				if t.Skills.needReconstruction() {
 					t.Skills = NewSkillsPageList(t, t.template)
 				} else {
 					t.Skills.Sync()
@@ -797,7 +793,7 @@ func (t *Template) createLists() {
 					refocusOn = t.Skills.Table
 				}
 			case gurps.BlockLayoutSpellsKey:
-				if t.Spells == nil {
+				if t.Spells.needReconstruction() {
 					t.Spells = NewSpellsPageList(t, t.template)
 				} else {
 					t.Spells.Sync()
@@ -807,7 +803,7 @@ func (t *Template) createLists() {
 					refocusOn = t.Spells.Table
 				}
 			case gurps.BlockLayoutEquipmentKey:
-				if t.Equipment == nil {
+				if t.Equipment.needReconstruction() {
 					t.Equipment = NewCarriedEquipmentPageList(t, t.template)
 				} else {
 					t.Equipment.Sync()
@@ -817,7 +813,7 @@ func (t *Template) createLists() {
 					refocusOn = t.Equipment.Table
 				}
 			case gurps.BlockLayoutNotesKey:
-				if t.Notes == nil {
+				if t.Notes.needReconstruction() {
 					t.Notes = NewNotesPageList(t, t.template)
 				} else {
 					t.Notes.Sync()
@@ -930,7 +926,12 @@ func (t *Template) syncWithAllSources() {
 	t.Notes.Table.SyncToModel()
 	if mgr != nil && undo != nil {
 		undo.AfterData = newTemplateTablesUndoData(t)
-		mgr.Add(undo)
+		addUndo(mgr, undo)
 	}
 	t.Rebuild(true)
 }
+
+// RevealItem implements itemRevealer.
+func (t *Template) RevealItem(name string) {
+	t.searchTracker.searchForName(name)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"time"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/unison"
+)
+
+// startThemeScheduler checks once a minute whether the theme schedule calls for a different color mode.
+func startThemeScheduler() {
+	unison.InvokeTaskAfter(func() {
+		applyScheduledThemeMode()
+		startThemeScheduler()
+	}, time.Minute)
+}
+
+// applyScheduledThemeMode switches to the color mode that should currently be in effect.
+func applyScheduledThemeMode() {
+	unison.SetThemeMode(gurps.GlobalSettings().EffectiveThemeMode(time.Now()))
+}
@@ -0,0 +1,316 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/study"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/timeuse"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+const applyDowntimeResponse = unison.ModalResponseUserBase
+
+type timeUseUndoData struct {
+	tables *sheetTablesUndoData
+	plan   gurps.TimeUsePlan
+	funds  fxp.Int
+}
+
+func newTimeUseUndoData(s *Sheet) *timeUseUndoData {
+	return &timeUseUndoData{
+		tables: newSheetTablesUndoData(s),
+		plan:   s.entity.TimeUse.Clone(),
+		funds:  s.entity.Funds,
+	}
+}
+
+func (d *timeUseUndoData) apply(s *Sheet) {
+	d.tables.Apply()
+	s.entity.TimeUse = d.plan.Clone()
+	s.entity.Funds = d.funds
+	s.MarkModified(s)
+	s.Rebuild(true)
+}
+
+type timeUsePlanner struct {
+	sheet     *Sheet
+	plan      gurps.TimeUsePlan
+	targets   []*gurps.StudyTarget
+	rows      *unison.Panel
+	summary   []*NonEditableField
+	addButton *unison.Button
+	weeks     int
+}
+
+func (s *Sheet) planTimeUse() {
+	p := &timeUsePlanner{
+		sheet:   s,
+		plan:    s.entity.TimeUse.Clone(),
+		targets: s.entity.StudyTargets(),
+		weeks:   1,
+	}
+
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  1,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing * 2,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(700, 0),
+		HAlign:  align.Fill,
+		HGrab:   true,
+	})
+	p.rows = unison.NewPanel()
+	p.rows.SetLayout(&unison.FlexLayout{
+		Columns:  6,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	p.rows.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	panel.AddChild(p.rows)
+	p.addButton = unison.NewButton()
+	p.addButton.SetTitle(i18n.Text("Add Activity"))
+	p.addButton.ClickCallback = func() {
+		p.plan = append(p.plan, &gurps.TimeUseEntry{Kind: timeuse.Other, Period: timeuse.PerDay})
+		p.rebuildRows()
+	}
+	panel.AddChild(p.addButton)
+	panel.AddChild(p.createSummary())
+	p.rebuildRows()
+
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		{
+			Title:        i18n.Text("Apply Downtime"),
+			ResponseCode: applyDowntimeResponse,
+		},
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Save Plan")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	response := dialog.RunModal()
+	if response != unison.ModalResponseOK && response != applyDowntimeResponse {
+		return
+	}
+	before := newTimeUseUndoData(s)
+	s.entity.TimeUse = p.plan.Clone()
+	editName := planTimeUseAction.Title
+	if response == applyDowntimeResponse && p.weeks > 0 {
+		s.entity.ApplyDowntime(p.weeks)
+		s.Traits.Table.SyncToModel()
+		s.Skills.Table.SyncToModel()
+		s.Spells.Table.SyncToModel()
+		editName = i18n.Text("Apply Downtime")
+	}
+	addUndo(s.undoMgr, &unison.UndoEdit[*timeUseUndoData]{
+		ID:         unison.NextUndoID(),
+		EditName:   editName,
+		UndoFunc:   func(edit *unison.UndoEdit[*timeUseUndoData]) { edit.BeforeData.apply(s) },
+		RedoFunc:   func(edit *unison.UndoEdit[*timeUseUndoData]) { edit.AfterData.apply(s) },
+		BeforeData: before,
+		AfterData:  newTimeUseUndoData(s),
+	})
+	s.MarkModified(s)
+	s.Rebuild(true)
+}
+
+func (p *timeUsePlanner) rebuildRows() {
+	focus := p.addButton
+	p.rows.RemoveAllChildren()
+	for _, one := range []string{i18n.Text("Activity"), i18n.Text("Description"), i18n.Text("Hours"), "",
+		i18n.Text("Details"), ""} {
+		label := unison.NewLabel()
+		label.SetTitle(one)
+		label.Font = unison.SystemFont
+		p.rows.AddChild(label)
+	}
+	for i, entry := range p.plan {
+		p.addRow(i, entry)
+	}
+	p.syncSummary()
+	p.rows.MarkForLayoutRecursivelyUpward()
+	if wnd := p.rows.Window(); wnd != nil {
+		wnd.Pack()
+		focus.RequestFocus()
+	}
+}
+
+func (p *timeUsePlanner) addRow(index int, entry *gurps.TimeUseEntry) {
+	kindPopup := unison.NewPopupMenu[timeuse.Kind]()
+	kindPopup.AddItem(timeuse.Kinds...)
+	kindPopup.Select(entry.Kind)
+	kindPopup.SelectionChangedCallback = func(popup *unison.PopupMenu[timeuse.Kind]) {
+		if item, ok := popup.Selected(); ok && item != entry.Kind {
+			entry.Kind = item
+			p.rebuildRows()
+		}
+	}
+	p.rows.AddChild(kindPopup)
+
+	nameField := NewStringField(nil, "", i18n.Text("Description"), func() string { return entry.Name },
+		func(value string) { entry.Name = value })
+	nameField.Watermark = entry.Kind.String()
+	nameField.SetMinimumTextWidthUsing("Apprentice Blacksmith")
+	nameField.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	p.rows.AddChild(nameField)
+
+	p.rows.AddChild(NewDecimalField(nil, "", i18n.Text("Hours"), func() fxp.Int { return entry.Hours },
+		func(value fxp.Int) {
+			entry.Hours = value
+			p.syncSummary()
+		}, 0, gurps.HoursPerWeek, false, false))
+
+	periodPopup := unison.NewPopupMenu[timeuse.Period]()
+	periodPopup.AddItem(timeuse.Periods...)
+	periodPopup.Select(entry.Period)
+	periodPopup.SelectionChangedCallback = func(popup *unison.PopupMenu[timeuse.Period]) {
+		if item, ok := popup.Selected(); ok {
+			entry.Period = item
+			p.syncSummary()
+		}
+	}
+	p.rows.AddChild(periodPopup)
+
+	p.rows.AddChild(p.createDetails(entry))
+
+	deleteButton := unison.NewSVGButton(svg.Trash)
+	deleteButton.Tooltip = newWrappedTooltip(i18n.Text("Remove this activity"))
+	deleteButton.ClickCallback = func() {
+		p.plan = slices.Delete(p.plan, index, index+1)
+		p.rebuildRows()
+	}
+	p.rows.AddChild(deleteButton)
+}
+
+func (p *timeUsePlanner) createDetails(entry *gurps.TimeUseEntry) *unison.Panel {
+	details := unison.NewPanel()
+	details.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+	})
+	switch entry.Kind {
+	case timeuse.Job:
+		title := i18n.Text("Monthly Pay")
+		details.AddChild(NewFieldLeadingLabel(title, false))
+		details.AddChild(NewDecimalField(nil, "", title, func() fxp.Int { return entry.MonthlyPay },
+			func(value fxp.Int) {
+				entry.MonthlyPay = value
+				p.syncSummary()
+			}, 0, fxp.Max, false, false))
+	case timeuse.Study:
+		targetPopup := unison.NewPopupMenu[*gurps.StudyTarget]()
+		targetPopup.AddItem(&gurps.StudyTarget{Name: i18n.Text("Nothing in particular")})
+		targetPopup.AddItem(p.targets...)
+		targetPopup.SelectIndex(0)
+		for i, one := range p.targets {
+			if one.ID == entry.StudyTarget {
+				targetPopup.SelectIndex(i + 1)
+				break
+			}
+		}
+		targetPopup.Tooltip = newWrappedTooltip(i18n.Text("The trait, skill or spell the study time is recorded against"))
+		targetPopup.SelectionChangedCallback = func(popup *unison.PopupMenu[*gurps.StudyTarget]) {
+			if item, ok := popup.Selected(); ok {
+				entry.StudyTarget = item.ID
+				p.syncSummary()
+			}
+		}
+		details.AddChild(targetPopup)
+		typePopup := unison.NewPopupMenu[study.Type]()
+		typePopup.AddItem(study.Types...)
+		typePopup.Select(entry.StudyType)
+		typePopup.SelectionChangedCallback = func(popup *unison.PopupMenu[study.Type]) {
+			if item, ok := popup.Selected(); ok {
+				entry.StudyType = item
+			}
+		}
+		details.AddChild(typePopup)
+	default:
+	}
+	return details
+}
+
+func (p *timeUsePlanner) createSummary() *unison.Panel {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  4,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	p.addSummaryField(panel, i18n.Text("Allocated"), func() string {
+		return fmt.Sprintf(i18n.Text("%s hours/week"), p.plan.HoursPerWeek().Comma())
+	}, nil)
+	p.addSummaryField(panel, i18n.Text("Free"), func() string {
+		return fmt.Sprintf(i18n.Text("%s hours/week"), (gurps.HoursPerWeek - p.plan.HoursPerWeek()).Comma())
+	}, func() bool { return p.plan.HoursPerWeek() > gurps.HoursPerWeek })
+	p.addSummaryField(panel, i18n.Text("Job Income"), func() string {
+		return fmt.Sprintf(i18n.Text("$%s/month"), p.plan.MonthlyIncome().Comma())
+	}, nil)
+	p.addSummaryField(panel, i18n.Text("Cost of Living"), func() string {
+		return fmt.Sprintf(i18n.Text("$%s/month"), p.sheet.entity.CostOfLiving().Comma())
+	}, nil)
+	title := i18n.Text("Downtime Weeks")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	weeksField := NewIntegerField(nil, "", title, func() int { return p.weeks },
+		func(value int) {
+			p.weeks = value
+			p.syncSummary()
+		}, 0, 520, false, false)
+	weeksField.Tooltip = newWrappedTooltip(i18n.Text("The number of weeks of downtime spent following this plan when it is applied"))
+	panel.AddChild(weeksField)
+	panel.AddChild(unison.NewPanel())
+	panel.AddChild(unison.NewPanel())
+	p.addSummaryField(panel, i18n.Text("Net Funds"), func() string {
+		return "$" + p.sheet.entity.PlanDowntime(p.plan, p.weeks).Net().Comma()
+	}, func() bool { return p.sheet.entity.PlanDowntime(p.plan, p.weeks).Net() < 0 })
+	p.addSummaryField(panel, i18n.Text("Study"), func() string {
+		return fmt.Sprintf(i18n.Text("%s hours"), p.sheet.entity.PlanDowntime(p.plan, p.weeks).StudyHours.Comma())
+	}, nil)
+	return panel
+}
+
+func (p *timeUsePlanner) addSummaryField(panel *unison.Panel, title string, text func() string, warn func() bool) {
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	field := NewNonEditableField(func(field *NonEditableField) {
+		field.SetTitle(text())
+		if warn != nil && warn() {
+			field.OnBackgroundInk = unison.ThemeError
+		} else {
+			field.OnBackgroundInk = unison.DefaultLabelTheme.OnBackgroundInk
+		}
+		field.MarkForLayoutAndRedraw()
+	})
+	p.summary = append(p.summary, field)
+	panel.AddChild(field)
+}
+
+func (p *timeUsePlanner) syncSummary() {
+	for _, one := range p.summary {
+		one.Sync()
+	}
+}
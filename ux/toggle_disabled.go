@@ -72,7 +72,7 @@ func toggleDisabled(owner Rebuildable, table *unison.Table[*Node[*gurps.Trait]])
 	}
 	if len(before.List) > 0 {
 		if mgr := unison.UndoManagerFor(table); mgr != nil {
-			mgr.Add(&unison.UndoEdit[*toggleDisabledList]{
+			addUndo(mgr, &unison.UndoEdit[*toggleDisabledList]{
 				ID:         unison.NextUndoID(),
 				EditName:   i18n.Text("Toggle Enablement"),
 				UndoFunc:   func(edit toggleDisabledUndoEdit) { edit.BeforeData.Apply() },
@@ -71,7 +71,7 @@ func toggleEquipped(owner Rebuildable, table *unison.Table[*Node[*gurps.Equipmen
 	}
 	if len(before.List) > 0 {
 		if mgr := unison.UndoManagerFor(table); mgr != nil {
-			mgr.Add(&unison.UndoEdit[*toggleEquippedList]{
+			addUndo(mgr, &unison.UndoEdit[*toggleEquippedList]{
 				ID:         unison.NextUndoID(),
 				EditName:   i18n.Text("Toggle Equipped"),
 				UndoFunc:   func(edit toggleEquippedUndoEdit) { edit.BeforeData.Apply() },
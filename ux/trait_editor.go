@@ -10,6 +10,8 @@
 package ux
 
 import (
+	"path/filepath"
+
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/container"
@@ -18,6 +20,7 @@ import (
 	"github.com/richardwilkes/toolbox/i18n"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/check"
 )
 
 // EditTrait displays the editor for a trait.
@@ -73,6 +76,7 @@ func initTraitEditor(e *editor[*gurps.Trait, *gurps.TraitEditData], content *uni
 			&e.editorData.PointsPerLevel, -fxp.MaxBasePoints, fxp.MaxBasePoints)
 		adjustFieldBlank(perLevelField, !e.editorData.CanLevel)
 		adjustFieldBlank(levelField, !e.editorData.CanLevel)
+		addLinkedCharacterFields(content, &e.editorData.LinkedCharacter)
 	}
 	addLabelAndPopup(content, i18n.Text("Self-Control Roll"), "", selfctrl.Rolls, &e.editorData.CR)
 	crAdjPopup := addLabelAndPopup(content, i18n.Text("CR Adjustment"), i18n.Text("Self-Control Roll Adjustment"),
@@ -140,3 +144,55 @@ func initTraitEditor(e *editor[*gurps.Trait, *gurps.TraitEditData], content *uni
 		}
 	}
 }
+
+func addLinkedCharacterFields(parent *unison.Panel, linked **gurps.LinkedCharacter) {
+	ensureLinked := func() *gurps.LinkedCharacter {
+		if *linked == nil {
+			*linked = &gurps.LinkedCharacter{}
+		}
+		return *linked
+	}
+	title := i18n.Text("Linked Character")
+	wrapper := addFlowWrapper(parent, title, 3)
+	pathField := NewStringField(nil, "", title,
+		func() string {
+			if *linked == nil {
+				return ""
+			}
+			return (*linked).Path
+		},
+		func(value string) {
+			if value == "" && *linked == nil {
+				return
+			}
+			ensureLinked().Path = value
+		})
+	pathField.Tooltip = newWrappedTooltip(i18n.Text(`The character sheet of the Ally or Dependent this trait represents. When set, the base cost is recomputed from that character's point total whenever the sheet is refreshed.`))
+	pathField.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	wrapper.AddChild(pathField)
+	chooseButton := unison.NewButton()
+	chooseButton.SetTitle(i18n.Text("Choose…"))
+	chooseButton.ClickCallback = func() {
+		dialog := unison.NewOpenDialog()
+		dialog.SetAllowsMultipleSelection(false)
+		dialog.SetResolvesAliases(true)
+		dialog.SetAllowedExtensions(gurps.SheetExt[1:])
+		dialog.SetCanChooseDirectories(false)
+		dialog.SetCanChooseFiles(true)
+		global := gurps.GlobalSettings()
+		dialog.SetInitialDirectory(global.LastDir(gurps.DefaultLastDirKey))
+		if dialog.RunModal() {
+			p := dialog.Path()
+			global.SetLastDir(gurps.DefaultLastDirKey, filepath.Dir(p))
+			ensureLinked().Path = p
+			pathField.Sync()
+		}
+	}
+	wrapper.AddChild(chooseButton)
+	wrapper.AddChild(NewCheckBox(nil, "", i18n.Text("Dependent"),
+		func() check.Enum { return check.FromBool(*linked != nil && (*linked).Dependent) },
+		func(value check.Enum) { ensureLinked().Dependent = value == check.On }))
+}
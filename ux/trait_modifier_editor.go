@@ -12,6 +12,7 @@ package ux
 import (
 	"github.com/richardwilkes/gcs/v5/model/fxp"
 	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/activation"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/affects"
 	"github.com/richardwilkes/gcs/v5/model/gurps/enums/tmcost"
 	"github.com/richardwilkes/gcs/v5/svg"
@@ -85,6 +86,21 @@ func initTraitModifierEditor(e *editor[*gurps.TraitModifier, *gurps.TraitModifie
 				MarkModified(popup)
 			}
 		}
+		activeLabel := i18n.Text("Features Active")
+		wrapper = addFlowWrapper(content, activeLabel, 2)
+		activationPopup := addPopup(wrapper, activation.Conditions, &e.editorData.Activation)
+		tagField := addStringField(wrapper, i18n.Text("Equipment Tag"),
+			i18n.Text("The tag of the carried equipment whose equipped state controls whether the features are active"),
+			&e.editorData.ActivationTag)
+		tagField.Watermark = i18n.Text("Equipment Tag")
+		adjustFieldBlank(tagField, e.editorData.Activation == activation.Always)
+		activationPopup.SelectionChangedCallback = func(popup *unison.PopupMenu[activation.Condition]) {
+			if what, ok := popup.Selected(); ok {
+				e.editorData.Activation = what
+				adjustFieldBlank(tagField, what == activation.Always)
+				MarkModified(popup)
+			}
+		}
 	}
 	addTagsLabelAndField(content, &e.editorData.Tags)
 	addPageRefLabelAndField(content, &e.editorData.PageRef)
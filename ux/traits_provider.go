@@ -202,6 +202,13 @@ func (p *traitsProvider) ContextMenuItems() []ContextMenuItem {
 		ContextMenuItem{i18n.Text("New Trait"), NewTraitItemID},
 		ContextMenuItem{i18n.Text("New Trait Container"), NewTraitContainerItemID},
 		ContextMenuItem{i18n.Text("Add Natural Attacks"), AddNaturalAttacksItemID},
+		ContextMenuItem{i18n.Text("Add Reputation…"), AddReputationItemID},
 	)
+	if p.forPage {
+		list = append(list,
+			ContextMenuItem{"", -1},
+			ContextMenuItem{openLinkedCharacterAction.Title, OpenLinkedCharacterItemID},
+		)
+	}
 	return AppendDefaultContextMenuItems(list)
 }
@@ -0,0 +1,41 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/unison"
+)
+
+// applyUIScale scales the content of the window by the UI scale from the general settings. This is applied on top of
+// any scaling the individual documents do. Only the window's content is scaled; dialogs, menus and tooltips are drawn
+// outside of it and remain at their normal size.
+func applyUIScale(wnd *unison.Window) {
+	content := wnd.Content()
+	scale := float32(gurps.GlobalSettings().General.UIScale) / 100
+	if content.Scale() != scale {
+		content.SetScale(scale)
+		// The root panel has to be laid out again, too, otherwise ValidateLayout() has nothing to do
+		content.MarkForLayoutRecursivelyUpward()
+		wnd.ValidateLayout()
+		wnd.MarkForRedraw()
+	}
+}
+
+// applyUIScaleToAllWindows updates the workspace and any document windows to use the current UI scale.
+func applyUIScaleToAllWindows() {
+	for _, wnd := range unison.Windows() {
+		if wnd == Workspace.Window {
+			applyUIScale(wnd)
+		} else if _, ok := wnd.ClientData()[dockableClientDataKey]; ok {
+			applyUIScale(wnd)
+		}
+	}
+}
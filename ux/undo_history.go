@@ -0,0 +1,141 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"slices"
+
+	"github.com/richardwilkes/unison"
+)
+
+// undoHistories holds the history of each undo manager that currently has edits. unison doesn't expose the contents of
+// its undo stack, so edits are added via addUndo, which wraps them such that the history can be tracked.
+var undoHistories = make(map[*unison.UndoManager]*undoHistory)
+
+type undoHistory struct {
+	mgr       *unison.UndoManager
+	edits     []*trackedUndo
+	changed   func()
+	notifying bool
+}
+
+// trackedUndo wraps an edit to keep its undoHistory up to date as the undo manager applies, reverts and releases it.
+type trackedUndo struct {
+	unison.Undoable
+	history  *undoHistory
+	applied  bool
+	released bool
+}
+
+// addUndo adds the edit to the undo manager. All edits should be added this way, so that the undo history can be
+// shown.
+func addUndo(mgr *unison.UndoManager, edit unison.Undoable) {
+	h := undoHistoryFor(mgr)
+	t := &trackedUndo{
+		Undoable: edit,
+		history:  h,
+		applied:  true,
+	}
+	mgr.Add(t)
+	if !t.released {
+		h.edits = append(h.edits, t)
+		// Edits released while adding may have caused the history to be forgotten, so make sure it is registered.
+		undoHistories[mgr] = h
+	}
+	h.notify()
+}
+
+func undoHistoryFor(mgr *unison.UndoManager) *undoHistory {
+	h, ok := undoHistories[mgr]
+	if !ok {
+		h = &undoHistory{mgr: mgr}
+		undoHistories[mgr] = h
+	}
+	return h
+}
+
+// setUndoHistoryChangedCallback sets the function to call when the history of the undo manager changes. Pass nil to
+// remove it.
+func setUndoHistoryChangedCallback(mgr *unison.UndoManager, f func()) {
+	h := undoHistoryFor(mgr)
+	h.changed = f
+	h.forgetIfUnused()
+}
+
+// undoHistoryEdits returns the edits held by the undo manager and the index of the most recently applied one, which
+// will be -1 if none are applied.
+func undoHistoryEdits(mgr *unison.UndoManager) (edits []unison.Undoable, current int) {
+	current = -1
+	if h, ok := undoHistories[mgr]; ok {
+		edits = make([]unison.Undoable, len(h.edits))
+		for i, one := range h.edits {
+			edits[i] = one
+			if one.applied {
+				current = i
+			}
+		}
+	}
+	return edits, current
+}
+
+// notify calls the changed callback once the current event has been processed, so that a series of changes, such as
+// when jumping through the history, results in a single call.
+func (h *undoHistory) notify() {
+	if h.changed == nil || h.notifying {
+		return
+	}
+	h.notifying = true
+	unison.InvokeTask(func() {
+		h.notifying = false
+		if h.changed != nil {
+			h.changed()
+		}
+	})
+}
+
+func (h *undoHistory) forgetIfUnused() {
+	if len(h.edits) == 0 && h.changed == nil {
+		delete(undoHistories, h.mgr)
+	}
+}
+
+// Absorb implements unison.Undoable. The edit being absorbed will also have been wrapped, so it is unwrapped before
+// being passed along.
+func (t *trackedUndo) Absorb(other unison.Undoable) bool {
+	if o, ok := other.(*trackedUndo); ok {
+		other = o.Undoable
+	}
+	return t.Undoable.Absorb(other)
+}
+
+// Undo implements unison.Undoable
+func (t *trackedUndo) Undo() {
+	t.Undoable.Undo()
+	t.applied = false
+	t.history.notify()
+}
+
+// Redo implements unison.Undoable
+func (t *trackedUndo) Redo() {
+	t.Undoable.Redo()
+	t.applied = true
+	t.history.notify()
+}
+
+// Release implements unison.Undoable
+func (t *trackedUndo) Release() {
+	t.Undoable.Release()
+	t.released = true
+	if i := slices.Index(t.history.edits, t); i != -1 {
+		t.history.edits = slices.Delete(t.history.edits, i, i+1)
+	}
+	t.history.notify()
+	t.history.forgetIfUnused()
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps/enums/dgroup"
+	"github.com/richardwilkes/gcs/v5/svg"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+	"github.com/richardwilkes/unison/enums/behavior"
+)
+
+var (
+	_ unison.Dockable = &UndoHistoryDockable{}
+	_ GroupedCloser   = &UndoHistoryDockable{}
+)
+
+// UndoHistoryDockable shows the undo stack of another dockable and allows jumping directly to any state within it.
+type UndoHistoryDockable struct {
+	unison.Panel
+	owner     unison.Dockable
+	mgr       *unison.UndoManager
+	list      *unison.List[*undoHistoryItem]
+	edits     []unison.Undoable
+	current   int
+	syncing   bool
+	refreshed bool
+}
+
+type undoHistoryItem struct {
+	name string
+}
+
+func (u *undoHistoryItem) String() string {
+	return u.name
+}
+
+// CanShowUndoHistory returns true if the dockable has an undo stack that can be shown.
+func CanShowUndoHistory(d unison.Dockable) bool {
+	if _, ok := d.(*UndoHistoryDockable); ok {
+		return false
+	}
+	provider, ok := d.(unison.UndoManagerProvider)
+	return ok && provider.UndoManager() != nil
+}
+
+// ShowUndoHistory shows the undo history of the dockable.
+func ShowUndoHistory(owner unison.Dockable) {
+	provider, ok := owner.(unison.UndoManagerProvider)
+	if !ok || !CanShowUndoHistory(owner) {
+		return
+	}
+	if Activate(func(d unison.Dockable) bool {
+		if h, ok := d.(*UndoHistoryDockable); ok {
+			return h.owner == owner
+		}
+		return false
+	}) {
+		return
+	}
+	d := &UndoHistoryDockable{
+		owner: owner,
+		mgr:   provider.UndoManager(),
+	}
+	d.Self = d
+	d.SetLayout(&unison.FlexLayout{Columns: 1})
+	d.SetBorder(unison.NewEmptyBorder(unison.NewUniformInsets(unison.StdHSpacing * 2)))
+	d.list = unison.NewList[*undoHistoryItem]()
+	d.list.BackgroundInk = unison.ThemeSurface
+	d.list.NewSelectionCallback = d.jumpToSelection
+	scroller := unison.NewScrollPanel()
+	scroller.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	scroller.SetContent(d.list, behavior.Fill, behavior.Fill)
+	scroller.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(250, 200),
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	d.AddChild(scroller)
+	d.sync()
+	group := dgroup.Editors
+	p := owner.AsPanel()
+	for p != nil {
+		if _, exists := p.ClientData()[AssociatedIDKey]; exists {
+			group = dgroup.SubEditors
+			break
+		}
+		p = p.Parent()
+	}
+	PlaceInDock(d, group, false)
+	setUndoHistoryChangedCallback(d.mgr, d.historyChanged)
+}
+
+func (d *UndoHistoryDockable) historyChanged() {
+	if d.Window() == nil {
+		setUndoHistoryChangedCallback(d.mgr, nil)
+		return
+	}
+	d.sync()
+}
+
+func (d *UndoHistoryDockable) sync() {
+	edits, current := undoHistoryEdits(d.mgr)
+	if d.refreshed && current == d.current && slices.Equal(edits, d.edits) {
+		return
+	}
+	d.refreshed = true
+	d.edits = edits
+	d.current = current
+	d.syncing = true
+	d.list.Clear()
+	d.list.Append(&undoHistoryItem{name: i18n.Text("Original State")})
+	for _, edit := range edits {
+		d.list.Append(&undoHistoryItem{name: edit.Name()})
+	}
+	d.list.Select(false, current+1)
+	d.syncing = false
+	d.list.Pack()
+	d.list.MarkForLayoutRecursivelyUpward()
+	d.MarkForLayoutAndRedraw()
+}
+
+// jumpToSelection undoes or redoes edits until the state after the selected edit has been reached.
+func (d *UndoHistoryDockable) jumpToSelection() {
+	if d.syncing {
+		return
+	}
+	target := d.list.Selection.FirstSet() - 1
+	if target < -1 {
+		return
+	}
+	_, current := undoHistoryEdits(d.mgr)
+	for current > target && d.mgr.CanUndo() {
+		d.mgr.Undo()
+		current--
+	}
+	for current < target && d.mgr.CanRedo() {
+		d.mgr.Redo()
+		current++
+	}
+	d.sync()
+}
+
+// TitleIcon implements unison.Dockable
+func (d *UndoHistoryDockable) TitleIcon(suggestedSize unison.Size) unison.Drawable {
+	return &unison.DrawableSVG{
+		SVG:  svg.Stack,
+		Size: suggestedSize,
+	}
+}
+
+// Title implements unison.Dockable
+func (d *UndoHistoryDockable) Title() string {
+	return fmt.Sprintf(i18n.Text("Undo History for %s"), d.owner.Title())
+}
+
+func (d *UndoHistoryDockable) String() string {
+	return d.Title()
+}
+
+// Tooltip implements unison.Dockable
+func (d *UndoHistoryDockable) Tooltip() string {
+	return ""
+}
+
+// Modified implements unison.Dockable
+func (d *UndoHistoryDockable) Modified() bool {
+	return false
+}
+
+// CloseWithGroup implements GroupedCloser
+func (d *UndoHistoryDockable) CloseWithGroup(other unison.Paneler) bool {
+	return d.owner != nil && d.owner == other
+}
+
+// MayAttemptClose implements GroupedCloser
+func (d *UndoHistoryDockable) MayAttemptClose() bool {
+	return MayAttemptCloseOfGroup(d)
+}
+
+// AttemptClose implements GroupedCloser
+func (d *UndoHistoryDockable) AttemptClose() bool {
+	if !CloseGroup(d) {
+		return false
+	}
+	if !AttemptCloseForDockable(d) {
+		return false
+	}
+	setUndoHistoryChangedCallback(d.mgr, nil)
+	return true
+}
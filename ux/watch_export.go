@@ -0,0 +1,130 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/atexit"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+)
+
+// Sheet export formats that require the page renderer.
+const (
+	PDFExportFormat  = "pdf"
+	PNGExportFormat  = "png"
+	WEBPExportFormat = "webp"
+	JPEGExportFormat = "jpeg"
+)
+
+// SheetExportFormats holds the formats that character sheets can be rendered to.
+var SheetExportFormats = []string{PDFExportFormat, PNGExportFormat, WEBPExportFormat, JPEGExportFormat}
+
+// WatchAndExport starts the UI toolkit without opening any windows, then monitors the given paths for changes to
+// character sheets, rendering each changed sheet into the given format alongside the original file. Each export is
+// reported through gurps.CLI. Never returns.
+func WatchAndExport(paths []string, format string) {
+	unison.Start(
+		unison.StartupFinishedCallback(func() {
+			go func() {
+				atexit.Exit(gurps.CLI.Finish(gurps.WatchSheets(paths, func(sheetPath string) {
+					done := make(chan struct{})
+					unison.InvokeTask(func() {
+						defer close(done)
+						if err := ExportSheetFile(sheetPath, format); err != nil {
+							gurps.CLI.Failed(sheetPath, err)
+						} else {
+							gurps.CLI.File(sheetPath, gurps.CLIStatusExported, fmt.Sprintf(i18n.Text("Exported %s"), sheetPath))
+						}
+					})
+					<-done
+				})))
+			}()
+		}),
+		unison.QuitAfterLastWindowClosedCallback(func() bool { return false }),
+	) // Never returns
+}
+
+// ExportSheets starts the UI toolkit without opening any windows, renders each character sheet found in the given paths
+// into the given format and writes the results into outputDir, or alongside the original files if outputDir is empty.
+// Exits once all sheets have been exported, using the exit code provided by gurps.CLI. Never returns.
+func ExportSheets(paths []string, format, outputDir string) {
+	unison.Start(
+		unison.StartupFinishedCallback(func() {
+			go func() {
+				atexit.Exit(gurps.CLI.Finish(exportSheets(paths, format, outputDir)))
+			}()
+		}),
+		unison.QuitAfterLastWindowClosedCallback(func() bool { return false }),
+	) // Never returns
+}
+
+func exportSheets(paths []string, format, outputDir string) error {
+	list, err := gurps.SheetPaths(paths...)
+	if err != nil {
+		return err
+	}
+	if len(list) == 0 {
+		return errs.New(i18n.Text("No character sheets to export"))
+	}
+	if outputDir != "" {
+		if err = os.MkdirAll(outputDir, 0o750); err != nil {
+			return errs.Wrap(err)
+		}
+	}
+	for _, p := range list {
+		done := make(chan struct{})
+		unison.InvokeTask(func() {
+			defer close(done)
+			err = ExportSheetFileTo(p, format, outputDir)
+		})
+		<-done
+		if err != nil {
+			gurps.CLI.Failed(p, err)
+			return errs.NewWithCause(p, err)
+		}
+		gurps.CLI.File(p, gurps.CLIStatusExported, fmt.Sprintf(i18n.Text("Exported %s"), p))
+	}
+	return nil
+}
+
+// ExportSheetFile loads the character sheet at sheetPath and renders it into the given format, writing the result
+// alongside the original file. Must be called on the UI thread.
+func ExportSheetFile(sheetPath, format string) error {
+	return ExportSheetFileTo(sheetPath, format, "")
+}
+
+// ExportSheetFileTo loads the character sheet at sheetPath and renders it into the given format, writing the result
+// into outputDir, or alongside the original file if outputDir is empty. Must be called on the UI thread.
+func ExportSheetFileTo(sheetPath, format, outputDir string) error {
+	entity, err := gurps.NewEntityFromFile(os.DirFS(filepath.Dir(sheetPath)), filepath.Base(sheetPath))
+	if err != nil {
+		return err
+	}
+	base := gurps.ExportBasePath(sheetPath, outputDir)
+	exporter := newPageExporter(entity)
+	switch format {
+	case PDFExportFormat:
+		return exporter.exportAsPDFFile(base + ".pdf")
+	case PNGExportFormat:
+		return exporter.exportAsPNGs(base)
+	case WEBPExportFormat:
+		return exporter.exportAsWEBPs(base)
+	case JPEGExportFormat:
+		return exporter.exportAsJPEGs(base)
+	default:
+		return errs.Newf("unknown export format: %s", format)
+	}
+}
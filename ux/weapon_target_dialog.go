@@ -0,0 +1,225 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+
+	"github.com/richardwilkes/gcs/v5/model/fxp"
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/align"
+)
+
+// Distances offered for quick selection, which are the range steps of the Size and Speed/Range Table (B550).
+var standardTargetDistances = []int{2, 3, 5, 7, 10, 15, 20, 30, 50, 70, 100, 150, 200, 300, 500, 700, 1000}
+
+type weaponTargetDistance struct {
+	title    string
+	distance fxp.Int
+}
+
+func (d weaponTargetDistance) String() string {
+	return d.title
+}
+
+type weaponTargetList struct {
+	Owner Rebuildable
+	List  []*weaponTargetAdjuster
+}
+
+func (a *weaponTargetList) Apply() {
+	for _, one := range a.List {
+		one.Apply()
+	}
+	MarkModified(a.Owner)
+}
+
+type weaponTargetAdjuster struct {
+	Weapon *gurps.Weapon
+	Target *gurps.WeaponTarget
+}
+
+func newWeaponTargetAdjuster(w *gurps.Weapon) *weaponTargetAdjuster {
+	a := &weaponTargetAdjuster{Weapon: w}
+	if w.Target != nil {
+		target := *w.Target
+		a.Target = &target
+	}
+	return a
+}
+
+func (a *weaponTargetAdjuster) Apply() {
+	a.Weapon.Target = nil
+	if a.Target != nil {
+		target := *a.Target
+		a.Weapon.Target = &target
+	}
+}
+
+func canTargetWeapon(table *unison.Table[*Node[*gurps.Weapon]]) bool {
+	return len(selectedRangedWeapons(table)) != 0
+}
+
+func selectedRangedWeapons(table *unison.Table[*Node[*gurps.Weapon]]) []*gurps.Weapon {
+	var list []*gurps.Weapon
+	for _, row := range table.SelectedRows(false) {
+		if w := row.Data(); w != nil && w.IsRanged() && w.Entity() != nil {
+			list = append(list, w)
+		}
+	}
+	return list
+}
+
+// targetWeapon displays the Size and Speed/Range helper for the selected ranged weapons, setting the target they are
+// aimed at.
+func targetWeapon(owner Rebuildable, table *unison.Table[*Node[*gurps.Weapon]]) {
+	weapons := selectedRangedWeapons(table)
+	if len(weapons) == 0 {
+		return
+	}
+	first := weapons[0]
+	target := gurps.WeaponTarget{Distance: fxp.Ten}
+	if first.Target != nil {
+		target = *first.Target
+	}
+	baseLevel := first.SkillLevel(nil) - first.Target.SkillAdjustment(nil)
+
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  3,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+
+	result := unison.NewLabel()
+	result.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  3,
+		HAlign: align.Middle,
+	})
+	update := func() {
+		modifier := target.Modifier()
+		result.SetTitle(fmt.Sprintf(i18n.Text("Net modifier %+d (range %+d, size %+d); %s skill %s becomes %s"),
+			modifier, target.RangeModifier(), target.SizeModifier, first.String(), baseLevel.String(),
+			(baseLevel + fxp.From(modifier)).Max(0).String()))
+		result.MarkForLayoutRecursivelyUpward()
+		if wnd := result.Window(); wnd != nil {
+			wnd.Pack()
+		}
+	}
+
+	title := i18n.Text("Distance")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	distanceFld := NewDecimalField(nil, "", title, func() fxp.Int { return target.Distance },
+		func(v fxp.Int) {
+			target.Distance = v
+			update()
+		}, 0, fxp.Max, false, false)
+	distanceFld.Tooltip = newWrappedTooltip(i18n.Text("The range to the target, in yards"))
+	panel.AddChild(distanceFld)
+	distances := make([]weaponTargetDistance, 0, len(standardTargetDistances)+2)
+	weaponRange := first.Range.Resolve(first, nil)
+	if weaponRange.HalfDamage > 0 {
+		distances = append(distances, weaponTargetDistance{
+			title:    fmt.Sprintf(i18n.Text("½D range (%s yd)"), weaponRange.HalfDamage.Comma()),
+			distance: weaponRange.HalfDamage,
+		})
+	}
+	if weaponRange.Max > 0 {
+		distances = append(distances, weaponTargetDistance{
+			title:    fmt.Sprintf(i18n.Text("Max range (%s yd)"), weaponRange.Max.Comma()),
+			distance: weaponRange.Max,
+		})
+	}
+	for _, one := range standardTargetDistances {
+		distances = append(distances, weaponTargetDistance{
+			title:    fmt.Sprintf(i18n.Text("%d yd"), one),
+			distance: fxp.From(one),
+		})
+	}
+	distancePopup := unison.NewPopupMenu[weaponTargetDistance]()
+	distancePopup.Tooltip = newWrappedTooltip(i18n.Text("Pick a distance"))
+	distancePopup.AddItem(distances...)
+	distancePopup.SelectionChangedCallback = func(p *unison.PopupMenu[weaponTargetDistance]) {
+		if one, ok := p.Selected(); ok {
+			target.Distance = one.distance
+			distanceFld.Sync()
+			update()
+		}
+	}
+	panel.AddChild(distancePopup)
+
+	title = i18n.Text("Speed")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	speedFld := NewDecimalField(nil, "", title, func() fxp.Int { return target.Speed },
+		func(v fxp.Int) {
+			target.Speed = v
+			update()
+		}, 0, fxp.Max, false, false)
+	speedFld.Tooltip = newWrappedTooltip(i18n.Text("The target's speed relative to you, in yards per second, which is added to the distance"))
+	speedFld.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	panel.AddChild(speedFld)
+
+	title = i18n.Text("Target SM")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	smFld := NewIntegerField(nil, "", title, func() int { return target.SizeModifier },
+		func(v int) {
+			target.SizeModifier = v
+			update()
+		}, -99, 99, true, false)
+	smFld.Tooltip = newWrappedTooltip(i18n.Text("The target's Size Modifier"))
+	smFld.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	panel.AddChild(smFld)
+	panel.AddChild(result)
+	update()
+
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		{
+			Title:        i18n.Text("Clear"),
+			ResponseCode: unison.ModalResponseUserBase,
+		},
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Set")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	dialog.Window().SetTitle(targetWeaponAction.Title)
+	var newTarget *gurps.WeaponTarget
+	switch dialog.RunModal() {
+	case unison.ModalResponseUserBase:
+	case unison.ModalResponseOK:
+		newTarget = &target
+	default:
+		return
+	}
+	before := &weaponTargetList{Owner: owner}
+	after := &weaponTargetList{Owner: owner}
+	for _, w := range weapons {
+		before.List = append(before.List, newWeaponTargetAdjuster(w))
+		adjuster := &weaponTargetAdjuster{Weapon: w, Target: newTarget}
+		adjuster.Apply()
+		after.List = append(after.List, newWeaponTargetAdjuster(w))
+	}
+	if mgr := unison.UndoManagerFor(table); mgr != nil {
+		addUndo(mgr, &unison.UndoEdit[*weaponTargetList]{
+			ID:         unison.NextUndoID(),
+			EditName:   targetWeaponAction.Title,
+			UndoFunc:   func(edit *unison.UndoEdit[*weaponTargetList]) { edit.BeforeData.Apply() },
+			RedoFunc:   func(edit *unison.UndoEdit[*weaponTargetList]) { edit.AfterData.Apply() },
+			BeforeData: before,
+			AfterData:  after,
+		})
+	}
+	MarkModified(owner)
+}
@@ -196,7 +196,13 @@ func (p *weaponsProvider) ContextMenuItems() []ContextMenuItem {
 	if p.melee {
 		list = append(list, ContextMenuItem{i18n.Text("New Melee Weapon"), NewMeleeWeaponItemID})
 	} else {
-		list = append(list, ContextMenuItem{i18n.Text("New Ranged Weapon"), NewRangedWeaponItemID})
+		list = append(list,
+			ContextMenuItem{i18n.Text("New Ranged Weapon"), NewRangedWeaponItemID},
+			ContextMenuItem{"", -1},
+			ContextMenuItem{fireWeaponAction.Title, FireWeaponItemID},
+			ContextMenuItem{reloadWeaponAction.Title, ReloadWeaponItemID},
+			ContextMenuItem{targetWeaponAction.Title, TargetWeaponItemID},
+		)
 	}
 	return AppendDefaultContextMenuItems(list)
 }
@@ -21,9 +21,11 @@ import (
 	"github.com/richardwilkes/gcs/v5/model/gurps"
 	"github.com/richardwilkes/gcs/v5/server/state"
 	"github.com/richardwilkes/gcs/v5/server/websettings"
+	"github.com/richardwilkes/gcs/v5/server/websettings/tlsmode"
 	"github.com/richardwilkes/gcs/v5/svg"
 	"github.com/richardwilkes/toolbox/errs"
 	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/toolbox/tid"
 	xfs "github.com/richardwilkes/toolbox/xio/fs"
 	"github.com/richardwilkes/unison"
 	"github.com/richardwilkes/unison/enums/align"
@@ -45,14 +47,20 @@ type webSettingsDockable struct {
 	errorMsg                 *unison.Label
 	enabledCheckbox          *CheckBox
 	addressField             *StringField
+	tlsModePopup             *unison.PopupMenu[tlsmode.Mode]
 	certFileField            *StringField
 	certFileButton           *unison.Button
 	keyFileField             *StringField
 	keyFileButton            *unison.Button
+	acmeDomainsField         *StringField
+	acmeEmailField           *StringField
 	shutdownGracePeriodField *DecimalField
 	readTimeoutField         *DecimalField
 	writeTimeoutField        *DecimalField
 	idleTimeoutField         *DecimalField
+	sessionExpiryField       *DecimalField
+	rateLimitField           *DecimalField
+	shareLibrariesCheckbox   *CheckBox
 	userList                 *unison.List[*websettings.User]
 	userAddButton            *unison.Button
 	userNameField            *StringField
@@ -65,6 +73,9 @@ type webSettingsDockable struct {
 	accessDirField           *StringField
 	accessDialog             *unison.Dialog
 	userDialog               *unison.Dialog
+	apiTokenList             *unison.List[*websettings.APIToken]
+	apiTokenAddButton        *unison.Button
+	embedList                *unison.List[*websettings.Embed]
 	waitingForSync           atomic.Bool
 }
 
@@ -104,13 +115,21 @@ func (d *webSettingsDockable) initContent(content *unison.Panel) {
 	d.createBanner(content)
 	d.createEnabledCheckbox(content)
 	d.createAddressField(content)
+	d.createTLSModePopup(content)
 	d.createCertFileField(content)
 	d.createKeyFileField(content)
+	d.createACMEDomainsField(content)
+	d.createACMEEmailField(content)
 	d.createShutdownGracePeriodField(content)
 	d.createReadTimeoutField(content)
 	d.createWriteTimeoutField(content)
 	d.createIdleTimeoutField(content)
+	d.createSessionExpiryField(content)
+	d.createRateLimitField(content)
+	d.createShareLibrariesCheckbox(content)
 	d.createUsersBlock(content)
+	d.createAPITokensBlock(content)
+	d.createEmbedsBlock(content)
 	d.syncEnablementToServer(nil)
 }
 
@@ -169,14 +188,20 @@ func (d *webSettingsDockable) finishSync(callback func()) {
 func (d *webSettingsDockable) setWebServerControlEnablement(enabled bool) {
 	d.enabledCheckbox.SetEnabled(enabled)
 	d.addressField.SetEnabled(enabled)
-	d.certFileField.SetEnabled(enabled)
-	d.certFileButton.SetEnabled(enabled)
-	d.keyFileField.SetEnabled(enabled)
-	d.keyFileButton.SetEnabled(enabled)
+	d.tlsModePopup.SetEnabled(enabled)
+	mode := gurps.GlobalSettings().WebServer.TLSMode
+	d.certFileField.SetEnabled(enabled && mode == tlsmode.Files)
+	d.certFileButton.SetEnabled(enabled && mode == tlsmode.Files)
+	d.keyFileField.SetEnabled(enabled && mode == tlsmode.Files)
+	d.keyFileButton.SetEnabled(enabled && mode == tlsmode.Files)
+	d.acmeDomainsField.SetEnabled(enabled && mode == tlsmode.Automatic)
+	d.acmeEmailField.SetEnabled(enabled && mode == tlsmode.Automatic)
 	d.shutdownGracePeriodField.SetEnabled(enabled)
 	d.readTimeoutField.SetEnabled(enabled)
 	d.writeTimeoutField.SetEnabled(enabled)
 	d.idleTimeoutField.SetEnabled(enabled)
+	d.sessionExpiryField.SetEnabled(enabled)
+	d.rateLimitField.SetEnabled(enabled)
 	d.userList.SetEnabled(enabled)
 	d.userAddButton.SetEnabled(enabled)
 }
@@ -234,7 +259,7 @@ func (d *webSettingsDockable) createEnabledCheckbox(content *unison.Panel) {
 
 func (d *webSettingsDockable) applyServerEnabled(on bool) {
 	settings := gurps.GlobalSettings().WebServer
-	if on && !settings.Valid() || (settings.CertFile == "") != (settings.KeyFile == "") {
+	if on && !settings.Valid() {
 		on = false
 		d.updateErrorMsg(errs.New("Invalid web settings"))
 	}
@@ -275,6 +300,22 @@ func isAddressValid(address string) bool {
 	return err == nil
 }
 
+func (d *webSettingsDockable) createTLSModePopup(content *unison.Panel) {
+	content.AddChild(NewFieldLeadingLabel(i18n.Text("TLS"), false))
+	d.tlsModePopup = unison.NewPopupMenu[tlsmode.Mode]()
+	for _, mode := range tlsmode.Modes {
+		d.tlsModePopup.AddItem(mode)
+	}
+	d.tlsModePopup.Select(gurps.GlobalSettings().WebServer.TLSMode)
+	d.tlsModePopup.SelectionChangedCallback = func(popup *unison.PopupMenu[tlsmode.Mode]) {
+		if mode, ok := popup.Selected(); ok {
+			gurps.GlobalSettings().WebServer.TLSMode = mode
+			d.setWebServerControlEnablement(true)
+		}
+	}
+	content.AddChild(d.tlsModePopup)
+}
+
 func (d *webSettingsDockable) createCertFileField(content *unison.Panel) {
 	d.certFileField, d.certFileButton = createFilePathField(content, i18n.Text("Certificate File"),
 		func() string { return gurps.GlobalSettings().WebServer.CertFile },
@@ -287,6 +328,31 @@ func (d *webSettingsDockable) createKeyFileField(content *unison.Panel) {
 		func(s string) { gurps.GlobalSettings().WebServer.KeyFile = s }, false)
 }
 
+func (d *webSettingsDockable) createACMEDomainsField(content *unison.Panel) {
+	title := i18n.Text("Domains")
+	content.AddChild(NewFieldLeadingLabel(title, false))
+	d.acmeDomainsField = NewStringField(nil, "", title,
+		func() string { return gurps.GlobalSettings().WebServer.ACMEDomains },
+		func(s string) { gurps.GlobalSettings().WebServer.ACMEDomains = s })
+	content.AddChild(d.acmeDomainsField)
+	content.AddChild(unison.NewPanel())
+	note := unison.NewLabel()
+	desc := note.Font.Descriptor()
+	desc.Size -= 2
+	note.Font = desc.Font()
+	note.SetTitle(i18n.Text(`Separate multiple domains with commas. Each must resolve to this computer and the server must be reachable from the internet on port 443.`))
+	content.AddChild(note)
+}
+
+func (d *webSettingsDockable) createACMEEmailField(content *unison.Panel) {
+	title := i18n.Text("Contact Email")
+	content.AddChild(NewFieldLeadingLabel(title, false))
+	d.acmeEmailField = NewStringField(nil, "", title,
+		func() string { return gurps.GlobalSettings().WebServer.ACMEEmail },
+		func(s string) { gurps.GlobalSettings().WebServer.ACMEEmail = s })
+	content.AddChild(d.acmeEmailField)
+}
+
 func createFilePathField(content *unison.Panel, title string, get func() string, set func(string), forDirs bool) (*StringField, *unison.Button) {
 	content.AddChild(NewFieldLeadingLabel(title, false))
 	fileField := NewStringField(nil, "", title, get, set)
@@ -367,10 +433,37 @@ func (d *webSettingsDockable) createIdleTimeoutField(content *unison.Panel) {
 		websettings.MinimumTimeout, websettings.MaximumTimeout)
 }
 
+func (d *webSettingsDockable) createSessionExpiryField(content *unison.Panel) {
+	d.sessionExpiryField = createFieldWithUnits(content, i18n.Text("Session Expiry"), i18n.Text("days"),
+		func() fxp.Int { return gurps.GlobalSettings().WebServer.SessionExpiry },
+		func(v fxp.Int) { gurps.GlobalSettings().WebServer.SessionExpiry = v },
+		websettings.MinimumSessionExpiry, websettings.MaximumSessionExpiry)
+}
+
+func (d *webSettingsDockable) createRateLimitField(content *unison.Panel) {
+	d.rateLimitField = createFieldWithUnits(content, i18n.Text("Rate Limit"),
+		i18n.Text("requests per minute for each client"),
+		func() fxp.Int { return gurps.GlobalSettings().WebServer.RateLimit },
+		func(v fxp.Int) { gurps.GlobalSettings().WebServer.RateLimit = v },
+		websettings.MinimumRateLimit, websettings.MaximumRateLimit)
+}
+
+func (d *webSettingsDockable) createShareLibrariesCheckbox(content *unison.Panel) {
+	content.AddChild(unison.NewPanel())
+	d.shareLibrariesCheckbox = NewCheckBox(nil, "", i18n.Text("Allow users to browse the libraries (read-only)"),
+		func() check.Enum { return check.FromBool(gurps.GlobalSettings().WebServer.ShareLibraries) },
+		func(state check.Enum) { gurps.GlobalSettings().WebServer.ShareLibraries = state == check.On })
+	content.AddChild(d.shareLibrariesCheckbox)
+}
+
 func createSecondsField(content *unison.Panel, title string, get func() fxp.Int, set func(fxp.Int), miniumum, maximum fxp.Int) *DecimalField {
+	return createFieldWithUnits(content, title, i18n.Text("seconds"), get, set, miniumum, maximum)
+}
+
+func createFieldWithUnits(content *unison.Panel, title, units string, get func() fxp.Int, set func(fxp.Int), miniumum, maximum fxp.Int) *DecimalField {
 	content.AddChild(NewFieldLeadingLabel(title, false))
 	field := NewDecimalField(nil, "", title, get, set, miniumum, maximum, false, false)
-	content.AddChild(WrapWithSpan(1, field, NewFieldTrailingLabel(i18n.Text("seconds"), false)))
+	content.AddChild(WrapWithSpan(1, field, NewFieldTrailingLabel(units, false)))
 	return field
 }
 
@@ -441,6 +534,8 @@ func (d *webSettingsDockable) deleteUser() {
 		settings.RemoveUser(d.userList.DataAtIndex(i).Name)
 		d.userList.Remove(i)
 	}
+	d.refreshAPITokenList()
+	d.refreshEmbedList()
 }
 
 func (d *webSettingsDockable) editUser() {
@@ -481,6 +576,8 @@ func (d *webSettingsDockable) editUser() {
 			}
 		}
 		settings.SetAccessList(u.Name, u.AccessList)
+		d.refreshAPITokenList()
+		d.refreshEmbedList()
 		all := settings.Users()
 		i := slices.IndexFunc(all, func(one *websettings.User) bool { return one.Key() == u.Key() })
 		d.userList.Select(false, i)
@@ -844,6 +941,213 @@ func (d *webSettingsDockable) adjustAccessDialogOKButton(accessKeyValid, accessD
 	d.accessDialog.Button(unison.ModalResponseOK).SetEnabled(accessKeyValid && accessDirValid)
 }
 
+func (d *webSettingsDockable) createAPITokensBlock(content *unison.Panel) {
+	header := unison.NewPanel()
+	header.SetLayoutData(&unison.FlexLayoutData{
+		HSpan:  2,
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	header.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing / 2,
+	})
+	content.AddChild(header)
+	d.apiTokenAddButton = unison.NewSVGButton(svg.CircledAdd)
+	d.apiTokenAddButton.Tooltip = newWrappedTooltip(i18n.Text("Add API Token"))
+	d.apiTokenAddButton.ClickCallback = d.addAPIToken
+	header.AddChild(d.apiTokenAddButton)
+	title := unison.NewLabel()
+	title.SetTitle(i18n.Text("API Tokens"))
+	title.Tooltip = newWrappedTooltip(i18n.Text(`API tokens allow other programs to access the server on behalf of a user by passing "Authorization: Bearer <token>" with their requests. Tokens remain valid until they are revoked. Revoking a token takes effect immediately, even while the server is running.`))
+	header.AddChild(title)
+	d.apiTokenList = unison.NewList[*websettings.APIToken]()
+	d.apiTokenList.BackgroundInk = unison.ThemeSurface
+	d.apiTokenList.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	d.apiTokenList.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(300, 64),
+		HSpan:   2,
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	d.apiTokenList.Append(gurps.GlobalSettings().WebServer.APITokens()...)
+	d.apiTokenList.KeyDownCallback = d.handleAPITokenListKey
+	content.AddChild(d.apiTokenList)
+}
+
+func (d *webSettingsDockable) refreshAPITokenList() {
+	d.apiTokenList.Clear()
+	d.apiTokenList.Append(gurps.GlobalSettings().WebServer.APITokens()...)
+	d.apiTokenList.Pack()
+	d.apiTokenList.MarkForLayoutRecursivelyUpward()
+	d.apiTokenList.MarkForRedraw()
+}
+
+func (d *webSettingsDockable) handleAPITokenListKey(keyCode unison.KeyCode, mod unison.Modifiers, repeat bool) bool {
+	switch keyCode {
+	case unison.KeyDelete, unison.KeyBackspace:
+		d.revokeAPITokens()
+		return true
+	}
+	return d.apiTokenList.DefaultKeyDown(keyCode, mod, repeat)
+}
+
+func (d *webSettingsDockable) revokeAPITokens() {
+	if d.apiTokenList.Selection.FirstSet() == -1 {
+		return
+	}
+	if unison.QuestionDialog(i18n.Text("Revoke the selected API tokens?"),
+		i18n.Text("Clients using them will no longer be able to access the server.")) != unison.ModalResponseOK {
+		return
+	}
+	settings := gurps.GlobalSettings().WebServer
+	for i := d.apiTokenList.Selection.FirstSet(); i != -1; i = d.apiTokenList.Selection.NextSet(i + 1) {
+		settings.RevokeAPIToken(d.apiTokenList.DataAtIndex(i).ID)
+	}
+	d.refreshAPITokenList()
+	d.ValidateLayout()
+}
+
+func (d *webSettingsDockable) createEmbedsBlock(content *unison.Panel) {
+	title := unison.NewLabel()
+	title.SetTitle(i18n.Text("Embedded Summaries"))
+	title.Tooltip = newWrappedTooltip(i18n.Text(`Embedded summaries publish a read-only summary card of a sheet that other web pages can display. They are created with the "Share as Embeddable Summary…" command on a sheet and remain available to anyone with the link until they are revoked.`))
+	title.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	content.AddChild(title)
+	d.embedList = unison.NewList[*websettings.Embed]()
+	d.embedList.BackgroundInk = unison.ThemeSurface
+	d.embedList.SetBorder(unison.NewLineBorder(unison.ThemeSurfaceEdge, 0, unison.NewUniformInsets(1), false))
+	d.embedList.SetLayoutData(&unison.FlexLayoutData{
+		MinSize: unison.NewSize(300, 64),
+		HSpan:   2,
+		HAlign:  align.Fill,
+		VAlign:  align.Fill,
+		HGrab:   true,
+		VGrab:   true,
+	})
+	d.embedList.Append(gurps.GlobalSettings().WebServer.Embeds()...)
+	d.embedList.KeyDownCallback = d.handleEmbedListKey
+	content.AddChild(d.embedList)
+}
+
+func (d *webSettingsDockable) refreshEmbedList() {
+	d.embedList.Clear()
+	d.embedList.Append(gurps.GlobalSettings().WebServer.Embeds()...)
+	d.embedList.Pack()
+	d.embedList.MarkForLayoutRecursivelyUpward()
+	d.embedList.MarkForRedraw()
+}
+
+func (d *webSettingsDockable) handleEmbedListKey(keyCode unison.KeyCode, mod unison.Modifiers, repeat bool) bool {
+	switch keyCode {
+	case unison.KeyDelete, unison.KeyBackspace:
+		d.revokeEmbeds()
+		return true
+	}
+	return d.embedList.DefaultKeyDown(keyCode, mod, repeat)
+}
+
+func (d *webSettingsDockable) revokeEmbeds() {
+	if d.embedList.Selection.FirstSet() == -1 {
+		return
+	}
+	if unison.QuestionDialog(i18n.Text("Revoke the selected embedded summaries?"),
+		i18n.Text("Pages displaying them will no longer be able to.")) != unison.ModalResponseOK {
+		return
+	}
+	settings := gurps.GlobalSettings().WebServer
+	for i := d.embedList.Selection.FirstSet(); i != -1; i = d.embedList.Selection.NextSet(i + 1) {
+		settings.RemoveEmbed(d.embedList.DataAtIndex(i).ID)
+	}
+	d.refreshEmbedList()
+	d.ValidateLayout()
+}
+
+func (d *webSettingsDockable) addAPIToken() {
+	settings := gurps.GlobalSettings().WebServer
+	users := settings.Users()
+	if len(users) == 0 {
+		unison.ErrorDialogWithMessage(i18n.Text("Unable to create an API token"),
+			i18n.Text("A user must be created first."))
+		return
+	}
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.SetLayoutData(&unison.FlexLayoutData{MinSize: unison.NewSize(300, 0)})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("User"), false))
+	userPopup := unison.NewPopupMenu[*websettings.User]()
+	userPopup.AddItem(users...)
+	userPopup.Select(users[0])
+	panel.AddChild(userPopup)
+	title := i18n.Text("Client")
+	panel.AddChild(NewFieldLeadingLabel(title, false))
+	var client string
+	clientField := NewStringField(nil, "", title, func() string { return client }, func(s string) { client = s })
+	clientField.Watermark = i18n.Text("The program that will use the token")
+	var dialog *unison.Dialog
+	clientField.ValidateCallback = func() bool {
+		valid := strings.TrimSpace(clientField.Text()) != ""
+		if dialog != nil {
+			dialog.Button(unison.ModalResponseOK).SetEnabled(valid)
+		}
+		return valid
+	}
+	panel.AddChild(clientField)
+	var err error
+	dialog, err = unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{
+		unison.NewCancelButtonInfo(),
+		unison.NewOKButtonInfoWithTitle(i18n.Text("Create")),
+	})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	clientField.Validate()
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	u, _ := userPopup.Selected()
+	id, ok := settings.CreateAPIToken(u.Name, strings.TrimSpace(client))
+	if !ok {
+		unison.ErrorDialogWithMessage(i18n.Text("Unable to create an API token"),
+			i18n.Text("A user with that name cannot be found."))
+		return
+	}
+	d.refreshAPITokenList()
+	d.ValidateLayout()
+	d.showAPIToken(id)
+}
+
+func (d *webSettingsDockable) showAPIToken(id tid.TID) {
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	label := unison.NewLabel()
+	label.SetTitle(i18n.Text("The client should pass this token as a bearer token in its requests:"))
+	label.SetLayoutData(&unison.FlexLayoutData{HSpan: 2})
+	panel.AddChild(label)
+	panel.AddChild(NewNonEditableField(func(field *NonEditableField) { field.SetTitle(string(id)) }))
+	copyButton := unison.NewSVGButton(svg.Copy)
+	copyButton.Tooltip = newWrappedTooltip(i18n.Text("Copy to clipboard"))
+	copyButton.ClickCallback = func() { unison.GlobalClipboard.SetText(string(id)) }
+	panel.AddChild(copyButton)
+	dialog, err := unison.NewDialog(nil, nil, panel, []*unison.DialogButtonInfo{unison.NewOKButtonInfo()})
+	if err != nil {
+		errs.Log(err)
+		return
+	}
+	dialog.RunModal()
+}
+
 func (d *webSettingsDockable) reset() {
 	if state.Current() != state.Stopped {
 		if unison.YesNoDialog(i18n.Text("Stop the server?"), i18n.Text("The web server must be stopped before the settings can be reset.")) == unison.ModalResponseOK {
@@ -862,14 +1166,22 @@ func (d *webSettingsDockable) sync(other *websettings.Settings) {
 	settings.Enabled = false
 	SetCheckBoxState(d.enabledCheckbox, settings.Enabled)
 	SetFieldValue(d.addressField.Field, settings.Address)
+	d.tlsModePopup.Select(settings.TLSMode)
 	SetFieldValue(d.certFileField.Field, settings.CertFile)
 	SetFieldValue(d.keyFileField.Field, settings.KeyFile)
+	SetFieldValue(d.acmeDomainsField.Field, settings.ACMEDomains)
+	SetFieldValue(d.acmeEmailField.Field, settings.ACMEEmail)
 	SetFieldValue(d.shutdownGracePeriodField.Field, settings.ShutdownGracePeriod.String())
 	SetFieldValue(d.readTimeoutField.Field, settings.ReadTimeout.String())
 	SetFieldValue(d.writeTimeoutField.Field, settings.WriteTimeout.String())
 	SetFieldValue(d.idleTimeoutField.Field, settings.IdleTimeout.String())
+	SetFieldValue(d.sessionExpiryField.Field, settings.SessionExpiry.String())
+	SetFieldValue(d.rateLimitField.Field, settings.RateLimit.String())
+	SetCheckBoxState(d.shareLibrariesCheckbox, settings.ShareLibraries)
 	d.userList.Clear()
 	d.userList.Append(settings.Users()...)
+	d.refreshAPITokenList()
+	d.refreshEmbedList()
 	d.updateErrorMsg(nil)
 	d.MarkForRedraw()
 	d.applyServerEnabled(on)
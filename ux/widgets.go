@@ -494,6 +494,11 @@ func addUsageCriteriaPanel(parent *unison.Panel, strCriteria *criteria.Text, hSp
 	addStringCriteriaPanel(parent, prefix, prefix, i18n.Text("Usage Qualifier"), strCriteria, hSpan, includeEmptyFiller)
 }
 
+func addDamageTypeCriteriaPanel(parent *unison.Panel, strCriteria *criteria.Text, hSpan int, includeEmptyFiller bool) {
+	prefix := i18n.Text("and whose damage type")
+	addStringCriteriaPanel(parent, prefix, prefix, i18n.Text("Damage Type Qualifier"), strCriteria, hSpan, includeEmptyFiller)
+}
+
 func addTagCriteriaPanel(parent *unison.Panel, strCriteria *criteria.Text, hSpan int, includeEmptyFiller bool) {
 	addStringCriteriaPanel(parent, i18n.Text("and at least one tag"), i18n.Text("and all tags"), i18n.Text("Tag Qualifier"), strCriteria, hSpan, includeEmptyFiller)
 }
@@ -655,6 +660,24 @@ func addLeveledAmountPanel(parent *unison.Panel, targetMgr *TargetMgr, targetKey
 			MarkModified(parent)
 		}, fxp.Min, fxp.Max, true, false))
 	addCheckBox(parent, title, &amount.PerLevel)
+	formula := i18n.Text("Level Formula")
+	formulaKey := ""
+	if targetKey != "" {
+		formulaKey = targetKey + ".formula"
+	}
+	formulaField := NewStringField(targetMgr, formulaKey, formula,
+		func() string { return amount.LevelFormula },
+		func(value string) {
+			amount.LevelFormula = strings.TrimSpace(value)
+			MarkModified(parent)
+		})
+	formulaField.Watermark = formula
+	formulaField.Tooltip = newWrappedTooltip(i18n.Text(`When per level is set, an optional formula used in place of the amount times the level. Use $level to refer to the level, e.g. "2*$level+1".`))
+	formulaField.SetLayoutData(&unison.FlexLayoutData{
+		HAlign: align.Fill,
+		HGrab:  true,
+	})
+	parent.AddChild(formulaField)
 }
 
 func addTemplateChoices(parent *unison.Panel, targetmgr *TargetMgr, targetKey string, tp **gurps.TemplatePicker) {
@@ -675,12 +698,14 @@ func addTemplateChoices(parent *unison.Panel, targetmgr *TargetMgr, targetKey st
 				field.(Syncer).Sync()
 			}
 			last = item
-			adjustFieldBlank(field, item == picker.NotApplicable || (*tp).Qualifier.Compare == criteria.AnyNumber)
-			adjustPopupBlank(popup, item == picker.NotApplicable)
+			noQualifier := item == picker.NotApplicable || item == picker.Optional
+			adjustFieldBlank(field, noQualifier || (*tp).Qualifier.Compare == criteria.AnyNumber)
+			adjustPopupBlank(popup, noQualifier)
 			MarkModified(parent)
 		}
 	}
-	adjustFieldBlank(field, (*tp).Type == picker.NotApplicable)
+	adjustFieldBlank(field, (*tp).Type == picker.NotApplicable || (*tp).Type == picker.Optional)
+	adjustPopupBlank(popup, (*tp).Type == picker.NotApplicable || (*tp).Type == picker.Optional)
 }
 
 // WrapWithSpan wraps a number of children with a single panel that request to fill in span number of columns.
@@ -62,6 +62,8 @@ func InitWorkspace(wnd *unison.Window) {
 	dc.SetCurrentDockable(Workspace.Navigator)
 	wnd.AllowCloseCallback = isWorkspaceAllowedToClose
 	wnd.WillCloseCallback = workspaceWillClose
+	installTabDetaching(wnd)
+	applyUIScale(wnd)
 	global := gurps.GlobalSettings()
 	if global.WorkspaceFrame != nil {
 		r := *global.WorkspaceFrame
@@ -126,8 +128,10 @@ func ActiveDockable() unison.Dockable {
 }
 
 func isWorkspaceAllowedToClose() bool {
+	captureSession()
 	for _, d := range AllDockables() {
 		if !mayDockableClose(d) {
+			abandonSessionCapture()
 			return false
 		}
 	}
@@ -142,6 +146,7 @@ func workspaceWillClose() {
 	if err := global.Save(); err != nil {
 		unison.ErrorDialogWithError(i18n.Text("Unable to save global settings"), err)
 	}
+	clearRecovery()
 }
 
 func mayDockableClose(d unison.Dockable) bool {
@@ -319,9 +324,31 @@ func MoveDockableToWindow(dockable unison.Dockable) (*unison.Window, error) {
 	return NewWindowForDockable(dockable, group)
 }
 
-// InstallDockUndockCmd installs the dock or undock command handler.
+// CanSplitDockable returns true if the dockable is in the document workspace and shares its tabs with other dockables,
+// so that it can be moved into its own area beside them.
+func CanSplitDockable(dockable unison.Dockable) bool {
+	dc := unison.Ancestor[*unison.DockContainer](dockable)
+	return dc != nil && len(dc.Dockables()) > 1 && unison.Ancestor[*DocumentDock](dc) == Workspace.DocumentDock
+}
+
+// SplitDockable moves the dockable out of its tabs and into a new area on the given side of them, allowing two
+// documents to be seen and dragged between at the same time.
+func SplitDockable(dockable unison.Dockable, s side.Enum) {
+	if !CanSplitDockable(dockable) {
+		return
+	}
+	dc := unison.Ancestor[*unison.DockContainer](dockable)
+	Workspace.DocumentDock.DockTo(dockable, dc, s)
+	dockable.AsPanel().RequestFocus()
+}
+
+// InstallDockUndockCmd installs the dock or undock and split command handlers.
 func InstallDockUndockCmd(dockable unison.Dockable) {
 	panel := dockable.AsPanel()
+	panel.InstallCmdHandlers(SplitRightItemID, func(_ any) bool { return CanSplitDockable(dockable) },
+		func(_ any) { SplitDockable(dockable, side.Right) })
+	panel.InstallCmdHandlers(SplitDownItemID, func(_ any) bool { return CanSplitDockable(dockable) },
+		func(_ any) { SplitDockable(dockable, side.Bottom) })
 	panel.InstallCmdHandlers(DockUnDockItemID,
 		func(_ any) bool {
 			if panel.Window() == Workspace.Window {
@@ -368,6 +395,7 @@ func NewWindowForDockable(dockable unison.Dockable, group dgroup.Group) (*unison
 	})
 	content.AddChild(panel)
 	wnd.ClientData()[dockableClientDataKey] = dockable
+	applyUIScale(wnd)
 	if tc, ok := dockable.(unison.TabCloser); ok {
 		pendingClose := false
 		wnd.AllowCloseCallback = func() bool {
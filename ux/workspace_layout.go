@@ -0,0 +1,244 @@
+// Copyright (c) 1998-2024 by Richard A. Wilkes. All rights reserved.
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, version 2.0. If a copy of the MPL was not distributed with
+// this file, You can obtain one at http://mozilla.org/MPL/2.0/.
+//
+// This Source Code Form is "Incompatible With Secondary Licenses", as
+// defined by the Mozilla Public License, version 2.0.
+
+package ux
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/richardwilkes/gcs/v5/model/gurps"
+	"github.com/richardwilkes/toolbox/errs"
+	"github.com/richardwilkes/toolbox/i18n"
+	"github.com/richardwilkes/unison"
+	"github.com/richardwilkes/unison/enums/side"
+)
+
+type workspaceLayoutDivider struct {
+	layout     *unison.DockLayout
+	horizontal bool
+	divider    float32
+}
+
+// captureWorkspaceLayout records the arrangement of the documents that are currently open.
+func captureWorkspaceLayout(name string) *gurps.WorkspaceLayout {
+	var areas []*gurps.WorkspaceArea
+	Workspace.DocumentDock.RootDockLayout().ForEachDockContainer(func(dc *unison.DockContainer) bool {
+		area := &gurps.WorkspaceArea{Frame: dc.FrameRect()}
+		current := dc.CurrentDockable()
+		for _, d := range dc.Dockables() {
+			if fbd, ok := d.(FileBackedDockable); ok && fbd.BackingFilePath() != "" {
+				if d == current {
+					area.Current = len(area.Documents)
+				}
+				area.Documents = append(area.Documents, fbd.BackingFilePath())
+			}
+		}
+		areas = append(areas, area)
+		return false
+	})
+	layout := &gurps.WorkspaceLayout{
+		Name: name,
+		Root: gurps.NewWorkspaceLayoutNode(areas),
+	}
+	for _, d := range AllDockables() {
+		if IsDockableInWorkspace(d) {
+			continue
+		}
+		if fbd, ok := d.(FileBackedDockable); ok && fbd.BackingFilePath() != "" {
+			if wnd := d.AsPanel().Window(); wnd != nil {
+				frame := wnd.FrameRect()
+				layout.Windows = append(layout.Windows, &gurps.SessionDocument{
+					Path:     fbd.BackingFilePath(),
+					Frame:    &frame,
+					InWindow: true,
+				})
+			}
+		}
+	}
+	return layout
+}
+
+// applyWorkspaceLayout arranges the documents to match the layout, opening those that aren't open yet. Saved documents
+// that aren't part of the layout are closed first; nothing is rearranged if any of them refuse to close.
+func applyWorkspaceLayout(layout *gurps.WorkspaceLayout) {
+	root := layout.Root.Restorable()
+	windows := (&gurps.Session{Documents: layout.Windows}).Restorable()
+	keep := make(map[string]bool)
+	for _, one := range root.Paths() {
+		keep[one] = true
+	}
+	for _, doc := range windows {
+		keep[doc.Path] = true
+	}
+	for _, d := range AllDockables() {
+		if fbd, ok := d.(FileBackedDockable); ok && fbd.BackingFilePath() != "" && !keep[fbd.BackingFilePath()] {
+			if !fbd.MayAttemptClose() || !fbd.AttemptClose() {
+				return
+			}
+		}
+	}
+	placeWorkspaceLayout(root)
+	for _, doc := range windows {
+		d := openWorkspaceLayoutDocument(doc.Path, false)
+		if d == nil {
+			continue
+		}
+		wnd, err := MoveDockableToWindow(d)
+		if err != nil {
+			errs.Log(err, "path", doc.Path)
+			continue
+		}
+		if doc.Frame != nil {
+			wnd.SetFrameRect(unison.BestDisplayForRect(*doc.Frame).FitRectOnto(*doc.Frame))
+		}
+	}
+}
+
+// placeWorkspaceLayout arranges the documents within the workspace to match the layout tree, opening those that aren't
+// open yet. The tree should only reference documents that exist.
+func placeWorkspaceLayout(root *gurps.WorkspaceLayoutNode) {
+	if root == nil {
+		return
+	}
+	if d := openWorkspaceLayoutDocument(root.Paths()[0], true); d != nil {
+		Workspace.DocumentDock.DockTo(d, nil, side.Left)
+		if dc := unison.Ancestor[*unison.DockContainer](d); dc != nil {
+			var dividers []*workspaceLayoutDivider
+			placeWorkspaceLayoutNode(root, dc, &dividers)
+			Workspace.DocumentDock.ValidateLayout()
+			for _, one := range dividers {
+				frame := one.layout.FrameRect()
+				extent := frame.Height
+				if one.horizontal {
+					extent = frame.Width
+				}
+				one.layout.SetDividerPosition(max(one.divider*(extent-Workspace.DocumentDock.DockDividerSize()), 0))
+			}
+		}
+	}
+}
+
+// placeWorkspaceLayoutNode fills the dock container with the contents of the node. The container must already hold the
+// first document of the node and nothing else.
+func placeWorkspaceLayoutNode(node *gurps.WorkspaceLayoutNode, dc *unison.DockContainer, dividers *[]*workspaceLayoutDivider) {
+	if node.IsSplit() {
+		if d := openWorkspaceLayoutDocument(node.Second.Paths()[0], true); d != nil {
+			s := side.Bottom
+			if node.Horizontal {
+				s = side.Right
+			}
+			Workspace.DocumentDock.DockTo(d, dc, s)
+			if other := unison.Ancestor[*unison.DockContainer](d); other != nil {
+				if layout := Workspace.DocumentDock.RootDockLayout().FindLayout(other); layout != nil {
+					*dividers = append(*dividers, &workspaceLayoutDivider{
+						layout:     layout,
+						horizontal: node.Horizontal,
+						divider:    node.Divider,
+					})
+				}
+				placeWorkspaceLayoutNode(node.First, dc, dividers)
+				placeWorkspaceLayoutNode(node.Second, other, dividers)
+				return
+			}
+		}
+		// The second half couldn't be opened, so put everything into this one
+		node = &gurps.WorkspaceLayoutNode{Documents: node.Paths()}
+	}
+	var current unison.Dockable
+	for i, one := range node.Documents {
+		if d := openWorkspaceLayoutDocument(one, true); d != nil {
+			dc.Stack(d, -1)
+			if i == node.Current {
+				current = d
+			}
+		}
+	}
+	if current != nil {
+		dc.SetCurrentDockable(current)
+	}
+}
+
+// openWorkspaceLayoutDocument opens the document, or locates it if already open. If inWorkspace is true and the
+// document is in its own window, it is moved into the workspace.
+func openWorkspaceLayoutDocument(filePath string, inWorkspace bool) unison.Dockable {
+	d, _ := OpenFile(filePath, 0)
+	if d != nil && inWorkspace {
+		MoveDockableToWorkspace(d)
+	}
+	return d
+}
+
+func saveWorkspaceLayout() {
+	name := fmt.Sprintf(i18n.Text("Layout %d"), len(gurps.GlobalSettings().WorkspaceLayouts)+1)
+	field := NewStringField(nil, "", "", func() string { return name }, func(v string) { name = v })
+	field.SetMinimumTextWidthUsing(minTextWidthCandidate)
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Layout Name"), false))
+	panel.AddChild(field)
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon,
+		unison.DefaultDialogTheme.QuestionIconInk, panel,
+		[]*unison.DialogButtonInfo{unison.NewCancelButtonInfo(), unison.NewOKButtonInfoWithTitle(i18n.Text("Save"))})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create workspace layout dialog"), err)
+		return
+	}
+	field.ValidateCallback = func() bool {
+		valid := strings.TrimSpace(name) != ""
+		dialog.Button(unison.ModalResponseOK).SetEnabled(valid)
+		return valid
+	}
+	if dialog.RunModal() != unison.ModalResponseOK {
+		return
+	}
+	name = strings.TrimSpace(name)
+	settings := gurps.GlobalSettings()
+	if settings.WorkspaceLayout(name) != nil && unison.QuestionDialog(fmt.Sprintf(i18n.Text("Replace the layout %q?"),
+		name), i18n.Text("A workspace layout with that name already exists.")) != unison.ModalResponseOK {
+		return
+	}
+	settings.SetWorkspaceLayout(captureWorkspaceLayout(name))
+}
+
+func deleteWorkspaceLayout() {
+	settings := gurps.GlobalSettings()
+	if len(settings.WorkspaceLayouts) == 0 {
+		return
+	}
+	popup := unison.NewPopupMenu[string]()
+	for _, one := range settings.WorkspaceLayouts {
+		popup.AddItem(one.Name)
+	}
+	popup.SelectIndex(0)
+	panel := unison.NewPanel()
+	panel.SetLayout(&unison.FlexLayout{
+		Columns:  2,
+		HSpacing: unison.StdHSpacing,
+		VSpacing: unison.StdVSpacing,
+	})
+	panel.AddChild(NewFieldLeadingLabel(i18n.Text("Layout"), false))
+	panel.AddChild(popup)
+	dialog, err := unison.NewDialog(unison.DefaultDialogTheme.QuestionIcon,
+		unison.DefaultDialogTheme.QuestionIconInk, panel,
+		[]*unison.DialogButtonInfo{unison.NewCancelButtonInfo(), unison.NewOKButtonInfoWithTitle(i18n.Text("Delete"))})
+	if err != nil {
+		unison.ErrorDialogWithError(i18n.Text("Unable to create workspace layout dialog"), err)
+		return
+	}
+	if dialog.RunModal() == unison.ModalResponseOK {
+		if name, ok := popup.Selected(); ok {
+			settings.RemoveWorkspaceLayout(name)
+		}
+	}
+}